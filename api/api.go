@@ -44,11 +44,21 @@ type BatteryCapacity interface {
 	Capacity() float64
 }
 
+// MpptPowers provides per-tracker DC power of pv inverters in W
+type MpptPowers interface {
+	MpptPowers() ([]float64, error)
+}
+
 // MaxACPower provides max AC power in W
 type MaxACPower interface {
 	MaxACPower() float64
 }
 
+// PowerSetter is implemented by continuously modulated consumers like heating rods
+type PowerSetter interface {
+	SetPower(power float64) error
+}
+
 // ChargeState provides current charging status
 type ChargeState interface {
 	Status() (ChargeStatus, error)
@@ -125,6 +135,11 @@ type PhaseDescriber interface {
 	Phases() int
 }
 
+// ChargeScheduler receives the planned charge schedule, e.g. for negotiating with the vehicle using ISO 15118
+type ChargeScheduler interface {
+	SetChargeSchedule(ChargeSchedule) error
+}
+
 // Vehicle represents the EV and it's battery
 type Vehicle interface {
 	Battery
@@ -212,6 +227,11 @@ type CsvWriter interface {
 	WriteCsv(context.Context, io.Writer) error
 }
 
+// PdfWriter converts to pdf
+type PdfWriter interface {
+	WritePdf(context.Context, io.Writer) error
+}
+
 // CircuitMeasurements is the measurements a circuit or load must deliver
 type CircuitMeasurements interface {
 	GetChargePower() float64
@@ -237,6 +257,10 @@ type Circuit interface {
 	GetMaxCurrent() float64
 	SetMaxPower(float64)
 	SetMaxCurrent(float64)
+	GetMaxPowerLimit() *float64
+	GetMaxCurrentLimit() *float64
+	SetMaxPowerLimit(*float64) error
+	SetMaxCurrentLimit(*float64) error
 	Update([]CircuitLoad) error
 	ValidateCurrent(old, new float64) float64
 	ValidatePower(old, new float64) float64
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"reflect"
+)
+
+// Capable is implemented by devices composing optional interfaces at runtime instead of using generated decorators.
+// Use As to access interfaces of devices that may be Capable.
+type Capable interface {
+	Capability(reflect.Type) (any, bool)
+}
+
+// Capabilities holds the optional interfaces of a device. Embed it to make the device Capable.
+type Capabilities struct {
+	caps map[reflect.Type]any
+}
+
+// Capability implements the Capable interface
+func (c *Capabilities) Capability(typ reflect.Type) (any, bool) {
+	impl, ok := c.caps[typ]
+	return impl, ok
+}
+
+// Add registers impl as implementation of interface T. Nil implementations are ignored.
+func Add[T any](c *Capabilities, impl T) {
+	if v := reflect.ValueOf(impl); !v.IsValid() {
+		return
+	} else if k := v.Kind(); (k == reflect.Func || k == reflect.Pointer || k == reflect.Interface) && v.IsNil() {
+		return
+	}
+
+	if c.caps == nil {
+		c.caps = make(map[reflect.Type]any)
+	}
+
+	c.caps[reflect.TypeFor[T]()] = impl
+}
+
+// As returns the device's implementation of interface T, either implemented by the device itself or composed at runtime
+func As[T any](dev any) (T, bool) {
+	if res, ok := dev.(T); ok {
+		return res, true
+	}
+
+	if c, ok := dev.(Capable); ok {
+		if impl, ok := c.Capability(reflect.TypeFor[T]()); ok {
+			return impl.(T), true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// Has returns true if the device implements interface T
+func Has[T any](dev any) bool {
+	_, ok := As[T](dev)
+	return ok
+}
+
+// MeterEnergyFunc adapts a function to the MeterEnergy interface
+type MeterEnergyFunc func() (float64, error)
+
+func (f MeterEnergyFunc) TotalEnergy() (float64, error) {
+	return f()
+}
+
+// BatteryFunc adapts a function to the Battery interface
+type BatteryFunc func() (float64, error)
+
+func (f BatteryFunc) Soc() (float64, error) {
+	return f()
+}
+
+// BatteryCapacityFunc adapts a function to the BatteryCapacity interface
+type BatteryCapacityFunc func() float64
+
+func (f BatteryCapacityFunc) Capacity() float64 {
+	return f()
+}
+
+// MpptPowersFunc adapts a function to the MpptPowers interface
+type MpptPowersFunc func() ([]float64, error)
+
+func (f MpptPowersFunc) MpptPowers() ([]float64, error) {
+	return f()
+}
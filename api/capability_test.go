@@ -0,0 +1,41 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type capableMeter struct {
+	Capabilities
+}
+
+func (m *capableMeter) CurrentPower() (float64, error) {
+	return 1, nil
+}
+
+func TestCapabilities(t *testing.T) {
+	m := new(capableMeter)
+
+	var soc func() (float64, error)
+	Add[Battery](&m.Capabilities, BatteryFunc(soc))
+	Add[MeterEnergy](&m.Capabilities, MeterEnergyFunc(func() (float64, error) {
+		return 2, nil
+	}))
+
+	// implemented directly
+	_, ok := As[Meter](m)
+	assert.True(t, ok)
+
+	// composed
+	me, ok := As[MeterEnergy](m)
+	require.True(t, ok)
+	f, err := me.TotalEnergy()
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, f)
+
+	// nil implementation is ignored
+	assert.False(t, Has[Battery](m))
+	assert.False(t, Has[BatteryCapacity](m))
+}
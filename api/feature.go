@@ -11,4 +11,5 @@ const (
 	Heating
 	Retryable
 	WelcomeCharge
+	Pending
 )
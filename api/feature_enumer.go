@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _FeatureName = "OfflineCoarseCurrentIntegratedDeviceHeatingRetryableWelcomeCharge"
+const _FeatureName = "OfflineCoarseCurrentIntegratedDeviceHeatingRetryableWelcomeChargePending"
 
-var _FeatureIndex = [...]uint8{0, 7, 20, 36, 43, 52, 65}
+var _FeatureIndex = [...]uint8{0, 7, 20, 36, 43, 52, 65, 72}
 
-const _FeatureLowerName = "offlinecoarsecurrentintegrateddeviceheatingretryablewelcomecharge"
+const _FeatureLowerName = "offlinecoarsecurrentintegrateddeviceheatingretryablewelcomechargepending"
 
 func (i Feature) String() string {
 	i -= 1
@@ -31,9 +31,10 @@ func _FeatureNoOp() {
 	_ = x[Heating-(4)]
 	_ = x[Retryable-(5)]
 	_ = x[WelcomeCharge-(6)]
+	_ = x[Pending-(7)]
 }
 
-var _FeatureValues = []Feature{Offline, CoarseCurrent, IntegratedDevice, Heating, Retryable, WelcomeCharge}
+var _FeatureValues = []Feature{Offline, CoarseCurrent, IntegratedDevice, Heating, Retryable, WelcomeCharge, Pending}
 
 var _FeatureNameToValueMap = map[string]Feature{
 	_FeatureName[0:7]:        Offline,
@@ -48,6 +49,8 @@ var _FeatureNameToValueMap = map[string]Feature{
 	_FeatureLowerName[43:52]: Retryable,
 	_FeatureName[52:65]:      WelcomeCharge,
 	_FeatureLowerName[52:65]: WelcomeCharge,
+	_FeatureName[65:72]:      Pending,
+	_FeatureLowerName[65:72]: Pending,
 }
 
 var _FeatureNames = []string{
@@ -57,6 +60,7 @@ var _FeatureNames = []string{
 	_FeatureName[36:43],
 	_FeatureName[43:52],
 	_FeatureName[52:65],
+	_FeatureName[65:72],
 }
 
 // FeatureString retrieves an enum value from the enum constants string name.
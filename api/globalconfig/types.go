@@ -10,13 +10,20 @@ import (
 	"github.com/evcc-io/evcc/provider/mqtt"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/server/eebus"
+	"github.com/evcc-io/evcc/server/federation"
+	"github.com/evcc-io/evcc/server/ocpi"
+	"github.com/evcc-io/evcc/server/relay"
+	"github.com/evcc-io/evcc/util/auth"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/modbus"
+	"github.com/evcc-io/evcc/util/tracing"
 )
 
 type All struct {
 	Network      Network
+	OIDC         auth.OIDCConfig
 	Log          string
+	LogFormat    string // text or json
 	SponsorToken string
 	Plant        string // telemetry plant id
 	Telemetry    bool
@@ -27,10 +34,14 @@ type All struct {
 	Database     DB
 	Mqtt         Mqtt
 	ModbusProxy  []ModbusProxy
+	ModbusServer ModbusServer
 	Javascript   []Javascript
 	Go           []Go
 	Influx       Influx
+	Grpc         Grpc
+	HomeKit      HomeKit
 	EEBus        eebus.Config
+	Ocpi         ocpi.Config
 	HEMS         Hems
 	Messaging    Messaging
 	Meters       []config.Named
@@ -40,6 +51,9 @@ type All struct {
 	Site         map[string]interface{}
 	Loadpoints   []map[string]interface{}
 	Circuits     []config.Named
+	Remotes      []federation.Config
+	Relay        relay.Config
+	Tracing      tracing.Config
 }
 
 type Javascript struct {
@@ -52,6 +66,24 @@ type Go struct {
 	Script string
 }
 
+// Grpc is the gRPC api configuration
+type Grpc struct {
+	Port int // listen port, zero disables the api
+}
+
+// HomeKit is the HomeKit bridge configuration
+type HomeKit struct {
+	Port int    // listen port, zero disables the bridge
+	Name string // bridge name
+	Pin  string // setup code, generated if empty
+}
+
+// ModbusServer is the SunSpec-like modbus slave configuration
+type ModbusServer struct {
+	Port     int  // listen port, zero disables the server
+	ReadOnly bool // reject control register writes
+}
+
 type ModbusProxy struct {
 	Port            int
 	ReadOnly        string
@@ -74,36 +106,64 @@ var _ api.Redactor = (*Mqtt)(nil)
 
 type Mqtt struct {
 	mqtt.Config `mapstructure:",squash"`
-	Topic       string `json:"topic"`
+	Topic       string      `json:"topic"`
+	Discovery   string      `json:"discovery"` // Home Assistant discovery prefix, empty to disable
+	Publish     MqttPublish `json:"publish"`
+}
+
+// MqttPublish is the topic layout and delivery policy of published values
+type MqttPublish struct {
+	Site      string       `json:"site"`      // site topic template, defaults to {{.root}}/site/{{.key}}
+	Loadpoint string       `json:"loadpoint"` // loadpoint topic template, defaults to {{.root}}/loadpoints/{{.loadpoint}}/{{.key}}
+	Json      bool         `json:"json"`      // publish a single json state document per loadpoint
+	Topics    []MqttPolicy `json:"topics"`    // qos and retain flag by topic filter, first match wins
+}
+
+// MqttPolicy overrides qos and retain flag for topics matching the filter
+type MqttPolicy struct {
+	Filter string `json:"filter"` // mqtt topic filter supporting + and # wildcards
+	Qos    *byte  `json:"qos,omitempty"`
+	Retain *bool  `json:"retain,omitempty"`
 }
 
 // Redacted implements the redactor interface used by the tee publisher
 func (m Mqtt) Redacted() any {
 	// TODO add masked password
 	return struct {
-		Broker   string `json:"broker"`
-		Topic    string `json:"topic"`
-		User     string `json:"user,omitempty"`
-		ClientID string `json:"clientID,omitempty"`
-		Insecure bool   `json:"insecure,omitempty"`
+		Broker    string `json:"broker"`
+		Topic     string `json:"topic"`
+		Discovery string `json:"discovery,omitempty"`
+		User      string `json:"user,omitempty"`
+		ClientID  string `json:"clientID,omitempty"`
+		Insecure  bool   `json:"insecure,omitempty"`
 	}{
-		Broker:   m.Broker,
-		Topic:    m.Topic,
-		User:     m.User,
-		ClientID: m.ClientID,
-		Insecure: m.Insecure,
+		Broker:    m.Broker,
+		Topic:     m.Topic,
+		Discovery: m.Discovery,
+		User:      m.User,
+		ClientID:  m.ClientID,
+		Insecure:  m.Insecure,
 	}
 }
 
 // Influx is the influx db configuration
 type Influx struct {
-	URL      string `json:"url"`
-	Database string `json:"database"`
-	Token    string `json:"token"`
-	Org      string `json:"org"`
-	User     string `json:"user"`
-	Password string `json:"password"`
-	Insecure bool   `json:"insecure"`
+	URL         string            `json:"url"`
+	Database    string            `json:"database"`
+	Token       string            `json:"token"`
+	Org         string            `json:"org"`
+	User        string            `json:"user"`
+	Password    string            `json:"password"`
+	Insecure    bool              `json:"insecure"`
+	Measurement string            `json:"measurement"` // measurement name template, defaults to {{.key}}
+	Tags        map[string]string `json:"tags"`        // tag name mapping, empty name drops the tag
+	Buckets     []InfluxBucket    `json:"buckets"`     // additional buckets
+}
+
+// InfluxBucket is an additional bucket with optional downsampling
+type InfluxBucket struct {
+	Database string        `json:"database"`
+	Interval time.Duration `json:"interval"` // downsampling interval, zero writes raw values
 }
 
 // Redacted implements the redactor interface used by the tee publisher
@@ -130,8 +190,10 @@ type DB struct {
 }
 
 type Messaging struct {
+	Language string // language of default event templates, system language if empty
 	Events   map[string]push.EventTemplateConfig
 	Services []config.Typed
+	Webhooks []push.WebhookConfig
 }
 
 type Tariffs struct {
@@ -140,12 +202,25 @@ type Tariffs struct {
 	FeedIn   config.Typed
 	Co2      config.Typed
 	Planner  config.Typed
+	Solar    config.Typed
 }
 
 type Network struct {
 	Schema string `json:"schema"`
 	Host   string `json:"host"`
 	Port   int    `json:"port"`
+	TLS    TLS    `json:"tls"`
+}
+
+type TLS struct {
+	Mode      string         `json:"mode,omitempty"`      // acme, selfsigned or file, empty for plain http
+	Domains   []string       `json:"domains,omitempty"`   // acme certificate domains, network host if empty
+	Email     string         `json:"email,omitempty"`     // acme account contact
+	Challenge string         `json:"challenge,omitempty"` // acme challenge http, tls-alpn or dns, default http unless listening on port 443
+	DNS       map[string]any `json:"dns,omitempty"`       // acme dns challenge plugin, receives "<record> <value>"
+	Dir       string         `json:"dir,omitempty"`       // acme and self-signed certificate directory
+	Cert      string         `json:"cert,omitempty"`      // certificate file
+	Key       string         `json:"key,omitempty"`       // private key file
 }
 
 func (c Network) HostPort() string {
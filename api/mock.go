@@ -820,6 +820,20 @@ func (mr *MockCircuitMockRecorder) GetMaxCurrent() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxCurrent", reflect.TypeOf((*MockCircuit)(nil).GetMaxCurrent))
 }
 
+// GetMaxCurrentLimit mocks base method.
+func (m *MockCircuit) GetMaxCurrentLimit() *float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxCurrentLimit")
+	ret0, _ := ret[0].(*float64)
+	return ret0
+}
+
+// GetMaxCurrentLimit indicates an expected call of GetMaxCurrentLimit.
+func (mr *MockCircuitMockRecorder) GetMaxCurrentLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxCurrentLimit", reflect.TypeOf((*MockCircuit)(nil).GetMaxCurrentLimit))
+}
+
 // GetMaxPhaseCurrent mocks base method.
 func (m *MockCircuit) GetMaxPhaseCurrent() float64 {
 	m.ctrl.T.Helper()
@@ -848,6 +862,20 @@ func (mr *MockCircuitMockRecorder) GetMaxPower() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxPower", reflect.TypeOf((*MockCircuit)(nil).GetMaxPower))
 }
 
+// GetMaxPowerLimit mocks base method.
+func (m *MockCircuit) GetMaxPowerLimit() *float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxPowerLimit")
+	ret0, _ := ret[0].(*float64)
+	return ret0
+}
+
+// GetMaxPowerLimit indicates an expected call of GetMaxPowerLimit.
+func (mr *MockCircuitMockRecorder) GetMaxPowerLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxPowerLimit", reflect.TypeOf((*MockCircuit)(nil).GetMaxPowerLimit))
+}
+
 // GetParent mocks base method.
 func (m *MockCircuit) GetParent() Circuit {
 	m.ctrl.T.Helper()
@@ -914,6 +942,20 @@ func (mr *MockCircuitMockRecorder) SetMaxCurrent(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxCurrent", reflect.TypeOf((*MockCircuit)(nil).SetMaxCurrent), arg0)
 }
 
+// SetMaxCurrentLimit mocks base method.
+func (m *MockCircuit) SetMaxCurrentLimit(arg0 *float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxCurrentLimit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMaxCurrentLimit indicates an expected call of SetMaxCurrentLimit.
+func (mr *MockCircuitMockRecorder) SetMaxCurrentLimit(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxCurrentLimit", reflect.TypeOf((*MockCircuit)(nil).SetMaxCurrentLimit), arg0)
+}
+
 // SetMaxPower mocks base method.
 func (m *MockCircuit) SetMaxPower(arg0 float64) {
 	m.ctrl.T.Helper()
@@ -926,6 +968,20 @@ func (mr *MockCircuitMockRecorder) SetMaxPower(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxPower", reflect.TypeOf((*MockCircuit)(nil).SetMaxPower), arg0)
 }
 
+// SetMaxPowerLimit mocks base method.
+func (m *MockCircuit) SetMaxPowerLimit(arg0 *float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxPowerLimit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMaxPowerLimit indicates an expected call of SetMaxPowerLimit.
+func (mr *MockCircuitMockRecorder) SetMaxPowerLimit(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxPowerLimit", reflect.TypeOf((*MockCircuit)(nil).SetMaxPowerLimit), arg0)
+}
+
 // SetTitle mocks base method.
 func (m *MockCircuit) SetTitle(arg0 string) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,761 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: proto/evcc.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys       []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Loadpoints []int32  `protobuf:"varint,2,rep,packed,name=loadpoints,proto3" json:"loadpoints,omitempty"`
+}
+
+func (x *StateRequest) Reset() {
+	*x = StateRequest{}
+	mi := &file_proto_evcc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateRequest) ProtoMessage() {}
+
+func (x *StateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_evcc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateRequest.ProtoReflect.Descriptor instead.
+func (*StateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_evcc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StateRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *StateRequest) GetLoadpoints() []int32 {
+	if x != nil {
+		return x.Loadpoints
+	}
+	return nil
+}
+
+type Update struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key       string          `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Loadpoint int32           `protobuf:"varint,2,opt,name=loadpoint,proto3" json:"loadpoint,omitempty"`
+	Value     *structpb.Value `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Update) Reset() {
+	*x = Update{}
+	mi := &file_proto_evcc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Update) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Update) ProtoMessage() {}
+
+func (x *Update) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_evcc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Update.ProtoReflect.Descriptor instead.
+func (*Update) Descriptor() ([]byte, []int) {
+	return file_proto_evcc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Update) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Update) GetLoadpoint() int32 {
+	if x != nil {
+		return x.Loadpoint
+	}
+	return 0
+}
+
+func (x *Update) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type StateReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []*Update `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *StateReply) Reset() {
+	*x = StateReply{}
+	mi := &file_proto_evcc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateReply) ProtoMessage() {}
+
+func (x *StateReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_evcc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateReply.ProtoReflect.Descriptor instead.
+func (*StateReply) Descriptor() ([]byte, []int) {
+	return file_proto_evcc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StateReply) GetValues() []*Update {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type LoadpointModeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Loadpoint int32  `protobuf:"varint,1,opt,name=loadpoint,proto3" json:"loadpoint,omitempty"`
+	Mode      string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+}
+
+func (x *LoadpointModeRequest) Reset() {
+	*x = LoadpointModeRequest{}
+	mi := &file_proto_evcc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadpointModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadpointModeRequest) ProtoMessage() {}
+
+func (x *LoadpointModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_evcc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadpointModeRequest.ProtoReflect.Descriptor instead.
+func (*LoadpointModeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_evcc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LoadpointModeRequest) GetLoadpoint() int32 {
+	if x != nil {
+		return x.Loadpoint
+	}
+	return 0
+}
+
+func (x *LoadpointModeRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type LoadpointCurrentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Loadpoint  int32                   `protobuf:"varint,1,opt,name=loadpoint,proto3" json:"loadpoint,omitempty"`
+	MinCurrent *wrapperspb.DoubleValue `protobuf:"bytes,2,opt,name=min_current,json=minCurrent,proto3" json:"min_current,omitempty"`
+	MaxCurrent *wrapperspb.DoubleValue `protobuf:"bytes,3,opt,name=max_current,json=maxCurrent,proto3" json:"max_current,omitempty"`
+}
+
+func (x *LoadpointCurrentsRequest) Reset() {
+	*x = LoadpointCurrentsRequest{}
+	mi := &file_proto_evcc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadpointCurrentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadpointCurrentsRequest) ProtoMessage() {}
+
+func (x *LoadpointCurrentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_evcc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadpointCurrentsRequest.ProtoReflect.Descriptor instead.
+func (*LoadpointCurrentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_evcc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LoadpointCurrentsRequest) GetLoadpoint() int32 {
+	if x != nil {
+		return x.Loadpoint
+	}
+	return 0
+}
+
+func (x *LoadpointCurrentsRequest) GetMinCurrent() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.MinCurrent
+	}
+	return nil
+}
+
+func (x *LoadpointCurrentsRequest) GetMaxCurrent() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.MaxCurrent
+	}
+	return nil
+}
+
+type LoadpointLimitSocRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Loadpoint int32 `protobuf:"varint,1,opt,name=loadpoint,proto3" json:"loadpoint,omitempty"`
+	LimitSoc  int32 `protobuf:"varint,2,opt,name=limit_soc,json=limitSoc,proto3" json:"limit_soc,omitempty"`
+}
+
+func (x *LoadpointLimitSocRequest) Reset() {
+	*x = LoadpointLimitSocRequest{}
+	mi := &file_proto_evcc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadpointLimitSocRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadpointLimitSocRequest) ProtoMessage() {}
+
+func (x *LoadpointLimitSocRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_evcc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadpointLimitSocRequest.ProtoReflect.Descriptor instead.
+func (*LoadpointLimitSocRequest) Descriptor() ([]byte, []int) {
+	return file_proto_evcc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LoadpointLimitSocRequest) GetLoadpoint() int32 {
+	if x != nil {
+		return x.Loadpoint
+	}
+	return 0
+}
+
+func (x *LoadpointLimitSocRequest) GetLimitSoc() int32 {
+	if x != nil {
+		return x.LimitSoc
+	}
+	return 0
+}
+
+type LoadpointReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Loadpoint  int32   `protobuf:"varint,1,opt,name=loadpoint,proto3" json:"loadpoint,omitempty"`
+	Mode       string  `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	MinCurrent float64 `protobuf:"fixed64,3,opt,name=min_current,json=minCurrent,proto3" json:"min_current,omitempty"`
+	MaxCurrent float64 `protobuf:"fixed64,4,opt,name=max_current,json=maxCurrent,proto3" json:"max_current,omitempty"`
+	LimitSoc   int32   `protobuf:"varint,5,opt,name=limit_soc,json=limitSoc,proto3" json:"limit_soc,omitempty"`
+}
+
+func (x *LoadpointReply) Reset() {
+	*x = LoadpointReply{}
+	mi := &file_proto_evcc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadpointReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadpointReply) ProtoMessage() {}
+
+func (x *LoadpointReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_evcc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadpointReply.ProtoReflect.Descriptor instead.
+func (*LoadpointReply) Descriptor() ([]byte, []int) {
+	return file_proto_evcc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LoadpointReply) GetLoadpoint() int32 {
+	if x != nil {
+		return x.Loadpoint
+	}
+	return 0
+}
+
+func (x *LoadpointReply) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *LoadpointReply) GetMinCurrent() float64 {
+	if x != nil {
+		return x.MinCurrent
+	}
+	return 0
+}
+
+func (x *LoadpointReply) GetMaxCurrent() float64 {
+	if x != nil {
+		return x.MaxCurrent
+	}
+	return 0
+}
+
+func (x *LoadpointReply) GetLimitSoc() int32 {
+	if x != nil {
+		return x.LimitSoc
+	}
+	return 0
+}
+
+type BatteryControlRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DischargeControl *wrapperspb.BoolValue   `protobuf:"bytes,1,opt,name=discharge_control,json=dischargeControl,proto3" json:"discharge_control,omitempty"`
+	PrioritySoc      *wrapperspb.DoubleValue `protobuf:"bytes,2,opt,name=priority_soc,json=prioritySoc,proto3" json:"priority_soc,omitempty"`
+	BufferSoc        *wrapperspb.DoubleValue `protobuf:"bytes,3,opt,name=buffer_soc,json=bufferSoc,proto3" json:"buffer_soc,omitempty"`
+	BufferStartSoc   *wrapperspb.DoubleValue `protobuf:"bytes,4,opt,name=buffer_start_soc,json=bufferStartSoc,proto3" json:"buffer_start_soc,omitempty"`
+}
+
+func (x *BatteryControlRequest) Reset() {
+	*x = BatteryControlRequest{}
+	mi := &file_proto_evcc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatteryControlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatteryControlRequest) ProtoMessage() {}
+
+func (x *BatteryControlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_evcc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatteryControlRequest.ProtoReflect.Descriptor instead.
+func (*BatteryControlRequest) Descriptor() ([]byte, []int) {
+	return file_proto_evcc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatteryControlRequest) GetDischargeControl() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.DischargeControl
+	}
+	return nil
+}
+
+func (x *BatteryControlRequest) GetPrioritySoc() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.PrioritySoc
+	}
+	return nil
+}
+
+func (x *BatteryControlRequest) GetBufferSoc() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.BufferSoc
+	}
+	return nil
+}
+
+func (x *BatteryControlRequest) GetBufferStartSoc() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.BufferStartSoc
+	}
+	return nil
+}
+
+type BatteryControlReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DischargeControl bool    `protobuf:"varint,1,opt,name=discharge_control,json=dischargeControl,proto3" json:"discharge_control,omitempty"`
+	PrioritySoc      float64 `protobuf:"fixed64,2,opt,name=priority_soc,json=prioritySoc,proto3" json:"priority_soc,omitempty"`
+	BufferSoc        float64 `protobuf:"fixed64,3,opt,name=buffer_soc,json=bufferSoc,proto3" json:"buffer_soc,omitempty"`
+	BufferStartSoc   float64 `protobuf:"fixed64,4,opt,name=buffer_start_soc,json=bufferStartSoc,proto3" json:"buffer_start_soc,omitempty"`
+}
+
+func (x *BatteryControlReply) Reset() {
+	*x = BatteryControlReply{}
+	mi := &file_proto_evcc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatteryControlReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatteryControlReply) ProtoMessage() {}
+
+func (x *BatteryControlReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_evcc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatteryControlReply.ProtoReflect.Descriptor instead.
+func (*BatteryControlReply) Descriptor() ([]byte, []int) {
+	return file_proto_evcc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatteryControlReply) GetDischargeControl() bool {
+	if x != nil {
+		return x.DischargeControl
+	}
+	return false
+}
+
+func (x *BatteryControlReply) GetPrioritySoc() float64 {
+	if x != nil {
+		return x.PrioritySoc
+	}
+	return 0
+}
+
+func (x *BatteryControlReply) GetBufferSoc() float64 {
+	if x != nil {
+		return x.BufferSoc
+	}
+	return 0
+}
+
+func (x *BatteryControlReply) GetBufferStartSoc() float64 {
+	if x != nil {
+		return x.BufferStartSoc
+	}
+	return 0
+}
+
+var File_proto_evcc_proto protoreflect.FileDescriptor
+
+var file_proto_evcc_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x76, 0x63, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x07, 0x65, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70,
+	0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x42, 0x0a, 0x0c, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x6c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x0a, 0x6c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x66, 0x0a,
+	0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x61,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x6f,
+	0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x35, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x48, 0x0a, 0x14,
+	0x4c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0xb6, 0x01, 0x0a, 0x18, 0x4c, 0x6f, 0x61, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x12, 0x3d, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x22,
+	0x55, 0x0a, 0x18, 0x4c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x53, 0x6f, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6c,
+	0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x6c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x5f, 0x73, 0x6f, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x53, 0x6f, 0x63, 0x22, 0xa1, 0x01, 0x0a, 0x0e, 0x4c, 0x6f, 0x61, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x61,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x6f,
+	0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
+	0x69, 0x6e, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6d, 0x61, 0x78, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x6f, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x53, 0x6f, 0x63, 0x22, 0xa6, 0x02, 0x0a, 0x15, 0x42,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x47, 0x0a, 0x11, 0x64, 0x69, 0x73, 0x63, 0x68, 0x61, 0x72, 0x67,
+	0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x10, 0x64, 0x69, 0x73,
+	0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x3f, 0x0a,
+	0x0c, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x6f, 0x63, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x53, 0x6f, 0x63, 0x12, 0x3b,
+	0x0a, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x6f, 0x63, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x09, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x6f, 0x63, 0x12, 0x46, 0x0a, 0x10, 0x62,
+	0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x6f, 0x63, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x0e, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x53, 0x6f, 0x63, 0x22, 0xae, 0x01, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2b, 0x0a, 0x11, 0x64,
+	0x69, 0x73, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x64, 0x69, 0x73, 0x63, 0x68, 0x61, 0x72, 0x67,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x6f,
+	0x72, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x6f, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x53, 0x6f, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x62,
+	0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x6f, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x09, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x6f, 0x63, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x75,
+	0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x6f, 0x63, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x53, 0x6f, 0x63, 0x32, 0xcc, 0x03, 0x0a, 0x04, 0x45, 0x76, 0x63, 0x63, 0x12, 0x38, 0x0a,
+	0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x15, 0x2e, 0x65, 0x76, 0x63, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x13, 0x2e, 0x65, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x15, 0x2e, 0x65, 0x76, 0x63, 0x63, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0f, 0x2e, 0x65, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x61, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x2e, 0x65, 0x76, 0x63, 0x63, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x65, 0x76, 0x63, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x54, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x65, 0x76, 0x63,
+	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x65, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x4c,
+	0x6f, 0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x53, 0x6f, 0x63,
+	0x12, 0x21, 0x2e, 0x65, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x53, 0x6f, 0x63, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x65, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f,
+	0x61, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x53,
+	0x0a, 0x11, 0x53, 0x65, 0x74, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x12, 0x1e, 0x2e, 0x65, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x42, 0x0a, 0x5a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_evcc_proto_rawDescOnce sync.Once
+	file_proto_evcc_proto_rawDescData = file_proto_evcc_proto_rawDesc
+)
+
+func file_proto_evcc_proto_rawDescGZIP() []byte {
+	file_proto_evcc_proto_rawDescOnce.Do(func() {
+		file_proto_evcc_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_evcc_proto_rawDescData)
+	})
+	return file_proto_evcc_proto_rawDescData
+}
+
+var file_proto_evcc_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_evcc_proto_goTypes = []any{
+	(*StateRequest)(nil),             // 0: evcc.v1.StateRequest
+	(*Update)(nil),                   // 1: evcc.v1.Update
+	(*StateReply)(nil),               // 2: evcc.v1.StateReply
+	(*LoadpointModeRequest)(nil),     // 3: evcc.v1.LoadpointModeRequest
+	(*LoadpointCurrentsRequest)(nil), // 4: evcc.v1.LoadpointCurrentsRequest
+	(*LoadpointLimitSocRequest)(nil), // 5: evcc.v1.LoadpointLimitSocRequest
+	(*LoadpointReply)(nil),           // 6: evcc.v1.LoadpointReply
+	(*BatteryControlRequest)(nil),    // 7: evcc.v1.BatteryControlRequest
+	(*BatteryControlReply)(nil),      // 8: evcc.v1.BatteryControlReply
+	(*structpb.Value)(nil),           // 9: google.protobuf.Value
+	(*wrapperspb.DoubleValue)(nil),   // 10: google.protobuf.DoubleValue
+	(*wrapperspb.BoolValue)(nil),     // 11: google.protobuf.BoolValue
+}
+var file_proto_evcc_proto_depIdxs = []int32{
+	9,  // 0: evcc.v1.Update.value:type_name -> google.protobuf.Value
+	1,  // 1: evcc.v1.StateReply.values:type_name -> evcc.v1.Update
+	10, // 2: evcc.v1.LoadpointCurrentsRequest.min_current:type_name -> google.protobuf.DoubleValue
+	10, // 3: evcc.v1.LoadpointCurrentsRequest.max_current:type_name -> google.protobuf.DoubleValue
+	11, // 4: evcc.v1.BatteryControlRequest.discharge_control:type_name -> google.protobuf.BoolValue
+	10, // 5: evcc.v1.BatteryControlRequest.priority_soc:type_name -> google.protobuf.DoubleValue
+	10, // 6: evcc.v1.BatteryControlRequest.buffer_soc:type_name -> google.protobuf.DoubleValue
+	10, // 7: evcc.v1.BatteryControlRequest.buffer_start_soc:type_name -> google.protobuf.DoubleValue
+	0,  // 8: evcc.v1.Evcc.GetState:input_type -> evcc.v1.StateRequest
+	0,  // 9: evcc.v1.Evcc.StreamUpdates:input_type -> evcc.v1.StateRequest
+	3,  // 10: evcc.v1.Evcc.SetLoadpointMode:input_type -> evcc.v1.LoadpointModeRequest
+	4,  // 11: evcc.v1.Evcc.SetLoadpointCurrents:input_type -> evcc.v1.LoadpointCurrentsRequest
+	5,  // 12: evcc.v1.Evcc.SetLoadpointLimitSoc:input_type -> evcc.v1.LoadpointLimitSocRequest
+	7,  // 13: evcc.v1.Evcc.SetBatteryControl:input_type -> evcc.v1.BatteryControlRequest
+	2,  // 14: evcc.v1.Evcc.GetState:output_type -> evcc.v1.StateReply
+	1,  // 15: evcc.v1.Evcc.StreamUpdates:output_type -> evcc.v1.Update
+	6,  // 16: evcc.v1.Evcc.SetLoadpointMode:output_type -> evcc.v1.LoadpointReply
+	6,  // 17: evcc.v1.Evcc.SetLoadpointCurrents:output_type -> evcc.v1.LoadpointReply
+	6,  // 18: evcc.v1.Evcc.SetLoadpointLimitSoc:output_type -> evcc.v1.LoadpointReply
+	8,  // 19: evcc.v1.Evcc.SetBatteryControl:output_type -> evcc.v1.BatteryControlReply
+	14, // [14:20] is the sub-list for method output_type
+	8,  // [8:14] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_proto_evcc_proto_init() }
+func file_proto_evcc_proto_init() {
+	if File_proto_evcc_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_evcc_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_evcc_proto_goTypes,
+		DependencyIndexes: file_proto_evcc_proto_depIdxs,
+		MessageInfos:      file_proto_evcc_proto_msgTypes,
+	}.Build()
+	File_proto_evcc_proto = out.File
+	file_proto_evcc_proto_rawDesc = nil
+	file_proto_evcc_proto_goTypes = nil
+	file_proto_evcc_proto_depIdxs = nil
+}
@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/evcc.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Evcc_GetState_FullMethodName             = "/evcc.v1.Evcc/GetState"
+	Evcc_StreamUpdates_FullMethodName        = "/evcc.v1.Evcc/StreamUpdates"
+	Evcc_SetLoadpointMode_FullMethodName     = "/evcc.v1.Evcc/SetLoadpointMode"
+	Evcc_SetLoadpointCurrents_FullMethodName = "/evcc.v1.Evcc/SetLoadpointCurrents"
+	Evcc_SetLoadpointLimitSoc_FullMethodName = "/evcc.v1.Evcc/SetLoadpointLimitSoc"
+	Evcc_SetBatteryControl_FullMethodName    = "/evcc.v1.Evcc/SetBatteryControl"
+)
+
+// EvccClient is the client API for Evcc service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EvccClient interface {
+	GetState(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateReply, error)
+	StreamUpdates(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Update], error)
+	SetLoadpointMode(ctx context.Context, in *LoadpointModeRequest, opts ...grpc.CallOption) (*LoadpointReply, error)
+	SetLoadpointCurrents(ctx context.Context, in *LoadpointCurrentsRequest, opts ...grpc.CallOption) (*LoadpointReply, error)
+	SetLoadpointLimitSoc(ctx context.Context, in *LoadpointLimitSocRequest, opts ...grpc.CallOption) (*LoadpointReply, error)
+	SetBatteryControl(ctx context.Context, in *BatteryControlRequest, opts ...grpc.CallOption) (*BatteryControlReply, error)
+}
+
+type evccClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEvccClient(cc grpc.ClientConnInterface) EvccClient {
+	return &evccClient{cc}
+}
+
+func (c *evccClient) GetState(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StateReply)
+	err := c.cc.Invoke(ctx, Evcc_GetState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *evccClient) StreamUpdates(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Update], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Evcc_ServiceDesc.Streams[0], Evcc_StreamUpdates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StateRequest, Update]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Evcc_StreamUpdatesClient = grpc.ServerStreamingClient[Update]
+
+func (c *evccClient) SetLoadpointMode(ctx context.Context, in *LoadpointModeRequest, opts ...grpc.CallOption) (*LoadpointReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoadpointReply)
+	err := c.cc.Invoke(ctx, Evcc_SetLoadpointMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *evccClient) SetLoadpointCurrents(ctx context.Context, in *LoadpointCurrentsRequest, opts ...grpc.CallOption) (*LoadpointReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoadpointReply)
+	err := c.cc.Invoke(ctx, Evcc_SetLoadpointCurrents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *evccClient) SetLoadpointLimitSoc(ctx context.Context, in *LoadpointLimitSocRequest, opts ...grpc.CallOption) (*LoadpointReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoadpointReply)
+	err := c.cc.Invoke(ctx, Evcc_SetLoadpointLimitSoc_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *evccClient) SetBatteryControl(ctx context.Context, in *BatteryControlRequest, opts ...grpc.CallOption) (*BatteryControlReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatteryControlReply)
+	err := c.cc.Invoke(ctx, Evcc_SetBatteryControl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvccServer is the server API for Evcc service.
+// All implementations must embed UnimplementedEvccServer
+// for forward compatibility.
+type EvccServer interface {
+	GetState(context.Context, *StateRequest) (*StateReply, error)
+	StreamUpdates(*StateRequest, grpc.ServerStreamingServer[Update]) error
+	SetLoadpointMode(context.Context, *LoadpointModeRequest) (*LoadpointReply, error)
+	SetLoadpointCurrents(context.Context, *LoadpointCurrentsRequest) (*LoadpointReply, error)
+	SetLoadpointLimitSoc(context.Context, *LoadpointLimitSocRequest) (*LoadpointReply, error)
+	SetBatteryControl(context.Context, *BatteryControlRequest) (*BatteryControlReply, error)
+	mustEmbedUnimplementedEvccServer()
+}
+
+// UnimplementedEvccServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEvccServer struct{}
+
+func (UnimplementedEvccServer) GetState(context.Context, *StateRequest) (*StateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+}
+func (UnimplementedEvccServer) StreamUpdates(*StateRequest, grpc.ServerStreamingServer[Update]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamUpdates not implemented")
+}
+func (UnimplementedEvccServer) SetLoadpointMode(context.Context, *LoadpointModeRequest) (*LoadpointReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLoadpointMode not implemented")
+}
+func (UnimplementedEvccServer) SetLoadpointCurrents(context.Context, *LoadpointCurrentsRequest) (*LoadpointReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLoadpointCurrents not implemented")
+}
+func (UnimplementedEvccServer) SetLoadpointLimitSoc(context.Context, *LoadpointLimitSocRequest) (*LoadpointReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLoadpointLimitSoc not implemented")
+}
+func (UnimplementedEvccServer) SetBatteryControl(context.Context, *BatteryControlRequest) (*BatteryControlReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBatteryControl not implemented")
+}
+func (UnimplementedEvccServer) mustEmbedUnimplementedEvccServer() {}
+func (UnimplementedEvccServer) testEmbeddedByValue()              {}
+
+// UnsafeEvccServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EvccServer will
+// result in compilation errors.
+type UnsafeEvccServer interface {
+	mustEmbedUnimplementedEvccServer()
+}
+
+func RegisterEvccServer(s grpc.ServiceRegistrar, srv EvccServer) {
+	// If the following call pancis, it indicates UnimplementedEvccServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Evcc_ServiceDesc, srv)
+}
+
+func _Evcc_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvccServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Evcc_GetState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvccServer).GetState(ctx, req.(*StateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Evcc_StreamUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EvccServer).StreamUpdates(m, &grpc.GenericServerStream[StateRequest, Update]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Evcc_StreamUpdatesServer = grpc.ServerStreamingServer[Update]
+
+func _Evcc_SetLoadpointMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadpointModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvccServer).SetLoadpointMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Evcc_SetLoadpointMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvccServer).SetLoadpointMode(ctx, req.(*LoadpointModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Evcc_SetLoadpointCurrents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadpointCurrentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvccServer).SetLoadpointCurrents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Evcc_SetLoadpointCurrents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvccServer).SetLoadpointCurrents(ctx, req.(*LoadpointCurrentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Evcc_SetLoadpointLimitSoc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadpointLimitSocRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvccServer).SetLoadpointLimitSoc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Evcc_SetLoadpointLimitSoc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvccServer).SetLoadpointLimitSoc(ctx, req.(*LoadpointLimitSocRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Evcc_SetBatteryControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatteryControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvccServer).SetBatteryControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Evcc_SetBatteryControl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvccServer).SetBatteryControl(ctx, req.(*BatteryControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Evcc_ServiceDesc is the grpc.ServiceDesc for Evcc service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Evcc_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "evcc.v1.Evcc",
+	HandlerType: (*EvccServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetState",
+			Handler:    _Evcc_GetState_Handler,
+		},
+		{
+			MethodName: "SetLoadpointMode",
+			Handler:    _Evcc_SetLoadpointMode_Handler,
+		},
+		{
+			MethodName: "SetLoadpointCurrents",
+			Handler:    _Evcc_SetLoadpointCurrents_Handler,
+		},
+		{
+			MethodName: "SetLoadpointLimitSoc",
+			Handler:    _Evcc_SetLoadpointLimitSoc_Handler,
+		},
+		{
+			MethodName: "SetBatteryControl",
+			Handler:    _Evcc_SetBatteryControl_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamUpdates",
+			Handler:       _Evcc_StreamUpdates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/evcc.proto",
+}
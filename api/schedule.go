@@ -0,0 +1,38 @@
+package api
+
+import "time"
+
+// ChargeSlot is a planned charge power in W. Discharge slots with negative power are not planned yet
+// and rejected by chargers.
+type ChargeSlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Power float64   `json:"power"`
+}
+
+// ChargeSchedule is a slice of planned charge slots
+type ChargeSchedule []ChargeSlot
+
+// Future returns the slots starting after given time
+func (s ChargeSchedule) Future(now time.Time) ChargeSchedule {
+	var res ChargeSchedule
+	for _, slot := range s {
+		if slot.Start.After(now) {
+			res = append(res, slot)
+		}
+	}
+	return res
+}
+
+// Equal returns true if both schedules contain the same slots
+func (s ChargeSchedule) Equal(other ChargeSchedule) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for i, slot := range s {
+		if !slot.Start.Equal(other[i].Start) || !slot.End.Equal(other[i].End) || slot.Power != other[i].Power {
+			return false
+		}
+	}
+	return true
+}
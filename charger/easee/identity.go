@@ -47,8 +47,11 @@ func TokenSource(log *util.Logger, user, password string) (oauth2.TokenSource, e
 	}
 
 	token, err := c.authenticate()
+	if err != nil {
+		return nil, err
+	}
 
-	return oauth.RefreshTokenSource(token.AsOAuth2Token(), c), err
+	return oauth.Tokens.Register("easee "+user, "", oauth.RefreshTokenSource(token.AsOAuth2Token(), c)), nil
 }
 
 func (c *tokenSource) authenticate() (*Token, error) {
@@ -0,0 +1,252 @@
+package charger
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	eebusapi "github.com/enbility/eebus-go/api"
+	ucapi "github.com/enbility/eebus-go/usecases/api"
+	"github.com/enbility/eebus-go/usecases/eg/lpc"
+	"github.com/enbility/eebus-go/usecases/ma/mpc"
+	spineapi "github.com/enbility/spine-go/api"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/provider"
+	"github.com/evcc-io/evcc/server/eebus"
+	"github.com/evcc-io/evcc/util"
+)
+
+// EEBusHeatPump controls heat pumps using the EEBUS energy guard use cases.
+// Power consumption is limited using LPC and monitored using MPC.
+type EEBusHeatPump struct {
+	log *util.Logger
+	uc  *eebus.UseCasesEG
+
+	mux       sync.RWMutex
+	entity    spineapi.EntityRemoteInterface // lpc entity
+	enabled   bool
+	power     *float64 // power limit, nil for unlimited
+	standby   float64
+	powerV    *provider.Value[float64]
+	energyV   *provider.Value[float64]
+	connected bool
+
+	*eebus.Connector
+}
+
+func init() {
+	registry.Add("eebus-heatpump", NewEEBusHeatPumpFromConfig)
+}
+
+// NewEEBusHeatPumpFromConfig creates an EEBus heat pump from generic config
+func NewEEBusHeatPumpFromConfig(other map[string]interface{}) (api.Charger, error) {
+	cc := struct {
+		Ski          string
+		Ip           string
+		StandbyPower float64
+		Timeout      time.Duration
+	}{
+		StandbyPower: 50,
+		Timeout:      10 * time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	return NewEEBusHeatPump(cc.Ski, cc.Ip, cc.StandbyPower, cc.Timeout)
+}
+
+// NewEEBusHeatPump creates EEBus heat pump
+func NewEEBusHeatPump(ski, ip string, standbypower float64, timeout time.Duration) (*EEBusHeatPump, error) {
+	if eebus.Instance == nil {
+		return nil, errors.New("eebus not configured")
+	}
+
+	c := &EEBusHeatPump{
+		log:       util.NewLogger("eebus-heatpump"),
+		uc:        eebus.Instance.EnergyGuard(),
+		enabled:   true,
+		standby:   standbypower,
+		powerV:    provider.NewValue[float64](timeout),
+		energyV:   provider.NewValue[float64](timeout),
+		Connector: eebus.NewConnector(),
+	}
+
+	if err := eebus.Instance.RegisterDevice(ski, ip, c); err != nil {
+		return nil, err
+	}
+
+	if err := c.Wait(90 * time.Second); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+var _ eebus.Device = (*EEBusHeatPump)(nil)
+
+// Connect implements the eebus.Device interface
+func (c *EEBusHeatPump) Connect(connected bool) {
+	c.mux.Lock()
+	reconnect := connected && !c.connected
+	c.connected = connected
+	c.mux.Unlock()
+
+	c.Connector.Connect(connected)
+
+	// re-apply limit after reconnect
+	if reconnect {
+		go func() {
+			if err := c.writeLimit(); err != nil {
+				c.log.DEBUG.Println("limit:", err)
+			}
+		}()
+	}
+}
+
+// UseCaseEvent implements the eebus.Device interface
+func (c *EEBusHeatPump) UseCaseEvent(_ spineapi.DeviceRemoteInterface, entity spineapi.EntityRemoteInterface, event eebusapi.EventType) {
+	switch event {
+	case lpc.UseCaseSupportUpdate, lpc.DataUpdateLimit:
+		c.mux.Lock()
+		c.entity = entity
+		c.mux.Unlock()
+
+	case mpc.DataUpdatePower:
+		if data, err := c.uc.MPC.Power(entity); err == nil {
+			c.powerV.Set(data)
+		} else {
+			c.log.ERROR.Println("MPC.Power:", err)
+		}
+
+	case mpc.DataUpdateEnergyConsumed:
+		if data, err := c.uc.MPC.EnergyConsumed(entity); err == nil {
+			c.energyV.Set(data)
+		} else {
+			c.log.ERROR.Println("MPC.EnergyConsumed:", err)
+		}
+	}
+}
+
+// limit returns the consumption limit according to enabled state and power setpoint
+func (c *EEBusHeatPump) limit() ucapi.LoadLimit {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	switch {
+	case !c.enabled:
+		return ucapi.LoadLimit{IsActive: true, Value: 0}
+	case c.power != nil:
+		return ucapi.LoadLimit{IsActive: true, Value: *c.power}
+	default:
+		return ucapi.LoadLimit{IsActive: false}
+	}
+}
+
+// writeLimit sends the consumption limit to the heat pump
+func (c *EEBusHeatPump) writeLimit() error {
+	c.mux.RLock()
+	entity := c.entity
+	c.mux.RUnlock()
+
+	if entity == nil {
+		return api.ErrNotAvailable
+	}
+
+	_, err := c.uc.LPC.WriteConsumptionLimit(entity, c.limit(), nil)
+	return err
+}
+
+var _ api.IconDescriber = (*EEBusHeatPump)(nil)
+
+// Icon implements the api.IconDescriber interface
+func (c *EEBusHeatPump) Icon() string {
+	return "heatpump"
+}
+
+var _ api.FeatureDescriber = (*EEBusHeatPump)(nil)
+
+// Features implements the api.FeatureDescriber interface
+func (c *EEBusHeatPump) Features() []api.Feature {
+	return []api.Feature{api.IntegratedDevice, api.Heating}
+}
+
+// Status implements the api.Charger interface
+func (c *EEBusHeatPump) Status() (api.ChargeStatus, error) {
+	power, err := c.CurrentPower()
+	if err != nil {
+		return api.StatusNone, err
+	}
+
+	if power > c.standby {
+		return api.StatusC, nil
+	}
+
+	return api.StatusB, nil
+}
+
+// Enabled implements the api.Charger interface
+func (c *EEBusHeatPump) Enabled() (bool, error) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	return c.enabled, nil
+}
+
+// Enable implements the api.Charger interface
+func (c *EEBusHeatPump) Enable(enable bool) error {
+	c.mux.Lock()
+	prev := c.enabled
+	c.enabled = enable
+	c.mux.Unlock()
+
+	err := c.writeLimit()
+	if err != nil {
+		c.mux.Lock()
+		c.enabled = prev
+		c.mux.Unlock()
+	}
+
+	return err
+}
+
+// MaxCurrent implements the api.Charger interface
+func (c *EEBusHeatPump) MaxCurrent(current int64) error {
+	// heat pumps are assumed to be connected on three phases
+	return c.SetPower(3 * voltage * float64(current))
+}
+
+var _ api.PowerSetter = (*EEBusHeatPump)(nil)
+
+// SetPower implements the api.PowerSetter interface
+func (c *EEBusHeatPump) SetPower(power float64) error {
+	power = max(power, 0)
+
+	c.mux.Lock()
+	prev := c.power
+	c.power = &power
+	c.mux.Unlock()
+
+	err := c.writeLimit()
+	if err != nil {
+		c.mux.Lock()
+		c.power = prev
+		c.mux.Unlock()
+	}
+
+	return err
+}
+
+var _ api.Meter = (*EEBusHeatPump)(nil)
+
+// CurrentPower implements the api.Meter interface
+func (c *EEBusHeatPump) CurrentPower() (float64, error) {
+	return c.powerV.Get()
+}
+
+var _ api.MeterEnergy = (*EEBusHeatPump)(nil)
+
+// TotalEnergy implements the api.MeterEnergy interface
+func (c *EEBusHeatPump) TotalEnergy() (float64, error) {
+	return c.energyV.Get()
+}
@@ -28,11 +28,13 @@ import (
 	"github.com/evcc-io/evcc/util/sponsor"
 )
 
-// MyPvElwa2 charger implementation
-type MyPvElwa2 struct {
-	log   *util.Logger
-	conn  *modbus.Connection
-	power uint32
+// MyPv charger implementation for my-PV AC ELWA 2 and AC THOR heating devices
+type MyPv struct {
+	log      *util.Logger
+	conn     *modbus.Connection
+	name     string
+	maxPower uint32
+	power    uint32
 }
 
 const (
@@ -41,29 +43,44 @@ const (
 	elwaRegTempLimit = 1002
 	elwaRegStatus    = 1003
 	elwaRegPower     = 1074
+
+	// rated heater power
+	elwaMaxPower = 3500 // W
+	thorMaxPower = 3000 // W
 )
 
 func init() {
-	registry.Add("ac-elwa-2", NewMyPvElwa2FromConfig)
+	registry.Add("ac-elwa-2", func(other map[string]interface{}) (api.Charger, error) {
+		return newMyPvFromConfig("ac-elwa-2", elwaMaxPower, other)
+	})
+	registry.Add("ac-thor", func(other map[string]interface{}) (api.Charger, error) {
+		return newMyPvFromConfig("ac-thor", thorMaxPower, other)
+	})
 }
 
 // https://github.com/evcc-io/evcc/discussions/12761
 
-// NewMyPvElwa2FromConfig creates a MyPvElwa2 charger from generic config
-func NewMyPvElwa2FromConfig(other map[string]interface{}) (api.Charger, error) {
-	cc := modbus.TcpSettings{
-		ID: 1,
+// newMyPvFromConfig creates a MyPv charger from generic config
+func newMyPvFromConfig(name string, maxPower uint32, other map[string]interface{}) (api.Charger, error) {
+	cc := struct {
+		modbus.TcpSettings `mapstructure:",squash"`
+		MaxPower           uint32
+	}{
+		TcpSettings: modbus.TcpSettings{
+			ID: 1,
+		},
+		MaxPower: maxPower,
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
 		return nil, err
 	}
 
-	return NewMyPvElwa2(cc.URI, cc.ID)
+	return NewMyPv(name, cc.URI, cc.ID, cc.MaxPower)
 }
 
-// NewMyPvElwa2 creates myPV AC Elwa 2 charger
-func NewMyPvElwa2(uri string, slaveID uint8) (api.Charger, error) {
+// NewMyPv creates my-PV AC ELWA 2 or AC THOR charger with power setpoints up to maxPower
+func NewMyPv(name, uri string, slaveID uint8, maxPower uint32) (*MyPv, error) {
 	conn, err := modbus.NewConnection(uri, "", "", 0, modbus.Tcp, slaveID)
 	if err != nil {
 		return nil, err
@@ -73,12 +90,14 @@ func NewMyPvElwa2(uri string, slaveID uint8) (api.Charger, error) {
 		return nil, api.ErrSponsorRequired
 	}
 
-	log := util.NewLogger("ac-elwa-2")
+	log := util.NewLogger(name)
 	conn.Logger(log.TRACE)
 
-	wb := &MyPvElwa2{
-		log:  log,
-		conn: conn,
+	wb := &MyPv{
+		log:      log,
+		conn:     conn,
+		name:     name,
+		maxPower: maxPower,
 	}
 
 	go wb.heartbeat(30 * time.Second)
@@ -86,21 +105,21 @@ func NewMyPvElwa2(uri string, slaveID uint8) (api.Charger, error) {
 	return wb, nil
 }
 
-var _ api.IconDescriber = (*MyPvElwa2)(nil)
+var _ api.IconDescriber = (*MyPv)(nil)
 
 // Icon implements the api.IconDescriber interface
-func (v *MyPvElwa2) Icon() string {
+func (v *MyPv) Icon() string {
 	return "waterheater"
 }
 
-var _ api.FeatureDescriber = (*MyPvElwa2)(nil)
+var _ api.FeatureDescriber = (*MyPv)(nil)
 
 // Features implements the api.FeatureDescriber interface
-func (wb *MyPvElwa2) Features() []api.Feature {
+func (wb *MyPv) Features() []api.Feature {
 	return []api.Feature{api.IntegratedDevice, api.Heating}
 }
 
-func (wb *MyPvElwa2) heartbeat(timeout time.Duration) {
+func (wb *MyPv) heartbeat(timeout time.Duration) {
 	for range time.Tick(timeout) {
 		if power := uint16(atomic.LoadUint32(&wb.power)); power > 0 {
 			enabled, err := wb.Enabled()
@@ -115,7 +134,7 @@ func (wb *MyPvElwa2) heartbeat(timeout time.Duration) {
 }
 
 // Status implements the api.Charger interface
-func (wb *MyPvElwa2) Status() (api.ChargeStatus, error) {
+func (wb *MyPv) Status() (api.ChargeStatus, error) {
 	res := api.StatusA
 	b, err := wb.conn.ReadHoldingRegisters(elwaRegStatus, 1)
 	if err != nil {
@@ -131,7 +150,7 @@ func (wb *MyPvElwa2) Status() (api.ChargeStatus, error) {
 }
 
 // Enabled implements the api.Charger interface
-func (wb *MyPvElwa2) Enabled() (bool, error) {
+func (wb *MyPv) Enabled() (bool, error) {
 	b, err := wb.conn.ReadHoldingRegisters(elwaRegSetPower, 1)
 	if err != nil {
 		return false, err
@@ -140,7 +159,7 @@ func (wb *MyPvElwa2) Enabled() (bool, error) {
 	return binary.BigEndian.Uint16(b) > 0, nil
 }
 
-func (wb *MyPvElwa2) setPower(power uint16) error {
+func (wb *MyPv) setPower(power uint16) error {
 	b := make([]byte, 2)
 	binary.BigEndian.PutUint16(b, power)
 
@@ -149,7 +168,7 @@ func (wb *MyPvElwa2) setPower(power uint16) error {
 }
 
 // Enable implements the api.Charger interface
-func (wb *MyPvElwa2) Enable(enable bool) error {
+func (wb *MyPv) Enable(enable bool) error {
 	var power uint16
 	if enable {
 		power = uint16(atomic.LoadUint32(&wb.power))
@@ -159,28 +178,35 @@ func (wb *MyPvElwa2) Enable(enable bool) error {
 }
 
 // MaxCurrent implements the api.Charger interface
-func (wb *MyPvElwa2) MaxCurrent(current int64) error {
+func (wb *MyPv) MaxCurrent(current int64) error {
 	return wb.MaxCurrentMillis(float64(current))
 }
 
-var _ api.ChargerEx = (*MyPvElwa2)(nil)
+var _ api.ChargerEx = (*MyPv)(nil)
 
 // MaxCurrentMillis implements the api.ChargerEx interface
-func (wb *MyPvElwa2) MaxCurrentMillis(current float64) error {
-	power := uint16(230 * current)
+func (wb *MyPv) MaxCurrentMillis(current float64) error {
+	return wb.SetPower(230 * current)
+}
+
+var _ api.PowerSetter = (*MyPv)(nil)
 
-	err := wb.setPower(power)
+// SetPower implements the api.PowerSetter interface
+func (wb *MyPv) SetPower(power float64) error {
+	p := uint16(min(max(power, 0), float64(wb.maxPower)))
+
+	err := wb.setPower(p)
 	if err == nil {
-		atomic.StoreUint32(&wb.power, uint32(power))
+		atomic.StoreUint32(&wb.power, uint32(p))
 	}
 
 	return err
 }
 
-var _ api.Meter = (*MyPvElwa2)(nil)
+var _ api.Meter = (*MyPv)(nil)
 
 // CurrentPower implements the api.Meter interface
-func (wb *MyPvElwa2) CurrentPower() (float64, error) {
+func (wb *MyPv) CurrentPower() (float64, error) {
 	b, err := wb.conn.ReadHoldingRegisters(elwaRegPower, 1)
 	if err != nil {
 		return 0, err
@@ -189,10 +215,10 @@ func (wb *MyPvElwa2) CurrentPower() (float64, error) {
 	return float64(binary.BigEndian.Uint16(b)), nil
 }
 
-var _ api.Battery = (*MyPvElwa2)(nil)
+var _ api.Battery = (*MyPv)(nil)
 
-// CurrentPower implements the api.Meter interface
-func (wb *MyPvElwa2) Soc() (float64, error) {
+// Soc implements the api.Battery interface
+func (wb *MyPv) Soc() (float64, error) {
 	b, err := wb.conn.ReadHoldingRegisters(elwaRegTemp, 1)
 	if err != nil {
 		return 0, err
@@ -201,10 +227,10 @@ func (wb *MyPvElwa2) Soc() (float64, error) {
 	return float64(binary.BigEndian.Uint16(b)) / 10, nil
 }
 
-var _ api.SocLimiter = (*MyPvElwa2)(nil)
+var _ api.SocLimiter = (*MyPv)(nil)
 
 // GetLimitSoc implements the api.SocLimiter interface
-func (wb *MyPvElwa2) GetLimitSoc() (int64, error) {
+func (wb *MyPv) GetLimitSoc() (int64, error) {
 	b, err := wb.conn.ReadHoldingRegisters(elwaRegTempLimit, 1)
 	if err != nil {
 		return 0, err
@@ -19,6 +19,7 @@ package charger
 
 import (
 	"cmp"
+	"errors"
 	"fmt"
 	"math"
 	"slices"
@@ -72,6 +73,7 @@ func NewOCPPFromConfig(other map[string]interface{}) (api.Charger, error) {
 
 		StackLevelZero *bool
 		RemoteStart    bool
+		Backend        string // existing backend to forward messages to
 	}{
 		Connector:      1,
 		MeterInterval:  10 * time.Second,
@@ -84,6 +86,14 @@ func NewOCPPFromConfig(other map[string]interface{}) (api.Charger, error) {
 
 	stackLevelZero := cc.StackLevelZero != nil && *cc.StackLevelZero
 
+	if cc.Backend != "" {
+		if cc.StationId == "" {
+			return nil, errors.New("backend requires station id")
+		}
+
+		ocpp.Instance().SetBackend(cc.StationId, cc.Backend)
+	}
+
 	c, err := NewOCPP(cc.StationId, cc.Connector, cc.IdTag,
 		cc.MeterValues, cc.MeterInterval,
 		stackLevelZero, cc.RemoteStart,
@@ -422,4 +432,7 @@ var _ loadpoint.Controller = (*OCPP)(nil)
 // LoadpointControl implements loadpoint.Controller
 func (c *OCPP) LoadpointControl(lp loadpoint.API) {
 	c.lp = lp
+
+	// decide on id tags presented at the charger
+	c.conn.SetAuthorizer(lp.AuthorizeIdentifier)
 }
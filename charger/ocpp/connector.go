@@ -31,6 +31,7 @@ type Connector struct {
 	idTag string
 
 	remoteIdTag string
+	authorize   func(idTag string) bool // decides on id tags presented at the connector, nil to accept all
 }
 
 func NewConnector(log *util.Logger, id int, cp *CP, idTag string) (*Connector, error) {
@@ -64,6 +65,27 @@ func (conn *Connector) IdTag() string {
 	return conn.idTag
 }
 
+// SetAuthorizer sets the function deciding on id tags presented at the connector
+func (conn *Connector) SetAuthorizer(fn func(idTag string) bool) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	conn.authorize = fn
+}
+
+// authorizationStatus returns the status of an id tag presented at the connector
+func (conn *Connector) authorizationStatus(idTag string) types.AuthorizationStatus {
+	conn.mu.Lock()
+	authorize, remoteIdTag := conn.authorize, conn.remoteIdTag
+	conn.mu.Unlock()
+
+	// evcc's own id tag is used for remote start
+	if authorize == nil || idTag == remoteIdTag || authorize(idTag) {
+		return types.AuthorizationStatusAccepted
+	}
+
+	return types.AuthorizationStatusInvalid
+}
+
 // getScheduleLimit queries the current or power limit the charge point is currently set to offer
 func (conn *Connector) GetScheduleLimit(duration int) (float64, error) {
 	schedule, err := conn.cp.GetCompositeScheduleRequest(conn.id, duration)
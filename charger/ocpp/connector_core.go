@@ -89,6 +89,8 @@ func (conn *Connector) OnMeterValues(request *core.MeterValuesRequest) (*core.Me
 }
 
 func (conn *Connector) OnStartTransaction(request *core.StartTransactionRequest) (*core.StartTransactionConfirmation, error) {
+	status := conn.authorizationStatus(request.IdTag)
+
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
 
@@ -97,7 +99,7 @@ func (conn *Connector) OnStartTransaction(request *core.StartTransactionRequest)
 
 	res := &core.StartTransactionConfirmation{
 		IdTagInfo: &types.IdTagInfo{
-			Status: types.AuthorizationStatusAccepted,
+			Status: status,
 		},
 		TransactionId: conn.txnId,
 	}
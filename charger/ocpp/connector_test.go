@@ -7,6 +7,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 	"github.com/stretchr/testify/suite"
 )
@@ -149,3 +150,26 @@ func (suite *connTestSuite) TestConnectorMeasurementsRunningTxn() {
 	_, _, _, err = suite.conn.Voltages()
 	suite.NoError(err, "Voltages")
 }
+
+func (suite *connTestSuite) TestAuthorization() {
+	suite.conn.remoteIdTag = "evcc"
+	suite.conn.SetAuthorizer(func(idTag string) bool {
+		return idTag == "accepted"
+	})
+
+	for _, tc := range []struct {
+		idTag  string
+		status types.AuthorizationStatus
+	}{
+		{"blocked", types.AuthorizationStatusInvalid},
+		{"accepted", types.AuthorizationStatusAccepted},
+		{"evcc", types.AuthorizationStatusAccepted}, // remote start
+	} {
+		res, err := suite.cp.OnAuthorize(&core.AuthorizeRequest{IdTag: tc.idTag})
+		suite.Require().NoError(err)
+		suite.Equal(tc.status, res.IdTagInfo.Status, tc.idTag)
+
+		// start transaction
+		suite.Equal(tc.status, suite.conn.authorizationStatus(tc.idTag), tc.idTag)
+	}
+}
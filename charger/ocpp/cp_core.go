@@ -2,6 +2,8 @@ package ocpp
 
 import (
 	"errors"
+	"maps"
+	"slices"
 
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
@@ -13,6 +15,32 @@ var (
 	ErrInvalidTransaction = errors.New("invalid transaction")
 )
 
+func (cp *CP) OnAuthorize(request *core.AuthorizeRequest) (*core.AuthorizeConfirmation, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	cp.mu.RLock()
+	conns := slices.Collect(maps.Values(cp.connectors))
+	cp.mu.RUnlock()
+
+	// id tag is not bound to a connector yet, accept if any connector accepts
+	status := types.AuthorizationStatusAccepted
+	for _, conn := range conns {
+		if status = conn.authorizationStatus(request.IdTag); status == types.AuthorizationStatusAccepted {
+			break
+		}
+	}
+
+	res := &core.AuthorizeConfirmation{
+		IdTagInfo: &types.IdTagInfo{
+			Status: status,
+		},
+	}
+
+	return res, nil
+}
+
 func (cp *CP) OnBootNotification(request *core.BootNotificationRequest) (*core.BootNotificationConfirmation, error) {
 	res := &core.BootNotificationConfirmation{
 		CurrentTime: types.Now(),
@@ -11,8 +11,9 @@ import (
 )
 
 type CS struct {
-	mu  sync.Mutex
-	log *util.Logger
+	mu    sync.Mutex
+	log   *util.Logger
+	proxy *proxy
 	ocpp16.CentralSystem
 	cps   map[string]*CP
 	init  map[string]*sync.Mutex
@@ -47,6 +48,11 @@ func (cs *CS) register(id string, new *CP) error {
 	return nil
 }
 
+// SetBackend forwards the charge point's messages to its existing backend
+func (cs *CS) SetBackend(id, uri string) {
+	cs.proxy.SetBackend(id, uri)
+}
+
 // errorHandler logs error channel
 func (cs *CS) errorHandler(errC <-chan error) {
 	for err := range errC {
@@ -27,19 +27,22 @@ func Instance() *CS {
 		server := ws.NewServer()
 		server.SetCheckOriginHandler(func(r *http.Request) bool { return true })
 
+		proxy := newProxy(log, server)
+
 		dispatcher := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
 		dispatcher.SetTimeout(Timeout)
 
-		endpoint := ocppj.NewServer(server, dispatcher, nil, core.Profile, remotetrigger.Profile, smartcharging.Profile)
+		endpoint := ocppj.NewServer(proxy, dispatcher, nil, core.Profile, remotetrigger.Profile, smartcharging.Profile)
 		endpoint.SetInvalidMessageHook(func(client ws.Channel, err *ocpp.Error, rawMessage string, parsedFields []interface{}) *ocpp.Error {
 			log.ERROR.Printf("%v (%s)", err, rawMessage)
 			return nil
 		})
 
-		cs := ocpp16.NewCentralSystem(endpoint, server)
+		cs := ocpp16.NewCentralSystem(endpoint, proxy)
 
 		instance = &CS{
 			log:           log,
+			proxy:         proxy,
 			cps:           make(map[string]*CP),
 			init:          make(map[string]*sync.Mutex),
 			CentralSystem: cs,
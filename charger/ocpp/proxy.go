@@ -0,0 +1,281 @@
+package ocpp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+// upstream is the connection to a charge point's backend
+type upstream struct {
+	uri     string
+	client  ws.WsClient
+	pending map[string]string // backend call ids by proxy call id awaiting the charge point's response
+	seq     int
+}
+
+// proxy wraps the websocket server and forwards messages between charge points and their
+// existing backends. Charge point calls are handled by evcc and mirrored to the backend, backend
+// calls are passed to the charge point. Charging profiles remain under evcc's control.
+type proxy struct {
+	ws.WsServer
+	log *util.Logger
+
+	mu        sync.Mutex
+	handler   func(ws.Channel, []byte) error
+	upstreams map[string]*upstream
+	connected map[string]bool
+}
+
+func newProxy(log *util.Logger, server ws.WsServer) *proxy {
+	return &proxy{
+		WsServer:  server,
+		log:       log,
+		upstreams: make(map[string]*upstream),
+		connected: make(map[string]bool),
+	}
+}
+
+// SetBackend registers the backend uri for the charge point id
+func (p *proxy) SetBackend(id, uri string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.upstreams[id]; ok {
+		return
+	}
+
+	up := &upstream{uri: strings.TrimSuffix(uri, "/"), pending: make(map[string]string)}
+	p.upstreams[id] = up
+
+	if p.connected[id] {
+		p.dial(id, up)
+	}
+}
+
+// dial connects the upstream, reconnecting on failure. Must be called with lock held.
+func (p *proxy) dial(id string, up *upstream) {
+	client := ws.NewClient()
+	client.SetRequestedSubProtocol(types.V16Subprotocol)
+	client.SetMessageHandler(func(data []byte) error {
+		p.fromBackend(id, data)
+		return nil
+	})
+
+	p.log.DEBUG.Printf("connecting backend for %s: %s", id, up.uri)
+
+	up.client = client
+	go client.StartWithRetries(up.uri + "/" + id)
+}
+
+// SetNewClientHandler implements ws.WsServer
+func (p *proxy) SetNewClientHandler(handler func(ws.Channel)) {
+	p.WsServer.SetNewClientHandler(func(ch ws.Channel) {
+		p.mu.Lock()
+		p.connected[ch.ID()] = true
+		if up, ok := p.upstreams[ch.ID()]; ok && up.client == nil {
+			p.dial(ch.ID(), up)
+		}
+		p.mu.Unlock()
+
+		handler(ch)
+	})
+}
+
+// SetDisconnectedClientHandler implements ws.WsServer
+func (p *proxy) SetDisconnectedClientHandler(handler func(ws.Channel)) {
+	p.WsServer.SetDisconnectedClientHandler(func(ch ws.Channel) {
+		p.mu.Lock()
+		delete(p.connected, ch.ID())
+		if up, ok := p.upstreams[ch.ID()]; ok && up.client != nil {
+			up.client.Stop()
+			up.client = nil
+			clear(up.pending)
+		}
+		p.mu.Unlock()
+
+		handler(ch)
+	})
+}
+
+// SetMessageHandler implements ws.WsServer
+func (p *proxy) SetMessageHandler(handler func(ws.Channel, []byte) error) {
+	p.handler = handler
+	p.WsServer.SetMessageHandler(p.fromChargePoint)
+}
+
+// fromChargePoint handles charge point messages. Responses to backend calls are forwarded to
+// the backend only, everything else is handled by evcc and calls are mirrored to the backend.
+func (p *proxy) fromChargePoint(ch ws.Channel, data []byte) error {
+	typ, id, _, ok := parseMessage(data)
+
+	p.mu.Lock()
+	up := p.upstreams[ch.ID()]
+	if up == nil || up.client == nil || !ok {
+		p.mu.Unlock()
+		return p.handler(ch, data)
+	}
+
+	backendID, pending := up.pending[id]
+	if typ != ocppj.CALL && pending {
+		delete(up.pending, id)
+	}
+	client := up.client
+	p.mu.Unlock()
+
+	if typ == ocppj.CALL || pending {
+		msg := data
+		if pending {
+			var err error
+			if msg, err = withMessageID(data, backendID); err != nil {
+				return err
+			}
+		}
+
+		if err := client.Write(msg); err != nil {
+			p.log.DEBUG.Printf("backend %s: %v", ch.ID(), err)
+		}
+	}
+
+	if pending {
+		return nil
+	}
+
+	return p.handler(ch, data)
+}
+
+// fromBackend handles backend messages. Responses to mirrored calls are dropped as evcc has
+// already responded, calls are passed to the charge point unless they modify charging profiles.
+// Backend call ids are replaced by proxy call ids to avoid collisions with evcc's own calls.
+func (p *proxy) fromBackend(cpID string, data []byte) {
+	typ, id, action, ok := parseMessage(data)
+	if !ok || typ != ocppj.CALL {
+		return
+	}
+
+	if res, intercept := profileResponse(action, data); intercept {
+		p.log.DEBUG.Printf("backend %s: ignoring %s", cpID, action)
+		p.respond(cpID, id, res)
+		return
+	}
+
+	p.mu.Lock()
+	up, ok := p.upstreams[cpID]
+	if !ok {
+		p.mu.Unlock()
+		return
+	}
+
+	up.seq++
+	proxyID := fmt.Sprintf("proxy-%d", up.seq)
+	up.pending[proxyID] = id
+	p.mu.Unlock()
+
+	msg, err := withMessageID(data, proxyID)
+	if err != nil {
+		return
+	}
+
+	if err := p.WsServer.Write(cpID, msg); err != nil {
+		p.log.DEBUG.Printf("charge point %s: %v", cpID, err)
+	}
+}
+
+// respond sends a call result to the backend
+func (p *proxy) respond(cpID, id string, res any) {
+	b, err := json.Marshal([]any{ocppj.CALL_RESULT, id, res})
+	if err != nil {
+		return
+	}
+
+	p.mu.Lock()
+	var client ws.WsClient
+	if up, ok := p.upstreams[cpID]; ok {
+		client = up.client
+	}
+	p.mu.Unlock()
+
+	if client != nil {
+		if err := client.Write(b); err != nil {
+			p.log.DEBUG.Printf("backend %s: %v", cpID, err)
+		}
+	}
+}
+
+// parseMessage returns type, id and action of an OCPP-J message
+func parseMessage(data []byte) (ocppj.MessageType, string, string, bool) {
+	var msg []json.RawMessage
+	if err := json.Unmarshal(data, &msg); err != nil || len(msg) < 3 {
+		return 0, "", "", false
+	}
+
+	var (
+		typ    ocppj.MessageType
+		id     string
+		action string
+	)
+
+	if json.Unmarshal(msg[0], &typ) != nil || json.Unmarshal(msg[1], &id) != nil {
+		return 0, "", "", false
+	}
+
+	if typ == ocppj.CALL && json.Unmarshal(msg[2], &action) != nil {
+		return 0, "", "", false
+	}
+
+	return typ, id, action, true
+}
+
+// withMessageID returns the OCPP-J message with its id replaced
+func withMessageID(data []byte, id string) ([]byte, error) {
+	var msg []json.RawMessage
+	if err := json.Unmarshal(data, &msg); err != nil || len(msg) < 2 {
+		return nil, fmt.Errorf("invalid message: %s", data)
+	}
+
+	b, err := json.Marshal(id)
+	if err != nil {
+		return nil, err
+	}
+	msg[1] = b
+
+	return json.Marshal(msg)
+}
+
+// profileResponse returns the response for backend charging profile calls that would override
+// evcc's transaction profiles. Charge point max profiles are passed through and limit evcc in addition.
+func profileResponse(action string, data []byte) (any, bool) {
+	var msg []json.RawMessage
+	if err := json.Unmarshal(data, &msg); err != nil || len(msg) < 4 {
+		return nil, false
+	}
+
+	switch action {
+	case smartcharging.SetChargingProfileFeatureName:
+		var req smartcharging.SetChargingProfileRequest
+		if err := json.Unmarshal(msg[3], &req); err != nil || req.ChargingProfile == nil ||
+			req.ChargingProfile.ChargingProfilePurpose == types.ChargingProfilePurposeChargePointMaxProfile {
+			return nil, false
+		}
+
+		return smartcharging.NewSetChargingProfileConfirmation(smartcharging.ChargingProfileStatusRejected), true
+
+	case smartcharging.ClearChargingProfileFeatureName:
+		var req smartcharging.ClearChargingProfileRequest
+		if err := json.Unmarshal(msg[3], &req); err != nil ||
+			req.ChargingProfilePurpose == types.ChargingProfilePurposeChargePointMaxProfile {
+			return nil, false
+		}
+
+		return smartcharging.NewClearChargingProfileConfirmation(smartcharging.ClearChargingProfileStatusUnknown), true
+	}
+
+	return nil, false
+}
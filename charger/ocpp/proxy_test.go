@@ -0,0 +1,108 @@
+package ocpp
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServer struct {
+	ws.WsServer
+	handler func(ws.Channel, []byte) error
+	written []string
+}
+
+func (s *testServer) SetMessageHandler(handler func(ws.Channel, []byte) error) { s.handler = handler }
+
+func (s *testServer) Write(_ string, data []byte) error {
+	s.written = append(s.written, string(data))
+	return nil
+}
+
+type testClient struct {
+	ws.WsClient
+	written []string
+}
+
+func (c *testClient) Write(data []byte) error {
+	c.written = append(c.written, string(data))
+	return nil
+}
+
+type testChannel struct {
+	ws.Channel
+	id string
+}
+
+func (c testChannel) ID() string { return c.id }
+
+func TestProxy(t *testing.T) {
+	server := new(testServer)
+	client := new(testClient)
+
+	var handled []string
+
+	p := newProxy(util.NewLogger("foo"), server)
+	p.SetMessageHandler(func(_ ws.Channel, data []byte) error {
+		handled = append(handled, string(data))
+		return nil
+	})
+	p.upstreams["cp"] = &upstream{client: client, pending: make(map[string]string)}
+
+	ch := testChannel{id: "cp"}
+
+	// charge point call is handled and mirrored
+	call := `[2,"1","Heartbeat",{}]`
+	require.NoError(t, server.handler(ch, []byte(call)))
+	assert.Equal(t, []string{call}, handled)
+	assert.Equal(t, []string{call}, client.written)
+
+	// backend response to mirrored call is dropped
+	p.fromBackend("cp", []byte(`[3,"1",{"currentTime":"2024-01-01T00:00:00Z"}]`))
+	assert.Empty(t, server.written)
+
+	// backend call is passed to charge point using a proxy id, response returned to backend only
+	p.fromBackend("cp", []byte(`[2,"e1","TriggerMessage",{"requestedMessage":"StatusNotification"}]`))
+	assert.Equal(t, []string{`[2,"proxy-1","TriggerMessage",{"requestedMessage":"StatusNotification"}]`}, server.written)
+
+	require.NoError(t, server.handler(ch, []byte(`[3,"proxy-1",{"status":"Accepted"}]`)))
+	assert.Len(t, handled, 1)
+	assert.Equal(t, `[3,"e1",{"status":"Accepted"}]`, client.written[1])
+
+	// evcc's own responses are not forwarded, even if the backend used the same id
+	own := `[3,"e1",{"status":"Accepted"}]`
+	require.NoError(t, server.handler(ch, []byte(own)))
+	assert.Equal(t, own, handled[1])
+	assert.Len(t, client.written, 2)
+
+	// charge points without backend are handled directly
+	require.NoError(t, server.handler(testChannel{id: "other"}, []byte(call)))
+	assert.Len(t, handled, 3)
+	assert.Len(t, client.written, 2)
+}
+
+func TestProxyChargingProfiles(t *testing.T) {
+	server := new(testServer)
+	client := new(testClient)
+
+	p := newProxy(util.NewLogger("foo"), server)
+	p.upstreams["cp"] = &upstream{client: client, pending: make(map[string]string)}
+
+	// transaction profiles are rejected
+	p.fromBackend("cp", []byte(`[2,"b1","SetChargingProfile",{"connectorId":1,"csChargingProfiles":{"chargingProfileId":1,"stackLevel":1,"chargingProfilePurpose":"TxDefaultProfile","chargingProfileKind":"Absolute","chargingSchedule":{"chargingRateUnit":"A","chargingSchedulePeriod":[{"startPeriod":0,"limit":6}]}}}]`))
+	assert.Empty(t, server.written)
+	assert.Equal(t, []string{`[3,"b1",{"status":"Rejected"}]`}, client.written)
+
+	// clearing all profiles is refused
+	p.fromBackend("cp", []byte(`[2,"b2","ClearChargingProfile",{}]`))
+	assert.Empty(t, server.written)
+	assert.Equal(t, `[3,"b2",{"status":"Unknown"}]`, client.written[1])
+
+	// charge point max profiles are passed through
+	max := `"SetChargingProfile",{"connectorId":0,"csChargingProfiles":{"chargingProfileId":2,"stackLevel":0,"chargingProfilePurpose":"ChargePointMaxProfile","chargingProfileKind":"Absolute","chargingSchedule":{"chargingRateUnit":"A","chargingSchedulePeriod":[{"startPeriod":0,"limit":16}]}}}]`
+	p.fromBackend("cp", []byte(`[2,"b3",`+max))
+	assert.Equal(t, []string{`[2,"proxy-1",` + max}, server.written)
+}
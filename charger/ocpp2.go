@@ -0,0 +1,301 @@
+package charger
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/ocpp2"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/sponsor"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// OCPP2 is an OCPP 2.0.1 charger. Charge plans are sent as charging schedule which the
+// station negotiates with ISO 15118 capable vehicles.
+// Bidirectional ISO 15118-20 discharge set-points are not supported, OCPP 2.0.1 schedule limits
+// cannot be negative and require the OCPP 2.1 extensions.
+type OCPP2 struct {
+	log      *util.Logger
+	station  *ocpp2.Station
+	evse     int
+	enabled  bool
+	current  float64
+	schedule api.ChargeSchedule
+	lp       loadpoint.API
+}
+
+// ocpp2ProfileId is the id of the charging profile managed by evcc
+const ocpp2ProfileId = 1
+
+func init() {
+	registry.Add("ocpp2", NewOCPP2FromConfig)
+}
+
+// NewOCPP2FromConfig creates an OCPP 2.0.1 charger from generic config
+func NewOCPP2FromConfig(other map[string]interface{}) (api.Charger, error) {
+	cc := struct {
+		StationId      string
+		Evse           int
+		ConnectTimeout time.Duration
+	}{
+		Evse:           1,
+		ConnectTimeout: 5 * time.Minute,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.StationId == "" {
+		return nil, errors.New("missing station id")
+	}
+
+	c, err := NewOCPP2(cc.StationId, cc.Evse, cc.ConnectTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sponsor.IsAuthorized() {
+		return nil, api.ErrSponsorRequired
+	}
+
+	return c, nil
+}
+
+// NewOCPP2 creates an OCPP 2.0.1 charger
+func NewOCPP2(id string, evse int, connectTimeout time.Duration) (*OCPP2, error) {
+	log := util.NewLogger(fmt.Sprintf("%s-%d", id, evse))
+
+	station := ocpp2.Instance().Station(id)
+
+	if !station.Connected() {
+		log.DEBUG.Printf("waiting for charging station: %v", connectTimeout)
+
+		select {
+		case <-time.After(connectTimeout):
+			return nil, api.ErrTimeout
+		case <-station.HasConnected():
+		}
+	}
+
+	c := &OCPP2{
+		log:     log,
+		station: station,
+		evse:    evse,
+	}
+
+	return c, nil
+}
+
+// Status implements the api.Charger interface
+func (c *OCPP2) Status() (api.ChargeStatus, error) {
+	status, state, err := c.station.Status(c.evse)
+	if err != nil {
+		return api.StatusNone, err
+	}
+
+	switch status {
+	case
+		availability.ConnectorStatusAvailable,   // "Available"
+		availability.ConnectorStatusUnavailable: // "Unavailable"
+		return api.StatusA, nil
+	case
+		availability.ConnectorStatusOccupied: // "Occupied"
+		if state == transactions.ChargingStateCharging {
+			return api.StatusC, nil
+		}
+		return api.StatusB, nil
+	case
+		availability.ConnectorStatusReserved, // "Reserved"
+		availability.ConnectorStatusFaulted:  // "Faulted"
+		return api.StatusF, fmt.Errorf("connector status: %s", status)
+	default:
+		return api.StatusNone, fmt.Errorf("invalid connector status: %s", status)
+	}
+}
+
+// Enabled implements the api.Charger interface
+func (c *OCPP2) Enabled() (bool, error) {
+	if _, state, err := c.station.Status(c.evse); err == nil {
+		switch state {
+		case transactions.ChargingStateSuspendedEVSE:
+			return false, nil
+		case transactions.ChargingStateCharging, transactions.ChargingStateSuspendedEV:
+			return true, nil
+		}
+	}
+
+	// fallback to cached value as last resort
+	return c.enabled, nil
+}
+
+// Enable implements the api.Charger interface
+func (c *OCPP2) Enable(enable bool) error {
+	var current float64
+	if enable {
+		current = c.current
+	}
+
+	err := c.setChargingProfile(current, c.schedule)
+	if err == nil {
+		c.enabled = enable
+	}
+
+	return err
+}
+
+// MaxCurrent implements the api.Charger interface
+func (c *OCPP2) MaxCurrent(current int64) error {
+	return c.MaxCurrentMillis(float64(current))
+}
+
+var _ api.ChargerEx = (*OCPP2)(nil)
+
+// MaxCurrentMillis implements the api.ChargerEx interface
+func (c *OCPP2) MaxCurrentMillis(current float64) error {
+	err := c.setChargingProfile(current, c.schedule)
+	if err == nil {
+		c.current = current
+	}
+	return err
+}
+
+var _ api.ChargeScheduler = (*OCPP2)(nil)
+
+// SetChargeSchedule implements the api.ChargeScheduler interface
+func (c *OCPP2) SetChargeSchedule(schedule api.ChargeSchedule) error {
+	// slots that have already started are covered by the current limit
+	schedule = schedule.Future(time.Now())
+
+	if schedule.Equal(c.schedule) {
+		return nil
+	}
+
+	for _, slot := range schedule {
+		if slot.Power < 0 {
+			return errors.New("discharging requires OCPP 2.1")
+		}
+	}
+
+	var current float64
+	if c.enabled {
+		current = c.current
+	}
+
+	err := c.setChargingProfile(current, schedule)
+	if err == nil {
+		c.schedule = schedule
+	}
+
+	return err
+}
+
+// setChargingProfile sets the TxDefaultProfile with given current and charge schedule
+func (c *OCPP2) setChargingProfile(current float64, schedule api.ChargeSchedule) error {
+	profile := c.chargingProfile(time.Now(), current, schedule)
+
+	err := c.station.SetChargingProfileRequest(c.evse, profile)
+	if err != nil {
+		err = fmt.Errorf("set charging profile: %w", err)
+	}
+
+	return err
+}
+
+// chargingProfile returns a TxDefaultProfile limited to the current until the first planned slot.
+// Planned slots are added as future periods for the station to negotiate with the vehicle.
+func (c *OCPP2) chargingProfile(now time.Time, current float64, schedule api.ChargeSchedule) *types.ChargingProfile {
+	phases := 3
+	if c.lp != nil && c.lp.GetPhases() > 0 {
+		phases = c.lp.GetPhases()
+	}
+
+	limit := func(current float64) float64 {
+		return math.Trunc(10*current) / 10
+	}
+
+	periods := []types.ChargingSchedulePeriod{types.NewChargingSchedulePeriod(0, limit(current))}
+
+	for i, slot := range schedule {
+		start := int(slot.Start.Sub(now).Seconds())
+		slotLimit := limit(slot.Power / (230 * float64(phases)))
+
+		// merge with previous slot's end
+		if last := &periods[len(periods)-1]; last.StartPeriod == start {
+			last.Limit = slotLimit
+		} else {
+			periods = append(periods, types.NewChargingSchedulePeriod(start, slotLimit))
+		}
+
+		// back to current limit unless the next slot follows immediately
+		if i == len(schedule)-1 || !schedule[i+1].Start.Equal(slot.End) {
+			periods = append(periods, types.NewChargingSchedulePeriod(int(slot.End.Sub(now).Seconds()), limit(current)))
+		}
+	}
+
+	return &types.ChargingProfile{
+		ID:                     ocpp2ProfileId,
+		ChargingProfilePurpose: types.ChargingProfilePurposeTxDefaultProfile,
+		ChargingProfileKind:    types.ChargingProfileKindAbsolute,
+		ChargingSchedule: []types.ChargingSchedule{{
+			ID:                     ocpp2ProfileId,
+			StartSchedule:          types.NewDateTime(now),
+			ChargingRateUnit:       types.ChargingRateUnitAmperes,
+			ChargingSchedulePeriod: periods,
+		}},
+	}
+}
+
+var _ api.Meter = (*OCPP2)(nil)
+
+// CurrentPower implements the api.Meter interface
+func (c *OCPP2) CurrentPower() (float64, error) {
+	return c.station.Measurement(c.evse, types.MeasurandPowerActiveImport)
+}
+
+var _ api.MeterEnergy = (*OCPP2)(nil)
+
+// TotalEnergy implements the api.MeterEnergy interface
+func (c *OCPP2) TotalEnergy() (float64, error) {
+	f, err := c.station.Measurement(c.evse, types.MeasurandEnergyActiveImportRegister)
+	return f / 1e3, err
+}
+
+var _ api.Diagnosis = (*OCPP2)(nil)
+
+// Diagnose implements the api.Diagnosis interface
+func (c *OCPP2) Diagnose() {
+	fmt.Printf("\tCharging Station ID: %s\n", c.station.ID())
+
+	if res := c.station.BootNotificationResult; res != nil {
+		fmt.Printf("\tBoot Notification:\n")
+		fmt.Printf("\t\tVendorName: %s\n", res.ChargingStation.VendorName)
+		fmt.Printf("\t\tModel: %s\n", res.ChargingStation.Model)
+		fmt.Printf("\t\tSerialNumber: %s\n", res.ChargingStation.SerialNumber)
+		fmt.Printf("\t\tFirmwareVersion: %s\n", res.ChargingStation.FirmwareVersion)
+	}
+
+	if needs, err := c.station.ChargingNeeds(c.evse); err == nil {
+		fmt.Printf("\tEV Charging Needs:\n")
+		fmt.Printf("\t\tRequestedEnergyTransfer: %s\n", needs.RequestedEnergyTransfer)
+		if needs.DepartureTime != nil {
+			fmt.Printf("\t\tDepartureTime: %s\n", needs.DepartureTime.Local())
+		}
+	}
+}
+
+var _ loadpoint.Controller = (*OCPP2)(nil)
+
+// LoadpointControl implements loadpoint.Controller
+func (c *OCPP2) LoadpointControl(lp loadpoint.API) {
+	c.lp = lp
+
+	// decide on id tokens presented at the charger
+	c.station.SetAuthorizer(c.evse, lp.AuthorizeIdentifier)
+}
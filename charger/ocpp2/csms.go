@@ -0,0 +1,53 @@
+package ocpp2
+
+import (
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+)
+
+var Timeout = 30 * time.Second // default request / response timeout on protocol level
+
+// CSMS is the OCPP 2.0.1 charging station management system
+type CSMS struct {
+	mu  sync.Mutex
+	log *util.Logger
+	ocpp201.CSMS
+	stations map[string]*Station
+}
+
+// errorHandler logs error channel
+func (cs *CSMS) errorHandler(errC <-chan error) {
+	for err := range errC {
+		cs.log.ERROR.Println(err)
+	}
+}
+
+// Station returns the charging station for given id. Stations are created on first
+// use, either by connecting or by being configured.
+func (cs *CSMS) Station(id string) *Station {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	station, ok := cs.stations[id]
+	if !ok {
+		station = NewStation(cs.log, id)
+		cs.stations[id] = station
+	}
+
+	return station
+}
+
+// NewChargingStation implements ocpp201.ChargingStationConnectionHandler
+func (cs *CSMS) NewChargingStation(chargingStation ocpp201.ChargingStationConnection) {
+	cs.log.DEBUG.Printf("charging station connected: %s", chargingStation.ID())
+	cs.Station(chargingStation.ID()).connect(true)
+}
+
+// ChargingStationDisconnected implements ocpp201.ChargingStationConnectionHandler
+func (cs *CSMS) ChargingStationDisconnected(chargingStation ocpp201.ChargingStationConnection) {
+	cs.log.DEBUG.Printf("charging station disconnected: %s", chargingStation.ID())
+	cs.Station(chargingStation.ID()).connect(false)
+}
@@ -0,0 +1,83 @@
+package ocpp2
+
+import (
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// station actions
+
+func (cs *CSMS) OnAuthorize(id string, request *authorization.AuthorizeRequest) (*authorization.AuthorizeResponse, error) {
+	status := cs.Station(id).AuthorizationStatus(0, request.IdToken.IdToken)
+	return authorization.NewAuthorizationResponse(*types.NewIdTokenInfo(status)), nil
+}
+
+func (cs *CSMS) OnBootNotification(id string, request *provisioning.BootNotificationRequest) (*provisioning.BootNotificationResponse, error) {
+	cs.Station(id).OnBootNotification(request)
+
+	return provisioning.NewBootNotificationResponse(types.Now(), int(Timeout.Seconds()), provisioning.RegistrationStatusAccepted), nil
+}
+
+func (cs *CSMS) OnNotifyReport(id string, request *provisioning.NotifyReportRequest) (*provisioning.NotifyReportResponse, error) {
+	return provisioning.NewNotifyReportResponse(), nil
+}
+
+func (cs *CSMS) OnHeartbeat(id string, request *availability.HeartbeatRequest) (*availability.HeartbeatResponse, error) {
+	return availability.NewHeartbeatResponse(*types.Now()), nil
+}
+
+func (cs *CSMS) OnStatusNotification(id string, request *availability.StatusNotificationRequest) (*availability.StatusNotificationResponse, error) {
+	cs.Station(id).OnStatusNotification(request)
+
+	return availability.NewStatusNotificationResponse(), nil
+}
+
+func (cs *CSMS) OnMeterValues(id string, request *meter.MeterValuesRequest) (*meter.MeterValuesResponse, error) {
+	cs.Station(id).OnMeterValues(request)
+
+	return meter.NewMeterValuesResponse(), nil
+}
+
+func (cs *CSMS) OnTransactionEvent(id string, request *transactions.TransactionEventRequest) (*transactions.TransactionEventResponse, error) {
+	cs.Station(id).OnTransactionEvent(request)
+
+	res := transactions.NewTransactionEventResponse()
+	if request.IDToken != nil {
+		var evse int
+		if request.Evse != nil {
+			evse = request.Evse.ID
+		}
+		res.IDTokenInfo = types.NewIdTokenInfo(cs.Station(id).AuthorizationStatus(evse, request.IDToken.IdToken))
+	}
+
+	return res, nil
+}
+
+func (cs *CSMS) OnNotifyEVChargingNeeds(id string, request *smartcharging.NotifyEVChargingNeedsRequest) (*smartcharging.NotifyEVChargingNeedsResponse, error) {
+	cs.Station(id).OnNotifyEVChargingNeeds(request)
+
+	return smartcharging.NewNotifyEVChargingNeedsResponse(smartcharging.EVChargingNeedsStatusAccepted), nil
+}
+
+func (cs *CSMS) OnNotifyEVChargingSchedule(id string, request *smartcharging.NotifyEVChargingScheduleRequest) (*smartcharging.NotifyEVChargingScheduleResponse, error) {
+	cs.log.DEBUG.Printf("%s: ev charging schedule for evse %d: %d periods", id, request.EvseID, len(request.ChargingSchedule.ChargingSchedulePeriod))
+
+	return smartcharging.NewNotifyEVChargingScheduleResponse(types.GenericStatusAccepted), nil
+}
+
+func (cs *CSMS) OnClearedChargingLimit(id string, request *smartcharging.ClearedChargingLimitRequest) (*smartcharging.ClearedChargingLimitResponse, error) {
+	return smartcharging.NewClearedChargingLimitResponse(), nil
+}
+
+func (cs *CSMS) OnNotifyChargingLimit(id string, request *smartcharging.NotifyChargingLimitRequest) (*smartcharging.NotifyChargingLimitResponse, error) {
+	return smartcharging.NewNotifyChargingLimitResponse(), nil
+}
+
+func (cs *CSMS) OnReportChargingProfiles(id string, request *smartcharging.ReportChargingProfilesRequest) (*smartcharging.ReportChargingProfilesResponse, error) {
+	return smartcharging.NewReportChargingProfilesResponse(), nil
+}
@@ -0,0 +1,76 @@
+package ocpp2
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+var (
+	once     sync.Once
+	instance *CSMS
+)
+
+// Port is the OCPP 2.0.1 listen port. OCPP 1.6 uses port 8887.
+var Port = 8888
+
+func Instance() *CSMS {
+	once.Do(func() {
+		log := util.NewLogger("ocpp2")
+
+		server := ws.NewServer()
+		server.SetCheckOriginHandler(func(r *http.Request) bool { return true })
+
+		dispatcher := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
+		dispatcher.SetTimeout(Timeout)
+
+		endpoint := ocppj.NewServer(server, dispatcher, nil,
+			authorization.Profile, availability.Profile, meter.Profile,
+			provisioning.Profile, smartcharging.Profile, transactions.Profile)
+		endpoint.SetInvalidMessageHook(func(client ws.Channel, err *ocpp.Error, rawMessage string, parsedFields []interface{}) *ocpp.Error {
+			log.ERROR.Printf("%v (%s)", err, rawMessage)
+			return nil
+		})
+
+		csms := ocpp201.NewCSMS(endpoint, server)
+
+		instance = &CSMS{
+			log:      log,
+			stations: make(map[string]*Station),
+			CSMS:     csms,
+		}
+
+		csms.SetAuthorizationHandler(instance)
+		csms.SetAvailabilityHandler(instance)
+		csms.SetMeterHandler(instance)
+		csms.SetProvisioningHandler(instance)
+		csms.SetSmartChargingHandler(instance)
+		csms.SetTransactionsHandler(instance)
+		csms.SetNewChargingStationHandler(instance.NewChargingStation)
+		csms.SetChargingStationDisconnectedHandler(instance.ChargingStationDisconnected)
+
+		go instance.errorHandler(csms.Errors())
+		go csms.Start(Port, "/{ws}")
+
+		// wait for server to start
+		for range time.Tick(10 * time.Millisecond) {
+			if dispatcher.IsRunning() {
+				break
+			}
+		}
+	})
+
+	return instance
+}
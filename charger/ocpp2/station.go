@@ -0,0 +1,277 @@
+package ocpp2
+
+import (
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// Station is a charging station with one or more EVSEs
+type Station struct {
+	mu  sync.Mutex
+	log *util.Logger
+	id  string
+
+	connected bool
+	connectC  chan struct{}
+
+	BootNotificationResult *provisioning.BootNotificationRequest
+
+	evses       map[int]*evse
+	authorizers map[int]func(idToken string) bool // decide on id tokens presented at the evse
+}
+
+// evse is the state of a single EVSE as reported by the station
+type evse struct {
+	status        availability.ConnectorStatus
+	chargingState transactions.ChargingState
+	txnId         string
+	measurements  map[types.Measurand]float64
+	needs         *smartcharging.ChargingNeeds
+}
+
+func NewStation(log *util.Logger, id string) *Station {
+	return &Station{
+		log:      log,
+		id:       id,
+		connectC: make(chan struct{}, 1),
+		evses:    make(map[int]*evse),
+
+		authorizers: make(map[int]func(string) bool),
+	}
+}
+
+// SetAuthorizer sets the function deciding on id tokens presented at the evse
+func (s *Station) SetAuthorizer(evse int, fn func(idToken string) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authorizers[evse] = fn
+}
+
+// AuthorizationStatus returns the status of an id token presented at the evse, or at any evse if zero
+func (s *Station) AuthorizationStatus(evse int, idToken string) types.AuthorizationStatus {
+	s.mu.Lock()
+	var fns []func(string) bool
+	for id, fn := range s.authorizers {
+		if evse == 0 || id == evse {
+			fns = append(fns, fn)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(fns) == 0 {
+		return types.AuthorizationStatusAccepted
+	}
+
+	for _, fn := range fns {
+		if fn(idToken) {
+			return types.AuthorizationStatusAccepted
+		}
+	}
+
+	return types.AuthorizationStatusInvalid
+}
+
+func (s *Station) ID() string {
+	return s.id
+}
+
+// HasConnected returns a channel that receives when the station has connected
+func (s *Station) HasConnected() <-chan struct{} {
+	return s.connectC
+}
+
+func (s *Station) Connected() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.connected
+}
+
+func (s *Station) connect(connected bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.connected = connected
+
+	if connected {
+		select {
+		case s.connectC <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// evse returns the EVSE state, the station lock must be held
+func (s *Station) evse(id int) *evse {
+	e, ok := s.evses[id]
+	if !ok {
+		e = &evse{measurements: make(map[types.Measurand]float64)}
+		s.evses[id] = e
+	}
+	return e
+}
+
+// evseByTransaction returns the EVSE id running the transaction, the station lock must be held
+func (s *Station) evseByTransaction(txnId string) (int, bool) {
+	for id, e := range s.evses {
+		if e.txnId == txnId {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Station) OnBootNotification(request *provisioning.BootNotificationRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.BootNotificationResult = request
+}
+
+func (s *Station) OnStatusNotification(request *availability.StatusNotificationRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evse(request.EvseID).status = request.ConnectorStatus
+}
+
+func (s *Station) OnMeterValues(request *meter.MeterValuesRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evse(request.EvseID).update(request.MeterValue)
+}
+
+func (s *Station) OnTransactionEvent(request *transactions.TransactionEventRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	txnId := request.TransactionInfo.TransactionID
+
+	// evse is only required for the first event of a transaction
+	id, ok := s.evseByTransaction(txnId)
+	if request.Evse != nil {
+		id, ok = request.Evse.ID, true
+	}
+
+	if !ok {
+		s.log.WARN.Printf("transaction %s: unknown evse", txnId)
+		return
+	}
+
+	e := s.evse(id)
+	e.txnId = txnId
+
+	if state := request.TransactionInfo.ChargingState; state != "" {
+		e.chargingState = state
+	}
+
+	if request.EventType == transactions.TransactionEventEnded {
+		e.txnId = ""
+		e.chargingState = transactions.ChargingStateIdle
+		e.needs = nil
+	}
+
+	e.update(request.MeterValue)
+}
+
+func (s *Station) OnNotifyEVChargingNeeds(request *smartcharging.NotifyEVChargingNeedsRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	needs := request.ChargingNeeds
+	s.evse(request.EvseID).needs = &needs
+
+	s.log.DEBUG.Printf("evse %d: ev charging needs: %s", request.EvseID, needs.RequestedEnergyTransfer)
+}
+
+// update updates the measurements from the given meter values
+func (e *evse) update(values []types.MeterValue) {
+	for _, mv := range values {
+		for _, sv := range mv.SampledValue {
+			// phase values are not used
+			if sv.Phase != "" {
+				continue
+			}
+
+			measurand := sv.Measurand
+			if measurand == "" {
+				measurand = types.MeasurandEnergyActiveImportRegister
+			}
+
+			e.measurements[measurand] = scale(sv.Value, sv.UnitOfMeasure)
+		}
+	}
+}
+
+// scale converts the value to its base unit, e.g. kWh to Wh
+func scale(f float64, unit *types.UnitOfMeasure) float64 {
+	if unit == nil {
+		return f
+	}
+
+	if unit.Multiplier != nil {
+		f *= math.Pow10(*unit.Multiplier)
+	}
+
+	if strings.HasPrefix(unit.Unit, "k") {
+		f *= 1e3
+	}
+
+	return f
+}
+
+// Status returns the EVSE's connector status and charging state
+func (s *Station) Status(id int) (availability.ConnectorStatus, transactions.ChargingState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.connected {
+		return "", "", api.ErrTimeout
+	}
+
+	e := s.evse(id)
+	if e.status == "" {
+		return "", "", api.ErrTimeout
+	}
+
+	return e.status, e.chargingState, nil
+}
+
+// Measurement returns the EVSE's last measurement in base units
+func (s *Station) Measurement(id int, measurand types.Measurand) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.connected {
+		return 0, api.ErrTimeout
+	}
+
+	if f, ok := s.evse(id).measurements[measurand]; ok {
+		return f, nil
+	}
+
+	return 0, api.ErrNotAvailable
+}
+
+// ChargingNeeds returns the charging needs the EV reported using ISO 15118
+func (s *Station) ChargingNeeds(id int) (smartcharging.ChargingNeeds, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if needs := s.evse(id).needs; needs != nil {
+		return *needs, nil
+	}
+
+	return smartcharging.ChargingNeeds{}, api.ErrNotAvailable
+}
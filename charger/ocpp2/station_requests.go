@@ -0,0 +1,38 @@
+package ocpp2
+
+import (
+	"errors"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/lorenzodonini/ocpp-go/ocpp"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/lorenzodonini/ocpp-go/ocppj"
+)
+
+// wait waits for a station roundtrip with timeout
+func wait(err error, rc chan error) error {
+	if err == nil {
+		err = <-rc
+
+		oe := new(ocpp.Error)
+		if errors.As(err, &oe) && oe.Code == ocppj.GenericError {
+			err = api.ErrTimeout
+		}
+	}
+	return err
+}
+
+func (s *Station) SetChargingProfileRequest(evseId int, profile *types.ChargingProfile) error {
+	rc := make(chan error, 1)
+
+	err := Instance().SetChargingProfile(s.id, func(request *smartcharging.SetChargingProfileResponse, err error) {
+		if err == nil && request != nil && request.Status != smartcharging.ChargingProfileStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, evseId, profile)
+
+	return wait(err, rc)
+}
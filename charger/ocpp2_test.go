@@ -0,0 +1,150 @@
+package charger
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/ocpp2"
+	"github.com/evcc-io/evcc/util"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ocpp2SmartChargingHandler struct {
+	profileC chan *types.ChargingProfile
+}
+
+func (h *ocpp2SmartChargingHandler) OnClearChargingProfile(request *smartcharging.ClearChargingProfileRequest) (*smartcharging.ClearChargingProfileResponse, error) {
+	return smartcharging.NewClearChargingProfileResponse(smartcharging.ClearChargingProfileStatusAccepted), nil
+}
+
+func (h *ocpp2SmartChargingHandler) OnGetChargingProfiles(request *smartcharging.GetChargingProfilesRequest) (*smartcharging.GetChargingProfilesResponse, error) {
+	return smartcharging.NewGetChargingProfilesResponse(smartcharging.GetChargingProfileStatusNoProfiles), nil
+}
+
+func (h *ocpp2SmartChargingHandler) OnGetCompositeSchedule(request *smartcharging.GetCompositeScheduleRequest) (*smartcharging.GetCompositeScheduleResponse, error) {
+	return smartcharging.NewGetCompositeScheduleResponse(smartcharging.GetCompositeScheduleStatusRejected, request.EvseID), nil
+}
+
+func (h *ocpp2SmartChargingHandler) OnSetChargingProfile(request *smartcharging.SetChargingProfileRequest) (*smartcharging.SetChargingProfileResponse, error) {
+	h.profileC <- request.ChargingProfile
+	return smartcharging.NewSetChargingProfileResponse(smartcharging.ChargingProfileStatusAccepted), nil
+}
+
+func TestOcpp2(t *testing.T) {
+	ocpp2.Timeout = 5 * time.Second
+	_ = ocpp2.Instance()
+
+	handler := &ocpp2SmartChargingHandler{
+		profileC: make(chan *types.ChargingProfile, 1),
+	}
+
+	cs := ocpp201.NewChargingStation("test-2", nil, nil)
+	cs.SetSmartChargingHandler(handler)
+	require.NoError(t, cs.Start(fmt.Sprintf("ws://localhost:%d", ocpp2.Port)))
+	defer cs.Stop()
+
+	_, err := cs.BootNotification(provisioning.BootReasonPowerUp, "model", "vendor")
+	require.NoError(t, err)
+
+	c, err := NewOCPP2("test-2", 1, 10*time.Second)
+	require.NoError(t, err)
+
+	// status not yet received
+	_, err = c.Status()
+	assert.ErrorIs(t, err, api.ErrTimeout)
+
+	_, err = cs.StatusNotification(types.Now(), availability.ConnectorStatusOccupied, 1, 1)
+	require.NoError(t, err)
+
+	_, err = cs.TransactionEvent(transactions.TransactionEventStarted, types.Now(), transactions.TriggerReasonChargingStateChanged, 0,
+		transactions.Transaction{TransactionID: "txn", ChargingState: transactions.ChargingStateCharging},
+		func(request *transactions.TransactionEventRequest) {
+			request.Evse = &types.EVSE{ID: 1}
+			request.MeterValue = []types.MeterValue{{
+				Timestamp: *types.Now(),
+				SampledValue: []types.SampledValue{
+					{Measurand: types.MeasurandPowerActiveImport, Value: 1000},
+					{Measurand: types.MeasurandEnergyActiveImportRegister, Value: 1.2, UnitOfMeasure: &types.UnitOfMeasure{Unit: "kWh"}},
+				},
+			}}
+		})
+	require.NoError(t, err)
+
+	status, err := c.Status()
+	require.NoError(t, err)
+	assert.Equal(t, api.StatusC, status)
+
+	power, err := c.CurrentPower()
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, power)
+
+	energy, err := c.TotalEnergy()
+	require.NoError(t, err)
+	assert.Equal(t, 1.2, energy)
+
+	// current limit
+	require.NoError(t, c.MaxCurrent(16))
+	profile := <-handler.profileC
+	assert.Equal(t, types.ChargingProfilePurposeTxDefaultProfile, profile.ChargingProfilePurpose)
+	assert.Equal(t, []types.ChargingSchedulePeriod{{StartPeriod: 0, Limit: 16}}, profile.ChargingSchedule[0].ChargingSchedulePeriod)
+
+	// discharging is not supported
+	now := time.Now()
+	assert.Error(t, c.SetChargeSchedule(api.ChargeSchedule{{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Power: -3680}}))
+
+	// charge schedule
+	require.NoError(t, c.SetChargeSchedule(api.ChargeSchedule{{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Power: 11040}}))
+	profile = <-handler.profileC
+	assert.Len(t, profile.ChargingSchedule[0].ChargingSchedulePeriod, 3)
+
+	// unchanged schedule is not sent again
+	require.NoError(t, c.SetChargeSchedule(api.ChargeSchedule{{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Power: 11040}}))
+	assert.Empty(t, handler.profileC)
+}
+
+func TestOcpp2ChargingProfile(t *testing.T) {
+	c := new(OCPP2)
+	now := time.Now()
+
+	profile := c.chargingProfile(now, 6, api.ChargeSchedule{
+		{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Power: 11040},
+		{Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour), Power: 6900},
+		{Start: now.Add(5 * time.Hour), End: now.Add(6 * time.Hour), Power: 11040},
+	})
+
+	require.Len(t, profile.ChargingSchedule, 1)
+	assert.Equal(t, types.ChargingRateUnitAmperes, profile.ChargingSchedule[0].ChargingRateUnit)
+	assert.Equal(t, []types.ChargingSchedulePeriod{
+		{StartPeriod: 0, Limit: 6},
+		{StartPeriod: 3600, Limit: 16},
+		{StartPeriod: 7200, Limit: 10},
+		{StartPeriod: 10800, Limit: 6},
+		{StartPeriod: 18000, Limit: 16},
+		{StartPeriod: 21600, Limit: 6},
+	}, profile.ChargingSchedule[0].ChargingSchedulePeriod)
+}
+
+func TestOcpp2Authorization(t *testing.T) {
+	station := ocpp2.NewStation(util.NewLogger("foo"), "station")
+
+	// no authorizer
+	assert.Equal(t, types.AuthorizationStatusAccepted, station.AuthorizationStatus(0, "blocked"))
+
+	station.SetAuthorizer(1, func(idToken string) bool {
+		return idToken != "blocked"
+	})
+
+	assert.Equal(t, types.AuthorizationStatusInvalid, station.AuthorizationStatus(0, "blocked"))
+	assert.Equal(t, types.AuthorizationStatusInvalid, station.AuthorizationStatus(1, "blocked"))
+	assert.Equal(t, types.AuthorizationStatusAccepted, station.AuthorizationStatus(1, "other"))
+	assert.Equal(t, types.AuthorizationStatusAccepted, station.AuthorizationStatus(2, "blocked"))
+}
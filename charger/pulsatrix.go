@@ -34,16 +34,17 @@ import (
 	"sync"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"github.com/coder/websocket"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/sponsor"
+	"github.com/evcc-io/evcc/util/supervisor"
 )
 
 // pulsatrix charger implementation
 type Pulsatrix struct {
+	ctx     context.Context
 	log     *util.Logger
 	mu      sync.Mutex
 	conn    *websocket.Conn
@@ -51,6 +52,7 @@ type Pulsatrix struct {
 	enabled bool
 	quit    chan struct{}
 	data    *util.Monitor[pulsatrixData]
+	sv      *supervisor.Supervisor
 }
 
 type pulsatrixData struct {
@@ -63,11 +65,11 @@ type pulsatrixData struct {
 }
 
 func init() {
-	registry.Add("pulsatrix", NewPulsatrixFromConfig)
+	registry.AddCtx("pulsatrix", NewPulsatrixFromConfig)
 }
 
 // NewPulsatrixtFromConfig creates a pulsatrix charger from generic config
-func NewPulsatrixFromConfig(other map[string]interface{}) (api.Charger, error) {
+func NewPulsatrixFromConfig(ctx context.Context, other map[string]interface{}) (api.Charger, error) {
 	var cc struct {
 		Host string
 	}
@@ -76,22 +78,28 @@ func NewPulsatrixFromConfig(other map[string]interface{}) (api.Charger, error) {
 		return nil, err
 	}
 
-	return NewPulsatrix(cc.Host)
+	return NewPulsatrix(ctx, cc.Host)
 }
 
-// NewPulsatrix creates pulsatrix charger
-func NewPulsatrix(hostname string) (*Pulsatrix, error) {
+// NewPulsatrix creates pulsatrix charger connected until ctx is done
+func NewPulsatrix(ctx context.Context, hostname string) (*Pulsatrix, error) {
+	log := util.NewLogger("pulsatrix")
+
 	wb := Pulsatrix{
-		log:  util.NewLogger("pulsatrix"),
+		ctx:  ctx,
+		log:  log,
 		uri:  fmt.Sprintf("ws://%s/api/ws", hostname),
 		data: util.NewMonitor[pulsatrixData](15 * time.Second),
+		sv:   supervisor.New(log, "pulsatrix "+hostname, supervisor.WithBackoff(time.Second, time.Minute)),
 	}
 
 	if err := wb.connectWs(); err != nil {
+		wb.sv.Close()
 		return nil, err
 	}
 
 	if !sponsor.IsAuthorized() {
+		wb.sv.Close()
 		return nil, api.ErrSponsorRequired
 	}
 
@@ -100,7 +108,7 @@ func NewPulsatrix(hostname string) (*Pulsatrix, error) {
 
 // ConnectWs connects to a pulsatrix SECC websocket
 func (c *Pulsatrix) connectWs() error {
-	ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+	ctx, cancel := context.WithTimeout(c.ctx, request.Timeout)
 	defer cancel()
 
 	c.log.TRACE.Printf("connecting to %s", c.uri)
@@ -122,24 +130,31 @@ func (c *Pulsatrix) connectWs() error {
 	return nil
 }
 
-// ReconnectWs reconnects to a pulsatrix SECC websocket
+// ReconnectWs reconnects to a pulsatrix SECC websocket until ctx is done
 func (c *Pulsatrix) reconnectWs() {
-	bo := backoff.NewExponentialBackOff(
-		backoff.WithInitialInterval(time.Second),
-		backoff.WithMaxInterval(time.Minute),
-		backoff.WithMaxElapsedTime(0)) // retry forever; default is 15 min
-	if err := backoff.Retry(c.connectWs, bo); err != nil {
-		c.log.ERROR.Println(err)
+	for c.ctx.Err() == nil {
+		err := c.connectWs()
+		if err == nil {
+			c.sv.Success()
+			return
+		}
+
+		select {
+		case <-time.After(c.sv.Failure(err)):
+		case <-c.ctx.Done():
+		}
 	}
+
+	c.sv.Close()
 }
 
 // WsReader runs a loop that reads messages from the websocket
 func (c *Pulsatrix) wsReader() {
 	for {
-		ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
-		defer cancel()
-
+		ctx, cancel := context.WithTimeout(c.ctx, request.Timeout)
 		messageType, message, err := c.conn.Read(ctx)
+		cancel()
+
 		if err != nil {
 			c.log.ERROR.Println("read message:", err)
 			break
@@ -62,7 +62,7 @@ func TokenSource(log *util.Logger, uri, user, password string) (oauth2.TokenSour
 	if err == nil {
 		var token Token
 		if err = c.DoJSON(req, &token); err == nil {
-			c.TokenSource = oauth.RefreshTokenSource(token.AsOAuth2Token(), c)
+			c.TokenSource = oauth.Tokens.Register("sma evcharger "+uri, "", oauth.RefreshTokenSource(token.AsOAuth2Token(), c))
 		}
 	}
 
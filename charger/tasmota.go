@@ -34,6 +34,7 @@ func NewTasmotaFromConfig(other map[string]interface{}) (api.Charger, error) {
 		Password     string
 		StandbyPower float64
 		Channel      []int
+		MeterChannel []int
 		Cache        time.Duration
 	}{
 		Channel: []int{1},
@@ -44,12 +45,12 @@ func NewTasmotaFromConfig(other map[string]interface{}) (api.Charger, error) {
 		return nil, err
 	}
 
-	return NewTasmota(cc.embed, cc.URI, cc.User, cc.Password, cc.Channel, cc.StandbyPower, cc.Cache)
+	return NewTasmota(cc.embed, cc.URI, cc.User, cc.Password, cc.Channel, cc.MeterChannel, cc.StandbyPower, cc.Cache)
 }
 
-// NewTasmota creates Tasmota charger
-func NewTasmota(embed embed, uri, user, password string, channels []int, standbypower float64, cache time.Duration) (api.Charger, error) {
-	conn, err := tasmota.NewConnection(uri, user, password, channels, cache)
+// NewTasmota creates Tasmota charger. Meter channels default to the relay channels.
+func NewTasmota(embed embed, uri, user, password string, channels, meterChannels []int, standbypower float64, cache time.Duration) (api.Charger, error) {
+	conn, err := tasmota.NewConnection(uri, user, password, channels, meterChannels, cache)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +66,7 @@ func NewTasmota(embed embed, uri, user, password string, channels []int, standby
 	c.switchSocket = NewSwitchSocket(&embed, c.Enabled, c.conn.CurrentPower, standbypower)
 
 	var currents, voltages func() (float64, float64, float64, error)
-	if len(channels) == 3 {
+	if len(meterChannels) == 3 || len(meterChannels) == 0 && len(channels) == 3 {
 		currents = c.currents
 		voltages = c.voltages
 	}
@@ -26,6 +26,7 @@ var acceptable = []string{
 	"(Client.Timeout exceeded while awaiting headers)",
 	"can only have either uri or device",                                   // modbus
 	"connection already registered with different protocol: localhost:502", // modbus
+	"circuit open",                                                         // modbus, unreachable device shared by templates
 	"sponsorship required, see https://github.com/evcc-io/evcc#sponsorship",
 	"eebus not configured",
 	"context deadline exceeded",
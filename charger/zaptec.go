@@ -30,6 +30,7 @@ import (
 	"github.com/evcc-io/evcc/charger/zaptec"
 	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/sponsor"
 	"github.com/samber/lo"
@@ -125,7 +126,7 @@ func NewZaptec(user, password, id string, priority bool, cache time.Duration) (a
 	}
 
 	c.Transport = &oauth2.Transport{
-		Source: oc.TokenSource(context.Background(), token),
+		Source: oauth.Tokens.Register("zaptec "+user, "", oc.TokenSource(context.Background(), token)),
 		Base:   c.Transport,
 	}
 
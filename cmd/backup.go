@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/spf13/cobra"
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup [file]",
+	Short: "Backup configuration, settings and session history",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   runBackup,
+}
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Restore backup (applied on next start, replaces config file)",
+	Args:  cobra.ExactArgs(1),
+	Run:   runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(restoreCmd)
+}
+
+func runBackup(cmd *cobra.Command, args []string) {
+	// load config
+	if err := loadConfigFile(&conf, !cmd.Flag(flagIgnoreDatabase).Changed); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	// setup persistence
+	if err := configureDatabase(conf.Database); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	file := "evcc-backup-" + time.Now().Format("20060102-150405") + ".zip"
+	if len(args) > 0 {
+		file = args[0]
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	if err := db.Backup(f, cfgFile); err != nil {
+		f.Close()
+		os.Remove(file)
+		log.FATAL.Fatal(err)
+	}
+
+	if err := f.Close(); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	log.INFO.Println("backup created:", file)
+}
+
+func runRestore(cmd *cobra.Command, args []string) {
+	// load config
+	if err := loadConfigFile(&conf, !cmd.Flag(flagIgnoreDatabase).Changed); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	// database location
+	if err := configureDatabase(conf.Database); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	if err := db.Restore(f, fi.Size(), cfgFile); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	log.INFO.Println("backup restored, restart evcc to apply")
+}
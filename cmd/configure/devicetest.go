@@ -124,7 +124,7 @@ func (d *DeviceTest) testMeter(deviceCategory DeviceCategory, v interface{}) (De
 	}
 
 	if deviceCategory == DeviceCategoryBatteryMeter {
-		b, ok := v.(api.Battery)
+		b, ok := api.As[api.Battery](v)
 		if !ok {
 			return DeviceTestResultInvalid, errors.New("selected device is not a battery meter")
 		}
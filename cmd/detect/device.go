@@ -0,0 +1,129 @@
+package detect
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/evcc-io/evcc/cmd/detect/tasks"
+	"github.com/evcc-io/evcc/util/templates"
+)
+
+// Device is a configuration suggestion for a discovered device
+type Device struct {
+	Class    templates.Class `yaml:"-"`
+	Source   string          `yaml:"-"`
+	Name     string          `yaml:"name"`
+	Type     string          `yaml:"type"`
+	Template string          `yaml:"template"`
+	Params   map[string]any  `yaml:",inline"`
+}
+
+var nameRe = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+func newDevice(class templates.Class, source, template, host string, params map[string]any) Device {
+	if params == nil {
+		params = make(map[string]any)
+	}
+	params["host"] = host
+
+	return Device{
+		Class:    class,
+		Source:   source,
+		Name:     nameRe.ReplaceAllString(template+"_"+host, "_"),
+		Type:     "template",
+		Template: template,
+		Params:   params,
+	}
+}
+
+// Host returns the device's host
+func (d Device) Host() string {
+	host, _ := d.Params["host"].(string)
+	return host
+}
+
+// Config returns the device configuration as used by the config api
+func (d Device) Config() map[string]any {
+	res := map[string]any{
+		"type":     d.Type,
+		"template": d.Template,
+	}
+	for k, v := range d.Params {
+		res[k] = v
+	}
+	return res
+}
+
+func (d Device) String() string {
+	return fmt.Sprintf("%s %s at %s (%s)", d.Class, d.Template, d.Host(), d.Source)
+}
+
+// Suggest converts scan results into device suggestions
+func Suggest(res []tasks.Result) []Device {
+	var devices []Device
+
+	for _, hit := range res {
+		ip := hit.ResultDetails.IP
+
+		switch hit.ID {
+		case taskKEBA:
+			devices = append(devices, newDevice(templates.Charger, "scan", "keba", ip, nil))
+
+		case taskGoE:
+			devices = append(devices, newDevice(templates.Charger, "scan", "go-e", ip, nil))
+
+		case taskShelly:
+			devices = append(devices, newDevice(templates.Meter, "scan", "shelly-1pm", ip, map[string]any{"usage": "pv"}))
+
+		case taskFroniusWeb:
+			devices = append(devices, newDevice(templates.Meter, "scan", "fronius-solarapi-v1", ip, map[string]any{"usage": "pv"}))
+
+		case taskSMA:
+			if sma := hit.ResultDetails.SmaResult; sma != nil && sma.Http {
+				devices = append(devices, newDevice(templates.Meter, "scan", "sma-inverter-speedwire", ip, map[string]any{"usage": "pv"}))
+			} else {
+				devices = append(devices, newDevice(templates.Meter, "scan", "sma-home-manager", ip, map[string]any{"usage": "grid"}))
+			}
+
+		case taskInverter, taskMeter, taskBattery:
+			usage := map[string]string{taskInverter: "pv", taskMeter: "grid", taskBattery: "battery"}[hit.ID]
+
+			params := map[string]any{
+				"usage":  usage,
+				"modbus": "tcpip",
+				"port":   hit.ResultDetails.Port,
+			}
+			if mr := hit.ResultDetails.ModbusResult; mr != nil {
+				params["id"] = mr.SlaveID
+			}
+
+			devices = append(devices, newDevice(templates.Meter, "modbus", "sunspec-inverter", ip, params))
+		}
+	}
+
+	return Unique(devices)
+}
+
+// Unique removes duplicate suggestions of the same template and host
+func Unique(devices []Device) []Device {
+	var res []Device
+
+	for _, d := range devices {
+		if !slices.ContainsFunc(res, func(e Device) bool {
+			return e.Template == d.Template && e.Host() == d.Host() && e.Params["usage"] == d.Params["usage"]
+		}) {
+			res = append(res, d)
+		}
+	}
+
+	slices.SortStableFunc(res, func(a, b Device) int {
+		if c := strings.Compare(a.Class.String(), b.Class.String()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Host(), b.Host())
+	})
+
+	return res
+}
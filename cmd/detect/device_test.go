@@ -0,0 +1,71 @@
+package detect
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/evcc-io/evcc/cmd/detect/tasks"
+	"github.com/evcc-io/evcc/util/templates"
+	"github.com/libp2p/zeroconf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMdnsDevice(t *testing.T) {
+	for _, tc := range []struct {
+		instance, template string
+		class              templates.Class
+	}{
+		{"shellyplus1pm-a8032ab12345", "shelly-1pm", templates.Meter},
+		{"shellypro3em-a8032ab12345", "shelly-3em", templates.Meter},
+		{"go-eCharger-012345", "go-e-v3", templates.Charger},
+		{"smartfox", "", 0},
+	} {
+		entry := &zeroconf.ServiceEntry{AddrIPv4: []net.IP{net.ParseIP("192.168.0.10")}}
+		entry.Instance = tc.instance
+
+		d, ok := mdnsDevice(entry)
+		require.Equal(t, tc.template != "", ok, tc.instance)
+
+		if ok {
+			assert.Equal(t, tc.template, d.Template)
+			assert.Equal(t, tc.class, d.Class)
+			assert.Equal(t, "192.168.0.10", d.Host())
+		}
+	}
+}
+
+func TestSsdpDevice(t *testing.T) {
+	resp, err := http.ReadResponse(bufio.NewReader(strings.NewReader(
+		"HTTP/1.1 200 OK\r\nSERVER: Linux UPnP/1.0 Fronius Datamanager\r\nST: upnp:rootdevice\r\n\r\n",
+	)), nil)
+	require.NoError(t, err)
+
+	d, ok := ssdpDevice(resp, "192.168.0.20")
+	require.True(t, ok)
+	assert.Equal(t, "fronius-solarapi-v1", d.Template)
+	assert.Equal(t, "pv", d.Params["usage"])
+}
+
+func TestSuggest(t *testing.T) {
+	res := []tasks.Result{
+		{Task: tasks.Task{ID: taskKEBA}, ResultDetails: tasks.ResultDetails{IP: "192.168.0.2"}},
+		{Task: tasks.Task{ID: taskKEBA}, ResultDetails: tasks.ResultDetails{IP: "192.168.0.2"}},
+		{Task: tasks.Task{ID: taskInverter}, ResultDetails: tasks.ResultDetails{IP: "192.168.0.3", Port: 502, ModbusResult: &tasks.ModbusResult{SlaveID: 1}}},
+		{Task: tasks.Task{ID: TaskPing}, ResultDetails: tasks.ResultDetails{IP: "192.168.0.4"}},
+	}
+
+	devices := Suggest(res)
+	require.Len(t, devices, 2)
+
+	assert.Equal(t, templates.Charger, devices[0].Class)
+	assert.Equal(t, "keba", devices[0].Template)
+
+	b, err := yaml.Marshal(devices[1])
+	require.NoError(t, err)
+	assert.Equal(t, "name: sunspec_inverter_192_168_0_3\ntype: template\ntemplate: sunspec-inverter\nhost: 192.168.0.3\nid: 1\nmodbus: tcpip\nport: 502\nusage: pv\n", string(b))
+}
@@ -0,0 +1,99 @@
+package detect
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/templates"
+	"github.com/libp2p/zeroconf/v2"
+)
+
+// mdnsServices are the service types browsed for known devices
+var mdnsServices = []string{"_http._tcp", "_shelly._tcp"}
+
+var mdnsSmaRe = regexp.MustCompile(`^sma[0-9 _-]`)
+
+// mdnsDevice identifies known devices by their mDNS instance name
+func mdnsDevice(entry *zeroconf.ServiceEntry) (Device, bool) {
+	if len(entry.AddrIPv4) == 0 {
+		return Device{}, false
+	}
+
+	host := entry.AddrIPv4[0].String()
+	name := strings.ToLower(entry.Instance)
+
+	switch {
+	case strings.HasPrefix(name, "shelly") && (strings.Contains(name, "3em") || strings.Contains(name, "em3")):
+		return newDevice(templates.Meter, "mdns", "shelly-3em", host, map[string]any{"usage": "grid"}), true
+
+	case strings.HasPrefix(name, "shelly"):
+		return newDevice(templates.Meter, "mdns", "shelly-1pm", host, map[string]any{"usage": "pv"}), true
+
+	case strings.HasPrefix(name, "go-e"):
+		return newDevice(templates.Charger, "mdns", "go-e-v3", host, nil), true
+
+	case strings.HasPrefix(name, "keba"):
+		return newDevice(templates.Charger, "mdns", "keba", host, nil), true
+
+	case strings.HasPrefix(name, "fronius"):
+		return newDevice(templates.Meter, "mdns", "fronius-solarapi-v1", host, map[string]any{"usage": "pv"}), true
+
+	case mdnsSmaRe.MatchString(name):
+		return newDevice(templates.Meter, "mdns", "sma-inverter-speedwire", host, map[string]any{"usage": "pv"}), true
+	}
+
+	return Device{}, false
+}
+
+// Mdns browses the local network for known devices until the context is done
+func Mdns(ctx context.Context, log *util.Logger) []Device {
+	var (
+		mu  sync.Mutex
+		res []Device
+		wg  sync.WaitGroup
+	)
+
+	for _, service := range mdnsServices {
+		entries := make(chan *zeroconf.ServiceEntry)
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+
+				case entry, ok := <-entries:
+					if !ok {
+						return
+					}
+
+					log.DEBUG.Printf("mdns: %s %s %v", entry.Service, entry.Instance, entry.AddrIPv4)
+
+					if d, ok := mdnsDevice(entry); ok {
+						mu.Lock()
+						res = append(res, d)
+						mu.Unlock()
+					}
+				}
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			if err := zeroconf.Browse(ctx, service, "local.", entries); err != nil {
+				log.ERROR.Printf("mdns: %s: %v", service, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return Unique(res)
+}
@@ -0,0 +1,103 @@
+package detect
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/templates"
+)
+
+const ssdpAddr = "239.255.255.250:1900"
+
+var ssdpSmaRe = regexp.MustCompile(`\bsma\b`)
+
+var ssdpSearch = []byte("M-SEARCH * HTTP/1.1\r\n" +
+	"HOST: " + ssdpAddr + "\r\n" +
+	"MAN: \"ssdp:discover\"\r\n" +
+	"MX: 2\r\n" +
+	"ST: ssdp:all\r\n\r\n")
+
+// ssdpDevice identifies known devices by their SSDP response headers
+func ssdpDevice(resp *http.Response, host string) (Device, bool) {
+	id := strings.ToLower(strings.Join([]string{
+		resp.Header.Get("Server"), resp.Header.Get("St"), resp.Header.Get("Usn"),
+	}, " "))
+
+	switch {
+	case strings.Contains(id, "fronius"):
+		return newDevice(templates.Meter, "ssdp", "fronius-solarapi-v1", host, map[string]any{"usage": "pv"}), true
+
+	case ssdpSmaRe.MatchString(id):
+		return newDevice(templates.Meter, "ssdp", "sma-inverter-speedwire", host, map[string]any{"usage": "pv"}), true
+
+	case strings.Contains(id, "keba"):
+		return newDevice(templates.Charger, "ssdp", "keba", host, nil), true
+
+	case strings.Contains(id, "go-e"):
+		return newDevice(templates.Charger, "ssdp", "go-e-v3", host, nil), true
+
+	case strings.Contains(id, "shelly"):
+		return newDevice(templates.Meter, "ssdp", "shelly-1pm", host, map[string]any{"usage": "pv"}), true
+	}
+
+	return Device{}, false
+}
+
+// Ssdp searches the local network for known devices until the context is done
+func Ssdp(ctx context.Context, log *util.Logger) []Device {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		log.ERROR.Println("ssdp:", err)
+		return nil
+	}
+	defer conn.Close()
+
+	addr, err := net.ResolveUDPAddr("udp4", ssdpAddr)
+	if err != nil {
+		log.ERROR.Println("ssdp:", err)
+		return nil
+	}
+
+	if _, err := conn.WriteTo(ssdpSearch, addr); err != nil {
+		log.ERROR.Println("ssdp:", err)
+		return nil
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(5 * time.Second)
+	}
+	_ = conn.SetReadDeadline(deadline)
+
+	var res []Device
+	buf := make([]byte, 2048)
+
+	for ctx.Err() == nil {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			break
+		}
+
+		resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+
+		host, _, _ := net.SplitHostPort(from.String())
+		log.DEBUG.Printf("ssdp: %s %s %s", host, resp.Header.Get("Server"), resp.Header.Get("St"))
+
+		if d, ok := ssdpDevice(resp, host); ok {
+			res = append(res, d)
+		}
+	}
+
+	return Unique(res)
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evcc-io/evcc/core"
+	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/evcc-io/evcc/util/logstash"
+	"github.com/spf13/cobra"
+	jww "github.com/spf13/jwalterweatherman"
+	"gopkg.in/yaml.v3"
+)
+
+const flagDiagOut = "out"
+
+// diagCmd represents the diag command
+var diagCmd = &cobra.Command{
+	Use:   "diag",
+	Short: "Create diagnostics bundle for support requests",
+	Long: `Diag collects version info, redacted configuration, device states, timing statistics and logs
+into a single zip archive. Credentials and tokens are masked.`,
+	Args: cobra.ExactArgs(0),
+	Run:  runDiag,
+}
+
+func init() {
+	rootCmd.AddCommand(diagCmd)
+	diagCmd.Flags().StringP(flagDiagOut, "o", "", "Archive file name (default evcc-diag-<timestamp>.zip)")
+}
+
+// diagBundle collects the bundle's files
+type diagBundle struct {
+	files  []string
+	data   map[string]*bytes.Buffer
+	timing *tabwriter.Writer
+}
+
+func newDiagBundle() *diagBundle {
+	b := &diagBundle{data: make(map[string]*bytes.Buffer)}
+	b.timing = tabwriter.NewWriter(b.file("timing.txt"), 0, 0, 1, ' ', 0)
+	return b
+}
+
+func (b *diagBundle) file(name string) *bytes.Buffer {
+	if buf, ok := b.data[name]; ok {
+		return buf
+	}
+
+	buf := new(bytes.Buffer)
+	b.files = append(b.files, name)
+	b.data[name] = buf
+
+	return buf
+}
+
+func (b *diagBundle) write(w io.Writer) error {
+	if err := b.timing.Flush(); err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+
+	for _, name := range b.files {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(f, maskSecrets(b.data[name].String())); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// diagDevices dumps the handler's device configurations and states, recording the time taken per device
+func diagDevices[T any](b *diagBundle, class string, h config.Handler[T]) {
+	devices := h.Devices()
+	d := dumper{len: 2, out: b.file("devices.txt")}
+
+	var named []config.Named
+	for _, dev := range devices {
+		conf := dev.Config()
+		named = append(named, conf)
+
+		start := time.Now()
+		d.DumpWithHeader(fmt.Sprintf("%s: %s", class, conf.Name), dev.Instance())
+		fmt.Fprintf(b.timing, "%s:\t%s\t%v\n", class, conf.Name, time.Since(start).Round(time.Millisecond))
+	}
+
+	if len(named) > 0 {
+		if src, err := yaml.Marshal(map[string][]config.Named{class: named}); err == nil {
+			b.file("devices.yaml").WriteString(redact(string(src)))
+		}
+	}
+}
+
+func runDiag(cmd *cobra.Command, args []string) {
+	b := newDiagBundle()
+
+	// version
+	v := b.file("version.txt")
+	fmt.Fprintf(v, "version: %s\n", server.FormattedVersion())
+	fmt.Fprintf(v, "go: %s\n", runtime.Version())
+	fmt.Fprintf(v, "os: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(v, "created: %s\n", time.Now().Format(time.RFC3339))
+
+	// load config
+	start := time.Now()
+	err := loadConfigFile(&conf, !cmd.Flag(flagIgnoreDatabase).Changed)
+
+	file, pathErr := filepath.Abs(cfgFile)
+	if pathErr != nil {
+		file = cfgFile
+	}
+	fmt.Fprintf(v, "config: %s\n", file)
+
+	if src, err := os.ReadFile(cfgFile); err == nil {
+		b.file("evcc.yaml").WriteString(redact(string(src)))
+	}
+
+	// setup environment
+	if err == nil {
+		err = configureEnvironment(cmd, &conf)
+	}
+
+	var site *core.Site
+	if err == nil {
+		site, err = configureSiteAndLoadpoints(&conf)
+	}
+	fmt.Fprintf(b.timing, "setup:\t\t%v\n", time.Since(start).Round(time.Millisecond))
+
+	if err != nil {
+		fmt.Fprintf(v, "error: %s\n", strings.Join(unwrap(err), ": "))
+	}
+
+	// devices
+	diagDevices(b, "meters", config.Meters())
+	diagDevices(b, "chargers", config.Chargers())
+	diagDevices(b, "vehicles", config.Vehicles())
+
+	if site != nil {
+		fmt.Fprintf(v, "loadpoints: %d\n", len(site.Loadpoints()))
+	}
+
+	// logs
+	l := b.file("evcc.log")
+	for _, line := range logstash.All(nil, jww.LevelTrace, 0) {
+		l.WriteString(line)
+	}
+
+	// archive
+	out, _ := cmd.Flags().GetString(flagDiagOut)
+	if out == "" {
+		out = "evcc-diag-" + time.Now().Format("20060102-150405") + ".zip"
+	}
+
+	f, err := os.Create(out)
+	if err == nil {
+		err = b.write(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	fmt.Println("diagnostics written to", out)
+
+	// wait for shutdown
+	<-shutdownDoneC()
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/evcc-io/evcc/cmd/detect"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/templates"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	flagDiscoverTimeout = "timeout"
+	flagDiscoverScan    = "scan"
+	flagDiscoverAdd     = "add"
+	flagDiscoverUrl     = "url"
+)
+
+// discoverCmd represents the discover command
+var discoverCmd = &cobra.Command{
+	Use:   "discover [host ...] [subnet ...]",
+	Short: "Discover known devices and suggest their configuration",
+	Long: `Discover searches the local network for known devices using mDNS, SSDP and Modbus probing.
+Found devices are printed as configuration snippets or can be added to a running instance using the config api.`,
+	Run: runDiscover,
+}
+
+func init() {
+	rootCmd.AddCommand(discoverCmd)
+	discoverCmd.Flags().Duration(flagDiscoverTimeout, 5*time.Second, "mDNS and SSDP listening duration")
+	discoverCmd.Flags().Bool(flagDiscoverScan, true, "Probe local subnet for devices")
+	discoverCmd.Flags().Bool(flagDiscoverAdd, false, "Offer to add discovered devices via the config api")
+	discoverCmd.Flags().String(flagDiscoverUrl, "http://localhost:7070", "Config api url for adding devices")
+}
+
+func runDiscover(cmd *cobra.Command, args []string) {
+	util.LogLevel(viper.GetString("log"), nil)
+
+	timeout, _ := cmd.Flags().GetDuration(flagDiscoverTimeout)
+	scan, _ := cmd.Flags().GetBool(flagDiscoverScan)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		devices []detect.Device
+		wg      sync.WaitGroup
+	)
+
+	collect := func(fun func() []detect.Device) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := fun()
+			mu.Lock()
+			devices = append(devices, res...)
+			mu.Unlock()
+		}()
+	}
+
+	collect(func() []detect.Device { return detect.Mdns(ctx, log) })
+	collect(func() []detect.Device { return detect.Ssdp(ctx, log) })
+
+	if scan {
+		var hosts []string
+		for _, arg := range args {
+			hosts = append(hosts, ParseHostIPNet(arg)...)
+		}
+
+		if len(hosts) == 0 {
+			ips := util.LocalIPs()
+			if len(ips) == 0 {
+				log.FATAL.Fatal("could not find ip")
+			}
+
+			hosts = IPsFromSubnet(ips[0].String())
+		}
+
+		collect(func() []detect.Device { return detect.Suggest(detect.Work(log, 50, hosts)) })
+	}
+
+	wg.Wait()
+	devices = detect.Unique(devices)
+
+	if len(devices) == 0 {
+		fmt.Println("no devices found")
+		return
+	}
+
+	if add, _ := cmd.Flags().GetBool(flagDiscoverAdd); add {
+		uri, _ := cmd.Flags().GetString(flagDiscoverUrl)
+		if err := addDiscovered(strings.TrimSuffix(uri, "/"), devices); err != nil {
+			log.FATAL.Fatal(err)
+		}
+		return
+	}
+
+	printDiscovered(devices)
+}
+
+// printDiscovered prints devices as ready-to-paste yaml
+func printDiscovered(devices []detect.Device) {
+	conf := make(map[string][]detect.Device)
+	for _, d := range devices {
+		key := d.Class.String() + "s"
+		conf[key] = append(conf[key], d)
+	}
+
+	b, err := yaml.Marshal(conf)
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	fmt.Println("# discovered devices, please verify usage and credentials")
+	fmt.Println(string(b))
+}
+
+// addDiscovered adds devices to a running instance after confirmation
+func addDiscovered(uri string, devices []detect.Device) error {
+	helper := request.NewHelper(log)
+	helper.Client.Jar, _ = cookiejar.New(nil)
+
+	var password string
+	if err := survey.AskOne(&survey.Password{Message: "Admin password"}, &password); err != nil {
+		return err
+	}
+
+	req, _ := request.New(http.MethodPost, uri+"/api/auth/login", request.MarshalJSON(struct {
+		Password string `json:"password"`
+	}{password}), request.JSONEncoding)
+
+	if _, err := helper.DoBody(req); err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+
+	for _, d := range devices {
+		var confirm bool
+		if err := survey.AskOne(&survey.Confirm{Message: fmt.Sprintf("Add %s?", d)}, &confirm); err != nil {
+			return err
+		}
+
+		if !confirm {
+			continue
+		}
+
+		if err := addDevice(helper, uri, d); err != nil {
+			log.ERROR.Printf("%s: %v", d, err)
+			continue
+		}
+
+		fmt.Println("added", d)
+	}
+
+	return nil
+}
+
+func addDevice(helper *request.Helper, uri string, d detect.Device) error {
+	if d.Class != templates.Charger && d.Class != templates.Meter {
+		return errors.New("unsupported class")
+	}
+
+	req, _ := request.New(http.MethodPost, fmt.Sprintf("%s/api/config/devices/%s", uri, d.Class), request.MarshalJSON(d.Config()), request.JSONEncoding)
+	_, err := helper.DoBody(req)
+
+	return err
+}
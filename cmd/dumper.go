@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"slices"
 	"strings"
@@ -15,11 +16,19 @@ import (
 
 type dumper struct {
 	len int
+	out io.Writer // defaults to stdout
+}
+
+func (d *dumper) writer() io.Writer {
+	if d.out != nil {
+		return d.out
+	}
+	return os.Stdout
 }
 
 func (d *dumper) Header(name, underline string) {
-	fmt.Println(name)
-	fmt.Println(strings.Repeat(underline, len(name)))
+	fmt.Fprintln(d.writer(), name)
+	fmt.Fprintln(d.writer(), strings.Repeat(underline, len(name)))
 }
 
 func (d *dumper) DumpWithHeader(name string, device interface{}) {
@@ -30,12 +39,12 @@ func (d *dumper) DumpWithHeader(name string, device interface{}) {
 	d.Dump(name, device)
 
 	if d.len > 1 {
-		fmt.Println()
+		fmt.Fprintln(d.writer())
 	}
 }
 
 func (d *dumper) Dump(name string, v interface{}) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	w := tabwriter.NewWriter(d.writer(), 0, 0, 1, ' ', 0)
 
 	var isHeating bool
 	if fd, ok := v.(api.FeatureDescriber); ok {
@@ -52,7 +61,7 @@ func (d *dumper) Dump(name string, v interface{}) {
 		}
 	}
 
-	if v, ok := v.(api.MeterEnergy); ok {
+	if v, ok := api.As[api.MeterEnergy](v); ok {
 		if energy, err := v.TotalEnergy(); err != nil {
 			fmt.Fprintf(w, "Energy:\t%v\n", err)
 		} else {
@@ -84,7 +93,15 @@ func (d *dumper) Dump(name string, v interface{}) {
 		}
 	}
 
-	if v, ok := v.(api.Battery); ok {
+	if v, ok := api.As[api.MpptPowers](v); ok {
+		if res, err := v.MpptPowers(); err != nil {
+			fmt.Fprintf(w, "Power MPPT:\t%v\n", err)
+		} else {
+			fmt.Fprintf(w, "Power MPPT:\t%.0fW\n", res)
+		}
+	}
+
+	if v, ok := api.As[api.Battery](v); ok {
 		var soc float64
 		var err error
 
@@ -116,7 +133,7 @@ func (d *dumper) Dump(name string, v interface{}) {
 		}
 	}
 
-	if v, ok := v.(api.BatteryCapacity); ok {
+	if v, ok := api.As[api.BatteryCapacity](v); ok {
 		fmt.Fprintf(w, "Capacity:\t%.1fkWh\n", v.Capacity())
 	}
 
@@ -289,7 +306,7 @@ func (d *dumper) Dump(name string, v interface{}) {
 }
 
 func (d *dumper) DumpDiagnosis(v interface{}) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	w := tabwriter.NewWriter(d.writer(), 0, 0, 1, ' ', 0)
 
 	if v, ok := v.(api.Diagnosis); ok {
 		fmt.Fprintln(w, "Diagnostic dump:")
@@ -61,6 +61,23 @@ func redact(src string) string {
 		ReplaceAllString(src, "$1: *****")
 }
 
+var secretsRe = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`eyJ[\w-]+\.[\w-]+\.[\w-]*`), "*****"},                                                                                   // jwt
+	{regexp.MustCompile(`(?i)\b(bearer|basic)\s+[\w.~+/=-]+`), "$1 *****"},                                                                       // auth headers
+	{regexp.MustCompile(`(?i)\b(password|passwd|secret|token|access_token|refresh_token|apikey|api_key|pin)([\s"':=]+)[^\s"',&]+`), "$1$2*****"}, // parameters
+}
+
+// maskSecrets masks credentials and tokens in free text like logs
+func maskSecrets(src string) string {
+	for _, s := range secretsRe {
+		src = s.re.ReplaceAllString(src, s.repl)
+	}
+	return src
+}
+
 // fatal logs a fatal error and runs shutdown functions before terminating
 func fatal(err error) {
 	log.FATAL.Println(err)
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util/config"
 	"github.com/spf13/cobra"
 )
 
@@ -100,7 +102,7 @@ func runMigrate(cmd *cobra.Command, args []string) {
 	log.DEBUG.Println("- tariffs")
 	if reset {
 		settings.Delete(keys.Tariffs)
-	} else if conf.Tariffs.Grid.Type != "" || conf.Tariffs.FeedIn.Type != "" || conf.Tariffs.Co2.Type != "" || conf.Tariffs.Planner.Type != "" {
+	} else if conf.Tariffs.Grid.Type != "" || conf.Tariffs.FeedIn.Type != "" || conf.Tariffs.Co2.Type != "" || conf.Tariffs.Planner.Type != "" || conf.Tariffs.Solar.Type != "" {
 		_ = settings.SetYaml(keys.Tariffs, conf.Tariffs)
 	}
 
@@ -111,6 +113,30 @@ func runMigrate(cmd *cobra.Command, args []string) {
 		_ = settings.SetYaml(keys.Circuits, conf.Circuits)
 	}
 
+	log.DEBUG.Println("- devices")
+	if reset {
+		log.WARN.Println("devices are not reset, use the ui to remove them")
+	} else if err := config.Init(db.Instance); err != nil {
+		log.ERROR.Println(err)
+	} else {
+		m, err := migrateDevices(conf)
+		if err != nil {
+			log.ERROR.Println(err)
+		}
+
+		for _, s := range m.migrated {
+			log.INFO.Println("migrated", s)
+		}
+
+		for _, s := range m.conflicts {
+			log.WARN.Println("conflict:", s)
+		}
+
+		if len(m.migrated) > 0 {
+			log.WARN.Println("remove migrated devices, loadpoints and site meters from the yaml configuration to avoid duplicates")
+		}
+	}
+
 	// wait for shutdown
 	<-shutdownDoneC()
 }
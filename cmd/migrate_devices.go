@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/evcc-io/evcc/api/globalconfig"
+	"github.com/evcc-io/evcc/core"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/evcc-io/evcc/util/templates"
+)
+
+// deviceMigration imports yaml devices into the database. Devices receive database names,
+// references from loadpoints and site are updated accordingly.
+type deviceMigration struct {
+	names     map[string]string // yaml name by class to database name
+	migrated  []string
+	conflicts []string
+}
+
+func newDeviceMigration() *deviceMigration {
+	return &deviceMigration{names: make(map[string]string)}
+}
+
+func (m *deviceMigration) conflict(format string, args ...any) {
+	m.conflicts = append(m.conflicts, fmt.Sprintf(format, args...))
+}
+
+// existing returns the database config of same class and configuration
+func existing(class templates.Class, typ string, other map[string]any) (*config.Config, error) {
+	b, err := json.Marshal(other)
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := config.ConfigurationsByClass(class)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range configs {
+		if c.Type == typ && c.Value == string(b) {
+			return &c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// device imports a single yaml device unless already present in the database
+func (m *deviceMigration) device(class templates.Class, cc config.Named) {
+	key := class.String() + ":" + cc.Name
+
+	if cc.Name == "" {
+		m.conflict("%s: missing name", class)
+		return
+	}
+
+	if _, ok := m.names[key]; ok {
+		m.conflict("%s %s: duplicate name", class, cc.Name)
+		return
+	}
+
+	found, err := existing(class, cc.Type, cc.Other)
+	if err != nil {
+		m.conflict("%s %s: %v", class, cc.Name, err)
+		return
+	}
+
+	if found != nil {
+		m.names[key] = config.NameForID(found.ID)
+		m.conflict("%s %s: already migrated as %s", class, cc.Name, m.names[key])
+		return
+	}
+
+	conf, err := config.AddConfig(class, cc.Type, cc.Other)
+	if err != nil {
+		m.conflict("%s %s: %v", class, cc.Name, err)
+		return
+	}
+
+	m.names[key] = config.NameForID(conf.ID)
+	m.migrated = append(m.migrated, fmt.Sprintf("%s %s: %s", class, cc.Name, m.names[key]))
+}
+
+// ref returns the database name for a yaml reference
+func (m *deviceMigration) ref(class templates.Class, name string) string {
+	if name == "" || strings.HasPrefix(name, "db:") {
+		return name
+	}
+
+	if res, ok := m.names[class.String()+":"+name]; ok {
+		return res
+	}
+
+	m.conflict("%s %s: unknown reference, not updated", class, name)
+
+	return name
+}
+
+// loadpoint imports a yaml loadpoint with updated device references
+func (m *deviceMigration) loadpoint(id int, other map[string]any) {
+	lp := maps.Clone(other)
+
+	for k, v := range lp {
+		ref, ok := v.(string)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(k) {
+		case "charger":
+			lp[k] = m.ref(templates.Charger, ref)
+		case "meter":
+			lp[k] = m.ref(templates.Meter, ref)
+		case "vehicle":
+			lp[k] = m.ref(templates.Vehicle, ref)
+		}
+	}
+
+	found, err := existing(templates.Loadpoint, "", lp)
+	if err != nil {
+		m.conflict("loadpoint %d: %v", id, err)
+		return
+	}
+
+	if found != nil {
+		m.conflict("loadpoint %d: already migrated as %s", id, config.NameForID(found.ID))
+		return
+	}
+
+	conf, err := config.AddConfig(templates.Loadpoint, "", lp)
+	if err != nil {
+		m.conflict("loadpoint %d: %v", id, err)
+		return
+	}
+
+	m.migrated = append(m.migrated, fmt.Sprintf("loadpoint %d: %s", id, config.NameForID(conf.ID)))
+}
+
+// siteMeter stores the updated site meter references unless configured differently
+func (m *deviceMigration) siteMeter(key string, refs []string) {
+	refs = slices.DeleteFunc(slices.Clone(refs), func(s string) bool { return s == "" })
+	if len(refs) == 0 {
+		return
+	}
+
+	for i, ref := range refs {
+		refs[i] = m.ref(templates.Meter, ref)
+	}
+
+	val := strings.Join(refs, ",")
+
+	if cur, err := settings.String(key); err == nil && cur != "" {
+		if cur != val {
+			m.conflict("site %s: already configured as %s, not updated", key, cur)
+		}
+		return
+	}
+
+	settings.SetString(key, val)
+	m.migrated = append(m.migrated, fmt.Sprintf("site %s: %s", key, val))
+}
+
+// migrateDevices imports meters, chargers, vehicles, loadpoints and site meters
+func migrateDevices(conf globalconfig.All) (*deviceMigration, error) {
+	m := newDeviceMigration()
+
+	for _, cc := range conf.Meters {
+		m.device(templates.Meter, cc)
+	}
+
+	for _, cc := range conf.Chargers {
+		m.device(templates.Charger, cc)
+	}
+
+	for _, cc := range conf.Vehicles {
+		m.device(templates.Vehicle, cc)
+	}
+
+	for id, lp := range conf.Loadpoints {
+		m.loadpoint(id+1, lp)
+	}
+
+	var site struct {
+		Meters core.MetersConfig
+		Other  map[string]any `mapstructure:",remain"`
+	}
+
+	if err := util.DecodeOther(conf.Site, &site); err != nil {
+		return m, err
+	}
+
+	m.siteMeter(keys.GridMeter, []string{site.Meters.GridMeterRef})
+	m.siteMeter(keys.PvMeters, site.Meters.PVMetersRef)
+	m.siteMeter(keys.BatteryMeters, site.Meters.BatteryMetersRef)
+	m.siteMeter(keys.ExtMeters, site.Meters.ExtMetersRef)
+	m.siteMeter(keys.AuxMeters, site.Meters.AuxMetersRef)
+
+	return m, nil
+}
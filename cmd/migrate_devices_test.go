@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/evcc-io/evcc/api/globalconfig"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/evcc-io/evcc/util/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDevices(t *testing.T) {
+	db, err := db.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, config.Init(db))
+
+	var conf globalconfig.All
+	viper.SetConfigType("yaml")
+
+	require.NoError(t, viper.ReadConfig(strings.NewReader(`
+site:
+  meters:
+    grid: grid
+    pv: [pv, other]
+meters:
+- name: grid
+  type: template
+  template: demo-meter
+  usage: grid
+- name: pv
+  type: template
+  template: demo-meter
+  usage: pv
+chargers:
+- name: wallbox
+  type: demo-charger
+loadpoints:
+- title: Garage
+  charger: wallbox
+`)))
+	require.NoError(t, viper.UnmarshalExact(&conf))
+
+	m, err := migrateDevices(conf)
+	require.NoError(t, err)
+	assert.Len(t, m.migrated, 6)
+	assert.Equal(t, []string{"meter other: unknown reference, not updated"}, m.conflicts)
+
+	meters, err := config.ConfigurationsByClass(templates.Meter)
+	require.NoError(t, err)
+	require.Len(t, meters, 2)
+
+	lps, err := config.ConfigurationsByClass(templates.Loadpoint)
+	require.NoError(t, err)
+	require.Len(t, lps, 1)
+	assert.Equal(t, m.names["charger:wallbox"], lps[0].Named().Other["charger"])
+
+	grid, err := settings.String(keys.GridMeter)
+	require.NoError(t, err)
+	assert.Equal(t, m.names["meter:grid"], grid)
+
+	// repeated migration reports conflicts instead of duplicating devices
+	m, err = migrateDevices(conf)
+	require.NoError(t, err)
+	assert.Empty(t, m.migrated)
+	assert.Len(t, m.conflicts, 5)
+
+	meters, err = config.ConfigurationsByClass(templates.Meter)
+	require.NoError(t, err)
+	assert.Len(t, meters, 2)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/evcc-io/evcc/server/relay"
+	"github.com/spf13/cobra"
+)
+
+// relayCmd represents the relay command
+var relayCmd = &cobra.Command{
+	Use:   "relay",
+	Short: "Run remote access relay server",
+	Long: `Run a relay server forwarding public requests to connected evcc instances.
+Instances are addressed by the first label of the requested host name, e.g. home.relay.example.org.
+
+WARNING: the relay terminates TLS and can read and modify all forwarded traffic including
+passwords and session cookies. Only run it on infrastructure you fully trust.`,
+	Run: runRelay,
+}
+
+func init() {
+	rootCmd.AddCommand(relayCmd)
+
+	relayCmd.Flags().String("listen", ":7080", "Listen address")
+	relayCmd.Flags().StringToString("instance", nil, "Instance name and token, e.g. home=secret")
+	relayCmd.Flags().String("cert", "", "TLS certificate file")
+	relayCmd.Flags().String("key", "", "TLS private key file")
+}
+
+func runRelay(cmd *cobra.Command, args []string) {
+	instances, err := cmd.Flags().GetStringToString("instance")
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	handler, err := relay.NewServer(instances)
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	addr := cmd.Flag("listen").Value.String()
+	cert := cmd.Flag("cert").Value.String()
+	key := cmd.Flag("key").Value.String()
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ErrorLog:          log.ERROR,
+	}
+
+	log.INFO.Printf("relay listening at %s", addr)
+
+	if cert != "" {
+		err = srv.ListenAndServeTLS(cert, key)
+	} else {
+		err = srv.ListenAndServe()
+	}
+
+	log.FATAL.Fatal(err)
+}
@@ -16,13 +16,22 @@ import (
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/federation"
+	"github.com/evcc-io/evcc/server/homekit"
+	"github.com/evcc-io/evcc/server/modbus"
+	"github.com/evcc-io/evcc/server/ocpi"
 	"github.com/evcc-io/evcc/server/updater"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/auth"
 	"github.com/evcc-io/evcc/util/config"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/pipe"
 	"github.com/evcc-io/evcc/util/sponsor"
 	"github.com/evcc-io/evcc/util/telemetry"
+	"github.com/evcc-io/evcc/util/tracing"
 	_ "github.com/joho/godotenv/autoload"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
@@ -38,9 +47,9 @@ var (
 	log     = util.NewLogger("main")
 	cfgFile string
 
-	ignoreEmpty = ""                                      // ignore empty keys
-	ignoreLogs  = []string{"log"}                         // ignore log messages, including warn/error
-	ignoreMqtt  = []string{"log", "auth", "releaseNotes"} // excessive size may crash certain brokers
+	ignoreEmpty = ""                                                 // ignore empty keys
+	ignoreLogs  = []string{"log"}                                    // ignore log messages, including warn/error
+	ignoreMqtt  = []string{"log", "auth", "releaseNotes", "remotes"} // excessive size may crash certain brokers
 
 	viper *vpr.Viper
 
@@ -151,6 +160,27 @@ func runRoot(cmd *cobra.Command, args []string) {
 	socketHub := server.NewSocketHub()
 	httpd := server.NewHTTPd(fmt.Sprintf(":%d", conf.Network.Port), socketHub)
 
+	// https
+	if err == nil && conf.Network.TLS.Mode != "" {
+		err = configureTLS(conf, httpd)
+		conf.Network.Schema = "https"
+	}
+
+	// single sign-on
+	if err == nil && conf.OIDC.Issuer != "" {
+		err = configureOIDC(conf)
+	}
+
+	// tracing
+	if err == nil && conf.Tracing.Endpoint != "" {
+		err = tracing.Configure(conf.Tracing, server.FormattedVersion())
+	}
+
+	// remote access
+	if err == nil && conf.Relay.URI != "" {
+		err = configureRelay(conf.Relay, httpd.Handler)
+	}
+
 	// metrics
 	if viper.GetBool("metrics") {
 		httpd.Router().Handle("/metrics", promhttp.Handler())
@@ -161,6 +191,9 @@ func runRoot(cmd *cobra.Command, args []string) {
 		httpd.Router().PathPrefix("/debug/").Handler(http.DefaultServeMux)
 	}
 
+	// refresh provider tokens
+	go oauth.Tokens.Run(5 * time.Minute)
+
 	// publish to UI
 	go socketHub.Run(pipe.NewDropper(ignoreEmpty).Pipe(tee.Attach()), cache)
 
@@ -212,18 +245,91 @@ func runRoot(cmd *cobra.Command, args []string) {
 	valueChan <- util.Param{Key: keys.Mqtt, Val: conf.Mqtt}
 	valueChan <- util.Param{Key: keys.Influx, Val: conf.Influx}
 	valueChan <- util.Param{Key: keys.Hems, Val: conf.HEMS}
+	valueChan <- util.Param{Key: keys.Oidc, Val: auth.IdentityProvider() != nil}
 	// TODO
 	valueChan <- util.Param{Key: keys.Sponsor, Val: sponsor.Status()}
 
+	// setup prometheus publisher
+	if err == nil && viper.GetBool("metrics") {
+		var prom *server.Prometheus
+		if prom, err = server.NewPrometheus(prometheus.DefaultRegisterer); err == nil {
+			go prom.Run(site, pipe.NewDropper(ignoreEmpty).Pipe(tee.Attach()))
+		}
+	}
+
+	// setup measurement history
+	if err == nil && db.Instance != nil {
+		var history *server.History
+		if history, err = server.NewHistory(db.Instance); err == nil {
+			go history.Run(pipe.NewDropper(ignoreLogs...).Pipe(tee.Attach()))
+			httpd.RegisterHistoryHandler(history)
+		}
+	}
+
+	// setup remote sites
+	if err == nil && len(conf.Remotes) > 0 {
+		var fed *federation.Federation
+		if fed, err = federation.New(conf.Remotes); err == nil {
+			go fed.Run(valueChan, conf.Interval)
+			httpd.RegisterFederationHandlers(fed)
+		}
+	}
+
 	// setup mqtt publisher
 	if err == nil && conf.Mqtt.Broker != "" {
 		var mqtt *server.MQTT
-		mqtt, err = server.NewMQTT(strings.Trim(conf.Mqtt.Topic, "/"), site)
+		mqtt, err = server.NewMQTT(strings.Trim(conf.Mqtt.Topic, "/"), conf.Mqtt.Publish, site)
+		if err == nil && conf.Mqtt.Discovery != "" {
+			err = mqtt.PublishDiscovery(conf.Mqtt.Discovery, site)
+		}
 		if err == nil {
 			go mqtt.Run(site, pipe.NewDropper(append(ignoreMqtt, ignoreEmpty)...).Pipe(tee.Attach()))
 		}
 	}
 
+	// setup modbus server
+	if err == nil && conf.ModbusServer.Port != 0 {
+		var mbs *modbus.Server
+		if mbs, err = modbus.StartServer(conf.ModbusServer.Port, site, conf.ModbusServer.ReadOnly); err == nil {
+			go mbs.Run(pipe.NewDropper(ignoreEmpty).Pipe(tee.Attach()))
+		}
+	}
+
+	// setup grpc api
+	if err == nil && conf.Grpc.Port != 0 {
+		var grpc *server.Grpc
+		if grpc, err = server.NewGrpc(conf.Grpc.Port, site, cache, auth.New()); err == nil {
+			go grpc.Run(pipe.NewDropper(ignoreEmpty).Pipe(tee.Attach()))
+			go func() {
+				if err := grpc.Serve(); err != nil {
+					log.ERROR.Println(err)
+				}
+			}()
+		}
+	}
+
+	// setup homekit bridge
+	if err == nil && conf.HomeKit.Port != 0 {
+		var hk *homekit.Bridge
+		if hk, err = homekit.New(conf.HomeKit.Name, conf.HomeKit.Pin, site); err == nil {
+			go hk.Run(pipe.NewDropper(ignoreEmpty).Pipe(tee.Attach()))
+			go func() {
+				if err := hk.Serve(conf.HomeKit.Port); err != nil {
+					log.ERROR.Println(err)
+				}
+			}()
+		}
+	}
+
+	// setup ocpi interface
+	if err == nil && conf.Ocpi.Token != "" {
+		var cpo *ocpi.Server
+		if cpo, err = ocpi.New(conf.Ocpi, site); err == nil {
+			cpo.Register(httpd.Router())
+			go cpo.Run(pipe.NewDropper(ignoreEmpty).Pipe(tee.Attach()))
+		}
+	}
+
 	// announce on mDNS
 	if err == nil && strings.HasSuffix(conf.Network.Host, ".local") {
 		err = configureMDNS(conf.Network)
@@ -270,7 +376,7 @@ func runRoot(cmd *cobra.Command, args []string) {
 	// allow web access for vehicles
 	configureAuth(conf.Network, config.Instances(config.Vehicles().Devices()), httpd.Router(), valueChan)
 
-	httpd.RegisterSystemHandler(valueChan, cache, func() {
+	httpd.RegisterSystemHandler(valueChan, cache, cfgFile, func() {
 		log.INFO.Println("evcc was stopped by user. OS should restart the service. Or restart manually.")
 		once.Do(func() { close(stopC) }) // signal loop to end
 	})
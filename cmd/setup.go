@@ -14,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	paho "github.com/eclipse/paho.mqtt.golang"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/api/globalconfig"
@@ -24,6 +25,7 @@ import (
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/hems"
 	"github.com/evcc-io/evcc/meter"
+	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/provider/golang"
 	"github.com/evcc-io/evcc/provider/javascript"
 	"github.com/evcc-io/evcc/provider/mqtt"
@@ -34,8 +36,10 @@ import (
 	"github.com/evcc-io/evcc/server/eebus"
 	"github.com/evcc-io/evcc/server/modbus"
 	"github.com/evcc-io/evcc/server/oauth2redirect"
+	"github.com/evcc-io/evcc/server/relay"
 	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/auth"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/locale"
 	"github.com/evcc-io/evcc/util/machine"
@@ -46,6 +50,8 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/libp2p/zeroconf/v2"
+	"github.com/mitchellh/go-homedir"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
@@ -128,6 +134,7 @@ If you know what you're doing, you can run evcc ignoring the service database wi
 	// parse log levels after reading config
 	if err == nil {
 		parseLogLevels()
+		err = util.LogFormat(conf.LogFormat)
 	}
 
 	return err
@@ -346,19 +353,106 @@ func vehicleInstance(cc config.Named) (api.Vehicle, error) {
 		instance = vehicle.NewWrapper(cc.Name, cc.Type, cc.Other, err)
 	}
 
-	// ensure vehicle config has title
+	return vehicleTitle(cc, instance), nil
+}
+
+// vehicleTitle ensures vehicle config has title
+func vehicleTitle(cc config.Named, instance api.Vehicle) api.Vehicle {
 	if instance.Title() == "" {
 		//lint:ignore SA1019 as Title is safe on ascii
 		instance.SetTitle(strings.Title(cc.Name))
 	}
 
-	return instance, nil
+	return instance
+}
+
+// vehicleStartupTimeout is the max. time to wait for vehicle initialization at startup
+const vehicleStartupTimeout = 10 * time.Second
+
+// lazyVehicleInstance creates the vehicle, retrying failed cloud logins in background. If the vehicle is not
+// available within timeout, a pending wrapper is returned and replaced once the vehicle has been initialized.
+// Zero timeout waits for a single creation attempt.
+func lazyVehicleInstance(cc config.Named, timeout time.Duration, replace func(api.Vehicle)) (api.Vehicle, error) {
+	if timeout == 0 {
+		return vehicleInstance(cc)
+	}
+
+	ctx := util.WithLogger(context.TODO(), util.NewLogger(cc.Name))
+
+	bo := backoff.NewExponentialBackOff(backoff.WithInitialInterval(time.Minute), backoff.WithMaxInterval(15*time.Minute), backoff.WithMaxElapsedTime(0))
+
+	type result struct {
+		instance api.Vehicle
+		err      error
+	}
+	resC := make(chan result, 1)
+
+	go func() {
+		instance, err := backoff.RetryWithData(func() (api.Vehicle, error) {
+			instance, err := vehicle.NewFromConfig(ctx, cc.Type, cc.Other)
+			if err != nil {
+				var ce *util.ConfigError
+				if errors.As(err, &ce) {
+					return nil, backoff.Permanent(err)
+				}
+
+				log.ERROR.Printf("creating vehicle %s failed: %v", cc.Name, err)
+			}
+
+			return instance, err
+		}, bo)
+
+		resC <- result{instance, err}
+	}()
+
+	select {
+	case res := <-resC:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return vehicleTitle(cc, res.instance), nil
+
+	case <-time.After(timeout):
+		log.WARN.Printf("vehicle %s not available, continuing initialization in background", cc.Name)
+	}
+
+	go func() {
+		res := <-resC
+		if res.err != nil {
+			log.ERROR.Printf("creating vehicle %s failed: %v", cc.Name, res.err)
+			return
+		}
+
+		log.INFO.Printf("vehicle %s available", cc.Name)
+		replace(vehicleTitle(cc, res.instance))
+	}()
+
+	return vehicleTitle(cc, vehicle.NewPendingWrapper(cc.Name, cc.Type, cc.Other)), nil
 }
 
-func configureVehicles(static []config.Named, names ...string) error {
+// replaceVehicle replaces a pending vehicle device with the initialized one
+func replaceVehicle(dev config.Device[api.Vehicle]) {
+	h := config.Vehicles()
+
+	if err := h.Delete(dev.Config().Name); err != nil {
+		log.ERROR.Printf("replacing vehicle %s failed: %v", dev.Config().Name, err)
+		return
+	}
+
+	if err := h.Add(dev); err != nil {
+		log.ERROR.Printf("replacing vehicle %s failed: %v", dev.Config().Name, err)
+	}
+}
+
+// configureVehicles creates the vehicles. Vehicles not available within timeout are added as pending and replaced in background.
+func configureVehicles(static []config.Named, timeout time.Duration, names ...string) error {
 	var mu sync.Mutex
 	var eg errgroup.Group
 
+	// replace pending vehicles only after all vehicles have been added
+	registered := make(chan struct{})
+	defer close(registered)
+
 	// stable-sort vehicles by name
 	devs1 := make([]config.Device[api.Vehicle], 0, len(static))
 
@@ -376,7 +470,10 @@ func configureVehicles(static []config.Named, names ...string) error {
 		}
 
 		eg.Go(func() error {
-			instance, err := vehicleInstance(cc)
+			instance, err := lazyVehicleInstance(cc, timeout, func(instance api.Vehicle) {
+				<-registered
+				replaceVehicle(config.NewStaticDevice(cc, instance))
+			})
 			if err != nil {
 				return fmt.Errorf("cannot create vehicle '%s': %w", cc.Name, err)
 			}
@@ -406,7 +503,10 @@ func configureVehicles(static []config.Named, names ...string) error {
 				return nil
 			}
 
-			instance, err := vehicleInstance(cc)
+			instance, err := lazyVehicleInstance(cc, timeout, func(instance api.Vehicle) {
+				<-registered
+				replaceVehicle(config.NewConfigurableDevice(conf, instance))
+			})
 			if err != nil {
 				return fmt.Errorf("cannot create vehicle '%s': %w", cc.Name, err)
 			}
@@ -567,7 +667,7 @@ func configureInflux(conf *globalconfig.Influx) (*server.Influx, error) {
 	// 	}
 	// }
 
-	influx := server.NewInfluxClient(
+	return server.NewInfluxClient(
 		conf.URL,
 		conf.Token,
 		conf.Org,
@@ -575,9 +675,10 @@ func configureInflux(conf *globalconfig.Influx) (*server.Influx, error) {
 		conf.Password,
 		conf.Database,
 		conf.Insecure,
+		server.WithMeasurement(conf.Measurement),
+		server.WithTags(conf.Tags),
+		server.WithBuckets(conf.Buckets),
 	)
-
-	return influx, nil
 }
 
 // setup mqtt
@@ -688,6 +789,68 @@ func networkSettings(conf *globalconfig.Network) error {
 	return nil
 }
 
+// configureTLS enables https, storing generated certificates in the tls directory
+func configureTLS(conf globalconfig.All, httpd *server.HTTPd) error {
+	cc := conf.Network.TLS
+
+	dir, err := homedir.Expand(cmp.Or(cc.Dir, "~/.evcc/certs"))
+	if err != nil {
+		return err
+	}
+
+	var dnsRecord func(string) error
+	if cc.DNS != nil {
+		var plugin provider.Config
+		if err := util.DecodeOther(cc.DNS, &plugin); err != nil {
+			return fmt.Errorf("dns: %w", err)
+		}
+
+		if dnsRecord, err = provider.NewStringSetterFromConfig(context.TODO(), "record", plugin); err != nil {
+			return fmt.Errorf("dns: %w", err)
+		}
+	}
+
+	return httpd.ConfigureTLS(cc, conf.Network.Host, dir, dnsRecord)
+}
+
+// configureOIDC enables single sign-on using an external identity provider
+func configureOIDC(conf globalconfig.All) error {
+	cc := conf.OIDC
+	if cc.RedirectURL == "" {
+		cc.RedirectURL = conf.Network.URI() + "/api/auth/oidc/callback"
+	}
+
+	return auth.ConfigureOIDC(cc)
+}
+
+// configureRelay enables remote access via the relay server.
+// The relay exposes the ui to the internet, api authentication is therefore enforced.
+func configureRelay(conf relay.Config, handler http.Handler) error {
+	rc, err := relay.NewClient(conf)
+	if err != nil {
+		return err
+	}
+
+	authObject := auth.New()
+	if !authObject.IsApiAuthRequired() {
+		log.WARN.Println("relay: enabling api authentication")
+		if err := authObject.SetApiAuthRequired(true); err != nil {
+			return err
+		}
+	}
+
+	go rc.Run(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// api authentication may have been disabled at runtime
+		if !authObject.IsApiAuthRequired() {
+			http.Error(w, "api authentication disabled", http.StatusForbidden)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+
+	return nil
+}
+
 // setup MDNS
 func configureMDNS(conf globalconfig.Network) error {
 	host := strings.TrimSuffix(conf.Host, ".local")
@@ -756,12 +919,23 @@ func configureMessengers(conf globalconfig.Messaging, vehicles push.Vehicles, va
 		return messageChan, fmt.Errorf("failed configuring push services: %w", err)
 	}
 
+	localizer := i18n.NewLocalizer(locale.Bundle, conf.Language, locale.Language)
+	messageHub.SetDefaults(push.LocalizedTemplates(localizer))
+
 	for _, service := range conf.Services {
-		impl, err := push.NewFromConfig(context.TODO(), service.Type, service.Other)
+		impl, err := push.NewServiceFromConfig(context.TODO(), service.Type, service.Other)
 		if err != nil {
 			return messageChan, fmt.Errorf("failed configuring push service %s: %w", service.Type, err)
 		}
-		messageHub.Add(impl)
+		messageHub.AddService(impl)
+	}
+
+	for _, cc := range conf.Webhooks {
+		wh, err := push.NewWebhook(cc)
+		if err != nil {
+			return messageChan, fmt.Errorf("failed configuring webhook %s: %w", cc.URI, err)
+		}
+		messageHub.AddWebhook(wh)
 	}
 
 	go messageHub.Run(messageChan, valueChan)
@@ -797,7 +971,8 @@ func configureTariff(name string, conf config.Typed, t *api.Tariff) error {
 		return &DeviceError{name, err}
 	}
 
-	*t = res
+	// persist rates to survive restarts and provider outages
+	*t = tariff.NewCached("tariff."+name, res)
 	return nil
 }
 
@@ -828,6 +1003,7 @@ func configureTariffs(conf globalconfig.Tariffs) (*tariff.Tariffs, error) {
 	eg.Go(func() error { return configureTariff("feedin", conf.FeedIn, &tariffs.FeedIn) })
 	eg.Go(func() error { return configureTariff("co2", conf.Co2, &tariffs.Co2) })
 	eg.Go(func() error { return configureTariff("planner", conf.Planner, &tariffs.Planner) })
+	eg.Go(func() error { return configureTariff("solar", conf.Solar, &tariffs.Solar) })
 
 	if err := eg.Wait(); err != nil {
 		return nil, &ClassError{ClassTariff, err}
@@ -844,7 +1020,7 @@ func configureDevices(conf globalconfig.All) error {
 	if err := configureChargers(conf.Chargers); err != nil {
 		return &ClassError{ClassCharger, err}
 	}
-	if err := configureVehicles(conf.Vehicles); err != nil {
+	if err := configureVehicles(conf.Vehicles, vehicleStartupTimeout); err != nil {
 		return &ClassError{ClassVehicle, err}
 	}
 	if err := configureCircuits(conf.Circuits); err != nil {
@@ -972,21 +1148,42 @@ func configureSite(conf map[string]interface{}, loadpoints []*core.Loadpoint, ta
 }
 
 func configureLoadpoints(conf globalconfig.All) ([]*core.Loadpoint, error) {
-	if len(conf.Loadpoints) == 0 {
+	configurable, err := config.ConfigurationsByClass(templates.Loadpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	lps := slices.Clone(conf.Loadpoints)
+	for _, cc := range configurable {
+		lps = append(lps, cc.Named().Other)
+	}
+
+	if len(lps) == 0 {
 		return nil, errors.New("missing loadpoints")
 	}
 
 	var loadpoints []*core.Loadpoint
 
-	for id, cfg := range conf.Loadpoints {
+	for id, cfg := range lps {
 		log := util.NewLoggerWithLoadpoint("lp-"+strconv.Itoa(id+1), id+1)
 		settings := &core.Settings{Key: "lp" + strconv.Itoa(id+1) + "."}
 
+		// database loadpoints keep their settings by id when others are added or removed
+		i := id - len(conf.Loadpoints)
+		if i >= 0 {
+			settings.Key = "lpdb" + strconv.Itoa(configurable[i].ID) + "."
+		}
+
 		lp, err := core.NewLoadpointFromConfig(log, settings, cfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed configuring loadpoint: %w", err)
 		}
 
+		// database loadpoints can be updated at runtime
+		if i >= 0 {
+			server.RegisterConfigLoadpoint(configurable[i].ID, lp)
+		}
+
 		loadpoints = append(loadpoints, lp)
 	}
 
@@ -44,6 +44,7 @@ func runTariff(cmd *cobra.Command, args []string) {
 		"feedin":  conf.Tariffs.FeedIn,
 		"co2":     conf.Tariffs.Co2,
 		"planner": conf.Tariffs.Planner,
+		"solar":   conf.Tariffs.Solar,
 	} {
 		if cc.Type == "" || (name != "" && key != name) {
 			continue
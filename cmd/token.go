@@ -2,12 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
 	"slices"
 	"strings"
 
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/templates"
+	"github.com/evcc-io/evcc/vehicle/polestar"
+	"github.com/evcc-io/evcc/vehicle/psa"
+	"github.com/evcc-io/evcc/vehicle/tesla"
 	"github.com/samber/lo"
+	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
 	"golang.org/x/oauth2"
 )
@@ -23,12 +30,36 @@ func init() {
 	rootCmd.AddCommand(tokenCmd)
 }
 
+// authorizationCode prompts for the authorization code or the redirect url containing it
+func authorizationCode() (string, error) {
+	var res string
+	if err := survey.AskOne(&survey.Input{
+		Message: "Please enter the redirect url or authorization code:",
+	}, &res, survey.WithValidator(survey.Required)); err != nil {
+		return "", err
+	}
+
+	res = strings.TrimSpace(res)
+
+	if u, err := url.Parse(res); err == nil && u.Query().Has("code") {
+		return u.Query().Get("code"), nil
+	}
+
+	return res, nil
+}
+
 func runToken(cmd *cobra.Command, args []string) {
 	// load config
 	if err := loadConfigFile(&conf, !cmd.Flag(flagIgnoreDatabase).Changed); err != nil {
 		log.FATAL.Fatal(err)
 	}
 
+	// setup persistence for storing tokens
+	dbErr := configureDatabase(conf.Database)
+	if dbErr != nil {
+		log.WARN.Println("tokens will not be stored:", dbErr)
+	}
+
 	var vehicleConf config.Named
 	if len(conf.Vehicles) == 1 {
 		vehicleConf = conf.Vehicles[0]
@@ -52,6 +83,10 @@ func runToken(cmd *cobra.Command, args []string) {
 	var token *oauth2.Token
 	var err error
 
+	// settings key of the identity restoring the token
+	var settingsKey string
+	user := cast.ToString(vehicleConf.Other["user"])
+
 	isTemplate := strings.ToLower(vehicleConf.Type) == "template"
 	if isTemplate {
 		instance, err := templates.RenderInstance(templates.Vehicle, vehicleConf.Other)
@@ -66,13 +101,27 @@ func runToken(cmd *cobra.Command, args []string) {
 
 	switch typ {
 	case "mercedes":
-		token, err = mercedesToken()
+		token, settingsKey, err = mercedesToken()
 	case "ford", "ford-connect":
 		token, err = fordConnectToken(vehicleConf)
 	case "tronity":
 		token, err = tronityToken(conf, vehicleConf)
 	case "citroen", "ds", "opel", "peugeot":
 		token, err = psaToken(typ)
+		if user != "" {
+			settingsKey = psa.SettingsKey(typ, user)
+		}
+	case "tesla":
+		token, err = teslaToken()
+		if err == nil {
+			settingsKey, err = tesla.SettingsKey(token)
+		}
+	case "polestar":
+		if user == "" {
+			log.FATAL.Fatal("missing user")
+		}
+		token, err = polestarToken()
+		settingsKey = polestar.SettingsKey(user)
 
 	default:
 		log.FATAL.Fatalf("vehicle type '%s' does not support token authentication", vehicleConf.Type)
@@ -82,6 +131,26 @@ func runToken(cmd *cobra.Command, args []string) {
 		log.FATAL.Fatal(err)
 	}
 
+	if settingsKey != "" && dbErr == nil {
+		err := settings.SetJson(settingsKey, token)
+		if err == nil {
+			err = settings.Persist()
+		}
+
+		if err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		fmt.Println()
+		fmt.Println("Token stored in database:", conf.Database.Dsn)
+
+		// identity restores token from database
+		if typ != "tesla" {
+			fmt.Println("Tokens can be omitted from the vehicle config.")
+			return
+		}
+	}
+
 	fmt.Println()
 	fmt.Println("Add the following tokens to the vehicle config:")
 	fmt.Println()
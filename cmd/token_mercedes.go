@@ -42,27 +42,29 @@ func mercedesPinPrompt() (string, error) {
 	return strings.TrimSpace(code), nil
 }
 
-func mercedesToken() (*oauth2.Token, error) {
+func mercedesToken() (*oauth2.Token, string, error) {
 	// Get username and region from user to initiate the email process
 	username, region, err := mercedesUsernameAndRegionPrompt()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	api := mercedes.NewSetupAPI(log, username, region)
 	result, nonce, err := api.RequestPin()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if !result {
-		return nil, errors.New("unknown PinResponse - 200, result empty")
+		return nil, "", errors.New("unknown PinResponse - 200, result empty")
 	}
 
 	pin, err := mercedesPinPrompt()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return api.RequestAccessToken(*nonce, pin)
+	token, err := api.RequestAccessToken(*nonce, pin)
+
+	return token, mercedes.SettingsKey(username, region), err
 }
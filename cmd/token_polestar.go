@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/vehicle/polestar"
+	"github.com/samber/lo"
+	"golang.org/x/oauth2"
+)
+
+func polestarToken() (*oauth2.Token, error) {
+	state := lo.RandomString(16, lo.AlphanumericCharset)
+
+	fmt.Println("Please visit: ", polestar.AuthCodeURL(state))
+	fmt.Println("After login, the browser is redirected to the Polestar website. Copy its address.")
+
+	code, err := authorizationCode()
+	if err != nil {
+		return nil, err
+	}
+
+	return polestar.ExchangeCode(util.NewLogger("polestar"), code)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/vehicle/tesla"
+	"github.com/samber/lo"
+	"golang.org/x/oauth2"
+)
+
+func teslaToken() (*oauth2.Token, error) {
+	oc := tesla.OAuth2Config
+	if oc.ClientID == "" {
+		return nil, errors.New("missing client id, set TESLA_CLIENT_ID and TESLA_CLIENT_SECRET")
+	}
+
+	cv := oauth2.GenerateVerifier()
+	state := lo.RandomString(16, lo.AlphanumericCharset)
+
+	fmt.Println("Please visit: ", oc.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(cv)))
+	fmt.Println("After login, the browser is redirected to a blank page. Copy its address.")
+
+	code, err := authorizationCode()
+	if err != nil {
+		return nil, err
+	}
+
+	client := request.NewClient(util.NewLogger("tesla"))
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+
+	return oc.Exchange(ctx, code, oauth2.VerifierOption(cv))
+}
@@ -44,7 +44,7 @@ func runVehicle(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	if err := configureVehicles(conf.Vehicles, args...); err != nil {
+	if err := configureVehicles(conf.Vehicles, 0, args...); err != nil {
 		fatal(err)
 	}
 
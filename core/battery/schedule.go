@@ -0,0 +1,140 @@
+package battery
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// Config is the battery schedule configuration
+type Config struct {
+	Mode   string   `mapstructure:"mode"`   // normal, hold or charge
+	Soc    float64  `mapstructure:"soc"`    // hold below or grid charge up to this soc, zero for any soc
+	Days   []string `mapstructure:"days"`   // weekdays like mon or sun, empty for all days
+	Months []int    `mapstructure:"months"` // months 1-12, empty for all months
+	From   string   `mapstructure:"from"`   // start time of day hh:mm
+	To     string   `mapstructure:"to"`     // end time of day hh:mm, before start for windows spanning midnight
+}
+
+// Schedule is a recurring calendar window applying a battery mode
+type Schedule struct {
+	mode     api.BatteryMode
+	soc      float64
+	days     []time.Weekday
+	months   []time.Month
+	from, to time.Duration // time of day, both zero for all day
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// timeOfDay parses hh:mm into the duration since midnight
+func timeOfDay(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day: %s", s)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// NewFromConfig creates a battery schedule from configuration
+func NewFromConfig(cc Config) (*Schedule, error) {
+	mode, err := api.BatteryModeString(cc.Mode)
+	if err != nil || mode == api.BatteryUnknown {
+		return nil, fmt.Errorf("invalid mode: %s", cc.Mode)
+	}
+
+	if cc.Soc < 0 || cc.Soc > 100 {
+		return nil, errors.New("soc must be between 0 and 100")
+	}
+
+	s := &Schedule{
+		mode: mode,
+		soc:  cc.Soc,
+	}
+
+	for _, d := range cc.Days {
+		wd, ok := weekdays[strings.ToLower(d)[:min(3, len(d))]]
+		if !ok {
+			return nil, fmt.Errorf("invalid day: %s", d)
+		}
+		s.days = append(s.days, wd)
+	}
+
+	for _, m := range cc.Months {
+		if m < 1 || m > 12 {
+			return nil, fmt.Errorf("invalid month: %d", m)
+		}
+		s.months = append(s.months, time.Month(m))
+	}
+
+	if s.from, err = timeOfDay(cc.From); err != nil {
+		return nil, err
+	}
+
+	if s.to, err = timeOfDay(cc.To); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Active returns true if the schedule's calendar window contains t
+func (s *Schedule) Active(t time.Time) bool {
+	// windows spanning midnight belong to the day they start
+	day := t
+	tod := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if s.from > s.to && tod < s.to {
+		day = t.AddDate(0, 0, -1)
+	}
+
+	if len(s.months) > 0 && !slices.Contains(s.months, day.Month()) {
+		return false
+	}
+
+	if len(s.days) > 0 && !slices.Contains(s.days, day.Weekday()) {
+		return false
+	}
+
+	switch {
+	case s.from == s.to:
+		return true
+	case s.from < s.to:
+		return tod >= s.from && tod < s.to
+	default:
+		return tod >= s.from || tod < s.to
+	}
+}
+
+// Mode returns the battery mode required at given battery soc, or unknown if the schedule imposes no mode.
+// Hold applies at or below the schedule's soc, charge below the schedule's soc.
+func (s *Schedule) Mode(soc float64) api.BatteryMode {
+	switch s.mode {
+	case api.BatteryHold:
+		if s.soc > 0 && soc > s.soc {
+			return api.BatteryUnknown
+		}
+	case api.BatteryCharge:
+		if s.soc > 0 && soc >= s.soc {
+			return api.BatteryUnknown
+		}
+	}
+
+	return s.mode
+}
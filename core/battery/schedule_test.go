@@ -0,0 +1,77 @@
+package battery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleActive(t *testing.T) {
+	// saturday
+	sat := func(hh, mm int) time.Time {
+		return time.Date(2026, time.January, 3, hh, mm, 0, 0, time.Local)
+	}
+
+	for _, tc := range []struct {
+		cc  Config
+		ts  time.Time
+		res bool
+	}{
+		{Config{Mode: "hold"}, sat(12, 0), true},
+		{Config{Mode: "hold", Months: []int{11, 12, 1, 2}}, sat(12, 0), true},
+		{Config{Mode: "hold", Months: []int{6, 7}}, sat(12, 0), false},
+		{Config{Mode: "charge", Days: []string{"sat", "Sunday"}}, sat(12, 0), true},
+		{Config{Mode: "charge", Days: []string{"mon"}}, sat(12, 0), false},
+		{Config{Mode: "charge", From: "10:00", To: "14:00"}, sat(12, 0), true},
+		{Config{Mode: "charge", From: "10:00", To: "14:00"}, sat(14, 0), false},
+		// spanning midnight, belongs to friday
+		{Config{Mode: "charge", Days: []string{"fri"}, From: "22:00", To: "06:00"}, sat(2, 0), true},
+		{Config{Mode: "charge", Days: []string{"fri"}, From: "22:00", To: "06:00"}, sat(23, 0), false},
+		{Config{Mode: "charge", Days: []string{"sat"}, From: "22:00", To: "06:00"}, sat(23, 0), true},
+	} {
+		t.Logf("%+v %v", tc.cc, tc.ts)
+
+		s, err := NewFromConfig(tc.cc)
+		require.NoError(t, err)
+		assert.Equal(t, tc.res, s.Active(tc.ts))
+	}
+}
+
+func TestScheduleMode(t *testing.T) {
+	for _, tc := range []struct {
+		cc  Config
+		soc float64
+		res api.BatteryMode
+	}{
+		{Config{Mode: "hold"}, 80, api.BatteryHold},
+		{Config{Mode: "hold", Soc: 50}, 50, api.BatteryHold},
+		{Config{Mode: "hold", Soc: 50}, 51, api.BatteryUnknown},
+		{Config{Mode: "charge"}, 100, api.BatteryCharge},
+		{Config{Mode: "charge", Soc: 80}, 79, api.BatteryCharge},
+		{Config{Mode: "charge", Soc: 80}, 80, api.BatteryUnknown},
+		{Config{Mode: "normal"}, 10, api.BatteryNormal},
+	} {
+		t.Logf("%+v %.0f", tc.cc, tc.soc)
+
+		s, err := NewFromConfig(tc.cc)
+		require.NoError(t, err)
+		assert.Equal(t, tc.res, s.Mode(tc.soc))
+	}
+}
+
+func TestScheduleConfig(t *testing.T) {
+	for _, cc := range []Config{
+		{},
+		{Mode: "unknown"},
+		{Mode: "hold", Soc: 101},
+		{Mode: "hold", Days: []string{"xyz"}},
+		{Mode: "hold", Months: []int{13}},
+		{Mode: "hold", From: "25:00"},
+	} {
+		_, err := NewFromConfig(cc)
+		assert.Error(t, err, "%+v", cc)
+	}
+}
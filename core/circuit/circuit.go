@@ -2,6 +2,7 @@ package circuit
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -30,6 +31,8 @@ type Circuit struct {
 	maxPower      float64                 // max allowed power
 	getMaxCurrent func() (float64, error) // dynamic max allowed current
 	getMaxPower   func() (float64, error) // dynamic max allowed power
+	currentLimit  *float64                // runtime max current limit
+	powerLimit    *float64                // runtime max power limit
 
 	current float64
 	power   float64
@@ -170,8 +173,16 @@ func (c *Circuit) HasMeter() bool {
 	return c.meter != nil
 }
 
-// GetMaxPower returns the max power setting
-func (c *Circuit) GetMaxPower() float64 {
+// limited applies the runtime limit to the configured value, zero meaning unlimited
+func limited(res float64, limit *float64) float64 {
+	if limit != nil && (res == 0 || *limit < res) {
+		return *limit
+	}
+	return res
+}
+
+// configuredMaxPower returns the configured or dynamic max power
+func (c *Circuit) configuredMaxPower() float64 {
 	if c.getMaxPower != nil {
 		res, err := c.getMaxPower()
 		if err == nil {
@@ -185,6 +196,15 @@ func (c *Circuit) GetMaxPower() float64 {
 	return c.maxPower
 }
 
+// GetMaxPower returns the max power setting
+func (c *Circuit) GetMaxPower() float64 {
+	res := c.configuredMaxPower()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return limited(res, c.powerLimit)
+}
+
 // SetMaxPower sets the max power
 func (c *Circuit) SetMaxPower(power float64) {
 	c.mu.Lock()
@@ -192,8 +212,8 @@ func (c *Circuit) SetMaxPower(power float64) {
 	c.maxPower = power
 }
 
-// GetMaxCurrent returns the max current setting
-func (c *Circuit) GetMaxCurrent() float64 {
+// configuredMaxCurrent returns the configured or dynamic max current
+func (c *Circuit) configuredMaxCurrent() float64 {
 	if c.getMaxCurrent != nil {
 		res, err := c.getMaxCurrent()
 		if err == nil {
@@ -207,6 +227,15 @@ func (c *Circuit) GetMaxCurrent() float64 {
 	return c.maxCurrent
 }
 
+// GetMaxCurrent returns the max current setting
+func (c *Circuit) GetMaxCurrent() float64 {
+	res := c.configuredMaxCurrent()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return limited(res, c.currentLimit)
+}
+
 // SetMaxCurrent sets the max current
 func (c *Circuit) SetMaxCurrent(current float64) {
 	c.mu.Lock()
@@ -214,6 +243,59 @@ func (c *Circuit) SetMaxCurrent(current float64) {
 	c.maxCurrent = current
 }
 
+// GetMaxPowerLimit returns the runtime max power limit
+func (c *Circuit) GetMaxPowerLimit() *float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.powerLimit
+}
+
+// SetMaxPowerLimit sets the runtime max power limit. The limit must be positive and not exceed the configured max power. Nil removes the limit.
+func (c *Circuit) SetMaxPowerLimit(power *float64) error {
+	if power != nil {
+		if *power <= 0 {
+			return errors.New("max power limit must be positive")
+		}
+		if bound := c.configuredMaxPower(); bound > 0 && *power > bound {
+			return fmt.Errorf("max power limit exceeds configured max power %.5gW", bound)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.powerLimit = power
+
+	return nil
+}
+
+// GetMaxCurrentLimit returns the runtime max current limit
+func (c *Circuit) GetMaxCurrentLimit() *float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.currentLimit
+}
+
+// SetMaxCurrentLimit sets the runtime max current limit. The limit must be positive and not exceed the configured max current. Nil removes the limit.
+func (c *Circuit) SetMaxCurrentLimit(current *float64) error {
+	if current != nil {
+		if *current <= 0 {
+			return errors.New("max current limit must be positive")
+		}
+		if bound := c.configuredMaxCurrent(); bound > 0 && *current > bound {
+			return fmt.Errorf("max current limit exceeds configured max current %.3gA", bound)
+		}
+		if _, ok := c.meter.(api.PhaseCurrents); c.meter != nil && !ok {
+			return errors.New("meter does not support phase currents")
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.currentLimit = current
+
+	return nil
+}
+
 // RegisterChild registers child circuit
 func (c *Circuit) RegisterChild(child api.Circuit) {
 	c.children = append(c.children, child)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -129,3 +130,26 @@ func TestCircuitCurrents(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestCircuitLimits(t *testing.T) {
+	c, err := New(util.NewLogger("foo"), "foo", 0, 10000, nil, 0)
+	require.NoError(t, err)
+
+	// bounds
+	assert.Error(t, c.SetMaxPowerLimit(lo.ToPtr(0.0)))
+	assert.Error(t, c.SetMaxPowerLimit(lo.ToPtr(11000.0)))
+	assert.Equal(t, 10000.0, c.GetMaxPower())
+
+	// tighten
+	require.NoError(t, c.SetMaxPowerLimit(lo.ToPtr(4000.0)))
+	assert.Equal(t, 4000.0, c.GetMaxPower())
+	assert.Equal(t, 4000.0, c.ValidatePower(0, 5000))
+
+	// restore configured value
+	require.NoError(t, c.SetMaxPowerLimit(nil))
+	assert.Equal(t, 10000.0, c.GetMaxPower())
+
+	// limit without configured value
+	require.NoError(t, c.SetMaxCurrentLimit(lo.ToPtr(16.0)))
+	assert.Equal(t, 16.0, c.GetMaxCurrent())
+}
@@ -0,0 +1,226 @@
+package consumer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// Config is the consumer configuration
+type Config struct {
+	Title        string        `mapstructure:"title"`        // display name
+	Charger      string        `mapstructure:"charger"`      // switchable charger reference, e.g. smart plug
+	Meter        string        `mapstructure:"meter"`        // optional meter reference, defaults to the charger if metered
+	NominalPower float64       `mapstructure:"nominalPower"` // power required for switching on, max power of modulated consumers
+	Priority     int           `mapstructure:"priority"`     // priority relative to loadpoints and other consumers
+	PrioritySoc  float64       `mapstructure:"prioritySoc"`  // battery has priority below this soc, defaults to site priority soc
+	MinRuntime   time.Duration `mapstructure:"minRuntime"`   // min runtime once switched on
+}
+
+// Consumer is a switchable or continuously modulated device drawing power from pv surplus
+type Consumer struct {
+	mu    sync.RWMutex
+	log   *util.Logger
+	clock clock.Clock
+
+	title        string
+	charger      api.Charger
+	meter        api.Meter
+	setter       api.PowerSetter // modulated consumer
+	nominalPower float64
+	priority     int
+	prioritySoc  float64
+	minRuntime   time.Duration
+
+	enabled  bool
+	power    float64
+	setpoint float64 // modulated power
+	switched time.Time
+}
+
+// NewFromConfig creates a consumer from configuration
+func NewFromConfig(log *util.Logger, cc Config) (*Consumer, error) {
+	if cc.Charger == "" {
+		return nil, errors.New("missing charger")
+	}
+
+	dev, err := config.Chargers().ByName(cc.Charger)
+	if err != nil {
+		return nil, err
+	}
+	charger := dev.Instance()
+
+	var meter api.Meter
+	if cc.Meter != "" {
+		dev, err := config.Meters().ByName(cc.Meter)
+		if err != nil {
+			return nil, err
+		}
+		meter = dev.Instance()
+	}
+
+	title := cc.Title
+	if title == "" {
+		title = cc.Charger
+	}
+
+	return New(log, clock.New(), title, charger, meter, cc.NominalPower, cc.Priority, cc.PrioritySoc, cc.MinRuntime)
+}
+
+// New creates a consumer. If meter is nil, the charger's power is used if metered or else the nominal power.
+func New(log *util.Logger, clock clock.Clock, title string, charger api.Charger, meter api.Meter, nominalPower float64, priority int, prioritySoc float64, minRuntime time.Duration) (*Consumer, error) {
+	if nominalPower <= 0 {
+		return nil, errors.New("nominal power must be positive")
+	}
+
+	if meter == nil {
+		meter, _ = charger.(api.Meter)
+	}
+
+	setter, _ := api.As[api.PowerSetter](charger)
+
+	c := &Consumer{
+		log:          log,
+		clock:        clock,
+		title:        title,
+		charger:      charger,
+		meter:        meter,
+		setter:       setter,
+		nominalPower: nominalPower,
+		priority:     priority,
+		prioritySoc:  prioritySoc,
+		minRuntime:   minRuntime,
+	}
+
+	return c, nil
+}
+
+// Title returns the consumer's display name
+func (c *Consumer) Title() string {
+	return c.title
+}
+
+// Priority returns the consumer's priority
+func (c *Consumer) Priority() int {
+	return c.priority
+}
+
+// PrioritySoc returns the battery soc below which the battery has priority, zero meaning the site's priority soc
+func (c *Consumer) PrioritySoc() float64 {
+	return c.prioritySoc
+}
+
+// NominalPower returns the power required for switching on
+func (c *Consumer) NominalPower() float64 {
+	return c.nominalPower
+}
+
+// Modulated returns true if the consumer's power can be set continuously
+func (c *Consumer) Modulated() bool {
+	return c.setter != nil
+}
+
+// Update reads the consumer's switch state and power
+func (c *Consumer) Update() error {
+	enabled, err := c.charger.Enabled()
+	if err != nil {
+		return fmt.Errorf("enabled: %w", err)
+	}
+
+	var power float64
+	if c.meter != nil {
+		if power, err = c.meter.CurrentPower(); err != nil {
+			return fmt.Errorf("power: %w", err)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.meter == nil && enabled {
+		power = c.nominalPower
+		if c.setter != nil {
+			power = c.setpoint
+		}
+	}
+
+	// switched externally
+	if enabled != c.enabled {
+		c.switched = c.clock.Now()
+	}
+
+	c.enabled = enabled
+	c.power = power
+
+	return nil
+}
+
+// Enabled returns the consumer's switch state
+func (c *Consumer) Enabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.enabled
+}
+
+// Power returns the consumer's power
+func (c *Consumer) Power() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.power
+}
+
+// CanDisable returns true if the min runtime has elapsed since switching on
+func (c *Consumer) CanDisable() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.clock.Since(c.switched) >= c.minRuntime
+}
+
+// SetEnabled switches the consumer
+func (c *Consumer) SetEnabled(enable bool) error {
+	if err := c.charger.Enable(enable); err != nil {
+		return err
+	}
+
+	c.log.DEBUG.Printf("consumer %s: enabled %t", c.title, enable)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.enabled = enable
+	c.switched = c.clock.Now()
+
+	return nil
+}
+
+// SetPower sets the power of a modulated consumer between zero and nominal power
+func (c *Consumer) SetPower(power float64) error {
+	if c.setter == nil {
+		return errors.New("not modulated")
+	}
+
+	power = min(max(power, 0), c.nominalPower)
+	if err := c.setter.SetPower(power); err != nil {
+		return err
+	}
+
+	c.log.DEBUG.Printf("consumer %s: power %.0fW", c.title, power)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setpoint = power
+
+	if enabled := power > 0; enabled != c.enabled {
+		c.enabled = enabled
+		c.switched = c.clock.Now()
+	}
+
+	return nil
+}
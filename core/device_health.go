@@ -0,0 +1,134 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/push"
+)
+
+const (
+	evDeviceOffline = "offline" // device failing
+	evDeviceOnline  = "online"  // device recovered
+
+	deviceHealthDebounce = 5 * time.Minute
+)
+
+// deviceHealth tracks device failures. State changes are only reported
+// once they have persisted for the debounce period to avoid flapping.
+type deviceHealth struct {
+	mu       sync.Mutex
+	clock    clock.Clock
+	debounce time.Duration
+	status   map[string]*deviceState
+}
+
+type deviceState struct {
+	failed      bool      // reported state
+	since       time.Time // start of unreported state change
+	lastSuccess time.Time
+	failures    int // consecutive failures
+	err         error
+}
+
+func newDeviceHealth(clock clock.Clock, debounce time.Duration) *deviceHealth {
+	return &deviceHealth{
+		clock:    clock,
+		debounce: debounce,
+		status:   make(map[string]*deviceState),
+	}
+}
+
+// update records the device's status and returns the event to send if its state has changed
+func (h *deviceHealth) update(device string, err error) (push.Event, bool) {
+	if h == nil || device == "" {
+		return push.Event{}, false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.status[device]
+	if !ok {
+		s = new(deviceState)
+		h.status[device] = s
+	}
+
+	s.err = err
+	if err == nil {
+		s.lastSuccess = h.clock.Now()
+		s.failures = 0
+	} else {
+		s.failures++
+	}
+
+	if failed := err != nil; failed == s.failed {
+		s.since = time.Time{}
+		return push.Event{}, false
+	}
+
+	now := h.clock.Now()
+	if s.since.IsZero() {
+		s.since = now
+	}
+
+	if now.Sub(s.since) < h.debounce {
+		return push.Event{}, false
+	}
+
+	s.failed = !s.failed
+	s.since = time.Time{}
+
+	if !s.failed {
+		return push.Event{Event: evDeviceOnline, Device: device}, true
+	}
+
+	return push.Event{Event: evDeviceOffline, Device: device, Error: err.Error()}, true
+}
+
+// devices returns the status of all tracked devices
+func (h *deviceHealth) devices() []site.DeviceStatus {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	res := make([]site.DeviceStatus, 0, len(h.status))
+
+	for device, s := range h.status {
+		status := site.DeviceStatus{
+			Device:      device,
+			Status:      site.DeviceDegraded,
+			LastSuccess: s.lastSuccess,
+			Failures:    s.failures,
+		}
+
+		switch {
+		case !s.failed && s.failures == 0:
+			status.Status = site.DeviceOk
+		case s.failed && s.failures > 0:
+			status.Status = site.DeviceError
+		}
+
+		if s.err != nil {
+			status.Error = s.err.Error()
+		}
+
+		res = append(res, status)
+	}
+
+	return res
+}
+
+func sortDevices(res []site.DeviceStatus) []site.DeviceStatus {
+	slices.SortFunc(res, func(a, b site.DeviceStatus) int {
+		return cmp.Compare(a.Device, b.Device)
+	})
+	return res
+}
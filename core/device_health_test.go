@@ -0,0 +1,89 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceHealth(t *testing.T) {
+	clock := clock.NewMock()
+	h := newDeviceHealth(clock, 5*time.Minute)
+	errFoo := errors.New("foo")
+
+	_, ok := h.update("grid", nil)
+	assert.False(t, ok, "healthy")
+
+	_, ok = h.update("grid", errFoo)
+	assert.False(t, ok, "failure debounced")
+
+	clock.Add(3 * time.Minute)
+	_, ok = h.update("grid", nil)
+	assert.False(t, ok, "recovered within debounce")
+
+	_, ok = h.update("grid", errFoo)
+	assert.False(t, ok, "debounce restarted")
+
+	clock.Add(3 * time.Minute)
+	_, ok = h.update("grid", errFoo)
+	assert.False(t, ok, "still debounced")
+
+	clock.Add(2 * time.Minute)
+	ev, ok := h.update("grid", errFoo)
+	assert.True(t, ok, "offline")
+	assert.Equal(t, evDeviceOffline, ev.Event)
+	assert.Equal(t, "grid", ev.Device)
+	assert.Equal(t, "foo", ev.Error)
+
+	_, ok = h.update("grid", errFoo)
+	assert.False(t, ok, "offline reported once")
+
+	_, ok = h.update("pv", errFoo)
+	assert.False(t, ok, "devices are independent")
+
+	_, ok = h.update("grid", nil)
+	assert.False(t, ok, "recovery debounced")
+
+	clock.Add(5 * time.Minute)
+	ev, ok = h.update("grid", nil)
+	assert.True(t, ok, "online")
+	assert.Equal(t, evDeviceOnline, ev.Event)
+	assert.Empty(t, ev.Error)
+}
+
+func TestDeviceHealthStatus(t *testing.T) {
+	clock := clock.NewMock()
+	h := newDeviceHealth(clock, 5*time.Minute)
+	errFoo := errors.New("foo")
+
+	h.update("grid", nil)
+	start := clock.Now()
+
+	clock.Add(time.Minute)
+	h.update("grid", errFoo)
+	h.update("pv", nil)
+
+	res := sortDevices(h.devices())
+	require.Len(t, res, 2)
+	assert.Equal(t, site.DeviceStatus{Device: "grid", Status: site.DeviceDegraded, LastSuccess: start, Failures: 1, Error: "foo"}, res[0])
+	assert.Equal(t, site.DeviceOk, res[1].Status)
+
+	clock.Add(5 * time.Minute)
+	h.update("grid", errFoo)
+
+	res = sortDevices(h.devices())
+	assert.Equal(t, site.DeviceError, res[0].Status)
+	assert.Equal(t, 2, res[0].Failures)
+
+	h.update("grid", nil)
+
+	res = sortDevices(h.devices())
+	assert.Equal(t, site.DeviceDegraded, res[0].Status, "recovery debounced")
+	assert.Zero(t, res[0].Failures)
+	assert.Empty(t, res[0].Error)
+}
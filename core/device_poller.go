@@ -0,0 +1,163 @@
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// devicePoller reads a device in its own goroutine. Slow devices don't block
+// the control loop which continues using the last result instead.
+type devicePoller[T any] struct {
+	mu       sync.Mutex
+	read     func() (T, error)
+	interval time.Duration // min. time between reads, zero for every update
+	done     chan struct{} // closed when the current read has completed, nil if idle
+	val      T
+	err      error
+	updated  time.Time
+}
+
+func newDevicePoller[T any](read func() (T, error), interval time.Duration) *devicePoller[T] {
+	return &devicePoller[T]{read: read, interval: interval}
+}
+
+// cached returns the last value if it is younger than the poll interval
+func (p *devicePoller[T]) cached() (T, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ok := p.err == nil && !p.updated.IsZero() && time.Since(p.updated) < p.interval
+	return p.val, ok
+}
+
+// trigger starts a read unless one is already in progress
+func (p *devicePoller[T]) trigger() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.done == nil {
+		done := make(chan struct{})
+		p.done = done
+
+		go func() {
+			val, err := p.read()
+
+			p.mu.Lock()
+			p.done = nil
+			p.err = err
+			if err == nil {
+				p.val = val
+				p.updated = time.Now()
+			}
+			p.mu.Unlock()
+
+			close(done)
+		}()
+	}
+
+	return p.done
+}
+
+// Get triggers a read and waits up to timeout for its result, using zero for no timeout.
+// If the read does not complete in time, the last result is returned unless older than maxAge after the poll interval.
+// Within the poll interval, the last result is returned without reading the device.
+func (p *devicePoller[T]) Get(timeout, maxAge time.Duration) (T, error) {
+	if val, ok := p.cached(); ok {
+		return val, nil
+	}
+
+	done := p.trigger()
+
+	if timeout == 0 {
+		<-done
+	} else {
+		select {
+		case <-done:
+		case <-time.After(timeout):
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	select {
+	case <-done:
+		return p.val, p.err
+	default:
+	}
+
+	// read still in progress
+	var zero T
+	if p.err != nil {
+		return zero, p.err
+	}
+	if p.updated.IsZero() || time.Since(p.updated) > p.interval+maxAge {
+		return zero, api.ErrTimeout
+	}
+
+	return p.val, nil
+}
+
+// vehiclePoller reads the vehicle apis in per-device workers
+type vehiclePoller struct {
+	api.Vehicle
+	timeout, maxAge time.Duration
+	soc             *devicePoller[float64]
+	limitSoc        *devicePoller[int64]            // nil if not supported
+	rng             *devicePoller[int64]            // nil if not supported
+	status          *devicePoller[api.ChargeStatus] // nil if not supported
+}
+
+func newVehiclePoller(v api.Vehicle, timeout, maxAge time.Duration) *vehiclePoller {
+	p := &vehiclePoller{
+		Vehicle: v,
+		timeout: timeout,
+		maxAge:  maxAge,
+		soc:     newDevicePoller(v.Soc, 0),
+	}
+
+	if vs, ok := v.(api.SocLimiter); ok {
+		p.limitSoc = newDevicePoller(vs.GetLimitSoc, 0)
+	}
+
+	if vr, ok := v.(api.VehicleRange); ok {
+		p.rng = newDevicePoller(vr.Range, 0)
+	}
+
+	if vs, ok := v.(api.ChargeState); ok {
+		p.status = newDevicePoller(vs.Status, 0)
+	}
+
+	return p
+}
+
+// Soc implements the api.Vehicle interface
+func (p *vehiclePoller) Soc() (float64, error) {
+	return p.soc.Get(p.timeout, p.maxAge)
+}
+
+// GetLimitSoc returns the vehicle limit soc or api.ErrNotAvailable if not supported
+func (p *vehiclePoller) GetLimitSoc() (int64, error) {
+	if p.limitSoc == nil {
+		return 0, api.ErrNotAvailable
+	}
+	return p.limitSoc.Get(p.timeout, p.maxAge)
+}
+
+// Range returns the vehicle range or api.ErrNotAvailable if not supported
+func (p *vehiclePoller) Range() (int64, error) {
+	if p.rng == nil {
+		return 0, api.ErrNotAvailable
+	}
+	return p.rng.Get(p.timeout, p.maxAge)
+}
+
+// Status returns the vehicle charge status or api.ErrNotAvailable if not supported
+func (p *vehiclePoller) Status() (api.ChargeStatus, error) {
+	if p.status == nil {
+		return api.StatusNone, api.ErrNotAvailable
+	}
+	return p.status.Get(p.timeout, p.maxAge)
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDevicePoller(t *testing.T) {
+	release := make(chan struct{})
+	var val float64
+	var err error
+
+	p := newDevicePoller(func() (float64, error) {
+		<-release
+		val++
+		return val, err
+	}, 0)
+
+	// no timeout waits for result
+	close(release)
+	res, e := p.Get(0, time.Minute)
+	require.NoError(t, e)
+	assert.Equal(t, 1.0, res)
+
+	// slow read returns last value
+	release = make(chan struct{})
+	res, e = p.Get(time.Millisecond, time.Minute)
+	require.NoError(t, e)
+	assert.Equal(t, 1.0, res)
+
+	// read is still in progress and not triggered again
+	res, e = p.Get(time.Millisecond, time.Minute)
+	require.NoError(t, e)
+	assert.Equal(t, 1.0, res)
+
+	// last value too old
+	_, e = p.Get(time.Millisecond, 0)
+	assert.ErrorIs(t, e, api.ErrTimeout)
+
+	// complete pending read
+	done := p.trigger()
+	close(release)
+	<-done
+
+	res, e = p.Get(0, time.Minute)
+	require.NoError(t, e)
+	assert.Equal(t, 3.0, res)
+
+	// errors are returned
+	err = errors.New("foo")
+	_, e = p.Get(0, time.Minute)
+	assert.Equal(t, err, e)
+}
+
+func TestDevicePollerInterval(t *testing.T) {
+	var val float64
+
+	p := newDevicePoller(func() (float64, error) {
+		val++
+		return val, nil
+	}, time.Hour)
+
+	res, err := p.Get(0, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, res)
+
+	// device is not read again within interval
+	res, err = p.Get(0, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, res)
+
+	// interval elapsed
+	p.updated = p.updated.Add(-time.Hour)
+
+	res, err = p.Get(0, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, res)
+}
+
+func TestVehiclePoller(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	v := api.NewMockVehicle(ctrl)
+	v.EXPECT().Soc().Return(50.0, nil)
+
+	p := newVehiclePoller(v, 0, time.Minute)
+
+	res, err := p.Soc()
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, res)
+
+	// optional apis not supported
+	_, err = p.GetLimitSoc()
+	assert.ErrorIs(t, err, api.ErrNotAvailable)
+
+	_, err = p.Range()
+	assert.ErrorIs(t, err, api.ErrNotAvailable)
+
+	_, err = p.Status()
+	assert.ErrorIs(t, err, api.ErrNotAvailable)
+}
@@ -1,5 +1,11 @@
 package core
 
+import (
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/samber/lo"
+)
+
 // EnergyMetrics calculates stats about the charged energy and gives you details about price or co2s
 type EnergyMetrics struct {
 	totalKWh          float64  // Total amount of energy used (kWh)
@@ -9,10 +15,14 @@ type EnergyMetrics struct {
 	currentGreenShare float64  // Current share of solar energy of site (0-1)
 	currentPrice      *float64 // Current price per kWh
 	currentCo2        *float64 // Current co2 emissions
+	slots             []session.Slot
+	clock             clock.Clock
 }
 
 func NewEnergyMetrics() *EnergyMetrics {
-	em := &EnergyMetrics{}
+	em := &EnergyMetrics{
+		clock: clock.New(),
+	}
 	em.Reset()
 
 	return em
@@ -53,15 +63,40 @@ func (em *EnergyMetrics) Update(chargedKWh float64) (float64, float64) {
 		}
 		em.co2 = &newCo2
 	}
+	em.updateSlot(added, addedGreen)
 	return added, addedGreen
 }
 
+// updateSlot adds the energy to the current slot
+func (em *EnergyMetrics) updateSlot(added, addedGreen float64) {
+	start := em.clock.Now().Truncate(session.SlotDuration)
+	if len(em.slots) == 0 || !em.slots[len(em.slots)-1].Start.Equal(start) {
+		em.slots = append(em.slots, session.Slot{Start: start, End: start.Add(session.SlotDuration)})
+	}
+
+	s := &em.slots[len(em.slots)-1]
+	prev := s.ChargedEnergy
+	s.ChargedEnergy += added
+	s.SolarPercentage = (s.SolarPercentage/100*prev + addedGreen) / s.ChargedEnergy * 100
+
+	if em.currentPrice != nil {
+		price := lo.FromPtr(s.Price) + *em.currentPrice*added
+		s.Price = &price
+		s.PricePerKWh = lo.ToPtr(price / s.ChargedEnergy)
+	}
+	if em.currentCo2 != nil {
+		co2 := lo.FromPtr(s.Co2PerKWh)*prev + *em.currentCo2*added
+		s.Co2PerKWh = lo.ToPtr(co2 / s.ChargedEnergy)
+	}
+}
+
 // Reset sets all calculations to initial values
 func (em *EnergyMetrics) Reset() {
 	em.totalKWh = 0
 	em.solarKWh = 0
 	em.price = nil
 	em.co2 = nil
+	em.slots = nil
 }
 
 // TotalWh returns the total energy in Wh
@@ -103,6 +138,11 @@ func (em *EnergyMetrics) Co2PerKWh() *float64 {
 	return &co2
 }
 
+// Slots returns the charged energy per slot
+func (em *EnergyMetrics) Slots() []session.Slot {
+	return append([]session.Slot(nil), em.slots...)
+}
+
 // Publish publishes metrics with a given prefix
 func (em *EnergyMetrics) Publish(prefix string, p publisher) {
 	p.publish(prefix+"Energy", em.TotalWh())
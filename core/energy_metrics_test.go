@@ -2,6 +2,12 @@ package core
 
 import (
 	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func isEqualFloat64(a, b *float64) bool {
@@ -153,3 +159,40 @@ func TestEnergyMetrics(t *testing.T) {
 		t.Errorf("Metrics not properly reset %+v", s)
 	}
 }
+
+func TestEnergyMetricsSlots(t *testing.T) {
+	clock := clock.NewMock()
+	clock.Set(time.Date(2025, 1, 1, 12, 5, 0, 0, time.UTC))
+
+	s := NewEnergyMetrics()
+	s.clock = clock
+
+	s.SetEnvironment(1, lo.ToPtr(0.2), lo.ToPtr(100.0))
+	s.Update(1)
+
+	s.SetEnvironment(0, lo.ToPtr(0.4), lo.ToPtr(300.0))
+	s.Update(2)
+
+	clock.Add(15 * time.Minute)
+	s.SetEnvironment(0, nil, nil)
+	s.Update(4)
+
+	slots := s.Slots()
+	require.Len(t, slots, 2)
+
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), slots[0].Start)
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 15, 0, 0, time.UTC), slots[0].End)
+	assert.Equal(t, 2.0, slots[0].ChargedEnergy)
+	assert.Equal(t, 50.0, slots[0].SolarPercentage)
+	assert.InDelta(t, 0.6, *slots[0].Price, 1e-9)
+	assert.InDelta(t, 0.3, *slots[0].PricePerKWh, 1e-9)
+	assert.InDelta(t, 200, *slots[0].Co2PerKWh, 1e-9)
+
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 15, 0, 0, time.UTC), slots[1].Start)
+	assert.Equal(t, 2.0, slots[1].ChargedEnergy)
+	assert.Nil(t, slots[1].Price)
+	assert.Nil(t, slots[1].Co2PerKWh)
+
+	s.Reset()
+	assert.Empty(t, s.Slots())
+}
@@ -1,6 +1,8 @@
 package keys
 
 const (
-	AdminPassword = "adminPassword"
-	JwtSecret     = "jwtSecretKey"
+	AdminPassword   = "adminPassword"
+	JwtSecret       = "jwtSecretKey"
+	ApiKeys         = "apiKeys"
+	ApiAuthRequired = "apiAuthRequired"
 )
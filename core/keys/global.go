@@ -3,6 +3,7 @@ package keys
 const (
 	Interval           = "interval"
 	PasswordConfigured = "passwordConfigured"
+	Oidc               = "oidc"
 	Sponsor            = "sponsor"
 	SponsorToken       = "sponsorToken"
 	Network            = "network"
@@ -10,6 +11,7 @@ const (
 	Influx             = "influx"
 	EEBus              = "eebus"
 	Hems               = "hems"
+	HomeKit            = "homekit"
 	Messaging          = "messaging"
 	ModbusProxy        = "modbusproxy"
 	Tariffs            = "tariffs"
@@ -34,6 +34,7 @@ const (
 	// smart charging
 	SmartCostActive    = "smartCostActive"    // smart cost active
 	SmartCostLimit     = "smartCostLimit"     // smart cost limit
+	SmartCo2Limit      = "smartCo2Limit"      // smart co2 limit
 	SmartCostNextStart = "smartCostNextStart" // smart cost next start
 
 	// effective values
@@ -71,9 +72,21 @@ const (
 	RemoteDisabled       = "remoteDisabled"       // remote disabled
 	RemoteDisabledSource = "remoteDisabledSource" // remote disabled source
 
+	// pause
+	PauseReason = "pauseReason" // external pause reason
+	PauseUntil  = "pauseUntil"  // external pause expiry
+
+	// plan optimization
+	PlanOptimization = "planOptimization" // plan optimization target (cost/co2)
+
+	// temporary mode
+	ModeRestore = "modeRestore" // charge mode restored after temporary mode
+	ModeUntil   = "modeUntil"   // temporary mode expiry
+
 	// vehicle
 	VehicleName            = "vehicleName"            // vehicle name
 	VehicleIdentity        = "vehicleIdentity"        // vehicle identity
+	User                   = "user"                   // identified user
 	VehicleDetectionActive = "vehicleDetectionActive" // vehicle detection active
 	VehicleOdometer        = "vehicleOdometer"        // vehicle odometer
 	VehicleRange           = "vehicleRange"           // vehicle range
@@ -4,21 +4,26 @@ const (
 	Aux                   = "aux"
 	AuxPower              = "auxPower"
 	Currency              = "currency"
+	DryRun                = "dryRun"
 	GreenShareHome        = "greenShareHome"
 	GreenShareLoadpoints  = "greenShareLoadpoints"
 	GridConfigured        = "gridConfigured"
+	GridConsumptionLimit  = "gridConsumptionLimit"
 	GridCurrents          = "gridCurrents"
 	GridEnergy            = "gridEnergy"
 	GridPower             = "gridPower"
 	GridPowers            = "gridPowers"
+	GridProductionLimit   = "gridProductionLimit"
 	HomePower             = "homePower"
 	PrioritySoc           = "prioritySoc"
 	Pv                    = "pv"
 	PvEnergy              = "pvEnergy"
 	PvPower               = "pvPower"
+	Remotes               = "remotes"
 	ResidualPower         = "residualPower"
 	SiteTitle             = "siteTitle"
 	SmartCostType         = "smartCostType"
+	SolarForecast         = "solarForecast"
 	Statistics            = "statistics"
 	TariffCo2             = "tariffCo2"
 	TariffCo2Home         = "tariffCo2Home"
@@ -27,8 +32,10 @@ const (
 	TariffGrid            = "tariffGrid"
 	TariffPriceHome       = "tariffPriceHome"
 	TariffPriceLoadpoints = "tariffPriceLoadpoints"
+	Users                 = "users"
 	Vehicles              = "vehicles"
 	Circuits              = "circuits"
+	Consumers             = "consumers"
 	Ext                   = "ext"
 
 	// meters
@@ -38,10 +45,17 @@ const (
 	ExtMeters     = "extMeters"
 	AuxMeters     = "auxMeters"
 
+	// circuit settings
+	CircuitMaxPower   = "maxPower"
+	CircuitMaxCurrent = "maxCurrent"
+
 	// battery settings
 	BatteryCapacity         = "batteryCapacity"
 	BatteryDischargeControl = "batteryDischargeControl"
 	BatteryGridChargeLimit  = "batteryGridChargeLimit"
+	BatteryGridChargeHours  = "batteryGridChargeHours"
+	BatteryReserve          = "batteryReserve"
+	ExportLimit             = "exportLimit"
 	BatteryGridChargeActive = "batteryGridChargeActive"
 	BufferSoc               = "bufferSoc"
 	BufferStartSoc          = "bufferStartSoc"
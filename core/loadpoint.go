@@ -6,6 +6,7 @@ import (
 	"math"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -21,6 +22,7 @@ import (
 	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/soc"
+	"github.com/evcc-io/evcc/core/user"
 	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/core/wrapper"
 	"github.com/evcc-io/evcc/provider"
@@ -39,6 +41,8 @@ const (
 	evVehicleDisconnect   = "disconnect" // vehicle disconnected
 	evVehicleSoc          = "soc"        // vehicle soc progress
 	evVehicleUnidentified = "guest"      // vehicle unidentified
+	evPlanStart           = "planstart"  // charge plan started
+	evChargerError        = "error"      // charger failed
 
 	pvTimer   = "pv"
 	pvEnable  = "enable"
@@ -67,8 +71,9 @@ var elapsed = time.Unix(0, 1)
 
 // PollConfig defines the vehicle polling mode and interval
 type PollConfig struct {
-	Mode     string        `mapstructure:"mode"`     // polling mode charging (default), connected, always
-	Interval time.Duration `mapstructure:"interval"` // interval when not charging
+	Mode             string        `mapstructure:"mode"`             // polling mode charging (default), connected, always
+	Interval         time.Duration `mapstructure:"interval"`         // interval when not charging
+	ChargingInterval time.Duration `mapstructure:"chargingInterval"` // interval when charging, zero for every update
 }
 
 // SocConfig defines soc settings, estimation and update behavior
@@ -111,12 +116,20 @@ type Loadpoint struct {
 	vmu   sync.RWMutex   // guard vehicle
 	Mode_ api.ChargeMode `mapstructure:"mode"` // Default charge mode, used for disconnect
 
-	Title_          string `mapstructure:"title"`    // UI title
-	Priority_       int    `mapstructure:"priority"` // Priority
-	CircuitRef      string `mapstructure:"circuit"`  // Circuit reference
-	ChargerRef      string `mapstructure:"charger"`  // Charger reference
-	VehicleRef      string `mapstructure:"vehicle"`  // Vehicle reference
-	MeterRef        string `mapstructure:"meter"`    // Charge meter reference
+	SmartCostLimit_ *float64 `mapstructure:"smartCostLimit"` // Default smart cost limit
+	SmartCo2Limit_  *float64 `mapstructure:"smartCo2Limit"`  // Default smart co2 limit
+	BufferSoc_      *float64 `mapstructure:"bufferSoc"`      // Default battery buffer soc, overrides site
+	BufferStartSoc_ *float64 `mapstructure:"bufferStartSoc"` // Default battery buffer start soc, overrides site
+
+	Title_          string        `mapstructure:"title"`          // UI title
+	Priority_       int           `mapstructure:"priority"`       // Priority
+	CircuitRef      string        `mapstructure:"circuit"`        // Circuit reference
+	ChargerRef      string        `mapstructure:"charger"`        // Charger reference
+	VehicleRef      string        `mapstructure:"vehicle"`        // Vehicle reference
+	MeterRef        string        `mapstructure:"meter"`          // Charge meter reference
+	RampRate        *float64      `mapstructure:"rampRate"`       // Max current increase per update (A)
+	Interval        time.Duration `mapstructure:"interval"`       // Charge meter update interval, zero for every update
+	PlanContinuous  *float64      `mapstructure:"planContinuous"` // Prefer continuous plans within this cost tolerance
 	Soc             SocConfig
 	Enable, Disable ThresholdConfig
 
@@ -132,6 +145,9 @@ type Loadpoint struct {
 	limitSoc         int      // Session limit for soc
 	limitEnergy      float64  // Session limit for energy
 	smartCostLimit   *float64 // always charge if cost is below this value
+	smartCo2Limit    *float64 // always charge if co2 is below this value
+	bufferSoc        *float64 // battery buffer soc, nil for site setting
+	bufferStartSoc   *float64 // battery buffer start soc, nil for site setting
 	batteryBoost     int      // battery boost state
 
 	mode                api.ChargeMode
@@ -139,14 +155,23 @@ type Loadpoint struct {
 	phases              int       // Charger enabled phases, guarded by mutex
 	measuredPhases      int       // Charger physically measured phases
 	chargeCurrent       float64   // Charger current limit
+	rampPower           float64   // Power of last ramped current increase
+	rampBasePower       float64   // Charge power before last ramped current increase
 	socUpdated          time.Time // Soc updated timestamp (poll: connected)
+	chargeMeterUpdated  time.Time // Charge power updated timestamp (interval)
 	vehicleDetect       time.Time // Vehicle connected timestamp
 	chargerSwitched     time.Time // Charger enabled/disabled timestamp
 	phasesSwitched      time.Time // Phase switch timestamp
 	vehicleDetectTicker *clock.Ticker
 	vehicleIdentifier   string
 
+	users       user.Users // configured users
+	requireAuth bool       // refuse unknown identifiers
+	user        *user.User // identified user
+	authRefused bool       // identifier refused, charging blocked until disconnect
+
 	charger          api.Charger
+	dryRun           bool // compute decisions without controlling the charger
 	chargeTimer      api.ChargeTimer
 	chargeRater      api.ChargeRater
 	chargedAtStartup float64 // session energy at startup
@@ -157,17 +182,28 @@ type Loadpoint struct {
 	defaultVehicle api.Vehicle // Default vehicle (disables detection)
 	coordinator    coordinator.API
 	socEstimator   *soc.Estimator
+	vehiclePoller  *vehiclePoller // reads active vehicle in device workers
+	pollTimeout    time.Duration  // max. wait for vehicle reads, then use last value
+	pollMaxAge     time.Duration  // max. age of last vehicle value
 
 	// charge planning
-	planner     *planner.Planner
-	planTime    time.Time // time goal
-	planEnergy  float64   // Plan charge energy in kWh (dumb vehicles)
-	planSlotEnd time.Time // current plan slot end time
-	planActive  bool      // charge plan exists and has a currently active slot
+	planner          *planner.Planner
+	co2Planner       *planner.Planner           // co2 optimized planner, nil without co2 forecast
+	planOptimization loadpoint.PlanOptimization // plan optimization target
+	planTime         time.Time                  // time goal
+	planEnergy       float64                    // Plan charge energy in kWh (dumb vehicles)
+	planSlotEnd      time.Time                  // current plan slot end time
+	planActive       bool                       // charge plan exists and has a currently active slot
 
 	// cached state
 	status         api.ChargeStatus       // Charger status
+	chargerFailed  bool                   // Charger status could not be read
+	health         *deviceHealth          // charger and vehicle failure notifications
 	remoteDemand   loadpoint.RemoteDemand // External status demand
+	pauseReason    loadpoint.PauseReason  // External pause reason
+	pauseUntil     time.Time              // External pause expiry, zero for indefinite
+	modeRestore    api.ChargeMode         // Charge mode to restore after temporary mode
+	modeUntil      time.Time              // Temporary mode expiry
 	chargePower    float64                // Charging power
 	chargeCurrents []float64              // Phase currents
 	connectedTime  time.Time              // Time when vehicle was connected
@@ -199,6 +235,10 @@ func NewLoadpointFromConfig(log *util.Logger, settings *Settings, other map[stri
 		return nil, err
 	}
 
+	if lp.RampRate != nil && *lp.RampRate <= 0 {
+		return nil, fmt.Errorf("invalid ramp rate: %.3gA", *lp.RampRate)
+	}
+
 	// set vehicle polling mode
 	switch lp.Soc.Poll.Mode = strings.ToLower(lp.Soc.Poll.Mode); lp.Soc.Poll.Mode {
 	case pollCharging:
@@ -284,6 +324,17 @@ func NewLoadpointFromConfig(log *util.Logger, settings *Settings, other map[stri
 		lp.mode = api.ModeOff
 	}
 
+	// smart charging defaults, may be overridden by settings
+	lp.smartCostLimit = lp.SmartCostLimit_
+	lp.smartCo2Limit = lp.SmartCo2Limit_
+
+	// battery buffer defaults, may be overridden by settings
+	if err := validateBufferSocs(lp.BufferSoc_, lp.BufferStartSoc_); err != nil {
+		return nil, err
+	}
+	lp.bufferSoc = lp.BufferSoc_
+	lp.bufferStartSoc = lp.BufferStartSoc_
+
 	return lp, nil
 }
 
@@ -313,11 +364,23 @@ func NewLoadpoint(log *util.Logger, settings *Settings) *Loadpoint {
 		progress:      NewProgress(0, 10),     // soc progress indicator
 		coordinator:   coordinator.NewDummy(), // dummy vehicle coordinator
 		tasks:         util.NewQueue[Task](),  // task queue
+		health:        newDeviceHealth(clock, deviceHealthDebounce),
 	}
 
 	return lp
 }
 
+// settingsLimit returns a persisted optional limit. An empty value means the limit has been disabled.
+func (lp *Loadpoint) settingsLimit(key string) (*float64, error) {
+	s, err := lp.settings.String(key)
+	if err != nil || s == "" {
+		return nil, err
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	return &v, err
+}
+
 // restoreSettings restores loadpoint settings
 func (lp *Loadpoint) restoreSettings() {
 	if testing.Testing() {
@@ -342,8 +405,30 @@ func (lp *Loadpoint) restoreSettings() {
 	if v, err := lp.settings.Float(keys.LimitEnergy); err == nil && v > 0 {
 		lp.setLimitEnergy(v)
 	}
-	if v, err := lp.settings.Float(keys.SmartCostLimit); err == nil {
-		lp.SetSmartCostLimit(&v)
+	if v, err := lp.settingsLimit(keys.SmartCostLimit); err == nil {
+		lp.SetSmartCostLimit(v)
+	}
+	if v, err := lp.settingsLimit(keys.SmartCo2Limit); err == nil {
+		lp.SetSmartCo2Limit(v)
+	}
+	if v, err := lp.settingsLimit(keys.BufferSoc); err == nil && validateBufferSoc(v) == nil {
+		lp.bufferSoc = v
+	}
+	if v, err := lp.settingsLimit(keys.BufferStartSoc); err == nil && validateBufferSoc(v) == nil {
+		lp.bufferStartSoc = v
+	}
+	if v, err := lp.settings.String(keys.ModeRestore); err == nil && v != "" {
+		if t, err := lp.settings.Time(keys.ModeUntil); err == nil {
+			lp.setModeOverride(api.ChargeMode(v), t)
+		}
+	}
+	if v, err := lp.settings.Int(keys.PlanOptimization); err == nil {
+		lp.planOptimization = loadpoint.PlanOptimization(v)
+	}
+	if v, err := lp.settings.Int(keys.PauseReason); err == nil && v > 0 {
+		if t, err := lp.settings.Time(keys.PauseUntil); err == nil {
+			lp.setPause(loadpoint.PauseReason(v), t)
+		}
 	}
 
 	t, err1 := lp.settings.Time(keys.PlanTime)
@@ -418,6 +503,36 @@ func (lp *Loadpoint) pushEvent(event string) {
 	lp.pushChan <- push.Event{Event: event}
 }
 
+// updateDeviceHealth sends push messages if the device has started failing or recovered
+func (lp *Loadpoint) updateDeviceHealth(device string, err error) {
+	if ev, ok := lp.health.update(device, err); ok {
+		lp.pushChan <- ev
+	}
+}
+
+// updateVehicleHealth sends push messages if the vehicle api has started failing or recovered
+func (lp *Loadpoint) updateVehicleHealth(err error) {
+	if v := lp.GetVehicle(); v != nil {
+		lp.updateDeviceHealth(vehicle.Settings(lp.log, v).Name(), err)
+	}
+}
+
+// setChargerError sends the error event if the charger has started failing
+func (lp *Loadpoint) setChargerError(err error) {
+	lp.updateDeviceHealth(lp.ChargerRef, err)
+
+	failed := err != nil
+	if failed == lp.chargerFailed {
+		return
+	}
+
+	lp.chargerFailed = failed
+
+	if failed {
+		lp.pushChan <- push.Event{Event: evChargerError, Error: err.Error()}
+	}
+}
+
 // publish sends values to UI and databases
 func (lp *Loadpoint) publish(key string, val interface{}) {
 	// test helper
@@ -428,6 +543,15 @@ func (lp *Loadpoint) publish(key string, val interface{}) {
 	lp.uiChan <- util.Param{Key: key, Val: val}
 }
 
+// publishPtr publishes the pointer's value or nil if not set
+func (lp *Loadpoint) publishPtr(key string, val *float64) {
+	if val == nil {
+		lp.publish(key, nil)
+	} else {
+		lp.publish(key, *val)
+	}
+}
+
 // evChargeStartHandler sends external start event
 func (lp *Loadpoint) evChargeStartHandler() {
 	lp.log.INFO.Println("start charging ->")
@@ -526,6 +650,8 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 
 	// remove charger vehicle id and stop potential detection
 	lp.setVehicleIdentifier("")
+	lp.setUser(nil)
+	lp.authRefused = false
 	lp.stopVehicleDetection()
 
 	// set default mode on disconnect
@@ -665,6 +791,14 @@ func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 	lp.publish(keys.PlanEnergy, lp.planEnergy)
 	lp.publish(keys.LimitSoc, lp.limitSoc)
 	lp.publish(keys.LimitEnergy, lp.limitEnergy)
+	lp.publishPtr(keys.SmartCostLimit, lp.smartCostLimit)
+	lp.publishPtr(keys.SmartCo2Limit, lp.smartCo2Limit)
+	lp.publishPtr(keys.BufferSoc, lp.bufferSoc)
+	lp.publishPtr(keys.BufferStartSoc, lp.bufferStartSoc)
+	lp.publish(keys.PlanOptimization, lp.planOptimization)
+	lp.publish(keys.PauseReason, lp.pauseReason)
+	lp.publishPauseUntil()
+	lp.publishModeUntil()
 
 	// battery boost
 	lp.publish(keys.BatteryBoost, lp.batteryBoost != boostDisabled)
@@ -695,6 +829,11 @@ func (lp *Loadpoint) setAndPublishEnabled(enabled bool) {
 
 // syncCharger updates charger status and synchronizes it with expectations
 func (lp *Loadpoint) syncCharger() error {
+	// charger state is simulated in dry run mode
+	if lp.dryRun {
+		return nil
+	}
+
 	enabled, err := lp.charger.Enabled()
 	if err != nil {
 		return fmt.Errorf("charger enabled: %w", err)
@@ -829,6 +968,39 @@ func (lp *Loadpoint) roundedCurrent(chargeCurrent float64) float64 {
 	return chargeCurrent
 }
 
+// rampedCurrent limits current increases to the configured ramp rate per update.
+// Current reductions are applied immediately to protect circuit and grid limits.
+func (lp *Loadpoint) rampedCurrent(chargeCurrent, minCurrent float64) float64 {
+	if lp.RampRate == nil || chargeCurrent <= lp.chargeCurrent || chargeCurrent < minCurrent {
+		return chargeCurrent
+	}
+
+	ramp := *lp.RampRate
+	if lp.coarseCurrent() {
+		ramp = max(ramp, 1)
+	}
+
+	// start ramping at min current when charger is disabled
+	if limit := lp.roundedCurrent(max(lp.chargeCurrent+ramp, minCurrent)); limit < chargeCurrent {
+		lp.log.DEBUG.Printf("ramp charge current: %.3gA (target %.3gA)", limit, chargeCurrent)
+		return limit
+	}
+
+	return chargeCurrent
+}
+
+// rampLagPower returns the part of the last ramped current increase not yet drawn by the vehicle
+func (lp *Loadpoint) rampLagPower() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	if lp.rampPower == 0 || lp.status != api.StatusC {
+		return 0
+	}
+
+	return min(max(lp.rampBasePower+lp.rampPower-lp.chargePower, 0), lp.rampPower)
+}
+
 // setLimit applies charger current limits and enables/disables accordingly
 func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
 	chargeCurrent = lp.roundedCurrent(chargeCurrent)
@@ -850,10 +1022,15 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
 		return fmt.Errorf("invalid config: min current %.3gA exceeds max current %.3gA", effMinCurrent, effMaxCurrent)
 	}
 
+	// smooth current increases
+	chargeCurrent = lp.rampedCurrent(chargeCurrent, effMinCurrent)
+
 	// set current
 	if chargeCurrent != lp.chargeCurrent && chargeCurrent >= effMinCurrent {
 		var err error
-		if charger, ok := lp.charger.(api.ChargerEx); ok {
+		if charger, ok := lp.charger.(api.ChargerEx); lp.dryRun {
+			lp.log.INFO.Printf("dry run: max charge current %.3gA", chargeCurrent)
+		} else if ok {
 			err = charger.MaxCurrentMillis(chargeCurrent)
 		} else {
 			err = lp.charger.MaxCurrent(int64(chargeCurrent))
@@ -874,13 +1051,28 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
 		}
 
 		lp.log.DEBUG.Printf("max charge current: %.3gA", chargeCurrent)
+
+		// remember ramped increase until drawn by the vehicle
+		lp.rampPower, lp.rampBasePower = 0, 0
+		if lp.RampRate != nil && chargeCurrent > lp.chargeCurrent {
+			lp.rampPower = currentToPower(chargeCurrent-lp.chargeCurrent, lp.ActivePhases())
+			lp.rampBasePower = lp.chargePower
+		}
+
 		lp.chargeCurrent = chargeCurrent
 		lp.bus.Publish(evChargeCurrent, chargeCurrent)
 	}
 
 	// set enabled/disabled
 	if enabled := chargeCurrent >= effMinCurrent; enabled != lp.enabled {
-		if err := lp.charger.Enable(enabled); err != nil {
+		var err error
+		if lp.dryRun {
+			lp.log.INFO.Printf("dry run: charger %s", status[enabled])
+		} else {
+			err = lp.charger.Enable(enabled)
+		}
+
+		if err != nil {
 			v := lp.GetVehicle()
 			if vv, ok := v.(api.Resurrector); enabled && ok && errors.Is(err, api.ErrAsleep) {
 				// https://github.com/evcc-io/evcc/issues/8254
@@ -951,6 +1143,22 @@ func (lp *Loadpoint) remainingLimitEnergy() (float64, bool) {
 		limit > 0 && !lp.socBasedPlanning()
 }
 
+// publishRemainingLimitEnergy publishes remaining energy and duration for vehicles with energy target
+func (lp *Loadpoint) publishRemainingLimitEnergy() {
+	remaining, ok := lp.remainingLimitEnergy()
+	if !ok {
+		return
+	}
+
+	var d time.Duration
+	if lp.charging() && lp.chargePower > 0 {
+		d = time.Duration(remaining * 1e3 / lp.chargePower * float64(time.Hour)).Round(time.Second)
+	}
+
+	lp.SetRemainingDuration(d)
+	lp.SetRemainingEnergy(1e3 * remaining)
+}
+
 // limitEnergyReached checks if target is configured and reached
 func (lp *Loadpoint) limitEnergyReached() bool {
 	f, ok := lp.remainingLimitEnergy()
@@ -1163,7 +1371,9 @@ func (lp *Loadpoint) scalePhases(phases int) error {
 
 	if lp.GetPhases() != phases {
 		// switch phases
-		if err := cp.Phases1p3p(phases); err != nil {
+		if lp.dryRun {
+			lp.log.INFO.Printf("dry run: switch phases %dp", phases)
+		} else if err := cp.Phases1p3p(phases); err != nil {
 			return fmt.Errorf("switch phases: %w", err)
 		}
 
@@ -1443,10 +1653,16 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower, batteryBoostPo
 
 // UpdateChargePowerAndCurrents updates charge meter power and currents for load management
 func (lp *Loadpoint) UpdateChargePowerAndCurrents() float64 {
+	// use cached values within update interval
+	if lp.Interval > 0 && lp.clock.Since(lp.chargeMeterUpdated) < lp.Interval {
+		return lp.GetChargePower()
+	}
+
 	power, err := backoff.RetryWithData(lp.chargeMeter.CurrentPower, bo())
 	if err == nil {
 		lp.Lock()
 		lp.chargePower = power // update value if no error
+		lp.chargeMeterUpdated = lp.clock.Now()
 		lp.Unlock()
 
 		lp.log.DEBUG.Printf("charge power: %.0fW", power)
@@ -1584,7 +1800,7 @@ func (lp *Loadpoint) publishChargeProgress() {
 	// TODO deprecated: use sessionEnergy instead
 	lp.publish(keys.ChargedEnergy, lp.getChargedEnergy())
 	lp.publish(keys.ChargeDuration, lp.chargeDuration)
-	if _, ok := lp.chargeMeter.(api.MeterEnergy); ok {
+	if api.Has[api.MeterEnergy](lp.chargeMeter) {
 		lp.publish(keys.ChargeTotalImport, lp.chargeMeterTotal())
 	}
 }
@@ -1596,7 +1812,7 @@ func (lp *Loadpoint) publishSocAndRange() {
 	// guard for socEstimator removed by api
 	// also keep a local copy in order to avoid race conditions
 	// https://github.com/evcc-io/evcc/issues/16180
-	socEstimator := lp.socEstimator
+	socEstimator, vehiclePoller := lp.socEstimator, lp.vehiclePoller
 	if socEstimator == nil || (!lp.vehicleHasSoc() && err != nil) {
 		// This is a workaround for heaters. Without vehicle, the soc estimator is not initialized.
 		// We need to check if the charger can provide soc and use it if available.
@@ -1605,6 +1821,8 @@ func (lp *Loadpoint) publishSocAndRange() {
 			lp.publish(keys.VehicleSoc, lp.vehicleSoc)
 		}
 
+		lp.publishRemainingLimitEnergy()
+
 		return
 	}
 
@@ -1617,11 +1835,14 @@ func (lp *Loadpoint) publishSocAndRange() {
 				lp.socUpdated = time.Time{}
 			} else {
 				lp.log.ERROR.Printf("vehicle soc: %v", err)
+				lp.updateVehicleHealth(err)
 			}
 
 			return
 		}
 
+		lp.updateVehicleHealth(nil)
+
 		lp.vehicleSoc = f
 		lp.log.DEBUG.Printf("vehicle soc: %.0f%%", lp.vehicleSoc)
 		lp.publish(keys.VehicleSoc, lp.vehicleSoc)
@@ -1629,8 +1850,8 @@ func (lp *Loadpoint) publishSocAndRange() {
 		// vehicle target soc
 		// TODO take vehicle api limits into account
 		apiLimitSoc := 100
-		if vs, ok := lp.GetVehicle().(api.SocLimiter); ok {
-			if limit, err := vs.GetLimitSoc(); err == nil {
+		if vehiclePoller != nil {
+			if limit, err := vehiclePoller.GetLimitSoc(); err == nil {
 				apiLimitSoc = int(limit)
 				lp.log.DEBUG.Printf("vehicle soc limit: %d%%", limit)
 				// https://github.com/evcc-io/evcc/issues/13349
@@ -1652,11 +1873,11 @@ func (lp *Loadpoint) publishSocAndRange() {
 		lp.SetRemainingEnergy(1e3 * socEstimator.RemainingChargeEnergy(limitSoc))
 
 		// range
-		if vs, ok := lp.GetVehicle().(api.VehicleRange); ok {
-			if rng, err := vs.Range(); err == nil {
+		if vehiclePoller != nil {
+			if rng, err := vehiclePoller.Range(); err == nil {
 				lp.log.DEBUG.Printf("vehicle range: %dkm", rng)
 				lp.publish(keys.VehicleRange, rng)
-			} else {
+			} else if !errors.Is(err, api.ErrNotAvailable) {
 				lp.log.ERROR.Printf("vehicle range: %v", err)
 			}
 		}
@@ -1716,14 +1937,14 @@ func (lp *Loadpoint) phaseSwitchCompleted() bool {
 }
 
 // Update is the main control function. It reevaluates meters and charger state
-func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rates, batteryBuffered, batteryStart bool, greenShare float64, effPrice, effCo2 *float64) {
+func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates, co2Rates api.Rates, batteryBuffered, batteryStart bool, greenShare float64, effPrice, effCo2 *float64) {
 	// smart cost
-	smartCostActive := lp.smartCostActive(rates)
+	smartCostActive := lp.smartCostActive(rates, co2Rates)
 	lp.publish(keys.SmartCostActive, smartCostActive)
 
 	var smartCostNextStart time.Time
 	if !smartCostActive {
-		smartCostNextStart = lp.smartCostNextStart(rates)
+		smartCostNextStart = lp.smartCostNextStart(rates, co2Rates)
 	}
 	lp.publish(keys.SmartCostNextStart, smartCostNextStart)
 
@@ -1746,6 +1967,7 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 
 	// read and publish status
 	welcomeCharge, err := lp.updateChargerStatus()
+	lp.setChargerError(err)
 	if err != nil {
 		lp.log.ERROR.Println(err)
 		return
@@ -1787,12 +2009,18 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 	// track if remote disabled is actually active
 	remoteDisabled := loadpoint.RemoteEnable
 
+	// restore charge mode after temporary mode has expired
+	lp.expireModeOverride()
+
 	mode := lp.GetMode()
 	lp.publish(keys.Mode, mode)
 
 	// update and publish plan without being short-circuited by modes etc.
 	plannerActive := lp.plannerActive()
 
+	// remove expired external pause
+	lp.expirePause()
+
 	// execute loading strategy
 	switch {
 	case !lp.connected():
@@ -1803,6 +2031,12 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 	case lp.scalePhasesRequired():
 		err = lp.scalePhases(lp.configuredPhases)
 
+	case lp.paused():
+		err = lp.setLimit(0)
+
+	case lp.authRefused:
+		err = lp.setLimit(0)
+
 	case lp.remoteControlled(loadpoint.RemoteHardDisable):
 		remoteDisabled = loadpoint.RemoteHardDisable
 		fallthrough
@@ -28,6 +28,8 @@ type API interface {
 
 	// Title returns the defined loadpoint title
 	Title() string
+	// SetTitle sets the loadpoint title
+	SetTitle(string)
 	// GetPriority returns the priority
 	GetPriority() int
 	// SetPriority sets the priority
@@ -45,6 +47,10 @@ type API interface {
 	GetMode() api.ChargeMode
 	// SetMode sets the charge mode
 	SetMode(api.ChargeMode)
+	// GetModeOverride returns the charge mode restored after the temporary mode and its expiry
+	GetModeOverride() (api.ChargeMode, time.Time)
+	// SetTemporaryMode sets the charge mode for the given duration after which the previous mode is restored
+	SetTemporaryMode(api.ChargeMode, time.Duration) error
 	// GetPhases returns the enabled phases
 	GetPhases() int
 	// SetPhases sets the enabled phases
@@ -92,6 +98,10 @@ type API interface {
 	SocBasedPlanning() bool
 	// GetPlan creates a charging plan
 	GetPlan(targetTime time.Time, requiredDuration time.Duration) (api.Rates, error)
+	// GetPlanOptimization returns the plan optimization target
+	GetPlanOptimization() PlanOptimization
+	// SetPlanOptimization sets the plan optimization target
+	SetPlanOptimization(PlanOptimization) error
 
 	// GetEnableThreshold gets the loadpoint enable threshold
 	GetEnableThreshold() float64
@@ -111,6 +121,15 @@ type API interface {
 	// SetDisableDelay sets loadpoint disable delay
 	SetDisableDelay(delay time.Duration)
 
+	// GetBufferSoc returns the battery buffer soc or nil if site setting applies
+	GetBufferSoc() *float64
+	// SetBufferSoc sets the battery buffer soc, nil to use site setting
+	SetBufferSoc(*float64) error
+	// GetBufferStartSoc returns the battery buffer start soc or nil if site setting applies
+	GetBufferStartSoc() *float64
+	// SetBufferStartSoc sets the battery buffer start soc, nil to use site setting
+	SetBufferStartSoc(*float64) error
+
 	// GetBatteryBoost returns the battery boost
 	GetBatteryBoost() bool
 	// SetBatteryBoost sets the battery boost
@@ -119,6 +138,13 @@ type API interface {
 	// RemoteControl sets remote status demand
 	RemoteControl(string, RemoteDemand)
 
+	// GetPause returns the external pause reason and expiry
+	GetPause() (PauseReason, time.Time)
+	// Pause interrupts charging for the given reason and duration
+	Pause(PauseReason, time.Duration) error
+	// Resume removes an external pause
+	Resume()
+
 	//
 	// smart grid charging
 	//
@@ -127,6 +153,10 @@ type API interface {
 	GetSmartCostLimit() *float64
 	// SetSmartCostLimit sets the smart cost limit
 	SetSmartCostLimit(limit *float64)
+	// GetSmartCo2Limit gets the smart co2 limit
+	GetSmartCo2Limit() *float64
+	// SetSmartCo2Limit sets the smart co2 limit
+	SetSmartCo2Limit(limit *float64)
 
 	//
 	// power and energy
@@ -134,6 +164,8 @@ type API interface {
 
 	// HasChargeMeter determines if a physical charge meter is attached
 	HasChargeMeter() bool
+	// HasChargerFeature checks availability of charger feature
+	HasChargerFeature(api.Feature) bool
 	// GetChargePower returns the current charging power
 	GetChargePower() float64
 	// GetChargePowerFlexibility returns the flexible amount of current charging power
@@ -162,6 +194,8 @@ type API interface {
 	SetVehicle(vehicle api.Vehicle)
 	// StartVehicleDetection allows triggering vehicle detection for debugging purposes
 	StartVehicleDetection()
+	// AuthorizeIdentifier decides whether the identifier presented at the charger may start a charging session
+	AuthorizeIdentifier(id string) bool
 
 	// GetCircuit gets the assigned circuit
 	GetCircuit() api.Circuit
@@ -0,0 +1,96 @@
+package loadpoint
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+// StaticConfig contains the loadpoint device references. Changes require a restart.
+type StaticConfig struct {
+	Charger string
+	Meter   string
+	Circuit string
+	Vehicle string
+}
+
+// ThresholdConfig is the pv enable/disable threshold configuration
+type ThresholdConfig struct {
+	Delay     time.Duration
+	Threshold float64
+}
+
+// DynamicConfig contains the loadpoint settings that can be applied at runtime
+type DynamicConfig struct {
+	Title          string
+	Priority       int
+	Phases         int
+	MinCurrent     float64
+	MaxCurrent     float64
+	SmartCostLimit *float64
+	SmartCo2Limit  *float64
+	Enable         ThresholdConfig
+	Disable        ThresholdConfig
+}
+
+// CurrentConfig returns the dynamic configuration of a running loadpoint
+func CurrentConfig(lp API) DynamicConfig {
+	return DynamicConfig{
+		Title:          lp.Title(),
+		Priority:       lp.GetPriority(),
+		Phases:         lp.GetPhases(),
+		MinCurrent:     lp.GetMinCurrent(),
+		MaxCurrent:     lp.GetMaxCurrent(),
+		SmartCostLimit: lp.GetSmartCostLimit(),
+		SmartCo2Limit:  lp.GetSmartCo2Limit(),
+		Enable:         ThresholdConfig{Delay: lp.GetEnableDelay(), Threshold: lp.GetEnableThreshold()},
+		Disable:        ThresholdConfig{Delay: lp.GetDisableDelay(), Threshold: lp.GetDisableThreshold()},
+	}
+}
+
+// SplitConfig splits a loadpoint configuration into its static and dynamic parts.
+// Dynamic settings missing from the configuration keep their values from base.
+func SplitConfig(conf map[string]any, base DynamicConfig) (StaticConfig, DynamicConfig, error) {
+	var cc struct {
+		StaticConfig  `mapstructure:",squash"`
+		DynamicConfig `mapstructure:",squash"`
+		Other         map[string]any `mapstructure:",remain"`
+	}
+	cc.DynamicConfig = base
+
+	err := util.DecodeOther(conf, &cc)
+
+	return cc.StaticConfig, cc.DynamicConfig, err
+}
+
+// Apply applies the dynamic configuration to a running loadpoint
+func (c DynamicConfig) Apply(lp API) error {
+	lp.SetTitle(c.Title)
+	lp.SetPriority(c.Priority)
+	lp.SetSmartCostLimit(c.SmartCostLimit)
+	lp.SetSmartCo2Limit(c.SmartCo2Limit)
+	lp.SetEnableThreshold(c.Enable.Threshold)
+	lp.SetEnableDelay(c.Enable.Delay)
+	lp.SetDisableThreshold(c.Disable.Threshold)
+	lp.SetDisableDelay(c.Disable.Delay)
+
+	var err error
+
+	if c.Phases != 0 && c.Phases != lp.GetPhases() {
+		err = errors.Join(err, lp.SetPhases(c.Phases))
+	}
+
+	// apply raised max current first to keep min below max
+	if c.MaxCurrent != 0 && c.MaxCurrent >= lp.GetMaxCurrent() {
+		err = errors.Join(err, lp.SetMaxCurrent(c.MaxCurrent))
+	}
+	if c.MinCurrent != 0 {
+		err = errors.Join(err, lp.SetMinCurrent(c.MinCurrent))
+	}
+	if c.MaxCurrent != 0 && c.MaxCurrent < lp.GetMaxCurrent() {
+		err = errors.Join(err, lp.SetMaxCurrent(c.MaxCurrent))
+	}
+
+	return err
+}
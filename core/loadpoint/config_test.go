@@ -0,0 +1,83 @@
+package loadpoint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSplitConfig(t *testing.T) {
+	static, dynamic, err := SplitConfig(map[string]any{
+		"title":      "Garage",
+		"charger":    "db:1",
+		"meter":      "db:2",
+		"minCurrent": 8,
+		"enable":     map[string]any{"delay": "2m"},
+		"mode":       "pv",
+	}, DynamicConfig{})
+	require.NoError(t, err)
+
+	assert.Equal(t, StaticConfig{Charger: "db:1", Meter: "db:2"}, static)
+	assert.Equal(t, "Garage", dynamic.Title)
+	assert.Equal(t, 8.0, dynamic.MinCurrent)
+	assert.Equal(t, 2*time.Minute, dynamic.Enable.Delay)
+}
+
+func TestSplitConfigMerge(t *testing.T) {
+	base := DynamicConfig{
+		Title:      "Garage",
+		MinCurrent: 6,
+		MaxCurrent: 16,
+		Enable:     ThresholdConfig{Delay: time.Minute},
+		Disable:    ThresholdConfig{Delay: 3 * time.Minute},
+	}
+
+	_, dynamic, err := SplitConfig(map[string]any{
+		"maxCurrent": 32,
+		"enable":     map[string]any{"threshold": -500},
+	}, base)
+	require.NoError(t, err)
+
+	// missing settings keep their values
+	assert.Equal(t, DynamicConfig{
+		Title:      "Garage",
+		MinCurrent: 6,
+		MaxCurrent: 32,
+		Enable:     ThresholdConfig{Delay: time.Minute, Threshold: -500},
+		Disable:    ThresholdConfig{Delay: 3 * time.Minute},
+	}, dynamic)
+}
+
+func TestApplyDynamicConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := NewMockAPI(ctrl)
+	lp.EXPECT().SetTitle("Garage")
+	lp.EXPECT().SetPriority(1)
+	lp.EXPECT().SetSmartCostLimit(nil)
+	lp.EXPECT().SetSmartCo2Limit(nil)
+	lp.EXPECT().SetEnableThreshold(0.0)
+	lp.EXPECT().SetEnableDelay(time.Minute)
+	lp.EXPECT().SetDisableThreshold(0.0)
+	lp.EXPECT().SetDisableDelay(time.Duration(0))
+	lp.EXPECT().GetPhases().Return(3)
+
+	// lowered max current is applied after min current
+	lp.EXPECT().GetMaxCurrent().Return(32.0).Times(2)
+	gomock.InOrder(
+		lp.EXPECT().SetMinCurrent(6.0),
+		lp.EXPECT().SetMaxCurrent(16.0),
+	)
+
+	require.NoError(t, DynamicConfig{
+		Title:      "Garage",
+		Priority:   1,
+		Phases:     3,
+		MinCurrent: 6,
+		MaxCurrent: 16,
+		Enable:     ThresholdConfig{Delay: time.Minute},
+	}.Apply(lp))
+}
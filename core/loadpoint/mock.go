@@ -55,6 +55,20 @@ func (mr *MockAPIMockRecorder) ActivePhases() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivePhases", reflect.TypeOf((*MockAPI)(nil).ActivePhases))
 }
 
+// AuthorizeIdentifier mocks base method.
+func (m *MockAPI) AuthorizeIdentifier(id string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeIdentifier", id)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// AuthorizeIdentifier indicates an expected call of AuthorizeIdentifier.
+func (mr *MockAPIMockRecorder) AuthorizeIdentifier(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeIdentifier", reflect.TypeOf((*MockAPI)(nil).AuthorizeIdentifier), id)
+}
+
 // EffectiveMaxPower mocks base method.
 func (m *MockAPI) EffectiveMaxPower() float64 {
 	m.ctrl.T.Helper()
@@ -125,6 +139,34 @@ func (mr *MockAPIMockRecorder) GetBatteryBoost() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatteryBoost", reflect.TypeOf((*MockAPI)(nil).GetBatteryBoost))
 }
 
+// GetBufferSoc mocks base method.
+func (m *MockAPI) GetBufferSoc() *float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBufferSoc")
+	ret0, _ := ret[0].(*float64)
+	return ret0
+}
+
+// GetBufferSoc indicates an expected call of GetBufferSoc.
+func (mr *MockAPIMockRecorder) GetBufferSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBufferSoc", reflect.TypeOf((*MockAPI)(nil).GetBufferSoc))
+}
+
+// GetBufferStartSoc mocks base method.
+func (m *MockAPI) GetBufferStartSoc() *float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBufferStartSoc")
+	ret0, _ := ret[0].(*float64)
+	return ret0
+}
+
+// GetBufferStartSoc indicates an expected call of GetBufferStartSoc.
+func (mr *MockAPIMockRecorder) GetBufferStartSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBufferStartSoc", reflect.TypeOf((*MockAPI)(nil).GetBufferStartSoc))
+}
+
 // GetChargePower mocks base method.
 func (m *MockAPI) GetChargePower() float64 {
 	m.ctrl.T.Helper()
@@ -307,6 +349,36 @@ func (mr *MockAPIMockRecorder) GetMode() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMode", reflect.TypeOf((*MockAPI)(nil).GetMode))
 }
 
+// GetModeOverride mocks base method.
+func (m *MockAPI) GetModeOverride() (api.ChargeMode, time.Time) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModeOverride")
+	ret0, _ := ret[0].(api.ChargeMode)
+	ret1, _ := ret[1].(time.Time)
+	return ret0, ret1
+}
+
+// GetModeOverride indicates an expected call of GetModeOverride.
+func (mr *MockAPIMockRecorder) GetModeOverride() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModeOverride", reflect.TypeOf((*MockAPI)(nil).GetModeOverride))
+}
+
+// GetPause mocks base method.
+func (m *MockAPI) GetPause() (PauseReason, time.Time) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPause")
+	ret0, _ := ret[0].(PauseReason)
+	ret1, _ := ret[1].(time.Time)
+	return ret0, ret1
+}
+
+// GetPause indicates an expected call of GetPause.
+func (mr *MockAPIMockRecorder) GetPause() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPause", reflect.TypeOf((*MockAPI)(nil).GetPause))
+}
+
 // GetPhases mocks base method.
 func (m *MockAPI) GetPhases() int {
 	m.ctrl.T.Helper()
@@ -366,6 +438,20 @@ func (mr *MockAPIMockRecorder) GetPlanGoal() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanGoal", reflect.TypeOf((*MockAPI)(nil).GetPlanGoal))
 }
 
+// GetPlanOptimization mocks base method.
+func (m *MockAPI) GetPlanOptimization() PlanOptimization {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlanOptimization")
+	ret0, _ := ret[0].(PlanOptimization)
+	return ret0
+}
+
+// GetPlanOptimization indicates an expected call of GetPlanOptimization.
+func (mr *MockAPIMockRecorder) GetPlanOptimization() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanOptimization", reflect.TypeOf((*MockAPI)(nil).GetPlanOptimization))
+}
+
 // GetPlanRequiredDuration mocks base method.
 func (m *MockAPI) GetPlanRequiredDuration(goal, maxPower float64) time.Duration {
 	m.ctrl.T.Helper()
@@ -422,6 +508,20 @@ func (mr *MockAPIMockRecorder) GetRemainingEnergy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemainingEnergy", reflect.TypeOf((*MockAPI)(nil).GetRemainingEnergy))
 }
 
+// GetSmartCo2Limit mocks base method.
+func (m *MockAPI) GetSmartCo2Limit() *float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartCo2Limit")
+	ret0, _ := ret[0].(*float64)
+	return ret0
+}
+
+// GetSmartCo2Limit indicates an expected call of GetSmartCo2Limit.
+func (mr *MockAPIMockRecorder) GetSmartCo2Limit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartCo2Limit", reflect.TypeOf((*MockAPI)(nil).GetSmartCo2Limit))
+}
+
 // GetSmartCostLimit mocks base method.
 func (m *MockAPI) GetSmartCostLimit() *float64 {
 	m.ctrl.T.Helper()
@@ -478,6 +578,20 @@ func (mr *MockAPIMockRecorder) HasChargeMeter() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasChargeMeter", reflect.TypeOf((*MockAPI)(nil).HasChargeMeter))
 }
 
+// HasChargerFeature mocks base method.
+func (m *MockAPI) HasChargerFeature(arg0 api.Feature) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasChargerFeature", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasChargerFeature indicates an expected call of HasChargerFeature.
+func (mr *MockAPIMockRecorder) HasChargerFeature(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasChargerFeature", reflect.TypeOf((*MockAPI)(nil).HasChargerFeature), arg0)
+}
+
 // IsFastChargingActive mocks base method.
 func (m *MockAPI) IsFastChargingActive() bool {
 	m.ctrl.T.Helper()
@@ -492,6 +606,20 @@ func (mr *MockAPIMockRecorder) IsFastChargingActive() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFastChargingActive", reflect.TypeOf((*MockAPI)(nil).IsFastChargingActive))
 }
 
+// Pause mocks base method.
+func (m *MockAPI) Pause(arg0 PauseReason, arg1 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockAPIMockRecorder) Pause(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockAPI)(nil).Pause), arg0, arg1)
+}
+
 // PublishEffectiveValues mocks base method.
 func (m *MockAPI) PublishEffectiveValues() {
 	m.ctrl.T.Helper()
@@ -516,6 +644,18 @@ func (mr *MockAPIMockRecorder) RemoteControl(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteControl", reflect.TypeOf((*MockAPI)(nil).RemoteControl), arg0, arg1)
 }
 
+// Resume mocks base method.
+func (m *MockAPI) Resume() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Resume")
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockAPIMockRecorder) Resume() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockAPI)(nil).Resume))
+}
+
 // SetBatteryBoost mocks base method.
 func (m *MockAPI) SetBatteryBoost(enable bool) error {
 	m.ctrl.T.Helper()
@@ -530,6 +670,34 @@ func (mr *MockAPIMockRecorder) SetBatteryBoost(enable any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBatteryBoost", reflect.TypeOf((*MockAPI)(nil).SetBatteryBoost), enable)
 }
 
+// SetBufferSoc mocks base method.
+func (m *MockAPI) SetBufferSoc(arg0 *float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBufferSoc", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBufferSoc indicates an expected call of SetBufferSoc.
+func (mr *MockAPIMockRecorder) SetBufferSoc(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBufferSoc", reflect.TypeOf((*MockAPI)(nil).SetBufferSoc), arg0)
+}
+
+// SetBufferStartSoc mocks base method.
+func (m *MockAPI) SetBufferStartSoc(arg0 *float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBufferStartSoc", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBufferStartSoc indicates an expected call of SetBufferStartSoc.
+func (mr *MockAPIMockRecorder) SetBufferStartSoc(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBufferStartSoc", reflect.TypeOf((*MockAPI)(nil).SetBufferStartSoc), arg0)
+}
+
 // SetDisableDelay mocks base method.
 func (m *MockAPI) SetDisableDelay(delay time.Duration) {
 	m.ctrl.T.Helper()
@@ -670,6 +838,20 @@ func (mr *MockAPIMockRecorder) SetPlanEnergy(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlanEnergy", reflect.TypeOf((*MockAPI)(nil).SetPlanEnergy), arg0, arg1)
 }
 
+// SetPlanOptimization mocks base method.
+func (m *MockAPI) SetPlanOptimization(arg0 PlanOptimization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPlanOptimization", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPlanOptimization indicates an expected call of SetPlanOptimization.
+func (mr *MockAPIMockRecorder) SetPlanOptimization(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlanOptimization", reflect.TypeOf((*MockAPI)(nil).SetPlanOptimization), arg0)
+}
+
 // SetPriority mocks base method.
 func (m *MockAPI) SetPriority(arg0 int) {
 	m.ctrl.T.Helper()
@@ -682,6 +864,18 @@ func (mr *MockAPIMockRecorder) SetPriority(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPriority", reflect.TypeOf((*MockAPI)(nil).SetPriority), arg0)
 }
 
+// SetSmartCo2Limit mocks base method.
+func (m *MockAPI) SetSmartCo2Limit(limit *float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSmartCo2Limit", limit)
+}
+
+// SetSmartCo2Limit indicates an expected call of SetSmartCo2Limit.
+func (mr *MockAPIMockRecorder) SetSmartCo2Limit(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSmartCo2Limit", reflect.TypeOf((*MockAPI)(nil).SetSmartCo2Limit), limit)
+}
+
 // SetSmartCostLimit mocks base method.
 func (m *MockAPI) SetSmartCostLimit(limit *float64) {
 	m.ctrl.T.Helper()
@@ -694,6 +888,32 @@ func (mr *MockAPIMockRecorder) SetSmartCostLimit(limit any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSmartCostLimit", reflect.TypeOf((*MockAPI)(nil).SetSmartCostLimit), limit)
 }
 
+// SetTemporaryMode mocks base method.
+func (m *MockAPI) SetTemporaryMode(arg0 api.ChargeMode, arg1 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTemporaryMode", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTemporaryMode indicates an expected call of SetTemporaryMode.
+func (mr *MockAPIMockRecorder) SetTemporaryMode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTemporaryMode", reflect.TypeOf((*MockAPI)(nil).SetTemporaryMode), arg0, arg1)
+}
+
+// SetTitle mocks base method.
+func (m *MockAPI) SetTitle(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTitle", arg0)
+}
+
+// SetTitle indicates an expected call of SetTitle.
+func (mr *MockAPIMockRecorder) SetTitle(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTitle", reflect.TypeOf((*MockAPI)(nil).SetTitle), arg0)
+}
+
 // SetVehicle mocks base method.
 func (m *MockAPI) SetVehicle(vehicle api.Vehicle) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,10 @@
+package loadpoint
+
+// PlanOptimization defines which forecast charging plans are optimized for
+type PlanOptimization int
+
+//go:generate enumer -type PlanOptimization -trimprefix Optimize -transform=lower -json
+const (
+	OptimizeCost PlanOptimization = iota
+	OptimizeCo2
+)
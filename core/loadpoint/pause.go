@@ -0,0 +1,12 @@
+package loadpoint
+
+// PauseReason defines why charging has been paused externally
+type PauseReason int
+
+//go:generate enumer -type PauseReason -trimprefix Pause -transform=lower -json
+const (
+	PauseNone PauseReason = iota
+	PauseUser
+	PauseGridOperator
+	PauseMaintenance
+)
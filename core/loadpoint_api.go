@@ -20,6 +20,19 @@ func (lp *Loadpoint) Title() string {
 	return lp.Title_
 }
 
+// SetTitle sets the loadpoint title
+func (lp *Loadpoint) SetTitle(title string) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set title:", title)
+
+	if lp.Title_ != title {
+		lp.Title_ = title
+		lp.publish(keys.Title, title)
+	}
+}
+
 // GetStatus returns the charging status
 func (lp *Loadpoint) GetStatus() api.ChargeStatus {
 	lp.RLock()
@@ -53,6 +66,16 @@ func (lp *Loadpoint) SetMode(mode api.ChargeMode) {
 
 	lp.log.DEBUG.Printf("set charge mode: %s", string(mode))
 
+	// explicit mode change ends temporary mode
+	if !lp.modeUntil.IsZero() {
+		lp.setModeOverride("", time.Time{})
+	}
+
+	lp.changeMode(mode)
+}
+
+// changeMode applies the charge mode and resets mode-dependent state (no mutex)
+func (lp *Loadpoint) changeMode(mode api.ChargeMode) {
 	// apply immediately
 	if lp.mode != mode {
 		lp.setMode(mode)
@@ -393,6 +416,20 @@ func (lp *Loadpoint) GetChargePower() float64 {
 	return lp.chargePower
 }
 
+// offeredPower returns the power offered to the vehicle by the current limit
+func (lp *Loadpoint) offeredPower() float64 {
+	phases := lp.ActivePhases()
+
+	lp.RLock()
+	defer lp.RUnlock()
+
+	if !lp.enabled {
+		return 0
+	}
+
+	return lp.chargeCurrent * float64(phases) * Voltage
+}
+
 // GetChargePowerFlexibility returns the flexible amount of current charging power
 func (lp *Loadpoint) GetChargePowerFlexibility() float64 {
 	// no locking
@@ -593,11 +630,38 @@ func (lp *Loadpoint) SetSmartCostLimit(val *float64) {
 
 		if val == nil {
 			lp.settings.SetString(keys.SmartCostLimit, "")
-			lp.publish(keys.SmartCostLimit, nil)
 		} else {
 			lp.settings.SetFloat(keys.SmartCostLimit, *val)
-			lp.publish(keys.SmartCostLimit, *val)
 		}
+
+		lp.publishPtr(keys.SmartCostLimit, val)
+	}
+}
+
+// GetSmartCo2Limit gets the smart co2 limit
+func (lp *Loadpoint) GetSmartCo2Limit() *float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.smartCo2Limit
+}
+
+// SetSmartCo2Limit sets the smart co2 limit
+func (lp *Loadpoint) SetSmartCo2Limit(val *float64) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set smart co2 limit:", printPtr("%.0f", val))
+
+	if !ptrValueEqual(lp.smartCo2Limit, val) {
+		lp.smartCo2Limit = val
+
+		if val == nil {
+			lp.settings.SetString(keys.SmartCo2Limit, "")
+		} else {
+			lp.settings.SetFloat(keys.SmartCo2Limit, *val)
+		}
+
+		lp.publishPtr(keys.SmartCo2Limit, val)
 	}
 }
 
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+var errBufferSocOrder = errors.New("buffer soc must be smaller or equal than buffer start soc")
+
+// validateBufferSoc checks a loadpoint battery buffer threshold
+func validateBufferSoc(soc *float64) error {
+	if soc != nil && (*soc < 0 || *soc > 100) {
+		return errors.New("soc must be between 0 and 100")
+	}
+	return nil
+}
+
+// validateBufferSocs checks a pair of loadpoint battery buffer thresholds
+func validateBufferSocs(soc, startSoc *float64) error {
+	if err := validateBufferSoc(soc); err != nil {
+		return fmt.Errorf("bufferSoc: %w", err)
+	}
+	if err := validateBufferSoc(startSoc); err != nil {
+		return fmt.Errorf("bufferStartSoc: %w", err)
+	}
+	if soc != nil && startSoc != nil && *startSoc != 0 && *soc > *startSoc {
+		return errBufferSocOrder
+	}
+	return nil
+}
+
+// GetBufferSoc returns the loadpoint battery buffer soc or nil if site setting applies
+func (lp *Loadpoint) GetBufferSoc() *float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.bufferSoc
+}
+
+// SetBufferSoc sets the loadpoint battery buffer soc, nil to use site setting
+func (lp *Loadpoint) SetBufferSoc(soc *float64) error {
+	if err := validateBufferSoc(soc); err != nil {
+		return err
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	if soc != nil && lp.bufferStartSoc != nil && *lp.bufferStartSoc != 0 && *soc > *lp.bufferStartSoc {
+		return errBufferSocOrder
+	}
+
+	lp.log.DEBUG.Println("set buffer soc:", printPtr("%.0f", soc))
+
+	if !ptrValueEqual(lp.bufferSoc, soc) {
+		lp.bufferSoc = soc
+
+		if soc == nil {
+			lp.settings.SetString(keys.BufferSoc, "")
+		} else {
+			lp.settings.SetFloat(keys.BufferSoc, *soc)
+		}
+
+		lp.publishPtr(keys.BufferSoc, soc)
+		lp.requestUpdate()
+	}
+
+	return nil
+}
+
+// GetBufferStartSoc returns the loadpoint battery buffer start soc or nil if site setting applies
+func (lp *Loadpoint) GetBufferStartSoc() *float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.bufferStartSoc
+}
+
+// SetBufferStartSoc sets the loadpoint battery buffer start soc, nil to use site setting
+func (lp *Loadpoint) SetBufferStartSoc(soc *float64) error {
+	if err := validateBufferSoc(soc); err != nil {
+		return err
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	if soc != nil && *soc != 0 && lp.bufferSoc != nil && *soc < *lp.bufferSoc {
+		return errors.New("buffer start soc must be larger than buffer soc")
+	}
+
+	lp.log.DEBUG.Println("set buffer start soc:", printPtr("%.0f", soc))
+
+	if !ptrValueEqual(lp.bufferStartSoc, soc) {
+		lp.bufferStartSoc = soc
+
+		if soc == nil {
+			lp.settings.SetString(keys.BufferStartSoc, "")
+		} else {
+			lp.settings.SetFloat(keys.BufferStartSoc, *soc)
+		}
+
+		lp.publishPtr(keys.BufferStartSoc, soc)
+		lp.requestUpdate()
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBufferSocs(t *testing.T) {
+	for _, tc := range []struct {
+		soc, startSoc *float64
+		ok            bool
+	}{
+		{nil, nil, true},
+		{lo.ToPtr(50.0), nil, true},
+		{lo.ToPtr(50.0), lo.ToPtr(80.0), true},
+		{lo.ToPtr(50.0), lo.ToPtr(50.0), true},
+		{lo.ToPtr(50.0), lo.ToPtr(0.0), true}, // start soc disabled
+		{lo.ToPtr(80.0), lo.ToPtr(50.0), false},
+		{lo.ToPtr(-1.0), nil, false},
+		{nil, lo.ToPtr(101.0), false},
+	} {
+		err := validateBufferSocs(tc.soc, tc.startSoc)
+		assert.Equal(t, tc.ok, err == nil, "%v %v: %v", printPtr("%.0f", tc.soc), printPtr("%.0f", tc.startSoc), err)
+	}
+}
@@ -17,6 +17,11 @@ func (lp *Loadpoint) chargerHasFeature(f api.Feature) bool {
 	return ok
 }
 
+// HasChargerFeature checks availability of charger feature
+func (lp *Loadpoint) HasChargerFeature(f api.Feature) bool {
+	return lp.chargerHasFeature(f)
+}
+
 // publishChargerFeature publishes availability of charger features
 func (lp *Loadpoint) publishChargerFeature(f api.Feature) {
 	c, ok := lp.charger.(api.FeatureDescriber)
@@ -28,7 +33,7 @@ func (lp *Loadpoint) publishChargerFeature(f api.Feature) {
 
 // chargerSoc returns charger soc if available
 func (lp *Loadpoint) chargerSoc() (float64, error) {
-	if c, ok := lp.charger.(api.Battery); ok {
+	if c, ok := api.As[api.Battery](lp.charger); ok {
 		return soc.Guard(c.Soc())
 	}
 	return 0, api.ErrNotAvailable
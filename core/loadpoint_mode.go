@@ -0,0 +1,80 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// GetModeOverride returns the charge mode restored after the temporary mode and its expiry
+func (lp *Loadpoint) GetModeOverride() (api.ChargeMode, time.Time) {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.modeRestore, lp.modeUntil
+}
+
+// setModeOverride sets the mode to restore and temporary mode expiry (no mutex)
+func (lp *Loadpoint) setModeOverride(restore api.ChargeMode, until time.Time) {
+	lp.modeRestore = restore
+	lp.modeUntil = until
+
+	lp.publishModeUntil()
+
+	lp.settings.SetString(keys.ModeRestore, string(restore))
+	lp.settings.SetTime(keys.ModeUntil, until)
+}
+
+// publishModeUntil publishes the temporary mode expiry or nil if not set
+func (lp *Loadpoint) publishModeUntil() {
+	if lp.modeUntil.IsZero() {
+		lp.publish(keys.ModeUntil, nil)
+	} else {
+		lp.publish(keys.ModeUntil, lp.modeUntil)
+	}
+}
+
+// SetTemporaryMode sets the charge mode for the given duration after which the previous mode is restored
+func (lp *Loadpoint) SetTemporaryMode(mode api.ChargeMode, duration time.Duration) error {
+	if _, err := api.ChargeModeString(mode.String()); err != nil {
+		return err
+	}
+
+	if duration <= 0 {
+		return errors.New("temporary mode duration must be positive")
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	// keep original mode when extending a temporary mode
+	restore := lp.mode
+	if !lp.modeUntil.IsZero() {
+		restore = lp.modeRestore
+	}
+
+	until := lp.clock.Now().Add(duration)
+	lp.log.DEBUG.Printf("set temporary charge mode: %s (until: %v, then: %s)", mode, until.Round(time.Second).Local(), restore)
+
+	lp.setModeOverride(restore, until)
+	lp.changeMode(mode)
+
+	return nil
+}
+
+// expireModeOverride restores the previous charge mode once the temporary mode has expired
+func (lp *Loadpoint) expireModeOverride() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.modeUntil.IsZero() || lp.clock.Now().Before(lp.modeUntil) {
+		return
+	}
+
+	restore := lp.modeRestore
+	lp.log.DEBUG.Printf("temporary charge mode expired- restoring: %s", restore)
+
+	lp.setModeOverride("", time.Time{})
+	lp.changeMode(restore)
+}
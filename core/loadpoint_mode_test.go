@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemporaryMode(t *testing.T) {
+	clock := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clock,
+		mode:  api.ModePV,
+	}
+
+	assert.Error(t, lp.SetTemporaryMode(api.ModeNow, 0))
+
+	require.NoError(t, lp.SetTemporaryMode(api.ModeNow, time.Hour))
+	assert.Equal(t, api.ModeNow, lp.GetMode())
+
+	// extending keeps original mode
+	require.NoError(t, lp.SetTemporaryMode(api.ModeMinPV, 2*time.Hour))
+	restore, until := lp.GetModeOverride()
+	assert.Equal(t, api.ModePV, restore)
+	assert.Equal(t, clock.Now().Add(2*time.Hour), until)
+
+	clock.Add(time.Hour)
+	lp.expireModeOverride()
+	assert.Equal(t, api.ModeMinPV, lp.GetMode())
+
+	clock.Add(time.Hour)
+	lp.expireModeOverride()
+	assert.Equal(t, api.ModePV, lp.GetMode())
+
+	_, until = lp.GetModeOverride()
+	assert.True(t, until.IsZero())
+
+	// explicit mode change ends temporary mode
+	require.NoError(t, lp.SetTemporaryMode(api.ModeNow, time.Hour))
+	lp.SetMode(api.ModeOff)
+
+	clock.Add(time.Hour)
+	lp.expireModeOverride()
+	assert.Equal(t, api.ModeOff, lp.GetMode())
+}
+
+func TestPublishModeUntil(t *testing.T) {
+	uiChan := make(chan util.Param, 1)
+
+	lp := &Loadpoint{
+		log:    util.NewLogger("foo"),
+		clock:  clock.NewMock(),
+		uiChan: uiChan,
+	}
+
+	lp.publishModeUntil()
+	assert.Equal(t, util.Param{Key: keys.ModeUntil}, <-uiChan)
+
+	lp.modeUntil = lp.clock.Now()
+	lp.publishModeUntil()
+	assert.Equal(t, util.Param{Key: keys.ModeUntil, Val: lp.modeUntil}, <-uiChan)
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/session"
+)
+
+// GetPause returns the external pause reason and expiry
+func (lp *Loadpoint) GetPause() (loadpoint.PauseReason, time.Time) {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.pauseReason, lp.pauseUntil
+}
+
+// setPause sets the external pause reason and expiry (no mutex)
+func (lp *Loadpoint) setPause(reason loadpoint.PauseReason, until time.Time) {
+	lp.pauseReason = reason
+	lp.pauseUntil = until
+
+	lp.publish(keys.PauseReason, reason)
+	lp.publishPauseUntil()
+
+	lp.settings.SetInt(keys.PauseReason, int64(reason))
+	lp.settings.SetTime(keys.PauseUntil, until)
+}
+
+// publishPauseUntil publishes the pause expiry or nil if the pause is indefinite (no mutex)
+func (lp *Loadpoint) publishPauseUntil() {
+	if lp.pauseUntil.IsZero() {
+		lp.publish(keys.PauseUntil, nil)
+	} else {
+		lp.publish(keys.PauseUntil, lp.pauseUntil)
+	}
+}
+
+// resumePause removes the external pause and records the resume in the session (no mutex)
+func (lp *Loadpoint) resumePause() {
+	lp.setPause(loadpoint.PauseNone, time.Time{})
+
+	now := lp.clock.Now()
+	lp.updateSession(func(session *session.Session) {
+		session.Resumed = &now
+	})
+}
+
+// Pause interrupts charging for the given reason. Zero duration pauses until resumed.
+func (lp *Loadpoint) Pause(reason loadpoint.PauseReason, duration time.Duration) error {
+	if reason == loadpoint.PauseNone {
+		return errors.New("missing pause reason")
+	}
+
+	if duration < 0 {
+		return errors.New("pause duration must not be negative")
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	var until time.Time
+	if duration > 0 {
+		until = lp.clock.Now().Add(duration)
+	}
+
+	lp.log.DEBUG.Printf("pause: %s (until: %v)", reason, until.Round(time.Second).Local())
+
+	if lp.pauseReason != reason || !lp.pauseUntil.Equal(until) {
+		lp.setPause(reason, until)
+
+		lp.updateSession(func(session *session.Session) {
+			session.PauseReason = reason.String()
+		})
+
+		lp.requestUpdate()
+	}
+
+	return nil
+}
+
+// Resume removes an external pause
+func (lp *Loadpoint) Resume() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("resume")
+
+	if lp.pauseReason != loadpoint.PauseNone {
+		lp.resumePause()
+		lp.requestUpdate()
+	}
+}
+
+// expirePause removes an expired external pause
+func (lp *Loadpoint) expirePause() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.pauseReason != loadpoint.PauseNone && !lp.pauseUntil.IsZero() && !lp.clock.Now().Before(lp.pauseUntil) {
+		lp.log.DEBUG.Printf("pause expired: %s", lp.pauseReason)
+		lp.resumePause()
+	}
+}
+
+// paused returns true if an external pause is active
+func (lp *Loadpoint) paused() bool {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	return lp.pauseReason != loadpoint.PauseNone &&
+		(lp.pauseUntil.IsZero() || lp.clock.Now().Before(lp.pauseUntil))
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPause(t *testing.T) {
+	clock := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clock,
+	}
+
+	assert.False(t, lp.paused())
+	assert.Error(t, lp.Pause(loadpoint.PauseNone, 0))
+
+	// indefinite pause
+	require.NoError(t, lp.Pause(loadpoint.PauseUser, 0))
+	clock.Add(24 * time.Hour)
+	assert.True(t, lp.paused())
+
+	lp.Resume()
+	assert.False(t, lp.paused())
+
+	// timed pause expires
+	require.NoError(t, lp.Pause(loadpoint.PauseGridOperator, time.Hour))
+	reason, until := lp.GetPause()
+	assert.Equal(t, loadpoint.PauseGridOperator, reason)
+	assert.Equal(t, clock.Now().Add(time.Hour), until)
+
+	clock.Add(59 * time.Minute)
+	assert.True(t, lp.paused())
+
+	clock.Add(time.Minute)
+	assert.False(t, lp.paused())
+
+	// paused has no side effects, expiry is handled separately
+	reason, _ = lp.GetPause()
+	assert.Equal(t, loadpoint.PauseGridOperator, reason)
+
+	lp.expirePause()
+	reason, until = lp.GetPause()
+	assert.Equal(t, loadpoint.PauseNone, reason)
+	assert.True(t, until.IsZero())
+}
@@ -1,13 +1,16 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/samber/lo"
 )
 
 const (
@@ -25,6 +28,10 @@ func (lp *Loadpoint) setPlanActive(active bool) {
 	if lp.planActive != active {
 		lp.planActive = active
 		lp.publish(keys.PlanActive, lp.planActive)
+
+		if active {
+			lp.pushEvent(evPlanStart)
+		}
 	}
 }
 
@@ -42,20 +49,51 @@ func (lp *Loadpoint) remainingPlanEnergy(planEnergy float64) float64 {
 	return max(0, planEnergy-lp.getChargedEnergy()/1e3)
 }
 
-// GetPlanRequiredDuration is the estimated total charging duration
+// planPhaseSwitchDuration returns the delay for switching 1p3p chargers to the phases used for plan charging
+func (lp *Loadpoint) planPhaseSwitchDuration() time.Duration {
+	if !lp.hasPhaseSwitching() {
+		return 0
+	}
+
+	lp.RLock()
+	defer lp.RUnlock()
+
+	// plan charging uses configured phases or 3p, see fastCharging
+	phases := lp.configuredPhases
+	if phases == 0 {
+		phases = 3
+	}
+
+	if lp.phases == phases {
+		return 0
+	}
+
+	return phaseSwitchDuration
+}
+
+// GetPlanRequiredDuration is the estimated total charging duration including switching phases if required
 func (lp *Loadpoint) GetPlanRequiredDuration(goal, maxPower float64) time.Duration {
+	switchDuration := lp.planPhaseSwitchDuration()
+
 	lp.RLock()
 	defer lp.RUnlock()
 
+	var res time.Duration
 	if lp.socBasedPlanning() {
 		if lp.socEstimator == nil {
 			return 0
 		}
-		return lp.socEstimator.RemainingChargeDuration(int(goal), maxPower)
+		res = lp.socEstimator.RemainingChargeDuration(int(goal), maxPower)
+	} else {
+		energy := lp.remainingPlanEnergy(goal)
+		res = time.Duration(energy * 1e3 / maxPower * float64(time.Hour))
 	}
 
-	energy := lp.remainingPlanEnergy(goal)
-	return time.Duration(energy * 1e3 / maxPower * float64(time.Hour))
+	if res <= 0 {
+		return res
+	}
+
+	return res + switchDuration
 }
 
 // GetPlanGoal returns the plan goal in %, true or kWh, false
@@ -74,11 +112,72 @@ func (lp *Loadpoint) GetPlanGoal() (float64, bool) {
 
 // GetPlan creates a charging plan for given time and duration
 func (lp *Loadpoint) GetPlan(targetTime time.Time, requiredDuration time.Duration) (api.Rates, error) {
-	if lp.planner == nil || targetTime.IsZero() {
+	planner := lp.planner
+	if lp.GetPlanOptimization() == loadpoint.OptimizeCo2 && lp.co2Planner != nil {
+		planner = lp.co2Planner
+	}
+
+	if planner == nil || targetTime.IsZero() {
 		return nil, nil
 	}
 
-	return lp.planner.Plan(requiredDuration, targetTime)
+	return planner.Plan(requiredDuration, targetTime)
+}
+
+// GetPlanOptimization returns the plan optimization target
+func (lp *Loadpoint) GetPlanOptimization() loadpoint.PlanOptimization {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.planOptimization
+}
+
+// SetPlanOptimization sets the plan optimization target
+func (lp *Loadpoint) SetPlanOptimization(val loadpoint.PlanOptimization) error {
+	if !val.IsAPlanOptimization() {
+		return fmt.Errorf("invalid plan optimization: %d", val)
+	}
+
+	if val == loadpoint.OptimizeCo2 && lp.co2Planner == nil {
+		return errors.New("co2 optimization requires co2 tariff")
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set plan optimization:", val)
+
+	if lp.planOptimization != val {
+		lp.planOptimization = val
+		lp.publish(keys.PlanOptimization, val)
+		lp.settings.SetInt(keys.PlanOptimization, int64(val))
+		lp.requestUpdate()
+	}
+
+	return nil
+}
+
+// chargeSchedule converts the plan into a schedule charging at given power
+func chargeSchedule(plan api.Rates, power float64) api.ChargeSchedule {
+	return lo.Map(plan, func(slot api.Rate, _ int) api.ChargeSlot {
+		return api.ChargeSlot{Start: slot.Start, End: slot.End, Power: power}
+	})
+}
+
+// setChargeSchedule sends the charge schedule to chargers negotiating it with the vehicle
+func (lp *Loadpoint) setChargeSchedule(schedule api.ChargeSchedule) {
+	cs, ok := lp.charger.(api.ChargeScheduler)
+	if !ok {
+		return
+	}
+
+	if lp.dryRun {
+		lp.log.DEBUG.Printf("dry run: charge schedule with %d slots", len(schedule))
+		return
+	}
+
+	if err := cs.SetChargeSchedule(schedule); err != nil {
+		lp.log.ERROR.Printf("charge schedule: %v", err)
+	}
 }
 
 // plannerActive checks if the charging plan has a currently active slot
@@ -89,6 +188,11 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 
 	var planStart, planEnd time.Time
 	var planOverrun time.Duration
+	var schedule api.ChargeSchedule
+
+	defer func() {
+		lp.setChargeSchedule(schedule)
+	}()
 
 	defer func() {
 		lp.publish(keys.PlanProjectedStart, planStart)
@@ -135,6 +239,7 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 
 	planStart = planner.Start(plan)
 	planEnd = planner.End(plan)
+	schedule = chargeSchedule(plan, maxPower)
 	lp.log.DEBUG.Printf("plan: charge %v between %v until %v (%spower: %.0fW, avg cost: %.3f)",
 		planner.Duration(plan).Round(time.Second), planStart.Round(time.Second).Local(), planTime.Round(time.Second).Local(), overrun,
 		maxPower, planner.AverageCost(plan))
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPlanRequiredDurationPhaseSwitching(t *testing.T) {
+	Voltage = 230 // V
+	ctrl := gomock.NewController(t)
+
+	charger := struct {
+		*api.MockCharger
+		*api.MockPhaseSwitcher
+	}{
+		api.NewMockCharger(ctrl), api.NewMockPhaseSwitcher(ctrl),
+	}
+
+	lp := &Loadpoint{
+		charger:       charger,
+		sessionEnergy: NewEnergyMetrics(),
+		phases:        1,
+		maxCurrent:    16,
+	}
+
+	// pv charging on 1p, plan power assumes 3p
+	maxPower := lp.EffectiveMaxPower()
+	assert.Equal(t, 3*Voltage*16, maxPower)
+
+	energy := 11.04 // kWh, 1h at 3p 16A
+	assert.Equal(t, time.Hour+phaseSwitchDuration, lp.GetPlanRequiredDuration(energy, maxPower), "switch 1p to 3p")
+
+	lp.phases = 3
+	assert.Equal(t, time.Hour, lp.GetPlanRequiredDuration(energy, maxPower), "already 3p")
+
+	// fixed 1p
+	lp.configuredPhases = 1
+	lp.phases = 1
+	maxPower = lp.EffectiveMaxPower()
+	assert.Equal(t, Voltage*16, maxPower)
+	assert.Equal(t, 3*time.Hour, lp.GetPlanRequiredDuration(energy, maxPower), "fixed 1p")
+
+	// goal reached
+	assert.Equal(t, time.Duration(0), lp.GetPlanRequiredDuration(0, maxPower))
+}
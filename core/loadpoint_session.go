@@ -6,7 +6,7 @@ import (
 )
 
 func (lp *Loadpoint) chargeMeterTotal() float64 {
-	m, ok := lp.chargeMeter.(api.MeterEnergy)
+	m, ok := api.As[api.MeterEnergy](lp.chargeMeter)
 	if !ok {
 		return 0
 	}
@@ -41,6 +41,10 @@ func (lp *Loadpoint) createSession() {
 			lp.session.Identifier = id
 		}
 	}
+
+	if lp.user != nil {
+		lp.session.User = lp.user.Name
+	}
 }
 
 // stopSession ends a charging session segment and persists the session.
@@ -73,6 +77,7 @@ func (lp *Loadpoint) stopSession() {
 	s.Co2PerKWh = lp.sessionEnergy.Co2PerKWh()
 	s.ChargedEnergy = lp.sessionEnergy.TotalWh() / 1e3
 	s.ChargeDuration = &lp.chargeDuration
+	s.SetSlots(lp.sessionEnergy.Slots())
 
 	lp.db.Persist(s)
 }
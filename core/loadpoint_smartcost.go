@@ -6,23 +6,46 @@ import (
 	"github.com/evcc-io/evcc/api"
 )
 
-func (lp *Loadpoint) smartCostActive(rates api.Rates) bool {
-	// potential error has already been logged by site, ignore
-	rate, _ := rates.Current(time.Now())
-	limit := lp.GetSmartCostLimit()
-	return limit != nil && !rate.IsEmpty() && rate.Price <= *limit
+// smartLimitMet returns true if the rate at given time satisfies the limit.
+// A nil limit is always satisfied.
+func smartLimitMet(rates api.Rates, ts time.Time, limit *float64) bool {
+	if limit == nil {
+		return true
+	}
+
+	rate, err := rates.Current(ts)
+	return err == nil && rate.Price <= *limit
+}
+
+// smartCostActive returns true if all configured smart charging limits are met
+func (lp *Loadpoint) smartCostActive(rates, co2Rates api.Rates) bool {
+	return lp.smartLimitsMet(rates, co2Rates, time.Now())
+}
+
+// smartLimitsMet returns true if at least one limit is configured and all configured limits are met at given time
+func (lp *Loadpoint) smartLimitsMet(rates, co2Rates api.Rates, ts time.Time) bool {
+	costLimit := lp.GetSmartCostLimit()
+	co2Limit := lp.GetSmartCo2Limit()
+
+	if costLimit == nil && co2Limit == nil {
+		return false
+	}
+
+	// potential errors have already been logged by site, ignore
+	return smartLimitMet(rates, ts, costLimit) && smartLimitMet(co2Rates, ts, co2Limit)
 }
 
-// smartCostNextStart returns the next start time for a smart cost rate below the limit
-func (lp *Loadpoint) smartCostNextStart(rates api.Rates) time.Time {
-	limit := lp.GetSmartCostLimit()
-	if limit == nil || rates == nil {
-		return time.Time{}
+// smartCostNextStart returns the next start time where all smart charging limits are met
+func (lp *Loadpoint) smartCostNextStart(rates, co2Rates api.Rates) time.Time {
+	// use the rates of the configured limit for finding slots
+	slots := rates
+	if lp.GetSmartCostLimit() == nil {
+		slots = co2Rates
 	}
 
 	now := time.Now()
-	for _, slot := range rates {
-		if slot.Start.After(now) && slot.Price <= *limit {
+	for _, slot := range slots {
+		if slot.Start.After(now) && lp.smartLimitsMet(rates, co2Rates, slot.Start) {
 			return slot.Start
 		}
 	}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSmartLimitsMet(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+
+	rates := func(prices ...float64) api.Rates {
+		var res api.Rates
+		for i, p := range prices {
+			start := now.Add(time.Duration(i) * time.Hour)
+			res = append(res, api.Rate{Start: start, End: start.Add(time.Hour), Price: p})
+		}
+		return res
+	}
+
+	tc := []struct {
+		costLimit, co2Limit *float64
+		active              bool
+		next                time.Time
+	}{
+		{nil, nil, false, time.Time{}},
+		{lo.ToPtr(0.2), nil, false, now.Add(2 * time.Hour)},
+		{lo.ToPtr(0.3), nil, true, time.Time{}},
+		{nil, lo.ToPtr(200.0), false, now.Add(time.Hour)},
+		{lo.ToPtr(0.2), lo.ToPtr(200.0), false, now.Add(2 * time.Hour)},
+		{lo.ToPtr(0.3), lo.ToPtr(100.0), false, now.Add(2 * time.Hour)},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		lp := &Loadpoint{
+			log:            util.NewLogger("foo"),
+			smartCostLimit: tc.costLimit,
+			smartCo2Limit:  tc.co2Limit,
+		}
+
+		price, co2 := rates(0.3, 0.4, 0.2), rates(300, 200, 100)
+		assert.Equal(t, tc.active, lp.smartCostActive(price, co2), "active")
+
+		if !tc.active {
+			assert.Equal(t, tc.next, lp.smartCostNextStart(price, co2), "next start")
+		}
+	}
+}
+
+func TestSiteSmartCostActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	now := time.Now()
+	rate := api.Rate{Start: now.Add(-time.Minute), End: now.Add(time.Hour), Price: 0.2}
+	co2Rates := api.Rates{{Start: now.Add(-time.Minute), End: now.Add(time.Hour), Price: 300}}
+
+	tc := []struct {
+		costLimit, co2Limit *float64
+		active              bool
+	}{
+		{nil, nil, false},
+		{lo.ToPtr(0.3), nil, true},
+		{lo.ToPtr(0.1), nil, false},
+		{nil, lo.ToPtr(400.0), true},
+		{lo.ToPtr(0.3), lo.ToPtr(200.0), false},
+		{lo.ToPtr(0.3), lo.ToPtr(400.0), true},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		lp := loadpoint.NewMockAPI(ctrl)
+		lp.EXPECT().GetSmartCostLimit().Return(tc.costLimit)
+		lp.EXPECT().GetSmartCo2Limit().Return(tc.co2Limit)
+
+		assert.Equal(t, tc.active, new(Site).smartCostActive(lp, rate, co2Rates))
+	}
+}
+
+func TestSettingsLimit(t *testing.T) {
+	lp := &Loadpoint{
+		settings: &Settings{Key: "test.smartcost."},
+	}
+
+	_, err := lp.settingsLimit(keys.SmartCostLimit)
+	assert.Error(t, err, "not persisted")
+
+	lp.settings.SetFloat(keys.SmartCostLimit, 0.2)
+	v, err := lp.settingsLimit(keys.SmartCostLimit)
+	require.NoError(t, err)
+	assert.Equal(t, lo.ToPtr(0.2), v)
+
+	// explicitly disabled
+	lp.settings.SetString(keys.SmartCostLimit, "")
+	v, err = lp.settingsLimit(keys.SmartCostLimit)
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
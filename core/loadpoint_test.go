@@ -10,7 +10,9 @@ import (
 	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -182,7 +184,7 @@ func TestUpdatePowerZero(t *testing.T) {
 		}
 
 		lp.mode = tc.mode
-		lp.Update(0, 0, nil, false, false, 0, nil, nil) // false,sitePower false,0
+		lp.Update(0, 0, nil, nil, false, false, 0, nil, nil) // false,sitePower false,0
 
 		ctrl.Finish()
 	}
@@ -428,7 +430,7 @@ func TestDisableAndEnableAtTargetSoc(t *testing.T) {
 	charger.EXPECT().Status().Return(api.StatusC, nil)
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
 	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
-	lp.Update(500, 0, nil, false, false, 0, nil, nil)
+	lp.Update(500, 0, nil, nil, false, false, 0, nil, nil)
 	ctrl.Finish()
 
 	t.Log("charging above target - soc deactivates charger")
@@ -437,7 +439,7 @@ func TestDisableAndEnableAtTargetSoc(t *testing.T) {
 	charger.EXPECT().Status().Return(api.StatusC, nil)
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
 	charger.EXPECT().Enable(false).Return(nil)
-	lp.Update(500, 0, nil, false, false, 0, nil, nil)
+	lp.Update(500, 0, nil, nil, false, false, 0, nil, nil)
 	ctrl.Finish()
 
 	t.Log("deactivated charger changes status to B")
@@ -445,14 +447,14 @@ func TestDisableAndEnableAtTargetSoc(t *testing.T) {
 	vehicle.EXPECT().Soc().Return(95.0, nil)
 	charger.EXPECT().Status().Return(api.StatusB, nil)
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
-	lp.Update(-500, 0, nil, false, false, 0, nil, nil)
+	lp.Update(-500, 0, nil, nil, false, false, 0, nil, nil)
 	ctrl.Finish()
 
 	t.Log("soc has risen below target - soc update prevented by timer")
 	clock.Add(5 * time.Minute)
 	charger.EXPECT().Status().Return(api.StatusB, nil)
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
-	lp.Update(-500, 0, nil, false, false, 0, nil, nil)
+	lp.Update(-500, 0, nil, nil, false, false, 0, nil, nil)
 	ctrl.Finish()
 
 	t.Log("soc has fallen below target - soc update timer expired")
@@ -462,7 +464,7 @@ func TestDisableAndEnableAtTargetSoc(t *testing.T) {
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
 	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
 	charger.EXPECT().Enable(true).Return(nil)
-	lp.Update(-500, 0, nil, false, false, 0, nil, nil)
+	lp.Update(-500, 0, nil, nil, false, false, 0, nil, nil)
 	ctrl.Finish()
 }
 
@@ -497,14 +499,14 @@ func TestSetModeAndSocAtDisconnect(t *testing.T) {
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
 	charger.EXPECT().Status().Return(api.StatusC, nil)
 	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
-	lp.Update(500, 0, nil, false, false, 0, nil, nil)
+	lp.Update(500, 0, nil, nil, false, false, 0, nil, nil)
 
 	t.Log("switch off when disconnected")
 	clock.Add(5 * time.Minute)
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
 	charger.EXPECT().Status().Return(api.StatusA, nil)
 	charger.EXPECT().Enable(false).Return(nil)
-	lp.Update(-300, 0, nil, false, false, 0, nil, nil)
+	lp.Update(-300, 0, nil, nil, false, false, 0, nil, nil)
 
 	if mode := lp.GetMode(); mode != api.ModeOff {
 		t.Error("unexpected mode", mode)
@@ -567,14 +569,14 @@ func TestChargedEnergyAtDisconnect(t *testing.T) {
 	rater.EXPECT().ChargedEnergy().Return(0.0, nil)
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
 	charger.EXPECT().Status().Return(api.StatusC, nil)
-	lp.Update(-1, 0, nil, false, false, 0, nil, nil)
+	lp.Update(-1, 0, nil, nil, false, false, 0, nil, nil)
 
 	t.Log("at 1:00h charging at 5 kWh")
 	clock.Add(time.Hour)
 	rater.EXPECT().ChargedEnergy().Return(5.0, nil)
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
 	charger.EXPECT().Status().Return(api.StatusC, nil)
-	lp.Update(-1, 0, nil, false, false, 0, nil, nil)
+	lp.Update(-1, 0, nil, nil, false, false, 0, nil, nil)
 	expectCache("chargedEnergy", 5000.0)
 
 	t.Log("at 1:00h stop charging at 5 kWh")
@@ -582,7 +584,7 @@ func TestChargedEnergyAtDisconnect(t *testing.T) {
 	rater.EXPECT().ChargedEnergy().Return(5.0, nil)
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
 	charger.EXPECT().Status().Return(api.StatusB, nil)
-	lp.Update(-1, 0, nil, false, false, 0, nil, nil)
+	lp.Update(-1, 0, nil, nil, false, false, 0, nil, nil)
 	expectCache("chargedEnergy", 5000.0)
 
 	t.Log("at 1:00h restart charging at 5 kWh")
@@ -590,7 +592,7 @@ func TestChargedEnergyAtDisconnect(t *testing.T) {
 	rater.EXPECT().ChargedEnergy().Return(5.0, nil)
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
 	charger.EXPECT().Status().Return(api.StatusC, nil)
-	lp.Update(-1, 0, nil, false, false, 0, nil, nil)
+	lp.Update(-1, 0, nil, nil, false, false, 0, nil, nil)
 	expectCache("chargedEnergy", 5000.0)
 
 	t.Log("at 1:30h continue charging at 7.5 kWh")
@@ -598,7 +600,7 @@ func TestChargedEnergyAtDisconnect(t *testing.T) {
 	rater.EXPECT().ChargedEnergy().Return(7.5, nil)
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
 	charger.EXPECT().Status().Return(api.StatusC, nil)
-	lp.Update(-1, 0, nil, false, false, 0, nil, nil)
+	lp.Update(-1, 0, nil, nil, false, false, 0, nil, nil)
 	expectCache("chargedEnergy", 7500.0)
 
 	t.Log("at 2:00h stop charging at 10 kWh")
@@ -606,7 +608,7 @@ func TestChargedEnergyAtDisconnect(t *testing.T) {
 	rater.EXPECT().ChargedEnergy().Return(10.0, nil)
 	charger.EXPECT().Enabled().Return(lp.enabled, nil)
 	charger.EXPECT().Status().Return(api.StatusB, nil)
-	lp.Update(-1, 0, nil, false, false, 0, nil, nil)
+	lp.Update(-1, 0, nil, nil, false, false, 0, nil, nil)
 	expectCache("chargedEnergy", 10000.0)
 
 	ctrl.Finish()
@@ -697,6 +699,31 @@ func TestSocPoll(t *testing.T) {
 	}
 }
 
+func TestSocPollChargingInterval(t *testing.T) {
+	clock := clock.NewMock()
+
+	lp := &Loadpoint{
+		clock:  clock,
+		log:    util.NewLogger("foo"),
+		status: api.StatusC,
+		Soc: SocConfig{
+			Poll: PollConfig{
+				Mode:             pollCharging,
+				ChargingInterval: 10 * time.Minute,
+			},
+		},
+	}
+
+	assert.True(t, lp.vehicleSocPollAllowed())
+	lp.socUpdated = clock.Now()
+
+	clock.Add(5 * time.Minute)
+	assert.False(t, lp.vehicleSocPollAllowed())
+
+	clock.Add(5 * time.Minute)
+	assert.True(t, lp.vehicleSocPollAllowed())
+}
+
 // test PV hysteresis after phase switch down, depending on remaining energy
 func TestPVHysteresisAfterPhaseSwitch(t *testing.T) {
 	const dt = time.Minute
@@ -764,3 +791,137 @@ func TestPVHysteresisAfterPhaseSwitch(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestRampedCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	tc := []struct {
+		ramp                      *float64
+		current, target, expected float64
+	}{
+		{nil, 6, 16, 16},            // disabled
+		{lo.ToPtr(2.0), 6, 16, 8},   // ramp up
+		{lo.ToPtr(2.0), 15, 16, 16}, // target reached
+		{lo.ToPtr(2.0), 16, 6, 6},   // reduce immediately
+		{lo.ToPtr(2.0), 0, 16, 6},   // start at min current
+		{lo.ToPtr(10.0), 0, 16, 10}, // start above min current
+		{lo.ToPtr(2.0), 0, 0, 0},    // stay disabled
+		{lo.ToPtr(0.5), 6, 16, 7},   // coarse current
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		lp := &Loadpoint{
+			log:           util.NewLogger("foo"),
+			charger:       charger,
+			RampRate:      tc.ramp,
+			chargeCurrent: tc.current,
+		}
+
+		assert.Equal(t, tc.expected, lp.rampedCurrent(tc.target, minA))
+	}
+}
+
+func TestRampLagPower(t *testing.T) {
+	lp := &Loadpoint{
+		status:        api.StatusC,
+		rampPower:     1380,
+		rampBasePower: 4140,
+		chargePower:   4140,
+	}
+
+	// increase not yet drawn
+	assert.Equal(t, 1380.0, lp.rampLagPower())
+
+	// partially drawn
+	lp.chargePower = 5000
+	assert.Equal(t, 520.0, lp.rampLagPower())
+
+	// fully drawn
+	lp.chargePower = 5600
+	assert.Equal(t, 0.0, lp.rampLagPower())
+
+	// not charging
+	lp.chargePower = 0
+	lp.status = api.StatusB
+	assert.Equal(t, 0.0, lp.rampLagPower())
+}
+
+func TestRemainingLimitEnergy(t *testing.T) {
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clock.NewMock(),
+		sessionEnergy: NewEnergyMetrics(),
+		status:        api.StatusC,
+		chargePower:   10e3,
+		limitEnergy:   20,
+	}
+
+	lp.sessionEnergy.Update(15)
+	assert.False(t, lp.limitEnergyReached())
+
+	lp.publishRemainingLimitEnergy()
+	assert.Equal(t, 5e3, lp.GetRemainingEnergy())
+	assert.Equal(t, 30*time.Minute, lp.GetRemainingDuration())
+
+	lp.sessionEnergy.Update(20)
+	assert.True(t, lp.limitEnergyReached())
+}
+
+// writeCharger fails the test on charge schedule or authorization writes
+type writeCharger struct {
+	t *testing.T
+}
+
+func (c writeCharger) SetChargeSchedule(api.ChargeSchedule) error {
+	c.t.Error("unexpected SetChargeSchedule")
+	return nil
+}
+
+func (c writeCharger) Authorize(string) error {
+	c.t.Error("unexpected Authorize")
+	return nil
+}
+
+func TestDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	// charger must not be controlled
+	charger := struct {
+		*api.MockCharger
+		*api.MockPhaseSwitcher
+		writeCharger
+	}{
+		api.NewMockCharger(ctrl), api.NewMockPhaseSwitcher(ctrl), writeCharger{t},
+	}
+
+	lp := &Loadpoint{
+		log:         util.NewLogger("foo"),
+		clock:       clock.NewMock(),
+		bus:         evbus.New(),
+		charger:     charger,
+		dryRun:      true,
+		minCurrent:  minA,
+		maxCurrent:  maxA,
+		phases:      1,
+		wakeUpTimer: NewTimer(),
+	}
+
+	require.NoError(t, lp.syncCharger())
+
+	require.NoError(t, lp.setLimit(10))
+	assert.True(t, lp.enabled)
+	assert.Equal(t, 10.0, lp.chargeCurrent)
+
+	require.NoError(t, lp.scalePhases(3))
+	assert.Equal(t, 3, lp.GetPhases())
+
+	require.NoError(t, lp.setLimit(0))
+	assert.False(t, lp.enabled)
+
+	lp.setChargeSchedule(api.ChargeSchedule{{Power: 1000}})
+
+	lp.authorize("rfid", false)
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/core/user"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// GetUser returns the identified user name
+func (lp *Loadpoint) GetUser() string {
+	lp.RLock()
+	defer lp.RUnlock()
+	if lp.user == nil {
+		return ""
+	}
+	return lp.user.Name
+}
+
+// setUser sets the identified user
+func (lp *Loadpoint) setUser(u *user.User) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.user == u || lp.user != nil && u != nil && lp.user.Name == u.Name {
+		return
+	}
+
+	lp.user = u
+
+	var name string
+	if u != nil {
+		name = u.Name
+	}
+
+	lp.publish(keys.User, name)
+
+	lp.updateSession(func(session *session.Session) {
+		session.User = name
+	})
+}
+
+// identifyUser selects the user owning the charger identifier and applies the user's limits
+func (lp *Loadpoint) identifyUser(id string) *user.User {
+	u, ok := lp.users.ByIdentifier(id)
+	if !ok {
+		return nil
+	}
+
+	lp.log.DEBUG.Printf("user identified: %s", u.DisplayName())
+	lp.setUser(&u)
+
+	if u.LimitSoc > 0 {
+		lp.SetLimitSoc(u.LimitSoc)
+	}
+
+	if u.LimitEnergy > 0 {
+		lp.SetLimitEnergy(u.LimitEnergy)
+	}
+
+	return &u
+}
+
+// authorize decides whether the identifier may start a charging session and passes it to chargers supporting remote authorization
+func (lp *Loadpoint) authorize(id string, known bool) user.Authorization {
+	res := lp.users.Authorize(id, known, lp.requireAuth)
+	lp.log.DEBUG.Printf("authorization: %s %s", id, res)
+
+	if res == user.Refused {
+		lp.log.WARN.Printf("authorization: refused %s, charging disabled until disconnect", id)
+		return res
+	}
+
+	authorizer, ok := lp.charger.(api.Authorizer)
+	if !ok {
+		return res
+	}
+
+	if lp.dryRun {
+		lp.log.INFO.Printf("dry run: authorize %s", id)
+		return res
+	}
+
+	if err := authorizer.Authorize(id); err != nil {
+		lp.log.ERROR.Printf("authorization: %v", err)
+	}
+
+	return res
+}
+
+// AuthorizeIdentifier implements loadpoint.API
+func (lp *Loadpoint) AuthorizeIdentifier(id string) bool {
+	return lp.users.Authorize(id, lp.selectVehicleByID(id) != nil, lp.requireAuth) != user.Refused
+}
+
+// userVehicle returns the user's default vehicle
+func (lp *Loadpoint) userVehicle(u user.User) api.Vehicle {
+	if u.Vehicle == "" {
+		return nil
+	}
+
+	dev, err := config.Vehicles().ByName(u.Vehicle)
+	if err != nil {
+		lp.log.ERROR.Printf("user %s: %v", u.Name, err)
+		return nil
+	}
+
+	return dev.Instance()
+}
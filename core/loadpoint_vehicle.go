@@ -12,6 +12,7 @@ import (
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/soc"
+	"github.com/evcc-io/evcc/core/user"
 	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/provider"
 )
@@ -68,7 +69,24 @@ func (lp *Loadpoint) identifyVehicle() {
 	if id != "" {
 		lp.log.DEBUG.Println("charger vehicle id:", id)
 
-		if vehicle := lp.selectVehicleByID(id); vehicle != nil {
+		vehicle := lp.selectVehicleByID(id)
+		auth := lp.authorize(id, vehicle != nil)
+
+		lp.updateSession(func(session *session.Session) {
+			session.Identifier = id
+			session.Authorization = string(auth)
+		})
+
+		if lp.authRefused = auth == user.Refused; lp.authRefused {
+			return
+		}
+
+		// identify user and fall back to user's default vehicle
+		if u := lp.identifyUser(id); u != nil && vehicle == nil {
+			vehicle = lp.userVehicle(*u)
+		}
+
+		if vehicle != nil {
 			lp.stopVehicleDetection()
 			lp.setActiveVehicle(vehicle)
 		}
@@ -107,6 +125,20 @@ func (lp *Loadpoint) selectVehicleByID(id string) api.Vehicle {
 	return nil
 }
 
+// setPollTimeout sets the vehicle read timeout and max. age of the last value
+func (lp *Loadpoint) setPollTimeout(timeout, maxAge time.Duration) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.pollTimeout = timeout
+	lp.pollMaxAge = maxAge
+
+	if lp.vehiclePoller != nil {
+		lp.vehiclePoller.timeout = timeout
+		lp.vehiclePoller.maxAge = maxAge
+	}
+}
+
 // setActiveVehicle assigns currently active vehicle, configures soc estimator
 // and adds an odometer task
 func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
@@ -138,7 +170,8 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 		if lp.Soc.Estimate == nil || *lp.Soc.Estimate {
 			estimate = true
 		}
-		lp.socEstimator = soc.NewEstimator(lp.log, lp.charger, v, estimate)
+		lp.vehiclePoller = newVehiclePoller(v, lp.pollTimeout, lp.pollMaxAge)
+		lp.socEstimator = soc.NewEstimator(lp.log, lp.charger, lp.vehiclePoller, estimate)
 
 		lp.publish(keys.VehicleName, vehicle.Settings(lp.log, v).Name())
 
@@ -151,6 +184,7 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 		lp.progress.Reset()
 	} else {
 		lp.socEstimator = nil
+		lp.vehiclePoller = nil
 		lp.publish(keys.VehicleSoc, 0)
 		lp.publish(keys.VehicleName, "")
 		lp.publish(keys.VehicleOdometer, 0.0)
@@ -174,6 +208,11 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 }
 
 func (lp *Loadpoint) wakeUpVehicle() {
+	if lp.dryRun {
+		lp.log.INFO.Printf("dry run: wake-up, attempts left: %d", lp.wakeUpTimer.wakeupAttemptsLeft)
+		return
+	}
+
 	if lp.wakeUpTimer.wakeupAttemptsLeft%2 != 0 {
 		// charger
 		if c, ok := lp.charger.(api.Resurrector); ok {
@@ -241,6 +280,14 @@ func (lp *Loadpoint) vehicleUnidentified() bool {
 	return true
 }
 
+// updateDefaultVehicle replaces the default vehicle and activates it
+func (lp *Loadpoint) updateDefaultVehicle(v api.Vehicle) {
+	lp.addTask(func() {
+		lp.defaultVehicle = v
+		lp.setActiveVehicle(v)
+	})
+}
+
 // vehicleDefaultOrDetect will assign and update default vehicle or start detection
 func (lp *Loadpoint) vehicleDefaultOrDetect() {
 	if lp.defaultVehicle != nil {
@@ -325,9 +372,9 @@ func (lp *Loadpoint) vehicleClimatePollAllowed() bool {
 
 // vehicleSocPollAllowed validates charging state against polling mode
 func (lp *Loadpoint) vehicleSocPollAllowed() bool {
-	// always update soc when charging
+	// update soc when charging unless limited by charging interval
 	if lp.charging() {
-		return true
+		return lp.Soc.Poll.ChargingInterval == 0 || lp.clock.Since(lp.socUpdated) >= lp.Soc.Poll.ChargingInterval
 	}
 
 	// update if connected and soc unknown
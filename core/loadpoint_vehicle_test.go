@@ -10,6 +10,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/coordinator"
 	"github.com/evcc-io/evcc/core/soc"
+	"github.com/evcc-io/evcc/core/user"
 	"github.com/evcc-io/evcc/util"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -270,7 +271,7 @@ func TestReconnectVehicle(t *testing.T) {
 			// vehicle not updated yet
 			vehicle.MockChargeState.EXPECT().Status().Return(api.StatusA, nil)
 
-			lp.Update(0, 0, nil, false, false, 0, nil, nil)
+			lp.Update(0, 0, nil, nil, false, false, 0, nil, nil)
 			ctrl.Finish()
 
 			// detection started
@@ -284,7 +285,7 @@ func TestReconnectVehicle(t *testing.T) {
 			// vehicle not updated yet
 			vehicle.MockChargeState.EXPECT().Status().Return(api.StatusB, nil)
 
-			lp.Update(0, 0, nil, false, false, 0, nil, nil)
+			lp.Update(0, 0, nil, nil, false, false, 0, nil, nil)
 			ctrl.Finish()
 
 			// vehicle detected
@@ -292,3 +293,28 @@ func TestReconnectVehicle(t *testing.T) {
 		})
 	}
 }
+
+func TestIdentifyVehicleRefused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	identifier := api.NewMockIdentifier(ctrl)
+	identifier.EXPECT().Identify().Return("blocked", nil)
+
+	lp := &Loadpoint{
+		log: util.NewLogger("foo"),
+		charger: struct {
+			*api.MockCharger
+			*api.MockIdentifier
+		}{
+			api.NewMockCharger(ctrl), identifier,
+		},
+		users: user.Users{{Name: "foo", Identifiers: []string{"blocked"}, Blocked: true}},
+	}
+
+	// refused identifier blocks the session
+	lp.identifyVehicle()
+	assert.True(t, lp.authRefused)
+
+	assert.False(t, lp.AuthorizeIdentifier("blocked"))
+	assert.True(t, lp.AuthorizeIdentifier("other"))
+}
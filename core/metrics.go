@@ -0,0 +1,15 @@
+package core
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var updateDuration = prometheus.NewSummary(prometheus.SummaryOpts{
+	Namespace:  "evcc",
+	Subsystem:  "site",
+	Name:       "update_duration_seconds",
+	Help:       "A summary of site update loop durations",
+	Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+})
+
+func init() {
+	prometheus.MustRegister(updateDuration)
+}
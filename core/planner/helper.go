@@ -37,6 +37,15 @@ func Duration(plan api.Rates) time.Duration {
 	return duration
 }
 
+// TotalCost returns the total cost of the plan at the given power in W
+func TotalCost(plan api.Rates, power float64) float64 {
+	var cost float64
+	for _, slot := range plan {
+		cost += slot.End.Sub(slot.Start).Hours() * power / 1e3 * slot.Price
+	}
+	return cost
+}
+
 // AverageCost returns the time-weighted average cost
 func AverageCost(plan api.Rates) float64 {
 	var cost float64
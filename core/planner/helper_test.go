@@ -47,3 +47,8 @@ func TestIsFirst(t *testing.T) {
 	// ensure single slot is always first
 	require.True(t, IsFirst(first, []api.Rate{first}))
 }
+
+func TestTotalCost(t *testing.T) {
+	plan := rates([]float64{0.2, 0.3}, time.Now(), time.Hour)
+	require.InDelta(t, 2*0.2+2*0.3, TotalCost(plan, 2000), 1e-6)
+}
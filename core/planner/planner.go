@@ -1,6 +1,7 @@
 package planner
 
 import (
+	"math"
 	"slices"
 	"time"
 
@@ -11,9 +12,10 @@ import (
 
 // Planner plans a series of charging slots for a given (variable) tariff
 type Planner struct {
-	log    *util.Logger
-	clock  clock.Clock // mockable time
-	tariff api.Tariff
+	log        *util.Logger
+	clock      clock.Clock // mockable time
+	tariff     api.Tariff
+	continuous *float64 // cost tolerance for preferring a continuous plan
 }
 
 // New creates a price planner
@@ -31,6 +33,14 @@ func New(log *util.Logger, tariff api.Tariff, opt ...func(t *Planner)) *Planner
 	return p
 }
 
+// WithContinuousTolerance prefers a single continuous plan over fragmented slots if its
+// average cost exceeds the lowest-cost plan's by at most the given share, e.g. 0.1 for 10%
+func WithContinuousTolerance(tolerance float64) func(t *Planner) {
+	return func(t *Planner) {
+		t.continuous = &tolerance
+	}
+}
+
 // plan creates a lowest-cost plan or required duration.
 // It MUST already established that
 // - rates are sorted in ascending order by cost and descending order by start time (prefer late slots)
@@ -82,6 +92,49 @@ func (t *Planner) plan(rates api.Rates, requiredDuration time.Duration, targetTi
 	return plan
 }
 
+// continuousBlock creates the lowest-cost continuous plan of required duration ending before target time.
+// Costs are linear between rate boundaries, hence only starts aligned with rate boundaries are considered.
+func (t *Planner) continuousBlock(rates api.Rates, requiredDuration time.Duration, targetTime time.Time) api.Rates {
+	latestStart := targetTime.Add(-requiredDuration)
+
+	starts := []time.Time{latestStart}
+	for _, r := range rates {
+		starts = append(starts, r.Start, r.End.Add(-requiredDuration))
+	}
+
+	var res api.Rates
+	var cost float64
+
+	for _, start := range starts {
+		if start.Before(t.clock.Now()) || start.After(latestStart) {
+			continue
+		}
+
+		block := t.continuousPlan(rates, start, start.Add(requiredDuration))
+
+		// prefer late blocks at equal cost
+		if c := AverageCost(block); res == nil || c < cost || c == cost && start.After(Start(res)) {
+			res, cost = block, c
+		}
+	}
+
+	return res
+}
+
+// isContinuous returns true if the plan has no gaps
+func isContinuous(plan api.Rates) bool {
+	plan = slices.Clone(plan)
+	plan.Sort()
+
+	for i := 1; i < len(plan); i++ {
+		if !plan[i-1].End.Equal(plan[i].Start) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Plan creates a continuous emergency charging plan
 func (t *Planner) continuousPlan(rates api.Rates, start, end time.Time) api.Rates {
 	rates.Sort()
@@ -196,6 +249,15 @@ func (t *Planner) Plan(requiredDuration time.Duration, targetTime time.Time) (ap
 
 	plan := t.plan(rates, requiredDuration, targetTime)
 
+	// prefer continuous plan within cost tolerance
+	if t.continuous != nil && !isContinuous(plan) {
+		cost := AverageCost(plan)
+		if block := t.continuousBlock(rates, requiredDuration, targetTime); block != nil && AverageCost(block) <= cost+math.Abs(cost)**t.continuous {
+			t.log.DEBUG.Printf("plan: preferring continuous plan at avg cost %.3f over %.3f", AverageCost(block), cost)
+			plan = block
+		}
+	}
+
 	// sort plan by time
 	plan.Sort()
 
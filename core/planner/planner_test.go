@@ -307,3 +307,36 @@ func TestContinuousPlanOutsideRates(t *testing.T) {
 	// 3-slot plan
 	assert.Len(t, plan, 1)
 }
+
+func TestContinuousTolerance(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().DoAndReturn(func() (api.Rates, error) {
+		return rates([]float64{20, 60, 10, 80, 40, 90}, clock.Now(), time.Hour), nil
+	})
+
+	for _, tc := range []struct {
+		tolerance  float64
+		start, end time.Duration
+		slots      int
+	}{
+		{1.0, 0, 3 * time.Hour, 2},         // 20 + 10 avg 15 beats 60 + 10 avg 35
+		{1.5, time.Hour, 3 * time.Hour, 2}, // continuous 60 + 10 within tolerance
+		{0.0, 0, 3 * time.Hour, 2},         // fragmented
+	} {
+		t.Logf("%+v", tc)
+
+		p := New(util.NewLogger("foo"), trf, WithContinuousTolerance(tc.tolerance))
+		p.clock = clock
+
+		plan, err := p.Plan(2*time.Hour, clock.Now().Add(6*time.Hour))
+		require.NoError(t, err)
+
+		assert.Len(t, plan, tc.slots)
+		assert.Equal(t, clock.Now().Add(tc.start), Start(plan))
+		assert.Equal(t, clock.Now().Add(tc.end), End(plan))
+		assert.Equal(t, tc.tolerance == 1.5, isContinuous(plan))
+	}
+}
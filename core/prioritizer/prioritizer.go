@@ -29,6 +29,21 @@ func (p *Prioritizer) UpdateChargePowerFlexibility(lp loadpoint.API) {
 	}
 }
 
+// GetChargePowerFlexibilityBelow returns the flexible charge power of loadpoints with lower priority
+func (p *Prioritizer) GetChargePowerFlexibilityBelow(prio int) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var res float64
+	for lp, power := range p.demand {
+		if lp.EffectivePriority() < prio && power > 0 {
+			res += power
+		}
+	}
+
+	return res
+}
+
 func (p *Prioritizer) GetChargePowerFlexibility(lp loadpoint.API) float64 {
 	prio := lp.EffectivePriority()
 
@@ -14,7 +14,7 @@ type DB struct {
 
 // NewStore creates a session store
 func NewStore(name string, db *gorm.DB) (*DB, error) {
-	err := db.AutoMigrate(new(Session))
+	err := db.AutoMigrate(new(Session), new(Slot))
 
 	sessiondb := &DB{
 		log:  util.NewLogger("db"),
@@ -38,9 +38,9 @@ func (s *DB) New(meter float64) *Session {
 	return &t
 }
 
-// Persist creates or updates a transaction in the database
+// Persist creates or updates a transaction and its slots in the database
 func (s *DB) Persist(session interface{}) {
-	if err := s.db.Save(session).Error; err != nil {
+	if err := s.db.Session(&gorm.Session{FullSaveAssociations: true}).Save(session).Error; err != nil {
 		s.log.ERROR.Printf("persist: %v", err)
 	}
 }
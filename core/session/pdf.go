@@ -0,0 +1,228 @@
+package session
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// A4 page size and margin in points
+const (
+	pdfWidth  = 595
+	pdfHeight = 842
+	pdfMargin = 40
+)
+
+type pdfFont string
+
+// standard fonts, not embedded
+const (
+	pdfRegular pdfFont = "F1"
+	pdfBold    pdfFont = "F2"
+)
+
+// helveticaWidths are the Helvetica glyph widths of ASCII 32..126 in 1/1000 em
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space../
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // 0..?
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // @..O
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // P.._
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // `..o
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // p..~
+}
+
+// pdfTextWidth approximates the rendered width of s in points
+func pdfTextWidth(s string, size float64) float64 {
+	var w int
+	for _, r := range s {
+		if r >= 32 && int(r-32) < len(helveticaWidths) {
+			w += helveticaWidths[r-32]
+		} else {
+			w += 556
+		}
+	}
+	return float64(w) * size / 1000
+}
+
+// pdfTruncate shortens s to fit the given width
+func pdfTruncate(s string, size, width float64) string {
+	if pdfTextWidth(s, size) <= width {
+		return s
+	}
+
+	rr := []rune(s)
+	for len(rr) > 0 && pdfTextWidth(string(rr)+"...", size) > width {
+		rr = rr[:len(rr)-1]
+	}
+
+	return string(rr) + "..."
+}
+
+// pdfEscape converts s to a WinAnsi encoded PDF string literal
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		c, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			c = '?'
+		}
+
+		switch c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			if c < 32 || c > 126 {
+				fmt.Fprintf(&b, "\\%03o", c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	return b.String()
+}
+
+// pdfColumn is a table column
+type pdfColumn struct {
+	caption string
+	width   float64
+	right   bool
+}
+
+// pdfDocument is a minimal multi-page PDF writer using the standard Helvetica fonts
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func newPdfDocument() *pdfDocument {
+	d := new(pdfDocument)
+	d.addPage()
+	return d
+}
+
+func (d *pdfDocument) addPage() {
+	d.pages = append(d.pages, new(bytes.Buffer))
+	d.y = pdfHeight - pdfMargin
+}
+
+func (d *pdfDocument) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// ensure adds a page if the given height does not fit the current page. Returns true if a page was added.
+func (d *pdfDocument) ensure(height float64) bool {
+	if d.y-height >= pdfMargin {
+		return false
+	}
+
+	d.addPage()
+	return true
+}
+
+// advance moves the baseline down by the given height
+func (d *pdfDocument) advance(height float64) {
+	d.y -= height
+}
+
+// text writes s at x on the current baseline
+func (d *pdfDocument) text(x float64, font pdfFont, size float64, s string) {
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.y, pdfEscape(s))
+}
+
+// rule draws a horizontal line below the current baseline
+func (d *pdfDocument) rule(x1, x2 float64) {
+	fmt.Fprintf(d.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, d.y-3, x2, d.y-3)
+}
+
+// row writes a table row with cells truncated to their column width
+func (d *pdfDocument) row(cols []pdfColumn, font pdfFont, size float64, cells []string) {
+	x := float64(pdfMargin)
+	for i, c := range cols {
+		s := pdfTruncate(cells[i], size, c.width-6)
+		if c.right {
+			d.text(x+c.width-pdfTextWidth(s, size), font, size, s)
+		} else {
+			d.text(x, font, size, s)
+		}
+		x += c.width
+	}
+}
+
+// table writes a table, repeating the header on each page
+func (d *pdfDocument) table(cols []pdfColumn, rows [][]string) {
+	const size = 8
+
+	width := 0.0
+	captions := make([]string, 0, len(cols))
+	for _, c := range cols {
+		width += c.width
+		captions = append(captions, c.caption)
+	}
+
+	header := func() {
+		d.advance(14)
+		d.row(cols, pdfBold, size, captions)
+		d.rule(pdfMargin, pdfMargin+width)
+		d.advance(2)
+	}
+
+	d.ensure(40)
+	header()
+
+	for _, r := range rows {
+		if d.ensure(12) {
+			header()
+		}
+		d.advance(12)
+		d.row(cols, pdfRegular, size, r)
+	}
+}
+
+// WriteTo writes the document, adding page numbers to the footer
+func (d *pdfDocument) WriteTo(w io.Writer) (int64, error) {
+	var (
+		b       bytes.Buffer
+		offsets []int
+	)
+
+	obj := func(format string, a ...any) {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n", len(offsets))
+		fmt.Fprintf(&b, format, a...)
+		b.WriteString("\nendobj\n")
+	}
+
+	b.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	// page objects follow catalog, page tree and fonts
+	kids := make([]string, 0, len(d.pages))
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+2*i))
+	}
+
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj("<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %d %d] >>", strings.Join(kids, " "), len(d.pages), pdfWidth, pdfHeight)
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, p := range d.pages {
+		page := fmt.Sprintf("%d / %d", i+1, len(d.pages))
+		stream := p.String() + fmt.Sprintf("BT /%s 8.0 Tf %.2f %d Td (%s) Tj ET\n", pdfRegular, pdfWidth-pdfMargin-pdfTextWidth(page, 8), pdfMargin/2, pdfEscape(page))
+
+		obj("<< /Type /Page /Parent 2 0 R /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>", pdfRegular, pdfBold, 6+2*i)
+		obj("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream)
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, o := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", o)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return b.WriteTo(w)
+}
@@ -0,0 +1,85 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util/locale"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestPdfEscape(t *testing.T) {
+	assert.Equal(t, `a\(b\)\\`, pdfEscape(`a(b)\`))
+	assert.Equal(t, `K\374che \200`, pdfEscape("Küche €"))
+	assert.Equal(t, "CO?", pdfEscape("CO₂"))
+}
+
+func TestPdfTruncate(t *testing.T) {
+	assert.Equal(t, "short", pdfTruncate("short", 8, 100))
+
+	s := pdfTruncate("a very long vehicle title", 8, 40)
+	assert.Regexp(t, `^a very.*\.\.\.$`, s)
+	assert.LessOrEqual(t, pdfTextWidth(s, 8), 40.0)
+}
+
+func TestWritePdf(t *testing.T) {
+	// empty bundle, captions fall back to defaults
+	locale.Bundle = i18n.NewBundle(language.English)
+	locale.Localizer = i18n.NewLocalizer(locale.Bundle, "en")
+
+	var sessions Sessions
+	for i := range 100 {
+		sessions = append(sessions, Session{
+			Created:         time.Date(2024, 1, 1+i%28, 12, 0, 0, 0, time.Local),
+			Loadpoint:       "Garage",
+			Vehicle:         "Model (Y)",
+			Identifier:      fmt.Sprintf("tag%d", i%3),
+			ChargedEnergy:   10,
+			SolarPercentage: lo.ToPtr(50.0),
+			Price:           lo.ToPtr(2.5),
+		})
+	}
+
+	ctx := context.WithValue(context.Background(), locale.Locale, "en")
+
+	var b bytes.Buffer
+	require.NoError(t, sessions.WritePdf(ctx, &b))
+
+	pdf := b.Bytes()
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+
+	// multiple pages
+	assert.Contains(t, b.String(), "/Count 2")
+	assert.Contains(t, b.String(), "(1 / 2)")
+
+	// escaped text and totals
+	assert.Contains(t, b.String(), `(Model \(Y\))`)
+	assert.Contains(t, b.String(), "(Summary by identifier)")
+	assert.Contains(t, b.String(), "(1000)")
+	assert.Contains(t, b.String(), "(250)")
+	assert.Contains(t, b.String(), `(2024-01-01 \226 2024-01-28)`)
+
+	// xref offsets point to objects
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	require.NotNil(t, m)
+	xref, _ := strconv.Atoi(string(m[1]))
+	require.True(t, bytes.HasPrefix(pdf[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	require.NotEmpty(t, entries)
+
+	for i, e := range entries {
+		offset, _ := strconv.Atoi(string(e[1]))
+		assert.True(t, bytes.HasPrefix(pdf[offset:], fmt.Appendf(nil, "%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+}
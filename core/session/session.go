@@ -25,6 +25,8 @@ type Session struct {
 	Finished        time.Time      `json:"finished"`
 	Loadpoint       string         `json:"loadpoint"`
 	Identifier      string         `json:"identifier"`
+	User            string         `json:"user"`
+	Authorization   string         `json:"authorization"`
 	Vehicle         string         `json:"vehicle"`
 	Odometer        *float64       `json:"odometer" format:"int"`
 	MeterStart      *float64       `json:"meterStart" csv:"Meter Start (kWh)" gorm:"column:meter_start_kwh"`
@@ -35,37 +37,62 @@ type Session struct {
 	Price           *float64       `json:"price" csv:"Price" gorm:"column:price"`
 	PricePerKWh     *float64       `json:"pricePerKWh" csv:"Price/kWh" gorm:"column:price_per_kwh"`
 	Co2PerKWh       *float64       `json:"co2PerKWh" csv:"CO2/kWh (gCO2eq)" gorm:"column:co2_per_kwh"`
+	PauseReason     string         `json:"pauseReason" csv:"Pause Reason" gorm:"column:pause_reason"`
+	Resumed         *time.Time     `json:"resumed" csv:"Resumed" gorm:"column:resumed"`
+	Slots           []Slot         `json:"slots,omitempty" csv:"-" gorm:"constraint:OnDelete:CASCADE"`
 }
 
 // Sessions is a list of sessions
 type Sessions []Session
 
-var _ api.CsvWriter = (*Sessions)(nil)
+var (
+	_ api.CsvWriter = (*Sessions)(nil)
+	_ api.PdfWriter = (*Sessions)(nil)
+)
 
-func (t *Sessions) writeHeader(ctx context.Context, ww *csv.Writer) error {
-	localizer := locale.Localizer
-	if val := ctx.Value(locale.Locale).(string); val != "" {
-		localizer = i18n.NewLocalizer(locale.Bundle, val, locale.Language)
+// contextLocalizer returns the localizer for the context's language
+func contextLocalizer(ctx context.Context) *i18n.Localizer {
+	if val, ok := ctx.Value(locale.Locale).(string); ok && val != "" {
+		return i18n.NewLocalizer(locale.Bundle, val, locale.Language)
 	}
+	return locale.Localizer
+}
+
+// contextLanguage returns the context's language tag
+func contextLanguage(ctx context.Context) (language.Tag, error) {
+	lang := locale.Language
+	if val, ok := ctx.Value(locale.Locale).(string); ok && val != "" {
+		lang = val
+	}
+
+	return language.Parse(lang)
+}
+
+// localize returns the localized message or the fallback if not found
+func localize(localizer *i18n.Localizer, id, fallback string) string {
+	if res, err := localizer.Localize(&locale.Config{MessageID: id}); err == nil {
+		return res
+	}
+	return fallback
+}
+
+func writeHeader(ctx context.Context, ww *csv.Writer, prefix string, v any) error {
+	localizer := contextLocalizer(ctx)
 
 	var row []string
-	for _, f := range structs.Fields(Session{}) {
+	for _, f := range structs.Fields(v) {
 		csv := f.Tag("csv")
 		if csv == "-" {
 			continue
 		}
 
-		caption, err := localizer.Localize(&locale.Config{
-			MessageID: "sessions.csv." + strings.ToLower(f.Name()),
-		})
-		if err != nil {
-			if csv != "" {
-				caption = csv
-			} else {
-				caption = f.Name()
-			}
+		fallback := csv
+		if fallback == "" {
+			fallback = f.Name()
 		}
 
+		caption := localize(localizer, prefix+strings.ToLower(f.Name()), fallback)
+
 		row = append(row, caption)
 	}
 
@@ -92,7 +119,7 @@ func formatValue(mp *message.Printer, value any, digits int) string {
 	}
 }
 
-func (t *Sessions) writeRow(ww *csv.Writer, mp *message.Printer, r Session) error {
+func writeRow(ww *csv.Writer, mp *message.Printer, r any) error {
 	var row []string
 	for _, f := range structs.Fields(r) {
 		if f.Tag("csv") == "-" {
@@ -114,17 +141,16 @@ func (t *Sessions) writeRow(ww *csv.Writer, mp *message.Printer, r Session) erro
 
 // WriteCsv implements the api.CsvWriter interface
 func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
+	return writeCsv(ctx, w, "sessions.csv.", *t)
+}
+
+// writeCsv writes the rows as localized csv, using the prefix for localizing the header
+func writeCsv[T any](ctx context.Context, w io.Writer, prefix string, rows []T) error {
 	if _, err := w.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
 		return err
 	}
 
-	// get context language
-	lang := locale.Language
-	if language, ok := ctx.Value(locale.Locale).(string); ok && language != "" {
-		lang = language
-	}
-
-	tag, err := language.Parse(lang)
+	tag, err := contextLanguage(ctx)
 	if err != nil {
 		return err
 	}
@@ -136,13 +162,14 @@ func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
 		ww.Comma = ';'
 	}
 
-	if err := t.writeHeader(ctx, ww); err != nil {
+	var zero T
+	if err := writeHeader(ctx, ww, prefix, zero); err != nil {
 		return err
 	}
 
 	mp := message.NewPrinter(tag)
-	for _, r := range *t {
-		if err := t.writeRow(ww, mp, r); err != nil {
+	for _, r := range rows {
+		if err := writeRow(ww, mp, r); err != nil {
 			return err
 		}
 	}
@@ -151,3 +178,110 @@ func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
 
 	return ww.Error()
 }
+
+// WritePdf implements the api.PdfWriter interface
+func (t *Sessions) WritePdf(ctx context.Context, w io.Writer) error {
+	tag, err := contextLanguage(ctx)
+	if err != nil {
+		return err
+	}
+
+	mp := message.NewPrinter(tag)
+	localizer := contextLocalizer(ctx)
+
+	caption := func(id, fallback string) string {
+		return localize(localizer, id, fallback)
+	}
+
+	d := newPdfDocument()
+
+	d.advance(16)
+	d.text(pdfMargin, pdfBold, 16, caption("sessions.title", "Charging Sessions"))
+
+	if len(*t) > 0 {
+		from, to := (*t)[0].Created, (*t)[0].Created
+		for _, s := range *t {
+			if s.Created.Before(from) {
+				from = s.Created
+			}
+			if s.Created.After(to) {
+				to = s.Created
+			}
+		}
+
+		d.advance(16)
+		d.text(pdfMargin, pdfRegular, 10, from.Local().Format(time.DateOnly)+" – "+to.Local().Format(time.DateOnly))
+	}
+
+	// totals
+	total := t.Total()
+
+	d.advance(10)
+	for _, r := range [][2]string{
+		{caption("sessions.csv.sessions", "Sessions"), mp.Sprint(total.Sessions)},
+		{caption("sessions.csv.chargedenergy", "Energy (kWh)"), formatValue(mp, total.ChargedEnergy, 2)},
+		{caption("sessions.csv.solarpercentage", "Solar (%)"), formatValue(mp, total.SolarPercentage, 1)},
+		{caption("sessions.csv.price", "Price"), formatValue(mp, total.Price, 2)},
+	} {
+		d.advance(14)
+		d.text(pdfMargin, pdfRegular, 10, r[0])
+		d.text(pdfMargin+150, pdfBold, 10, r[1])
+	}
+
+	// sessions
+	rows := make([][]string, 0, len(*t))
+	for _, s := range *t {
+		rows = append(rows, []string{
+			s.Created.Local().Format("2006-01-02 15:04"),
+			s.Loadpoint,
+			s.Vehicle,
+			s.Identifier,
+			formatValue(mp, s.ChargedEnergy, 2),
+			formatValue(mp, s.SolarPercentage, 1),
+			formatValue(mp, s.Price, 2),
+		})
+	}
+
+	d.advance(16)
+	d.table([]pdfColumn{
+		{caption: caption("sessions.csv.created", "Created"), width: 80},
+		{caption: caption("sessions.csv.loadpoint", "Charging point"), width: 95},
+		{caption: caption("sessions.csv.vehicle", "Vehicle"), width: 95},
+		{caption: caption("sessions.csv.identifier", "Identifier"), width: 85},
+		{caption: caption("sessions.csv.chargedenergy", "Energy (kWh)"), width: 55, right: true},
+		{caption: caption("sessions.csv.solarpercentage", "Solar (%)"), width: 50, right: true},
+		{caption: caption("sessions.csv.price", "Price"), width: 55, right: true},
+	}, rows)
+
+	// summary by identifier
+	summary := t.SummaryByIdentifier()
+
+	rows = make([][]string, 0, len(summary))
+	for _, s := range summary {
+		rows = append(rows, []string{
+			s.Identifier,
+			s.User,
+			mp.Sprint(s.Sessions),
+			formatValue(mp, s.ChargedEnergy, 2),
+			formatValue(mp, s.SolarPercentage, 1),
+			formatValue(mp, s.Price, 2),
+		})
+	}
+
+	d.ensure(80)
+	d.advance(30)
+	d.text(pdfMargin, pdfBold, 12, caption("sessions.pdf.summary", "Summary by identifier"))
+
+	d.advance(4)
+	d.table([]pdfColumn{
+		{caption: caption("sessions.csv.identifier", "Identifier"), width: 120},
+		{caption: caption("sessions.csv.user", "User"), width: 120},
+		{caption: caption("sessions.csv.sessions", "Sessions"), width: 55, right: true},
+		{caption: caption("sessions.csv.chargedenergy", "Energy (kWh)"), width: 75, right: true},
+		{caption: caption("sessions.csv.solarpercentage", "Solar (%)"), width: 65, right: true},
+		{caption: caption("sessions.csv.price", "Price"), width: 80, right: true},
+	}, rows)
+
+	_, err = d.WriteTo(w)
+	return err
+}
@@ -0,0 +1,43 @@
+package session
+
+import (
+	"time"
+)
+
+// SlotDuration is the interval of session slots, matching tariff slots
+const SlotDuration = 15 * time.Minute
+
+// Slot is the energy charged within a single interval of a session
+type Slot struct {
+	ID              uint      `json:"-" gorm:"primarykey"`
+	SessionID       uint      `json:"-" gorm:"index"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	ChargedEnergy   float64   `json:"chargedEnergy" gorm:"column:charged_kwh"`
+	SolarPercentage float64   `json:"solarPercentage" gorm:"column:solar_percentage"`
+	Price           *float64  `json:"price" gorm:"column:price"`
+	PricePerKWh     *float64  `json:"pricePerKWh" gorm:"column:price_per_kwh"`
+	Co2PerKWh       *float64  `json:"co2PerKWh" gorm:"column:co2_per_kwh"`
+}
+
+// TableName implements the gorm Tabler interface
+func (Slot) TableName() string {
+	return "session_slots"
+}
+
+// SetSlots updates the session's slots, keeping the database ids of already persisted slots
+func (t *Session) SetSlots(slots []Slot) {
+	ids := make(map[time.Time]uint, len(t.Slots))
+	for _, s := range t.Slots {
+		ids[s.Start] = s.ID
+	}
+
+	res := make([]Slot, 0, len(slots))
+	for _, s := range slots {
+		s.ID = ids[s.Start]
+		s.SessionID = t.ID
+		res = append(res, s)
+	}
+
+	t.Slots = res
+}
@@ -0,0 +1,44 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlotsPersist(t *testing.T) {
+	gdb, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	db, err := NewStore("foo", gdb)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	slot := func(offset int, energy float64) Slot {
+		ts := start.Add(time.Duration(offset) * SlotDuration)
+		return Slot{Start: ts, End: ts.Add(SlotDuration), ChargedEnergy: energy, PricePerKWh: lo.ToPtr(0.3)}
+	}
+
+	s := db.New(0)
+	s.Created = start
+
+	s.SetSlots([]Slot{slot(0, 1)})
+	db.Persist(s)
+
+	// updated and added slots
+	s.SetSlots([]Slot{slot(0, 2), slot(1, 1)})
+	db.Persist(s)
+
+	var res Sessions
+	require.NoError(t, gdb.Preload("Slots").Find(&res).Error)
+	require.Len(t, res, 1)
+	require.Len(t, res[0].Slots, 2)
+
+	assert.Equal(t, 2.0, res[0].Slots[0].ChargedEnergy)
+	assert.Equal(t, 1.0, res[0].Slots[1].ChargedEnergy)
+	assert.Equal(t, 0.3, *res[0].Slots[1].PricePerKWh)
+}
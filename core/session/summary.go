@@ -0,0 +1,118 @@
+package session
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// Summary aggregates the sessions of a single identifier
+type Summary struct {
+	Identifier      string   `json:"identifier"`
+	User            string   `json:"user"`
+	Sessions        int      `json:"sessions" format:"int"`
+	ChargedEnergy   float64  `json:"chargedEnergy" csv:"Charged Energy (kWh)"`
+	SolarPercentage *float64 `json:"solarPercentage" csv:"Solar (%)"`
+	Price           *float64 `json:"price" csv:"Price"`
+	PricePerKWh     *float64 `json:"pricePerKWh" csv:"Price/kWh"`
+}
+
+// Summaries is a list of session summaries
+type Summaries []Summary
+
+var _ api.CsvWriter = (*Summaries)(nil)
+
+// WriteCsv implements the api.CsvWriter interface
+func (t *Summaries) WriteCsv(ctx context.Context, w io.Writer) error {
+	return writeCsv(ctx, w, "sessions.csv.", *t)
+}
+
+// SummaryByIdentifier aggregates sessions by identifier. Solar share and price per kWh are energy-weighted.
+func (t Sessions) SummaryByIdentifier() Summaries {
+	return t.summarize(func(s Session) string { return s.Identifier })
+}
+
+// Total aggregates all sessions into a single summary without identifier and user
+func (t Sessions) Total() Summary {
+	res := t.summarize(func(Session) string { return "" })
+	if len(res) == 0 {
+		return Summary{}
+	}
+
+	total := res[0]
+	total.User = ""
+
+	return total
+}
+
+// summarize aggregates sessions by the given key
+func (t Sessions) summarize(key func(Session) string) Summaries {
+	type acc struct {
+		Summary
+		solarEnergy  float64 // energy with known solar percentage
+		solarWeight  float64
+		pricedEnergy float64 // energy with known price
+	}
+
+	var keys []string
+	res := make(map[string]*acc)
+
+	for _, s := range t {
+		k := key(s)
+
+		a, ok := res[k]
+		if !ok {
+			a = &acc{Summary: Summary{Identifier: k}}
+			res[k] = a
+			keys = append(keys, k)
+		}
+
+		if a.User == "" {
+			a.User = s.User
+		}
+
+		a.Sessions++
+		a.ChargedEnergy += s.ChargedEnergy
+
+		if s.SolarPercentage != nil {
+			a.solarEnergy += s.ChargedEnergy * *s.SolarPercentage
+			a.solarWeight += s.ChargedEnergy
+		}
+
+		if s.Price != nil {
+			a.Price = ptrAdd(a.Price, *s.Price)
+			a.pricedEnergy += s.ChargedEnergy
+		}
+	}
+
+	slices.SortFunc(keys, strings.Compare)
+
+	summaries := make(Summaries, 0, len(keys))
+	for _, k := range keys {
+		a := res[k]
+
+		if a.solarWeight > 0 {
+			solar := a.solarEnergy / a.solarWeight
+			a.SolarPercentage = &solar
+		}
+
+		if a.Price != nil && a.pricedEnergy > 0 {
+			perKWh := *a.Price / a.pricedEnergy
+			a.PricePerKWh = &perKWh
+		}
+
+		summaries = append(summaries, a.Summary)
+	}
+
+	return summaries
+}
+
+func ptrAdd(p *float64, v float64) *float64 {
+	if p != nil {
+		v += *p
+	}
+	return &v
+}
@@ -0,0 +1,46 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryByIdentifier(t *testing.T) {
+	sessions := Sessions{
+		{Identifier: "b", ChargedEnergy: 10, SolarPercentage: lo.ToPtr(100.0), Price: lo.ToPtr(1.0)},
+		{Identifier: "a", User: "alice", ChargedEnergy: 5},
+		{Identifier: "b", ChargedEnergy: 30, SolarPercentage: lo.ToPtr(0.0), Price: lo.ToPtr(9.0)},
+	}
+
+	res := sessions.SummaryByIdentifier()
+	require.Len(t, res, 2)
+
+	assert.Equal(t, Summary{Identifier: "a", User: "alice", Sessions: 1, ChargedEnergy: 5}, res[0])
+
+	b := res[1]
+	assert.Equal(t, "b", b.Identifier)
+	assert.Equal(t, 2, b.Sessions)
+	assert.Equal(t, 40.0, b.ChargedEnergy)
+	assert.Equal(t, 25.0, *b.SolarPercentage)
+	assert.Equal(t, 10.0, *b.Price)
+	assert.Equal(t, 0.25, *b.PricePerKWh)
+}
+
+func TestTotal(t *testing.T) {
+	assert.Equal(t, Summary{}, Sessions(nil).Total())
+
+	sessions := Sessions{
+		{Identifier: "a", User: "alice", ChargedEnergy: 10, SolarPercentage: lo.ToPtr(100.0)},
+		{Identifier: "b", ChargedEnergy: 30, SolarPercentage: lo.ToPtr(0.0)},
+	}
+
+	res := sessions.Total()
+	assert.Equal(t, "", res.User)
+	assert.Equal(t, 2, res.Sessions)
+	assert.Equal(t, 40.0, res.ChargedEnergy)
+	assert.Equal(t, 25.0, *res.SolarPercentage)
+	assert.Nil(t, res.Price)
+}
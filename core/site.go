@@ -9,10 +9,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/cmd/shutdown"
+	"github.com/evcc-io/evcc/core/battery"
 	"github.com/evcc-io/evcc/core/circuit"
+	"github.com/evcc-io/evcc/core/consumer"
 	"github.com/evcc-io/evcc/core/coordinator"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
@@ -21,6 +24,7 @@ import (
 	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/core/soc"
+	"github.com/evcc-io/evcc/core/user"
 	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/server/db"
@@ -29,6 +33,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/telemetry"
+	"github.com/evcc-io/evcc/util/tracing"
 	"github.com/samber/lo"
 	"github.com/smallnest/chanx"
 	"golang.org/x/sync/errgroup"
@@ -36,17 +41,27 @@ import (
 
 const standbyPower = 10 // consider less than 10W as charger in standby
 
+const evGridLimit = "gridlimit" // grid operator limit activated
+
+const (
+	exportLimitTolerance  = 100 // W below export limit considered as limit reached
+	exportLimitProbePower = 500 // W additional power offered while export is limited
+)
+
 // updater abstracts the Loadpoint implementation for testing
 type updater interface {
 	loadpoint.API
-	Update(sitePower, batteryBoostPower float64, rates api.Rates, batteryBuffered, batteryStart bool, greenShare float64, effectivePrice, effectiveCo2 *float64)
+	Update(sitePower, batteryBoostPower float64, rates, co2Rates api.Rates, batteryBuffered, batteryStart bool, greenShare float64, effectivePrice, effectiveCo2 *float64)
+	offeredPower() float64
 }
 
 // meterMeasurement is used as slice element for publishing structured data
 type meterMeasurement struct {
-	Power         float64 `json:"power"`
-	Energy        float64 `json:"energy,omitempty"`
-	ExcessDCPower float64 `json:"excessdcpower,omitempty"`
+	Name          string    `json:"name,omitempty"`
+	Power         float64   `json:"power"`
+	Energy        float64   `json:"energy,omitempty"`
+	ExcessDCPower float64   `json:"excessdcpower,omitempty"`
+	Mppts         []float64 `json:"mppts,omitempty"` // per-tracker dc power
 }
 
 // batteryMeasurement is used as slice element for publishing structured data
@@ -63,6 +78,7 @@ var _ site.API = (*Site)(nil)
 // Site is the main configuration container. A site can host multiple loadpoints.
 type Site struct {
 	uiChan       chan<- util.Param // client push messages
+	pushChan     chan<- push.Event // notifications
 	lpUpdateChan chan *Loadpoint
 
 	*Health
@@ -71,10 +87,16 @@ type Site struct {
 	log *util.Logger
 
 	// configuration
-	Title         string       `mapstructure:"title"`         // UI title
-	Voltage       float64      `mapstructure:"voltage"`       // Operating voltage. 230V for Germany.
-	ResidualPower float64      `mapstructure:"residualPower"` // PV meter only: household usage. Grid meter: household safety margin
-	Meters        MetersConfig `mapstructure:"meters"`        // Meter references
+	Title         string                   `mapstructure:"title"`            // UI title
+	Voltage       float64                  `mapstructure:"voltage"`          // Operating voltage. 230V for Germany.
+	ResidualPower float64                  `mapstructure:"residualPower"`    // PV meter only: household usage. Grid meter: household safety margin
+	Meters        MetersConfig             `mapstructure:"meters"`           // Meter references
+	Users         user.Users               `mapstructure:"users"`            // Users identified by rfid
+	RequireAuth   bool                     `mapstructure:"requireAuth"`      // Refuse unknown identifiers on chargers supporting remote authorization
+	Consumers     []consumer.Config        `mapstructure:"consumers"`        // Switchable consumers using pv surplus
+	Schedules     []battery.Config         `mapstructure:"batterySchedules"` // Calendar-based battery modes
+	Intervals     map[string]time.Duration `mapstructure:"intervals"`        // Update intervals by meter reference
+	DryRun        bool                     `mapstructure:"dryRun"`           // Read devices and compute decisions without controlling chargers, batteries or consumers
 	// TODO deprecated
 	CircuitRef_                        string  `mapstructure:"circuit"`                           // Circuit reference
 	MaxGridSupplyWhileBatteryCharging_ float64 `mapstructure:"maxGridSupplyWhileBatteryCharging"` // ignore battery charging if AC consumption is above this value
@@ -87,12 +109,19 @@ type Site struct {
 	extMeters     []api.Meter // External meters - for monitoring only
 	auxMeters     []api.Meter // Auxiliary meters
 
+	consumers []*consumer.Consumer // Switchable consumers by descending priority
+
 	// battery settings
 	prioritySoc             float64  // prefer battery up to this Soc
 	bufferSoc               float64  // continue charging on battery above this Soc
 	bufferStartSoc          float64  // start charging on battery above this Soc
 	batteryDischargeControl bool     // prevent battery discharge for fast and planned charging
 	batteryGridChargeLimit  *float64 // grid charging limit
+	batteryGridChargeHours  int      // grid charging during cheapest hours
+	batteryReserve          *float64 // charge and hold battery up to this soc, e.g. for storms
+	batterySchedules        []*battery.Schedule
+
+	exportLimit *float64 // grid export limit
 
 	loadpoints  []*Loadpoint             // Loadpoints
 	tariffs     *tariff.Tariffs          // Tariffs
@@ -109,7 +138,16 @@ type Site struct {
 	batterySoc    float64         // Battery soc
 	batteryMode   api.BatteryMode // Battery mode (runtime only, not persisted)
 
+	// external grid limits (runtime only, not persisted)
+	gridConsumptionLimit float64 // Grid operator consumption limit
+	gridProductionLimit  float64 // Grid operator production limit
+
 	publishCache map[string]any // store last published values to avoid unnecessary republishing
+	health       *deviceHealth  // meter failure notifications
+
+	pollers     sync.Map      // device pollers by device
+	pollTimeout time.Duration // max. wait for device reads, then use last value
+	pollMaxAge  time.Duration // max. age of last value
 }
 
 // MetersConfig contains the site's meter configuration
@@ -143,6 +181,26 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 	site.loadpoints = loadpoints
 	site.tariffs = tariffs
 
+	if site.DryRun {
+		site.log.WARN.Println("dry run: devices are read but not controlled")
+		for _, lp := range loadpoints {
+			lp.dryRun = true
+		}
+	}
+
+	if err := site.Users.Validate(); err != nil {
+		return err
+	}
+
+	for _, u := range site.Users {
+		if u.Vehicle == "" {
+			continue
+		}
+		if _, err := config.Vehicles().ByName(u.Vehicle); err != nil {
+			return fmt.Errorf("user %s: %w", u.Name, err)
+		}
+	}
+
 	handler := config.Vehicles()
 	site.coordinator = coordinator.New(log, config.Instances(handler.Devices()))
 	handler.Subscribe(site.updateVehicles)
@@ -158,11 +216,22 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 	}
 
 	tariff := site.GetTariff(PlannerTariff)
+	co2Tariff := site.GetTariff(Co2Tariff)
 
 	// give loadpoints access to vehicles and database
 	for _, lp := range loadpoints {
 		lp.coordinator = coordinator.NewAdapter(lp, site.coordinator)
-		lp.planner = planner.New(lp.log, tariff)
+		var opt []func(*planner.Planner)
+		if lp.PlanContinuous != nil {
+			opt = append(opt, planner.WithContinuousTolerance(*lp.PlanContinuous))
+		}
+
+		lp.planner = planner.New(lp.log, site.solarAdjustedTariff(tariff, lp.GetMaxPower()), opt...)
+		if co2Tariff != nil {
+			lp.co2Planner = planner.New(lp.log, co2Tariff, opt...)
+		}
+		lp.users = site.Users
+		lp.requireAuth = site.RequireAuth
 
 		if db.Instance != nil {
 			var err error
@@ -229,6 +298,18 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 		site.auxMeters = append(site.auxMeters, dev.Instance())
 	}
 
+	if err := site.configureConsumers(); err != nil {
+		return err
+	}
+
+	for i, cc := range site.Schedules {
+		s, err := battery.NewFromConfig(cc)
+		if err != nil {
+			return fmt.Errorf("battery schedule %d: %w", i+1, err)
+		}
+		site.batterySchedules = append(site.batterySchedules, s)
+	}
+
 	if site.MaxGridSupplyWhileBatteryCharging_ != 0 {
 		site.log.WARN.Println("`MaxGridSupplyWhileBatteryCharging` is deprecated- use `maxACPower` in pv configuration instead")
 	}
@@ -250,6 +331,7 @@ func NewSite() *Site {
 	lp := &Site{
 		log:          util.NewLogger("site"),
 		publishCache: make(map[string]any),
+		health:       newDeviceHealth(clock.New(), deviceHealthDebounce),
 		Voltage:      230, // V
 	}
 
@@ -302,6 +384,11 @@ func (site *Site) restoreSettings() error {
 			return err
 		}
 	}
+	if v, err := settings.Int(keys.BatteryGridChargeHours); err == nil {
+		if err := site.SetBatteryGridChargeHours(int(v)); err != nil {
+			return err
+		}
+	}
 	if v, err := settings.Bool(keys.BatteryDischargeControl); err == nil {
 		if err := site.SetBatteryDischargeControl(v); err != nil {
 			return err
@@ -312,16 +399,28 @@ func (site *Site) restoreSettings() error {
 			return err
 		}
 	}
+	if v, err := settings.Float(keys.ExportLimit); err == nil {
+		if err := site.SetExportLimit(&v); err != nil {
+			return err
+		}
+	}
 	if v, err := settings.Float(keys.BatteryGridChargeLimit); err == nil {
 		site.SetBatteryGridChargeLimit(&v)
 	}
+	if v, err := settings.Float(keys.BatteryReserve); err == nil {
+		if err := site.SetBatteryReserve(&v); err != nil {
+			return err
+		}
+	}
+
+	site.restoreCircuitLimits()
 
 	return nil
 }
 
 func meterCapabilities(name string, meter interface{}) string {
 	_, power := meter.(api.Meter)
-	_, energy := meter.(api.MeterEnergy)
+	energy := api.Has[api.MeterEnergy](meter)
 	_, currents := meter.(api.PhaseCurrents)
 
 	name += ":"
@@ -363,8 +462,8 @@ func (site *Site) DumpConfig() {
 
 	if len(site.batteryMeters) > 0 {
 		for i, battery := range site.batteryMeters {
-			_, ok := battery.(api.Battery)
-			_, hasCapacity := battery.(api.BatteryCapacity)
+			ok := api.Has[api.Battery](battery)
+			hasCapacity := api.Has[api.BatteryCapacity](battery)
 
 			site.log.INFO.Println(
 				meterCapabilities(fmt.Sprintf("battery %d", i+1), battery),
@@ -393,7 +492,7 @@ func (site *Site) DumpConfig() {
 		lp.log.INFO.Printf("  mode:        %s", lp.GetMode())
 
 		_, power := lp.charger.(api.Meter)
-		_, energy := lp.charger.(api.MeterEnergy)
+		energy := api.Has[api.MeterEnergy](lp.charger)
 		_, currents := lp.charger.(api.PhaseCurrents)
 		_, phases := lp.charger.(api.PhaseSwitcher)
 		_, wakeup := lp.charger.(api.Resurrector)
@@ -424,6 +523,48 @@ func (site *Site) publish(key string, val interface{}) {
 	site.uiChan <- util.Param{Key: key, Val: val}
 }
 
+// pushEvent sends push messages to clients
+func (site *Site) pushEvent(event string) {
+	// test helper
+	if site.pushChan == nil {
+		return
+	}
+
+	site.pushChan <- push.Event{Event: event}
+}
+
+// poll reads the device in its own goroutine without blocking the site update for longer than the poll timeout.
+// Meters with a configured interval are only read once per interval.
+func poll[T any](site *Site, device, ref string, read func() (T, error)) (T, error) {
+	p, _ := site.pollers.LoadOrStore(device, newDevicePoller(read, site.interval(ref)))
+	return p.(*devicePoller[T]).Get(site.pollTimeout, site.pollMaxAge)
+}
+
+// interval returns the configured meter update interval. Config keys may have been lower-cased.
+func (site *Site) interval(ref string) time.Duration {
+	for k, v := range site.Intervals {
+		if strings.EqualFold(k, ref) {
+			return v
+		}
+	}
+	return 0
+}
+
+// meterRef returns the i-th meter reference if configured
+func meterRef(refs []string, i int) string {
+	if i < len(refs) {
+		return refs[i]
+	}
+	return ""
+}
+
+// updateDeviceHealth sends push messages if the device has started failing or recovered
+func (site *Site) updateDeviceHealth(device string, err error) {
+	if ev, ok := site.health.update(device, err); ok && site.pushChan != nil {
+		site.pushChan <- ev
+	}
+}
+
 // publishDelta deduplicates messages before publishing
 func (site *Site) publishDelta(key string, val interface{}) {
 	if v, ok := site.publishCache[key]; ok && v == val {
@@ -444,9 +585,10 @@ func (site *Site) updatePvMeters() {
 
 	mm := make([]meterMeasurement, len(site.pvMeters))
 
-	fun := func(i int, meter api.Meter) {
+	fun := func(i int, meter api.Meter) (meterMeasurement, error) {
 		// power
 		power, err := backoff.RetryWithData(meter.CurrentPower, bo())
+		site.updateDeviceHealth(meterRef(site.Meters.PVMetersRef, i), err)
 		if err == nil {
 			if power < -500 {
 				site.log.WARN.Printf("pv %d power: %.0fW is negative - check configuration if sign is correct", i+1, power)
@@ -457,7 +599,7 @@ func (site *Site) updatePvMeters() {
 
 		// energy (production)
 		var energy float64
-		if m, ok := meter.(api.MeterEnergy); err == nil && ok {
+		if m, ok := api.As[api.MeterEnergy](meter); err == nil && ok {
 			energy, err = m.TotalEnergy()
 			if err != nil {
 				site.log.ERROR.Printf("pv %d energy: %v", i+1, err)
@@ -473,22 +615,40 @@ func (site *Site) updatePvMeters() {
 			}
 		}
 
+		// mppt powers
+		var mppts []float64
+		if m, ok := api.As[api.MpptPowers](meter); ok {
+			if res, err := m.MpptPowers(); err == nil {
+				mppts = res
+			} else {
+				site.log.ERROR.Printf("pv %d mppt powers: %v", i+1, err)
+			}
+		}
+
 		if len(site.pvMeters) > 1 {
 			site.log.DEBUG.Printf("pv %d power: %.0fW"+excessStr, i+1, power)
 		}
 
-		mm[i] = meterMeasurement{
+		return meterMeasurement{
+			Name:          meterRef(site.Meters.PVMetersRef, i),
 			Power:         power,
 			Energy:        energy,
 			ExcessDCPower: excessDC,
-		}
-
-		wg.Done()
+			Mppts:         mppts,
+		}, nil
 	}
 
 	wg.Add(len(site.pvMeters))
 	for i, meter := range site.pvMeters {
-		go fun(i, meter)
+		go func() {
+			defer wg.Done()
+
+			res, err := poll(site, fmt.Sprintf("pv-%d", i), meterRef(site.Meters.PVMetersRef, i), func() (meterMeasurement, error) { return fun(i, meter) })
+			if err != nil {
+				site.log.ERROR.Printf("pv %d: %v", i+1, err)
+			}
+			mm[i] = res
+		}()
 	}
 	wg.Wait()
 
@@ -523,20 +683,18 @@ func (site *Site) updateAuxMeters() {
 
 	mm := make([]meterMeasurement, len(site.auxMeters))
 
-	fun := func(i int, meter api.Meter) {
-		if power, err := meter.CurrentPower(); err == nil {
-			mm[i].Power = power
-			site.log.DEBUG.Printf("aux power %d: %.0fW", i+1, power)
-		} else {
-			site.log.ERROR.Printf("aux meter %d: %v", i+1, err)
-		}
-
-		wg.Done()
-	}
-
 	wg.Add(len(site.auxMeters))
 	for i, meter := range site.auxMeters {
-		go fun(i, meter)
+		go func() {
+			defer wg.Done()
+
+			if power, err := poll(site, fmt.Sprintf("aux-%d", i), meterRef(site.Meters.AuxMetersRef, i), meter.CurrentPower); err == nil {
+				mm[i].Power = power
+				site.log.DEBUG.Printf("aux power %d: %.0fW", i+1, power)
+			} else {
+				site.log.ERROR.Printf("aux meter %d: %v", i+1, err)
+			}
+		}()
 	}
 	wg.Wait()
 
@@ -557,7 +715,7 @@ func (site *Site) updateExtMeters() {
 
 	mm := make([]meterMeasurement, len(site.extMeters))
 
-	for i, meter := range site.extMeters {
+	fun := func(i int, meter api.Meter) (meterMeasurement, error) {
 		// ext power
 		power, err := backoff.RetryWithData(meter.CurrentPower, bo())
 		if err != nil {
@@ -566,19 +724,35 @@ func (site *Site) updateExtMeters() {
 
 		// ext energy
 		var energy float64
-		if m, ok := meter.(api.MeterEnergy); err == nil && ok {
+		if m, ok := api.As[api.MeterEnergy](meter); err == nil && ok {
 			energy, err = m.TotalEnergy()
 			if err != nil {
 				site.log.ERROR.Printf("ext meter %d energy: %v", i+1, err)
 			}
 		}
 
-		mm[i] = meterMeasurement{
+		return meterMeasurement{
 			Power:  power,
 			Energy: energy,
-		}
+		}, nil
 	}
 
+	var wg sync.WaitGroup
+
+	wg.Add(len(site.extMeters))
+	for i, meter := range site.extMeters {
+		go func() {
+			defer wg.Done()
+
+			res, err := poll(site, fmt.Sprintf("ext-%d", i), meterRef(site.Meters.ExtMetersRef, i), func() (meterMeasurement, error) { return fun(i, meter) })
+			if err != nil {
+				site.log.ERROR.Printf("ext meter %d: %v", i+1, err)
+			}
+			mm[i] = res
+		}()
+	}
+	wg.Wait()
+
 	// Publishing will be done in separate PR
 }
 
@@ -592,11 +766,12 @@ func (site *Site) updateBatteryMeters() error {
 
 	mm := make([]batteryMeasurement, len(site.batteryMeters))
 
-	fun := func(i int, meter api.Meter) error {
+	fun := func(i int, meter api.Meter) (batteryMeasurement, error) {
 		power, err := backoff.RetryWithData(meter.CurrentPower, bo())
+		site.updateDeviceHealth(meterRef(site.Meters.BatteryMetersRef, i), err)
 		if err != nil {
 			// power is required- return on error
-			return fmt.Errorf("battery %d power: %v", i+1, err)
+			return batteryMeasurement{}, fmt.Errorf("battery %d power: %v", i+1, err)
 		}
 
 		if len(site.batteryMeters) > 1 {
@@ -605,7 +780,7 @@ func (site *Site) updateBatteryMeters() error {
 
 		// battery energy (discharge)
 		var energy float64
-		if m, ok := meter.(api.MeterEnergy); ok {
+		if m, ok := api.As[api.MeterEnergy](meter); ok {
 			energy, err = m.TotalEnergy()
 			if err != nil {
 				site.log.ERROR.Printf("battery %d energy: %v", i+1, err)
@@ -614,11 +789,11 @@ func (site *Site) updateBatteryMeters() error {
 
 		// battery soc and capacity
 		var batSoc, capacity float64
-		if meter, ok := meter.(api.Battery); ok {
-			batSoc, err = soc.Guard(meter.Soc())
+		if bat, ok := api.As[api.Battery](meter); ok {
+			batSoc, err = soc.Guard(bat.Soc())
 
 			if err == nil {
-				if m, ok := meter.(api.BatteryCapacity); ok {
+				if m, ok := api.As[api.BatteryCapacity](meter); ok {
 					capacity = m.Capacity()
 				}
 
@@ -632,19 +807,20 @@ func (site *Site) updateBatteryMeters() error {
 
 		_, controllable := meter.(api.BatteryController)
 
-		mm[i] = batteryMeasurement{
+		return batteryMeasurement{
 			Power:        power,
 			Energy:       energy,
 			Soc:          batSoc,
 			Capacity:     capacity,
 			Controllable: controllable,
-		}
-
-		return nil
+		}, nil
 	}
 
 	for i, meter := range site.batteryMeters {
-		eg.Go(func() error { return fun(i, meter) })
+		eg.Go(func() (err error) {
+			mm[i], err = poll(site, fmt.Sprintf("battery-%d", i), meterRef(site.Meters.BatteryMetersRef, i), func() (batteryMeasurement, error) { return fun(i, meter) })
+			return err
+		})
 	}
 
 	if err := eg.Wait(); err != nil {
@@ -688,20 +864,25 @@ func (site *Site) updateBatteryMeters() error {
 	return nil
 }
 
-// updateGridMeter updates grid meter
-func (site *Site) updateGridMeter() error {
-	if site.gridMeter == nil {
-		return nil
-	}
+// gridMeasurement contains the grid meter's readings
+type gridMeasurement struct {
+	power            float64
+	powers, currents []float64 // optional
+	energy           *float64  // optional
+}
 
-	if res, err := backoff.RetryWithData(site.gridMeter.CurrentPower, bo()); err == nil {
-		site.gridPower = res
-		site.log.DEBUG.Printf("grid power: %.0fW", res)
-		site.publish(keys.GridPower, res)
-	} else {
-		return fmt.Errorf("grid power: %v", err)
+// readGridMeter reads the grid meter. Only power is required.
+func (site *Site) readGridMeter() (gridMeasurement, error) {
+	var res gridMeasurement
+
+	power, err := backoff.RetryWithData(site.gridMeter.CurrentPower, bo())
+	site.updateDeviceHealth(site.Meters.GridMeterRef, err)
+	if err != nil {
+		return res, fmt.Errorf("grid power: %v", err)
 	}
 
+	res.power = power
+
 	// grid phase currents (signed)
 	if phaseMeter, ok := site.gridMeter.(api.PhaseCurrents); ok {
 		// grid phase powers
@@ -709,32 +890,61 @@ func (site *Site) updateGridMeter() error {
 		if phaseMeter, ok := site.gridMeter.(api.PhasePowers); ok {
 			var err error // phases needed for signed currents
 			if p1, p2, p3, err = phaseMeter.Powers(); err == nil {
-				phases := []float64{p1, p2, p3}
-				site.log.DEBUG.Printf("grid powers: %.0fW", phases)
-				site.publish(keys.GridPowers, phases)
+				res.powers = []float64{p1, p2, p3}
 			} else {
 				site.log.ERROR.Printf("grid powers: %v", err)
 			}
 		}
 
 		if i1, i2, i3, err := phaseMeter.Currents(); err == nil {
-			phases := []float64{util.SignFromPower(i1, p1), util.SignFromPower(i2, p2), util.SignFromPower(i3, p3)}
-			site.log.DEBUG.Printf("grid currents: %.3gA", phases)
-			site.publish(keys.GridCurrents, phases)
+			res.currents = []float64{util.SignFromPower(i1, p1), util.SignFromPower(i2, p2), util.SignFromPower(i3, p3)}
 		} else {
 			site.log.ERROR.Printf("grid currents: %v", err)
 		}
 	}
 
 	// grid energy (import)
-	if energyMeter, ok := site.gridMeter.(api.MeterEnergy); ok {
+	if energyMeter, ok := api.As[api.MeterEnergy](site.gridMeter); ok {
 		if f, err := energyMeter.TotalEnergy(); err == nil {
-			site.publish(keys.GridEnergy, f)
+			res.energy = &f
 		} else {
 			site.log.ERROR.Printf("grid energy: %v", err)
 		}
 	}
 
+	return res, nil
+}
+
+// updateGridMeter updates grid meter
+func (site *Site) updateGridMeter() error {
+	if site.gridMeter == nil {
+		return nil
+	}
+
+	// grid power drives all control decisions, always read synchronously
+	res, err := site.readGridMeter()
+	if err != nil {
+		return err
+	}
+
+	site.gridPower = res.power
+	site.log.DEBUG.Printf("grid power: %.0fW", res.power)
+	site.publish(keys.GridPower, res.power)
+
+	if res.powers != nil {
+		site.log.DEBUG.Printf("grid powers: %.0fW", res.powers)
+		site.publish(keys.GridPowers, res.powers)
+	}
+
+	if res.currents != nil {
+		site.log.DEBUG.Printf("grid currents: %.3gA", res.currents)
+		site.publish(keys.GridCurrents, res.currents)
+	}
+
+	if res.energy != nil {
+		site.publish(keys.GridEnergy, *res.energy)
+	}
+
 	return nil
 }
 
@@ -755,7 +965,7 @@ func (site *Site) updateMeters() error {
 //   - the net power exported by the site minus a residual margin
 //     (negative values mean grid: export, battery: charging
 //   - if battery buffer can be used for charging
-func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, bool, bool, error) {
+func (site *Site) sitePower(totalChargePower, flexiblePower, bufferSoc, bufferStartSoc float64) (float64, bool, bool, error) {
 	if err := site.updateMeters(); err != nil {
 		return 0, false, false, err
 	}
@@ -782,6 +992,10 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 		site.publish(keys.PvPower, site.pvPower)
 	}
 
+	// check export limit and feed-in price before locking
+	exportLimit, exportLimited := site.exportLimitReached()
+	feedinPrice, exportUnprofitable := site.exportUnprofitable()
+
 	// honour battery priority
 	batteryPower := site.batteryPower
 	excessDCPower := site.excessDCPower
@@ -800,13 +1014,32 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 			excessDCPower = 0
 		} else {
 			// if battery is above bufferSoc allow using it for charging
-			batteryBuffered = site.bufferSoc > 0 && site.batterySoc > site.bufferSoc
-			batteryStart = site.bufferStartSoc > 0 && site.batterySoc > site.bufferStartSoc
+			batteryBuffered = bufferSoc > 0 && site.batterySoc > bufferSoc
+			batteryStart = bufferStartSoc > 0 && site.batterySoc > bufferStartSoc
 		}
 	}
 
 	sitePower := site.gridPower + batteryPower - excessDCPower + residualPower - site.auxPower - flexiblePower
 
+	// offer additional power to raise consumption if pv is likely curtailed at the export limit
+	// or if exporting costs money due to negative feed-in price
+	switch {
+	case exportLimited:
+		site.log.DEBUG.Printf("export limit reached: %.0fW", exportLimit)
+		sitePower -= exportLimitProbePower
+	case exportUnprofitable:
+		site.log.DEBUG.Printf("export unprofitable at feed-in price: %.3f", feedinPrice)
+		sitePower -= exportLimitProbePower
+	}
+
+	// compensate current increases not yet followed by the vehicles
+	for _, lp := range site.loadpoints {
+		if lag := lp.rampLagPower(); lag > 0 {
+			site.log.DEBUG.Printf("ramp lag: %.0fW (%s)", lag, lp.Title())
+			sitePower += lag
+		}
+	}
+
 	// handle priority
 	var flexStr string
 	if flexiblePower > 0 {
@@ -874,6 +1107,7 @@ func (site *Site) publishTariffs(greenShareHome float64, greenShareLoadpoints fl
 	if co2, err := site.tariffs.CurrentCo2(); err == nil {
 		site.publishDelta(keys.TariffCo2, co2)
 	}
+	site.publishSolarForecast()
 	if price := site.effectivePrice(greenShareHome); price != nil {
 		site.publish(keys.TariffPriceHome, price)
 	}
@@ -917,8 +1151,16 @@ func (site *Site) updateLoadpoints() float64 {
 func (site *Site) update(lp updater) {
 	site.log.DEBUG.Println("----")
 
+	ctx, span := tracing.Start(context.Background(), "site.update", tracing.Attr("loadpoint", lp.Title()))
+
+	defer func(start time.Time) {
+		updateDuration.Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	// update loadpoints
+	_, lpSpan := tracing.Start(ctx, "site.loadpoints")
 	totalChargePower := site.updateLoadpoints()
+	lpSpan.End(nil)
 
 	// update all circuits' power and currents
 	if site.circuit != nil {
@@ -932,7 +1174,7 @@ func (site *Site) update(lp updater) {
 	// prioritize if possible
 	var flexiblePower float64
 	if lp.GetMode() == api.ModePV {
-		flexiblePower = site.prioritizer.GetChargePowerFlexibility(lp)
+		flexiblePower = site.prioritizer.GetChargePowerFlexibility(lp) + site.consumerFlexibility(lp.EffectivePriority())
 	}
 
 	// battery mode handling
@@ -946,7 +1188,12 @@ func (site *Site) update(lp updater) {
 		site.log.WARN.Println("planner:", err)
 	}
 
-	batteryGridChargeActive := site.batteryGridChargeActive(rate)
+	co2Rates, err := site.co2Rates()
+	if err != nil {
+		site.log.WARN.Println("co2:", err)
+	}
+
+	batteryGridChargeActive := site.batteryGridChargeActive(rates, rate)
 	site.publish(keys.BatteryGridChargeActive, batteryGridChargeActive)
 
 	if batteryMode := site.requiredBatteryMode(batteryGridChargeActive, rate); batteryMode != api.BatteryUnknown {
@@ -957,7 +1204,13 @@ func (site *Site) update(lp updater) {
 		}
 	}
 
-	if sitePower, batteryBuffered, batteryStart, err := site.sitePower(totalChargePower, flexiblePower); err == nil {
+	bufferSoc, bufferStartSoc := site.batteryBufferSoc(lp)
+
+	_, metersSpan := tracing.Start(ctx, "site.meters")
+	sitePower, batteryBuffered, batteryStart, err := site.sitePower(totalChargePower, flexiblePower, bufferSoc, bufferStartSoc)
+	metersSpan.End(err)
+
+	if err == nil {
 		// ignore negative pvPower values as that means it is not an energy source but consumption
 		homePower := site.gridPower + max(0, site.pvPower) + site.batteryPower - totalChargePower
 		homePower = max(homePower, 0)
@@ -969,10 +1222,17 @@ func (site *Site) update(lp updater) {
 		greenShareHome := site.greenShare(0, homePower)
 		greenShareLoadpoints := site.greenShare(nonChargePower, nonChargePower+totalChargePower)
 
+		offered := lp.offeredPower()
+
+		_, updateSpan := tracing.Start(ctx, "loadpoint.update", tracing.Attr("loadpoint", lp.Title()))
 		lp.Update(
-			sitePower, max(0, site.batteryPower), rates, batteryBuffered, batteryStart,
+			sitePower, max(0, site.batteryPower), rates, co2Rates, batteryBuffered, batteryStart,
 			greenShareLoadpoints, site.effectivePrice(greenShareLoadpoints), site.effectiveCo2(greenShareLoadpoints),
 		)
+		updateSpan.End(nil)
+
+		// surplus claimed by the loadpoint in this cycle is not yet reflected by the grid power
+		site.updateConsumers(lp.offeredPower() - offered)
 
 		site.Health.Update()
 
@@ -986,6 +1246,8 @@ func (site *Site) update(lp updater) {
 	}
 
 	site.stats.Update(site)
+
+	span.End(err)
 }
 
 // prepare publishes initial values
@@ -995,6 +1257,8 @@ func (site *Site) prepare() {
 	}
 
 	site.publish(keys.SiteTitle, site.Title)
+	site.publish(keys.Users, site.Users)
+	site.publish(keys.DryRun, site.DryRun)
 
 	site.publish(keys.GridConfigured, site.gridMeter != nil)
 	site.publish(keys.Pv, make([]api.Meter, len(site.pvMeters)))
@@ -1003,7 +1267,20 @@ func (site *Site) prepare() {
 	site.publish(keys.BufferSoc, site.bufferSoc)
 	site.publish(keys.BufferStartSoc, site.bufferStartSoc)
 	site.publish(keys.BatteryMode, site.batteryMode)
+	if site.exportLimit != nil {
+		site.publish(keys.ExportLimit, *site.exportLimit)
+	} else {
+		site.publish(keys.ExportLimit, nil)
+	}
+	site.publish(keys.GridConsumptionLimit, site.gridConsumptionLimit)
+	site.publish(keys.GridProductionLimit, site.gridProductionLimit)
 	site.publish(keys.BatteryDischargeControl, site.batteryDischargeControl)
+	site.publish(keys.BatteryGridChargeHours, site.batteryGridChargeHours)
+	if site.batteryReserve != nil {
+		site.publish(keys.BatteryReserve, *site.batteryReserve)
+	} else {
+		site.publish(keys.BatteryReserve, nil)
+	}
 	site.publish(keys.ResidualPower, site.GetResidualPower())
 
 	site.publish(keys.Currency, site.tariffs.Currency)
@@ -1035,6 +1312,7 @@ func (site *Site) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Event)
 		}
 	}()
 
+	site.pushChan = pushChan
 	site.lpUpdateChan = make(chan *Loadpoint, 1) // 1 capacity to avoid deadlock
 
 	site.prepare()
@@ -1075,6 +1353,14 @@ func (site *Site) loopLoadpoints(next chan<- updater) {
 func (site *Site) Run(stopC chan struct{}, interval time.Duration) {
 	site.Health = NewHealth(time.Minute + interval)
 
+	// don't let slow devices delay the update
+	site.pollTimeout = interval / 2
+	site.pollMaxAge = 3 * interval
+
+	for _, lp := range site.loadpoints {
+		lp.setPollTimeout(site.pollTimeout, site.pollMaxAge)
+	}
+
 	if max := 30 * time.Second; interval < max {
 		site.log.WARN.Printf("interval <%.0fs can lead to unexpected behavior, see https://docs.evcc.io/docs/reference/configuration/interval", max.Seconds())
 	}
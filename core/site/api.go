@@ -8,6 +8,8 @@ import (
 // API is the external site API
 type API interface {
 	Healthy() bool
+	// DeviceHealth returns the health state of all polled devices
+	DeviceHealth() []DeviceStatus
 	Loadpoints() []loadpoint.API
 	Vehicles() Vehicles
 
@@ -26,6 +28,10 @@ type API interface {
 	// circuits
 	GetCircuit() api.Circuit
 	SetCircuit(api.Circuit)
+	// SetCircuitMaxPower sets the runtime max power limit of a circuit, nil restores the configured max power
+	SetCircuitMaxPower(string, *float64) error
+	// SetCircuitMaxCurrent sets the runtime max current limit of a circuit, nil restores the configured max current
+	SetCircuitMaxCurrent(string, *float64) error
 
 	//
 	// battery
@@ -42,6 +48,14 @@ type API interface {
 	GetBatteryGridChargeLimit() *float64
 	// SetBatteryGridChargeLimit sets the grid charge limit
 	SetBatteryGridChargeLimit(limit *float64)
+	// GetBatteryGridChargeHours get the number of cheapest hours for grid charging
+	GetBatteryGridChargeHours() int
+	// SetBatteryGridChargeHours sets the number of cheapest hours for grid charging, zero disables
+	SetBatteryGridChargeHours(hours int) error
+	// GetBatteryReserve returns the soc up to which the battery is charged and held
+	GetBatteryReserve() *float64
+	// SetBatteryReserve sets the soc up to which the battery is charged and held, nil removes the reserve
+	SetBatteryReserve(*float64) error
 
 	//
 	// power and energy
@@ -50,6 +64,16 @@ type API interface {
 	GetResidualPower() float64
 	SetResidualPower(float64) error
 
+	// GetExportLimit returns the grid export limit
+	GetExportLimit() *float64
+	// SetExportLimit sets the grid export limit, nil removes the limit
+	SetExportLimit(*float64) error
+
+	// GetGridLimits returns the grid operator consumption and production limits
+	GetGridLimits() (float64, float64)
+	// SetGridLimits sets the grid operator consumption and production limits, zero means unlimited
+	SetGridLimits(consumption, production float64)
+
 	//
 	// tariffs and costs
 	//
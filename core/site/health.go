@@ -0,0 +1,18 @@
+package site
+
+import "time"
+
+const (
+	DeviceOk       = "ok"       // last read succeeded
+	DeviceDegraded = "degraded" // failing or recovering, not yet reported
+	DeviceError    = "error"    // failure persisted beyond the debounce period
+)
+
+// DeviceStatus is the health state of a device
+type DeviceStatus struct {
+	Device      string    `json:"device"`
+	Status      string    `json:"status"`
+	LastSuccess time.Time `json:"lastSuccess"`
+	Failures    int       `json:"failures"` // consecutive failures
+	Error       string    `json:"error,omitempty"`
+}
@@ -20,7 +20,9 @@ var ErrBatteryNotConfigured = errors.New("battery not configured")
 const (
 	GridTariff    = "grid"
 	FeedinTariff  = "feedin"
+	Co2Tariff     = "co2"
 	PlannerTariff = "planner"
+	SolarTariff   = "solar"
 )
 
 // isConfigurable checks if the meter is configurable
@@ -41,6 +43,16 @@ func filterConfigurable(ref []string) []string {
 	return res
 }
 
+// DeviceHealth returns the health state of the site's and loadpoints' devices
+func (site *Site) DeviceHealth() []site.DeviceStatus {
+	res := site.health.devices()
+	for _, lp := range site.loadpoints {
+		res = append(res, lp.health.devices()...)
+	}
+
+	return sortDevices(res)
+}
+
 // GetTitle returns the title
 func (site *Site) GetTitle() string {
 	site.RLock()
@@ -271,6 +283,72 @@ func (site *Site) SetResidualPower(power float64) error {
 	return nil
 }
 
+// GetExportLimit returns the grid export limit
+func (site *Site) GetExportLimit() *float64 {
+	site.RLock()
+	defer site.RUnlock()
+	return site.exportLimit
+}
+
+// SetExportLimit sets the grid export limit, nil removes the limit
+func (site *Site) SetExportLimit(val *float64) error {
+	site.log.DEBUG.Println("set export limit:", printPtr("%.0f", val))
+
+	if val != nil && *val < 0 {
+		return errors.New("export limit must not be negative")
+	}
+
+	site.Lock()
+	defer site.Unlock()
+
+	if !ptrValueEqual(site.exportLimit, val) {
+		site.exportLimit = val
+
+		if val == nil {
+			settings.SetString(keys.ExportLimit, "")
+			site.publish(keys.ExportLimit, nil)
+		} else {
+			settings.SetFloat(keys.ExportLimit, *val)
+			site.publish(keys.ExportLimit, *val)
+		}
+	}
+
+	return nil
+}
+
+// GetGridLimits returns the grid operator consumption and production limits
+func (site *Site) GetGridLimits() (float64, float64) {
+	site.RLock()
+	defer site.RUnlock()
+	return site.gridConsumptionLimit, site.gridProductionLimit
+}
+
+// SetGridLimits sets the grid operator consumption and production limits, zero means unlimited
+func (site *Site) SetGridLimits(consumption, production float64) {
+	site.log.DEBUG.Printf("set grid limits: consumption %.0fW, production %.0fW", consumption, production)
+
+	site.Lock()
+
+	wasLimited := site.gridConsumptionLimit > 0 || site.gridProductionLimit > 0
+
+	if site.gridConsumptionLimit != consumption {
+		site.gridConsumptionLimit = consumption
+		site.publish(keys.GridConsumptionLimit, consumption)
+	}
+
+	if site.gridProductionLimit != production {
+		site.gridProductionLimit = production
+		site.publish(keys.GridProductionLimit, production)
+	}
+
+	site.Unlock()
+
+	// notify outside lock
+	if !wasLimited && (consumption > 0 || production > 0) {
+		site.pushEvent(evGridLimit)
+	}
+}
+
 // GetTariff returns the respective tariff if configured or nil
 func (site *Site) GetTariff(tariff string) api.Tariff {
 	site.RLock()
@@ -283,6 +361,12 @@ func (site *Site) GetTariff(tariff string) api.Tariff {
 	case FeedinTariff:
 		return site.tariffs.FeedIn
 
+	case Co2Tariff:
+		return site.tariffs.Co2
+
+	case SolarTariff:
+		return site.tariffs.Solar
+
 	case PlannerTariff:
 		switch {
 		case site.tariffs.Planner != nil:
@@ -354,3 +438,63 @@ func (site *Site) SetBatteryGridChargeLimit(val *float64) {
 		}
 	}
 }
+
+// GetBatteryReserve returns the soc up to which the battery is charged and held
+func (site *Site) GetBatteryReserve() *float64 {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batteryReserve
+}
+
+// SetBatteryReserve sets the soc up to which the battery is charged and held, nil removes the reserve
+func (site *Site) SetBatteryReserve(val *float64) error {
+	site.log.DEBUG.Println("set battery reserve:", printPtr("%.0f", val))
+
+	if val != nil && (*val <= 0 || *val > 100) {
+		return errors.New("battery reserve must be between 0 and 100")
+	}
+
+	site.Lock()
+	defer site.Unlock()
+
+	if !ptrValueEqual(site.batteryReserve, val) {
+		site.batteryReserve = val
+
+		if val == nil {
+			settings.SetString(keys.BatteryReserve, "")
+			site.publish(keys.BatteryReserve, nil)
+		} else {
+			settings.SetFloat(keys.BatteryReserve, *val)
+			site.publish(keys.BatteryReserve, *val)
+		}
+	}
+
+	return nil
+}
+
+// GetBatteryGridChargeHours returns the number of cheapest hours for grid charging
+func (site *Site) GetBatteryGridChargeHours() int {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batteryGridChargeHours
+}
+
+// SetBatteryGridChargeHours sets the number of cheapest hours for grid charging, zero disables
+func (site *Site) SetBatteryGridChargeHours(hours int) error {
+	site.log.DEBUG.Println("set grid charge hours:", hours)
+
+	if hours < 0 || hours > 24 {
+		return errors.New("grid charge hours must be between 0 and 24")
+	}
+
+	site.Lock()
+	defer site.Unlock()
+
+	if site.batteryGridChargeHours != hours {
+		site.batteryGridChargeHours = hours
+		settings.SetInt(keys.BatteryGridChargeHours, int64(hours))
+		site.publish(keys.BatteryGridChargeHours, hours)
+	}
+
+	return nil
+}
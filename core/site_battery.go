@@ -1,13 +1,19 @@
 package core
 
 import (
+	"cmp"
 	"errors"
+	"slices"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 )
 
+// batteryCycleEfficiency is the assumed round-trip efficiency of charging and discharging the battery
+const batteryCycleEfficiency = 0.8
+
 func batteryModeModified(mode api.BatteryMode) bool {
 	return mode != api.BatteryUnknown && mode != api.BatteryNormal
 }
@@ -45,6 +51,7 @@ func (site *Site) SetBatteryMode(batMode api.BatteryMode) {
 func (site *Site) requiredBatteryMode(batteryGridChargeActive bool, rate api.Rate) api.BatteryMode {
 	var res api.BatteryMode
 	batMode := site.GetBatteryMode()
+	scheduled := site.scheduledBatteryMode(time.Now())
 
 	mapper := func(s api.BatteryMode) api.BatteryMode {
 		return map[bool]api.BatteryMode{false: s, true: api.BatteryUnknown}[batMode == s]
@@ -53,6 +60,8 @@ func (site *Site) requiredBatteryMode(batteryGridChargeActive bool, rate api.Rat
 	switch {
 	case !site.batteryConfigured():
 		res = api.BatteryUnknown
+	case scheduled != api.BatteryUnknown:
+		res = mapper(scheduled)
 	case batteryGridChargeActive:
 		res = mapper(api.BatteryCharge)
 	case site.dischargeControlActive(rate):
@@ -64,8 +73,36 @@ func (site *Site) requiredBatteryMode(batteryGridChargeActive bool, rate api.Rat
 	return res
 }
 
+// scheduledBatteryMode returns the battery mode required by battery reserve or schedules, or unknown for automatic control.
+// The reserve takes precedence over schedules, earlier schedules over later ones and both over automatic control.
+func (site *Site) scheduledBatteryMode(now time.Time) api.BatteryMode {
+	if reserve := site.GetBatteryReserve(); reserve != nil {
+		if site.batterySoc < *reserve {
+			return api.BatteryCharge
+		}
+		return api.BatteryHold
+	}
+
+	for _, s := range site.batterySchedules {
+		if !s.Active(now) {
+			continue
+		}
+
+		if mode := s.Mode(site.batterySoc); mode != api.BatteryUnknown {
+			return mode
+		}
+	}
+
+	return api.BatteryUnknown
+}
+
 // applyBatteryMode applies the mode to each battery
 func (site *Site) applyBatteryMode(mode api.BatteryMode) error {
+	if site.DryRun {
+		site.log.INFO.Printf("dry run: battery mode %s", mode)
+		return nil
+	}
+
 	for _, meter := range site.batteryMeters {
 		if batCtrl, ok := meter.(api.BatteryController); ok {
 			if err := batCtrl.SetBatteryMode(mode); err != nil && !errors.Is(err, api.ErrNotAvailable) {
@@ -86,14 +123,106 @@ func (site *Site) plannerRates() (api.Rates, error) {
 	return tariff.Rates()
 }
 
-func (site *Site) smartCostActive(lp loadpoint.API, rate api.Rate) bool {
-	limit := lp.GetSmartCostLimit()
-	return limit != nil && !rate.IsEmpty() && rate.Price <= *limit
+// batteryBufferSoc returns the battery buffer thresholds for the loadpoint, falling back to site settings
+func (site *Site) batteryBufferSoc(lp loadpoint.API) (float64, float64) {
+	bufferSoc := site.GetBufferSoc()
+	if soc := lp.GetBufferSoc(); soc != nil {
+		bufferSoc = *soc
+	}
+
+	bufferStartSoc := site.GetBufferStartSoc()
+	if soc := lp.GetBufferStartSoc(); soc != nil {
+		bufferStartSoc = *soc
+	}
+
+	return bufferSoc, bufferStartSoc
+}
+
+// co2Rates returns the co2 tariff rates if configured
+func (site *Site) co2Rates() (api.Rates, error) {
+	tariff := site.GetTariff(Co2Tariff)
+	if tariff == nil {
+		return nil, nil
+	}
+
+	return tariff.Rates()
 }
 
-func (site *Site) batteryGridChargeActive(rate api.Rate) bool {
+// smartCostActive returns true if at least one smart charging limit is configured and all configured limits are met
+func (site *Site) smartCostActive(lp loadpoint.API, rate api.Rate, co2Rates api.Rates) bool {
+	costLimit := lp.GetSmartCostLimit()
+	co2Limit := lp.GetSmartCo2Limit()
+
+	if costLimit == nil && co2Limit == nil {
+		return false
+	}
+
+	if costLimit != nil && (rate.IsEmpty() || rate.Price > *costLimit) {
+		return false
+	}
+
+	return smartLimitMet(co2Rates, time.Now(), co2Limit)
+}
+
+func (site *Site) batteryGridChargeActive(rates api.Rates, rate api.Rate) bool {
+	if rate.IsEmpty() {
+		return false
+	}
+
 	limit := site.GetBatteryGridChargeLimit()
-	return limit != nil && !rate.IsEmpty() && rate.Price <= *limit
+	limitActive := limit != nil && rate.Price <= *limit
+
+	hours := site.GetBatteryGridChargeHours()
+	hoursActive := hours > 0 && cheapestHoursActive(rates, rate, time.Duration(hours)*time.Hour) && gridChargeProfitable(rates, rate)
+
+	if !limitActive && !hoursActive {
+		return false
+	}
+
+	// grid operator consumption limit prevents grid charging
+	if consumption, _ := site.GetGridLimits(); consumption > 0 {
+		site.log.DEBUG.Printf("battery grid charging blocked by consumption limit: %.0fW", consumption)
+		return false
+	}
+
+	return true
+}
+
+// cheapestHoursActive returns true if the current rate is among the cheapest upcoming rates of given total duration
+func cheapestHoursActive(rates api.Rates, rate api.Rate, duration time.Duration) bool {
+	upcoming := slices.DeleteFunc(slices.Clone(rates), func(r api.Rate) bool {
+		return !r.End.After(rate.Start)
+	})
+
+	slices.SortStableFunc(upcoming, func(a, b api.Rate) int {
+		return cmp.Compare(a.Price, b.Price)
+	})
+
+	for _, r := range upcoming {
+		if duration <= 0 {
+			break
+		}
+
+		if r.Start.Equal(rate.Start) {
+			return true
+		}
+
+		duration -= r.End.Sub(r.Start)
+	}
+
+	return false
+}
+
+// gridChargeProfitable returns true if the most expensive upcoming rate exceeds the current rate including cycling losses
+func gridChargeProfitable(rates api.Rates, rate api.Rate) bool {
+	var maxPrice float64
+	for _, r := range rates {
+		if r.Start.After(rate.Start) {
+			maxPrice = max(maxPrice, r.Price)
+		}
+	}
+
+	return maxPrice*batteryCycleEfficiency > rate.Price
 }
 
 func (site *Site) dischargeControlActive(rate api.Rate) bool {
@@ -101,8 +230,11 @@ func (site *Site) dischargeControlActive(rate api.Rate) bool {
 		return false
 	}
 
+	// potential errors have already been logged by site, ignore
+	co2Rates, _ := site.co2Rates()
+
 	for _, lp := range site.Loadpoints() {
-		smartCostActive := site.smartCostActive(lp, rate)
+		smartCostActive := site.smartCostActive(lp, rate, co2Rates)
 		if lp.GetStatus() == api.StatusC && (smartCostActive || lp.IsFastChargingActive()) {
 			return true
 		}
@@ -110,3 +242,71 @@ func (site *Site) dischargeControlActive(rate api.Rate) bool {
 
 	return false
 }
+
+// effectiveExportLimit returns the lower of the configured export limit and the grid operator production limit
+func (site *Site) effectiveExportLimit() *float64 {
+	limit := site.GetExportLimit()
+
+	if _, production := site.GetGridLimits(); production > 0 && (limit == nil || production < *limit) {
+		limit = &production
+	}
+
+	return limit
+}
+
+// exportLimitReached returns true if grid export is at the export limit
+func (site *Site) exportLimitReached() (float64, bool) {
+	limit := site.effectiveExportLimit()
+	if limit == nil {
+		return 0, false
+	}
+
+	return *limit, -site.gridPower >= *limit-exportLimitTolerance
+}
+
+// exportUnprofitable returns true if grid export is active while consuming the surplus beats exporting it.
+// This is the case if the current feed-in price is negative, or if both the current feed-in and grid price
+// are below all upcoming grid prices, i.e. the energy can only be bought more expensively later.
+func (site *Site) exportUnprofitable() (float64, bool) {
+	if site.tariffs == nil || site.gridPower >= 0 {
+		return 0, false
+	}
+
+	feedin, err := site.tariffs.CurrentFeedInPrice()
+	if err != nil {
+		return 0, false
+	}
+
+	if feedin < 0 || site.tariffs.Grid == nil {
+		return feedin, feedin < 0
+	}
+
+	rr, err := site.tariffs.Grid.Rates()
+	if err != nil {
+		return feedin, false
+	}
+
+	return feedin, cheapestGridRate(rr, time.Now(), feedin)
+}
+
+// cheapestGridRate returns true if both the current grid rate and the feed-in price are below all upcoming grid rates
+func cheapestGridRate(rr api.Rates, now time.Time, feedin float64) bool {
+	current, err := rr.Current(now)
+	if err != nil {
+		return false
+	}
+
+	var upcoming bool
+	for _, r := range rr {
+		if r.Start.Before(current.End) {
+			continue
+		}
+
+		upcoming = true
+		if r.Price <= max(current.Price, feedin) {
+			return false
+		}
+	}
+
+	return upcoming
+}
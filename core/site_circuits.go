@@ -1,16 +1,22 @@
 package core
 
 import (
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/samber/lo"
 )
 
 type circuitStruct struct {
-	Power      float64  `json:"power"`
-	Current    *float64 `json:"current,omitempty"`
-	MaxPower   float64  `json:"maxPower,omitempty"`
-	MaxCurrent float64  `json:"maxCurrent,omitempty"`
+	Power           float64  `json:"power"`
+	Current         *float64 `json:"current,omitempty"`
+	MaxPower        float64  `json:"maxPower,omitempty"`
+	MaxCurrent      float64  `json:"maxCurrent,omitempty"`
+	MaxPowerLimit   *float64 `json:"maxPowerLimit,omitempty"`
+	MaxCurrentLimit *float64 `json:"maxCurrentLimit,omitempty"`
 }
 
 // publishCircuits returns a list of circuit titles
@@ -22,9 +28,11 @@ func (site *Site) publishCircuits() {
 		instance := c.Instance()
 
 		data := circuitStruct{
-			Power:      instance.GetChargePower(),
-			MaxPower:   instance.GetMaxPower(),
-			MaxCurrent: instance.GetMaxCurrent(),
+			Power:           instance.GetChargePower(),
+			MaxPower:        instance.GetMaxPower(),
+			MaxCurrent:      instance.GetMaxCurrent(),
+			MaxPowerLimit:   instance.GetMaxPowerLimit(),
+			MaxCurrentLimit: instance.GetMaxCurrentLimit(),
 		}
 
 		if instance.GetMaxCurrent() > 0 {
@@ -36,3 +44,62 @@ func (site *Site) publishCircuits() {
 
 	site.publish(keys.Circuits, res)
 }
+
+// circuitSettingsKey returns the settings key of a circuit's value
+func circuitSettingsKey(name, key string) string {
+	return fmt.Sprintf("circuit.%s.%s", name, key)
+}
+
+// setCircuitLimit sets and persists a circuit's runtime limit, nil removes the limit
+func (site *Site) setCircuitLimit(name, key string, val *float64, set func(api.Circuit, *float64) error) error {
+	site.log.DEBUG.Printf("set circuit %s %s: %s", name, key, printPtr("%.5g", val))
+
+	dev, err := config.Circuits().ByName(name)
+	if err != nil {
+		return err
+	}
+
+	if err := set(dev.Instance(), val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		settings.SetString(circuitSettingsKey(name, key), "")
+	} else {
+		settings.SetFloat(circuitSettingsKey(name, key), *val)
+	}
+
+	site.publishCircuits()
+
+	return nil
+}
+
+// SetCircuitMaxPower sets the runtime max power limit of a circuit, nil restores the configured max power
+func (site *Site) SetCircuitMaxPower(name string, power *float64) error {
+	return site.setCircuitLimit(name, keys.CircuitMaxPower, power, api.Circuit.SetMaxPowerLimit)
+}
+
+// SetCircuitMaxCurrent sets the runtime max current limit of a circuit, nil restores the configured max current
+func (site *Site) SetCircuitMaxCurrent(name string, current *float64) error {
+	return site.setCircuitLimit(name, keys.CircuitMaxCurrent, current, api.Circuit.SetMaxCurrentLimit)
+}
+
+// restoreCircuitLimits restores the circuits' runtime limits
+func (site *Site) restoreCircuitLimits() {
+	for _, dev := range config.Circuits().Devices() {
+		name := dev.Config().Name
+		instance := dev.Instance()
+
+		if v, err := settings.Float(circuitSettingsKey(name, keys.CircuitMaxPower)); err == nil {
+			if err := instance.SetMaxPowerLimit(&v); err != nil {
+				site.log.WARN.Printf("circuit %s: %v", name, err)
+			}
+		}
+
+		if v, err := settings.Float(circuitSettingsKey(name, keys.CircuitMaxCurrent)); err == nil {
+			if err := instance.SetMaxCurrentLimit(&v); err != nil {
+				site.log.WARN.Printf("circuit %s: %v", name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/evcc-io/evcc/core/consumer"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+)
+
+type consumerStruct struct {
+	Title     string  `json:"title"`
+	Enabled   bool    `json:"enabled"`
+	Modulated bool    `json:"modulated,omitempty"`
+	Power     float64 `json:"power"`
+	Priority  int     `json:"priority"`
+}
+
+// configureConsumers creates the consumers ordered by descending priority
+func (site *Site) configureConsumers() error {
+	for i, cc := range site.Consumers {
+		c, err := consumer.NewFromConfig(util.NewLogger(fmt.Sprintf("consumer-%d", i+1)), cc)
+		if err != nil {
+			return fmt.Errorf("consumer %d: %w", i+1, err)
+		}
+		site.consumers = append(site.consumers, c)
+	}
+
+	slices.SortStableFunc(site.consumers, func(a, b *consumer.Consumer) int {
+		return cmp.Compare(b.Priority(), a.Priority())
+	})
+
+	return nil
+}
+
+// publishConsumers publishes the consumers' state
+func (site *Site) publishConsumers() {
+	res := make([]consumerStruct, 0, len(site.consumers))
+
+	for _, c := range site.consumers {
+		res = append(res, consumerStruct{
+			Title:     c.Title(),
+			Enabled:   c.Enabled(),
+			Modulated: c.Modulated(),
+			Power:     c.Power(),
+			Priority:  c.Priority(),
+		})
+	}
+
+	site.publish(keys.Consumers, res)
+}
+
+// consumerFlexibility returns the power of enabled consumers with lower priority than the loadpoint
+func (site *Site) consumerFlexibility(prio int) float64 {
+	var res float64
+
+	for _, c := range site.consumers {
+		if c.Enabled() && c.Priority() < prio {
+			res += c.Power()
+		}
+	}
+
+	return res
+}
+
+// consumerSurplus returns the surplus available to the consumer. Battery charging power is available above the
+// consumer's priority soc, power of pv loadpoints and modulated consumers is available if they have lower priority.
+func (site *Site) consumerSurplus(c *consumer.Consumer) float64 {
+	res := -site.gridPower - site.GetResidualPower()
+
+	for _, other := range site.consumers {
+		if other.Modulated() && other.Priority() < c.Priority() {
+			res += other.Power()
+		}
+	}
+
+	if len(site.batteryMeters) > 0 {
+		prioritySoc := c.PrioritySoc()
+		if prioritySoc == 0 {
+			prioritySoc = site.GetPrioritySoc()
+		}
+
+		if site.batterySoc >= prioritySoc {
+			res += max(0, -site.batteryPower)
+		}
+	}
+
+	return res + site.prioritizer.GetChargePowerFlexibilityBelow(c.Priority())
+}
+
+// modulateConsumers sets the power of modulated consumers in order of priority. It returns the power
+// allocated in this cycle including the claimed power which is not yet reflected by the measured grid power.
+func (site *Site) modulateConsumers(claimed float64) float64 {
+	allocated := claimed
+
+	for _, c := range site.consumers {
+		if !c.Modulated() {
+			continue
+		}
+
+		power := c.Power()
+		target := min(max(power+site.consumerSurplus(c)-allocated, 0), c.NominalPower())
+
+		if site.DryRun {
+			site.log.INFO.Printf("dry run: consumer %s power %.0fW", c.Title(), target)
+			continue
+		}
+
+		if err := c.SetPower(target); err != nil {
+			site.log.ERROR.Printf("consumer %s: %v", c.Title(), err)
+			continue
+		}
+
+		allocated += target - power
+	}
+
+	return allocated
+}
+
+// updateConsumers modulates consumers and switches at most one binary consumer per cycle. Lowest priority consumers
+// are switched off first when importing from grid, highest priority consumers are switched on first when surplus
+// covers their nominal power. Claimed is the power allocated to loadpoints in this cycle.
+func (site *Site) updateConsumers(claimed float64) {
+	if len(site.consumers) == 0 {
+		return
+	}
+
+	defer site.publishConsumers()
+
+	for _, c := range site.consumers {
+		if err := c.Update(); err != nil {
+			site.log.ERROR.Printf("consumer %s: %v", c.Title(), err)
+		}
+	}
+
+	allocated := site.modulateConsumers(claimed)
+
+	for _, c := range slices.Backward(site.consumers) {
+		if c.Modulated() {
+			continue
+		}
+
+		if surplus := site.consumerSurplus(c) - allocated; c.Enabled() && surplus < 0 && c.CanDisable() {
+			site.log.DEBUG.Printf("consumer %s: disable at surplus %.0fW", c.Title(), surplus)
+			if site.DryRun {
+				site.log.INFO.Printf("dry run: consumer %s disable", c.Title())
+				return
+			}
+			if err := c.SetEnabled(false); err != nil {
+				site.log.ERROR.Printf("consumer %s: %v", c.Title(), err)
+			}
+			return
+		}
+	}
+
+	for _, c := range site.consumers {
+		if c.Modulated() {
+			continue
+		}
+
+		if surplus := site.consumerSurplus(c) - allocated; !c.Enabled() && surplus >= c.NominalPower() {
+			site.log.DEBUG.Printf("consumer %s: enable at surplus %.0fW", c.Title(), surplus)
+			if site.DryRun {
+				site.log.INFO.Printf("dry run: consumer %s enable", c.Title())
+				return
+			}
+			if err := c.SetEnabled(true); err != nil {
+				site.log.ERROR.Printf("consumer %s: %v", c.Title(), err)
+			}
+			return
+		}
+	}
+}
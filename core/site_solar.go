@@ -0,0 +1,52 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/jinzhu/now"
+)
+
+// solarForecast is the published summary of the solar forecast
+type solarForecast struct {
+	Today    float64 `json:"today"`    // remaining energy today in kWh
+	Tomorrow float64 `json:"tomorrow"` // energy tomorrow in kWh
+}
+
+// solarAdjustedTariff returns a planner tariff that accounts for the solar forecast
+// at the given consumption power if a solar forecast and price tariff are configured
+func (site *Site) solarAdjustedTariff(planner api.Tariff, power float64) api.Tariff {
+	solar := site.GetTariff(SolarTariff)
+	if solar == nil || planner == nil {
+		return planner
+	}
+
+	if typ := planner.Type(); typ != api.TariffTypePriceDynamic && typ != api.TariffTypePriceForecast {
+		return planner
+	}
+
+	return tariff.NewSolarAdjusted(planner, site.GetTariff(FeedinTariff), solar, power)
+}
+
+// publishSolarForecast publishes the forecasted solar energy for today and tomorrow
+func (site *Site) publishSolarForecast() {
+	solar := site.GetTariff(SolarTariff)
+	if solar == nil {
+		return
+	}
+
+	rates, err := solar.Rates()
+	if err != nil {
+		return
+	}
+
+	ts := time.Now()
+	eod := now.With(ts).EndOfDay()
+
+	site.publishDelta(keys.SolarForecast, solarForecast{
+		Today:    tariff.SolarEnergy(rates, ts, eod),
+		Tomorrow: tariff.SolarEnergy(rates, eod, eod.AddDate(0, 0, 1)),
+	})
+}
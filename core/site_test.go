@@ -2,9 +2,22 @@ package core
 
 import (
 	"testing"
+	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/battery"
+	"github.com/evcc-io/evcc/core/consumer"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/prioritizer"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func TestGreenShare(t *testing.T) {
@@ -154,3 +167,434 @@ func TestRequiredBatteryMode(t *testing.T) {
 		assert.Equal(t, tc.res, res, "expected %s, got %s", tc.res, res)
 	}
 }
+
+func TestScheduledBatteryMode(t *testing.T) {
+	hold, err := battery.NewFromConfig(battery.Config{Mode: "hold", Soc: 50})
+	require.NoError(t, err)
+
+	s := &Site{
+		log:              util.NewLogger("foo"),
+		batteryMeters:    []api.Meter{nil},
+		batteryMode:      api.BatteryNormal,
+		batterySoc:       40,
+		batterySchedules: []*battery.Schedule{hold},
+	}
+
+	// schedule overrides automatic grid charging
+	assert.Equal(t, api.BatteryHold, s.requiredBatteryMode(true, api.Rate{}))
+
+	// schedule imposes no mode above its soc
+	s.batterySoc = 60
+	assert.Equal(t, api.BatteryCharge, s.requiredBatteryMode(true, api.Rate{}))
+
+	// reserve overrides schedules
+	require.NoError(t, s.SetBatteryReserve(lo.ToPtr(80.0)))
+	assert.Equal(t, api.BatteryCharge, s.requiredBatteryMode(false, api.Rate{}))
+
+	s.batterySoc = 80
+	assert.Equal(t, api.BatteryHold, s.requiredBatteryMode(true, api.Rate{}))
+
+	assert.Error(t, s.SetBatteryReserve(lo.ToPtr(0.0)))
+}
+
+func TestBatteryBufferSoc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	site := &Site{
+		log:            util.NewLogger("foo"),
+		bufferSoc:      50,
+		bufferStartSoc: 80,
+	}
+
+	// site defaults
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetBufferSoc().Return(nil)
+	lp.EXPECT().GetBufferStartSoc().Return(nil)
+
+	bufferSoc, bufferStartSoc := site.batteryBufferSoc(lp)
+	assert.Equal(t, 50.0, bufferSoc)
+	assert.Equal(t, 80.0, bufferStartSoc)
+
+	// loadpoint overrides
+	lp.EXPECT().GetBufferSoc().Return(lo.ToPtr(20.0))
+	lp.EXPECT().GetBufferStartSoc().Return(lo.ToPtr(0.0))
+
+	bufferSoc, bufferStartSoc = site.batteryBufferSoc(lp)
+	assert.Equal(t, 20.0, bufferSoc)
+	assert.Equal(t, 0.0, bufferStartSoc)
+}
+
+func TestBatteryGridChargeConsumptionLimit(t *testing.T) {
+	site := &Site{
+		log:                    util.NewLogger("foo"),
+		batteryGridChargeLimit: lo.ToPtr(0.2),
+	}
+
+	rate := api.Rate{Price: 0.1}
+	assert.True(t, site.batteryGridChargeActive(nil, rate))
+
+	// grid operator consumption limit
+	site.SetGridLimits(4200, 0)
+	assert.False(t, site.batteryGridChargeActive(nil, rate))
+
+	// production limit only
+	site.SetGridLimits(0, 5000)
+	assert.True(t, site.batteryGridChargeActive(nil, rate))
+}
+
+func TestGridLimitEvent(t *testing.T) {
+	pushChan := make(chan push.Event, 3)
+
+	site := &Site{
+		log:      util.NewLogger("foo"),
+		pushChan: pushChan,
+	}
+
+	site.SetGridLimits(4200, 0)
+	site.SetGridLimits(3000, 0)
+	site.SetGridLimits(0, 0)
+	site.SetGridLimits(0, 5000)
+	close(pushChan)
+
+	var events []string
+	for ev := range pushChan {
+		events = append(events, ev.Event)
+	}
+
+	assert.Equal(t, []string{evGridLimit, evGridLimit}, events, "event on activation only")
+}
+
+func TestExportLimit(t *testing.T) {
+	site := &Site{
+		log: util.NewLogger("foo"),
+	}
+
+	site.gridPower = -5000
+	_, ok := site.exportLimitReached()
+	assert.False(t, ok, "no limit")
+
+	require.NoError(t, site.SetExportLimit(lo.ToPtr(6000.0)))
+	_, ok = site.exportLimitReached()
+	assert.False(t, ok, "below limit")
+
+	site.gridPower = -5950
+	limit, ok := site.exportLimitReached()
+	assert.True(t, ok, "at limit within tolerance")
+	assert.Equal(t, 6000.0, limit)
+
+	// grid operator production limit takes precedence if lower
+	site.SetGridLimits(0, 4000)
+	site.gridPower = -4000
+	limit, ok = site.exportLimitReached()
+	assert.True(t, ok)
+	assert.Equal(t, 4000.0, limit)
+
+	assert.Error(t, site.SetExportLimit(lo.ToPtr(-1.0)))
+}
+
+func TestExportUnprofitable(t *testing.T) {
+	feedin, err := tariff.NewFixedFromConfig(map[string]interface{}{"price": -0.05})
+	require.NoError(t, err)
+
+	site := &Site{
+		log:     util.NewLogger("foo"),
+		tariffs: &tariff.Tariffs{},
+	}
+
+	site.gridPower = -1000
+	_, ok := site.exportUnprofitable()
+	assert.False(t, ok, "no feed-in tariff")
+
+	site.tariffs.FeedIn = feedin
+	price, ok := site.exportUnprofitable()
+	assert.True(t, ok, "negative feed-in")
+	assert.Equal(t, -0.05, price)
+
+	site.gridPower = 500
+	_, ok = site.exportUnprofitable()
+	assert.False(t, ok, "importing")
+}
+
+func TestCheapestGridRate(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	rates := func(prices ...float64) api.Rates {
+		var res api.Rates
+		for i, p := range prices {
+			start := now.Add(time.Duration(i) * time.Hour)
+			res = append(res, api.Rate{Start: start, End: start.Add(time.Hour), Price: p})
+		}
+		return res
+	}
+
+	for _, tc := range []struct {
+		rates  api.Rates
+		feedin float64
+		ok     bool
+	}{
+		{nil, 0.08, false},
+		{rates(0.30), 0.08, false},             // no upcoming rates
+		{rates(0.30, 0.30, 0.30), 0.08, false}, // fixed price
+		{rates(0.10, 0.20, 0.30), 0.08, true},  // cheapest now
+		{rates(0.10, 0.20, 0.05), 0.08, false}, // cheaper later
+		{rates(0.10, 0.20, 0.30), 0.25, false}, // export pays more than buying later
+		{rates(0.30, 0.20)[1:], 0.08, false},   // no current rate
+	} {
+		assert.Equal(t, tc.ok, cheapestGridRate(tc.rates, now, tc.feedin), "%v %.2f", tc.rates, tc.feedin)
+	}
+}
+
+func TestBatteryGridChargeHours(t *testing.T) {
+	clock := clock.NewMock()
+	now := clock.Now()
+
+	rate := func(hour int, price float64) api.Rate {
+		return api.Rate{
+			Start: now.Add(time.Duration(hour) * time.Hour),
+			End:   now.Add(time.Duration(hour+1) * time.Hour),
+			Price: price,
+		}
+	}
+
+	rates := api.Rates{rate(0, 0.20), rate(1, 0.10), rate(2, 0.15), rate(3, 0.40)}
+
+	site := &Site{
+		log:                    util.NewLogger("foo"),
+		batteryGridChargeHours: 2,
+	}
+
+	assert.False(t, site.batteryGridChargeActive(rates, rates[0]), "not among cheapest")
+	assert.True(t, site.batteryGridChargeActive(rates, rates[1]), "cheapest")
+	assert.True(t, site.batteryGridChargeActive(rates, rates[2]), "second cheapest")
+	assert.False(t, site.batteryGridChargeActive(rates, rates[3]), "most expensive")
+
+	// cycling losses exceed price spread
+	flat := api.Rates{rate(0, 0.30), rate(1, 0.28), rate(2, 0.32)}
+	assert.False(t, site.batteryGridChargeActive(flat, flat[1]))
+}
+
+func TestUpdateConsumers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	newConsumer := func(title string, power float64, prio int) (*consumer.Consumer, *api.MockCharger) {
+		charger := api.NewMockCharger(ctrl)
+		c, err := consumer.New(util.NewLogger("foo"), clck, title, charger, nil, power, prio, 0, time.Hour)
+		require.NoError(t, err)
+		return c, charger
+	}
+
+	low, lowCharger := newConsumer("low", 500, 0)
+	high, highCharger := newConsumer("high", 1000, 1)
+
+	site := &Site{
+		log:         util.NewLogger("foo"),
+		prioritizer: prioritizer.New(nil),
+		consumers:   []*consumer.Consumer{high, low},
+	}
+
+	// surplus covers high priority consumer only
+	site.gridPower = -1200
+	lowCharger.EXPECT().Enabled().Return(false, nil)
+	highCharger.EXPECT().Enabled().Return(false, nil)
+	highCharger.EXPECT().Enable(true).Return(nil)
+	site.updateConsumers(0)
+	assert.True(t, high.Enabled())
+	assert.False(t, low.Enabled())
+
+	// surplus claimed by loadpoints in this cycle is not available
+	site.gridPower = -600
+	lowCharger.EXPECT().Enabled().Return(false, nil)
+	highCharger.EXPECT().Enabled().Return(true, nil)
+	site.updateConsumers(200)
+	assert.False(t, low.Enabled())
+
+	// remaining surplus covers low priority consumer
+	site.gridPower = -600
+	lowCharger.EXPECT().Enabled().Return(false, nil)
+	highCharger.EXPECT().Enabled().Return(true, nil)
+	lowCharger.EXPECT().Enable(true).Return(nil)
+	site.updateConsumers(0)
+	assert.True(t, low.Enabled())
+
+	// grid import, min runtime not elapsed
+	site.gridPower = 300
+	lowCharger.EXPECT().Enabled().Return(true, nil)
+	highCharger.EXPECT().Enabled().Return(true, nil)
+	site.updateConsumers(0)
+	assert.True(t, low.Enabled())
+
+	// low priority consumer is switched off first
+	clck.Add(time.Hour)
+	lowCharger.EXPECT().Enabled().Return(true, nil)
+	highCharger.EXPECT().Enabled().Return(true, nil)
+	lowCharger.EXPECT().Enable(false).Return(nil)
+	site.updateConsumers(0)
+	assert.False(t, low.Enabled())
+	assert.True(t, high.Enabled())
+
+	// loadpoints with higher priority may use consumer power
+	assert.Equal(t, 1000.0, site.consumerFlexibility(2))
+	assert.Equal(t, 0.0, site.consumerFlexibility(1))
+}
+
+type powerSetterCharger struct {
+	*api.MockCharger
+	power float64
+}
+
+func (c *powerSetterCharger) SetPower(power float64) error {
+	c.power = power
+	return nil
+}
+
+func TestModulateConsumers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	charger := &powerSetterCharger{MockCharger: api.NewMockCharger(ctrl)}
+	heater, err := consumer.New(util.NewLogger("foo"), clock.NewMock(), "heater", charger, nil, 3000, 0, 0, 0)
+	require.NoError(t, err)
+	require.True(t, heater.Modulated())
+
+	site := &Site{
+		log:         util.NewLogger("foo"),
+		prioritizer: prioritizer.New(nil),
+		consumers:   []*consumer.Consumer{heater},
+	}
+
+	// follow surplus
+	site.gridPower = -1200
+	charger.EXPECT().Enabled().Return(false, nil)
+	site.updateConsumers(0)
+	assert.Equal(t, 1200.0, charger.power)
+	assert.True(t, heater.Enabled())
+
+	// limited to nominal power
+	site.gridPower = -5000
+	charger.EXPECT().Enabled().Return(true, nil)
+	site.updateConsumers(0)
+	assert.Equal(t, 3000.0, charger.power)
+
+	// reduce on grid import
+	site.gridPower = 3500
+	charger.EXPECT().Enabled().Return(true, nil)
+	site.updateConsumers(0)
+	assert.Equal(t, 0.0, charger.power)
+	assert.False(t, heater.Enabled())
+
+	// surplus claimed by loadpoints in this cycle is not available
+	site.gridPower = -1200
+	charger.EXPECT().Enabled().Return(false, nil)
+	site.updateConsumers(1000)
+	assert.Equal(t, 200.0, charger.power)
+}
+
+type mpptMeter struct {
+	power float64
+	mppts []float64
+}
+
+func (m *mpptMeter) CurrentPower() (float64, error) {
+	return m.power, nil
+}
+
+func (m *mpptMeter) MpptPowers() ([]float64, error) {
+	return m.mppts, nil
+}
+
+func TestPvBreakdown(t *testing.T) {
+	uiChan := make(chan util.Param, 10)
+
+	site := &Site{
+		log:    util.NewLogger("foo"),
+		uiChan: uiChan,
+		health: newDeviceHealth(clock.New(), deviceHealthDebounce),
+		Meters: MetersConfig{PVMetersRef: []string{"south", "west"}},
+		pvMeters: []api.Meter{
+			&mpptMeter{power: 3000, mppts: []float64{1000, 2000}},
+			&mpptMeter{power: 1000},
+		},
+	}
+
+	site.updatePvMeters()
+	close(uiChan)
+
+	var pv []meterMeasurement
+	for p := range uiChan {
+		if p.Key == keys.Pv {
+			pv = p.Val.([]meterMeasurement)
+		}
+	}
+
+	assert.Equal(t, 4000.0, site.pvPower)
+	assert.Equal(t, []meterMeasurement{
+		{Name: "south", Power: 3000, Mppts: []float64{1000, 2000}},
+		{Name: "west", Power: 1000},
+	}, pv)
+}
+
+type capableBattery struct {
+	api.Capabilities
+	power float64
+}
+
+func (m *capableBattery) CurrentPower() (float64, error) {
+	return m.power, nil
+}
+
+func newCapableBattery(power, soc, capacity float64) *capableBattery {
+	m := &capableBattery{power: power}
+	api.Add[api.Battery](&m.Capabilities, api.BatteryFunc(func() (float64, error) { return soc, nil }))
+	api.Add[api.BatteryCapacity](&m.Capabilities, api.BatteryCapacityFunc(func() float64 { return capacity }))
+	return m
+}
+
+func TestCapableBatteryMeters(t *testing.T) {
+	site := &Site{
+		log: util.NewLogger("foo"),
+		batteryMeters: []api.Meter{
+			newCapableBattery(1000, 20, 10),
+			newCapableBattery(-500, 80, 30),
+		},
+	}
+
+	require.NoError(t, site.updateBatteryMeters())
+
+	assert.Equal(t, 500.0, site.batteryPower)
+	// soc weighed by capacity
+	assert.Equal(t, 65.0, site.batterySoc)
+}
+
+func TestDryRunConsumers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	// consumers are read but never switched or modulated
+	switched := api.NewMockCharger(ctrl)
+	sc, err := consumer.New(util.NewLogger("foo"), clock.NewMock(), "switched", switched, nil, 500, 1, 0, 0)
+	require.NoError(t, err)
+
+	modulated := &powerSetterCharger{MockCharger: api.NewMockCharger(ctrl), power: -1}
+	mc, err := consumer.New(util.NewLogger("foo"), clock.NewMock(), "modulated", modulated, nil, 3000, 0, 0, 0)
+	require.NoError(t, err)
+
+	site := &Site{
+		log:         util.NewLogger("foo"),
+		prioritizer: prioritizer.New(nil),
+		consumers:   []*consumer.Consumer{sc, mc},
+		DryRun:      true,
+	}
+
+	// surplus would enable
+	site.gridPower = -5000
+	switched.EXPECT().Enabled().Return(false, nil)
+	modulated.EXPECT().Enabled().Return(false, nil)
+	site.updateConsumers(0)
+
+	// grid import would disable
+	site.gridPower = 5000
+	switched.EXPECT().Enabled().Return(true, nil)
+	modulated.EXPECT().Enabled().Return(true, nil)
+	site.updateConsumers(0)
+
+	assert.Equal(t, -1.0, modulated.power)
+}
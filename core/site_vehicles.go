@@ -77,6 +77,13 @@ func (site *Site) updateVehicles(op config.Operation, dev config.Device[api.Vehi
 	case config.OpAdd:
 		site.coordinator.Add(vehicle)
 
+		// update default vehicle, e.g. after background initialization
+		for _, lp := range site.loadpoints {
+			if lp.VehicleRef == dev.Config().Name {
+				lp.updateDefaultVehicle(vehicle)
+			}
+		}
+
 	case config.OpDelete:
 		site.coordinator.Delete(vehicle)
 	}
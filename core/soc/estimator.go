@@ -107,7 +107,7 @@ func (s *Estimator) RemainingChargeEnergy(targetSoc int) float64 {
 func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 	var fetchedSoc *float64
 
-	if charger, ok := s.charger.(api.Battery); ok {
+	if charger, ok := api.As[api.Battery](s.charger); ok {
 		f, err := Guard(charger.Soc())
 
 		// if the charger does or could provide Soc, we always use it instead of using the vehicle API
@@ -0,0 +1,137 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// User is a person identified by RFID cards or similar identifiers
+type User struct {
+	Name        string   `mapstructure:"name" json:"name"`                 // unique user reference
+	Title       string   `mapstructure:"title" json:"title,omitempty"`     // display name
+	Identifiers []string `mapstructure:"identifiers" json:"-"`             // rfid tags or other charger identifiers
+	Vehicle     string   `mapstructure:"vehicle" json:"vehicle,omitempty"` // default vehicle reference
+	LimitSoc    int      `mapstructure:"limitSoc" json:"limitSoc,omitempty"`
+	LimitEnergy float64  `mapstructure:"limitEnergy" json:"limitEnergy,omitempty"` // session energy limit in kWh for vehicles without soc
+	Blocked     bool     `mapstructure:"blocked" json:"blocked,omitempty"`         // refuse charging sessions for the user's identifiers
+}
+
+// Authorization is the decision taken for a charger identifier
+type Authorization string
+
+const (
+	Accepted Authorization = "accepted" // identifier is registered
+	Unknown  Authorization = "unknown"  // identifier is not registered, authorization not enforced
+	Refused  Authorization = "refused"  // identifier is blocked or not registered while authorization is enforced
+)
+
+// Authorize decides whether the identifier may start a charging session.
+// Identifiers are registered if owned by a user or if known is true, e.g. when matching a vehicle.
+func (uu Users) Authorize(id string, known, enforce bool) Authorization {
+	if u, ok := uu.ByIdentifier(id); ok {
+		if u.Blocked {
+			return Refused
+		}
+		return Accepted
+	}
+
+	switch {
+	case known:
+		return Accepted
+	case enforce:
+		return Refused
+	default:
+		return Unknown
+	}
+}
+
+// DisplayName returns the title or name of the user
+func (u User) DisplayName() string {
+	if u.Title != "" {
+		return u.Title
+	}
+	return u.Name
+}
+
+// Users is a list of users
+type Users []User
+
+// Validate checks for missing or duplicate names and identifiers
+func (uu Users) Validate() error {
+	names := make(map[string]struct{})
+	ids := make(map[string]string)
+
+	for _, u := range uu {
+		if u.Name == "" {
+			return errors.New("user: missing name")
+		}
+
+		if _, ok := names[u.Name]; ok {
+			return fmt.Errorf("user: duplicate name: %s", u.Name)
+		}
+		names[u.Name] = struct{}{}
+
+		if u.LimitSoc < 0 || u.LimitSoc > 100 {
+			return fmt.Errorf("user %s: invalid limit soc: %d", u.Name, u.LimitSoc)
+		}
+
+		if u.LimitEnergy < 0 {
+			return fmt.Errorf("user %s: invalid limit energy: %.1f", u.Name, u.LimitEnergy)
+		}
+
+		for _, id := range u.Identifiers {
+			key := strings.ToLower(id)
+			if other, ok := ids[key]; ok {
+				return fmt.Errorf("user %s: identifier %s already assigned to %s", u.Name, id, other)
+			}
+			ids[key] = u.Name
+		}
+	}
+
+	return nil
+}
+
+// ByName returns the user with the given name
+func (uu Users) ByName(name string) (User, bool) {
+	for _, u := range uu {
+		if u.Name == name {
+			return u, true
+		}
+	}
+
+	return User{}, false
+}
+
+// ByIdentifier returns the user owning the given identifier. Exact matches take precedence over placeholders.
+func (uu Users) ByIdentifier(id string) (User, bool) {
+	if id == "" {
+		return User{}, false
+	}
+
+	// find exact match
+	for _, u := range uu {
+		for _, uid := range u.Identifiers {
+			if strings.EqualFold(id, uid) {
+				return u, true
+			}
+		}
+	}
+
+	// find placeholder match
+	for _, u := range uu {
+		for _, uid := range u.Identifiers {
+			re, err := regexp.Compile("(?i)^" + strings.ReplaceAll(regexp.QuoteMeta(uid), `\*`, ".*?") + "$")
+			if err != nil {
+				continue
+			}
+
+			if re.MatchString(id) {
+				return u, true
+			}
+		}
+	}
+
+	return User{}, false
+}
@@ -0,0 +1,53 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByIdentifier(t *testing.T) {
+	users := Users{
+		{Name: "alice", Identifiers: []string{"AB12CD"}},
+		{Name: "bob", Identifiers: []string{"ab*"}},
+	}
+	require.NoError(t, users.Validate())
+
+	u, ok := users.ByIdentifier("ab12cd")
+	assert.True(t, ok)
+	assert.Equal(t, "alice", u.Name)
+
+	u, ok = users.ByIdentifier("AB99")
+	assert.True(t, ok)
+	assert.Equal(t, "bob", u.Name)
+
+	_, ok = users.ByIdentifier("xAB99")
+	assert.False(t, ok)
+
+	_, ok = users.ByIdentifier("")
+	assert.False(t, ok)
+}
+
+func TestValidate(t *testing.T) {
+	assert.Error(t, Users{{Name: ""}}.Validate())
+	assert.Error(t, Users{{Name: "a"}, {Name: "a"}}.Validate())
+	assert.Error(t, Users{{Name: "a", LimitSoc: 101}}.Validate())
+	assert.Error(t, Users{
+		{Name: "a", Identifiers: []string{"x"}},
+		{Name: "b", Identifiers: []string{"X"}},
+	}.Validate())
+}
+
+func TestAuthorize(t *testing.T) {
+	users := Users{
+		{Name: "alice", Identifiers: []string{"AB12CD"}},
+		{Name: "mallory", Identifiers: []string{"EF34"}, Blocked: true},
+	}
+
+	assert.Equal(t, Accepted, users.Authorize("ab12cd", false, true))
+	assert.Equal(t, Refused, users.Authorize("ef34", true, false))
+	assert.Equal(t, Accepted, users.Authorize("1234", true, true))
+	assert.Equal(t, Unknown, users.Authorize("1234", false, false))
+	assert.Equal(t, Refused, users.Authorize("1234", false, true))
+}
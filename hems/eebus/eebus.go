@@ -22,6 +22,7 @@ type EEBus struct {
 	uc *eebus.UseCasesCS
 
 	root api.Circuit
+	site site.API
 
 	status        status
 	statusUpdated time.Time
@@ -30,6 +31,15 @@ type EEBus struct {
 	failsafeLimit    float64
 	failsafeDuration time.Duration
 
+	productionStatus           status
+	productionStatusUpdated    time.Time
+	productionLimit            *ucapi.LoadLimit // LPP-041
+	failsafeProductionLimit    float64
+	failsafeProductionDuration time.Duration
+
+	// effective limits
+	maxConsumption, maxProduction float64
+
 	heartbeat *provider.Value[struct{}]
 }
 
@@ -38,6 +48,11 @@ type Limits struct {
 	ConsumptionLimit                    float64
 	FailsafeConsumptionActivePowerLimit float64
 	FailsafeDurationMinimum             time.Duration
+
+	ContractualProductionNominalMax    float64
+	ProductionLimit                    float64
+	FailsafeProductionActivePowerLimit float64
+	FailsafeProductionDurationMinimum  time.Duration
 }
 
 // New creates an EEBus HEMS from generic config
@@ -51,6 +66,11 @@ func New(other map[string]interface{}, site site.API) (*EEBus, error) {
 			ConsumptionLimit:                    0,
 			FailsafeConsumptionActivePowerLimit: 4200,
 			FailsafeDurationMinimum:             2 * time.Hour,
+
+			ContractualProductionNominalMax:    30000,
+			ProductionLimit:                    0,
+			FailsafeProductionActivePowerLimit: 30000,
+			FailsafeProductionDurationMinimum:  2 * time.Hour,
 		},
 	}
 
@@ -76,11 +96,11 @@ func New(other map[string]interface{}, site site.API) (*EEBus, error) {
 	}
 	site.SetCircuit(lpc)
 
-	return NewEEBus(cc.Ski, cc.Limits, lpc)
+	return NewEEBus(cc.Ski, cc.Limits, lpc, site)
 }
 
 // NewEEBus creates EEBus charger
-func NewEEBus(ski string, limits Limits, root api.Circuit) (*EEBus, error) {
+func NewEEBus(ski string, limits Limits, root api.Circuit, site site.API) (*EEBus, error) {
 	if eebus.Instance == nil {
 		return nil, errors.New("eebus not configured")
 	}
@@ -88,6 +108,7 @@ func NewEEBus(ski string, limits Limits, root api.Circuit) (*EEBus, error) {
 	c := &EEBus{
 		log:       util.NewLogger("eebus"),
 		root:      root,
+		site:      site,
 		uc:        eebus.Instance.ControllableSystem(),
 		Connector: eebus.NewConnector(),
 		heartbeat: provider.NewValue[struct{}](2 * time.Minute), // LPC-031
@@ -99,6 +120,14 @@ func NewEEBus(ski string, limits Limits, root api.Circuit) (*EEBus, error) {
 
 		failsafeLimit:    limits.FailsafeConsumptionActivePowerLimit,
 		failsafeDuration: limits.FailsafeDurationMinimum,
+
+		productionLimit: &ucapi.LoadLimit{
+			Value:        limits.ProductionLimit,
+			IsChangeable: true,
+		},
+
+		failsafeProductionLimit:    limits.FailsafeProductionActivePowerLimit,
+		failsafeProductionDuration: limits.FailsafeProductionDurationMinimum,
 	}
 
 	if err := eebus.Instance.RegisterDevice(ski, "", c); err != nil {
@@ -134,6 +163,19 @@ func NewEEBus(ski string, limits Limits, root api.Circuit) (*EEBus, error) {
 		c.log.ERROR.Println("LPC SetFailsafeDurationMinimum:", err)
 	}
 
+	if err := c.uc.LPP.SetProductionNominalMax(limits.ContractualProductionNominalMax); err != nil {
+		c.log.ERROR.Println("LPP SetProductionNominalMax:", err)
+	}
+	if err := c.uc.LPP.SetProductionLimit(*c.productionLimit); err != nil {
+		c.log.ERROR.Println("LPP SetProductionLimit:", err)
+	}
+	if err := c.uc.LPP.SetFailsafeProductionActivePowerLimit(c.failsafeProductionLimit, true); err != nil {
+		c.log.ERROR.Println("LPP SetFailsafeProductionActivePowerLimit:", err)
+	}
+	if err := c.uc.LPP.SetFailsafeDurationMinimum(c.failsafeProductionDuration, true); err != nil {
+		c.log.ERROR.Println("LPP SetFailsafeDurationMinimum:", err)
+	}
+
 	return c, nil
 }
 
@@ -147,19 +189,28 @@ func (c *EEBus) Run() {
 
 // TODO check state machine against spec
 func (c *EEBus) run() error {
-	c.mux.RLock()
-	defer c.mux.RUnlock()
+	c.mux.Lock()
+	defer c.mux.Unlock()
 
-	c.log.TRACE.Println("status:", c.status)
+	c.log.TRACE.Println("status:", c.status, "production status:", c.productionStatus)
 
 	// check heartbeat
 	_, heartbeatErr := c.heartbeat.Get()
+
+	c.runConsumption(heartbeatErr)
+	c.runProduction(heartbeatErr)
+
+	return nil
+}
+
+// runConsumption implements the LPC state machine
+func (c *EEBus) runConsumption(heartbeatErr error) {
 	if heartbeatErr != nil && c.status != StatusFailsafe {
 		// LPC-914/2
-		c.log.WARN.Println("missing heartbeat- entering failsafe mode")
+		c.log.WARN.Println("missing heartbeat- entering consumption failsafe mode")
 		c.setStatusAndLimit(StatusFailsafe, c.failsafeLimit)
 
-		return nil
+		return
 	}
 
 	// TODO
@@ -177,7 +228,7 @@ func (c *EEBus) run() error {
 
 	case StatusLimited:
 		// limit updated?
-		if !c.consumptionLimit.IsActive {
+		if c.consumptionLimit == nil || !c.consumptionLimit.IsActive {
 			c.log.WARN.Println("inactive consumption limit")
 			c.setStatusAndLimit(StatusUnlimited, 0)
 			break
@@ -200,8 +251,51 @@ func (c *EEBus) run() error {
 			c.setStatusAndLimit(StatusUnlimited, 0)
 		}
 	}
+}
 
-	return nil
+// runProduction implements the LPP state machine
+func (c *EEBus) runProduction(heartbeatErr error) {
+	if heartbeatErr != nil && c.productionStatus != StatusFailsafe {
+		// LPP-914/2
+		c.log.WARN.Println("missing heartbeat- entering production failsafe mode")
+		c.setProductionStatusAndLimit(StatusFailsafe, c.failsafeProductionLimit)
+
+		return
+	}
+
+	switch c.productionStatus {
+	case StatusUnlimited:
+		// LPP-914/1
+		if c.productionLimit != nil && c.productionLimit.IsActive {
+			c.log.WARN.Println("active production limit")
+			c.setProductionStatusAndLimit(StatusLimited, c.productionLimit.Value)
+		}
+
+	case StatusLimited:
+		// limit updated?
+		if c.productionLimit == nil || !c.productionLimit.IsActive {
+			c.log.WARN.Println("inactive production limit")
+			c.setProductionStatusAndLimit(StatusUnlimited, 0)
+			break
+		}
+
+		c.setProductionLimit(c.productionLimit.Value)
+
+		// LPP-914/1
+		if d := c.productionLimit.Duration; d > 0 && time.Since(c.productionStatusUpdated) > d {
+			c.productionLimit = nil
+
+			c.log.DEBUG.Println("production limit duration exceeded- return to normal")
+			c.setProductionStatusAndLimit(StatusUnlimited, 0)
+		}
+
+	case StatusFailsafe:
+		// LPP-914/2
+		if d := c.failsafeProductionDuration; heartbeatErr == nil && time.Since(c.productionStatusUpdated) > d {
+			c.log.DEBUG.Println("heartbeat returned and production failsafe duration exceeded- return to normal")
+			c.setProductionStatusAndLimit(StatusUnlimited, 0)
+		}
+	}
 }
 
 func (c *EEBus) setStatusAndLimit(status status, limit float64) {
@@ -213,4 +307,26 @@ func (c *EEBus) setStatusAndLimit(status status, limit float64) {
 
 func (c *EEBus) setLimit(limit float64) {
 	c.root.SetMaxPower(limit)
+
+	c.maxConsumption = limit
+	c.updateSiteLimits()
+}
+
+func (c *EEBus) setProductionStatusAndLimit(status status, limit float64) {
+	c.productionStatus = status
+	c.productionStatusUpdated = time.Now()
+
+	c.setProductionLimit(limit)
+}
+
+func (c *EEBus) setProductionLimit(limit float64) {
+	c.maxProduction = limit
+	c.updateSiteLimits()
+}
+
+// updateSiteLimits hands the effective limits to the site for battery and loadpoint control
+func (c *EEBus) updateSiteLimits() {
+	if c.site != nil {
+		c.site.SetGridLimits(c.maxConsumption, c.maxProduction)
+	}
 }
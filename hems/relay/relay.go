@@ -12,12 +12,18 @@ import (
 	"github.com/evcc-io/evcc/util"
 )
 
+// devicePower is the guaranteed minimum power per controllable device according to §14a EnWG
+const devicePower = 4200 // W
+
 type Relay struct {
 	log *util.Logger
 
 	root     api.Circuit
 	limit    func() (bool, error)
 	maxPower float64
+
+	active bool      // dimming active
+	since  time.Time // dimming start
 }
 
 // New creates an Relay HEMS from generic config
@@ -37,6 +43,11 @@ func New(ctx context.Context, other map[string]interface{}, site site.API) (*Rel
 		return nil, errors.New("hems requires load management- please configure root circuit")
 	}
 
+	// default to guaranteed power per controllable device
+	if cc.MaxPower == 0 {
+		cc.MaxPower = devicePower * float64(max(len(site.Loadpoints()), 1))
+	}
+
 	// create new root circuit for LPC
 	lpc, err := circuit.New(util.NewLogger("lpc"), "relay", 0, 0, nil, time.Minute)
 	if err != nil {
@@ -84,6 +95,18 @@ func (c *Relay) run() error {
 		return err
 	}
 
+	// log curtailment events
+	if limit != c.active {
+		if limit {
+			c.since = time.Now()
+			c.log.WARN.Printf("grid operator dimming started: consumption limited to %.0fW", c.maxPower)
+		} else {
+			c.log.WARN.Printf("grid operator dimming ended after %v", time.Since(c.since).Round(time.Second))
+		}
+
+		c.active = limit
+	}
+
 	var power float64
 	if limit {
 		power = c.maxPower
@@ -0,0 +1,32 @@
+package relay
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRelay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	root := api.NewMockCircuit(ctrl)
+
+	var limit bool
+	c, err := NewRelay(root, func() (bool, error) { return limit, nil }, 8400)
+	require.NoError(t, err)
+
+	root.EXPECT().SetMaxPower(0.0)
+	require.NoError(t, c.run())
+	require.False(t, c.active)
+
+	limit = true
+	root.EXPECT().SetMaxPower(8400.0)
+	require.NoError(t, c.run())
+	require.True(t, c.active)
+
+	limit = false
+	root.EXPECT().SetMaxPower(0.0)
+	require.NoError(t, c.run())
+	require.False(t, c.active)
+}
@@ -30,6 +30,8 @@ const (
 	sempDeviceId     = "F-%s-%.12x-00" // 6 bytes
 	sempSerialNumber = "%s-%d"
 	sempCharger      = "EVCharger"
+	sempHeatPump     = "HeatPump"
+	sempOther        = "Other"
 	basePath         = "/semp"
 	maxAge           = 1800
 )
@@ -368,24 +370,38 @@ func (s *SEMP) deviceID(id int) string {
 	return fmt.Sprintf(sempDeviceId, s.vid, ^uint64(0xffff<<48)&(binary.BigEndian.Uint64(did)+uint64(id)))
 }
 
+// deviceType returns the SEMP device type of the loadpoint
+func deviceType(lp loadpoint.API) string {
+	switch {
+	case lp.HasChargerFeature(api.Heating):
+		return sempHeatPump
+	case lp.HasChargerFeature(api.IntegratedDevice):
+		return sempOther
+	default:
+		return sempCharger
+	}
+}
+
 func (s *SEMP) deviceInfo(id int, lp loadpoint.API) DeviceInfo {
 	method := MethodEstimation
 	if lp.HasChargeMeter() {
 		method = MethodMeasurement
 	}
 
+	typ := deviceType(lp)
+
 	res := DeviceInfo{
 		Identification: Identification{
 			DeviceID:     s.deviceID(id),
 			DeviceName:   lp.Title(),
-			DeviceType:   sempCharger,
+			DeviceType:   typ,
 			DeviceSerial: s.serialNumber(id),
 			DeviceVendor: "github.com/evcc-io/evcc",
 		},
 		Capabilities: Capabilities{
 			CurrentPowerMethod:   method,
 			InterruptionsAllowed: true,
-			OptionalEnergy:       true,
+			OptionalEnergy:       typ == sempCharger,
 		},
 		Characteristics: Characteristics{
 			MinPowerConsumption: int(lp.EffectiveMinPower()),
@@ -393,6 +409,12 @@ func (s *SEMP) deviceInfo(id int, lp loadpoint.API) DeviceInfo {
 		},
 	}
 
+	// switchable devices must not be toggled faster than the enable/disable delays
+	if typ != sempCharger {
+		res.Characteristics.MinOnTime = int(lp.GetDisableDelay() / time.Second)
+		res.Characteristics.MinOffTime = int(lp.GetEnableDelay() / time.Second)
+	}
+
 	return res
 }
 
@@ -439,7 +461,58 @@ func (s *SEMP) allDeviceStatus() (res []DeviceStatus) {
 	return res
 }
 
-func (s *SEMP) planningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
+func (s *SEMP) planningRequest(id int, lp loadpoint.API) PlanningRequest {
+	if deviceType(lp) != sempCharger {
+		return s.devicePlanningRequest(id, lp)
+	}
+	return s.chargerPlanningRequest(id, lp)
+}
+
+// devicePlanningRequest creates running time based planning requests for heating and switchable devices
+func (s *SEMP) devicePlanningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
+	mode := lp.GetMode()
+	status := lp.GetStatus()
+
+	if mode == api.ModeOff || (status != api.StatusB && status != api.StatusC) {
+		return res
+	}
+
+	// plan target time limits the timeframe
+	latestEnd := 24 * 3600
+	planTime := lp.EffectivePlanTime()
+	if !planTime.IsZero() {
+		if d := int(time.Until(planTime) / time.Second); d > 0 {
+			latestEnd = d
+		}
+	}
+
+	maxRunningTime := latestEnd
+	if d := int(lp.GetRemainingDuration() / time.Second); d > 0 && d < latestEnd {
+		maxRunningTime = d
+	}
+
+	// running is optional unless forced or planned
+	var minRunningTime int
+	if mode == api.ModeNow || !planTime.IsZero() {
+		minRunningTime = maxRunningTime
+	}
+
+	maxPowerConsumption := int(lp.EffectiveMaxPower())
+
+	return PlanningRequest{
+		Timeframe: []Timeframe{{
+			DeviceID:            s.deviceID(id),
+			EarliestStart:       0,
+			LatestEnd:           latestEnd,
+			MinRunningTime:      &minRunningTime,
+			MaxRunningTime:      &maxRunningTime,
+			MaxPowerConsumption: &maxPowerConsumption,
+		}},
+	}
+}
+
+// chargerPlanningRequest creates energy based planning requests for ev chargers
+func (s *SEMP) chargerPlanningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
 	mode := lp.GetMode()
 	charging := lp.GetStatus() == api.StatusC
 	connected := charging || lp.GetStatus() == api.StatusB
@@ -0,0 +1,59 @@
+package semp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDevicePlanningRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	s := &SEMP{
+		vid: "28081973",
+		did: []byte{1, 2, 3, 4, 5, 6},
+		uid: "00000000-0000-0000-0000-000000000000",
+	}
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().HasChargerFeature(api.Heating).Return(true).AnyTimes()
+	lp.EXPECT().HasChargeMeter().Return(true).AnyTimes()
+	lp.EXPECT().Title().Return("Heat pump").AnyTimes()
+	lp.EXPECT().EffectiveMinPower().Return(1000.0).AnyTimes()
+	lp.EXPECT().EffectiveMaxPower().Return(3000.0).AnyTimes()
+	lp.EXPECT().GetEnableDelay().Return(time.Minute).AnyTimes()
+	lp.EXPECT().GetDisableDelay().Return(3 * time.Minute).AnyTimes()
+	lp.EXPECT().GetStatus().Return(api.StatusB).AnyTimes()
+	lp.EXPECT().EffectivePlanTime().Return(time.Time{}).AnyTimes()
+	lp.EXPECT().GetRemainingDuration().Return(time.Hour).AnyTimes()
+
+	info := s.deviceInfo(0, lp)
+	assert.Equal(t, sempHeatPump, info.Identification.DeviceType)
+	assert.False(t, info.Capabilities.OptionalEnergy)
+	assert.Equal(t, 180, info.Characteristics.MinOnTime)
+	assert.Equal(t, 60, info.Characteristics.MinOffTime)
+
+	// optional in pv mode
+	lp.EXPECT().GetMode().Return(api.ModePV)
+	pr := s.planningRequest(0, lp)
+	require.Len(t, pr.Timeframe, 1)
+	assert.Equal(t, 0, *pr.Timeframe[0].MinRunningTime)
+	assert.Equal(t, 3600, *pr.Timeframe[0].MaxRunningTime)
+	assert.Equal(t, 24*3600, pr.Timeframe[0].LatestEnd)
+	assert.Nil(t, pr.Timeframe[0].MaxEnergy)
+
+	// mandatory in now mode
+	lp.EXPECT().GetMode().Return(api.ModeNow)
+	pr = s.planningRequest(0, lp)
+	require.Len(t, pr.Timeframe, 1)
+	assert.Equal(t, 3600, *pr.Timeframe[0].MinRunningTime)
+
+	// no request when off
+	lp.EXPECT().GetMode().Return(api.ModeOff)
+	assert.Empty(t, s.planningRequest(0, lp).Timeframe)
+}
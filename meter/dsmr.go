@@ -2,6 +2,7 @@ package meter
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -14,10 +15,10 @@ import (
 
 	"github.com/basvdlei/gotsmart/crc16"
 	"github.com/basvdlei/gotsmart/dsmr"
-	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/supervisor"
 )
 
 // github.com/basvdlei/gotsmart package is subject to the following license:
@@ -68,13 +69,13 @@ var (
 )
 
 func init() {
-	registry.Add("dsmr", NewDsmrFromConfig)
+	registry.AddCtx("dsmr", NewDsmrFromConfig)
 }
 
 //go:generate go run ../cmd/tools/decorate.go -f decorateDsmr -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)"
 
 // NewDsmrFromConfig creates a DSMR meter from generic config
-func NewDsmrFromConfig(other map[string]interface{}) (api.Meter, error) {
+func NewDsmrFromConfig(ctx context.Context, other map[string]interface{}) (api.Meter, error) {
 	cc := struct {
 		URI     string
 		Energy  string
@@ -87,11 +88,11 @@ func NewDsmrFromConfig(other map[string]interface{}) (api.Meter, error) {
 		return nil, err
 	}
 
-	return NewDsmr(cc.URI, cc.Energy, cc.Timeout)
+	return NewDsmr(ctx, cc.URI, cc.Energy, cc.Timeout)
 }
 
 // NewDsmr creates DSMR meter
-func NewDsmr(uri, energy string, timeout time.Duration) (api.Meter, error) {
+func NewDsmr(ctx context.Context, uri, energy string, timeout time.Duration) (api.Meter, error) {
 	m := &Dsmr{
 		addr:    uri,
 		energy:  energy,
@@ -104,7 +105,7 @@ func NewDsmr(uri, energy string, timeout time.Duration) (api.Meter, error) {
 		return nil, err
 	}
 
-	go m.run(conn, done)
+	go m.run(ctx, conn, done)
 
 	// wait for initial value
 	select {
@@ -137,9 +138,16 @@ func NewDsmr(uri, energy string, timeout time.Duration) (api.Meter, error) {
 }
 
 // based on https://github.com/basvdlei/gotsmart/blob/master/gotsmart.go
-func (m *Dsmr) run(conn net.Conn, done chan struct{}) {
+func (m *Dsmr) run(ctx context.Context, conn net.Conn, done chan struct{}) {
 	log := util.NewLogger("dsmr")
-	bo := backoff.NewExponentialBackOff(backoff.WithMaxInterval(5 * time.Minute))
+	sv := supervisor.New(log, "dsmr "+m.addr, supervisor.WithBackoff(time.Second, 5*time.Minute))
+	defer sv.Close()
+
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
 
 	handle := func(op string, err error) {
 		log.ERROR.Printf("%s: %v", op, err)
@@ -153,13 +161,13 @@ func (m *Dsmr) run(conn net.Conn, done chan struct{}) {
 
 	reader := bufio.NewReader(conn)
 
-	for {
+	for ctx.Err() == nil {
 		if conn == nil {
 			var err error
 			conn, err = m.connect()
 			if err != nil {
 				handle("connect", err)
-				time.Sleep(bo.NextBackOff().Truncate(time.Second))
+				time.Sleep(sv.Failure(err).Truncate(time.Second))
 				continue
 			}
 
@@ -167,7 +175,7 @@ func (m *Dsmr) run(conn net.Conn, done chan struct{}) {
 		}
 
 		if b, err := reader.Peek(1); err == nil {
-			bo.Reset()
+			sv.Success()
 
 			if string(b) != "/" {
 				log.DEBUG.Printf("ignoring garbage character: %c\n", b)
@@ -176,7 +184,7 @@ func (m *Dsmr) run(conn net.Conn, done chan struct{}) {
 			}
 		} else {
 			handle("peek", err)
-			time.Sleep(bo.NextBackOff().Truncate(time.Second))
+			time.Sleep(sv.Failure(err).Truncate(time.Second))
 			continue
 		}
 
@@ -10,6 +10,7 @@ import (
 )
 
 type goodWeWiFi struct {
+	api.Capabilities
 	usage    string
 	inverter *util.Monitor[goodwe.Inverter]
 }
@@ -18,8 +19,6 @@ func init() {
 	registry.Add("goodwe-wifi", NewGoodWeWifiFromConfig)
 }
 
-//go:generate go run ../cmd/tools/decorate.go -f decorateGoodWeWifi -b *goodWeWiFi -r api.Meter -t "api.Battery,Soc,func() (float64, error)"
-
 // TODO deprecated remove
 
 func NewGoodWeWifiFromConfig(other map[string]interface{}) (api.Meter, error) {
@@ -54,13 +53,11 @@ func NewGoodWeWiFi(uri, usage string, timeout time.Duration) (api.Meter, error)
 		inverter: inverter,
 	}
 
-	// decorate api.BatterySoc
-	var batterySoc func() (float64, error)
 	if usage == "battery" {
-		batterySoc = res.batterySoc
+		api.Add[api.Battery](&res.Capabilities, api.BatteryFunc(res.batterySoc))
 	}
 
-	return decorateGoodWeWifi(res, batterySoc), nil
+	return res, nil
 }
 
 func (m *goodWeWiFi) CurrentPower() (float64, error) {
@@ -56,6 +56,37 @@ func BuildPhaseMeasurements(ctx context.Context, currents, voltages, powers []pr
 	return currentsG, voltagesG, powersG, nil
 }
 
+// BuildMpptMeasurements returns the per-tracker power getter for given config
+func BuildMpptMeasurements(ctx context.Context, providers []provider.Config) (func() ([]float64, error), error) {
+	if len(providers) == 0 {
+		return nil, nil
+	}
+
+	g := make([]func() (float64, error), 0, len(providers))
+	for idx, prov := range providers {
+		c, err := provider.NewFloatGetterFromConfig(ctx, prov)
+		if err != nil {
+			return nil, fmt.Errorf("mppts: [%d] %w", idx, err)
+		}
+
+		g = append(g, c)
+	}
+
+	return func() ([]float64, error) {
+		res := make([]float64, 0, len(g))
+		for _, powerG := range g {
+			p, err := powerG()
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, p)
+		}
+
+		return res, nil
+	}, nil
+}
+
 // buildPhaseProviders returns phases getter for given config
 func buildPhaseProviders(ctx context.Context, providers []provider.Config) (func() (float64, float64, float64, error), error) {
 	if len(providers) == 0 {
@@ -41,6 +41,7 @@ same hardware instance is accessed with the different usages.
 
 // LgEss implements the api.Meter interface
 type LgEss struct {
+	api.Capabilities
 	usage string     // grid, pv, battery
 	lp    *lgpcs.Com // communication with the lgpcs device
 }
@@ -49,8 +50,6 @@ func init() {
 	registry.Add("lgess", NewLgEssFromConfig)
 }
 
-//go:generate go run ../cmd/tools/decorate.go -f decorateLgEss -b *LgEss -r api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64"
-
 // NewLgEssFromConfig creates an LgEss Meter from generic config
 func NewLgEssFromConfig(other map[string]interface{}) (api.Meter, error) {
 	cc := struct {
@@ -85,19 +84,19 @@ func NewLgEss(uri, usage, registration, password string, cache time.Duration, ca
 		lp:    lp,
 	}
 
-	// decorate api.MeterEnergy
-	var totalEnergy func() (float64, error)
 	if m.usage == "grid" {
-		totalEnergy = m.totalEnergy
+		api.Add[api.MeterEnergy](&m.Capabilities, api.MeterEnergyFunc(m.totalEnergy))
 	}
 
-	// decorate api.BatterySoc
-	var batterySoc func() (float64, error)
 	if usage == "battery" {
-		batterySoc = m.batterySoc
+		api.Add[api.Battery](&m.Capabilities, api.BatteryFunc(m.batterySoc))
+	}
+
+	if capacity != nil {
+		api.Add[api.BatteryCapacity](&m.Capabilities, api.BatteryCapacityFunc(capacity))
 	}
 
-	return decorateLgEss(m, totalEnergy, batterySoc, capacity), nil
+	return m, nil
 }
 
 // CurrentPower implements the api.Meter interface
@@ -13,7 +13,7 @@ func init() {
 	registry.AddCtx(api.Custom, NewConfigurableFromConfig)
 }
 
-//go:generate go run ../cmd/tools/decorate.go -f decorateMeter -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64" -t "api.MaxACPower,MaxACPower,func() float64" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error"
+//go:generate go run ../cmd/tools/decorate.go -f decorateMeter -b *Meter -r api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64" -t "api.MaxACPower,MaxACPower,func() float64" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error"
 
 // NewConfigurableFromConfig creates api.Meter from config
 func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}) (api.Meter, error) {
@@ -23,6 +23,7 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		Currents []provider.Config // optional
 		Voltages []provider.Config // optional
 		Powers   []provider.Config // optional
+		Mppts    []provider.Config // optional
 
 		// battery
 		capacity    `mapstructure:",squash"`
@@ -52,6 +53,11 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		return nil, err
 	}
 
+	mpptsG, err := BuildMpptMeasurements(ctx, cc.Mppts)
+	if err != nil {
+		return nil, err
+	}
+
 	m, _ := NewConfigurable(powerG)
 
 	// decorate soc
@@ -83,7 +89,7 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		batModeS = cc.battery.ModeController(modeS)
 	}
 
-	res := m.Decorate(energyG, currentsG, voltagesG, powersG, socG, cc.capacity.Decorator(), cc.maxpower.Decorator(), batModeS)
+	res := m.Decorate(energyG, currentsG, voltagesG, powersG, socG, cc.capacity.Decorator(), cc.maxpower.Decorator(), batModeS, mpptsG)
 
 	return res, nil
 }
@@ -98,6 +104,7 @@ func NewConfigurable(currentPowerG func() (float64, error)) (*Meter, error) {
 
 // Meter is an api.Meter implementation with configurable getters and setters.
 type Meter struct {
+	api.Capabilities
 	currentPowerG func() (float64, error)
 }
 
@@ -111,7 +118,10 @@ func (m *Meter) Decorate(
 	batteryCapacity func() float64,
 	MaxACPower func() float64,
 	setBatteryMode func(api.BatteryMode) error,
+	mpptPowers func() ([]float64, error),
 ) api.Meter {
+	api.Add[api.MpptPowers](&m.Capabilities, api.MpptPowersFunc(mpptPowers))
+
 	return decorateMeter(m, totalEnergy, currents, voltages, powers, batterySoc, batteryCapacity, MaxACPower, setBatteryMode)
 }
 
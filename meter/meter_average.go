@@ -42,35 +42,41 @@ func NewMovingAverageFromConfig(ctx context.Context, other map[string]interface{
 
 	// decorate energy reading
 	var totalEnergy func() (float64, error)
-	if m, ok := m.(api.MeterEnergy); ok {
+	if m, ok := api.As[api.MeterEnergy](m); ok {
 		totalEnergy = m.TotalEnergy
 	}
 
 	// decorate battery reading
 	var batterySoc func() (float64, error)
-	if m, ok := m.(api.Battery); ok {
+	if m, ok := api.As[api.Battery](m); ok {
 		batterySoc = m.Soc
 	}
 
 	// decorate currents reading
 	var currents func() (float64, float64, float64, error)
-	if m, ok := m.(api.PhaseCurrents); ok {
+	if m, ok := api.As[api.PhaseCurrents](m); ok {
 		currents = m.Currents
 	}
 
 	// decorate voltages reading
 	var voltages func() (float64, float64, float64, error)
-	if m, ok := m.(api.PhaseVoltages); ok {
+	if m, ok := api.As[api.PhaseVoltages](m); ok {
 		voltages = m.Voltages
 	}
 
 	// decorate powers reading
 	var powers func() (float64, float64, float64, error)
-	if m, ok := m.(api.PhasePowers); ok {
+	if m, ok := api.As[api.PhasePowers](m); ok {
 		powers = m.Powers
 	}
 
-	return meter.Decorate(totalEnergy, currents, voltages, powers, batterySoc, cc.Meter.capacity.Decorator(), nil, nil), nil
+	// decorate mppt powers reading
+	var mpptPowers func() ([]float64, error)
+	if m, ok := api.As[api.MpptPowers](m); ok {
+		mpptPowers = m.MpptPowers
+	}
+
+	return meter.Decorate(totalEnergy, currents, voltages, powers, batterySoc, cc.Meter.capacity.Decorator(), nil, nil, mpptPowers), nil
 }
 
 type MovingAverage struct {
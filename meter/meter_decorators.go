@@ -6,14 +6,14 @@ import (
 	"github.com/evcc-io/evcc/api"
 )
 
-func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error), phaseVoltages func() (float64, float64, float64, error), phasePowers func() (float64, float64, float64, error), battery func() (float64, error), batteryCapacity func() float64, maxACPower func() float64, batteryController func(api.BatteryMode) error) api.Meter {
+func decorateMeter(base *Meter, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error), phaseVoltages func() (float64, float64, float64, error), phasePowers func() (float64, float64, float64, error), battery func() (float64, error), batteryCapacity func() float64, maxACPower func() float64, batteryController func(api.BatteryMode) error) api.Meter {
 	switch {
 	case battery == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return base
 
 	case battery == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 		}{
 			Meter: base,
@@ -24,7 +24,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhaseCurrents
 		}{
 			Meter: base,
@@ -35,7 +35,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhaseCurrents
 		}{
@@ -50,7 +50,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhaseVoltages
 		}{
 			Meter: base,
@@ -61,7 +61,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhaseVoltages
 		}{
@@ -76,7 +76,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhaseCurrents
 			api.PhaseVoltages
 		}{
@@ -91,7 +91,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhaseVoltages
@@ -110,7 +110,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhaseCurrents
 			api.PhasePowers
 		}{
@@ -125,7 +125,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhasePowers
@@ -144,7 +144,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhaseCurrents
 			api.PhasePowers
 			api.PhaseVoltages
@@ -163,7 +163,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhasePowers
@@ -186,7 +186,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 		}{
 			Meter: base,
@@ -197,7 +197,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 		}{
@@ -212,7 +212,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhaseCurrents
 		}{
@@ -227,7 +227,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -246,7 +246,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhaseVoltages
 		}{
@@ -261,7 +261,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhaseVoltages
@@ -280,7 +280,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhaseCurrents
 			api.PhaseVoltages
@@ -299,7 +299,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -322,7 +322,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhaseCurrents
 			api.PhasePowers
@@ -341,7 +341,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -364,7 +364,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhaseCurrents
 			api.PhasePowers
@@ -387,7 +387,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -414,7 +414,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 		}{
@@ -429,7 +429,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -448,7 +448,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
@@ -467,7 +467,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -490,7 +490,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseVoltages
@@ -509,7 +509,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -532,7 +532,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
@@ -555,7 +555,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -582,7 +582,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
@@ -605,7 +605,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -632,7 +632,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
@@ -659,7 +659,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -690,7 +690,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 		}{
 			Meter: base,
@@ -701,7 +701,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 			api.MeterEnergy
 		}{
@@ -716,7 +716,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 			api.PhaseCurrents
 		}{
@@ -731,7 +731,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -750,7 +750,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 			api.PhaseVoltages
 		}{
@@ -765,7 +765,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 			api.MeterEnergy
 			api.PhaseVoltages
@@ -784,7 +784,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 			api.PhaseCurrents
 			api.PhaseVoltages
@@ -803,7 +803,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -826,7 +826,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 			api.PhaseCurrents
 			api.PhasePowers
@@ -845,7 +845,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -868,7 +868,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 			api.PhaseCurrents
 			api.PhasePowers
@@ -891,7 +891,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MaxACPower
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -918,7 +918,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 		}{
@@ -933,7 +933,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 			api.MeterEnergy
@@ -952,7 +952,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 			api.PhaseCurrents
@@ -971,7 +971,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 			api.MeterEnergy
@@ -994,7 +994,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 			api.PhaseVoltages
@@ -1013,7 +1013,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 			api.MeterEnergy
@@ -1036,7 +1036,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 			api.PhaseCurrents
@@ -1059,7 +1059,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 			api.MeterEnergy
@@ -1086,7 +1086,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 			api.PhaseCurrents
@@ -1109,7 +1109,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 			api.MeterEnergy
@@ -1136,7 +1136,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 			api.PhaseCurrents
@@ -1163,7 +1163,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MaxACPower
 			api.MeterEnergy
@@ -1194,7 +1194,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1213,7 +1213,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1236,7 +1236,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1259,7 +1259,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1286,7 +1286,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1309,7 +1309,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1336,7 +1336,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1363,7 +1363,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1394,7 +1394,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1421,7 +1421,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1452,7 +1452,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1483,7 +1483,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController == nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MaxACPower
@@ -1518,7 +1518,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 		}{
@@ -1533,7 +1533,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MeterEnergy
@@ -1552,7 +1552,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.PhaseCurrents
@@ -1571,7 +1571,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MeterEnergy
@@ -1594,7 +1594,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.PhaseVoltages
@@ -1613,7 +1613,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MeterEnergy
@@ -1636,7 +1636,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.PhaseCurrents
@@ -1659,7 +1659,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MeterEnergy
@@ -1686,7 +1686,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.PhaseCurrents
@@ -1709,7 +1709,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MeterEnergy
@@ -1736,7 +1736,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.PhaseCurrents
@@ -1763,7 +1763,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MeterEnergy
@@ -1794,7 +1794,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -1813,7 +1813,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -1836,7 +1836,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -1859,7 +1859,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -1886,7 +1886,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -1909,7 +1909,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -1936,7 +1936,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -1963,7 +1963,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -1994,7 +1994,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2021,7 +2021,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2052,7 +2052,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2083,7 +2083,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2118,7 +2118,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2137,7 +2137,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2160,7 +2160,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2183,7 +2183,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2210,7 +2210,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2233,7 +2233,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2260,7 +2260,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2287,7 +2287,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2318,7 +2318,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2345,7 +2345,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2376,7 +2376,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2407,7 +2407,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryController
 			api.MaxACPower
@@ -2442,7 +2442,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2465,7 +2465,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2492,7 +2492,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2519,7 +2519,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2550,7 +2550,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2577,7 +2577,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2608,7 +2608,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2639,7 +2639,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2674,7 +2674,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2705,7 +2705,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2740,7 +2740,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
@@ -2775,7 +2775,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && batteryController != nil && maxACPower != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.BatteryController
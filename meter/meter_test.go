@@ -0,0 +1,28 @@
+package meter
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeterMpptPowers(t *testing.T) {
+	m, _ := NewConfigurable(func() (float64, error) { return 0, nil })
+
+	energy := func() (float64, error) { return 0, nil }
+	mppts := func() ([]float64, error) { return []float64{1, 2}, nil }
+
+	res := m.Decorate(energy, nil, nil, nil, nil, nil, nil, nil, mppts)
+
+	// capabilities survive decoration
+	mm, ok := api.As[api.MpptPowers](res)
+	require.True(t, ok)
+
+	p, err := mm.MpptPowers()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 2}, p)
+
+	assert.True(t, api.Has[api.MeterEnergy](res))
+}
@@ -1,6 +1,7 @@
 package meter
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/supervisor"
 	"github.com/mlnoga/rct"
 )
 
@@ -43,19 +45,18 @@ meters:
 
 // RCT implements the api.Meter interface
 type RCT struct {
-	bo    *backoff.ExponentialBackOff
+	api.Capabilities
+	sv    *supervisor.Supervisor
 	conn  *rct.Connection // connection with the RCT device
 	usage string          // grid, pv, battery
 }
 
 func init() {
-	registry.Add("rct", NewRCTFromConfig)
+	registry.AddCtx("rct", NewRCTFromConfig)
 }
 
-//go:generate go run ../cmd/tools/decorate.go -f decorateRCT -b *RCT -r api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64"
-
 // NewRCTFromConfig creates an RCT from generic config
-func NewRCTFromConfig(other map[string]interface{}) (api.Meter, error) {
+func NewRCTFromConfig(ctx context.Context, other map[string]interface{}) (api.Meter, error) {
 	cc := struct {
 		capacity   `mapstructure:",squash"`
 		Uri, Usage string
@@ -72,13 +73,13 @@ func NewRCTFromConfig(other map[string]interface{}) (api.Meter, error) {
 		return nil, errors.New("missing usage")
 	}
 
-	return NewRCT(cc.Uri, cc.Usage, cc.Cache, cc.capacity.Decorator())
+	return NewRCT(ctx, cc.Uri, cc.Usage, cc.Cache, cc.capacity.Decorator())
 }
 
 var rctMu sync.Mutex
 
-// NewRCT creates an RCT meter
-func NewRCT(uri, usage string, cache time.Duration, capacity func() float64) (api.Meter, error) {
+// NewRCT creates an RCT meter supervised until ctx is done
+func NewRCT(ctx context.Context, uri, usage string, cache time.Duration, capacity func() float64) (api.Meter, error) {
 	rctMu.Lock()
 	defer rctMu.Unlock()
 
@@ -87,29 +88,36 @@ func NewRCT(uri, usage string, cache time.Duration, capacity func() float64) (ap
 		return nil, err
 	}
 
-	bo := backoff.NewExponentialBackOff(
-		backoff.WithInitialInterval(10*time.Millisecond),
-		backoff.WithMaxElapsedTime(time.Second))
+	log := util.NewLogger("rct")
+	sv := supervisor.New(log, fmt.Sprintf("rct %s (%s)", uri, usage),
+		supervisor.WithBackoff(10*time.Millisecond, time.Second),
+		supervisor.WithRetryTimeout(time.Second))
+
+	context.AfterFunc(ctx, sv.Close)
 
 	m := &RCT{
 		usage: strings.ToLower(usage),
 		conn:  conn,
-		bo:    bo,
+		sv:    sv,
 	}
 
-	// decorate api.MeterEnergy
-	var totalEnergy func() (float64, error)
 	if usage == "grid" {
-		totalEnergy = m.totalEnergy
+		api.Add[api.MeterEnergy](&m.Capabilities, api.MeterEnergyFunc(m.totalEnergy))
+	}
+
+	if usage == "pv" {
+		api.Add[api.MpptPowers](&m.Capabilities, api.MpptPowersFunc(m.mpptPowers))
 	}
 
-	// decorate api.BatterySoc
-	var batterySoc func() (float64, error)
 	if usage == "battery" {
-		batterySoc = m.batterySoc
+		api.Add[api.Battery](&m.Capabilities, api.BatteryFunc(m.batterySoc))
+	}
+
+	if capacity != nil {
+		api.Add[api.BatteryCapacity](&m.Capabilities, api.BatteryCapacityFunc(capacity))
 	}
 
-	return decorateRCT(m, totalEnergy, batterySoc, capacity), nil
+	return m, nil
 }
 
 // CurrentPower implements the api.Meter interface
@@ -166,6 +174,16 @@ func (m *RCT) totalEnergy() (float64, error) {
 	}
 }
 
+// mpptPowers implements the api.MpptPowers interface
+func (m *RCT) mpptPowers() ([]float64, error) {
+	a, err := m.queryFloat(rct.SolarGenAPowerW)
+	if err != nil {
+		return nil, err
+	}
+	b, err := m.queryFloat(rct.SolarGenBPowerW)
+	return []float64{a, b}, err
+}
+
 // batterySoc implements the api.Battery interface
 func (m *RCT) batterySoc() (float64, error) {
 	res, err := m.queryFloat(rct.BatterySoC)
@@ -174,16 +192,14 @@ func (m *RCT) batterySoc() (float64, error) {
 
 // queryFloat adds retry logic of recoverable errors to QueryFloat32
 func (m *RCT) queryFloat(id rct.Identifier) (float64, error) {
-	m.bo.Reset()
-
-	res, err := backoff.RetryWithData(func() (float32, error) {
+	res, err := supervisor.Retry(m.sv, func() (float32, error) {
 		res, err := m.conn.QueryFloat32(id)
 		if err != nil && !errors.As(err, new(rct.RecoverableError)) {
 			err = backoff.Permanent(err)
 		}
 
 		return res, err
-	}, m.bo)
+	})
 
 	return float64(res), err
 }
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/provider"
 )
 
 type Switch struct {
 	*Connection
+	status provider.Cacheable[Gen2Switch]
 }
 
 func NewSwitch(conn *Connection) *Switch {
@@ -16,9 +20,22 @@ func NewSwitch(conn *Connection) *Switch {
 		Connection: conn,
 	}
 
+	// share a single status request between power, energy and switch state
+	res.status = provider.ResettableCached(res.gen2Status, time.Second)
+
 	return res
 }
 
+// gen2Status returns the status of the channel's switch or power meter component
+func (sh *Switch) gen2Status() (Gen2Switch, error) {
+	var res Gen2StatusResponse
+	if err := sh.Connection.execGen2Cmd("Shelly.GetStatus", false, &res); err != nil {
+		return Gen2Switch{}, err
+	}
+
+	return res.Component(sh.Connection.channel)
+}
+
 // CurrentPower implements the api.Meter interface
 func (sh *Switch) CurrentPower() (float64, error) {
 	var power float64
@@ -42,19 +59,12 @@ func (sh *Switch) CurrentPower() (float64, error) {
 		}
 
 	default:
-		var res Gen2StatusResponse
-		if err := d.execGen2Cmd("Shelly.GetStatus", false, &res); err != nil {
+		res, err := sh.status.Get()
+		if err != nil {
 			return 0, err
 		}
 
-		switch d.channel {
-		case 1:
-			power = res.Switch1.Apower + res.Pm1.Apower
-		case 2:
-			power = res.Switch2.Apower + res.Pm2.Apower
-		default:
-			power = res.Switch0.Apower + res.Pm0.Apower
-		}
+		power = res.Apower
 	}
 
 	// Assure positive power response (Gen 1 EM devices can provide negative values)
@@ -72,8 +82,7 @@ func (sh *Switch) Enabled() (bool, error) {
 		return res.Ison, err
 
 	default:
-		var res Gen2SwitchResponse
-		err := d.execGen2Cmd("Switch.GetStatus", false, &res)
+		res, err := sh.status.Get()
 		return res.Output, err
 	}
 }
@@ -93,6 +102,7 @@ func (sh *Switch) Enable(enable bool) error {
 	default:
 		var res Gen2SwitchResponse
 		err = d.execGen2Cmd("Switch.Set", enable, &res)
+		sh.status.Reset()
 	}
 
 	return err
@@ -123,19 +133,12 @@ func (sh *Switch) TotalEnergy() (float64, error) {
 		energy = gen1Energy(d.devicetype, energy)
 
 	default:
-		var res Gen2StatusResponse
-		if err := d.execGen2Cmd("Shelly.GetStatus", false, &res); err != nil {
+		res, err := sh.status.Get()
+		if err != nil {
 			return 0, err
 		}
 
-		switch d.channel {
-		case 1:
-			energy = res.Switch1.Aenergy.Total + res.Pm1.Aenergy.Total
-		case 2:
-			energy = res.Switch2.Aenergy.Total + res.Pm2.Aenergy.Total
-		default:
-			energy = res.Switch0.Aenergy.Total + res.Pm0.Aenergy.Total
-		}
+		energy = res.Aenergy.Total
 	}
 
 	return energy / 1000, nil
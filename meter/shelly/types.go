@@ -1,5 +1,11 @@
 package shelly
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
 // Shelly api homepage
 // https://shelly-api-docs.shelly.cloud/#common-http-api
 type DeviceInfo struct {
@@ -24,20 +30,30 @@ type Gen2SwitchResponse struct {
 	Output bool `json:"output"`
 }
 
+// Gen2Switch is the status of a switch or power meter component
 type Gen2Switch struct {
+	Output  bool
 	Apower  float64
 	Aenergy struct {
 		Total float64
 	}
 }
 
-type Gen2StatusResponse struct {
-	Switch0 Gen2Switch `json:"switch:0"`
-	Switch1 Gen2Switch `json:"switch:1"`
-	Switch2 Gen2Switch `json:"switch:2"`
-	Pm0     Gen2Switch `json:"pm1:0"`
-	Pm1     Gen2Switch `json:"pm2:1"`
-	Pm2     Gen2Switch `json:"pm3:2"`
+// Gen2StatusResponse is the Shelly.GetStatus response by component key, e.g. switch:0 or pm1:0
+type Gen2StatusResponse map[string]json.RawMessage
+
+// Component returns the status of the channel's switch or, for devices without relay, power meter component
+func (res Gen2StatusResponse) Component(channel int) (Gen2Switch, error) {
+	var sw Gen2Switch
+
+	for _, typ := range []string{"switch", "pm1"} {
+		if b, ok := res[fmt.Sprintf("%s:%d", typ, channel)]; ok {
+			err := json.Unmarshal(b, &sw)
+			return sw, err
+		}
+	}
+
+	return sw, errors.New("invalid channel, missing power meter")
 }
 
 type Gen2EmStatusResponse struct {
@@ -53,8 +53,14 @@ func TestUnmarshalGen2StatusResponse(t *testing.T) {
 		jsonstr := `{"ble":{},"cloud":{"connected":true},"eth":{"ip":null},"input:0":{"id":0,"state":false},"input:1":{"id":1,"state":false},"mqtt":{"connected":false},"switch:0":{"id":0, "source":"HTTP", "output":false, "apower":47.11, "voltage":232.0, "current":0.000, "pf":0.00, "aenergy":{"total":5.125,"by_minute":[0.000,0.000,0.000],"minute_ts":1675718520},"temperature":{"tC":25.3, "tF":77.5}},"sys":{"mac":"30C6F78BB4D8","restart_required":false,"time":"22:22","unixtime":1675718522,"uptime":45070,"ram_size":234204,"ram_free":137716,"fs_size":524288,"fs_free":172032,"cfg_rev":13,"kvs_rev":1,"schedule_rev":0,"webhook_rev":0,"available_updates":{"beta":{"version":"0.13.0-beta3"}}},"wifi":{"sta_ip":"192.168.178.64","status":"got ip","ssid":"***","rssi":-62},"ws":{"connected":false}}`
 		require.NoError(t, json.Unmarshal([]byte(jsonstr), &res))
 
-		assert.Equal(t, 5.125, res.Switch0.Aenergy.Total)
-		assert.Equal(t, 47.11, res.Switch0.Apower)
+		sw, err := res.Component(0)
+		require.NoError(t, err)
+		assert.Equal(t, 5.125, sw.Aenergy.Total)
+		assert.Equal(t, 47.11, sw.Apower)
+		assert.False(t, sw.Output)
+
+		_, err = res.Component(1)
+		assert.Error(t, err)
 	}
 
 	{
@@ -64,7 +70,23 @@ func TestUnmarshalGen2StatusResponse(t *testing.T) {
 		jsonstr := `{"ble":{},"cloud":{"connected":true},"mqtt":{"connected":false},"pm1:0":{"id":0, "voltage":239.9, "current":7.434, "apower":1780.1 ,"freq":50.1,"aenergy":{"total":3551.682,"by_minute":[15234.772,29611.247,29825.821],"minute_ts":1719917850},"ret_aenergy":{"total":0.000,"by_minute":[0.000,0.000,0.000],"minute_ts":1719917850}},"sys":{"mac":"84FCE638D818","restart_required":false,"time":"12:57","unixtime":1719917851,"uptime":62328,"ram_size":261744,"ram_free":151436,"fs_size":1048576,"fs_free":712704,"cfg_rev":10,"kvs_rev":1,"schedule_rev":0,"webhook_rev":0,"available_updates":{"stable":{"version":"1.3.3"}},"reset_reason":1},"wifi":{"sta_ip":"192.168.178.89","status":"got ip","ssid":"FritzBox 8 2.4","rssi":-62},"ws":{"connected":false}}`
 		require.NoError(t, json.Unmarshal([]byte(jsonstr), &res))
 
-		assert.Equal(t, 3551.682, res.Pm0.Aenergy.Total)
-		assert.Equal(t, 1780.1, res.Pm0.Apower)
+		sw, err := res.Component(0)
+		require.NoError(t, err)
+		assert.Equal(t, 3551.682, sw.Aenergy.Total)
+		assert.Equal(t, 1780.1, sw.Apower)
+	}
+
+	{
+		// Shelly 1PM Gen3 channel 0 (1), relay on
+		var res Gen2StatusResponse
+
+		jsonstr := `{"ble":{},"cloud":{"connected":false},"input:0":{"id":0,"state":false},"mqtt":{"connected":false},"switch:0":{"id":0,"source":"HTTP_in","output":true,"apower":2950.4,"voltage":231.2,"freq":50.0,"current":12.762,"pf":1.00,"aenergy":{"total":812345.250,"by_minute":[49173.333,49180.000,49166.667],"minute_ts":1735732800},"ret_aenergy":{"total":0.000,"by_minute":[0.000,0.000,0.000],"minute_ts":1735732800},"temperature":{"tC":48.2,"tF":118.8}},"sys":{"mac":"34CDB0000000","restart_required":false,"time":"12:00","unixtime":1735732801,"uptime":3601},"wifi":{"sta_ip":"192.168.1.50","status":"got ip","ssid":"***","rssi":-60},"ws":{"connected":false}}`
+		require.NoError(t, json.Unmarshal([]byte(jsonstr), &res))
+
+		sw, err := res.Component(0)
+		require.NoError(t, err)
+		assert.True(t, sw.Output)
+		assert.Equal(t, 812345.25, sw.Aenergy.Total)
+		assert.Equal(t, 2950.4, sw.Apower)
 	}
 }
@@ -45,7 +45,7 @@ func NewTasmotaFromConfig(other map[string]interface{}) (api.Meter, error) {
 
 // NewTasmota creates Tasmota meter
 func NewTasmota(uri, user, password, usage string, channels []int, cache time.Duration) (api.Meter, error) {
-	conn, err := tasmota.NewConnection(uri, user, password, channels, cache)
+	conn, err := tasmota.NewConnection(uri, user, password, channels, nil, cache)
 	if err != nil {
 		return nil, err
 	}
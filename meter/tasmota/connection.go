@@ -17,35 +17,53 @@ import (
 type Connection struct {
 	*request.Helper
 	uri, user, password string
-	channels            []int
+	channels            []int // relay channels
+	meterChannels       []int // energy meter channels
 	statusSnsG          provider.Cacheable[StatusSNSResponse]
 	statusStsG          provider.Cacheable[StatusSTSResponse]
 }
 
-// NewConnection creates a Tasmota connection
-func NewConnection(uri, user, password string, channels []int, cache time.Duration) (*Connection, error) {
-	if uri == "" {
-		return nil, errors.New("missing uri")
-	}
-
+// validateChannels checks for invalid or duplicate channels
+func validateChannels(channels []int) error {
 	used := make(map[int]bool)
 	for _, c := range channels {
 		if c < 1 || c > 8 {
-			return nil, fmt.Errorf("invalid channel: %d", c)
+			return fmt.Errorf("invalid channel: %d", c)
 		}
 		if used[c] {
-			return nil, fmt.Errorf("duplicate channel: %d", c)
+			return fmt.Errorf("duplicate channel: %d", c)
 		}
 		used[c] = true
 	}
+	return nil
+}
+
+// NewConnection creates a Tasmota connection. Meter channels default to the relay channels.
+func NewConnection(uri, user, password string, channels, meterChannels []int, cache time.Duration) (*Connection, error) {
+	if uri == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	if len(meterChannels) == 0 {
+		meterChannels = channels
+	}
+
+	if err := validateChannels(channels); err != nil {
+		return nil, err
+	}
+
+	if err := validateChannels(meterChannels); err != nil {
+		return nil, err
+	}
 
 	log := util.NewLogger("tasmota")
 	c := &Connection{
-		Helper:   request.NewHelper(log),
-		uri:      util.DefaultScheme(strings.TrimRight(uri, "/"), "http"),
-		user:     user,
-		password: password,
-		channels: channels,
+		Helper:        request.NewHelper(log),
+		uri:           util.DefaultScheme(strings.TrimRight(uri, "/"), "http"),
+		user:          user,
+		password:      password,
+		channels:      channels,
+		meterChannels: meterChannels,
 	}
 
 	c.Client.Transport = request.NewTripper(log, transport.Insecure())
@@ -164,15 +182,15 @@ func (c *Connection) Enable(enable bool) error {
 	return nil
 }
 
-// Enabled implements the api.Charger interface
+// Enabled implements the api.Charger interface. Multiple relays are enabled if all relays are on.
 func (c *Connection) Enabled() (bool, error) {
 	res, err := c.statusStsG.Get()
 	if err != nil {
 		return false, err
 	}
 
-	var enabled bool
 	for _, channel := range c.channels {
+		var enabled bool
 		switch channel {
 		case 2:
 			enabled = strings.ToUpper(res.StatusSTS.Power2) == "ON"
@@ -191,8 +209,13 @@ func (c *Connection) Enabled() (bool, error) {
 		default:
 			enabled = strings.ToUpper(res.StatusSTS.Power) == "ON" || strings.ToUpper(res.StatusSTS.Power1) == "ON"
 		}
+
+		if !enabled {
+			return false, nil
+		}
 	}
-	return enabled, nil
+
+	return len(c.channels) > 0, nil
 }
 
 // CurrentPower implements the api.Meter interface
@@ -202,7 +225,7 @@ func (c *Connection) CurrentPower() (float64, error) {
 		return 0, err
 	}
 	var res float64
-	for _, channel := range c.channels {
+	for _, channel := range c.meterChannels {
 		power, err := s.StatusSNS.Energy.Power.Channel(channel)
 		if err != nil {
 			return 0, err
@@ -212,10 +235,29 @@ func (c *Connection) CurrentPower() (float64, error) {
 	return res, nil
 }
 
-// TotalEnergy implements the api.MeterEnergy interface
+// TotalEnergy implements the api.MeterEnergy interface. It uses the persisted total counters,
+// either per meter channel if split by the device (SetOption129) or as device total.
 func (c *Connection) TotalEnergy() (float64, error) {
-	res, err := c.statusSnsG.Get()
-	return res.StatusSNS.Energy.Total, err
+	s, err := c.statusSnsG.Get()
+	if err != nil {
+		return 0, err
+	}
+
+	total := s.StatusSNS.Energy.Total
+	if len(total) <= 1 {
+		return total.Channel(1)
+	}
+
+	var res float64
+	for _, channel := range c.meterChannels {
+		energy, err := total.Channel(channel)
+		if err != nil {
+			return 0, err
+		}
+		res += energy
+	}
+
+	return res, nil
 }
 
 // Currents implements the api.PhaseCurrents interface
@@ -243,7 +285,7 @@ func (c *Connection) getPhaseValues(fun func(StatusSNSResponse) Channels) (float
 
 	var res [3]float64
 	for i := range res {
-		res[i], err = all.Channel(c.channels[i])
+		res[i], err = all.Channel(c.meterChannels[i])
 		if err != nil {
 			return 0, 0, 0, err
 		}
@@ -71,9 +71,9 @@ type StatusSNSResponse struct {
 		// Energy readings
 		Energy struct {
 			TotalStartTime string
-			Total          float64
-			Yesterday      float64
-			Today          float64
+			Total          Channels
+			Yesterday      Channels
+			Today          Channels
 			Power          Channels
 			ApparentPower  Channels
 			ReactivePower  Channels
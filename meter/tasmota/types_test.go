@@ -36,3 +36,20 @@ func TestUnmarshalStatusSNSResponse(t *testing.T) {
 		t.Error("res.StatusSNS.SML.PowerCurr != -894")
 	}
 }
+
+// Test split energy totals of multi-channel devices
+func TestUnmarshalStatusSNSTotals(t *testing.T) {
+	var res StatusSNSResponse
+
+	// SetOption129 1
+	jsonstr := `{"StatusSNS":{"Time":"2024-11-20T08:12:41","ENERGY":{"TotalStartTime":"2024-01-03T10:44:02","Total":[812.345,96.120],"Yesterday":[4.210,0.000],"Today":[1.017,0.000],"Power":[2010,0],"ApparentPower":[2014,0],"ReactivePower":[130,0],"Factor":[1.00,0.00],"Voltage":231,"Current":[8.716,0.000]}}}`
+	if err := json.Unmarshal([]byte(jsonstr), &res); err != nil {
+		t.Error(err)
+	}
+	if total, err := res.StatusSNS.Energy.Total.Channel(2); err != nil || total != 96.12 {
+		t.Error("StatusSNS.Energy.Total.Channel(2) != 96.12")
+	}
+	if power, err := res.StatusSNS.Energy.Power.Channel(1); err != nil || power != 2010 {
+		t.Error("StatusSNS.Energy.Power.Channel(1) != 2010")
+	}
+}
@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/charger/nrg/ble"
+	"github.com/evcc-io/evcc/util"
+	"github.com/muka/go-bluetooth/bluez/profile/adapter"
+	"github.com/muka/go-bluetooth/bluez/profile/device"
+)
+
+// BLE implements Bluetooth Low Energy GATT characteristic provider
+type BLE struct {
+	*getter
+	log     *util.Logger
+	mu      sync.Mutex
+	adapter *adapter.Adapter1
+	dev     *device.Device1
+	mac     string
+	uuid    string
+	codec   byteCodec
+	timeout time.Duration
+}
+
+func init() {
+	registry.AddCtx("ble", NewBLEFromConfig)
+}
+
+// NewBLEFromConfig creates BLE provider
+func NewBLEFromConfig(ctx context.Context, other map[string]interface{}) (Provider, error) {
+	cc := struct {
+		Device         string // adapter id
+		Mac            string
+		Characteristic string // characteristic uuid
+		Decode         string
+		BigEndian      bool
+		Scale          float64
+		Timeout        time.Duration
+	}{
+		Device:  "hci0",
+		Scale:   1,
+		Timeout: 10 * time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Mac == "" || cc.Characteristic == "" {
+		return nil, errors.New("missing mac or characteristic")
+	}
+
+	codec, err := newByteCodec(cc.Decode, cc.BigEndian)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := adapter.GetAdapter(cc.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &BLE{
+		log:     contextLogger(ctx, util.NewLogger("ble")),
+		adapter: a,
+		mac:     cc.Mac,
+		uuid:    cc.Characteristic,
+		codec:   codec,
+		timeout: cc.Timeout,
+	}
+
+	p.getter = defaultGetters(p, cc.Scale)
+
+	return p, nil
+}
+
+// connect finds and connects the device, waiting for services to be resolved
+func (p *BLE) connect() error {
+	if p.dev != nil {
+		return nil
+	}
+
+	dev, err := p.adapter.GetDeviceByAddress(p.mac)
+	if err == nil && dev == nil {
+		dev, err = ble.FindDevice(p.adapter, p.mac, p.timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("find device: %w", err)
+	}
+
+	if connected, err := dev.GetConnected(); err != nil || !connected {
+		if err := dev.Connect(); err != nil {
+			dev.Close()
+			return fmt.Errorf("connect: %w", err)
+		}
+	}
+
+	for start := time.Now(); ; time.Sleep(100 * time.Millisecond) {
+		if resolved, err := dev.GetServicesResolved(); err == nil && resolved {
+			break
+		}
+
+		if time.Since(start) > p.timeout {
+			dev.Close()
+			return errors.New("services not resolved")
+		}
+	}
+
+	p.dev = dev
+
+	return nil
+}
+
+func (p *BLE) close() {
+	if p.dev != nil {
+		p.dev.Close()
+		p.dev = nil
+	}
+}
+
+func (p *BLE) read() ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.connect(); err != nil {
+		return nil, err
+	}
+
+	char, err := p.dev.GetCharByUUID(p.uuid)
+	if err == nil && char == nil {
+		err = fmt.Errorf("characteristic not found: %s", p.uuid)
+	}
+
+	var b []byte
+	if err == nil {
+		b, err = char.ReadValue(map[string]interface{}{})
+	}
+
+	if err != nil {
+		p.close()
+		return nil, err
+	}
+
+	p.log.TRACE.Printf("read %s: %0x", p.uuid, b)
+
+	return b, nil
+}
+
+func (p *BLE) write(b []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.connect(); err != nil {
+		return err
+	}
+
+	p.log.TRACE.Printf("write %s: %0x", p.uuid, b)
+
+	char, err := p.dev.GetCharByUUID(p.uuid)
+	if err == nil && char == nil {
+		err = fmt.Errorf("characteristic not found: %s", p.uuid)
+	}
+
+	if err == nil {
+		err = char.WriteValue(b, map[string]interface{}{})
+	}
+
+	if err != nil {
+		p.close()
+	}
+
+	return err
+}
+
+var _ Getters = (*BLE)(nil)
+
+// StringGetter reads and decodes the characteristic value
+func (p *BLE) StringGetter() (func() (string, error), error) {
+	return func() (string, error) {
+		b, err := p.read()
+		if err != nil {
+			return "", err
+		}
+
+		return p.codec.decode(b)
+	}, nil
+}
+
+func (p *BLE) set(s string) error {
+	b, err := p.codec.encode(s)
+	if err != nil {
+		return err
+	}
+
+	return p.write(b)
+}
+
+var _ SetIntProvider = (*BLE)(nil)
+
+// IntSetter encodes and writes the characteristic value
+func (p *BLE) IntSetter(_ string) (func(int64) error, error) {
+	return func(val int64) error {
+		return p.set(strconv.FormatInt(val, 10))
+	}, nil
+}
+
+var _ SetFloatProvider = (*BLE)(nil)
+
+// FloatSetter encodes and writes the characteristic value
+func (p *BLE) FloatSetter(_ string) (func(float64) error, error) {
+	return func(val float64) error {
+		return p.set(strconv.FormatFloat(val, 'f', -1, 64))
+	}, nil
+}
+
+var _ SetBoolProvider = (*BLE)(nil)
+
+// BoolSetter encodes and writes the characteristic value
+func (p *BLE) BoolSetter(_ string) (func(bool) error, error) {
+	return func(val bool) error {
+		return p.set(strconv.FormatBool(val))
+	}, nil
+}
+
+var _ SetStringProvider = (*BLE)(nil)
+
+// StringSetter encodes and writes the characteristic value
+func (p *BLE) StringSetter(_ string) (func(string) error, error) {
+	return p.set, nil
+}
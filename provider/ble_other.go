@@ -0,0 +1,17 @@
+//go:build !linux
+
+package provider
+
+import (
+	"context"
+	"errors"
+)
+
+func init() {
+	registry.AddCtx("ble", NewBLEFromConfig)
+}
+
+// NewBLEFromConfig creates BLE provider
+func NewBLEFromConfig(ctx context.Context, other map[string]interface{}) (Provider, error) {
+	return nil, errors.New("bluetooth is only supported on linux")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"golang.org/x/sync/singleflight"
 )
 
 var (
@@ -20,6 +21,7 @@ var (
 const (
 	reset           = "reset"
 	backoffDuration = 5 * time.Second
+	errorDuration   = 5 * time.Second // max. duration for caching shared errors
 )
 
 func ResetCached() {
@@ -29,6 +31,7 @@ func ResetCached() {
 // cached wraps a getter with a cache
 type cached[T any] struct {
 	mux            sync.Mutex
+	group          singleflight.Group
 	clock          clock.Clock
 	updated        time.Time
 	retried        time.Time
@@ -66,23 +69,54 @@ func ResettableCached[T any](g func() (T, error), cache time.Duration) *cached[T
 	return c
 }
 
+// Get returns the cached value. Concurrent requests share a single update
+// without blocking the cache while the getter is running.
 func (c *cached[T]) Get() (T, error) {
-	c.mux.Lock()
-	defer c.mux.Unlock()
+	var leader, fetched bool
+
+	_, _, _ = c.group.Do("", func() (any, error) {
+		leader = true
 
-	if c.mustUpdate() {
-		c.val, c.err = c.g()
-		c.updated = c.clock.Now()
-		c.retried = c.clock.Now()
+		c.mux.Lock()
+		fetched = c.mustUpdate()
+		c.mux.Unlock()
 
-		if c.err == nil {
-			c.backoffCounter = 0
+		if fetched {
+			val, err := c.g()
+
+			c.mux.Lock()
+			c.val, c.err = val, err
+			c.updated = c.clock.Now()
+			c.retried = c.clock.Now()
+
+			if c.err == nil {
+				c.backoffCounter = 0
+			}
+			c.mux.Unlock()
 		}
-	}
+
+		return nil, nil
+	})
+
+	cacheRequests.WithLabelValues(cacheResult(leader, fetched)).Inc()
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
 
 	return c.val, c.err
 }
 
+func cacheResult(leader, fetched bool) string {
+	switch {
+	case !leader:
+		return cacheShared
+	case fetched:
+		return cacheMiss
+	default:
+		return cacheHit
+	}
+}
+
 func (c *cached[T]) Reset() {
 	c.mux.Lock()
 	c.updated = time.Time{}
@@ -106,6 +140,89 @@ func (c *cached[T]) shouldRetryWithBackoff() bool {
 	return false
 }
 
+// cacheEntry is a shared cache value
+type cacheEntry[T any] struct {
+	val     T
+	err     error
+	updated time.Time
+	cache   time.Duration // entry ttl
+}
+
+// expired returns true if the entry is older than its ttl
+func (e cacheEntry[T]) expired(now time.Time, cache time.Duration) bool {
+	if e.err != nil {
+		cache = min(cache, errorDuration)
+	}
+	return now.Sub(e.updated) >= cache
+}
+
+// sharedCache shares values between getters requesting the same key, e.g. multiple
+// providers reading the same device. Errors are cached for errorDuration at most.
+// Expired entries are evicted on update.
+type sharedCache[T any] struct {
+	mu      sync.Mutex
+	clock   clock.Clock
+	group   singleflight.Group
+	entries map[string]cacheEntry[T]
+}
+
+func newSharedCache[T any]() *sharedCache[T] {
+	return &sharedCache[T]{
+		clock:   clock.New(),
+		entries: make(map[string]cacheEntry[T]),
+	}
+}
+
+// valid returns the cached entry if it has not expired
+func (c *sharedCache[T]) valid(key string, cache time.Duration) (cacheEntry[T], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	return e, ok && !e.expired(c.clock.Now(), cache)
+}
+
+// evict removes entries older than their ttl
+func (c *sharedCache[T]) evict() {
+	now := c.clock.Now()
+
+	for key, e := range c.entries {
+		if e.expired(now, e.cache) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Get returns the cached value for key or updates it using g. Concurrent requests share a single update.
+func (c *sharedCache[T]) Get(key string, cache time.Duration, g func() (T, error)) (T, error) {
+	var leader, fetched bool
+
+	res, _, _ := c.group.Do(key, func() (any, error) {
+		leader = true
+
+		e, ok := c.valid(key, cache)
+		if !ok {
+			fetched = true
+
+			e.val, e.err = g()
+			e.updated = c.clock.Now()
+			e.cache = cache
+
+			c.mu.Lock()
+			c.evict()
+			c.entries[key] = e
+			c.mu.Unlock()
+		}
+
+		return e, nil
+	})
+
+	cacheRequests.WithLabelValues(cacheResult(leader, fetched)).Inc()
+
+	e := res.(cacheEntry[T])
+	return e.val, e.err
+}
+
 // Value is a cacheable value that can expire
 type Value[T any] struct {
 	mux     sync.RWMutex
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+)
+
+// cacheProvider wraps any plugin with a cache
+type cacheProvider struct {
+	ctx               context.Context
+	log               *util.Logger
+	get               Config
+	ttl, stale, grace time.Duration
+}
+
+func init() {
+	registry.AddCtx("cache", NewCacheFromConfig)
+}
+
+// NewCacheFromConfig creates cache provider
+func NewCacheFromConfig(ctx context.Context, other map[string]interface{}) (Provider, error) {
+	var cc struct {
+		Get   Config
+		TTL   time.Duration // values are fresh for ttl
+		Stale time.Duration // after ttl, stale values are returned while refreshing in background
+		Grace time.Duration // after ttl, last value is returned on errors
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	o := &cacheProvider{
+		ctx:   ctx,
+		log:   contextLogger(ctx, util.NewLogger("cache")),
+		get:   cc.Get,
+		ttl:   cc.TTL,
+		stale: cc.Stale,
+		grace: cc.Grace,
+	}
+
+	return o, nil
+}
+
+var _ FloatProvider = (*cacheProvider)(nil)
+
+func (o *cacheProvider) FloatGetter() (func() (float64, error), error) {
+	g, err := NewFloatGetterFromConfig(o.ctx, o.get)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRevalidatingCache(o.log, g, o.ttl, o.stale, o.grace).Get, nil
+}
+
+var _ IntProvider = (*cacheProvider)(nil)
+
+func (o *cacheProvider) IntGetter() (func() (int64, error), error) {
+	g, err := NewIntGetterFromConfig(o.ctx, o.get)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRevalidatingCache(o.log, g, o.ttl, o.stale, o.grace).Get, nil
+}
+
+var _ StringProvider = (*cacheProvider)(nil)
+
+func (o *cacheProvider) StringGetter() (func() (string, error), error) {
+	g, err := NewStringGetterFromConfig(o.ctx, o.get)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRevalidatingCache(o.log, g, o.ttl, o.stale, o.grace).Get, nil
+}
+
+var _ BoolProvider = (*cacheProvider)(nil)
+
+func (o *cacheProvider) BoolGetter() (func() (bool, error), error) {
+	g, err := NewBoolGetterFromConfig(o.ctx, o.get)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRevalidatingCache(o.log, g, o.ttl, o.stale, o.grace).Get, nil
+}
+
+// revalidatingCache implements stale-while-revalidate and error grace caching
+type revalidatingCache[T any] struct {
+	mu                sync.Mutex
+	log               *util.Logger
+	clock             clock.Clock
+	g                 func() (T, error)
+	ttl, stale, grace time.Duration
+	val               T
+	updated           time.Time
+	refreshing        bool
+}
+
+func newRevalidatingCache[T any](log *util.Logger, g func() (T, error), ttl, stale, grace time.Duration) *revalidatingCache[T] {
+	return &revalidatingCache[T]{
+		log:   log,
+		clock: clock.New(),
+		g:     g,
+		ttl:   ttl,
+		stale: stale,
+		grace: grace,
+	}
+}
+
+// refresh updates the value in background
+func (c *revalidatingCache[T]) refresh() {
+	val, err := c.g()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.refreshing = false
+
+	if err != nil {
+		c.log.DEBUG.Printf("refresh: %v", err)
+		return
+	}
+
+	c.val = val
+	c.updated = c.clock.Now()
+}
+
+func (c *revalidatingCache[T]) Get() (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	valid := !c.updated.IsZero()
+	age := c.clock.Since(c.updated)
+
+	if valid && age <= c.ttl {
+		return c.val, nil
+	}
+
+	if valid && age <= c.ttl+c.stale {
+		if !c.refreshing {
+			c.refreshing = true
+			go c.refresh()
+		}
+
+		return c.val, nil
+	}
+
+	val, err := c.g()
+	if err != nil {
+		if valid && age <= c.ttl+c.grace {
+			c.log.DEBUG.Printf("using cached value: %v", err)
+			return c.val, nil
+		}
+
+		var zero T
+		return zero, err
+	}
+
+	c.val = val
+	c.updated = c.clock.Now()
+
+	return val, nil
+}
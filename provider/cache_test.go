@@ -2,11 +2,14 @@ package provider
 
 import (
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -128,3 +131,148 @@ func TestRetryWithBackoff(t *testing.T) {
 		assert.Equal(t, tt.functionCalled, functionCalled)
 	}
 }
+
+func TestRevalidatingCache(t *testing.T) {
+	var (
+		val  int64
+		err  error
+		done = make(chan struct{}, 1)
+	)
+
+	g := func() (int64, error) {
+		val++
+		defer func() {
+			select {
+			case done <- struct{}{}:
+			default:
+			}
+		}()
+		return val, err
+	}
+
+	c := newRevalidatingCache(util.NewLogger("foo"), g, time.Minute, time.Minute, 5*time.Minute)
+	clock := clock.NewMock()
+	c.clock = clock
+
+	expect := func(exp int64, expErr error) {
+		t.Helper()
+		v, err := c.Get()
+		assert.Equal(t, exp, v)
+		assert.Equal(t, expErr, err)
+	}
+
+	// initial fetch
+	expect(1, nil)
+	<-done
+
+	// fresh
+	clock.Add(time.Minute)
+	expect(1, nil)
+
+	// stale while revalidating
+	clock.Add(time.Second)
+	expect(1, nil)
+	<-done
+	assert.Eventually(t, func() bool {
+		v, _ := c.Get()
+		return v == 2
+	}, time.Second, time.Millisecond)
+
+	// expired, synchronous update fails within grace period
+	err = errors.New("foo")
+	clock.Add(3 * time.Minute)
+	expect(2, nil)
+	<-done
+
+	// expired beyond grace period
+	clock.Add(4 * time.Minute)
+	expect(0, err)
+	<-done
+
+	// recovered
+	err = nil
+	expect(5, nil)
+}
+
+func TestCacheSingleflight(t *testing.T) {
+	var calls atomic.Int64
+	release := make(chan struct{})
+
+	c := ResettableCached(func() (int64, error) {
+		<-release
+		return calls.Add(1), nil
+	}, time.Minute)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.Get()
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), v)
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestSharedCache(t *testing.T) {
+	var calls int
+	var err error
+
+	g := func() (int, error) {
+		calls++
+		return calls, err
+	}
+
+	c := newSharedCache[int]()
+	clock := clock.NewMock()
+	c.clock = clock
+
+	expect := func(key string, exp int, expErr error) {
+		t.Helper()
+		v, err := c.Get(key, time.Minute, g)
+		assert.Equal(t, exp, v)
+		assert.Equal(t, expErr, err)
+	}
+
+	// values are shared by key
+	expect("foo", 1, nil)
+	expect("foo", 1, nil)
+	expect("bar", 2, nil)
+
+	clock.Add(time.Minute)
+	expect("foo", 3, nil)
+
+	// errors expire early
+	err = errors.New("foo")
+	clock.Add(time.Minute)
+	expect("foo", 4, err)
+	expect("foo", 4, err)
+
+	clock.Add(errorDuration)
+	expect("foo", 5, err)
+}
+
+func TestSharedCacheEvict(t *testing.T) {
+	c := newSharedCache[int]()
+	clock := clock.NewMock()
+	c.clock = clock
+
+	g := func() (int, error) { return 1, nil }
+
+	_, _ = c.Get("foo", time.Second, g)
+	_, _ = c.Get("bar", time.Hour, g)
+	assert.Len(t, c.entries, 2)
+
+	// expired entries are evicted on update
+	clock.Add(time.Minute)
+	_, _ = c.Get("baz", time.Second, g)
+	assert.Len(t, c.entries, 2)
+	assert.NotContains(t, c.entries, "foo")
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// SocketCAN frame layout and id flags
+const (
+	canFrameSize = 16
+	canEFFFlag   = 0x80000000 // extended frame format
+	canRTRFlag   = 0x40000000 // remote transmission request
+	canERRFlag   = 0x20000000 // error frame
+	canSFFMask   = 0x000007ff
+	canEFFMask   = 0x1fffffff
+)
+
+// canFrame is a classic CAN frame
+type canFrame struct {
+	id   uint32
+	data []byte
+}
+
+// decodeCanFrame decodes a raw SocketCAN frame. Error and remote frames are ignored.
+func decodeCanFrame(b []byte) (canFrame, bool) {
+	if len(b) < canFrameSize {
+		return canFrame{}, false
+	}
+
+	id := binary.NativeEndian.Uint32(b)
+	if id&(canRTRFlag|canERRFlag) != 0 {
+		return canFrame{}, false
+	}
+
+	if id&canEFFFlag != 0 {
+		id &= canEFFMask
+	} else {
+		id &= canSFFMask
+	}
+
+	n := min(int(b[4]), 8)
+
+	return canFrame{id: id, data: b[8 : 8+n]}, true
+}
+
+// canValue decodes the value at offset of the frame data
+func canValue(c byteCodec, data []byte, offset int) (string, error) {
+	if offset >= len(data) {
+		return "", fmt.Errorf("invalid offset %d for length %d", offset, len(data))
+	}
+
+	return c.decode(data[offset:])
+}
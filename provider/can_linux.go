@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"golang.org/x/sys/unix"
+)
+
+// CAN implements SocketCAN frame provider
+type CAN struct {
+	*getter
+	frame  *util.Monitor[[]byte]
+	offset int
+	codec  byteCodec
+}
+
+func init() {
+	registry.AddCtx("can", NewCANFromConfig)
+}
+
+// NewCANFromConfig creates CAN provider
+func NewCANFromConfig(ctx context.Context, other map[string]interface{}) (Provider, error) {
+	cc := struct {
+		Device    string // interface name
+		Id        uint32 // frame id
+		Offset    int    // data byte offset
+		Decode    string
+		BigEndian bool
+		Scale     float64
+		Timeout   time.Duration
+	}{
+		Device:  "can0",
+		Decode:  "uint16",
+		Scale:   1,
+		Timeout: 30 * time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Id > canEFFMask {
+		return nil, fmt.Errorf("invalid id: %x", cc.Id)
+	}
+
+	if cc.Offset < 0 || cc.Offset > 7 {
+		return nil, fmt.Errorf("invalid offset: %d", cc.Offset)
+	}
+
+	codec, err := newByteCodec(cc.Decode, cc.BigEndian)
+	if err != nil {
+		return nil, err
+	}
+
+	bus, err := canInstance(contextLogger(ctx, util.NewLogger("can")), cc.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &CAN{
+		frame:  bus.subscribe(cc.Id, cc.Timeout),
+		offset: cc.Offset,
+		codec:  codec,
+	}
+
+	p.getter = defaultGetters(p, cc.Scale)
+
+	return p, nil
+}
+
+var _ Getters = (*CAN)(nil)
+
+// StringGetter decodes the value from the most recent frame
+func (p *CAN) StringGetter() (func() (string, error), error) {
+	return func() (string, error) {
+		data, err := p.frame.Get()
+		if err != nil {
+			return "", err
+		}
+
+		return canValue(p.codec, data, p.offset)
+	}, nil
+}
+
+// canBus receives frames from a CAN interface
+type canBus struct {
+	log    *util.Logger
+	device string
+	mu     sync.Mutex
+	subs   map[uint32][]*util.Monitor[[]byte]
+}
+
+var (
+	canMu    sync.Mutex
+	canBuses = make(map[string]*canBus)
+)
+
+// canInstance returns the shared receiver for the interface
+func canInstance(log *util.Logger, device string) (*canBus, error) {
+	canMu.Lock()
+	defer canMu.Unlock()
+
+	if bus, ok := canBuses[device]; ok {
+		return bus, nil
+	}
+
+	fd, err := canOpen(device)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", device, err)
+	}
+
+	bus := &canBus{
+		log:    log,
+		device: device,
+		subs:   make(map[uint32][]*util.Monitor[[]byte]),
+	}
+
+	go bus.run(fd)
+
+	canBuses[device] = bus
+
+	return bus, nil
+}
+
+func canOpen(device string) (int, error) {
+	iface, err := net.InterfaceByName(device)
+	if err != nil {
+		return 0, err
+	}
+
+	fd, err := unix.Socket(unix.AF_CAN, unix.SOCK_RAW, unix.CAN_RAW)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := unix.Bind(fd, &unix.SockaddrCAN{Ifindex: iface.Index}); err != nil {
+		unix.Close(fd)
+		return 0, err
+	}
+
+	return fd, nil
+}
+
+// subscribe returns a monitor updated with the data of frames matching id
+func (b *canBus) subscribe(id uint32, timeout time.Duration) *util.Monitor[[]byte] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	m := util.NewMonitor[[]byte](timeout)
+	b.subs[id] = append(b.subs[id], m)
+
+	return m
+}
+
+// run receives frames and reopens the interface on errors
+func (b *canBus) run(fd int) {
+	buf := make([]byte, canFrameSize)
+
+	for {
+		n, err := unix.Read(fd, buf)
+		if err == nil && n != canFrameSize {
+			err = errors.New("invalid frame size")
+		}
+
+		if err != nil {
+			b.log.ERROR.Printf("%s: %v", b.device, err)
+			unix.Close(fd)
+
+			for {
+				time.Sleep(time.Second)
+
+				if fd, err = canOpen(b.device); err == nil {
+					break
+				}
+			}
+
+			continue
+		}
+
+		frame, ok := decodeCanFrame(buf)
+		if !ok {
+			continue
+		}
+
+		b.log.TRACE.Printf("recv %03x: % x", frame.id, frame.data)
+
+		b.mu.Lock()
+		for _, m := range b.subs[frame.id] {
+			m.Set(append([]byte(nil), frame.data...))
+		}
+		b.mu.Unlock()
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+
+package provider
+
+import (
+	"context"
+	"errors"
+)
+
+func init() {
+	registry.AddCtx("can", NewCANFromConfig)
+}
+
+// NewCANFromConfig creates CAN provider
+func NewCANFromConfig(ctx context.Context, other map[string]interface{}) (Provider, error) {
+	return nil, errors.New("socketcan is only supported on linux")
+}
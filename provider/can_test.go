@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func canRaw(id uint32, data ...byte) []byte {
+	b := make([]byte, canFrameSize)
+	binary.NativeEndian.PutUint32(b, id)
+	b[4] = byte(len(data))
+	copy(b[8:], data)
+	return b
+}
+
+func TestCanFrame(t *testing.T) {
+	// pylontech 0x355: soc, soh
+	frame, ok := decodeCanFrame(canRaw(0x355, 0x4b, 0x00, 0x63, 0x00))
+	require.True(t, ok)
+	assert.Equal(t, uint32(0x355), frame.id)
+	assert.Equal(t, []byte{0x4b, 0x00, 0x63, 0x00}, frame.data)
+
+	codec, err := newByteCodec("uint16", false)
+	require.NoError(t, err)
+
+	soc, err := canValue(codec, frame.data, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "75", soc)
+
+	soh, err := canValue(codec, frame.data, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "99", soh)
+
+	_, err = canValue(codec, frame.data, 4)
+	assert.Error(t, err)
+
+	// pylontech 0x356: voltage, current
+	frame, ok = decodeCanFrame(canRaw(0x356, 0x5a, 0x14, 0x9c, 0xff))
+	require.True(t, ok)
+
+	codec, err = newByteCodec("int16", false)
+	require.NoError(t, err)
+
+	current, err := canValue(codec, frame.data, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "-100", current)
+
+	// extended frame
+	frame, ok = decodeCanFrame(canRaw(canEFFFlag|0x18ff50e5, 1))
+	require.True(t, ok)
+	assert.Equal(t, uint32(0x18ff50e5), frame.id)
+
+	// error and remote frames
+	_, ok = decodeCanFrame(canRaw(canERRFlag | 0x1))
+	assert.False(t, ok)
+	_, ok = decodeCanFrame(canRaw(canRTRFlag | 0x355))
+	assert.False(t, ok)
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// byteCodec decodes and encodes binary values like GATT characteristics or CAN frame data
+type byteCodec struct {
+	typ   string
+	order binary.ByteOrder
+}
+
+func newByteCodec(typ string, bigEndian bool) (byteCodec, error) {
+	c := byteCodec{typ: strings.ToLower(typ), order: binary.LittleEndian}
+	if bigEndian {
+		c.order = binary.BigEndian
+	}
+
+	if c.typ == "" {
+		c.typ = "uint8"
+	}
+
+	if _, ok := c.size(); !ok && c.typ != "string" && c.typ != "hex" {
+		return c, fmt.Errorf("invalid decode: %s", typ)
+	}
+
+	return c, nil
+}
+
+// size returns the encoded size of numeric types
+func (c byteCodec) size() (int, bool) {
+	switch c.typ {
+	case "bool", "int8", "uint8":
+		return 1, true
+	case "int16", "uint16":
+		return 2, true
+	case "int32", "uint32", "float32":
+		return 4, true
+	case "int64", "uint64", "float64":
+		return 8, true
+	}
+	return 0, false
+}
+
+// decode converts the characteristic value to its string representation
+func (c byteCodec) decode(b []byte) (string, error) {
+	switch c.typ {
+	case "string":
+		return string(bytes.TrimRight(b, "\x00")), nil
+	case "hex":
+		return hex.EncodeToString(b), nil
+	}
+
+	if n, _ := c.size(); len(b) < n {
+		return "", fmt.Errorf("invalid length %d for %s", len(b), c.typ)
+	}
+
+	switch c.typ {
+	case "bool":
+		return strconv.FormatBool(b[0] != 0), nil
+	case "int8":
+		return strconv.FormatInt(int64(int8(b[0])), 10), nil
+	case "uint8":
+		return strconv.FormatUint(uint64(b[0]), 10), nil
+	case "int16":
+		return strconv.FormatInt(int64(int16(c.order.Uint16(b))), 10), nil
+	case "uint16":
+		return strconv.FormatUint(uint64(c.order.Uint16(b)), 10), nil
+	case "int32":
+		return strconv.FormatInt(int64(int32(c.order.Uint32(b))), 10), nil
+	case "uint32":
+		return strconv.FormatUint(uint64(c.order.Uint32(b)), 10), nil
+	case "int64":
+		return strconv.FormatInt(int64(c.order.Uint64(b)), 10), nil
+	case "uint64":
+		return strconv.FormatUint(c.order.Uint64(b), 10), nil
+	case "float32":
+		return strconv.FormatFloat(float64(math.Float32frombits(c.order.Uint32(b))), 'f', -1, 32), nil
+	default: // float64
+		return strconv.FormatFloat(math.Float64frombits(c.order.Uint64(b)), 'f', -1, 64), nil
+	}
+}
+
+// encode converts the string representation to the characteristic value
+func (c byteCodec) encode(s string) ([]byte, error) {
+	switch c.typ {
+	case "string":
+		return []byte(s), nil
+	case "hex":
+		return hex.DecodeString(s)
+	case "bool":
+		v, err := strconv.ParseBool(s)
+		if v {
+			return []byte{1}, err
+		}
+		return []byte{0}, err
+	}
+
+	n, _ := c.size()
+	b := make([]byte, n)
+
+	switch c.typ {
+	case "float32":
+		f, err := strconv.ParseFloat(s, 32)
+		c.order.PutUint32(b, math.Float32bits(float32(f)))
+		return b, err
+	case "float64":
+		f, err := strconv.ParseFloat(s, 64)
+		c.order.PutUint64(b, math.Float64bits(f))
+		return b, err
+	}
+
+	var (
+		v   uint64
+		err error
+	)
+
+	if strings.HasPrefix(c.typ, "int") {
+		var i int64
+		i, err = strconv.ParseInt(s, 10, n*8)
+		v = uint64(i)
+	} else {
+		v, err = strconv.ParseUint(s, 10, n*8)
+	}
+
+	switch n {
+	case 1:
+		b[0] = byte(v)
+	case 2:
+		c.order.PutUint16(b, uint16(v))
+	case 4:
+		c.order.PutUint32(b, uint32(v))
+	default:
+		c.order.PutUint64(b, v)
+	}
+
+	return b, err
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteCodec(t *testing.T) {
+	for _, tc := range []struct {
+		typ       string
+		bigEndian bool
+		b         []byte
+		s         string
+	}{
+		{"uint8", false, []byte{0xff}, "255"},
+		{"int8", false, []byte{0xff}, "-1"},
+		{"bool", false, []byte{0x01}, "true"},
+		{"uint16", false, []byte{0x34, 0x12}, "4660"},
+		{"uint16", true, []byte{0x12, 0x34}, "4660"},
+		{"int16", false, []byte{0x18, 0xfc}, "-1000"},
+		{"uint32", false, []byte{0x78, 0x56, 0x34, 0x12}, "305419896"},
+		{"int32", true, []byte{0xff, 0xff, 0xfc, 0x18}, "-1000"},
+		{"float32", false, []byte{0x00, 0x00, 0x20, 0x41}, "10"},
+		{"float64", true, []byte{0x40, 0x24, 0, 0, 0, 0, 0, 0}, "10"},
+		{"string", false, []byte("evcc\x00\x00"), "evcc"},
+		{"hex", false, []byte{0xca, 0xfe}, "cafe"},
+	} {
+		c, err := newByteCodec(tc.typ, tc.bigEndian)
+		require.NoError(t, err)
+
+		s, err := c.decode(tc.b)
+		require.NoError(t, err, tc.typ)
+		assert.Equal(t, tc.s, s, tc.typ)
+
+		if tc.typ == "string" {
+			continue
+		}
+
+		b, err := c.encode(tc.s)
+		require.NoError(t, err, tc.typ)
+		assert.Equal(t, tc.b, b, tc.typ)
+	}
+
+	_, err := newByteCodec("foo", false)
+	assert.Error(t, err)
+
+	c, err := newByteCodec("uint32", false)
+	require.NoError(t, err)
+
+	_, err = c.decode([]byte{1})
+	assert.Error(t, err)
+
+	_, err = c.encode("-1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// deviceProvider reads values of other configured meters or chargers by name
+type deviceProvider struct {
+	meter, charger string
+	value          func(any) (float64, error)
+}
+
+func init() {
+	registry.AddCtx("device", NewDeviceFromConfig)
+}
+
+// phaseValue returns a phase value getter
+func phaseValue[T any](phase int, fun func(T) (float64, float64, float64, error)) func(any) (float64, error) {
+	return func(dev any) (float64, error) {
+		d, ok := api.As[T](dev)
+		if !ok {
+			return 0, api.ErrNotAvailable
+		}
+
+		l1, l2, l3, err := fun(d)
+		return []float64{l1, l2, l3}[phase-1], err
+	}
+}
+
+// deviceValue returns a getter for the named device value
+func deviceValue(value string) (func(any) (float64, error), error) {
+	switch strings.ToLower(value) {
+	case "", "power":
+		return func(dev any) (float64, error) {
+			if d, ok := api.As[api.Meter](dev); ok {
+				return d.CurrentPower()
+			}
+			return 0, api.ErrNotAvailable
+		}, nil
+	case "energy":
+		return func(dev any) (float64, error) {
+			if d, ok := api.As[api.MeterEnergy](dev); ok {
+				return d.TotalEnergy()
+			}
+			return 0, api.ErrNotAvailable
+		}, nil
+	case "soc":
+		return func(dev any) (float64, error) {
+			if d, ok := api.As[api.Battery](dev); ok {
+				return d.Soc()
+			}
+			return 0, api.ErrNotAvailable
+		}, nil
+	}
+
+	for phase := 1; phase <= 3; phase++ {
+		switch strings.ToLower(value) {
+		case fmt.Sprintf("current%d", phase):
+			return phaseValue(phase, api.PhaseCurrents.Currents), nil
+		case fmt.Sprintf("voltage%d", phase):
+			return phaseValue(phase, api.PhaseVoltages.Voltages), nil
+		case fmt.Sprintf("power%d", phase):
+			return phaseValue(phase, api.PhasePowers.Powers), nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid value: %s", value)
+}
+
+// NewDeviceFromConfig creates device provider
+func NewDeviceFromConfig(ctx context.Context, other map[string]interface{}) (Provider, error) {
+	var cc struct {
+		Meter   string
+		Charger string
+		Value   string // power, energy, soc, current1..3, voltage1..3, power1..3
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if (cc.Meter == "") == (cc.Charger == "") {
+		return nil, errors.New("need either meter or charger")
+	}
+
+	value, err := deviceValue(cc.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &deviceProvider{
+		meter:   cc.Meter,
+		charger: cc.Charger,
+		value:   value,
+	}
+
+	return o, nil
+}
+
+// instance resolves the device when reading since it may be created after the referencing device
+func (o *deviceProvider) instance() (any, error) {
+	if o.meter != "" {
+		dev, err := config.Meters().ByName(o.meter)
+		if err != nil {
+			return nil, err
+		}
+		return dev.Instance(), nil
+	}
+
+	dev, err := config.Chargers().ByName(o.charger)
+	if err != nil {
+		return nil, err
+	}
+	return dev.Instance(), nil
+}
+
+var _ FloatProvider = (*deviceProvider)(nil)
+
+func (o *deviceProvider) FloatGetter() (func() (float64, error), error) {
+	return func() (float64, error) {
+		dev, err := o.instance()
+		if err != nil {
+			return 0, err
+		}
+
+		return o.value(dev)
+	}, nil
+}
+
+var _ IntProvider = (*deviceProvider)(nil)
+
+func (o *deviceProvider) IntGetter() (func() (int64, error), error) {
+	g, err := o.FloatGetter()
+
+	return func() (int64, error) {
+		f, err := g()
+		return int64(f), err
+	}, err
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deviceTestMeter struct{}
+
+func (m *deviceTestMeter) CurrentPower() (float64, error) {
+	return 1000, nil
+}
+
+func (m *deviceTestMeter) Currents() (float64, float64, float64, error) {
+	return 1, 2, 3, nil
+}
+
+func TestDeviceCalc(t *testing.T) {
+	config.Reset()
+	t.Cleanup(config.Reset)
+
+	require.NoError(t, config.Meters().Add(config.NewStaticDevice(config.Named{Name: "grid"}, api.Meter(&deviceTestMeter{}))))
+
+	g, err := NewFloatGetterFromConfig(context.TODO(), Config{
+		Source: "calc",
+		Other: map[string]any{
+			"add": []map[string]any{
+				{"source": "device", "meter": "grid"},
+				{"source": "device", "meter": "grid", "value": "current2"},
+				{"source": "const", "value": 100},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	f, err := g()
+	require.NoError(t, err)
+	assert.Equal(t, 1102.0, f)
+
+	for _, tc := range []struct {
+		other map[string]any
+		err   error
+	}{
+		{map[string]any{"meter": "grid", "value": "soc"}, api.ErrNotAvailable},
+		{map[string]any{"meter": "pv"}, nil},
+		{map[string]any{"charger": "wallbox"}, nil},
+	} {
+		g, err := NewFloatGetterFromConfig(context.TODO(), Config{Source: "device", Other: tc.other})
+		require.NoError(t, err)
+
+		_, err = g()
+		if tc.err != nil {
+			assert.ErrorIs(t, err, tc.err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+
+	_, err = NewFloatGetterFromConfig(context.TODO(), Config{Source: "device", Other: map[string]any{"meter": "grid", "charger": "wallbox"}})
+	assert.Error(t, err)
+
+	_, err = NewFloatGetterFromConfig(context.TODO(), Config{Source: "device", Other: map[string]any{"meter": "grid", "value": "foo"}})
+	assert.Error(t, err)
+}
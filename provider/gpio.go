@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+// gpioProvider reads digital inputs using the Linux sysfs gpio interface
+type gpioProvider struct {
+	path   string
+	invert bool
+}
+
+func init() {
+	registry.Add("gpio", NewGpioFromConfig)
+}
+
+// NewGpioFromConfig creates gpio provider
+func NewGpioFromConfig(other map[string]interface{}) (Provider, error) {
+	var cc struct {
+		Pin    int
+		Path   string
+		Invert bool
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Path == "" {
+		cc.Path = fmt.Sprintf("/sys/class/gpio/gpio%d/value", cc.Pin)
+	}
+
+	o := &gpioProvider{
+		path:   cc.Path,
+		invert: cc.Invert,
+	}
+
+	return o, nil
+}
+
+var _ BoolProvider = (*gpioProvider)(nil)
+
+func (o *gpioProvider) BoolGetter() (func() (bool, error), error) {
+	return func() (bool, error) {
+		b, err := os.ReadFile(o.path)
+		if err != nil {
+			return false, err
+		}
+
+		var res bool
+		switch s := strings.TrimSpace(string(b)); s {
+		case "0":
+		case "1":
+			res = true
+		default:
+			return false, fmt.Errorf("invalid gpio value: %s", s)
+		}
+
+		return res != o.invert, nil
+	}, nil
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGpio(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "value")
+
+	for _, tc := range []struct {
+		value          string
+		invert, expect bool
+	}{
+		{"0\n", false, false},
+		{"1\n", false, true},
+		{"0\n", true, true},
+		{"1\n", true, false},
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(tc.value), 0o644))
+
+		p, err := NewGpioFromConfig(map[string]any{"path": path, "invert": tc.invert})
+		require.NoError(t, err)
+
+		g, err := p.(BoolProvider).BoolGetter()
+		require.NoError(t, err)
+
+		res, err := g()
+		require.NoError(t, err)
+		assert.Equal(t, tc.expect, res)
+	}
+}
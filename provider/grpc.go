@@ -0,0 +1,431 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/provider/pipeline"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/supervisor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Grpc implements gRPC request provider. Message types are resolved using server reflection.
+type Grpc struct {
+	*getter
+	log      *util.Logger
+	conn     *grpc.ClientConn
+	service  string
+	method   string
+	request  string // json request, setter parameter is replaced
+	timeout  time.Duration
+	pipeline *pipeline.Pipeline
+
+	mu   sync.Mutex
+	desc protoreflect.MethodDescriptor
+
+	val *util.Monitor[[]byte] // server-streaming only
+}
+
+func init() {
+	registry.AddCtx("grpc", NewGrpcFromConfig)
+}
+
+// NewGrpcFromConfig creates gRPC provider
+func NewGrpcFromConfig(ctx context.Context, other map[string]interface{}) (Provider, error) {
+	cc := struct {
+		URI               string
+		Method            string // fully qualified method name, e.g. package.Service/Method
+		Request           string
+		Stream            bool // server-streaming method
+		TLS               bool
+		Insecure          bool
+		pipeline.Settings `mapstructure:",squash"`
+		Scale             float64
+		Timeout           time.Duration
+	}{
+		Scale:   1,
+		Timeout: request.Timeout,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	service, method, err := splitGrpcMethod(cc.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := insecure.NewCredentials()
+	if cc.TLS {
+		creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: cc.Insecure})
+	}
+
+	conn, err := grpc.NewClient(cc.URI, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+
+	log := contextLogger(ctx, util.NewLogger("grpc"))
+
+	p := &Grpc{
+		log:     log,
+		conn:    conn,
+		service: service,
+		method:  method,
+		request: cc.Request,
+		timeout: cc.Timeout,
+	}
+
+	p.getter = defaultGetters(p, cc.Scale)
+
+	if p.pipeline, err = pipeline.New(log, cc.Settings); err != nil {
+		return nil, err
+	}
+
+	if cc.Stream {
+		p.val = util.NewMonitor[[]byte](0)
+		go p.run(ctx)
+	}
+
+	return p, nil
+}
+
+// splitGrpcMethod splits package.Service/Method or package.Service.Method into service and method
+func splitGrpcMethod(s string) (string, string, error) {
+	s = strings.TrimPrefix(s, "/")
+
+	i := strings.LastIndex(s, "/")
+	if i < 0 {
+		i = strings.LastIndex(s, ".")
+	}
+
+	if i <= 0 || i == len(s)-1 {
+		return "", "", fmt.Errorf("invalid method: %s", s)
+	}
+
+	return s[:i], s[i+1:], nil
+}
+
+// fullMethod returns the method path used on the wire
+func (p *Grpc) fullMethod() string {
+	return "/" + p.service + "/" + p.method
+}
+
+// descriptor resolves the method descriptor via server reflection
+func (p *Grpc) descriptor(ctx context.Context) (protoreflect.MethodDescriptor, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.desc != nil {
+		return p.desc, nil
+	}
+
+	files, err := resolveGrpcFiles(ctx, p.conn, p.service)
+	if err != nil {
+		return nil, fmt.Errorf("reflection: %w", err)
+	}
+
+	d, err := files.FindDescriptorByName(protoreflect.FullName(p.service))
+	if err != nil {
+		return nil, err
+	}
+
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("not a service: %s", p.service)
+	}
+
+	md := sd.Methods().ByName(protoreflect.Name(p.method))
+	if md == nil {
+		return nil, fmt.Errorf("unknown method: %s", p.fullMethod())
+	}
+
+	p.desc = md
+
+	return md, nil
+}
+
+// resolveGrpcFiles loads the file containing the symbol and its dependencies
+func resolveGrpcFiles(ctx context.Context, conn *grpc.ClientConn, symbol string) (*protoregistry.Files, error) {
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stream.CloseSend() }()
+
+	fds := make(map[string]*descriptorpb.FileDescriptorProto)
+
+	query := func(req *rpb.ServerReflectionRequest) error {
+		if err := stream.Send(req); err != nil {
+			return err
+		}
+
+		res, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		if e := res.GetErrorResponse(); e != nil {
+			return errors.New(e.GetErrorMessage())
+		}
+
+		for _, b := range res.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := new(descriptorpb.FileDescriptorProto)
+			if err := proto.Unmarshal(b, fd); err != nil {
+				return err
+			}
+			fds[fd.GetName()] = fd
+		}
+
+		return nil
+	}
+
+	if err := query(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol},
+	}); err != nil {
+		return nil, err
+	}
+
+	// load missing dependencies, preferring locally known files
+	for missing := true; missing; {
+		missing = false
+
+		for _, fd := range fds {
+			for _, dep := range fd.GetDependency() {
+				if _, ok := fds[dep]; ok {
+					continue
+				}
+
+				if local, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+					fds[dep] = protodesc.ToFileDescriptorProto(local)
+				} else if err := query(&rpb.ServerReflectionRequest{
+					MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+				}); err != nil {
+					return nil, err
+				}
+
+				// map modified, restart iteration
+				missing = true
+				break
+			}
+
+			if missing {
+				break
+			}
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range fds {
+		set.File = append(set.File, fd)
+	}
+
+	return protodesc.NewFiles(set)
+}
+
+// newRequest creates the request message from json
+func (p *Grpc) newRequest(md protoreflect.MethodDescriptor, body string) (*dynamicpb.Message, error) {
+	req := dynamicpb.NewMessage(md.Input())
+
+	if body != "" {
+		if err := protojson.Unmarshal([]byte(body), req); err != nil {
+			return nil, fmt.Errorf("request: %w", err)
+		}
+	}
+
+	return req, nil
+}
+
+// grpcJSON converts the response to json, including zero values
+func grpcJSON(msg proto.Message) ([]byte, error) {
+	return protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(msg)
+}
+
+// invoke calls the unary method and returns the json response
+func (p *Grpc) invoke(body string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	md, err := p.descriptor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, fmt.Errorf("not a unary method: %s", p.fullMethod())
+	}
+
+	req, err := p.newRequest(md, body)
+	if err != nil {
+		return nil, err
+	}
+
+	res := dynamicpb.NewMessage(md.Output())
+	if err := p.conn.Invoke(ctx, p.fullMethod(), req, res); err != nil {
+		return nil, err
+	}
+
+	b, err := grpcJSON(res)
+	if err == nil {
+		p.log.TRACE.Printf("recv: %s", b)
+	}
+
+	return b, err
+}
+
+// run receives messages from the server-streaming method, reconnecting on failure until ctx is done
+func (p *Grpc) run(ctx context.Context) {
+	sv := supervisor.New(p.log, "grpc "+p.conn.Target()+p.fullMethod(), supervisor.WithBackoff(500*time.Millisecond, maxRetryDelay))
+	sv.Run(ctx, func(connected func()) error {
+		return p.receive(ctx, connected)
+	})
+}
+
+// receive streams messages until the stream ends, calling connected for each received message
+func (p *Grpc) receive(ctx context.Context, connected func()) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	dctx, dcancel := context.WithTimeout(ctx, p.timeout)
+	md, err := p.descriptor(dctx)
+	dcancel()
+	if err != nil {
+		return err
+	}
+
+	if md.IsStreamingClient() || !md.IsStreamingServer() {
+		return fmt.Errorf("not a server-streaming method: %s", p.fullMethod())
+	}
+
+	req, err := p.newRequest(md, p.request)
+	if err != nil {
+		return err
+	}
+
+	stream, err := p.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, p.fullMethod())
+	if err != nil {
+		return err
+	}
+
+	if err := stream.SendMsg(req); err != nil {
+		return err
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		res := dynamicpb.NewMessage(md.Output())
+		if err := stream.RecvMsg(res); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		connected()
+
+		b, err := grpcJSON(res)
+		if err != nil {
+			return err
+		}
+
+		p.log.TRACE.Printf("recv: %s", b)
+
+		if v, err := p.pipeline.Process(b); err == nil {
+			p.val.Set(v)
+		}
+	}
+}
+
+var _ Getters = (*Grpc)(nil)
+
+// StringGetter calls the method or returns the last streamed value
+func (p *Grpc) StringGetter() (func() (string, error), error) {
+	return func() (string, error) {
+		var (
+			b   []byte
+			err error
+		)
+
+		if p.val != nil {
+			b, err = p.val.Get()
+		} else if b, err = p.invoke(p.request); err == nil {
+			b, err = p.pipeline.Process(b)
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		if err := knownErrors(b); err != nil {
+			return "", err
+		}
+
+		return string(b), nil
+	}, nil
+}
+
+func (p *Grpc) set(param string, val interface{}) error {
+	body, err := setFormattedValue(p.request, param, val)
+	if err == nil {
+		_, err = p.invoke(body)
+	}
+	return err
+}
+
+var _ SetIntProvider = (*Grpc)(nil)
+
+// IntSetter calls the method with parameter replaced by int value
+func (p *Grpc) IntSetter(param string) (func(int64) error, error) {
+	return func(val int64) error {
+		return p.set(param, val)
+	}, nil
+}
+
+var _ SetFloatProvider = (*Grpc)(nil)
+
+// FloatSetter calls the method with parameter replaced by float value
+func (p *Grpc) FloatSetter(param string) (func(float64) error, error) {
+	return func(val float64) error {
+		return p.set(param, val)
+	}, nil
+}
+
+var _ SetStringProvider = (*Grpc)(nil)
+
+// StringSetter calls the method with parameter replaced by string value
+func (p *Grpc) StringSetter(param string) (func(string) error, error) {
+	return func(val string) error {
+		return p.set(param, val)
+	}, nil
+}
+
+var _ SetBoolProvider = (*Grpc)(nil)
+
+// BoolSetter calls the method with parameter replaced by bool value
+func (p *Grpc) BoolSetter(param string) (func(bool) error, error) {
+	return func(val bool) error {
+		return p.set(param, val)
+	}, nil
+}
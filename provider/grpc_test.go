@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func grpcTestServer(t *testing.T) (string, *health.Server) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	reflection.Register(srv)
+
+	go func() { _ = srv.Serve(l) }()
+	t.Cleanup(srv.Stop)
+
+	return l.Addr().String(), hs
+}
+
+func TestSplitGrpcMethod(t *testing.T) {
+	for _, tc := range []string{
+		"grpc.health.v1.Health/Check",
+		"/grpc.health.v1.Health/Check",
+		"grpc.health.v1.Health.Check",
+	} {
+		service, method, err := splitGrpcMethod(tc)
+		require.NoError(t, err, tc)
+		assert.Equal(t, "grpc.health.v1.Health", service, tc)
+		assert.Equal(t, "Check", method, tc)
+	}
+
+	_, _, err := splitGrpcMethod("Check")
+	assert.Error(t, err)
+}
+
+func TestGrpcProvider(t *testing.T) {
+	uri, hs := grpcTestServer(t)
+	hs.SetServingStatus("foo", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	p, err := NewGrpcFromConfig(context.TODO(), map[string]any{
+		"uri":     uri,
+		"method":  "grpc.health.v1.Health/Check",
+		"request": `{"service":"foo"}`,
+		"jq":      ".status",
+	})
+	require.NoError(t, err)
+
+	g, err := p.(StringProvider).StringGetter()
+	require.NoError(t, err)
+
+	s, err := g()
+	require.NoError(t, err)
+	assert.Equal(t, "NOT_SERVING", s)
+
+	// setter with parameter replaced in request
+	p, err = NewGrpcFromConfig(context.TODO(), map[string]any{
+		"uri":     uri,
+		"method":  "grpc.health.v1.Health/Check",
+		"request": `{"service":"${service}"}`,
+	})
+	require.NoError(t, err)
+
+	set, err := p.(SetStringProvider).StringSetter("service")
+	require.NoError(t, err)
+
+	assert.NoError(t, set("foo"))
+	assert.Error(t, set("bar"))
+}
+
+func TestGrpcProviderStream(t *testing.T) {
+	uri, hs := grpcTestServer(t)
+	hs.SetServingStatus("foo", healthpb.HealthCheckResponse_SERVING)
+
+	p, err := NewGrpcFromConfig(context.TODO(), map[string]any{
+		"uri":     uri,
+		"method":  "grpc.health.v1.Health/Watch",
+		"request": `{"service":"foo"}`,
+		"stream":  true,
+		"jq":      `.status == "SERVING"`,
+	})
+	require.NoError(t, err)
+
+	g, err := p.(BoolProvider).BoolGetter()
+	require.NoError(t, err)
+
+	<-p.(*Grpc).val.Done()
+
+	b, err := g()
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	hs.SetServingStatus("foo", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	assert.Eventually(t, func() bool {
+		b, err := g()
+		return err == nil && !b
+	}, time.Second, 10*time.Millisecond)
+}
@@ -2,6 +2,8 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,13 +25,15 @@ type HTTP struct {
 	url, method string
 	headers     map[string]string
 	body        string
+	auth        string // Authorization identity hash, part of the cache key
+	insecure    bool
 	cache       time.Duration
-	updated     time.Time
 	pipeline    *pipeline.Pipeline
-	val         []byte // Cached http response value
-	err         error  // Cached http response error
 }
 
+// httpCache shares responses between providers requesting the same resource
+var httpCache = newSharedCache[[]byte]()
+
 func init() {
 	registry.AddCtx("http", NewHTTPProviderFromConfig)
 }
@@ -94,10 +98,11 @@ func NewHTTPProviderFromConfig(ctx context.Context, other map[string]interface{}
 // NewHTTP create HTTP provider
 func NewHTTP(log *util.Logger, method, uri string, insecure bool, cache time.Duration) *HTTP {
 	p := &HTTP{
-		Helper: request.NewHelper(log),
-		url:    uri,
-		method: method,
-		cache:  cache,
+		Helper:   request.NewHelper(log),
+		url:      uri,
+		method:   method,
+		insecure: insecure,
+		cache:    cache,
 	}
 
 	// http cache
@@ -147,35 +152,56 @@ func (p *HTTP) WithAuth(typ, user, password string) (*HTTP, error) {
 		return nil, fmt.Errorf("unknown auth type '%s'", typ)
 	}
 
+	p.auth = hashKey(typ, user, password)
+
 	return p, nil
 }
 
-// request executes the configured request or returns the cached value
+// hashKey returns a hash of the given parts to avoid keeping credentials in cache keys
+func hashKey(parts ...any) string {
+	h := sha256.New()
+	for _, p := range parts {
+		fmt.Fprintf(h, "%v\x00", p)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// request executes the configured request. GET requests share cached responses.
 func (p *HTTP) request(url string, body string) ([]byte, error) {
-	if time.Since(p.updated) >= p.cache {
-		var b io.Reader
-		if p.method != http.MethodGet {
-			b = strings.NewReader(body)
-		}
+	url = util.DefaultScheme(url, "http")
 
-		url := util.DefaultScheme(url, "http")
+	if p.method != http.MethodGet {
+		return p.do(url, body)
+	}
 
-		// empty method becomes GET
-		req, err := request.New(p.method, url, b, p.headers)
-		if err != nil {
-			return []byte{}, err
-		}
+	key := hashKey(p.method, url, body, p.headers, p.auth, p.insecure)
 
-		p.val, p.err = p.DoBody(req)
-		if p.err != nil {
-			if err := knownErrors(p.val); err != nil {
-				p.err = err
-			}
+	return httpCache.Get(key, p.cache, func() ([]byte, error) {
+		return p.do(url, body)
+	})
+}
+
+// do executes the request
+func (p *HTTP) do(url string, body string) ([]byte, error) {
+	var b io.Reader
+	if p.method != http.MethodGet {
+		b = strings.NewReader(body)
+	}
+
+	// empty method becomes GET
+	req, err := request.New(p.method, url, b, p.headers)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	val, err := p.DoBody(req)
+	if err != nil {
+		if kerr := knownErrors(val); kerr != nil {
+			err = kerr
 		}
-		p.updated = time.Now()
 	}
 
-	return p.val, p.err
+	return val, err
 }
 
 var _ Getters = (*HTTP)(nil)
@@ -209,7 +235,8 @@ func (p *HTTP) set(param string, val interface{}) error {
 		return err
 	}
 
-	_, err = p.request(url, body)
+	// setters are never cached
+	_, err = p.do(util.DefaultScheme(url, "http"), body)
 
 	return err
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/evcc-io/evcc/util"
 	"github.com/samber/lo"
@@ -72,3 +73,25 @@ func (suite *httpTestSuite) TestSetPath() {
 	suite.Require().NoError(s("4711"))
 	suite.Require().Equal("/foo/bar/4711", suite.h.req.URL.String())
 }
+
+func (suite *httpTestSuite) TestSetNotCached() {
+	uri := suite.srv.URL + "/foo/bar/{{.baz}}"
+	p := NewHTTP(util.NewLogger("foo"), http.MethodGet, uri, false, time.Hour)
+
+	s, err := p.StringSetter("baz")
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(s("4711"))
+	suite.h.req = nil
+
+	suite.Require().NoError(s("4711"))
+	suite.Require().NotNil(suite.h.req)
+}
+
+func (suite *httpTestSuite) TestAuthCacheKey() {
+	p := NewHTTP(util.NewLogger("foo"), http.MethodGet, suite.srv.URL, false, 0)
+
+	_, err := p.WithAuth("basic", "user", "secret")
+	suite.Require().NoError(err)
+	suite.Require().NotContains(p.auth, "secret")
+}
@@ -11,8 +11,9 @@ import (
 
 // Javascript implements Javascript request provider
 type Javascript struct {
-	vm     *otto.Otto
+	vm     *javascript.VM
 	script string
+	state  otto.Value
 	in     []inputTransformation
 	out    []outputTransformation
 }
@@ -39,6 +40,14 @@ func NewJavascriptProviderFromConfig(ctx context.Context, other map[string]inter
 		return nil, err
 	}
 
+	// per-plugin state surviving invocations
+	vm.Lock()
+	state, err := vm.Object("({})")
+	vm.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
 	in, err := configureInputs(ctx, cc.In)
 	if err != nil {
 		return nil, err
@@ -52,6 +61,7 @@ func NewJavascriptProviderFromConfig(ctx context.Context, other map[string]inter
 	p := &Javascript{
 		vm:     vm,
 		script: cc.Script,
+		state:  state.Value(),
 		in:     in,
 		out:    out,
 	}
@@ -120,8 +130,8 @@ func (p *Javascript) handleGetter() (any, error) {
 		return nil, err
 	}
 
-	javascript.Lock()
-	defer javascript.Unlock()
+	p.vm.Lock()
+	defer p.vm.Unlock()
 
 	return p.evaluate()
 }
@@ -131,23 +141,27 @@ func (p *Javascript) handleSetter(param string, val any) error {
 		return err
 	}
 
-	javascript.Lock()
+	p.vm.Lock()
 	if err := p.setParam(param, val); err != nil {
-		javascript.Unlock()
+		p.vm.Unlock()
 		return err
 	}
 
 	v, err := p.evaluate()
 	if err != nil {
-		javascript.Unlock()
+		p.vm.Unlock()
 		return err
 	}
 
-	javascript.Unlock()
+	p.vm.Unlock()
 	return transformOutputs(p.out, v)
 }
 
 func (p *Javascript) evaluate() (any, error) {
+	if err := p.vm.Set("state", p.state); err != nil {
+		return nil, err
+	}
+
 	v, err := p.vm.Eval(p.script)
 	if err != nil {
 		return nil, err
@@ -171,8 +185,8 @@ func (p *Javascript) setParam(param string, val any) error {
 
 // setParamSync is the synchronized version of setParam
 func (p *Javascript) setParamSync(param string, val any) error {
-	javascript.Lock()
-	defer javascript.Unlock()
+	p.vm.Lock()
+	defer p.vm.Unlock()
 	return p.setParam(param, val)
 }
 
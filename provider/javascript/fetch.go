@@ -0,0 +1,138 @@
+package javascript
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/robertkrimen/otto"
+)
+
+const (
+	fetchTimeout    = 10 * time.Second
+	fetchMaxTimeout = time.Minute
+	fetchMaxBody    = 1 << 20
+)
+
+// fetchOptions are the supported fetch() options
+type fetchOptions struct {
+	Method  string
+	Headers map[string]string
+	Body    any
+	Timeout int // ms
+}
+
+// setFetch adds a synchronous fetch(url, options) function returning status, ok, headers and body
+func setFetch(vm *otto.Otto, log *util.Logger) error {
+	client := request.NewClient(log)
+	client.Timeout = 0 // limited by request context
+
+	return vm.Set("fetch", func(call otto.FunctionCall) otto.Value {
+		res, err := fetch(client, call)
+		if err != nil {
+			panic(call.Otto.MakeCustomError("FetchError", err.Error()))
+		}
+
+		v, err := call.Otto.ToValue(res)
+		if err != nil {
+			panic(call.Otto.MakeCustomError("FetchError", err.Error()))
+		}
+
+		return v
+	})
+}
+
+func fetch(client *http.Client, call otto.FunctionCall) (map[string]any, error) {
+	uri := call.Argument(0).String()
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+
+	var opt fetchOptions
+	if arg := call.Argument(1); arg.IsObject() {
+		other, err := arg.Export()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := util.DecodeOther(other, &opt); err != nil {
+			return nil, err
+		}
+	}
+
+	timeout := fetchTimeout
+	if opt.Timeout > 0 {
+		timeout = min(time.Duration(opt.Timeout)*time.Millisecond, fetchMaxTimeout)
+	}
+
+	var body io.Reader
+	switch b := opt.Body.(type) {
+	case nil:
+	case string:
+		body = strings.NewReader(b)
+	default:
+		bb, err := json.Marshal(b)
+		if err != nil {
+			return nil, err
+		}
+		body = strings.NewReader(string(bb))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := request.New(strings.ToUpper(opt.Method), uri, body, opt.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, fetchMaxBody+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) > fetchMaxBody {
+		return nil, errors.New("response too large")
+	}
+
+	headers := make(map[string]any, len(resp.Header))
+	for k := range resp.Header {
+		headers[strings.ToLower(k)] = resp.Header.Get(k)
+	}
+
+	res := map[string]any{
+		"status":  resp.StatusCode,
+		"ok":      resp.StatusCode >= 200 && resp.StatusCode < 300,
+		"headers": headers,
+		"body":    string(b),
+		"json": func(call otto.FunctionCall) otto.Value {
+			v, err := call.Otto.Call("JSON.parse", nil, string(b))
+			if err != nil {
+				panic(call.Otto.MakeCustomError("FetchError", err.Error()))
+			}
+			return v
+		},
+	}
+
+	return res, nil
+}
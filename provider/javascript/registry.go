@@ -14,21 +14,18 @@ import (
 
 var (
 	mu       sync.Mutex
-	registry = make(map[string]*otto.Otto)
+	registry = make(map[string]*VM)
 )
 
-// expose mutex to serialize VM access
-func Lock() {
-	mu.Lock()
-}
-
-// expose mutex to serialize VM access
-func Unlock() {
-	mu.Unlock()
+// VM is a JS VM. Access must be serialized using its lock.
+// Each VM has its own lock such that blocking scripts, e.g. using fetch(), don't delay other VMs.
+type VM struct {
+	sync.Mutex
+	*otto.Otto
 }
 
 // RegisteredVM returns a JS VM. If name is not empty, it will return a shared instance.
-func RegisteredVM(name, init string) (*otto.Otto, error) {
+func RegisteredVM(name, init string) (*VM, error) {
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -37,8 +34,14 @@ func RegisteredVM(name, init string) (*otto.Otto, error) {
 
 	// create new VM
 	if !ok {
-		vm = otto.New()
-		if err := setConsole(vm, name); err != nil {
+		vm = &VM{Otto: otto.New()}
+		log := logger(name)
+
+		if err := setConsole(vm.Otto, log); err != nil {
+			return nil, err
+		}
+
+		if err := setFetch(vm.Otto, log); err != nil {
 			return nil, err
 		}
 
@@ -56,14 +59,16 @@ func RegisteredVM(name, init string) (*otto.Otto, error) {
 	return vm, nil
 }
 
-func setConsole(vm *otto.Otto, suffix string) error {
+func logger(suffix string) *util.Logger {
 	name := "js"
 	if suffix != "" {
 		name = name + "-" + suffix
 	}
 
-	log := util.NewLogger(name)
+	return util.NewLogger(name)
+}
 
+func setConsole(vm *otto.Otto, log *util.Logger) error {
 	console := map[string]any{
 		"trace": printer(log.TRACE),
 		"log":   printer(log.DEBUG),
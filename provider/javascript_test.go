@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJavascriptState(t *testing.T) {
+	p, err := NewJavascriptProviderFromConfig(context.TODO(), map[string]any{
+		"vm":     "state",
+		"script": "state.count = (state.count || 0) + 1",
+	})
+	require.NoError(t, err)
+
+	// second plugin sharing the vm has separate state
+	p2, err := NewJavascriptProviderFromConfig(context.TODO(), map[string]any{
+		"vm":     "state",
+		"script": "state.count = (state.count || 0) + 10",
+	})
+	require.NoError(t, err)
+
+	g, err := p.(IntProvider).IntGetter()
+	require.NoError(t, err)
+
+	g2, err := p2.(IntProvider).IntGetter()
+	require.NoError(t, err)
+
+	for i := int64(1); i <= 3; i++ {
+		v, err := g()
+		require.NoError(t, err)
+		assert.Equal(t, i, v)
+
+		v, err = g2()
+		require.NoError(t, err)
+		assert.Equal(t, 10*i, v)
+	}
+}
+
+func TestJavascriptFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"power":1234}`))
+	}))
+	defer srv.Close()
+
+	p, err := NewJavascriptProviderFromConfig(context.TODO(), map[string]any{
+		"script": `
+			var res = fetch("` + srv.URL + `", { headers: { Authorization: "Bearer token" }, timeout: 1000 });
+			res.ok ? res.json().power : -res.status`,
+	})
+	require.NoError(t, err)
+
+	g, err := p.(FloatProvider).FloatGetter()
+	require.NoError(t, err)
+
+	v, err := g()
+	require.NoError(t, err)
+	assert.Equal(t, 1234.0, v)
+
+	p, err = NewJavascriptProviderFromConfig(context.TODO(), map[string]any{
+		"script": `fetch("` + srv.URL + `").status`,
+	})
+	require.NoError(t, err)
+
+	g, err = p.(FloatProvider).FloatGetter()
+	require.NoError(t, err)
+
+	v, err = g()
+	require.NoError(t, err)
+	assert.Equal(t, 401.0, v)
+
+	p, err = NewJavascriptProviderFromConfig(context.TODO(), map[string]any{
+		"script": `fetch("file:///etc/passwd")`,
+	})
+	require.NoError(t, err)
+
+	g, err = p.(FloatProvider).FloatGetter()
+	require.NoError(t, err)
+
+	_, err = g()
+	assert.ErrorContains(t, err, "unsupported scheme")
+}
+
+func TestJavascriptFetchNotBlocking(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	p, err := NewJavascriptProviderFromConfig(context.TODO(), map[string]any{
+		"vm":     "fetch",
+		"script": `fetch("` + srv.URL + `", { timeout: 5000 }).status`,
+	})
+	require.NoError(t, err)
+
+	g, err := p.(IntProvider).IntGetter()
+	require.NoError(t, err)
+
+	go func() { _, _ = g() }()
+	<-started
+
+	p2, err := NewJavascriptProviderFromConfig(context.TODO(), map[string]any{
+		"script": "1",
+	})
+	require.NoError(t, err)
+
+	g2, err := p2.(IntProvider).IntGetter()
+	require.NoError(t, err)
+
+	// other vms are not blocked by the pending fetch
+	done := make(chan struct{})
+	go func() {
+		v, err := g2()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), v)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("blocked by pending fetch")
+	}
+}
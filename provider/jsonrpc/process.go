@@ -0,0 +1,262 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/kballard/go-shellquote"
+)
+
+// restartDelay limits process restarts after unexpected exits
+const restartDelay = 5 * time.Second
+
+var (
+	mu        sync.Mutex
+	instances = make(map[string]*Process)
+)
+
+// Instance returns the shared process for the command line
+func Instance(log *util.Logger, cmd string) (*Process, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if p, ok := instances[cmd]; ok {
+		return p, nil
+	}
+
+	args, err := shellquote.Split(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 0 {
+		return nil, errors.New("missing cmd")
+	}
+
+	p := &Process{
+		log:  log,
+		args: args,
+	}
+
+	instances[cmd] = p
+
+	return p, nil
+}
+
+// Error is a JSON-RPC error object
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (%d)", e.Message, e.Code)
+}
+
+type request struct {
+	Version string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type response struct {
+	ID     *uint64         `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+// Process is a long-running process exchanging newline-delimited JSON-RPC 2.0 messages over stdio
+type Process struct {
+	log  *util.Logger
+	args []string
+
+	mu      sync.Mutex
+	writeMu sync.Mutex // serializes writes to stdin
+	stdin   io.WriteCloser
+	proc    *os.Process
+	done    chan struct{}
+	started time.Time
+	id      uint64
+	pending map[uint64]chan response
+}
+
+// start launches the process if not running
+func (p *Process) start() error {
+	if p.done != nil {
+		select {
+		case <-p.done:
+		default:
+			return nil
+		}
+	}
+
+	if wait := restartDelay - time.Since(p.started); wait > 0 && !p.started.IsZero() {
+		return fmt.Errorf("process exited, restarting in %v", wait.Round(time.Second))
+	}
+	p.started = time.Now()
+
+	cmd := exec.Command(p.args[0], p.args[1:]...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	p.log.DEBUG.Printf("started %s (pid %d)", p.args[0], cmd.Process.Pid)
+
+	p.stdin = stdin
+	p.proc = cmd.Process
+	p.done = make(chan struct{})
+	p.pending = make(map[uint64]chan response)
+
+	go p.logStderr(stderr)
+	go p.receive(stdout, stdin, cmd.Process, p.pending, p.done)
+
+	go func(done chan struct{}) {
+		<-done
+		err := cmd.Wait()
+		p.log.ERROR.Printf("%s exited: %v", p.args[0], err)
+	}(p.done)
+
+	return nil
+}
+
+func (p *Process) logStderr(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		p.log.DEBUG.Println(scanner.Text())
+	}
+}
+
+// receive dispatches responses until stdout is closed or unreadable, then stops the process
+func (p *Process) receive(r io.Reader, stdin io.Closer, proc *os.Process, pending map[uint64]chan response, done chan struct{}) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+	defer func() {
+		if err := scanner.Err(); err != nil {
+			p.log.ERROR.Printf("%s: %v", p.args[0], err)
+		}
+
+		// the process must not outlive its output, a restart would leave it running
+		_ = proc.Kill()
+
+		p.mu.Lock()
+		close(done)
+		stdin.Close()
+		p.mu.Unlock()
+	}()
+
+	for scanner.Scan() {
+		b := scanner.Bytes()
+		p.log.TRACE.Printf("recv: %s", b)
+
+		var res response
+		if err := json.Unmarshal(b, &res); err != nil {
+			p.log.ERROR.Printf("invalid response: %s", b)
+			continue
+		}
+
+		// ignore notifications
+		if res.ID == nil {
+			continue
+		}
+
+		p.mu.Lock()
+		if ch, ok := pending[*res.ID]; ok {
+			ch <- res
+			delete(pending, *res.ID)
+		}
+		p.mu.Unlock()
+	}
+}
+
+// Call invokes the method and returns the raw result
+func (p *Process) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	p.mu.Lock()
+
+	if err := p.start(); err != nil {
+		p.mu.Unlock()
+		return nil, err
+	}
+
+	p.id++
+	id := p.id
+
+	b, err := json.Marshal(request{Version: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		p.mu.Unlock()
+		return nil, err
+	}
+
+	p.log.TRACE.Printf("send: %s", b)
+
+	ch := make(chan response, 1)
+	p.pending[id] = ch
+	pending, done, stdin, proc := p.pending, p.done, p.stdin, p.proc
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		delete(pending, id)
+		p.mu.Unlock()
+	}()
+
+	// write without holding the lock, the process may not read its input while its output is pending
+	errC := make(chan error, 1)
+	go func() {
+		p.writeMu.Lock()
+		defer p.writeMu.Unlock()
+		_, err := stdin.Write(append(b, '\n'))
+		errC <- err
+	}()
+
+	select {
+	case err := <-errC:
+		if err != nil {
+			return nil, err
+		}
+	case <-done:
+		return nil, errors.New("process exited")
+	case <-ctx.Done():
+		p.log.ERROR.Printf("%s not reading input, stopping", p.args[0])
+		_ = proc.Kill()
+		return nil, ctx.Err()
+	}
+
+	select {
+	case res := <-ch:
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		return res.Result, nil
+	case <-done:
+		return nil, errors.New("process exited")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
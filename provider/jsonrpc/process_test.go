@@ -0,0 +1,136 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHelperProcess is a JSON-RPC server used by the tests
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("JSONRPC_HELPER_PROCESS") != "1" {
+		t.Skip()
+	}
+
+	var counter int
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var req struct {
+			ID     uint64         `json:"id"`
+			Method string         `json:"method"`
+			Params map[string]any `json:"params"`
+		}
+
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			os.Exit(1)
+		}
+
+		// notification
+		fmt.Println(`{"jsonrpc":"2.0","method":"ping"}`)
+
+		switch req.Method {
+		case "count":
+			counter++
+			fmt.Printf(`{"jsonrpc":"2.0","id":%d,"result":%d}`+"\n", req.ID, counter)
+		case "echo":
+			b, _ := json.Marshal(req.Params)
+			fmt.Printf(`{"jsonrpc":"2.0","id":%d,"result":%s}`+"\n", req.ID, b)
+		case "exit":
+			os.Exit(0)
+		case "overflow":
+			fmt.Printf(`{"jsonrpc":"2.0","id":%d,"result":"%s"}`+"\n", req.ID, strings.Repeat("x", 2<<20))
+		case "block":
+			// stop reading input
+			select {}
+		default:
+			fmt.Printf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"method not found"}}`+"\n", req.ID)
+		}
+	}
+
+	os.Exit(0)
+}
+
+func TestProcess(t *testing.T) {
+	t.Setenv("JSONRPC_HELPER_PROCESS", "1")
+
+	p, err := Instance(util.NewLogger("foo"), os.Args[0]+" -test.run=TestHelperProcess")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// process is reused
+	for i := 1; i <= 3; i++ {
+		res, err := p.Call(ctx, "count", nil)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprint(i), string(res))
+	}
+
+	res, err := p.Call(ctx, "echo", map[string]any{"foo": "bar"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"foo":"bar"}`, string(res))
+
+	_, err = p.Call(ctx, "foo", nil)
+	assert.EqualError(t, err, "method not found (-32601)")
+
+	_, err = p.Call(ctx, "exit", nil)
+	assert.EqualError(t, err, "process exited")
+
+	// restart is delayed
+	_, err = p.Call(ctx, "count", nil)
+	assert.ErrorContains(t, err, "restarting")
+}
+
+func TestProcessStopped(t *testing.T) {
+	t.Setenv("JSONRPC_HELPER_PROCESS", "1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	helper := func() *Process {
+		return &Process{log: util.NewLogger("foo"), args: []string{os.Args[0], "-test.run=TestHelperProcess"}}
+	}
+
+	// oversized response
+	p := helper()
+
+	_, err := p.Call(ctx, "overflow", nil)
+	assert.EqualError(t, err, "process exited")
+
+	// process not reading its input
+	p = helper()
+
+	blockCtx, blockCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer blockCancel()
+
+	_, err = p.Call(blockCtx, "block", nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// write exceeding the pipe buffer stops the process
+	writeCtx, writeCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer writeCancel()
+
+	_, err = p.Call(writeCtx, "echo", strings.Repeat("x", 1<<20))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		select {
+		case <-p.done:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+}
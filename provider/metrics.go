@@ -0,0 +1,21 @@
+package provider
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// cache results
+const (
+	cacheHit    = "hit"    // value served from cache
+	cacheMiss   = "miss"   // value fetched
+	cacheShared = "shared" // value fetched by concurrent request
+)
+
+var cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "evcc",
+	Subsystem: "provider",
+	Name:      "cache_requests_total",
+	Help:      "Total number of cached provider requests by result",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(cacheRequests)
+}
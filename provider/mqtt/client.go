@@ -3,8 +3,10 @@ package mqtt
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"math/rand/v2"
+	"os"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -51,14 +53,90 @@ type Option func(*paho.ClientOptions)
 
 const secure = "tls://"
 
-// NewClient creates new Mqtt publisher
-func NewClient(log *util.Logger, broker, user, password, clientID string, qos byte, insecure bool, caCert, clientCert, clientKey string, opts ...Option) (*Client, error) {
-	broker, isSecure := strings.CutPrefix(broker, secure)
+// secureSchemes are the broker url schemes implying tls
+var secureSchemes = []string{secure, "ssl://", "mqtts://"}
+
+// pemOrFile returns the PEM encoded value or reads it from file if a path is given
+func pemOrFile(val string) ([]byte, error) {
+	if val == "" || strings.Contains(val, "-----BEGIN") {
+		return []byte(val), nil
+	}
+	return os.ReadFile(val)
+}
+
+// newTLSConfig creates the tls configuration from PEM encoded or file based certificates
+func newTLSConfig(insecure bool, caCert, clientCert, clientKey string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecure,
+	}
+
+	if caCert != "" {
+		pem, err := pemOrFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca cert: %w", err)
+		}
+
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM(pem); !ok {
+			return nil, fmt.Errorf("failed to add ca cert to cert pool")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if (clientCert == "") != (clientKey == "") {
+		return nil, errors.New("client cert and key must be configured together")
+	}
+
+	if clientCert != "" {
+		cert, err := pemOrFile(clientCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client cert: %w", err)
+		}
+
+		key, err := pemOrFile(clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %w", err)
+		}
+
+		clientKeyPair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add client cert: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientKeyPair}
+	}
+
+	return tlsConfig, nil
+}
+
+// brokerURL adds default port and scheme. Configured certificates imply tls.
+func brokerURL(broker string, certs bool) string {
+	var isSecure bool
+	for _, scheme := range secureSchemes {
+		if b, ok := strings.CutPrefix(broker, scheme); ok {
+			broker, isSecure = b, true
+			break
+		}
+	}
+
+	if !isSecure && certs && !strings.Contains(broker, "://") {
+		isSecure = true
+	}
 
 	// strip schema as it breaks net.SplitHostPort
-	broker = util.DefaultPort(broker, 1883)
 	if isSecure {
-		broker = secure + broker
+		return secure + util.DefaultPort(broker, 8883)
+	}
+
+	return util.DefaultPort(broker, 1883)
+}
+
+// NewClient creates new Mqtt publisher
+func NewClient(log *util.Logger, broker, user, password, clientID string, qos byte, insecure bool, caCert, clientCert, clientKey string, opts ...Option) (*Client, error) {
+	broker = brokerURL(broker, caCert != "" || clientCert != "")
+
+	tlsConfig, err := newTLSConfig(insecure, caCert, clientCert, clientKey)
+	if err != nil {
+		return nil, err
 	}
 
 	mc := &Client{
@@ -79,23 +157,6 @@ func NewClient(log *util.Logger, broker, user, password, clientID string, qos by
 	options.SetConnectTimeout(request.Timeout)
 	options.SetWriteTimeout(request.Timeout)
 
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: insecure,
-	}
-	if caCert != "" {
-		caCertPool := x509.NewCertPool()
-		if ok := caCertPool.AppendCertsFromPEM([]byte(caCert)); !ok {
-			return nil, fmt.Errorf("failed to add ca cert to cert pool")
-		}
-		tlsConfig.RootCAs = caCertPool
-	}
-	if clientCert != "" && clientKey != "" {
-		clientKeyPair, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
-		if err != nil {
-			return nil, fmt.Errorf("failed to add client cert: %w", err)
-		}
-		tlsConfig.Certificates = []tls.Certificate{clientKeyPair}
-	}
 	options.SetTLSConfig(tlsConfig)
 
 	// additional options
@@ -0,0 +1,47 @@
+package mqtt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrokerURL(t *testing.T) {
+	for _, tc := range []struct {
+		broker   string
+		certs    bool
+		expected string
+	}{
+		{"localhost", false, "localhost:1883"},
+		{"localhost:1884", false, "localhost:1884"},
+		{"tls://localhost", false, "tls://localhost:8883"},
+		{"ssl://localhost:8884", false, "tls://localhost:8884"},
+		{"mqtts://localhost", false, "tls://localhost:8883"},
+		{"example.iot.amazonaws.com", true, "tls://example.iot.amazonaws.com:8883"},
+	} {
+		assert.Equal(t, tc.expected, brokerURL(tc.broker, tc.certs), tc.broker)
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	_, err := newTLSConfig(false, "", "cert", "")
+	assert.Error(t, err, "cert without key")
+
+	_, err = newTLSConfig(false, "", "", "key")
+	assert.Error(t, err, "key without cert")
+
+	_, err = newTLSConfig(false, filepath.Join(t.TempDir(), "missing.pem"), "", "")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(file, []byte("-----BEGIN CERTIFICATE-----\ninvalid\n-----END CERTIFICATE-----\n"), 0o600))
+	_, err = newTLSConfig(false, file, "", "")
+	assert.ErrorContains(t, err, "cert pool", "file read but invalid pem")
+
+	tc, err := newTLSConfig(true, "", "", "")
+	require.NoError(t, err)
+	assert.True(t, tc.InsecureSkipVerify)
+}
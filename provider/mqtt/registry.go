@@ -33,6 +33,10 @@ var (
 // RegisteredClient reuses an registered Mqtt publisher or creates a new one
 func RegisteredClient(log *util.Logger, broker, user, password, clientID string, qos byte, insecure bool, caCert, clientCert, clientKey string, opts ...Option) (*Client, error) {
 	key := fmt.Sprintf("%s.%s:%s", broker, user, password)
+	if clientCert != "" {
+		// separate connection per client identity
+		key += "." + clientCert
+	}
 
 	mu.Lock()
 	defer mu.Unlock()
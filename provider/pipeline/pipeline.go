@@ -22,14 +22,16 @@ type Pipeline struct {
 	dflt   string
 	unpack string
 	decode string
+	trafos []transform
 }
 
 type Settings struct {
-	Regex   string
-	Default string
-	Jq      string
-	Unpack  string
-	Decode  string
+	Regex     string
+	Default   string
+	Jq        string
+	Unpack    string
+	Decode    string
+	Transform []string
 }
 
 func New(log *util.Logger, cc Settings) (*Pipeline, error) {
@@ -54,6 +56,10 @@ func New(log *util.Logger, cc Settings) (*Pipeline, error) {
 		_, err = p.WithDecode(cc.Decode)
 	}
 
+	if err == nil && len(cc.Transform) > 0 {
+		_, err = p.WithTransform(cc.Transform)
+	}
+
 	return p, err
 }
 
@@ -96,6 +102,20 @@ func (p *Pipeline) WithDecode(decode string) (*Pipeline, error) {
 	return p, nil
 }
 
+// WithTransform adds numeric transformations applied in order
+func (p *Pipeline) WithTransform(transforms []string) (*Pipeline, error) {
+	for _, s := range transforms {
+		t, err := parseTransform(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transform '%s': %w", s, err)
+		}
+
+		p.trafos = append(p.trafos, t)
+	}
+
+	return p, nil
+}
+
 // transform XML into JSON with attribute names getting 'attr' prefix
 func (p *Pipeline) transformXML(value []byte) []byte {
 	value = bytes.TrimSpace(value)
@@ -206,5 +226,16 @@ func (p *Pipeline) Process(in []byte) ([]byte, error) {
 		b = []byte(strconv.FormatFloat(v, 'f', -1, 64))
 	}
 
+	if len(p.trafos) > 0 {
+		v, err := strconv.ParseFloat(strings.TrimSpace(string(b)), 64)
+		if err != nil {
+			return b, err
+		}
+		for _, t := range p.trafos {
+			v = t(v)
+		}
+		b = []byte(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+
 	return b, nil
 }
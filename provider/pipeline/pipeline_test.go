@@ -49,3 +49,47 @@ func TestJq(t *testing.T) {
 		require.Equal(t, []byte("2"), res)
 	}
 }
+
+func TestTransform(t *testing.T) {
+	for _, tc := range []struct {
+		transform []string
+		in, out   string
+	}{
+		{[]string{"scale 0.1"}, "123", "12.3"},
+		{[]string{"offset -10"}, "15", "5"},
+		{[]string{"abs"}, "-42", "42"},
+		{[]string{"invert"}, "42", "-42"},
+		{[]string{"clamp 0 100"}, "120", "100"},
+		{[]string{"clamp 0 100"}, "-5", "0"},
+		{[]string{"min 0"}, "-5", "0"},
+		{[]string{"max 100"}, "120", "100"},
+		{[]string{"convert mW W"}, "4200", "4.2"},
+		{[]string{"convert kWh Wh"}, "1.5", "1500"},
+		{[]string{"convert MW kW"}, "2", "2000"},
+		{[]string{"invert", "convert kW W", "clamp 0 11000"}, "-2.5", "2500"},
+	} {
+		p, err := new(Pipeline).WithTransform(tc.transform)
+		require.NoError(t, err, tc.transform)
+
+		res, err := p.Process([]byte(tc.in))
+		require.NoError(t, err, tc.transform)
+		require.Equal(t, tc.out, string(res), tc.transform)
+	}
+
+	for _, tc := range []string{"", "foo", "scale", "scale x", "abs 1", "clamp 10 0", "convert W", "convert W A", "convert xW W"} {
+		_, err := new(Pipeline).WithTransform([]string{tc})
+		require.Error(t, err, tc)
+	}
+}
+
+func TestTransformJq(t *testing.T) {
+	p, err := New(nil, Settings{Jq: ".power", Transform: []string{"convert mW W"}})
+	require.NoError(t, err)
+
+	res, err := p.Process([]byte(`{"power":1500}`))
+	require.NoError(t, err)
+	require.Equal(t, "1.5", string(res))
+
+	_, err = p.Process([]byte(`{"power":"foo"}`))
+	require.Error(t, err)
+}
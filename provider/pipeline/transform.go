@@ -0,0 +1,143 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// transform is a numeric transformation step
+type transform func(float64) float64
+
+// unit is a unit's dimension and factor relative to its base unit
+type unit struct {
+	dimension string
+	factor    float64
+}
+
+var (
+	baseUnits = map[string]string{"W": "power", "Wh": "energy", "A": "current", "V": "voltage", "Hz": "frequency"}
+	prefixes  = map[byte]float64{'m': 1e-3, 'k': 1e3, 'M': 1e6}
+)
+
+// lookupUnit resolves the unit symbol. Prefixes are case-sensitive to distinguish milli (m) from mega (M).
+func lookupUnit(s string) (unit, error) {
+	if dim, ok := baseUnits[s]; ok {
+		return unit{dim, 1}, nil
+	}
+
+	if len(s) > 1 {
+		if dim, ok := baseUnits[s[1:]]; ok {
+			if f, ok := prefixes[s[0]]; ok {
+				return unit{dim, f}, nil
+			}
+		}
+	}
+
+	return unit{}, fmt.Errorf("unknown unit: %s", s)
+}
+
+func parseFloats(args []string, n int) ([]float64, error) {
+	if len(args) != n {
+		return nil, fmt.Errorf("expected %d arguments, got %d", n, len(args))
+	}
+
+	res := make([]float64, 0, n)
+	for _, a := range args {
+		f, err := strconv.ParseFloat(a, 64)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, f)
+	}
+
+	return res, nil
+}
+
+// parseTransform parses a transformation like `scale 0.1`, `clamp 0 100` or `convert mW W`
+func parseTransform(s string) (transform, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, errors.New("empty transform")
+	}
+
+	op, args := strings.ToLower(fields[0]), fields[1:]
+
+	switch op {
+	case "abs":
+		if _, err := parseFloats(args, 0); err != nil {
+			return nil, err
+		}
+		return math.Abs, nil
+
+	case "invert":
+		if _, err := parseFloats(args, 0); err != nil {
+			return nil, err
+		}
+		return func(v float64) float64 { return -v }, nil
+
+	case "scale":
+		f, err := parseFloats(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(v float64) float64 { return v * f[0] }, nil
+
+	case "offset":
+		f, err := parseFloats(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(v float64) float64 { return v + f[0] }, nil
+
+	case "min":
+		f, err := parseFloats(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(v float64) float64 { return max(v, f[0]) }, nil
+
+	case "max":
+		f, err := parseFloats(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(v float64) float64 { return min(v, f[0]) }, nil
+
+	case "clamp":
+		f, err := parseFloats(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		if f[0] > f[1] {
+			return nil, errors.New("clamp minimum exceeds maximum")
+		}
+		return func(v float64) float64 { return min(max(v, f[0]), f[1]) }, nil
+
+	case "convert":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("expected 2 arguments, got %d", len(args))
+		}
+
+		from, err := lookupUnit(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := lookupUnit(args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if from.dimension != to.dimension {
+			return nil, fmt.Errorf("cannot convert %s to %s", args[0], args[1])
+		}
+
+		f := from.factor / to.factor
+		return func(v float64) float64 { return v * f }, nil
+	}
+
+	return nil, fmt.Errorf("unknown operation: %s", op)
+}
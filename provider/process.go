@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/provider/jsonrpc"
+	"github.com/evcc-io/evcc/provider/pipeline"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+// Process implements JSON-RPC calls to a long-running process
+type Process struct {
+	*getter
+	process  *jsonrpc.Process
+	method   string
+	params   map[string]any
+	timeout  time.Duration
+	pipeline *pipeline.Pipeline
+}
+
+func init() {
+	registry.AddCtx("process", NewProcessFromConfig)
+}
+
+// NewProcessFromConfig creates process provider
+func NewProcessFromConfig(ctx context.Context, other map[string]interface{}) (Provider, error) {
+	cc := struct {
+		Cmd               string
+		Method            string
+		Params            map[string]any
+		pipeline.Settings `mapstructure:",squash"`
+		Scale             float64
+		Timeout           time.Duration
+	}{
+		Scale:   1,
+		Timeout: request.Timeout,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Method == "" {
+		return nil, errors.New("missing method")
+	}
+
+	log := contextLogger(ctx, util.NewLogger("process"))
+
+	process, err := jsonrpc.Instance(log, strings.TrimSpace(cc.Cmd))
+	if err != nil {
+		return nil, err
+	}
+
+	pipe, err := pipeline.New(log, cc.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Process{
+		process:  process,
+		method:   cc.Method,
+		params:   cc.Params,
+		timeout:  cc.Timeout,
+		pipeline: pipe,
+	}
+
+	p.getter = defaultGetters(p, cc.Scale)
+
+	return p, nil
+}
+
+func (p *Process) call(params map[string]any) (json.RawMessage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	return p.process.Call(ctx, p.method, params)
+}
+
+var _ Getters = (*Process)(nil)
+
+// StringGetter calls the method. String results are returned unquoted, other results as JSON.
+func (p *Process) StringGetter() (func() (string, error), error) {
+	return func() (string, error) {
+		b, err := p.call(p.params)
+		if err != nil {
+			return "", err
+		}
+
+		var s string
+		if err := json.Unmarshal(b, &s); err == nil {
+			b = []byte(s)
+		}
+
+		if p.pipeline != nil {
+			b, err = p.pipeline.Process(b)
+		}
+
+		return string(b), err
+	}, nil
+}
+
+func (p *Process) set(param string, val any) error {
+	params := maps.Clone(p.params)
+	if params == nil {
+		params = make(map[string]any)
+	}
+	params[param] = val
+
+	_, err := p.call(params)
+	return err
+}
+
+var _ SetIntProvider = (*Process)(nil)
+
+// IntSetter calls the method with the value added to the parameters
+func (p *Process) IntSetter(param string) (func(int64) error, error) {
+	return func(val int64) error {
+		return p.set(param, val)
+	}, nil
+}
+
+var _ SetFloatProvider = (*Process)(nil)
+
+// FloatSetter calls the method with the value added to the parameters
+func (p *Process) FloatSetter(param string) (func(float64) error, error) {
+	return func(val float64) error {
+		return p.set(param, val)
+	}, nil
+}
+
+var _ SetBoolProvider = (*Process)(nil)
+
+// BoolSetter calls the method with the value added to the parameters
+func (p *Process) BoolSetter(param string) (func(bool) error, error) {
+	return func(val bool) error {
+		return p.set(param, val)
+	}, nil
+}
+
+var _ SetStringProvider = (*Process)(nil)
+
+// StringSetter calls the method with the value added to the parameters
+func (p *Process) StringSetter(param string) (func(string) error, error) {
+	return func(val string) error {
+		return p.set(param, val)
+	}, nil
+}
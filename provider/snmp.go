@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/provider/pipeline"
+	"github.com/evcc-io/evcc/util"
+	"github.com/gosnmp/gosnmp"
+)
+
+// SNMP implements SNMP get and walk provider
+type SNMP struct {
+	*getter
+	mu       sync.Mutex
+	client   *gosnmp.GoSNMP
+	oid      string
+	walk     bool
+	pipeline *pipeline.Pipeline
+}
+
+var (
+	snmpAuthProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+		"MD5":    gosnmp.MD5,
+		"SHA":    gosnmp.SHA,
+		"SHA224": gosnmp.SHA224,
+		"SHA256": gosnmp.SHA256,
+		"SHA384": gosnmp.SHA384,
+		"SHA512": gosnmp.SHA512,
+	}
+
+	snmpPrivProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+		"DES":    gosnmp.DES,
+		"AES":    gosnmp.AES,
+		"AES192": gosnmp.AES192,
+		"AES256": gosnmp.AES256,
+	}
+)
+
+func init() {
+	registry.AddCtx("snmp", NewSNMPFromConfig)
+}
+
+// NewSNMPFromConfig creates SNMP provider
+func NewSNMPFromConfig(ctx context.Context, other map[string]interface{}) (Provider, error) {
+	cc := struct {
+		URI               string
+		Version           string // 2c or 3
+		Community         string // v2c
+		User              string // v3
+		AuthProtocol      string // v3 MD5 or SHA
+		AuthPassword      string
+		PrivProtocol      string // v3 DES or AES
+		PrivPassword      string
+		Timeout           time.Duration
+		Oid               string
+		Walk              bool // return all variables below oid as json object
+		pipeline.Settings `mapstructure:",squash"`
+		Scale             float64
+	}{
+		Community: "public",
+		Timeout:   5 * time.Second,
+		Scale:     1,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	if cc.Oid == "" {
+		return nil, errors.New("missing oid")
+	}
+
+	host, port, err := net.SplitHostPort(util.DefaultPort(cc.URI, 161))
+	if err != nil {
+		return nil, err
+	}
+
+	portNum, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, err
+	}
+
+	log := contextLogger(ctx, util.NewLogger("snmp"))
+
+	client := &gosnmp.GoSNMP{
+		Target:    host,
+		Port:      uint16(portNum),
+		Community: cc.Community,
+		Timeout:   cc.Timeout,
+		Retries:   2,
+		MaxOids:   gosnmp.MaxOids,
+		Logger:    gosnmp.NewLogger(log.TRACE),
+	}
+
+	switch strings.TrimPrefix(strings.ToLower(cc.Version), "v") {
+	case "", "2", "2c":
+		client.Version = gosnmp.Version2c
+	case "3":
+		if err := snmpV3(client, cc.User, cc.AuthProtocol, cc.AuthPassword, cc.PrivProtocol, cc.PrivPassword); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported version: %s", cc.Version)
+	}
+
+	if err := client.Connect(); err != nil {
+		return nil, err
+	}
+
+	pipe, err := pipeline.New(log, cc.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &SNMP{
+		client:   client,
+		oid:      cc.Oid,
+		walk:     cc.Walk,
+		pipeline: pipe,
+	}
+
+	p.getter = defaultGetters(p, cc.Scale)
+
+	return p, nil
+}
+
+// snmpV3 configures user-based security
+func snmpV3(client *gosnmp.GoSNMP, user, authProtocol, authPassword, privProtocol, privPassword string) error {
+	if user == "" {
+		return errors.New("missing user")
+	}
+
+	params := &gosnmp.UsmSecurityParameters{
+		UserName:               user,
+		AuthenticationProtocol: gosnmp.NoAuth,
+		PrivacyProtocol:        gosnmp.NoPriv,
+	}
+
+	client.Version = gosnmp.Version3
+	client.SecurityModel = gosnmp.UserSecurityModel
+	client.SecurityParameters = params
+	client.MsgFlags = gosnmp.NoAuthNoPriv
+
+	if authProtocol == "" {
+		return nil
+	}
+
+	auth, ok := snmpAuthProtocols[strings.ToUpper(authProtocol)]
+	if !ok {
+		return fmt.Errorf("unsupported auth protocol: %s", authProtocol)
+	}
+
+	params.AuthenticationProtocol = auth
+	params.AuthenticationPassphrase = authPassword
+	client.MsgFlags = gosnmp.AuthNoPriv
+
+	if privProtocol == "" {
+		return nil
+	}
+
+	priv, ok := snmpPrivProtocols[strings.ToUpper(privProtocol)]
+	if !ok {
+		return fmt.Errorf("unsupported privacy protocol: %s", privProtocol)
+	}
+
+	params.PrivacyProtocol = priv
+	params.PrivacyPassphrase = privPassword
+	client.MsgFlags = gosnmp.AuthPriv
+
+	return nil
+}
+
+// snmpValue converts the variable's value for output
+func snmpValue(v gosnmp.SnmpPDU) any {
+	if b, ok := v.Value.([]byte); ok {
+		return string(b)
+	}
+	return v.Value
+}
+
+func (p *SNMP) read() ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.walk {
+		res, err := p.client.Get([]string{p.oid})
+		if err != nil {
+			return nil, err
+		}
+
+		if res.Error != gosnmp.NoError {
+			return nil, fmt.Errorf("%s at index %d", res.Error, res.ErrorIndex)
+		}
+
+		if len(res.Variables) != 1 {
+			return nil, fmt.Errorf("invalid response: %d variables", len(res.Variables))
+		}
+
+		v := res.Variables[0]
+		switch v.Type {
+		case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+			return nil, api.ErrNotAvailable
+		}
+
+		return []byte(fmt.Sprint(snmpValue(v))), nil
+	}
+
+	vars, err := p.client.WalkAll(p.oid)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]any, len(vars))
+	for _, v := range vars {
+		res[strings.TrimPrefix(v.Name, ".")] = snmpValue(v)
+	}
+
+	return json.Marshal(res)
+}
+
+var _ Getters = (*SNMP)(nil)
+
+// StringGetter reads the variable or walks the subtree
+func (p *SNMP) StringGetter() (func() (string, error), error) {
+	return func() (string, error) {
+		b, err := p.read()
+
+		if err == nil && p.pipeline != nil {
+			b, err = p.pipeline.Process(b)
+		}
+
+		return string(b), err
+	}, nil
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var snmpMib = []gosnmp.SnmpPDU{
+	{Name: ".1.3.6.1.2.1.33.1.2.4.0", Type: gosnmp.Gauge32, Value: uint(100)},
+	{Name: ".1.3.6.1.2.1.33.1.4.4.1.4.1", Type: gosnmp.Integer, Value: 1200},
+	{Name: ".1.3.6.1.2.1.33.1.4.4.1.4.2", Type: gosnmp.Integer, Value: -300},
+	{Name: ".1.3.6.1.2.1.33.1.4.4.1.5.1", Type: gosnmp.OctetString, Value: []byte("foo")},
+}
+
+// snmpLookup answers get and getnext requests from the mib
+func snmpLookup(typ gosnmp.PDUType, oid string) gosnmp.SnmpPDU {
+	if typ == gosnmp.GetNextRequest {
+		for _, v := range snmpMib {
+			if v.Name > oid && !strings.HasPrefix(oid, v.Name+".") {
+				return v
+			}
+		}
+		return gosnmp.SnmpPDU{Name: oid, Type: gosnmp.EndOfMibView}
+	}
+
+	for _, v := range snmpMib {
+		if v.Name == oid {
+			return v
+		}
+	}
+
+	return gosnmp.SnmpPDU{Name: oid, Type: gosnmp.NoSuchObject}
+}
+
+// snmpAgent serves the mib via SNMP v2c
+func snmpAgent(t *testing.T, community string) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		decoder := &gosnmp.GoSNMP{Logger: gosnmp.NewLogger(nil)}
+		buf := make([]byte, 65535)
+
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			req, err := decoder.SnmpDecodePacket(buf[:n])
+			if err != nil || req.Community != community {
+				continue
+			}
+
+			res := &gosnmp.SnmpPacket{
+				Version:   gosnmp.Version2c,
+				Community: community,
+				PDUType:   gosnmp.GetResponse,
+				RequestID: req.RequestID,
+				Variables: []gosnmp.SnmpPDU{snmpLookup(req.PDUType, req.Variables[0].Name)},
+			}
+
+			if b, err := res.MarshalMsg(); err == nil {
+				_, _ = conn.WriteTo(b, addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestSNMP(t *testing.T) {
+	uri := snmpAgent(t, "private")
+
+	get := func(oid string, walk bool) (string, error) {
+		p, err := NewSNMPFromConfig(context.TODO(), map[string]any{
+			"uri":       uri,
+			"community": "private",
+			"oid":       oid,
+			"walk":      walk,
+		})
+		require.NoError(t, err)
+
+		g, err := p.(StringProvider).StringGetter()
+		require.NoError(t, err)
+
+		return g()
+	}
+
+	s, err := get("1.3.6.1.2.1.33.1.2.4.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, "100", s)
+
+	s, err = get(".1.3.6.1.2.1.33.1.4.4.1.5.1", false)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", s)
+
+	_, err = get("1.3.6.1.2.1.33.1.2.5.0", false)
+	assert.ErrorIs(t, err, api.ErrNotAvailable)
+
+	s, err = get("1.3.6.1.2.1.33.1.4.4.1.4", true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"1.3.6.1.2.1.33.1.4.4.1.4.1":1200,"1.3.6.1.2.1.33.1.4.4.1.4.2":-300}`, s)
+}
+
+func TestSNMPConfig(t *testing.T) {
+	for _, cc := range []map[string]any{
+		{"oid": "1.3.6.1"},
+		{"uri": "localhost"},
+		{"uri": "localhost", "oid": "1.3.6.1", "version": "1"},
+		{"uri": "localhost", "oid": "1.3.6.1", "version": "3"},
+		{"uri": "localhost", "oid": "1.3.6.1", "version": "3", "user": "evcc", "authProtocol": "foo"},
+		{"uri": "localhost", "oid": "1.3.6.1", "version": "3", "user": "evcc", "authProtocol": "SHA", "privProtocol": "foo"},
+	} {
+		_, err := NewSNMPFromConfig(context.TODO(), cc)
+		assert.Error(t, err, cc)
+	}
+
+	_, err := NewSNMPFromConfig(context.TODO(), map[string]any{
+		"uri": "localhost", "oid": "1.3.6.1", "version": "3", "user": "evcc",
+		"authProtocol": "SHA", "authPassword": "authpassword", "privProtocol": "AES", "privPassword": "privpassword",
+	})
+	assert.NoError(t, err)
+}
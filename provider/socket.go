@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"sync"
 	"time"
 
@@ -11,32 +12,36 @@ import (
 	"github.com/evcc-io/evcc/provider/pipeline"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/supervisor"
 	"github.com/evcc-io/evcc/util/transport"
 )
 
-const retryDelay = 5 * time.Second
+const maxRetryDelay = 5 * time.Minute
 
 // Socket implements websocket request provider
 type Socket struct {
 	*getter
 	*request.Helper
-	log      *util.Logger
-	url      string
-	headers  map[string]string
-	pipeline *pipeline.Pipeline
-	val      *util.Monitor[[]byte]
+	log       *util.Logger
+	url       string
+	headers   map[string]string
+	subscribe string
+	client    *http.Client // dial client, nil for default
+	pipeline  *pipeline.Pipeline
+	val       *util.Monitor[[]byte]
 }
 
 func init() {
-	registry.Add("ws", NewSocketProviderFromConfig)
-	registry.Add("websocket", NewSocketProviderFromConfig)
+	registry.AddCtx("ws", NewSocketProviderFromConfig)
+	registry.AddCtx("websocket", NewSocketProviderFromConfig)
 }
 
-// NewSocketProviderFromConfig creates a HTTP provider
-func NewSocketProviderFromConfig(other map[string]interface{}) (Provider, error) {
+// NewSocketProviderFromConfig creates a HTTP provider. The connection is kept until ctx is done.
+func NewSocketProviderFromConfig(ctx context.Context, other map[string]interface{}) (Provider, error) {
 	cc := struct {
 		URI               string
 		Headers           map[string]string
+		Subscribe         string // message sent after connecting
 		pipeline.Settings `mapstructure:",squash"`
 		Scale             float64
 		Insecure          bool
@@ -59,11 +64,12 @@ func NewSocketProviderFromConfig(other map[string]interface{}) (Provider, error)
 	}
 
 	p := &Socket{
-		log:     log,
-		Helper:  request.NewHelper(log),
-		url:     url,
-		headers: cc.Headers,
-		val:     util.NewMonitor[[]byte](cc.Timeout),
+		log:       log,
+		Helper:    request.NewHelper(log),
+		url:       url,
+		headers:   cc.Headers,
+		subscribe: cc.Subscribe,
+		val:       util.NewMonitor[[]byte](cc.Timeout),
 	}
 
 	p.getter = defaultGetters(p, cc.Scale)
@@ -79,6 +85,7 @@ func NewSocketProviderFromConfig(other map[string]interface{}) (Provider, error)
 	// ignore the self signed certificate
 	if cc.Insecure {
 		p.Client.Transport = request.NewTripper(log, transport.Insecure())
+		p.client = &http.Client{Transport: transport.Insecure()}
 	}
 
 	var err error
@@ -87,7 +94,7 @@ func NewSocketProviderFromConfig(other map[string]interface{}) (Provider, error)
 	}
 
 	errC := make(chan error, 1)
-	go p.run(errC)
+	go p.run(ctx, errC)
 
 	if cc.Timeout > 0 {
 		select {
@@ -102,7 +109,7 @@ func NewSocketProviderFromConfig(other map[string]interface{}) (Provider, error)
 	return p, nil
 }
 
-func (p *Socket) run(errC chan error) {
+func (p *Socket) run(ctx context.Context, errC chan error) {
 	var once sync.Once
 
 	headers := make(http.Header)
@@ -111,29 +118,33 @@ func (p *Socket) run(errC chan error) {
 	}
 
 	opts := &websocket.DialOptions{
+		HTTPClient: p.client,
 		HTTPHeader: headers,
 	}
 
-	for {
-		ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
-		conn, _, err := websocket.Dial(ctx, p.url, opts)
-		cancel()
+	// name without credentials
+	name := p.url
+	if u, err := url.Parse(p.url); err == nil {
+		name = u.Host + u.Path
+	}
+
+	sv := supervisor.New(p.log, "ws "+name, supervisor.WithBackoff(500*time.Millisecond, maxRetryDelay))
 
+	sv.Run(ctx, func(connected func()) error {
+		conn, err := p.connect(opts)
 		if err != nil {
 			// handle initial connection error immediately
 			once.Do(func() { errC <- err })
-
-			p.log.ERROR.Println(err)
-			time.Sleep(retryDelay)
-			continue
+			return err
 		}
+		defer conn.Close(websocket.StatusAbnormalClosure, "done")
+
+		connected()
 
 		for {
-			_, b, err := conn.Read(context.Background())
+			_, b, err := conn.Read(ctx)
 			if err != nil {
-				p.log.TRACE.Println("read:", err)
-				_ = conn.Close(websocket.StatusAbnormalClosure, "done")
-				break
+				return err
 			}
 
 			p.log.TRACE.Printf("recv: %s", b)
@@ -142,7 +153,29 @@ func (p *Socket) run(errC chan error) {
 				p.val.Set(v)
 			}
 		}
+	})
+}
+
+// connect dials the websocket and sends the subscribe message
+func (p *Socket) connect(opts *websocket.DialOptions) (*websocket.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, p.url, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.subscribe != "" {
+		p.log.TRACE.Printf("send: %s", p.subscribe)
+
+		if err := conn.Write(ctx, websocket.MessageText, []byte(p.subscribe)); err != nil {
+			_ = conn.Close(websocket.StatusAbnormalClosure, "done")
+			return nil, err
+		}
 	}
+
+	return conn, nil
 }
 
 var _ Getters = (*Socket)(nil)
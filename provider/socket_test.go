@@ -40,7 +40,7 @@ func TestSocketProvider(t *testing.T) {
 	defer srv.Close()
 
 	addr := "ws://" + srv.Listener.Addr().String()
-	p, err := NewSocketProviderFromConfig(map[string]any{
+	p, err := NewSocketProviderFromConfig(context.TODO(), map[string]any{
 		"uri": addr,
 		"jq":  `.data | select(.uuid=="bar") .tuples[0][1]`,
 	})
@@ -55,3 +55,40 @@ func TestSocketProvider(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int64(1), i)
 }
+
+func TestSocketProviderSubscribe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := websocket.Accept(w, r, nil)
+		require.NoError(t, err)
+		defer c.Close(websocket.StatusNormalClosure, "")
+
+		// respond only after subscription
+		_, b, err := c.Read(ctx)
+		if err != nil || string(b) != `{"subscribe":"power"}` {
+			return
+		}
+
+		_ = c.Write(ctx, websocket.MessageText, []byte(`{"power":4200}`))
+		<-ctx.Done()
+	}))
+
+	defer srv.Close()
+
+	p, err := NewSocketProviderFromConfig(context.TODO(), map[string]any{
+		"uri":       "ws://" + srv.Listener.Addr().String(),
+		"subscribe": `{"subscribe":"power"}`,
+		"jq":        ".power",
+		"timeout":   "5s",
+	})
+	require.NoError(t, err)
+
+	g, err := p.(FloatProvider).FloatGetter()
+	require.NoError(t, err)
+
+	f, err := g()
+	require.NoError(t, err)
+	require.Equal(t, 4200.0, f)
+}
@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/godbus/dbus/v5"
+)
+
+const victronBusItem = "com.victronenergy.BusItem"
+
+// Victron implements Victron Venus OS D-Bus access
+type Victron struct {
+	*getter
+	log      *util.Logger
+	mu       sync.Mutex
+	bus      string
+	conn     *dbus.Conn
+	service  string
+	instance *int
+	name     string // resolved bus name
+	path     dbus.ObjectPath
+	def      *string
+	timeout  time.Duration
+}
+
+func init() {
+	registry.AddCtx("victron", NewVictronFromConfig)
+}
+
+var (
+	victronMu    sync.Mutex
+	victronConns = make(map[string]*dbus.Conn)
+)
+
+// victronConnection returns a shared connection to the system bus or a remote bus address like tcp:host=venus.local,port=78
+func victronConnection(bus string) (*dbus.Conn, error) {
+	victronMu.Lock()
+	defer victronMu.Unlock()
+
+	if conn, ok := victronConns[bus]; ok && conn.Connected() {
+		return conn, nil
+	}
+
+	var (
+		conn *dbus.Conn
+		err  error
+	)
+
+	switch bus {
+	case "", "system":
+		conn, err = dbus.ConnectSystemBus()
+	case "session":
+		conn, err = dbus.ConnectSessionBus()
+	default:
+		uid := strconv.Itoa(os.Geteuid())
+		home, _ := os.UserHomeDir()
+		conn, err = dbus.Connect(bus, dbus.WithAuth(dbus.AuthExternal(uid), dbus.AuthCookieSha1(uid, home), dbus.AuthAnonymous()))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("dbus: %w", err)
+	}
+
+	victronConns[bus] = conn
+
+	return conn, nil
+}
+
+// NewVictronFromConfig creates Victron D-Bus provider
+func NewVictronFromConfig(ctx context.Context, other map[string]interface{}) (Provider, error) {
+	cc := struct {
+		Bus      string
+		Service  string // bus name or prefix like com.victronenergy.grid
+		Instance *int   // device instance if service is a prefix
+		Path     string
+		Default  *string // value for invalid paths
+		Scale    float64
+		Timeout  time.Duration
+	}{
+		Scale:   1,
+		Timeout: 5 * time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Service == "" || cc.Path == "" {
+		return nil, errors.New("missing service or path")
+	}
+
+	if !strings.HasPrefix(cc.Path, "/") {
+		cc.Path = "/" + cc.Path
+	}
+
+	if !dbus.ObjectPath(cc.Path).IsValid() {
+		return nil, fmt.Errorf("invalid path: %s", cc.Path)
+	}
+
+	p := &Victron{
+		log:      contextLogger(ctx, util.NewLogger("victron")),
+		bus:      cc.Bus,
+		service:  cc.Service,
+		instance: cc.Instance,
+		path:     dbus.ObjectPath(cc.Path),
+		def:      cc.Default,
+		timeout:  cc.Timeout,
+	}
+
+	p.getter = defaultGetters(p, cc.Scale)
+
+	return p, nil
+}
+
+func (p *Victron) call(ctx context.Context, name string, path dbus.ObjectPath, method string, args ...any) *dbus.Call {
+	return p.conn.Object(name, path).CallWithContext(ctx, victronBusItem+"."+method, 0, args...)
+}
+
+// resolve finds the bus name matching service and device instance
+func (p *Victron) resolve(ctx context.Context) (string, error) {
+	var names []string
+	if err := p.conn.BusObject().CallWithContext(ctx, "org.freedesktop.DBus.ListNames", 0).Store(&names); err != nil {
+		return "", err
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		if name != p.service && !strings.HasPrefix(name, p.service+".") {
+			continue
+		}
+
+		if p.instance == nil {
+			return name, nil
+		}
+
+		var v dbus.Variant
+		if err := p.call(ctx, name, "/DeviceInstance", "GetValue").Store(&v); err != nil {
+			continue
+		}
+
+		if s, ok := victronValue(v); ok && s == strconv.Itoa(*p.instance) {
+			return name, nil
+		}
+	}
+
+	if p.instance != nil {
+		return "", fmt.Errorf("service not found: %s (instance %d)", p.service, *p.instance)
+	}
+
+	return "", fmt.Errorf("service not found: %s", p.service)
+}
+
+// do executes the bus item method, connecting and resolving the service name if required
+func (p *Victron) do(method string, args []any, res ...any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	if p.conn == nil || !p.conn.Connected() {
+		conn, err := victronConnection(p.bus)
+		if err != nil {
+			return err
+		}
+
+		p.conn = conn
+		p.name = ""
+	}
+
+	if p.name == "" {
+		name, err := p.resolve(ctx)
+		if err != nil {
+			return err
+		}
+
+		p.name = name
+	}
+
+	err := p.call(ctx, p.name, p.path, method, args...).Store(res...)
+	if err != nil {
+		var dbusErr dbus.Error
+		if errors.As(err, &dbusErr) && dbusErr.Name == "org.freedesktop.DBus.Error.ServiceUnknown" {
+			// service restarted with different name
+			p.name = ""
+		}
+	}
+
+	return err
+}
+
+// victronValue converts the bus item value to string. Invalid values are represented by empty arrays.
+func victronValue(v dbus.Variant) (string, bool) {
+	switch val := v.Value().(type) {
+	case string:
+		return val, true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(val), true
+	case byte, int16, uint16, int32, uint32, int64, uint64:
+		return fmt.Sprint(val), true
+	default:
+		return "", false
+	}
+}
+
+var _ Getters = (*Victron)(nil)
+
+// StringGetter reads the bus item value
+func (p *Victron) StringGetter() (func() (string, error), error) {
+	return func() (string, error) {
+		var v dbus.Variant
+		if err := p.do("GetValue", nil, &v); err != nil {
+			return "", err
+		}
+
+		p.log.TRACE.Printf("get %s%s: %v", p.name, p.path, v)
+
+		s, ok := victronValue(v)
+		if !ok {
+			if p.def != nil {
+				return *p.def, nil
+			}
+			return "", api.ErrNotAvailable
+		}
+
+		return s, nil
+	}, nil
+}
+
+func (p *Victron) set(val any) error {
+	p.log.TRACE.Printf("set %s%s: %v", p.name, p.path, val)
+
+	var res int32
+	if err := p.do("SetValue", []any{dbus.MakeVariant(val)}, &res); err != nil {
+		return err
+	}
+
+	if res != 0 {
+		return fmt.Errorf("set %s: error %d", p.path, res)
+	}
+
+	return nil
+}
+
+var _ SetIntProvider = (*Victron)(nil)
+
+// IntSetter writes the bus item value
+func (p *Victron) IntSetter(_ string) (func(int64) error, error) {
+	return func(val int64) error {
+		return p.set(int32(val))
+	}, nil
+}
+
+var _ SetFloatProvider = (*Victron)(nil)
+
+// FloatSetter writes the bus item value
+func (p *Victron) FloatSetter(_ string) (func(float64) error, error) {
+	return func(val float64) error {
+		return p.set(val)
+	}, nil
+}
+
+var _ SetBoolProvider = (*Victron)(nil)
+
+// BoolSetter writes the bus item value as 0/1
+func (p *Victron) BoolSetter(_ string) (func(bool) error, error) {
+	return func(val bool) error {
+		var i int32
+		if val {
+			i = 1
+		}
+		return p.set(i)
+	}, nil
+}
+
+var _ SetStringProvider = (*Victron)(nil)
+
+// StringSetter writes the bus item value
+func (p *Victron) StringSetter(_ string) (func(string) error, error) {
+	return func(val string) error {
+		return p.set(val)
+	}, nil
+}
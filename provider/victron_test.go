@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVictronValue(t *testing.T) {
+	for _, tc := range []struct {
+		in    any
+		out   string
+		valid bool
+	}{
+		{float64(1.5), "1.5", true},
+		{int32(-42), "-42", true},
+		{uint32(7), "7", true},
+		{"foo", "foo", true},
+		{[]int32{}, "", false},
+	} {
+		s, ok := victronValue(dbus.MakeVariant(tc.in))
+		assert.Equal(t, tc.out, s)
+		assert.Equal(t, tc.valid, ok)
+	}
+}
@@ -1,10 +1,13 @@
 package push
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/evcc-io/evcc/util"
 	reg "github.com/evcc-io/evcc/util/registry"
 )
 
@@ -13,6 +16,11 @@ type Messenger interface {
 	Send(title, msg string)
 }
 
+// EventMessenger receives the event and its attributes in addition to the rendered message
+type EventMessenger interface {
+	SendEvent(event, title, msg string, attr map[string]any)
+}
+
 var registry = reg.New[Messenger]("messenger")
 
 // NewFromConfig creates messenger from configuration
@@ -29,3 +37,67 @@ func NewFromConfig(ctx context.Context, typ string, other map[string]interface{}
 
 	return v, err
 }
+
+// Service is a messenger with its event selection and template overrides
+type Service struct {
+	Messenger
+	events    []string
+	templates map[string]EventTemplateConfig
+}
+
+// NewServiceFromConfig creates messenger from configuration, separating the per-messenger event configuration
+func NewServiceFromConfig(ctx context.Context, typ string, other map[string]interface{}) (*Service, error) {
+	var cc struct {
+		Events    []string                       // events to send, empty for all
+		Templates map[string]EventTemplateConfig // templates overriding the global event templates
+		Other     map[string]any                 `mapstructure:",remain"`
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if err := validateTemplates(cc.Templates); err != nil {
+		return nil, err
+	}
+
+	m, err := NewFromConfig(ctx, typ, cc.Other)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		Messenger: m,
+		events:    cc.Events,
+		templates: cc.Templates,
+	}, nil
+}
+
+// Accepts returns true if the service is subscribed to the event
+func (s *Service) Accepts(event string) bool {
+	return len(s.events) == 0 || slices.Contains(s.events, event)
+}
+
+// template returns the event template with the service's overrides and localized defaults applied
+func (s *Service) template(event string, definitions, defaults map[string]EventTemplateConfig) (EventTemplateConfig, bool) {
+	res, ok := definitions[event]
+
+	if t, found := s.templates[event]; found {
+		if t.Title != "" {
+			res.Title = t.Title
+		}
+		if t.Msg != "" {
+			res.Msg = t.Msg
+		}
+		ok = true
+	}
+
+	// localized defaults for configured or explicitly subscribed events
+	if d, found := defaults[event]; found && (ok || slices.Contains(s.events, event)) {
+		res.Title = cmp.Or(res.Title, d.Title)
+		res.Msg = cmp.Or(res.Msg, d.Msg)
+		ok = true
+	}
+
+	return res, ok
+}
@@ -0,0 +1,155 @@
+package push
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+func init() {
+	registry.Add("discord", NewDiscordFromConfig)
+}
+
+// embed colors
+const (
+	discordGreen = 0x0fdd42
+	discordRed   = 0xfc440f
+	discordBlue  = 0x3b82f6
+)
+
+// Discord implements the Discord webhook messenger
+type Discord struct {
+	*request.Helper
+	log      *util.Logger
+	uri      string
+	username string
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color,omitempty"`
+	Fields      []discordField `json:"fields,omitempty"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+}
+
+// NewDiscordFromConfig creates new Discord messenger
+func NewDiscordFromConfig(other map[string]interface{}) (Messenger, error) {
+	var cc struct {
+		URI      string // webhook url
+		Username string // optional sender name
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	log := util.NewLogger("discord").Redact(cc.URI)
+
+	m := &Discord{
+		Helper:   request.NewHelper(log),
+		log:      log,
+		uri:      cc.URI,
+		username: cc.Username,
+	}
+
+	return m, nil
+}
+
+// floatAttr returns the numeric attribute value if available
+func floatAttr(attr map[string]any, key string) (float64, bool) {
+	switch v := attr[key].(type) {
+	case float64:
+		return v, true
+	case *float64:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return 0, false
+}
+
+// sessionFields returns the session summary fields
+func sessionFields(attr map[string]any) []discordField {
+	var res []discordField
+
+	if wh, ok := floatAttr(attr, "sessionEnergy"); ok && wh > 0 {
+		res = append(res, discordField{Name: "Energy", Value: fmt.Sprintf("%.1f kWh", wh/1e3), Inline: true})
+	}
+
+	if price, ok := floatAttr(attr, "sessionPrice"); ok {
+		var currency string
+		if c := attr["currency"]; c != nil {
+			currency = fmt.Sprint(c)
+		}
+		res = append(res, discordField{Name: "Cost", Value: strings.TrimSpace(fmt.Sprintf("%.2f %s", price, currency)), Inline: true})
+	}
+
+	if solar, ok := floatAttr(attr, "sessionSolarPercentage"); ok {
+		res = append(res, discordField{Name: "Solar", Value: fmt.Sprintf("%.0f%%", solar), Inline: true})
+	}
+
+	return res
+}
+
+// Send sends to the webhook
+func (m *Discord) Send(title, msg string) {
+	m.send(discordEmbed{Title: title, Description: msg, Color: discordBlue})
+}
+
+// SendEvent sends to the webhook, adding a session summary on charge completion
+func (m *Discord) SendEvent(event, title, msg string, attr map[string]any) {
+	embed := discordEmbed{
+		Title:       title,
+		Description: msg,
+		Color:       discordBlue,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+
+	switch event {
+	case "start":
+		embed.Color = discordGreen
+	case "stop":
+		embed.Fields = sessionFields(attr)
+	case "error":
+		embed.Color = discordRed
+	}
+
+	m.send(embed)
+}
+
+func (m *Discord) send(embed discordEmbed) {
+	data := struct {
+		Username string         `json:"username,omitempty"`
+		Embeds   []discordEmbed `json:"embeds"`
+	}{
+		Username: m.username,
+		Embeds:   []discordEmbed{embed},
+	}
+
+	req, err := request.New(http.MethodPost, m.uri, request.MarshalJSON(data), request.JSONEncoding)
+	if err == nil {
+		_, err = m.DoBody(req)
+	}
+
+	if err != nil {
+		m.log.ERROR.Println("send:", err)
+	}
+}
+
+var _ EventMessenger = (*Discord)(nil)
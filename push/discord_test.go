@@ -0,0 +1,46 @@
+package push
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/currency"
+)
+
+func TestDiscordSession(t *testing.T) {
+	var body struct {
+		Embeds []discordEmbed
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	m, err := NewDiscordFromConfig(map[string]any{"uri": srv.URL})
+	require.NoError(t, err)
+
+	m.(EventMessenger).SendEvent("stop", "Charge finished", "Finished charging", map[string]any{
+		"sessionEnergy":          12345.0,
+		"sessionPrice":           lo.ToPtr(3.456),
+		"sessionSolarPercentage": 62.5,
+		"currency":               currency.EUR,
+	})
+
+	require.Len(t, body.Embeds, 1)
+	assert.Equal(t, "Charge finished", body.Embeds[0].Title)
+	assert.Equal(t, []discordField{
+		{Name: "Energy", Value: "12.3 kWh", Inline: true},
+		{Name: "Cost", Value: "3.46 EUR", Inline: true},
+		{Name: "Solar", Value: "62%", Inline: true},
+	}, body.Embeds[0].Fields)
+
+	// no price available
+	assert.Len(t, sessionFields(map[string]any{"sessionEnergy": 1000.0, "sessionPrice": (*float64)(nil)}), 1)
+}
@@ -4,16 +4,20 @@ import (
 	"fmt"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
 )
 
 // Event is a notification event
 type Event struct {
 	Loadpoint *int // optional loadpoint id
 	Event     string
+	Error     string // optional error message
+	Device    string // optional device name
 }
 
 // EventTemplateConfig is the push message configuration for an event
@@ -29,23 +33,34 @@ type Vehicles interface {
 // Hub subscribes to event notifications and sends them to client devices
 type Hub struct {
 	definitions map[string]EventTemplateConfig
-	sender      []Messenger
+	defaults    map[string]EventTemplateConfig // localized templates
+	services    []*Service
+	webhooks    []*Webhook
 	cache       *util.Cache
 	vehicles    Vehicles
 }
 
-// NewHub creates push hub with definitions and receiver
-func NewHub(cc map[string]EventTemplateConfig, vv Vehicles, cache *util.Cache) (*Hub, error) {
-	// instantiate all event templates
+// validateTemplates parses all event templates
+func validateTemplates(cc map[string]EventTemplateConfig) error {
 	for k, v := range cc {
 		if _, err := template.New("out").Funcs(sprig.FuncMap()).Parse(v.Title); err != nil {
-			return nil, fmt.Errorf("invalid event title: %s (%w)", k, err)
+			return fmt.Errorf("invalid event title: %s (%w)", k, err)
 		}
 		if _, err := template.New("out").Funcs(sprig.FuncMap()).Parse(v.Msg); err != nil {
-			return nil, fmt.Errorf("invalid event message: %s (%w)", k, err)
+			return fmt.Errorf("invalid event message: %s (%w)", k, err)
 		}
 	}
 
+	return nil
+}
+
+// NewHub creates push hub with definitions and receiver
+func NewHub(cc map[string]EventTemplateConfig, vv Vehicles, cache *util.Cache) (*Hub, error) {
+	// instantiate all event templates
+	if err := validateTemplates(cc); err != nil {
+		return nil, err
+	}
+
 	h := &Hub{
 		definitions: cc,
 		cache:       cache,
@@ -55,13 +70,28 @@ func NewHub(cc map[string]EventTemplateConfig, vv Vehicles, cache *util.Cache) (
 	return h, nil
 }
 
-// Add adds a sender to the list of senders
+// SetDefaults sets the localized templates used for events without configured title or message
+func (h *Hub) SetDefaults(defaults map[string]EventTemplateConfig) {
+	h.defaults = defaults
+}
+
+// Add adds a sender receiving all events to the list of services
 func (h *Hub) Add(sender Messenger) {
-	h.sender = append(h.sender, sender)
+	h.AddService(&Service{Messenger: sender})
+}
+
+// AddService adds a service to the list of services
+func (h *Hub) AddService(s *Service) {
+	h.services = append(h.services, s)
+}
+
+// AddWebhook adds a webhook to the list of webhooks
+func (h *Hub) AddWebhook(wh *Webhook) {
+	h.webhooks = append(h.webhooks, wh)
 }
 
-// apply applies the event template to the content to produce the actual message
-func (h *Hub) apply(ev Event, tmpl string) (string, error) {
+// attributes returns the event's attributes used for templates and webhook payloads
+func (h *Hub) attributes(ev Event) map[string]interface{} {
 	attr := make(map[string]interface{})
 
 	// loadpoint id
@@ -69,6 +99,14 @@ func (h *Hub) apply(ev Event, tmpl string) (string, error) {
 		attr["loadpoint"] = *ev.Loadpoint + 1
 	}
 
+	if ev.Error != "" {
+		attr["error"] = ev.Error
+	}
+
+	if ev.Device != "" {
+		attr["device"] = ev.Device
+	}
+
 	// get all values from cache
 	for _, p := range h.cache.All() {
 		if p.Loadpoint == nil || ev.Loadpoint == p.Loadpoint {
@@ -90,7 +128,30 @@ func (h *Hub) apply(ev Event, tmpl string) (string, error) {
 		}
 	}
 
-	return util.ReplaceFormatted(tmpl, attr)
+	return attr
+}
+
+type message struct {
+	title, msg string
+}
+
+// render executes the event template, returning nil for empty messages
+func render(definition EventTemplateConfig, attr map[string]interface{}) (*message, error) {
+	title, err := util.ReplaceFormatted(definition.Title, attr)
+	if err != nil {
+		return nil, fmt.Errorf("title: %w", err)
+	}
+
+	msg, err := util.ReplaceFormatted(definition.Msg, attr)
+	if err != nil {
+		return nil, fmt.Errorf("message: %w", err)
+	}
+
+	if strings.TrimSpace(msg) == "" {
+		return nil, nil
+	}
+
+	return &message{title: title, msg: msg}, nil
 }
 
 // Run is the Hub's main publishing loop
@@ -98,12 +159,29 @@ func (h *Hub) Run(events <-chan Event, valueChan chan<- util.Param) {
 	log := util.NewLogger("push")
 
 	for ev := range events {
-		if len(h.sender) == 0 {
-			continue
+		type notification struct {
+			*Service
+			definition EventTemplateConfig
 		}
 
-		definition, ok := h.definitions[ev.Event]
-		if !ok {
+		var notifications []notification
+		for _, s := range h.services {
+			if !s.Accepts(ev.Event) {
+				continue
+			}
+			if definition, ok := s.template(ev.Event, h.definitions, h.defaults); ok {
+				notifications = append(notifications, notification{s, definition})
+			}
+		}
+
+		var webhooks []*Webhook
+		for _, wh := range h.webhooks {
+			if wh.Accepts(ev.Event) {
+				webhooks = append(webhooks, wh)
+			}
+		}
+
+		if len(notifications) == 0 && len(webhooks) == 0 {
 			continue
 		}
 
@@ -112,24 +190,46 @@ func (h *Hub) Run(events <-chan Event, valueChan chan<- util.Param) {
 		valueChan <- util.Param{Val: flushC}
 		<-flushC
 
-		title, err := h.apply(ev, definition.Title)
-		if err != nil {
-			log.ERROR.Printf("invalid title template for %s: %v", ev.Event, err)
-			continue
-		}
+		attr := h.attributes(ev)
 
-		msg, err := h.apply(ev, definition.Msg)
-		if err != nil {
-			log.ERROR.Printf("invalid message template for %s: %v", ev.Event, err)
-			continue
-		}
+		if len(webhooks) > 0 {
+			payload := WebhookPayload{
+				Event:     ev.Event,
+				Timestamp: time.Now(),
+				Data:      attr,
+			}
 
-		if strings.TrimSpace(msg) == "" {
-			continue
+			if ev.Loadpoint != nil {
+				payload.Loadpoint = lo.ToPtr(*ev.Loadpoint + 1)
+			}
+
+			for _, wh := range webhooks {
+				go wh.Send(payload)
+			}
 		}
 
-		for _, sender := range h.sender {
-			go sender.Send(title, msg)
+		// render each distinct template only once
+		rendered := make(map[EventTemplateConfig]*message)
+
+		for _, n := range notifications {
+			m, found := rendered[n.definition]
+			if !found {
+				var err error
+				if m, err = render(n.definition, attr); err != nil {
+					log.ERROR.Printf("invalid template for %s: %v", ev.Event, err)
+				}
+				rendered[n.definition] = m
+			}
+
+			if m == nil {
+				continue
+			}
+
+			if em, ok := n.Messenger.(EventMessenger); ok {
+				go em.SendEvent(ev.Event, m.title, m.msg, attr)
+			} else {
+				go n.Send(m.title, m.msg)
+			}
 		}
 	}
 }
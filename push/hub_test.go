@@ -0,0 +1,89 @@
+package push
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu  sync.Mutex
+	msg []string
+}
+
+func (r *recorder) Send(title, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.msg = append(r.msg, title+": "+msg)
+}
+
+func (r *recorder) messages() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.msg
+}
+
+func TestHubServices(t *testing.T) {
+	cache := util.NewCache()
+	cache.Add("mode", util.Param{Key: "mode", Val: "pv"})
+
+	hub, err := NewHub(map[string]EventTemplateConfig{
+		"start": {Title: "Start", Msg: "Charging in ${mode} mode"},
+		"stop":  {Title: "Stop", Msg: "Finished"},
+	}, nil, cache)
+	require.NoError(t, err)
+
+	all := new(recorder)
+	hub.Add(all)
+
+	filtered := new(recorder)
+	hub.AddService(&Service{
+		Messenger: filtered,
+		events:    []string{"start", "connect"},
+		templates: map[string]EventTemplateConfig{
+			"start":   {Msg: "Mode: ${mode}"},
+			"connect": {Title: "Connect", Msg: "Connected"},
+		},
+	})
+
+	events := make(chan Event)
+	valueChan := make(chan util.Param)
+
+	go cache.Run(valueChan)
+	go hub.Run(events, valueChan)
+
+	for _, ev := range []string{"start", "stop", "connect"} {
+		events <- Event{Event: ev}
+	}
+
+	require.Eventually(t, func() bool {
+		return len(all.messages()) == 2 && len(filtered.messages()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	assert.ElementsMatch(t, []string{"Start: Charging in pv mode", "Stop: Finished"}, all.messages())
+	assert.ElementsMatch(t, []string{"Start: Mode: pv", "Connect: Connected"}, filtered.messages())
+}
+
+func TestServiceConfig(t *testing.T) {
+	_, err := NewServiceFromConfig(context.TODO(), "ntfy", map[string]any{
+		"uri":    "https://ntfy.example.com/evcc",
+		"events": []string{"start"},
+		"templates": map[string]any{
+			"start": map[string]any{"msg": "{{ .mode"},
+		},
+	})
+	require.Error(t, err)
+
+	s, err := NewServiceFromConfig(context.TODO(), "ntfy", map[string]any{
+		"uri":    "https://ntfy.example.com/evcc",
+		"events": []string{"start"},
+	})
+	require.NoError(t, err)
+	assert.True(t, s.Accepts("start"))
+	assert.False(t, s.Accepts("stop"))
+}
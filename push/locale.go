@@ -0,0 +1,29 @@
+package push
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// events are the events with localized default templates
+var events = []string{"start", "stop", "connect", "disconnect", "soc", "guest", "planstart", "error", "gridlimit", "offline", "online"}
+
+// LocalizedTemplates returns the default event templates translated by the localizer
+func LocalizedTemplates(localizer *i18n.Localizer) map[string]EventTemplateConfig {
+	res := make(map[string]EventTemplateConfig)
+
+	for _, ev := range events {
+		title, err := localizer.Localize(&i18n.LocalizeConfig{MessageID: "push." + ev + ".title"})
+		if err != nil {
+			continue
+		}
+
+		msg, err := localizer.Localize(&i18n.LocalizeConfig{MessageID: "push." + ev + ".msg"})
+		if err != nil {
+			continue
+		}
+
+		res[ev] = EventTemplateConfig{Title: title, Msg: msg}
+	}
+
+	return res
+}
@@ -0,0 +1,57 @@
+package push
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestLocalizedTemplates(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.RegisterUnmarshalFunc("toml", toml.Unmarshal)
+
+	for _, f := range []string{"../i18n/en.toml", "../i18n/de.toml"} {
+		_, err := bundle.LoadMessageFile(f)
+		require.NoError(t, err)
+	}
+
+	en := LocalizedTemplates(i18n.NewLocalizer(bundle, "en"))
+	de := LocalizedTemplates(i18n.NewLocalizer(bundle, "de"))
+	fr := LocalizedTemplates(i18n.NewLocalizer(bundle, "fr"))
+
+	for _, ev := range events {
+		assert.Contains(t, en, ev)
+		assert.Contains(t, de, ev)
+	}
+	assert.NoError(t, validateTemplates(de))
+
+	assert.Equal(t, "Ladevorgang gestartet", de["start"].Title)
+	assert.Equal(t, en, fr, "english fallback")
+}
+
+func TestServiceTemplateDefaults(t *testing.T) {
+	defaults := map[string]EventTemplateConfig{
+		"start": {Title: "Ladevorgang gestartet", Msg: "Laden gestartet"},
+		"stop":  {Title: "Ladevorgang beendet", Msg: "Laden beendet"},
+	}
+
+	s := &Service{events: []string{"stop"}}
+
+	// configured event with title override
+	res, ok := s.template("start", map[string]EventTemplateConfig{"start": {Title: "Start"}}, defaults)
+	assert.True(t, ok)
+	assert.Equal(t, EventTemplateConfig{Title: "Start", Msg: "Laden gestartet"}, res)
+
+	// neither configured nor subscribed
+	_, ok = s.template("start", nil, defaults)
+	assert.False(t, ok)
+
+	// explicitly subscribed
+	res, ok = s.template("stop", nil, defaults)
+	assert.True(t, ok)
+	assert.Equal(t, defaults["stop"], res)
+}
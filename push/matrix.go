@@ -0,0 +1,119 @@
+package push
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/transport"
+)
+
+func init() {
+	registry.Add("matrix", NewMatrixFromConfig)
+}
+
+// Matrix implements the Matrix messenger. For encrypted rooms, the homeserver uri can point to a pantalaimon proxy.
+type Matrix struct {
+	*request.Helper
+	log  *util.Logger
+	mu   sync.Mutex
+	uri  string
+	room string
+}
+
+// NewMatrixFromConfig creates new Matrix messenger
+func NewMatrixFromConfig(other map[string]interface{}) (Messenger, error) {
+	var cc struct {
+		URI   string // homeserver
+		Token string // access token
+		Room  string // room id or alias
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" || cc.Token == "" || cc.Room == "" {
+		return nil, errors.New("missing uri, token or room")
+	}
+
+	log := util.NewLogger("matrix").Redact(cc.Token)
+
+	m := &Matrix{
+		Helper: request.NewHelper(log),
+		log:    log,
+		uri:    strings.TrimSuffix(util.DefaultScheme(cc.URI, "https"), "/") + "/_matrix/client/v3",
+		room:   cc.Room,
+	}
+
+	m.Client.Transport = transport.BearerAuth(cc.Token, m.Client.Transport)
+
+	return m, nil
+}
+
+// roomID resolves room aliases
+func (m *Matrix) roomID() (string, error) {
+	if !strings.HasPrefix(m.room, "#") {
+		return m.room, nil
+	}
+
+	var res struct {
+		RoomID string `json:"room_id"`
+	}
+
+	if err := m.GetJSON(fmt.Sprintf("%s/directory/room/%s", m.uri, url.PathEscape(m.room)), &res); err != nil {
+		return "", fmt.Errorf("room alias: %w", err)
+	}
+
+	m.room = res.RoomID
+
+	return m.room, nil
+}
+
+// Send sends to the room
+func (m *Matrix) Send(title, msg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	room, err := m.roomID()
+	if err != nil {
+		m.log.ERROR.Println(err)
+		return
+	}
+
+	body, formatted := msg, html.EscapeString(msg)
+	if title != "" {
+		body = title + "\n" + msg
+		formatted = "<b>" + html.EscapeString(title) + "</b><br>" + formatted
+	}
+
+	data := struct {
+		MsgType       string `json:"msgtype"`
+		Body          string `json:"body"`
+		Format        string `json:"format"`
+		FormattedBody string `json:"formatted_body"`
+	}{
+		MsgType:       "m.text",
+		Body:          body,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: strings.ReplaceAll(formatted, "\n", "<br>"),
+	}
+
+	uri := fmt.Sprintf("%s/rooms/%s/send/m.room.message/evcc-%d", m.uri, url.PathEscape(room), time.Now().UnixNano())
+
+	req, err := request.New(http.MethodPut, uri, request.MarshalJSON(data), request.JSONEncoding)
+	if err == nil {
+		_, err = m.DoBody(req)
+	}
+
+	if err != nil {
+		m.log.ERROR.Println("send:", err)
+	}
+}
@@ -0,0 +1,52 @@
+package push
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrix(t *testing.T) {
+	var body map[string]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/_matrix/client/v3/directory/room/%23evcc:example.org":
+			_, _ = w.Write([]byte(`{"room_id":"!abc:example.org"}`))
+
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/rooms/!abc:example.org/send/m.room.message/"):
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			_, _ = w.Write([]byte(`{"event_id":"$1"}`))
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	m, err := NewMatrixFromConfig(map[string]any{
+		"uri":   srv.URL,
+		"token": "token",
+		"room":  "#evcc:example.org",
+	})
+	require.NoError(t, err)
+
+	m.Send("Charge started", "Started <charging>")
+
+	assert.Equal(t, map[string]string{
+		"msgtype":        "m.text",
+		"body":           "Charge started\nStarted <charging>",
+		"format":         "org.matrix.custom.html",
+		"formatted_body": "<b>Charge started</b><br>Started &lt;charging&gt;",
+	}, body)
+}
@@ -0,0 +1,104 @@
+package push
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/evcc-io/evcc/provider/mqtt"
+	"github.com/evcc-io/evcc/util"
+)
+
+func init() {
+	registry.Add("mqtt", NewMqttFromConfig)
+}
+
+// MqttPayload is the json payload published for an event
+type MqttPayload struct {
+	Event     string         `json:"event"`
+	Loadpoint *int           `json:"loadpoint,omitempty"` // 1-based loadpoint id
+	Title     string         `json:"title"`
+	Msg       string         `json:"msg"`
+	Timestamp time.Time      `json:"timestamp"`
+	Data      map[string]any `json:"data"`
+}
+
+// Mqtt implements the MQTT messenger
+type Mqtt struct {
+	log      *util.Logger
+	client   *mqtt.Client
+	topic    string
+	retained bool
+}
+
+// NewMqttFromConfig creates new MQTT messenger
+func NewMqttFromConfig(other map[string]interface{}) (Messenger, error) {
+	cc := struct {
+		mqtt.Config `mapstructure:",squash"`
+		Topic       string // supports event attributes like ${event} and ${loadpoint}
+		Retained    bool
+	}{
+		Topic: "evcc/events/${event}",
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	log := util.NewLogger("mqtt")
+
+	client, err := mqtt.RegisteredClientOrDefault(log, cc.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Mqtt{
+		log:      log,
+		client:   client,
+		topic:    cc.Topic,
+		retained: cc.Retained,
+	}
+
+	return m, nil
+}
+
+// Send publishes the message without event details
+func (m *Mqtt) Send(title, msg string) {
+	m.SendEvent("", title, msg, nil)
+}
+
+// SendEvent publishes the event to the templated topic
+func (m *Mqtt) SendEvent(event, title, msg string, attr map[string]any) {
+	vars := map[string]any{"event": event}
+	for k, v := range attr {
+		vars[k] = v
+	}
+
+	topic, err := util.ReplaceFormatted(m.topic, vars)
+	if err != nil {
+		m.log.ERROR.Printf("invalid topic template: %v", err)
+		return
+	}
+
+	payload := MqttPayload{
+		Event:     event,
+		Title:     title,
+		Msg:       msg,
+		Timestamp: time.Now(),
+		Data:      attr,
+	}
+
+	if lp, ok := attr["loadpoint"].(int); ok {
+		payload.Loadpoint = &lp
+	}
+
+	b, err := json.Marshal(payload)
+	if err == nil {
+		err = m.client.Publish(topic, m.retained, string(b))
+	}
+
+	if err != nil {
+		m.log.ERROR.Println("send:", err)
+	}
+}
+
+var _ EventMessenger = (*Mqtt)(nil)
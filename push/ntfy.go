@@ -2,31 +2,81 @@ package push
 
 import (
 	"errors"
+	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/transport"
 )
 
 func init() {
 	registry.Add("ntfy", NewNtfyFromConfig)
 }
 
+// NtfyAction is a ntfy action button
+type NtfyAction struct {
+	Action  string // view or http
+	Label   string
+	URL     string
+	Method  string            // http only
+	Headers map[string]string // http only
+	Body    string            // http only
+	Clear   bool              // clear notification after action
+}
+
+// header returns the action in ntfy's short header format
+func (a NtfyAction) header() string {
+	quote := func(s string) string {
+		if strings.ContainsAny(s, ",;=\"") {
+			return `"` + strings.ReplaceAll(s, `"`, `'`) + `"`
+		}
+		return s
+	}
+
+	parts := []string{a.Action, quote(a.Label), quote(a.URL)}
+
+	if a.Method != "" {
+		parts = append(parts, "method="+quote(a.Method))
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(a.Headers)) {
+		parts = append(parts, "headers."+k+"="+quote(a.Headers[k]))
+	}
+
+	if a.Body != "" {
+		parts = append(parts, "body="+quote(a.Body))
+	}
+
+	if a.Clear {
+		parts = append(parts, "clear=true")
+	}
+
+	return strings.Join(parts, ", ")
+}
+
 // Ntfy implements the ntfy messaging aggregator
 type Ntfy struct {
+	*request.Helper
 	log      *util.Logger
 	uri      string
 	priority string
 	tags     string
+	actions  string
 }
 
 // NewNtfyFromConfig creates new Ntfy messenger
 func NewNtfyFromConfig(other map[string]interface{}) (Messenger, error) {
 	var cc struct {
 		URI      string
+		Topic    string // optional, appended to uri
+		Token    string // optional access token
 		Priority string
 		Tags     string
+		Actions  []NtfyAction
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -37,16 +87,46 @@ func NewNtfyFromConfig(other map[string]interface{}) (Messenger, error) {
 		return nil, errors.New("missing uri")
 	}
 
-	log := util.NewLogger("ntfy")
-	if token, ok := strings.CutPrefix(cc.URI, "https://ntfy.sh/"); ok {
+	if len(cc.Actions) > 3 {
+		return nil, errors.New("max. 3 actions supported")
+	}
+
+	uri := strings.TrimSuffix(cc.URI, "/")
+	if cc.Topic != "" {
+		uri += "/" + cc.Topic
+	}
+
+	log := util.NewLogger("ntfy").Redact(cc.Token)
+	if token, ok := strings.CutPrefix(uri, "https://ntfy.sh/"); ok {
 		log = log.Redact(token)
 	}
 
+	actions := make([]string, 0, len(cc.Actions))
+	for i, a := range cc.Actions {
+		switch a.Action {
+		case "view", "http":
+		default:
+			return nil, fmt.Errorf("action %d: invalid type: %s", i+1, a.Action)
+		}
+
+		if a.Label == "" || a.URL == "" {
+			return nil, fmt.Errorf("action %d: missing label or url", i+1)
+		}
+
+		actions = append(actions, a.header())
+	}
+
 	m := &Ntfy{
+		Helper:   request.NewHelper(log),
 		log:      log,
-		uri:      cc.URI,
+		uri:      uri,
 		priority: cc.Priority,
 		tags:     cc.Tags,
+		actions:  strings.Join(actions, "; "),
+	}
+
+	if cc.Token != "" {
+		m.Client.Transport = transport.BearerAuth(cc.Token, m.Client.Transport)
 	}
 
 	return m, nil
@@ -54,16 +134,22 @@ func NewNtfyFromConfig(other map[string]interface{}) (Messenger, error) {
 
 // Send sends to all receivers
 func (m *Ntfy) Send(title, msg string) {
-	req, err := request.New("POST", m.uri, strings.NewReader(msg), map[string]string{
+	headers := map[string]string{
 		"Priority": m.priority,
 		"Title":    title,
 		"Tags":     m.tags,
-	})
-	if err != nil {
-		m.log.ERROR.Printf("ntfy: %v", err)
 	}
 
-	if _, err := http.DefaultClient.Do(req); err != nil {
+	if m.actions != "" {
+		headers["Actions"] = m.actions
+	}
+
+	req, err := request.New(http.MethodPost, m.uri, strings.NewReader(msg), headers)
+	if err == nil {
+		_, err = m.DoBody(req)
+	}
+
+	if err != nil {
 		m.log.ERROR.Printf("ntfy: %v", err)
 	}
 }
@@ -0,0 +1,52 @@
+package push
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNtfy(t *testing.T) {
+	var (
+		header http.Header
+		body   string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/evcc", r.URL.Path)
+		header = r.Header
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer srv.Close()
+
+	m, err := NewNtfyFromConfig(map[string]any{
+		"uri":      srv.URL,
+		"topic":    "evcc",
+		"token":    "secret",
+		"priority": "high",
+		"actions": []map[string]any{
+			{"action": "view", "label": "Open evcc", "url": "http://evcc.local:7070"},
+			{"action": "http", "label": "Stop charging", "url": "http://evcc.local:7070/api/loadpoints/1/mode/off", "method": "POST", "headers": map[string]string{"Authorization": "Bearer a,b"}, "clear": true},
+		},
+	})
+	require.NoError(t, err)
+
+	m.Send("Charge started", "Started charging")
+
+	assert.Equal(t, "Started charging", body)
+	assert.Equal(t, "Charge started", header.Get("Title"))
+	assert.Equal(t, "high", header.Get("Priority"))
+	assert.Equal(t, "Bearer secret", header.Get("Authorization"))
+	assert.Equal(t, `view, Open evcc, http://evcc.local:7070; http, Stop charging, http://evcc.local:7070/api/loadpoints/1/mode/off, method=POST, headers.Authorization="Bearer a,b", clear=true`, header.Get("Actions"))
+
+	_, err = NewNtfyFromConfig(map[string]any{
+		"uri":     srv.URL,
+		"actions": []map[string]any{{"action": "foo", "label": "bar", "url": "http://foo"}},
+	})
+	assert.Error(t, err)
+}
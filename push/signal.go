@@ -0,0 +1,91 @@
+package push
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+func init() {
+	registry.Add("signal", NewSignalFromConfig)
+}
+
+// Signal implements the Signal messenger using the signal-cli REST API
+type Signal struct {
+	*request.Helper
+	log        *util.Logger
+	uri        string
+	number     string
+	recipients []string
+}
+
+// NewSignalFromConfig creates new Signal messenger
+func NewSignalFromConfig(other map[string]interface{}) (Messenger, error) {
+	var cc struct {
+		URI        string   // signal-cli REST API
+		Number     string   // registered sender number
+		Recipients []string // phone numbers
+		Groups     []string // group ids
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" || cc.Number == "" {
+		return nil, errors.New("missing uri or number")
+	}
+
+	recipients := cc.Recipients
+	for _, g := range cc.Groups {
+		if !strings.HasPrefix(g, "group.") {
+			g = "group." + g
+		}
+		recipients = append(recipients, g)
+	}
+
+	if len(recipients) == 0 {
+		return nil, errors.New("missing recipients or groups")
+	}
+
+	log := util.NewLogger("signal").Redact(append([]string{cc.Number}, recipients...)...)
+
+	m := &Signal{
+		Helper:     request.NewHelper(log),
+		log:        log,
+		uri:        strings.TrimSuffix(util.DefaultScheme(cc.URI, "http"), "/"),
+		number:     cc.Number,
+		recipients: recipients,
+	}
+
+	return m, nil
+}
+
+// Send sends to all receivers
+func (m *Signal) Send(title, msg string) {
+	if title != "" {
+		msg = title + "\n" + msg
+	}
+
+	data := struct {
+		Message    string   `json:"message"`
+		Number     string   `json:"number"`
+		Recipients []string `json:"recipients"`
+	}{
+		Message:    msg,
+		Number:     m.number,
+		Recipients: m.recipients,
+	}
+
+	req, err := request.New(http.MethodPost, m.uri+"/v2/send", request.MarshalJSON(data), request.JSONEncoding)
+	if err == nil {
+		_, err = m.DoBody(req)
+	}
+
+	if err != nil {
+		m.log.ERROR.Println("send:", err)
+	}
+}
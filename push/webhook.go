@@ -0,0 +1,127 @@
+package push
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+// WebhookConfig is the webhook configuration
+type WebhookConfig struct {
+	URI     string
+	Events  []string          // events to send, empty for all
+	Secret  string            // optional HMAC-SHA256 signing secret
+	Headers map[string]string // additional request headers
+	Retries int               // retries on failure
+	Timeout time.Duration
+}
+
+// WebhookPayload is the json payload posted for an event
+type WebhookPayload struct {
+	Event     string         `json:"event"`
+	Loadpoint *int           `json:"loadpoint,omitempty"` // 1-based loadpoint id
+	Timestamp time.Time      `json:"timestamp"`
+	Data      map[string]any `json:"data"`
+}
+
+// Webhook posts event payloads to an external endpoint
+type Webhook struct {
+	*request.Helper
+	log      *util.Logger
+	uri      string
+	events   []string
+	secret   []byte
+	headers  map[string]string
+	retries  int
+	interval time.Duration // initial retry interval
+}
+
+// NewWebhook creates a webhook from configuration
+func NewWebhook(cc WebhookConfig) (*Webhook, error) {
+	if cc.URI == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	if cc.Retries < 0 {
+		return nil, errors.New("retries must not be negative")
+	}
+
+	log := util.NewLogger("webhook").Redact(cc.Secret)
+
+	wh := &Webhook{
+		Helper:   request.NewHelper(log),
+		log:      log,
+		uri:      cc.URI,
+		events:   cc.Events,
+		secret:   []byte(cc.Secret),
+		headers:  cc.Headers,
+		retries:  cc.Retries,
+		interval: time.Second,
+	}
+
+	if cc.Timeout > 0 {
+		wh.Client.Timeout = cc.Timeout
+	}
+
+	return wh, nil
+}
+
+// Accepts returns true if the webhook is subscribed to the event
+func (wh *Webhook) Accepts(event string) bool {
+	return len(wh.events) == 0 || slices.Contains(wh.events, event)
+}
+
+// Signature returns the hex-encoded HMAC-SHA256 of the body
+func Signature(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the payload, retrying with exponential backoff on failure
+func (wh *Webhook) Send(payload WebhookPayload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		wh.log.ERROR.Printf("%s: %v", payload.Event, err)
+		return
+	}
+
+	headers := map[string]string{
+		"X-Evcc-Event": payload.Event,
+	}
+
+	if len(wh.secret) > 0 {
+		headers["X-Evcc-Signature"] = "sha256=" + Signature(wh.secret, body)
+	}
+
+	bo := backoff.WithMaxRetries(backoff.NewExponentialBackOff(backoff.WithInitialInterval(wh.interval)), uint64(wh.retries))
+
+	if err := backoff.Retry(func() error {
+		req, err := request.New(http.MethodPost, wh.uri, bytes.NewReader(body), request.JSONEncoding, headers, wh.headers)
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+
+		_, err = wh.DoBody(req)
+
+		// client errors except timeouts and rate limits are not retried
+		var se request.StatusError
+		if errors.As(err, &se) && se.StatusCode() < 500 && !se.HasStatus(http.StatusRequestTimeout, http.StatusTooManyRequests) {
+			return backoff.Permanent(err)
+		}
+
+		return err
+	}, bo); err != nil {
+		wh.log.ERROR.Printf("%s: %v", payload.Event, err)
+	}
+}
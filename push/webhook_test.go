@@ -0,0 +1,79 @@
+package push
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSignatureAndRetry(t *testing.T) {
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, "start", r.Header.Get("X-Evcc-Event"))
+		assert.Equal(t, "sha256="+Signature([]byte("secret"), body), r.Header.Get("X-Evcc-Signature"))
+		assert.Equal(t, "bar", r.Header.Get("X-Foo"))
+
+		var payload WebhookPayload
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, 1, *payload.Loadpoint)
+
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	wh, err := NewWebhook(WebhookConfig{
+		URI:     srv.URL,
+		Secret:  "secret",
+		Headers: map[string]string{"X-Foo": "bar"},
+		Retries: 2,
+	})
+	require.NoError(t, err)
+	wh.interval = time.Millisecond
+
+	wh.Send(WebhookPayload{Event: "start", Loadpoint: lo.ToPtr(1), Timestamp: time.Now()})
+	assert.Equal(t, int32(2), calls.Load(), "retry after server error")
+}
+
+func TestWebhookClientError(t *testing.T) {
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	wh, err := NewWebhook(WebhookConfig{URI: srv.URL, Retries: 3})
+	require.NoError(t, err)
+	wh.interval = time.Millisecond
+
+	wh.Send(WebhookPayload{Event: "stop"})
+	assert.Equal(t, int32(1), calls.Load(), "no retry after client error")
+}
+
+func TestWebhookAccepts(t *testing.T) {
+	wh, err := NewWebhook(WebhookConfig{URI: "http://localhost", Events: []string{"start", "error"}})
+	require.NoError(t, err)
+
+	assert.True(t, wh.Accepts("error"))
+	assert.False(t, wh.Accepts("stop"))
+
+	wh.events = nil
+	assert.True(t, wh.Accepts("stop"), "all events")
+
+	_, err = NewWebhook(WebhookConfig{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,251 @@
+package db
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/glebarez/sqlite"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+const (
+	backupDatabase = "evcc.db"
+	backupConfig   = "evcc.yaml"
+
+	// restoreSuffix marks a restored database to be applied on next start
+	restoreSuffix = ".restore"
+)
+
+// file is the sqlite database file
+var file string
+
+// applyRestore replaces the database with a pending restored database
+func applyRestore(file string) error {
+	if _, err := os.Stat(file + restoreSuffix); err != nil {
+		return nil
+	}
+
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(file + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return os.Rename(file+restoreSuffix, file)
+}
+
+// Backup writes a zip archive containing the database and the optional config file
+func Backup(w io.Writer, configFile string) error {
+	if Instance == nil || file == "" {
+		return errors.New("backup requires sqlite database")
+	}
+
+	dir, err := os.MkdirTemp("", "evcc-backup")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	// consistent snapshot of the live database
+	snapshot := filepath.Join(dir, backupDatabase)
+	if err := Instance.Exec("VACUUM INTO ?", snapshot).Error; err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+
+	zw := zip.NewWriter(w)
+
+	add := func(name, src string) error {
+		f, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(fw, f)
+		return err
+	}
+
+	if err := add(backupDatabase, snapshot); err != nil {
+		return err
+	}
+
+	if configFile != "" {
+		if err := add(backupConfig, configFile); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// Restore validates the backup archive and stages its database to be applied on next start.
+// The config file is replaced immediately, keeping a copy of the previous file.
+func Restore(r io.ReaderAt, size int64, configFile string) error {
+	if file == "" {
+		return errors.New("restore requires sqlite database")
+	}
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("invalid archive: %w", err)
+	}
+
+	var database, config *zip.File
+	for _, f := range zr.File {
+		switch f.Name {
+		case backupDatabase:
+			database = f
+		case backupConfig:
+			config = f
+		}
+	}
+
+	if database == nil {
+		return errors.New("invalid archive: missing database")
+	}
+
+	var configData []byte
+	if config != nil && configFile != "" {
+		if configData, err = readConfig(config); err != nil {
+			return err
+		}
+	}
+
+	staged := file + restoreSuffix
+	if err := extract(database, staged); err != nil {
+		return err
+	}
+
+	if err := validate(staged); err != nil {
+		os.Remove(staged)
+		return err
+	}
+
+	if configData != nil {
+		if err := writeConfig(configFile, configData); err != nil {
+			os.Remove(staged)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readConfig reads and validates the archived yaml configuration
+func readConfig(f *zip.File) ([]byte, error) {
+	src, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	b, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var res map[string]any
+	if err := yaml.Unmarshal(b, &res); err != nil {
+		return nil, fmt.Errorf("invalid archive: config: %w", err)
+	}
+
+	return b, nil
+}
+
+// writeConfig replaces the configuration file atomically keeping its permissions and a backup
+func writeConfig(configFile string, b []byte) error {
+	mode := os.FileMode(0o600)
+
+	if fi, err := os.Stat(configFile); err == nil {
+		mode = fi.Mode().Perm()
+
+		current, err := os.ReadFile(configFile)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(configFile+".bak", current, mode); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(configFile), filepath.Base(configFile)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), configFile)
+}
+
+func extract(f *zip.File, dst string) error {
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// validate checks that the file is an evcc database
+func validate(file string) error {
+	db, err := gorm.Open(sqlite.Open(file), &gorm.Config{
+		Logger: &Logger{util.NewLogger("db")},
+	})
+	if err != nil {
+		return err
+	}
+
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	if !db.Migrator().HasTable("settings") {
+		return errors.New("invalid archive: not an evcc database")
+	}
+
+	return nil
+}
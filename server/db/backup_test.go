@@ -0,0 +1,83 @@
+package db
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type setting struct {
+	Key   string `gorm:"primarykey"`
+	Value string
+}
+
+func TestBackupRestore(t *testing.T) {
+	dir := t.TempDir()
+	dsn := filepath.Join(dir, "evcc.db")
+	configFile := filepath.Join(dir, "evcc.yaml")
+
+	require.NoError(t, os.WriteFile(configFile, []byte("interval: 10s\n"), 0o644))
+	require.NoError(t, NewInstance("sqlite", dsn))
+	require.NoError(t, Instance.AutoMigrate(new(setting)))
+	require.NoError(t, Instance.Create(&setting{Key: "foo", Value: "backup"}).Error)
+
+	var buf bytes.Buffer
+	require.NoError(t, Backup(&buf, configFile))
+
+	// change state after backup
+	require.NoError(t, Instance.Save(&setting{Key: "foo", Value: "changed"}).Error)
+	require.NoError(t, os.WriteFile(configFile, []byte("interval: 30s\n"), 0o644))
+	require.NoError(t, os.Chmod(configFile, 0o640))
+
+	require.NoError(t, Restore(bytes.NewReader(buf.Bytes()), int64(buf.Len()), configFile))
+
+	b, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, "interval: 10s\n", string(b))
+
+	fi, err := os.Stat(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), fi.Mode().Perm())
+
+	b, err = os.ReadFile(configFile + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, "interval: 30s\n", string(b))
+
+	// restored database is applied on next start
+	sqlDB, err := Instance.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	require.NoError(t, NewInstance("sqlite", dsn))
+
+	var res setting
+	require.NoError(t, Instance.First(&res, "key = ?", "foo").Error)
+	assert.Equal(t, "backup", res.Value)
+
+	_, err = os.Stat(dsn + restoreSuffix)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// invalid archives are rejected
+	assert.Error(t, Restore(bytes.NewReader([]byte("foo")), 3, configFile))
+
+	// invalid config is rejected without touching the current config
+	buf.Reset()
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{backupDatabase: "", backupConfig: "interval: [10s\n"} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	assert.Error(t, Restore(bytes.NewReader(buf.Bytes()), int64(buf.Len()), configFile))
+
+	b, err = os.ReadFile(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, "interval: 10s\n", string(b))
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/glebarez/sqlite"
 	"github.com/mitchellh/go-homedir"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
@@ -28,14 +30,27 @@ func New(driver, dsn string) (*gorm.DB, error) {
 		if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
 			return nil, err
 		}
+		if err := applyRestore(file); err != nil {
+			return nil, fmt.Errorf("restore: %w", err)
+		}
 		// avoid busy errors
 		dialect = sqlite.Open(file + "?_pragma=busy_timeout(5000)")
-	// case "postgres":
-	// 	dialect = postgres.Open(dsn)
-	// case "mysql":
-	// 	dialect = mysql.Open(dsn)
+	case "postgres":
+		log.INFO.Println("using postgres database")
+		dialect = postgres.Open(dsn)
+	case "mysql":
+		log.INFO.Println("using mysql database")
+		// scan timestamps into time.Time
+		if !strings.Contains(dsn, "parseTime=") {
+			sep := "?"
+			if strings.Contains(dsn, "?") {
+				sep = "&"
+			}
+			dsn += sep + "parseTime=true"
+		}
+		dialect = mysql.Open(dsn)
 	default:
-		return nil, fmt.Errorf("invalid database type: %s not in [sqlite]", driver)
+		return nil, fmt.Errorf("invalid database type: %s not in [sqlite, postgres, mysql]", driver)
 	}
 
 	return gorm.Open(dialect, &gorm.Config{
@@ -45,5 +60,8 @@ func New(driver, dsn string) (*gorm.DB, error) {
 
 func NewInstance(driver, dsn string) (err error) {
 	Instance, err = New(strings.ToLower(driver), dsn)
+	if err == nil && strings.ToLower(driver) == "sqlite" {
+		file, err = homedir.Expand(dsn)
+	}
 	return
 }
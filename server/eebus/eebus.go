@@ -21,7 +21,9 @@ import (
 	"github.com/enbility/eebus-go/usecases/cem/oscev"
 	"github.com/enbility/eebus-go/usecases/cs/lpc"
 	"github.com/enbility/eebus-go/usecases/cs/lpp"
+	eglpc "github.com/enbility/eebus-go/usecases/eg/lpc"
 	"github.com/enbility/eebus-go/usecases/ma/mgcp"
+	"github.com/enbility/eebus-go/usecases/ma/mpc"
 	shipapi "github.com/enbility/ship-go/api"
 	"github.com/enbility/ship-go/mdns"
 	shiputil "github.com/enbility/ship-go/util"
@@ -51,11 +53,18 @@ type UseCasesCS struct {
 	MGCP ucapi.MaMGCPInterface
 }
 
+// Energy guard UseCases for controllable consumers like heat pumps
+type UseCasesEG struct {
+	LPC ucapi.EgLPCInterface
+	MPC ucapi.MaMPCInterface
+}
+
 type EEBus struct {
 	service eebusapi.ServiceInterface
 
 	evseUC UseCasesEVSE
 	csUC   UseCasesCS
+	egUC   UseCasesEG
 
 	mux sync.Mutex
 	log *util.Logger
@@ -158,12 +167,19 @@ func NewServer(other Config) (*EEBus, error) {
 		MGCP: mgcp.NewMGCP(localEntity, c.ucCallback),
 	}
 
+	// energy guard
+	c.egUC = UseCasesEG{
+		LPC: eglpc.NewLPC(localEntity, c.ucCallback),
+		MPC: mpc.NewMPC(localEntity, c.ucCallback),
+	}
+
 	// register use cases
 	for _, uc := range []eebusapi.UseCaseInterface{
 		c.evseUC.EvseCC, c.evseUC.EvCC,
 		c.evseUC.EvCem, c.evseUC.OpEV,
 		c.evseUC.OscEV, c.evseUC.EvSoc,
 		c.csUC.LPC, c.csUC.LPP, c.csUC.MGCP,
+		c.egUC.LPC, c.egUC.MPC,
 	} {
 		c.service.AddUseCase(uc)
 	}
@@ -199,6 +215,10 @@ func (c *EEBus) ControllableSystem() *UseCasesCS {
 	return &c.csUC
 }
 
+func (c *EEBus) EnergyGuard() *UseCasesEG {
+	return &c.egUC
+}
+
 func (c *EEBus) Run() {
 	c.service.Start()
 }
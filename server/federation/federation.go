@@ -0,0 +1,207 @@
+package federation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/transport"
+)
+
+// ErrNotFound indicates an unknown remote instance
+var ErrNotFound = errors.New("remote not found")
+
+// Config is the remote instance configuration
+type Config struct {
+	Name  string // unique name
+	URI   string // base uri, e.g. https://evcc.example.org
+	Token string // api key or jwt token
+}
+
+// siteKeys and loadpointKeys are the site and loadpoint keys included in the site overview
+var (
+	siteKeys      = []string{keys.SiteTitle, keys.Currency, keys.PvPower, keys.GridPower, keys.HomePower, keys.BatteryPower, keys.BatterySoc, keys.TariffGrid}
+	loadpointKeys = []string{keys.Title, keys.Mode, keys.Connected, keys.Charging, keys.ChargePower, keys.VehicleName, keys.VehicleSoc}
+)
+
+// Site is the overview of a remote instance's site
+type Site struct {
+	Name    string         `json:"name"`
+	URI     string         `json:"uri"`
+	Online  bool           `json:"online"`
+	Updated time.Time      `json:"updated"`
+	Error   string         `json:"error,omitempty"`
+	Site    map[string]any `json:"site,omitempty"`
+}
+
+type remote struct {
+	*request.Helper
+	Config
+
+	mu      sync.RWMutex
+	state   map[string]any
+	updated time.Time
+	err     error
+}
+
+// Federation aggregates the sites of remote evcc instances
+type Federation struct {
+	log     *util.Logger
+	remotes []*remote
+}
+
+// New creates a federation of remote instances
+func New(cc []Config) (*Federation, error) {
+	f := &Federation{
+		log: util.NewLogger("federation"),
+	}
+
+	for _, c := range cc {
+		if c.Name == "" || c.URI == "" {
+			return nil, errors.New("missing name or uri")
+		}
+
+		if slices.ContainsFunc(f.remotes, func(r *remote) bool { return r.Name == c.Name }) {
+			return nil, fmt.Errorf("duplicate remote: %s", c.Name)
+		}
+
+		r := &remote{
+			Helper: request.NewHelper(f.log),
+			Config: c,
+		}
+		r.URI = strings.TrimSuffix(c.URI, "/")
+
+		if c.Token != "" {
+			r.Client.Transport = transport.BearerAuth(c.Token, r.Client.Transport)
+		}
+
+		f.remotes = append(f.remotes, r)
+	}
+
+	return f, nil
+}
+
+// Run updates the remote instances' state and publishes the site overview
+func (f *Federation) Run(valueChan chan<- util.Param, interval time.Duration) {
+	for tick := time.Tick(interval); ; <-tick {
+		var wg sync.WaitGroup
+
+		for _, r := range f.remotes {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := r.update(); err != nil {
+					f.log.ERROR.Printf("%s: %v", r.Name, err)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		valueChan <- util.Param{Key: keys.Remotes, Val: f.Sites()}
+	}
+}
+
+func (r *remote) update() error {
+	var res struct {
+		Result map[string]any
+	}
+
+	err := r.GetJSON(r.URI+"/api/state", &res)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.err = err
+	if err == nil {
+		r.state = res.Result
+		r.updated = time.Now()
+	}
+
+	return err
+}
+
+// Sites returns the overview of all remote sites
+func (f *Federation) Sites() []Site {
+	res := make([]Site, 0, len(f.remotes))
+
+	for _, r := range f.remotes {
+		r.mu.RLock()
+
+		s := Site{
+			Name:    r.Name,
+			URI:     r.URI,
+			Online:  r.err == nil && r.state != nil,
+			Updated: r.updated,
+		}
+
+		if r.err != nil {
+			s.Error = r.err.Error()
+		}
+
+		if r.state != nil {
+			s.Site = summary(r.state)
+		}
+
+		r.mu.RUnlock()
+
+		res = append(res, s)
+	}
+
+	return res
+}
+
+// State returns the last known full state of the named remote instance
+func (f *Federation) State(name string) (map[string]any, error) {
+	idx := slices.IndexFunc(f.remotes, func(r *remote) bool { return r.Name == name })
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	r := f.remotes[idx]
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.state == nil {
+		if r.err != nil {
+			return nil, r.err
+		}
+		return nil, errors.New("remote not available")
+	}
+
+	return r.state, nil
+}
+
+// summary extracts the site overview from the remote state
+func summary(state map[string]any) map[string]any {
+	pick := func(src map[string]any, keys []string) map[string]any {
+		res := make(map[string]any)
+		for _, k := range keys {
+			if v, ok := src[k]; ok {
+				res[k] = v
+			}
+		}
+		return res
+	}
+
+	res := pick(state, siteKeys)
+
+	if lps, ok := state["loadpoints"].([]any); ok {
+		loadpoints := make([]map[string]any, 0, len(lps))
+		for _, lp := range lps {
+			if lp, ok := lp.(map[string]any); ok {
+				loadpoints = append(loadpoints, pick(lp, loadpointKeys))
+			}
+		}
+		res["loadpoints"] = loadpoints
+	}
+
+	return res
+}
@@ -0,0 +1,61 @@
+package federation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFederation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":{"siteTitle":"Cottage","pvPower":1000,"statistics":{},"loadpoints":[{"title":"Garage","chargePower":3700,"sessionEnergy":5}]}}`))
+	}))
+	defer srv.Close()
+
+	f, err := New([]Config{
+		{Name: "cottage", URI: srv.URL + "/", Token: "secret"},
+		{Name: "denied", URI: srv.URL},
+	})
+	require.NoError(t, err)
+
+	for _, r := range f.remotes {
+		_ = r.update()
+	}
+
+	sites := f.Sites()
+	require.Len(t, sites, 2)
+
+	assert.True(t, sites[0].Online)
+	assert.Equal(t, map[string]any{
+		"siteTitle": "Cottage",
+		"pvPower":   1000.0,
+		"loadpoints": []map[string]any{
+			{"title": "Garage", "chargePower": 3700.0},
+		},
+	}, sites[0].Site)
+
+	assert.False(t, sites[1].Online)
+	assert.NotEmpty(t, sites[1].Error)
+
+	state, err := f.State("cottage")
+	require.NoError(t, err)
+	assert.Contains(t, state, "statistics")
+
+	_, err = f.State("denied")
+	assert.Error(t, err)
+
+	_, err = f.State("unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = New([]Config{{Name: "a", URI: "http://a"}, {Name: "a", URI: "http://b"}})
+	assert.Error(t, err, "duplicate")
+}
@@ -0,0 +1,359 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/api/proto/pb"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// grpcScopes are the required api key scopes per method, methods not listed require control scope
+var grpcScopes = map[string]auth.Scope{
+	pb.Evcc_GetState_FullMethodName:      auth.ScopeRead,
+	pb.Evcc_StreamUpdates_FullMethodName: auth.ScopeRead,
+}
+
+// Grpc is the gRPC api server for external energy management systems
+type Grpc struct {
+	pb.UnimplementedEvccServer
+	log         *util.Logger
+	site        site.API
+	cache       *util.Cache
+	auth        auth.Auth
+	server      *grpc.Server
+	listener    net.Listener
+	mu          sync.Mutex
+	subscribers map[chan util.Param]struct{}
+}
+
+// NewGrpc creates the gRPC api server listening on the given port
+func NewGrpc(port int, site site.API, cache *util.Cache, auth auth.Auth) (*Grpc, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("grpc: %w", err)
+	}
+
+	s := &Grpc{
+		log:         util.NewLogger("grpc"),
+		site:        site,
+		cache:       cache,
+		auth:        auth,
+		listener:    listener,
+		subscribers: make(map[chan util.Param]struct{}),
+	}
+
+	s.server = grpc.NewServer(
+		grpc.UnaryInterceptor(s.unaryInterceptor),
+		grpc.StreamInterceptor(s.streamInterceptor),
+	)
+	pb.RegisterEvccServer(s.server, s)
+
+	return s, nil
+}
+
+// authorize checks the api key from the request metadata against the method's required scope
+func (s *Grpc) authorize(ctx context.Context, method string) error {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("authorization"); len(v) > 0 {
+			token = strings.TrimPrefix(v[0], "Bearer ")
+		}
+	}
+
+	scope, ok := s.auth.Authorize(token)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "invalid api key")
+	}
+
+	required, ok := grpcScopes[method]
+	if !ok {
+		required = auth.ScopeControl
+	}
+
+	if !scope.Allows(required) {
+		return status.Errorf(codes.PermissionDenied, "%s scope required", required)
+	}
+
+	return nil
+}
+
+func (s *Grpc) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Grpc) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// Serve serves gRPC requests until the server is stopped
+func (s *Grpc) Serve() error {
+	s.log.INFO.Printf("listening at %s", s.listener.Addr())
+	return s.server.Serve(s.listener)
+}
+
+// Run distributes site and loadpoint values to the streaming subscribers
+func (s *Grpc) Run(in <-chan util.Param) {
+	for p := range in {
+		s.mu.Lock()
+		for ch := range s.subscribers {
+			select {
+			case ch <- p:
+			default:
+				// drop slow subscriber
+				delete(s.subscribers, ch)
+				close(ch)
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	s.server.GracefulStop()
+}
+
+// grpcFilter converts the request into a websocket filter with zero-based loadpoint ids
+func grpcFilter(req *pb.StateRequest) socketFilter {
+	var f socketFilter
+
+	if len(req.GetKeys()) > 0 {
+		f.keys = make(map[string]bool)
+		for _, k := range req.GetKeys() {
+			f.keys[k] = true
+		}
+	}
+
+	if len(req.GetLoadpoints()) > 0 {
+		f.loadpoints = make(map[int]bool)
+		for _, id := range req.GetLoadpoints() {
+			f.loadpoints[int(id)-1] = true
+		}
+	}
+
+	return f
+}
+
+// grpcUpdate converts a param into its protobuf representation
+func grpcUpdate(p util.Param) (*pb.Update, error) {
+	b, err := json.Marshal(enc.Encode(p.Val))
+	if err != nil {
+		return nil, err
+	}
+
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	val, err := structpb.NewValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.Update{
+		Key:   p.Key,
+		Value: val,
+	}
+
+	if p.Loadpoint != nil {
+		res.Loadpoint = int32(*p.Loadpoint + 1)
+	}
+
+	return res, nil
+}
+
+// GetState returns the current site and loadpoint values
+func (s *Grpc) GetState(ctx context.Context, req *pb.StateRequest) (*pb.StateReply, error) {
+	filter := grpcFilter(req)
+
+	res := new(pb.StateReply)
+	for _, p := range s.cache.All() {
+		if !filter.match(p) {
+			continue
+		}
+
+		u, err := grpcUpdate(p)
+		if err != nil {
+			s.log.DEBUG.Printf("skipping %s: %v", p.Key, err)
+			continue
+		}
+
+		res.Values = append(res.Values, u)
+	}
+
+	return res, nil
+}
+
+// StreamUpdates streams site and loadpoint value changes
+func (s *Grpc) StreamUpdates(req *pb.StateRequest, stream pb.Evcc_StreamUpdatesServer) error {
+	filter := grpcFilter(req)
+
+	ch := make(chan util.Param, 1024)
+
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		if _, ok := s.subscribers[ch]; ok {
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+
+		case p, ok := <-ch:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "subscriber too slow")
+			}
+
+			if !filter.match(p) {
+				continue
+			}
+
+			u, err := grpcUpdate(p)
+			if err != nil {
+				continue
+			}
+
+			if err := stream.Send(u); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// loadpoint returns the loadpoint by its id starting at 1
+func (s *Grpc) loadpoint(id int32) (loadpoint.API, error) {
+	lps := s.site.Loadpoints()
+	if id < 1 || int(id) > len(lps) {
+		return nil, status.Errorf(codes.NotFound, "loadpoint not found: %d", id)
+	}
+	return lps[id-1], nil
+}
+
+func loadpointReply(id int32, lp loadpoint.API) *pb.LoadpointReply {
+	return &pb.LoadpointReply{
+		Loadpoint:  id,
+		Mode:       lp.GetMode().String(),
+		MinCurrent: lp.GetMinCurrent(),
+		MaxCurrent: lp.GetMaxCurrent(),
+		LimitSoc:   int32(lp.GetLimitSoc()),
+	}
+}
+
+// SetLoadpointMode sets the loadpoint charge mode
+func (s *Grpc) SetLoadpointMode(ctx context.Context, req *pb.LoadpointModeRequest) (*pb.LoadpointReply, error) {
+	lp, err := s.loadpoint(req.GetLoadpoint())
+	if err != nil {
+		return nil, err
+	}
+
+	mode, err := api.ChargeModeString(req.GetMode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	lp.SetMode(mode)
+
+	return loadpointReply(req.GetLoadpoint(), lp), nil
+}
+
+// SetLoadpointCurrents sets the loadpoint min and max currents
+func (s *Grpc) SetLoadpointCurrents(ctx context.Context, req *pb.LoadpointCurrentsRequest) (*pb.LoadpointReply, error) {
+	lp, err := s.loadpoint(req.GetLoadpoint())
+	if err != nil {
+		return nil, err
+	}
+
+	if v := req.GetMinCurrent(); v != nil {
+		if err := lp.SetMinCurrent(v.GetValue()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if v := req.GetMaxCurrent(); v != nil {
+		if err := lp.SetMaxCurrent(v.GetValue()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	return loadpointReply(req.GetLoadpoint(), lp), nil
+}
+
+// SetLoadpointLimitSoc sets the loadpoint limit soc
+func (s *Grpc) SetLoadpointLimitSoc(ctx context.Context, req *pb.LoadpointLimitSocRequest) (*pb.LoadpointReply, error) {
+	lp, err := s.loadpoint(req.GetLoadpoint())
+	if err != nil {
+		return nil, err
+	}
+
+	lp.SetLimitSoc(int(req.GetLimitSoc()))
+
+	return loadpointReply(req.GetLoadpoint(), lp), nil
+}
+
+// SetBatteryControl updates the site battery settings
+func (s *Grpc) SetBatteryControl(ctx context.Context, req *pb.BatteryControlRequest) (*pb.BatteryControlReply, error) {
+	for _, set := range []func() error{
+		func() error {
+			if v := req.GetDischargeControl(); v != nil {
+				return s.site.SetBatteryDischargeControl(v.GetValue())
+			}
+			return nil
+		},
+		func() error {
+			if v := req.GetPrioritySoc(); v != nil {
+				return s.site.SetPrioritySoc(v.GetValue())
+			}
+			return nil
+		},
+		func() error {
+			if v := req.GetBufferSoc(); v != nil {
+				return s.site.SetBufferSoc(v.GetValue())
+			}
+			return nil
+		},
+		func() error {
+			if v := req.GetBufferStartSoc(); v != nil {
+				return s.site.SetBufferStartSoc(v.GetValue())
+			}
+			return nil
+		},
+	} {
+		if err := set(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	return &pb.BatteryControlReply{
+		DischargeControl: s.site.GetBatteryDischargeControl(),
+		PrioritySoc:      s.site.GetPrioritySoc(),
+		BufferSoc:        s.site.GetBufferSoc(),
+		BufferStartSoc:   s.site.GetBufferStartSoc(),
+	}, nil
+}
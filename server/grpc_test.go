@@ -0,0 +1,37 @@
+package server
+
+import (
+	"math"
+	"testing"
+
+	"github.com/evcc-io/evcc/api/proto/pb"
+	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestGrpcUpdate(t *testing.T) {
+	u, err := grpcUpdate(util.Param{Key: "chargePower", Loadpoint: lo.ToPtr(0), Val: 1234.5})
+	require.NoError(t, err)
+	assert.Equal(t, "chargePower", u.GetKey())
+	assert.Equal(t, int32(1), u.GetLoadpoint())
+	assert.Equal(t, 1234.5, u.GetValue().GetNumberValue())
+
+	u, err = grpcUpdate(util.Param{Key: "batterySoc", Val: math.NaN()})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), u.GetLoadpoint())
+	assert.IsType(t, new(structpb.Value_NullValue), u.GetValue().GetKind())
+}
+
+func TestGrpcFilter(t *testing.T) {
+	f := grpcFilter(&pb.StateRequest{Keys: []string{"chargePower"}, Loadpoints: []int32{2}})
+
+	assert.True(t, f.match(util.Param{Key: "chargePower", Loadpoint: lo.ToPtr(1)}))
+	assert.False(t, f.match(util.Param{Key: "chargePower", Loadpoint: lo.ToPtr(0)}))
+	assert.False(t, f.match(util.Param{Key: "mode", Loadpoint: lo.ToPtr(1)}))
+
+	f = grpcFilter(new(pb.StateRequest))
+	assert.True(t, f.match(util.Param{Key: "gridPower"}))
+}
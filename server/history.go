@@ -0,0 +1,238 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	historyInterval  = 5 * time.Minute    // resolution of recent values
+	historyRetention = 7 * 24 * time.Hour // recent values kept in memory
+)
+
+var (
+	historySiteKeys      = []string{keys.GridPower, keys.PvPower, keys.HomePower, keys.BatteryPower, keys.BatterySoc}
+	historyLoadpointKeys = []string{keys.ChargePower, keys.VehicleSoc}
+)
+
+// HistoryPoint is the average value of an interval
+type HistoryPoint struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// HistoryDay is the daily aggregate of a value. For power values,
+// import and export are the energies (kWh) of positive and negative values.
+type HistoryDay struct {
+	Day    time.Time `json:"day" gorm:"primaryKey"`
+	Key    string    `json:"key" gorm:"primaryKey"`
+	Min    float64   `json:"min"`
+	Max    float64   `json:"max"`
+	Avg    float64   `json:"avg"`
+	Import float64   `json:"import"`
+	Export float64   `json:"export"`
+	Count  int       `json:"-"`
+}
+
+// TableName implements the gorm Tabler interface
+func (HistoryDay) TableName() string {
+	return "history_daily"
+}
+
+// historySeries is a ring buffer of interval averages and the current day's aggregate
+type historySeries struct {
+	points []HistoryPoint
+	next   int
+
+	start time.Time // current interval
+	sum   float64
+	count int
+	day   *HistoryDay
+}
+
+func (s *historySeries) add(p HistoryPoint) {
+	if len(s.points) < cap(s.points) {
+		s.points = append(s.points, p)
+		return
+	}
+
+	s.points[s.next] = p
+	s.next = (s.next + 1) % len(s.points)
+}
+
+// since returns the points in chronological order
+func (s *historySeries) since(from time.Time) []HistoryPoint {
+	res := make([]HistoryPoint, 0, len(s.points))
+	for _, p := range slices.Concat(s.points[s.next:], s.points[:s.next]) {
+		if !p.Time.Before(from) {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// History stores site power values for UI charts without external database
+type History struct {
+	mu     sync.RWMutex
+	log    *util.Logger
+	clock  clock.Clock
+	db     *gorm.DB
+	series map[string]*historySeries
+}
+
+// NewHistory creates history store persisting daily aggregates to the database
+func NewHistory(db *gorm.DB) (*History, error) {
+	if err := db.AutoMigrate(new(HistoryDay)); err != nil {
+		return nil, err
+	}
+
+	h := &History{
+		log:    util.NewLogger("history"),
+		clock:  clock.New(),
+		db:     db,
+		series: make(map[string]*historySeries),
+	}
+
+	return h, nil
+}
+
+// historyKey returns the series key for the param or false if not tracked
+func historyKey(param util.Param) (string, bool) {
+	if param.Loadpoint == nil {
+		return param.Key, slices.Contains(historySiteKeys, param.Key)
+	}
+
+	return fmt.Sprintf("lp-%d/%s", *param.Loadpoint+1, param.Key), slices.Contains(historyLoadpointKeys, param.Key)
+}
+
+// Run records the values from the channel
+func (h *History) Run(in <-chan util.Param) {
+	for param := range in {
+		key, ok := historyKey(param)
+		if !ok {
+			continue
+		}
+
+		if val, ok := metricValue(param.Val); ok && !math.IsNaN(val) {
+			h.record(key, val)
+		}
+	}
+}
+
+func (h *History) record(key string, val float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.clock.Now()
+	start := now.Truncate(historyInterval)
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &historySeries{
+			points: make([]HistoryPoint, 0, historyRetention/historyInterval),
+			start:  start,
+		}
+		h.series[key] = s
+	}
+
+	if !start.Equal(s.start) {
+		h.closeInterval(key, s)
+		s.start = start
+	}
+
+	s.sum += val
+	s.count++
+}
+
+// closeInterval adds the interval average to the series and the daily aggregate
+func (h *History) closeInterval(key string, s *historySeries) {
+	if s.count == 0 {
+		return
+	}
+
+	avg := s.sum / float64(s.count)
+	s.sum, s.count = 0, 0
+
+	s.add(HistoryPoint{Time: s.start, Value: avg})
+
+	y, m, d := s.start.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, s.start.Location())
+
+	if s.day == nil || !s.day.Day.Equal(day) {
+		s.day = h.loadDay(key, day)
+	}
+
+	agg := s.day
+	if agg.Count == 0 {
+		agg.Min, agg.Max = avg, avg
+	}
+
+	agg.Min = min(agg.Min, avg)
+	agg.Max = max(agg.Max, avg)
+	agg.Avg = (agg.Avg*float64(agg.Count) + avg) / float64(agg.Count+1)
+	agg.Count++
+
+	energy := avg * historyInterval.Hours() / 1e3
+	if energy > 0 {
+		agg.Import += energy
+	} else {
+		agg.Export -= energy
+	}
+
+	if err := h.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(agg).Error; err != nil {
+		h.log.ERROR.Printf("%s: %v", key, err)
+	}
+}
+
+// loadDay returns the persisted aggregate to continue after restart
+func (h *History) loadDay(key string, day time.Time) *HistoryDay {
+	res := &HistoryDay{Day: day, Key: key}
+	if err := h.db.Where(res).Limit(1).Find(res).Error; err != nil {
+		h.log.ERROR.Printf("%s: %v", key, err)
+	}
+	return res
+}
+
+// Keys returns the recorded series keys
+func (h *History) Keys() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	res := make([]string, 0, len(h.series))
+	for k := range h.series {
+		res = append(res, k)
+	}
+	slices.Sort(res)
+
+	return res
+}
+
+// Recent returns the interval averages of the given period
+func (h *History) Recent(key string, period time.Duration) []HistoryPoint {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		return []HistoryPoint{}
+	}
+
+	return s.since(h.clock.Now().Add(-period))
+}
+
+// Daily returns the daily aggregates between from and to, both inclusive
+func (h *History) Daily(key string, from, to time.Time) ([]HistoryDay, error) {
+	var res []HistoryDay
+	// struct condition quotes the key column which is reserved in mysql
+	err := h.db.Where(&HistoryDay{Key: key}).Where("day >= ? AND day <= ?", from, to).Order("day").Find(&res).Error
+	return res, err
+}
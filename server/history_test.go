@@ -0,0 +1,78 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	gdb, err := db.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	h, err := NewHistory(gdb)
+	require.NoError(t, err)
+
+	clock := clock.NewMock()
+	clock.Set(time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local))
+	h.clock = clock
+
+	in := make(chan util.Param)
+	done := make(chan struct{})
+	go func() {
+		h.Run(in)
+		close(done)
+	}()
+
+	lp := 0
+	send := func(val float64) {
+		in <- util.Param{Key: keys.GridPower, Val: val}
+		in <- util.Param{Key: keys.ChargePower, Val: val, Loadpoint: &lp}
+		in <- util.Param{Key: keys.Title, Val: "ignored"}
+	}
+
+	// 1h of 1kW import, 1h of 2kW export
+	for range 12 {
+		send(500)
+		send(1500)
+		clock.Add(historyInterval)
+	}
+	for range 12 {
+		send(-2000)
+		clock.Add(historyInterval)
+	}
+	send(0)
+
+	close(in)
+	<-done
+
+	assert.Equal(t, []string{keys.GridPower, "lp-1/chargePower"}, h.Keys())
+
+	recent := h.Recent(keys.GridPower, time.Hour)
+	require.Len(t, recent, 12)
+	assert.Equal(t, -2000.0, recent[0].Value)
+
+	all := h.Recent(keys.GridPower, 24*time.Hour)
+	require.Len(t, all, 24)
+	assert.Equal(t, 1000.0, all[0].Value)
+	assert.Equal(t, clock.Now().Add(-2*time.Hour), all[0].Time)
+
+	y, m, d := clock.Now().Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+
+	res, err := h.Daily(keys.GridPower, day, day)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	assert.InDelta(t, 1.0, res[0].Import, 1e-9)
+	assert.InDelta(t, 2.0, res[0].Export, 1e-9)
+	assert.Equal(t, -2000.0, res[0].Min)
+	assert.Equal(t, 1000.0, res[0].Max)
+	assert.InDelta(t, -500.0, res[0].Avg, 1e-9)
+}
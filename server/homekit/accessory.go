@@ -0,0 +1,177 @@
+package homekit
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// HAP service types
+const (
+	serviceAccessoryInformation = "3E"
+	serviceProtocolInformation  = "A2"
+	serviceOutlet               = "47"
+	serviceSwitch               = "49"
+	serviceBattery              = "96"
+	serviceLightSensor          = "84"
+)
+
+// HAP characteristic types
+const (
+	charIdentify         = "14"
+	charManufacturer     = "20"
+	charModel            = "21"
+	charName             = "23"
+	charSerialNumber     = "30"
+	charFirmwareRevision = "52"
+	charVersion          = "37"
+	charOn               = "25"
+	charOutletInUse      = "26"
+	charBatteryLevel     = "68"
+	charChargingState    = "8F"
+	charStatusLowBattery = "79"
+	charAmbientLight     = "6B"
+
+	// charPower is the Eve power consumption characteristic (W)
+	charPower = "E863F10D-079E-48FF-8F27-9C2605A29F52"
+)
+
+// HAP characteristic permissions
+const (
+	permRead   = "pr"
+	permWrite  = "pw"
+	permEvents = "ev"
+)
+
+// HAP status codes
+const (
+	statusInsufficientPriv = -70401
+	statusCommunication    = -70402
+	statusReadOnly         = -70404
+	statusWriteOnly        = -70405
+	statusNotifyDenied     = -70406
+	statusNotFound         = -70409
+	statusInvalidValue     = -70410
+)
+
+type characteristic struct {
+	Type     string   `json:"type"`
+	IID      int      `json:"iid"`
+	Perms    []string `json:"perms"`
+	Format   string   `json:"format"`
+	Value    any      `json:"-"`
+	Unit     string   `json:"unit,omitempty"`
+	MinValue *float64 `json:"minValue,omitempty"`
+	MaxValue *float64 `json:"maxValue,omitempty"`
+	MinStep  *float64 `json:"minStep,omitempty"`
+
+	// set handles value writes
+	set func(any) error
+}
+
+// MarshalJSON includes the value for readable characteristics only
+func (c *characteristic) MarshalJSON() ([]byte, error) {
+	type alias characteristic
+
+	if !c.can(permRead) {
+		return json.Marshal((*alias)(c))
+	}
+
+	return json.Marshal(struct {
+		*alias
+		Value any `json:"value"`
+	}{
+		alias: (*alias)(c),
+		Value: c.Value,
+	})
+}
+
+func (c *characteristic) can(perm string) bool {
+	return slices.Contains(c.Perms, perm)
+}
+
+func (c *characteristic) withRange(min, max, step float64) *characteristic {
+	c.MinValue, c.MaxValue, c.MinStep = &min, &max, &step
+	return c
+}
+
+func (c *characteristic) withUnit(unit string) *characteristic {
+	c.Unit = unit
+	return c
+}
+
+func (c *characteristic) withSetter(set func(any) error) *characteristic {
+	c.Perms = append(c.Perms, permWrite)
+	c.set = set
+	return c
+}
+
+// readonly creates a readable characteristic with the given static value
+func readonly(typ, format string, value any) *characteristic {
+	return &characteristic{Type: typ, Perms: []string{permRead}, Format: format, Value: value}
+}
+
+// notifying creates a readable characteristic supporting event notifications
+func notifying(typ, format string, value any) *characteristic {
+	return &characteristic{Type: typ, Perms: []string{permRead, permEvents}, Format: format, Value: value}
+}
+
+type service struct {
+	Type            string            `json:"type"`
+	IID             int               `json:"iid"`
+	Primary         bool              `json:"primary,omitempty"`
+	Characteristics []*characteristic `json:"characteristics"`
+}
+
+type accessory struct {
+	AID      int        `json:"aid"`
+	Services []*service `json:"services"`
+
+	iid int
+}
+
+// newAccessory creates an accessory with accessory information service
+func newAccessory(aid int, name, model, serial, firmware string) *accessory {
+	a := &accessory{AID: aid}
+
+	a.addService(serviceAccessoryInformation, "",
+		&characteristic{Type: charIdentify, Perms: []string{permWrite}, Format: "bool", set: func(any) error { return nil }},
+		readonly(charManufacturer, "string", "evcc"),
+		readonly(charModel, "string", model),
+		readonly(charName, "string", name),
+		readonly(charSerialNumber, "string", serial),
+		readonly(charFirmwareRevision, "string", firmware),
+	)
+
+	return a
+}
+
+// addService adds a service and assigns instance ids. A name characteristic is added if name is not empty.
+func (a *accessory) addService(typ, name string, cc ...*characteristic) *service {
+	a.iid++
+	s := &service{Type: typ, IID: a.iid}
+
+	if name != "" {
+		cc = append(cc, readonly(charName, "string", name))
+	}
+
+	for _, c := range cc {
+		a.iid++
+		c.IID = a.iid
+		s.Characteristics = append(s.Characteristics, c)
+	}
+
+	a.Services = append(a.Services, s)
+
+	return s
+}
+
+func (a *accessory) characteristic(iid int) *characteristic {
+	for _, s := range a.Services {
+		for _, c := range s.Characteristics {
+			if c.IID == iid {
+				return c
+			}
+		}
+	}
+	return nil
+}
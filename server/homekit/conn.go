@@ -0,0 +1,185 @@
+package homekit
+
+import (
+	"crypto/cipher"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// maxFrameSize is the maximum plaintext length of an encrypted frame
+const maxFrameSize = 1024
+
+// hkdfKey derives a 32 bytes key using HKDF-SHA-512
+func hkdfKey(secret []byte, salt, info string) ([]byte, error) {
+	key := make([]byte, 32)
+	_, err := io.ReadFull(hkdf.New(sha512.New, secret, []byte(salt), []byte(info)), key)
+	return key, err
+}
+
+// labelNonce returns the 12 bytes nonce for pairing messages like PS-Msg05
+func labelNonce(label string) []byte {
+	return append(make([]byte, 4), label...)
+}
+
+func counterNonce(count uint64) []byte {
+	nonce := make([]byte, 12)
+	binary.LittleEndian.PutUint64(nonce[4:], count)
+	return nonce
+}
+
+// seal encrypts data for the given pairing message label
+func seal(key []byte, label string, data []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nil, labelNonce(label), data, nil), nil
+}
+
+// open decrypts data for the given pairing message label
+func open(key []byte, label string, data []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, labelNonce(label), data, nil)
+}
+
+// conn is a HAP connection which is encrypted once pair verify has completed
+type conn struct {
+	net.Conn
+
+	// pairing state
+	setup  *setupSession
+	verify *verifySession
+
+	// controller is the paired controller id of the verified session
+	controller string
+
+	rmu        sync.Mutex
+	readKey    cipher.AEAD
+	readCount  uint64
+	readBuf    []byte
+	wmu        sync.Mutex
+	writeKey   cipher.AEAD
+	writeCount uint64
+}
+
+// listener wraps accepted connections as HAP connections
+type listener struct {
+	net.Listener
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: c}, nil
+}
+
+// encrypted returns true if the session has been verified
+func (c *conn) encrypted() bool {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	return c.writeKey != nil
+}
+
+// upgrade enables encryption using the pair verify shared secret
+func (c *conn) upgrade(shared []byte) error {
+	readKey, err := hkdfKey(shared, "Control-Salt", "Control-Write-Encryption-Key")
+	if err != nil {
+		return err
+	}
+
+	writeKey, err := hkdfKey(shared, "Control-Salt", "Control-Read-Encryption-Key")
+	if err != nil {
+		return err
+	}
+
+	c.rmu.Lock()
+	defer c.rmu.Unlock()
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if c.readKey, err = chacha20poly1305.New(readKey); err == nil {
+		c.writeKey, err = chacha20poly1305.New(writeKey)
+	}
+
+	return err
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	c.rmu.Lock()
+	defer c.rmu.Unlock()
+
+	if c.readKey == nil {
+		return c.Conn.Read(b)
+	}
+
+	if len(c.readBuf) == 0 {
+		aad := make([]byte, 2)
+		if _, err := io.ReadFull(c.Conn, aad); err != nil {
+			return 0, err
+		}
+
+		n := int(binary.LittleEndian.Uint16(aad))
+		if n > maxFrameSize {
+			return 0, errors.New("frame too large")
+		}
+
+		frame := make([]byte, n+chacha20poly1305.Overhead)
+		if _, err := io.ReadFull(c.Conn, frame); err != nil {
+			return 0, err
+		}
+
+		data, err := c.readKey.Open(nil, counterNonce(c.readCount), frame, aad)
+		if err != nil {
+			return 0, err
+		}
+
+		c.readCount++
+		c.readBuf = data
+	}
+
+	n := copy(b, c.readBuf)
+	c.readBuf = c.readBuf[n:]
+
+	return n, nil
+}
+
+func (c *conn) Write(b []byte) (int, error) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if c.writeKey == nil {
+		return c.Conn.Write(b)
+	}
+
+	var written int
+	for len(b) > 0 {
+		n := min(len(b), maxFrameSize)
+
+		aad := make([]byte, 2)
+		binary.LittleEndian.PutUint16(aad, uint16(n))
+
+		frame := c.writeKey.Seal(aad, counterNonce(c.writeCount), b[:n], aad)
+		c.writeCount++
+
+		if _, err := c.Conn.Write(frame); err != nil {
+			return written, err
+		}
+
+		written += n
+		b = b[n:]
+	}
+
+	return written, nil
+}
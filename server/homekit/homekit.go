@@ -0,0 +1,871 @@
+package homekit
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/gorilla/mux"
+	"github.com/libp2p/zeroconf/v2"
+	"github.com/samber/lo"
+)
+
+const (
+	hapJSON = "application/hap+json"
+
+	// siteID is the binding id of site values
+	siteID = -1
+)
+
+var (
+	pinRE = regexp.MustCompile(`^(\d{3})-?(\d{2})-?(\d{3})$`)
+
+	// invalidPins are rejected by iOS
+	invalidPins = []string{
+		"000-00-000", "111-11-111", "222-22-222", "333-33-333", "444-44-444",
+		"555-55-555", "666-66-666", "777-77-777", "888-88-888", "999-99-999",
+		"123-45-678", "876-54-321",
+	}
+)
+
+// state is the persisted bridge identity and its pairings
+type state struct {
+	ID       string             `json:"id"`
+	Seed     []byte             `json:"seed"`
+	Pin      string             `json:"pin,omitempty"`
+	Config   int                `json:"config"`
+	Hash     string             `json:"hash,omitempty"`
+	Pairings map[string]pairing `json:"pairings,omitempty"`
+	Failures int                `json:"failures,omitempty"` // unsuccessful pair setup attempts
+}
+
+type charID struct {
+	aid, iid int
+}
+
+type bindingKey struct {
+	lp  int
+	key string
+}
+
+type binding struct {
+	id   charID
+	conv func(any) any
+}
+
+// Bridge is a HomeKit accessory bridge exposing site and loadpoints
+type Bridge struct {
+	log  *util.Logger
+	name string
+	pin  string
+	id   string
+	key  ed25519.PrivateKey
+
+	mu          sync.RWMutex
+	state       state
+	setupRetry  time.Time // earliest next pair setup attempt
+	accessories []*accessory
+	bindings    map[bindingKey][]binding
+	subscribers map[*conn]map[charID]bool
+	mdns        *zeroconf.Server
+}
+
+// parsePin validates and formats the setup code as XXX-XX-XXX
+func parsePin(pin string) (string, error) {
+	m := pinRE.FindStringSubmatch(pin)
+	if m == nil {
+		return "", fmt.Errorf("invalid setup code: %s", pin)
+	}
+
+	res := strings.Join(m[1:], "-")
+	if slices.Contains(invalidPins, res) {
+		return "", fmt.Errorf("setup code not allowed: %s", res)
+	}
+
+	return res, nil
+}
+
+func randomPin() (string, error) {
+	for {
+		b := make([]byte, 8)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+
+		var digits string
+		for _, v := range b {
+			digits += strconv.Itoa(int(v) % 10)
+		}
+
+		if pin, err := parsePin(digits); err == nil {
+			return pin, nil
+		}
+	}
+}
+
+// New creates a HomeKit bridge. The setup code is generated if empty.
+func New(name, pin string, site site.API) (*Bridge, error) {
+	b := &Bridge{
+		log:         util.NewLogger("homekit"),
+		name:        name,
+		bindings:    make(map[bindingKey][]binding),
+		subscribers: make(map[*conn]map[charID]bool),
+	}
+
+	if b.name == "" {
+		b.name = "evcc"
+	}
+
+	if err := settings.Json(keys.HomeKit, &b.state); err != nil && !errors.Is(err, settings.ErrNotFound) {
+		return nil, err
+	}
+
+	if b.state.ID == "" {
+		id := make([]byte, 6)
+		if _, err := rand.Read(id); err != nil {
+			return nil, err
+		}
+		b.state.ID = fmt.Sprintf("%02X:%02X:%02X:%02X:%02X:%02X", id[0], id[1], id[2], id[3], id[4], id[5])
+	}
+
+	if len(b.state.Seed) != ed25519.SeedSize {
+		b.state.Seed = make([]byte, ed25519.SeedSize)
+		if _, err := rand.Read(b.state.Seed); err != nil {
+			return nil, err
+		}
+	}
+
+	b.id = b.state.ID
+	b.key = ed25519.NewKeyFromSeed(b.state.Seed)
+
+	var err error
+	if pin != "" {
+		b.pin, err = parsePin(pin)
+	} else {
+		if b.state.Pin == "" {
+			b.state.Pin, err = randomPin()
+		}
+		b.pin = b.state.Pin
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b.createAccessories(site)
+
+	// increase configuration number when the accessories change
+	if hash := b.accessoriesHash(); hash != b.state.Hash {
+		b.state.Config++
+		b.state.Hash = hash
+	}
+
+	if err := b.save(); err != nil {
+		return nil, err
+	}
+
+	if !b.paired() {
+		b.log.INFO.Printf("setup code: %s", b.pin)
+	}
+
+	return b, nil
+}
+
+// save persists the bridge state (no mutex)
+func (b *Bridge) save() error {
+	return settings.SetJson(keys.HomeKit, b.state)
+}
+
+// accessoriesHash identifies the accessory structure
+func (b *Bridge) accessoriesHash() string {
+	h := sha256.New()
+	for _, a := range b.accessories {
+		for _, s := range a.Services {
+			fmt.Fprintf(h, "%d.%d:%s;", a.AID, s.IID, s.Type)
+			for _, c := range s.Characteristics {
+				fmt.Fprintf(h, "%d.%d:%s;", a.AID, c.IID, c.Type)
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (b *Bridge) bind(lp int, key string, a *accessory, c *characteristic, conv func(any) any) {
+	k := bindingKey{lp: lp, key: key}
+	b.bindings[k] = append(b.bindings[k], binding{id: charID{a.AID, c.IID}, conv: conv})
+}
+
+func toFloat(v any) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
+
+func toBool(v any) (bool, error) {
+	switch val := v.(type) {
+	case bool:
+		return val, nil
+	case float64:
+		return val != 0, nil
+	default:
+		return false, fmt.Errorf("invalid value: %v", v)
+	}
+}
+
+// lux converts power into the light sensor range
+func lux(v any) any {
+	if f, ok := toFloat(v); ok {
+		return min(max(f, 0.0001), 100000)
+	}
+	return nil
+}
+
+func percent(v any) any {
+	if f, ok := toFloat(v); ok {
+		return uint8(min(max(f, 0), 100))
+	}
+	return nil
+}
+
+func boolean(v any) any {
+	if b, ok := v.(bool); ok {
+		return b
+	}
+	return nil
+}
+
+func (b *Bridge) createAccessories(site site.API) {
+	version := server.Version
+
+	bridge := newAccessory(1, b.name, "Bridge", b.id, version)
+	bridge.addService(serviceProtocolInformation, "", readonly(charVersion, "string", "1.1.0"))
+
+	// site
+	title := site.GetTitle()
+	if title == "" {
+		title = "Site"
+	}
+
+	sa := newAccessory(2, title, "Site", b.id+"-site", version)
+
+	sensor := func(name, key string, conv func(any) any) {
+		c := notifying(charAmbientLight, "float", 0.0001).withRange(0.0001, 100000, 0).withUnit("lux")
+		sa.addService(serviceLightSensor, name, c)
+		b.bind(siteID, key, sa, c, conv)
+	}
+
+	sensor("PV power", keys.PvPower, lux)
+	sensor("Home power", keys.HomePower, lux)
+	sensor("Grid import", keys.GridPower, lux)
+	sensor("Grid export", keys.GridPower, func(v any) any {
+		if f, ok := toFloat(v); ok {
+			return lux(-f)
+		}
+		return nil
+	})
+
+	if len(site.GetBatteryMeterRefs()) > 0 {
+		level := notifying(charBatteryLevel, "uint8", uint8(0)).withRange(0, 100, 1).withUnit("percentage")
+		low := notifying(charStatusLowBattery, "uint8", uint8(0)).withRange(0, 1, 1)
+		charging := notifying(charChargingState, "uint8", uint8(0)).withRange(0, 2, 1)
+		sa.addService(serviceBattery, "Battery", level, charging, low)
+		b.bind(siteID, keys.BatterySoc, sa, level, percent)
+		b.bind(siteID, keys.BatterySoc, sa, low, lowBattery)
+		b.bind(siteID, keys.BatteryPower, sa, charging, func(v any) any {
+			if f, ok := toFloat(v); ok && f < 0 {
+				return uint8(1)
+			}
+			return uint8(0)
+		})
+	}
+
+	b.accessories = append(b.accessories, bridge, sa)
+
+	// loadpoints
+	for id, lp := range site.Loadpoints() {
+		b.accessories = append(b.accessories, b.loadpointAccessory(id, lp, version))
+	}
+}
+
+func lowBattery(v any) any {
+	if f, ok := toFloat(v); ok {
+		if f < 20 {
+			return uint8(1)
+		}
+		return uint8(0)
+	}
+	return nil
+}
+
+// modeNames are the loadpoint mode switch names
+var modeNames = map[api.ChargeMode]string{
+	api.ModeOff:   "Off",
+	api.ModeNow:   "Fast",
+	api.ModeMinPV: "Min+Solar",
+	api.ModePV:    "Solar",
+}
+
+func (b *Bridge) loadpointAccessory(id int, lp loadpoint.API, version string) *accessory {
+	a := newAccessory(id+3, lp.Title(), "Loadpoint", fmt.Sprintf("%s-lp%d", b.id, id+1), version)
+
+	// charging outlet, switching on charges immediately
+	on := notifying(charOn, "bool", false).withSetter(func(v any) error {
+		on, err := toBool(v)
+		if err == nil {
+			mode := api.ModeOff
+			if on {
+				mode = api.ModeNow
+			}
+			lp.SetMode(mode)
+		}
+		return err
+	})
+	inUse := notifying(charOutletInUse, "bool", false)
+	power := notifying(charPower, "float", 0.0).withUnit("W")
+
+	outlet := a.addService(serviceOutlet, "Charging", on, inUse, power)
+	outlet.Primary = true
+
+	b.bind(id, keys.Charging, a, on, boolean)
+	b.bind(id, keys.Connected, a, inUse, boolean)
+	b.bind(id, keys.ChargePower, a, power, func(v any) any {
+		if f, ok := toFloat(v); ok {
+			return f
+		}
+		return nil
+	})
+
+	// mode switches
+	for _, mode := range []api.ChargeMode{api.ModeOff, api.ModeNow, api.ModeMinPV, api.ModePV} {
+		sw := notifying(charOn, "bool", false).withSetter(func(v any) error {
+			on, err := toBool(v)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case on:
+				lp.SetMode(mode)
+			case mode != api.ModeOff && lp.GetMode() == mode:
+				lp.SetMode(api.ModeOff)
+			}
+
+			return nil
+		})
+
+		a.addService(serviceSwitch, modeNames[mode], sw)
+
+		b.bind(id, keys.Mode, a, sw, func(v any) any {
+			if m, ok := v.(api.ChargeMode); ok {
+				return m == mode
+			}
+			return nil
+		})
+	}
+
+	// vehicle battery
+	level := notifying(charBatteryLevel, "uint8", uint8(0)).withRange(0, 100, 1).withUnit("percentage")
+	charging := notifying(charChargingState, "uint8", uint8(0)).withRange(0, 2, 1)
+	low := notifying(charStatusLowBattery, "uint8", uint8(0)).withRange(0, 1, 1)
+
+	a.addService(serviceBattery, "Vehicle", level, charging, low)
+
+	b.bind(id, keys.VehicleSoc, a, level, percent)
+	b.bind(id, keys.VehicleSoc, a, low, lowBattery)
+	b.bind(id, keys.Charging, a, charging, func(v any) any {
+		if b, ok := v.(bool); ok && b {
+			return uint8(1)
+		}
+		return uint8(0)
+	})
+
+	return a
+}
+
+func (b *Bridge) characteristic(id charID) *characteristic {
+	for _, a := range b.accessories {
+		if a.AID == id.aid {
+			return a.characteristic(id.iid)
+		}
+	}
+	return nil
+}
+
+// Run updates characteristic values and notifies subscribed controllers
+func (b *Bridge) Run(in <-chan util.Param) {
+	for p := range in {
+		lp := siteID
+		if p.Loadpoint != nil {
+			lp = *p.Loadpoint
+		}
+
+		bb, ok := b.bindings[bindingKey{lp: lp, key: p.Key}]
+		if !ok {
+			continue
+		}
+
+		var changed []charID
+
+		b.mu.Lock()
+		for _, bi := range bb {
+			val := bi.conv(p.Val)
+			if val == nil {
+				continue
+			}
+
+			if c := b.characteristic(bi.id); c != nil && c.Value != val {
+				c.Value = val
+				changed = append(changed, bi.id)
+			}
+		}
+		b.mu.Unlock()
+
+		if len(changed) > 0 {
+			b.notify(changed)
+		}
+	}
+}
+
+type charValue struct {
+	AID    int  `json:"aid"`
+	IID    int  `json:"iid"`
+	Value  any  `json:"value,omitempty"`
+	Status *int `json:"status,omitempty"`
+}
+
+// notify sends event notifications for changed characteristics to subscribed controllers
+func (b *Bridge) notify(changed []charID) {
+	type event struct {
+		conn   *conn
+		values []charValue
+	}
+
+	var events []event
+
+	b.mu.RLock()
+	for c, subs := range b.subscribers {
+		var values []charValue
+		for _, id := range changed {
+			if subs[id] {
+				values = append(values, charValue{AID: id.aid, IID: id.iid, Value: b.characteristic(id).Value})
+			}
+		}
+
+		if len(values) > 0 {
+			events = append(events, event{conn: c, values: values})
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, ev := range events {
+		body, err := json.Marshal(struct {
+			Characteristics []charValue `json:"characteristics"`
+		}{ev.values})
+		if err != nil {
+			continue
+		}
+
+		msg := fmt.Sprintf("EVENT/1.0 200 OK\r\nContent-Type: %s\r\nContent-Length: %d\r\n\r\n%s", hapJSON, len(body), body)
+
+		_ = ev.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+		if _, err := ev.conn.Write([]byte(msg)); err != nil {
+			b.log.DEBUG.Printf("event: %v", err)
+		}
+		_ = ev.conn.SetWriteDeadline(time.Time{})
+	}
+}
+
+//
+// pairings
+//
+
+type namedPairing struct {
+	pairing
+	id string
+}
+
+func (b *Bridge) paired() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.state.Pairings) > 0
+}
+
+// setupDelay returns the remaining delay until the next pair setup attempt
+func (b *Bridge) setupDelay() time.Duration {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return max(time.Until(b.setupRetry), 0)
+}
+
+// setupFailed counts an unsuccessful pair setup attempt and delays the next attempt
+func (b *Bridge) setupFailed() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state.Failures++
+
+	delay := setupBackoffMax
+	if shift := b.state.Failures - 1; shift < 32 {
+		delay = min(setupBackoff<<shift, setupBackoffMax)
+	}
+	b.setupRetry = time.Now().Add(delay)
+
+	b.log.WARN.Printf("pair setup delayed by %v after %d unsuccessful attempts", delay, b.state.Failures)
+
+	if err := b.save(); err != nil {
+		b.log.ERROR.Println(err)
+	}
+}
+
+func (b *Bridge) pairing(id string) (pairing, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	p, ok := b.state.Pairings[id]
+	return p, ok
+}
+
+func (b *Bridge) pairings() []namedPairing {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var res []namedPairing
+	for _, id := range slices.Sorted(maps.Keys(b.state.Pairings)) {
+		res = append(res, namedPairing{pairing: b.state.Pairings[id], id: id})
+	}
+
+	return res
+}
+
+func (b *Bridge) addPairing(id string, p pairing) error {
+	if id == "" || len(p.PublicKey) != ed25519.PublicKeySize {
+		return errors.New("invalid pairing")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if existing, ok := b.state.Pairings[id]; ok && !slices.Equal(existing.PublicKey, p.PublicKey) {
+		return errors.New("pairing key mismatch")
+	}
+
+	if b.state.Pairings == nil {
+		b.state.Pairings = make(map[string]pairing)
+	}
+	b.state.Pairings[id] = p
+	b.state.Failures = 0
+
+	b.updateMDNS()
+
+	return b.save()
+}
+
+// removePairing removes the pairing. All pairings are removed with the last admin.
+func (b *Bridge) removePairing(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.state.Pairings, id)
+
+	if !slices.ContainsFunc(slices.Collect(maps.Values(b.state.Pairings)), func(p pairing) bool { return p.Admin }) {
+		clear(b.state.Pairings)
+	}
+
+	// close sessions of removed controllers
+	for c := range b.subscribers {
+		if _, ok := b.state.Pairings[c.controller]; !ok {
+			_ = c.Close()
+		}
+	}
+
+	b.updateMDNS()
+
+	return b.save()
+}
+
+//
+// server
+//
+
+type connKey struct{}
+
+func connFromContext(ctx context.Context) *conn {
+	c, _ := ctx.Value(connKey{}).(*conn)
+	return c
+}
+
+// mdnsText returns the _hap._tcp txt records (no mutex)
+func (b *Bridge) mdnsText() []string {
+	sf := "1"
+	if len(b.state.Pairings) > 0 {
+		sf = "0"
+	}
+
+	return []string{
+		"c#=" + strconv.Itoa(b.state.Config),
+		"ff=0",
+		"id=" + b.id,
+		"md=" + b.name,
+		"pv=1.1",
+		"s#=1",
+		"sf=" + sf,
+		"ci=2", // bridge
+	}
+}
+
+// updateMDNS updates the pairing status flag (no mutex)
+func (b *Bridge) updateMDNS() {
+	if b.mdns != nil {
+		b.mdns.SetText(b.mdnsText())
+	}
+}
+
+func (b *Bridge) router() http.Handler {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/pair-setup", b.pairSetupHandler).Methods(http.MethodPost)
+	router.HandleFunc("/pair-verify", b.pairVerifyHandler).Methods(http.MethodPost)
+	router.HandleFunc("/identify", b.identifyHandler).Methods(http.MethodPost)
+
+	secure := router.NewRoute().Subrouter()
+	secure.Use(b.ensureSessionHandler)
+	secure.HandleFunc("/pairings", b.pairingsHandler).Methods(http.MethodPost)
+	secure.HandleFunc("/accessories", b.accessoriesHandler).Methods(http.MethodGet)
+	secure.HandleFunc("/characteristics", b.getCharacteristicsHandler).Methods(http.MethodGet)
+	secure.HandleFunc("/characteristics", b.putCharacteristicsHandler).Methods(http.MethodPut)
+
+	return router
+}
+
+// Serve announces the bridge and serves HAP requests on the given port
+func (b *Bridge) Serve(port int) error {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.mdns, err = zeroconf.Register(b.name, "_hap._tcp", "local.", l.Addr().(*net.TCPAddr).Port, b.mdnsText(), nil)
+	b.mu.Unlock()
+	if err != nil {
+		l.Close()
+		return fmt.Errorf("mDNS announcement: %w", err)
+	}
+	defer b.mdns.Shutdown()
+
+	b.log.INFO.Printf("listening at :%d", port)
+
+	return b.serve(l)
+}
+
+func (b *Bridge) serve(l net.Listener) error {
+	srv := &http.Server{
+		Handler:           b.router(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ConnContext: func(ctx context.Context, c net.Conn) context.Context {
+			return context.WithValue(ctx, connKey{}, c)
+		},
+		ConnState: func(c net.Conn, state http.ConnState) {
+			if state == http.StateClosed || state == http.StateHijacked {
+				b.mu.Lock()
+				delete(b.subscribers, c.(*conn))
+				b.mu.Unlock()
+			}
+		},
+	}
+
+	return srv.Serve(&listener{Listener: l})
+}
+
+func writeHAPJSON(w http.ResponseWriter, status int, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", hapJSON)
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+func writeHAPStatus(w http.ResponseWriter, code, status int) {
+	writeHAPJSON(w, code, struct {
+		Status int `json:"status"`
+	}{status})
+}
+
+// ensureSessionHandler requires a verified and encrypted session
+func (b *Bridge) ensureSessionHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c := connFromContext(r.Context()); c == nil || !c.encrypted() {
+			writeHAPStatus(w, 470, statusInsufficientPriv)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (b *Bridge) identifyHandler(w http.ResponseWriter, r *http.Request) {
+	if b.paired() {
+		writeHAPStatus(w, http.StatusBadRequest, statusInsufficientPriv)
+		return
+	}
+
+	b.log.INFO.Println("identify")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Bridge) accessoriesHandler(w http.ResponseWriter, r *http.Request) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	writeHAPJSON(w, http.StatusOK, struct {
+		Accessories []*accessory `json:"accessories"`
+	}{b.accessories})
+}
+
+// multiStatus writes the characteristic results, using multi-status if any request failed
+func multiStatus(w http.ResponseWriter, res []charValue, failed bool, ok int) {
+	status := ok
+	if failed {
+		status = http.StatusMultiStatus
+		for i := range res {
+			if res[i].Status == nil {
+				res[i].Status = new(int)
+			}
+		}
+	}
+
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+
+	writeHAPJSON(w, status, struct {
+		Characteristics []charValue `json:"characteristics"`
+	}{res})
+}
+
+func (b *Bridge) getCharacteristicsHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		res    []charValue
+		failed bool
+	)
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, s := range strings.Split(r.URL.Query().Get("id"), ",") {
+		aid, iid, ok := strings.Cut(s, ".")
+		a, err1 := strconv.Atoi(aid)
+		i, err2 := strconv.Atoi(iid)
+		if !ok || err1 != nil || err2 != nil {
+			writeHAPStatus(w, http.StatusBadRequest, statusInvalidValue)
+			return
+		}
+
+		v := charValue{AID: a, IID: i}
+
+		switch c := b.characteristic(charID{a, i}); {
+		case c == nil:
+			v.Status, failed = lo.ToPtr(statusNotFound), true
+		case !c.can(permRead):
+			v.Status, failed = lo.ToPtr(statusWriteOnly), true
+		default:
+			v.Value = c.Value
+		}
+
+		res = append(res, v)
+	}
+
+	multiStatus(w, res, failed, http.StatusOK)
+}
+
+func (b *Bridge) putCharacteristicsHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Characteristics []struct {
+			AID   int   `json:"aid"`
+			IID   int   `json:"iid"`
+			Value any   `json:"value"`
+			Ev    *bool `json:"ev"`
+		} `json:"characteristics"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeHAPStatus(w, http.StatusBadRequest, statusInvalidValue)
+		return
+	}
+
+	conn := connFromContext(r.Context())
+
+	var (
+		res    []charValue
+		failed bool
+	)
+
+	for _, cr := range req.Characteristics {
+		id := charID{cr.AID, cr.IID}
+		v := charValue{AID: cr.AID, IID: cr.IID}
+
+		b.mu.Lock()
+		c := b.characteristic(id)
+
+		switch {
+		case c == nil:
+			v.Status = lo.ToPtr(statusNotFound)
+
+		case cr.Ev != nil && !c.can(permEvents):
+			v.Status = lo.ToPtr(statusNotifyDenied)
+
+		case cr.Ev != nil:
+			subs, ok := b.subscribers[conn]
+			if !ok {
+				subs = make(map[charID]bool)
+				b.subscribers[conn] = subs
+			}
+			subs[id] = *cr.Ev
+
+		case cr.Value != nil && !c.can(permWrite):
+			v.Status = lo.ToPtr(statusReadOnly)
+		}
+
+		var set func(any) error
+		if c != nil {
+			set = c.set
+		}
+		b.mu.Unlock()
+
+		// write outside lock, loadpoint updates are published back via Run
+		if v.Status == nil && cr.Value != nil && set != nil {
+			if err := set(cr.Value); err != nil {
+				b.log.DEBUG.Printf("write %d.%d: %v", cr.AID, cr.IID, err)
+				v.Status = lo.ToPtr(statusCommunication)
+			}
+		}
+
+		failed = failed || v.Status != nil
+		res = append(res, v)
+	}
+
+	multiStatus(w, res, failed, http.StatusNoContent)
+}
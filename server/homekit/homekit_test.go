@@ -0,0 +1,295 @@
+package homekit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+)
+
+type testSite struct {
+	site.API
+	lps []loadpoint.API
+}
+
+func (s *testSite) GetTitle() string              { return "Home" }
+func (s *testSite) GetBatteryMeterRefs() []string { return nil }
+func (s *testSite) Loadpoints() []loadpoint.API   { return s.lps }
+
+func TestTLV8(t *testing.T) {
+	long := bytes.Repeat([]byte{1}, 300)
+
+	in := new(tlv8).setByte(tlvState, 1).set(tlvPublicKey, long).set(tlvSeparator, nil).set(tlvIdentifier, []byte("id"))
+
+	out, err := decodeTLV8(in.encode())
+	require.NoError(t, err)
+
+	state, _ := out.getByte(tlvState)
+	assert.Equal(t, byte(1), state)
+	assert.Equal(t, long, out.get(tlvPublicKey))
+	assert.Equal(t, []byte("id"), out.get(tlvIdentifier))
+}
+
+func TestParsePin(t *testing.T) {
+	pin, err := parsePin("03145154")
+	require.NoError(t, err)
+	assert.Equal(t, "031-45-154", pin)
+
+	_, err = parsePin("123-45-678")
+	assert.Error(t, err)
+
+	_, err = parsePin("1234")
+	assert.Error(t, err)
+}
+
+// hapClient is the controller side of a HAP connection
+type hapClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func (c *hapClient) do(method, path, contentType string, body []byte) (*http.Response, []byte) {
+	req, err := http.NewRequest(method, "http://bridge"+path, bytes.NewReader(body))
+	require.NoError(c.t, err)
+	req.Header.Set("Content-Type", contentType)
+	require.NoError(c.t, req.Write(c.conn))
+
+	res, err := http.ReadResponse(c.r, req)
+	require.NoError(c.t, err)
+
+	var b bytes.Buffer
+	_, err = b.ReadFrom(res.Body)
+	require.NoError(c.t, err)
+
+	return res, b.Bytes()
+}
+
+func (c *hapClient) tlv(path string, req *tlv8) *tlv8 {
+	_, b := c.do(http.MethodPost, path, "application/pairing+tlv8", req.encode())
+	res, err := decodeTLV8(b)
+	require.NoError(c.t, err)
+	return res
+}
+
+func TestPairingAndSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().Title().Return("Garage").AnyTimes()
+
+	const pin = "031-45-154"
+
+	b, err := New("evcc", pin, &testSite{lps: []loadpoint.API{lp}})
+	require.NoError(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	go func() { _ = b.serve(l) }()
+
+	raw, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	defer raw.Close()
+
+	c := &hapClient{t: t, conn: raw, r: bufio.NewReader(raw)}
+
+	// unverified session
+	res, _ := c.do(http.MethodGet, "/accessories", hapJSON, nil)
+	assert.Equal(t, 470, res.StatusCode)
+
+	// pair setup M1
+	m2 := c.tlv("/pair-setup", new(tlv8).setByte(tlvState, 1).setByte(tlvMethod, methodPairSetup))
+	salt, B := m2.get(tlvSalt), srpInt(m2.get(tlvPublicKey))
+
+	// client srp
+	a, _ := rand.Int(rand.Reader, srpPrime)
+	A := new(big.Int).Exp(srpGenerator, a, srpPrime)
+	x := srpInt(srpHash(salt, srpHash([]byte(srpUsername+":"+pin))))
+	k := srpInt(srpHash(srpPrime.Bytes(), srpPad(srpGenerator.Bytes())))
+	u := srpInt(srpHash(srpPad(A.Bytes()), srpPad(B.Bytes())))
+
+	base := new(big.Int).Sub(B, new(big.Int).Mul(k, new(big.Int).Exp(srpGenerator, x, srpPrime)))
+	base.Mod(base, srpPrime)
+	S := new(big.Int).Exp(base, new(big.Int).Add(a, new(big.Int).Mul(u, x)), srpPrime)
+	K := srpHash(S.Bytes())
+
+	hn, hg := srpHash(srpPrime.Bytes()), srpHash(srpGenerator.Bytes())
+	for i := range hn {
+		hn[i] ^= hg[i]
+	}
+	M1 := srpHash(hn, srpHash([]byte(srpUsername)), salt, A.Bytes(), B.Bytes(), K)
+
+	// pair setup M3
+	m4 := c.tlv("/pair-setup", new(tlv8).setByte(tlvState, 3).set(tlvPublicKey, A.Bytes()).set(tlvProof, M1))
+	require.Nil(t, m4.get(tlvError))
+	assert.Equal(t, srpHash(A.Bytes(), M1, K), m4.get(tlvProof))
+
+	// pair setup M5
+	ctrlPub, ctrlKey, _ := ed25519.GenerateKey(rand.Reader)
+	ctrlID := []byte("controller")
+
+	encKey, _ := hkdfKey(K, "Pair-Setup-Encrypt-Salt", "Pair-Setup-Encrypt-Info")
+	signKey, _ := hkdfKey(K, "Pair-Setup-Controller-Sign-Salt", "Pair-Setup-Controller-Sign-Info")
+
+	sub := new(tlv8).
+		set(tlvIdentifier, ctrlID).
+		set(tlvPublicKey, ctrlPub).
+		set(tlvSignature, ed25519.Sign(ctrlKey, concat(signKey, ctrlID, ctrlPub)))
+	enc, err := seal(encKey, "PS-Msg05", sub.encode())
+	require.NoError(t, err)
+
+	m6 := c.tlv("/pair-setup", new(tlv8).setByte(tlvState, 5).set(tlvEncryptedData, enc))
+	require.Nil(t, m6.get(tlvError))
+
+	data, err := open(encKey, "PS-Msg06", m6.get(tlvEncryptedData))
+	require.NoError(t, err)
+	acc, err := decodeTLV8(data)
+	require.NoError(t, err)
+
+	accID, accPub := acc.get(tlvIdentifier), acc.get(tlvPublicKey)
+	accSign, _ := hkdfKey(K, "Pair-Setup-Accessory-Sign-Salt", "Pair-Setup-Accessory-Sign-Info")
+	assert.True(t, ed25519.Verify(accPub, concat(accSign, accID, accPub), acc.get(tlvSignature)))
+	assert.True(t, b.paired())
+
+	// pair verify M1
+	priv := make([]byte, curve25519.ScalarSize)
+	_, _ = rand.Read(priv)
+	pub, _ := curve25519.X25519(priv, curve25519.Basepoint)
+
+	v2 := c.tlv("/pair-verify", new(tlv8).setByte(tlvState, 1).set(tlvPublicKey, pub))
+	sessionPub := v2.get(tlvPublicKey)
+	shared, err := curve25519.X25519(priv, sessionPub)
+	require.NoError(t, err)
+
+	verifyKey, _ := hkdfKey(shared, "Pair-Verify-Encrypt-Salt", "Pair-Verify-Encrypt-Info")
+	data, err = open(verifyKey, "PV-Msg02", v2.get(tlvEncryptedData))
+	require.NoError(t, err)
+	acc, err = decodeTLV8(data)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(accPub, concat(sessionPub, accID, pub), acc.get(tlvSignature)))
+
+	// pair verify M3
+	sub = new(tlv8).
+		set(tlvIdentifier, ctrlID).
+		set(tlvSignature, ed25519.Sign(ctrlKey, concat(pub, ctrlID, sessionPub)))
+	enc, err = seal(verifyKey, "PV-Msg03", sub.encode())
+	require.NoError(t, err)
+
+	v4 := c.tlv("/pair-verify", new(tlv8).setByte(tlvState, 3).set(tlvEncryptedData, enc))
+	require.Nil(t, v4.get(tlvError))
+
+	// encrypted session with swapped keys
+	readKey, _ := hkdfKey(shared, "Control-Salt", "Control-Read-Encryption-Key")
+	writeKey, _ := hkdfKey(shared, "Control-Salt", "Control-Write-Encryption-Key")
+
+	sc := &conn{Conn: raw}
+	sc.readKey, _ = chacha20poly1305.New(readKey)
+	sc.writeKey, _ = chacha20poly1305.New(writeKey)
+
+	c.conn, c.r = sc, bufio.NewReader(sc)
+
+	res, body := c.do(http.MethodGet, "/accessories", hapJSON, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var accessories struct {
+		Accessories []accessory `json:"accessories"`
+	}
+	require.NoError(t, json.Unmarshal(body, &accessories))
+	require.Len(t, accessories.Accessories, 3)
+
+	// find loadpoint charging and fast mode switch
+	var charging, fast charID
+	for _, s := range b.accessories[2].Services {
+		name := s.Characteristics[len(s.Characteristics)-1].Value
+		switch {
+		case s.Type == serviceOutlet:
+			charging = charID{3, s.Characteristics[0].IID}
+		case s.Type == serviceSwitch && name == "Fast":
+			fast = charID{3, s.Characteristics[0].IID}
+		}
+	}
+
+	// subscribe and write
+	lp.EXPECT().SetMode(api.ModeNow)
+
+	put, _ := json.Marshal(map[string]any{"characteristics": []map[string]any{
+		{"aid": charging.aid, "iid": charging.iid, "ev": true},
+		{"aid": fast.aid, "iid": fast.iid, "value": 1},
+	}})
+
+	res, _ = c.do(http.MethodPut, "/characteristics", hapJSON, put)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+	// event
+	in := make(chan util.Param, 1)
+	in <- util.Param{Loadpoint: lo.ToPtr(0), Key: keys.Charging, Val: true}
+	close(in)
+	b.Run(in)
+
+	line, err := c.r.ReadString('\n')
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(line, "EVENT/1.0 200 OK"))
+}
+
+func TestPairSetupBackoff(t *testing.T) {
+	b, err := New("evcc", "031-45-154", &testSite{})
+	require.NoError(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	go func() { _ = b.serve(l) }()
+
+	raw, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	defer raw.Close()
+
+	c := &hapClient{t: t, conn: raw, r: bufio.NewReader(raw)}
+
+	b.state.Pairings = nil
+	b.state.Failures = 9
+
+	// attempt with invalid proof
+	m2 := c.tlv("/pair-setup", new(tlv8).setByte(tlvState, 1).setByte(tlvMethod, methodPairSetup))
+	require.Nil(t, m2.get(tlvError))
+
+	m4 := c.tlv("/pair-setup", new(tlv8).setByte(tlvState, 3).set(tlvPublicKey, []byte{1}).set(tlvProof, []byte{1}))
+	code, _ := m4.getByte(tlvError)
+	assert.Equal(t, byte(tlvErrorAuthentication), code)
+
+	// delayed
+	m2 = c.tlv("/pair-setup", new(tlv8).setByte(tlvState, 1).setByte(tlvMethod, methodPairSetup))
+	code, _ = m2.getByte(tlvError)
+	assert.Equal(t, byte(tlvErrorBackoff), code)
+	assert.Equal(t, []byte{0x00, 0x02}, m2.get(tlvRetryDelay), "512s")
+
+	// retry after delay
+	b.mu.Lock()
+	b.setupRetry = time.Now()
+	b.mu.Unlock()
+
+	m2 = c.tlv("/pair-setup", new(tlv8).setByte(tlvState, 1).setByte(tlvMethod, methodPairSetup))
+	assert.Nil(t, m2.get(tlvError))
+}
@@ -0,0 +1,358 @@
+package homekit
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+// pairing is a paired controller
+type pairing struct {
+	PublicKey []byte `json:"publicKey"`
+	Admin     bool   `json:"admin"`
+}
+
+// pair setup is delayed after unsuccessful attempts, doubling with every attempt up to the maximum
+const (
+	setupBackoff    = time.Second
+	setupBackoffMax = time.Hour
+)
+
+// setupSession is the pair setup state of a connection
+type setupSession struct {
+	srp *srpServer
+	key []byte
+}
+
+// verifySession is the pair verify state of a connection
+type verifySession struct {
+	shared        []byte
+	key           []byte
+	publicKey     []byte
+	controllerKey []byte
+}
+
+func readTLV(r *http.Request) (*tlv8, error) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeTLV8(b)
+}
+
+func writeTLV(w http.ResponseWriter, t *tlv8) {
+	b := t.encode()
+	w.Header().Set("Content-Type", "application/pairing+tlv8")
+	w.Header().Set("Content-Length", fmt.Sprint(len(b)))
+	_, _ = w.Write(b)
+}
+
+// tlvErrorResponse returns an error response for the given state
+func tlvErrorResponse(state, code byte) *tlv8 {
+	return new(tlv8).setByte(tlvState, state).setByte(tlvError, code)
+}
+
+// pairSetupHandler handles the pair setup M1, M3 and M5 requests
+func (b *Bridge) pairSetupHandler(w http.ResponseWriter, r *http.Request) {
+	c := connFromContext(r.Context())
+
+	req, err := readTLV(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var res *tlv8
+	switch state, _ := req.getByte(tlvState); state {
+	case 1:
+		res = b.pairSetupStart(c)
+	case 3:
+		res = b.pairSetupVerify(c, req)
+	case 5:
+		res = b.pairSetupExchange(c, req)
+	default:
+		res = tlvErrorResponse(state+1, tlvErrorUnknown)
+	}
+
+	writeTLV(w, res)
+}
+
+// pairSetupStart answers M1 with the SRP salt and public key
+func (b *Bridge) pairSetupStart(c *conn) *tlv8 {
+	if b.paired() {
+		return tlvErrorResponse(2, tlvErrorUnavailable)
+	}
+
+	if delay := b.setupDelay(); delay > 0 {
+		seconds := uint16(delay.Round(time.Second).Seconds())
+		return tlvErrorResponse(2, tlvErrorBackoff).set(tlvRetryDelay, binary.LittleEndian.AppendUint16(nil, seconds))
+	}
+
+	srp, err := newSRPServer(b.pin)
+	if err != nil {
+		b.log.ERROR.Printf("pair setup: %v", err)
+		return tlvErrorResponse(2, tlvErrorUnknown)
+	}
+
+	c.setup = &setupSession{srp: srp}
+
+	return new(tlv8).
+		setByte(tlvState, 2).
+		set(tlvSalt, srp.salt).
+		set(tlvPublicKey, srp.PublicKey())
+}
+
+// pairSetupVerify answers M3 with the accessory SRP proof
+func (b *Bridge) pairSetupVerify(c *conn, req *tlv8) *tlv8 {
+	if c.setup == nil {
+		return tlvErrorResponse(4, tlvErrorUnknown)
+	}
+
+	proof, err := c.setup.srp.Verify(req.get(tlvPublicKey), req.get(tlvProof))
+	if err != nil {
+		b.log.WARN.Printf("pair setup: %v", err)
+		c.setup = nil
+		b.setupFailed()
+		return tlvErrorResponse(4, tlvErrorAuthentication)
+	}
+
+	if c.setup.key, err = hkdfKey(c.setup.srp.K, "Pair-Setup-Encrypt-Salt", "Pair-Setup-Encrypt-Info"); err != nil {
+		return tlvErrorResponse(4, tlvErrorUnknown)
+	}
+
+	return new(tlv8).
+		setByte(tlvState, 4).
+		set(tlvProof, proof)
+}
+
+// pairSetupExchange answers M5 by storing the controller's long-term key and returning the accessory's
+func (b *Bridge) pairSetupExchange(c *conn, req *tlv8) *tlv8 {
+	if c.setup == nil || c.setup.key == nil {
+		return tlvErrorResponse(6, tlvErrorUnknown)
+	}
+
+	setup := c.setup
+	c.setup = nil
+
+	data, err := open(setup.key, "PS-Msg05", req.get(tlvEncryptedData))
+	if err != nil {
+		return tlvErrorResponse(6, tlvErrorAuthentication)
+	}
+
+	sub, err := decodeTLV8(data)
+	if err != nil {
+		return tlvErrorResponse(6, tlvErrorUnknown)
+	}
+
+	id, ltpk, sig := sub.get(tlvIdentifier), sub.get(tlvPublicKey), sub.get(tlvSignature)
+
+	x, err := hkdfKey(setup.srp.K, "Pair-Setup-Controller-Sign-Salt", "Pair-Setup-Controller-Sign-Info")
+	if err != nil || len(ltpk) != ed25519.PublicKeySize || !ed25519.Verify(ltpk, concat(x, id, ltpk), sig) {
+		return tlvErrorResponse(6, tlvErrorAuthentication)
+	}
+
+	if err := b.addPairing(string(id), pairing{PublicKey: ltpk, Admin: true}); err != nil {
+		b.log.ERROR.Printf("pair setup: %v", err)
+		return tlvErrorResponse(6, tlvErrorMaxPeers)
+	}
+
+	if x, err = hkdfKey(setup.srp.K, "Pair-Setup-Accessory-Sign-Salt", "Pair-Setup-Accessory-Sign-Info"); err != nil {
+		return tlvErrorResponse(6, tlvErrorUnknown)
+	}
+
+	pub := b.key.Public().(ed25519.PublicKey)
+	res := new(tlv8).
+		set(tlvIdentifier, []byte(b.id)).
+		set(tlvPublicKey, pub).
+		set(tlvSignature, ed25519.Sign(b.key, concat(x, []byte(b.id), pub)))
+
+	enc, err := seal(setup.key, "PS-Msg06", res.encode())
+	if err != nil {
+		return tlvErrorResponse(6, tlvErrorUnknown)
+	}
+
+	b.log.INFO.Printf("paired with controller %s", id)
+
+	return new(tlv8).
+		setByte(tlvState, 6).
+		set(tlvEncryptedData, enc)
+}
+
+// pairVerifyHandler handles the pair verify M1 and M3 requests
+func (b *Bridge) pairVerifyHandler(w http.ResponseWriter, r *http.Request) {
+	c := connFromContext(r.Context())
+
+	req, err := readTLV(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch state, _ := req.getByte(tlvState); state {
+	case 1:
+		writeTLV(w, b.pairVerifyStart(c, req))
+
+	case 3:
+		res, ok := b.pairVerifyFinish(c, req)
+		writeTLV(w, res)
+
+		if ok {
+			// response is sent in plain text, encryption starts afterwards
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+
+			if err := c.upgrade(c.verify.shared); err != nil {
+				b.log.ERROR.Printf("pair verify: %v", err)
+			}
+		}
+
+		c.verify = nil
+
+	default:
+		writeTLV(w, tlvErrorResponse(state+1, tlvErrorUnknown))
+	}
+}
+
+// pairVerifyStart answers M1 with the accessory's ephemeral public key and signed proof
+func (b *Bridge) pairVerifyStart(c *conn, req *tlv8) *tlv8 {
+	controllerKey := req.get(tlvPublicKey)
+
+	priv := make([]byte, curve25519.ScalarSize)
+	if _, err := rand.Read(priv); err != nil {
+		return tlvErrorResponse(2, tlvErrorUnknown)
+	}
+
+	pub, err := curve25519.X25519(priv, curve25519.Basepoint)
+	if err != nil {
+		return tlvErrorResponse(2, tlvErrorUnknown)
+	}
+
+	shared, err := curve25519.X25519(priv, controllerKey)
+	if err != nil {
+		return tlvErrorResponse(2, tlvErrorAuthentication)
+	}
+
+	key, err := hkdfKey(shared, "Pair-Verify-Encrypt-Salt", "Pair-Verify-Encrypt-Info")
+	if err != nil {
+		return tlvErrorResponse(2, tlvErrorUnknown)
+	}
+
+	sub := new(tlv8).
+		set(tlvIdentifier, []byte(b.id)).
+		set(tlvSignature, ed25519.Sign(b.key, concat(pub, []byte(b.id), controllerKey)))
+
+	enc, err := seal(key, "PV-Msg02", sub.encode())
+	if err != nil {
+		return tlvErrorResponse(2, tlvErrorUnknown)
+	}
+
+	c.verify = &verifySession{
+		shared:        shared,
+		key:           key,
+		publicKey:     pub,
+		controllerKey: controllerKey,
+	}
+
+	return new(tlv8).
+		setByte(tlvState, 2).
+		set(tlvPublicKey, pub).
+		set(tlvEncryptedData, enc)
+}
+
+// pairVerifyFinish verifies the controller's proof from M3
+func (b *Bridge) pairVerifyFinish(c *conn, req *tlv8) (*tlv8, bool) {
+	if c.verify == nil {
+		return tlvErrorResponse(4, tlvErrorUnknown), false
+	}
+
+	data, err := open(c.verify.key, "PV-Msg03", req.get(tlvEncryptedData))
+	if err != nil {
+		return tlvErrorResponse(4, tlvErrorAuthentication), false
+	}
+
+	sub, err := decodeTLV8(data)
+	if err != nil {
+		return tlvErrorResponse(4, tlvErrorUnknown), false
+	}
+
+	id, sig := sub.get(tlvIdentifier), sub.get(tlvSignature)
+
+	p, ok := b.pairing(string(id))
+	if !ok || !ed25519.Verify(p.PublicKey, concat(c.verify.controllerKey, id, c.verify.publicKey), sig) {
+		return tlvErrorResponse(4, tlvErrorAuthentication), false
+	}
+
+	c.controller = string(id)
+
+	return new(tlv8).setByte(tlvState, 4), true
+}
+
+// pairingsHandler adds, removes and lists pairings. Requires an admin controller.
+func (b *Bridge) pairingsHandler(w http.ResponseWriter, r *http.Request) {
+	c := connFromContext(r.Context())
+
+	req, err := readTLV(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if p, ok := b.pairing(c.controller); !ok || !p.Admin {
+		writeTLV(w, tlvErrorResponse(2, tlvErrorAuthentication))
+		return
+	}
+
+	method, _ := req.getByte(tlvMethod)
+	id := string(req.get(tlvIdentifier))
+
+	res := new(tlv8).setByte(tlvState, 2)
+
+	switch method {
+	case methodAddPairing:
+		perm, _ := req.getByte(tlvPermissions)
+		if err := b.addPairing(id, pairing{PublicKey: req.get(tlvPublicKey), Admin: perm == 1}); err != nil {
+			b.log.WARN.Printf("add pairing: %v", err)
+			res = tlvErrorResponse(2, tlvErrorUnknown)
+		}
+
+	case methodRemovePairing:
+		if err := b.removePairing(id); err != nil {
+			b.log.ERROR.Printf("remove pairing: %v", err)
+			res = tlvErrorResponse(2, tlvErrorUnknown)
+		}
+
+	case methodListPairings:
+		for i, p := range b.pairings() {
+			if i > 0 {
+				res.set(tlvSeparator, nil)
+			}
+
+			var perm byte
+			if p.Admin {
+				perm = 1
+			}
+
+			res.set(tlvIdentifier, []byte(p.id)).set(tlvPublicKey, p.PublicKey).setByte(tlvPermissions, perm)
+		}
+
+	default:
+		res = tlvErrorResponse(2, tlvErrorUnknown)
+	}
+
+	writeTLV(w, res)
+}
+
+func concat(bb ...[]byte) []byte {
+	var res []byte
+	for _, b := range bb {
+		res = append(res, b...)
+	}
+	return res
+}
@@ -0,0 +1,128 @@
+package homekit
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// srpUsername is the fixed SRP user name used by HAP pair setup
+const srpUsername = "Pair-Setup"
+
+// srpPrime is the RFC 5054 3072-bit group prime, the generator is 5
+var srpPrime, _ = new(big.Int).SetString(strings.Join(strings.Fields(`
+	FFFFFFFF FFFFFFFF C90FDAA2 2168C234 C4C6628B 80DC1CD1
+	29024E08 8A67CC74 020BBEA6 3B139B22 514A0879 8E3404DD
+	EF9519B3 CD3A431B 302B0A6D F25F1437 4FE1356D 6D51C245
+	E485B576 625E7EC6 F44C42E9 A637ED6B 0BFF5CB6 F406B7ED
+	EE386BFB 5A899FA5 AE9F2411 7C4B1FE6 49286651 ECE45B3D
+	C2007CB8 A163BF05 98DA4836 1C55D39A 69163FA8 FD24CF5F
+	83655D23 DCA3AD96 1C62F356 208552BB 9ED52907 7096966D
+	670C354E 4ABC9804 F1746C08 CA18217C 32905E46 2E36CE3B
+	E39E772C 180E8603 9B2783A2 EC07A28F B5C55DF0 6F4C52C9
+	DE2BCBF6 95581718 3995497C EA956AE5 15D22618 98FA0510
+	15728E5A 8AAAC42D AD33170D 04507A33 A85521AB DF1CBA64
+	ECFB8504 58DBEF0A 8AEA7157 5D060C7D B3970F85 A6E1E4C7
+	ABF5AE8C DB0933D7 1E8C94E0 4A25619D CEE3D226 1AD2EE6B
+	F12FFA06 D98A0864 D8760273 3EC86A64 521F2B18 177B200C
+	BBE11757 7A615D6C 770988C0 BAD946E2 08E24FA0 74E5AB31
+	43DB5BFC E0FD108E 4B82D120 A93AD2CA FFFFFFFF FFFFFFFF`), ""), 16)
+
+var srpGenerator = big.NewInt(5)
+
+func srpHash(bb ...[]byte) []byte {
+	h := sha512.New()
+	for _, b := range bb {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// srpPad left-pads b to the length of the prime
+func srpPad(b []byte) []byte {
+	n := (srpPrime.BitLen() + 7) / 8
+	if len(b) >= n {
+		return b
+	}
+	return append(make([]byte, n-len(b)), b...)
+}
+
+func srpInt(b []byte) *big.Int {
+	return new(big.Int).SetBytes(b)
+}
+
+// srpServer is the SRP-6a (SHA-512) server side of HAP pair setup
+type srpServer struct {
+	salt []byte
+	v    *big.Int
+	b, B *big.Int
+	A    *big.Int
+	K    []byte
+	M1   []byte
+}
+
+func newSRPServer(password string) (*srpServer, error) {
+	s := &srpServer{salt: make([]byte, 16)}
+	if _, err := rand.Read(s.salt); err != nil {
+		return nil, err
+	}
+
+	// v = g^x, x = H(s | H(I | ":" | P))
+	x := srpInt(srpHash(s.salt, srpHash([]byte(srpUsername+":"+password))))
+	s.v = new(big.Int).Exp(srpGenerator, x, srpPrime)
+
+	priv := make([]byte, 32)
+	if _, err := rand.Read(priv); err != nil {
+		return nil, err
+	}
+	s.b = srpInt(priv)
+
+	// B = k*v + g^b, k = H(N | PAD(g))
+	k := srpInt(srpHash(srpPrime.Bytes(), srpPad(srpGenerator.Bytes())))
+	s.B = new(big.Int).Mul(k, s.v)
+	s.B.Add(s.B, new(big.Int).Exp(srpGenerator, s.b, srpPrime))
+	s.B.Mod(s.B, srpPrime)
+
+	return s, nil
+}
+
+// PublicKey returns the server public key B
+func (s *srpServer) PublicKey() []byte {
+	return s.B.Bytes()
+}
+
+// Verify computes the session key from the client public key and verifies the client proof.
+// It returns the server proof.
+func (s *srpServer) Verify(A, M1 []byte) ([]byte, error) {
+	s.A = srpInt(A)
+	if new(big.Int).Mod(s.A, srpPrime).Sign() == 0 {
+		return nil, errors.New("invalid public key")
+	}
+
+	// u = H(PAD(A) | PAD(B))
+	u := srpInt(srpHash(srpPad(A), srpPad(s.B.Bytes())))
+
+	// S = (A * v^u)^b
+	S := new(big.Int).Exp(s.v, u, srpPrime)
+	S.Mul(S, s.A)
+	S.Exp(S, s.b, srpPrime)
+
+	s.K = srpHash(S.Bytes())
+
+	// M1 = H(H(N) xor H(g) | H(I) | s | A | B | K)
+	hn, hg := srpHash(srpPrime.Bytes()), srpHash(srpGenerator.Bytes())
+	for i := range hn {
+		hn[i] ^= hg[i]
+	}
+
+	s.M1 = srpHash(hn, srpHash([]byte(srpUsername)), s.salt, A, s.B.Bytes(), s.K)
+	if subtle.ConstantTimeCompare(s.M1, M1) != 1 {
+		return nil, errors.New("invalid proof")
+	}
+
+	// M2 = H(A | M1 | K)
+	return srpHash(A, s.M1, s.K), nil
+}
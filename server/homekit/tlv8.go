@@ -0,0 +1,127 @@
+package homekit
+
+import (
+	"bytes"
+	"errors"
+)
+
+// tlv types
+const (
+	tlvMethod        = 0x00
+	tlvIdentifier    = 0x01
+	tlvSalt          = 0x02
+	tlvPublicKey     = 0x03
+	tlvProof         = 0x04
+	tlvEncryptedData = 0x05
+	tlvState         = 0x06
+	tlvError         = 0x07
+	tlvRetryDelay    = 0x08
+	tlvSignature     = 0x0a
+	tlvPermissions   = 0x0b
+	tlvSeparator     = 0xff
+)
+
+// tlv errors
+const (
+	tlvErrorUnknown        = 0x01
+	tlvErrorAuthentication = 0x02
+	tlvErrorBackoff        = 0x03
+	tlvErrorMaxPeers       = 0x04
+	tlvErrorUnavailable    = 0x06
+)
+
+// tlv pairing methods
+const (
+	methodPairSetup     = 0
+	methodAddPairing    = 3
+	methodRemovePairing = 4
+	methodListPairings  = 5
+)
+
+// tlv8 is a HAP type-length-value container preserving item order
+type tlv8 struct {
+	items []tlvItem
+}
+
+type tlvItem struct {
+	typ byte
+	val []byte
+}
+
+func (t *tlv8) set(typ byte, val []byte) *tlv8 {
+	t.items = append(t.items, tlvItem{typ: typ, val: val})
+	return t
+}
+
+func (t *tlv8) setByte(typ byte, val byte) *tlv8 {
+	return t.set(typ, []byte{val})
+}
+
+// get returns the first value of the given type
+func (t *tlv8) get(typ byte) []byte {
+	for _, it := range t.items {
+		if it.typ == typ {
+			return it.val
+		}
+	}
+	return nil
+}
+
+func (t *tlv8) getByte(typ byte) (byte, bool) {
+	if v := t.get(typ); len(v) == 1 {
+		return v[0], true
+	}
+	return 0, false
+}
+
+// encode serializes the items, values longer than 255 bytes are fragmented
+func (t *tlv8) encode() []byte {
+	var b bytes.Buffer
+
+	for _, it := range t.items {
+		val := it.val
+		for {
+			n := min(len(val), 255)
+			b.WriteByte(it.typ)
+			b.WriteByte(byte(n))
+			b.Write(val[:n])
+
+			val = val[n:]
+			if len(val) == 0 {
+				break
+			}
+		}
+	}
+
+	return b.Bytes()
+}
+
+// decodeTLV8 parses b, consecutive fragments of the same type are merged
+func decodeTLV8(b []byte) (*tlv8, error) {
+	res := new(tlv8)
+
+	last := -1
+	for len(b) > 0 {
+		if len(b) < 2 {
+			return nil, errors.New("tlv8: short item")
+		}
+
+		typ, n := b[0], int(b[1])
+		if len(b) < 2+n {
+			return nil, errors.New("tlv8: short value")
+		}
+
+		val := b[2 : 2+n]
+		b = b[2+n:]
+
+		if last >= 0 && res.items[last].typ == typ && len(res.items[last].val)%255 == 0 && len(res.items[last].val) > 0 {
+			res.items[last].val = append(res.items[last].val, val...)
+			continue
+		}
+
+		res.items = append(res.items, tlvItem{typ: typ, val: append([]byte(nil), val...)})
+		last = len(res.items) - 1
+	}
+
+	return res, nil
+}
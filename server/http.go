@@ -8,6 +8,7 @@ import (
 	eapi "github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/api/globalconfig"
 	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server/assets"
 	"github.com/evcc-io/evcc/server/eebus"
@@ -43,7 +44,10 @@ func NewHTTPd(addr string, hub *SocketHub) *HTTPd {
 	router := mux.NewRouter().StrictSlash(true)
 
 	// websocket
-	router.HandleFunc("/ws", socketHandler(hub))
+	router.Handle("/ws", ensureApiScopeHandler(auth.New())(socketHandler(hub)))
+
+	// server-sent events
+	router.Handle("/events", ensureApiScopeHandler(auth.New())(http.HandlerFunc(hub.ServeEvents)))
 
 	// static - individual handlers per root and folders
 	static := router.PathPrefix("/").Subrouter()
@@ -96,8 +100,9 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 	api.Use(jsonHandler)
 	api.Use(handlers.CompressHandler)
 	api.Use(handlers.CORS(
-		handlers.AllowedHeaders([]string{"Content-Type"}),
+		handlers.AllowedHeaders([]string{"Content-Type", "Authorization"}),
 	))
+	api.Use(ensureApiScopeHandler(auth.New()))
 
 	// site api
 	routes := map[string]route{
@@ -106,13 +111,20 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 		"bufferstartsoc":          {"POST", "/bufferstartsoc/{value:[0-9.]+}", floatHandler(site.SetBufferStartSoc, site.GetBufferStartSoc)},
 		"batterydischargecontrol": {"POST", "/batterydischargecontrol/{value:[01truefalse]+}", boolHandler(site.SetBatteryDischargeControl, site.GetBatteryDischargeControl)},
 		"batterygridcharge":       {"POST", "/batterygridchargelimit/{value:-?[0-9.]+}", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit)},
+		"batterygridchargehours":  {"POST", "/batterygridchargehours/{value:[0-9]+}", intHandler(site.SetBatteryGridChargeHours, site.GetBatteryGridChargeHours)},
 		"batterygridchargedelete": {"DELETE", "/batterygridchargelimit", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit)},
+		"batteryreserve":          {"POST", "/batteryreserve/{value:[0-9.]+}", floatPtrHandler(site.SetBatteryReserve, site.GetBatteryReserve)},
+		"batteryreservedelete":    {"DELETE", "/batteryreserve", floatPtrHandler(site.SetBatteryReserve, site.GetBatteryReserve)},
+		"exportlimit":             {"POST", "/exportlimit/{value:[0-9.]+}", floatPtrHandler(site.SetExportLimit, site.GetExportLimit)},
+		"exportlimitdelete":       {"DELETE", "/exportlimit", floatPtrHandler(site.SetExportLimit, site.GetExportLimit)},
 		"prioritysoc":             {"POST", "/prioritysoc/{value:[0-9.]+}", floatHandler(site.SetPrioritySoc, site.GetPrioritySoc)},
 		"residualpower":           {"POST", "/residualpower/{value:-?[0-9.]+}", floatHandler(site.SetResidualPower, site.GetResidualPower)},
 		"smartcost":               {"POST", "/smartcostlimit/{value:-?[0-9.]+}", updateSmartCostLimit(site)},
 		"smartcostdelete":         {"DELETE", "/smartcostlimit", updateSmartCostLimit(site)},
 		"tariff":                  {"GET", "/tariff/{tariff:[a-z]+}", tariffHandler(site)},
+		"plannerwindow":           {"GET", "/planner/window", plannerWindowHandler(site)},
 		"sessions":                {"GET", "/sessions", sessionHandler},
+		"sessionsummary":          {"GET", "/sessions/summary", sessionSummaryHandler},
 		"updatesession":           {"PUT", "/session/{id:[0-9]+}", updateSessionHandler},
 		"deletesession":           {"DELETE", "/session/{id:[0-9]+}", deleteSessionHandler},
 		"telemetry":               {"GET", "/settings/telemetry", boolGetHandler(telemetry.Enabled)},
@@ -154,12 +166,25 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 		api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
 	}
 
+	// circuit api
+	circuits := map[string]route{
+		"maxpower":    {"POST", "/circuits/{name:[a-zA-Z0-9_.:-]+}/maxpower/{value:[0-9.]+}", circuitLimitHandler(site.SetCircuitMaxPower, eapi.Circuit.GetMaxPowerLimit)},
+		"maxpower2":   {"DELETE", "/circuits/{name:[a-zA-Z0-9_.:-]+}/maxpower", circuitLimitHandler(site.SetCircuitMaxPower, eapi.Circuit.GetMaxPowerLimit)},
+		"maxcurrent":  {"POST", "/circuits/{name:[a-zA-Z0-9_.:-]+}/maxcurrent/{value:[0-9.]+}", circuitLimitHandler(site.SetCircuitMaxCurrent, eapi.Circuit.GetMaxCurrentLimit)},
+		"maxcurrent2": {"DELETE", "/circuits/{name:[a-zA-Z0-9_.:-]+}/maxcurrent", circuitLimitHandler(site.SetCircuitMaxCurrent, eapi.Circuit.GetMaxCurrentLimit)},
+	}
+
+	for _, r := range circuits {
+		api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+	}
+
 	// loadpoint api
 	for id, lp := range site.Loadpoints() {
 		api := api.PathPrefix(fmt.Sprintf("/loadpoints/%d", id+1)).Subrouter()
 
 		routes := map[string]route{
 			"mode":             {"POST", "/mode/{value:[a-z]+}", handler(eapi.ChargeModeString, pass(lp.SetMode), lp.GetMode)},
+			"mode2":            {"POST", "/mode/{value:[a-z]+}/{duration:[0-9]+}", temporaryModeHandler(lp)},
 			"limitsoc":         {"POST", "/limitsoc/{value:[0-9]+}", intHandler(pass(lp.SetLimitSoc), lp.GetLimitSoc)},
 			"limitenergy":      {"POST", "/limitenergy/{value:[0-9.]+}", floatHandler(pass(lp.SetLimitEnergy), lp.GetLimitEnergy)},
 			"mincurrent":       {"POST", "/mincurrent/{value:[0-9.]+}", floatHandler(lp.SetMinCurrent, lp.GetMinCurrent)},
@@ -169,18 +194,28 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 			"planpreview":      {"GET", "/plan/preview/{type:(?:soc|energy)}/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", planPreviewHandler(lp)},
 			"planenergy":       {"POST", "/plan/energy/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", planEnergyHandler(lp)},
 			"planenergy2":      {"DELETE", "/plan/energy", planRemoveHandler(lp)},
+			"planoptimization": {"POST", "/plan/optimization/{value:[a-z0-9]+}", handler(loadpoint.PlanOptimizationString, lp.SetPlanOptimization, lp.GetPlanOptimization)},
 			"vehicle":          {"POST", "/vehicle/{name:[a-zA-Z0-9_.:-]+}", vehicleSelectHandler(site, lp)},
 			"vehicle2":         {"DELETE", "/vehicle", vehicleRemoveHandler(lp)},
 			"vehicleDetect":    {"PATCH", "/vehicle", vehicleDetectHandler(lp)},
 			"remotedemand":     {"POST", "/remotedemand/{demand:[a-z]+}/{source:[0-9a-zA-Z_-]+}", remoteDemandHandler(lp)},
+			"pause":            {"POST", "/pause/{reason:[a-z]+}", pauseHandler(lp)},
+			"pause2":           {"POST", "/pause/{reason:[a-z]+}/{duration:[0-9]+}", pauseHandler(lp)},
+			"resume":           {"DELETE", "/pause", resumeHandler(lp)},
 			"enableThreshold":  {"POST", "/enable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetEnableThreshold), lp.GetEnableThreshold)},
 			"enableDelay":      {"POST", "/enable/delay/{value:[0-9]+}", durationHandler(pass(lp.SetEnableDelay), lp.GetEnableDelay)},
 			"disableThreshold": {"POST", "/disable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetDisableThreshold), lp.GetDisableThreshold)},
 			"disableDelay":     {"POST", "/disable/delay/{value:[0-9]+}", durationHandler(pass(lp.SetDisableDelay), lp.GetDisableDelay)},
 			"smartCost":        {"POST", "/smartcostlimit/{value:-?[0-9.]+}", floatPtrHandler(pass(lp.SetSmartCostLimit), lp.GetSmartCostLimit)},
 			"smartCostDelete":  {"DELETE", "/smartcostlimit", floatPtrHandler(pass(lp.SetSmartCostLimit), lp.GetSmartCostLimit)},
+			"smartCo2":         {"POST", "/smartco2limit/{value:[0-9.]+}", floatPtrHandler(pass(lp.SetSmartCo2Limit), lp.GetSmartCo2Limit)},
+			"smartCo2Delete":   {"DELETE", "/smartco2limit", floatPtrHandler(pass(lp.SetSmartCo2Limit), lp.GetSmartCo2Limit)},
 			"priority":         {"POST", "/priority/{value:[0-9]+}", intHandler(pass(lp.SetPriority), lp.GetPriority)},
 			"batteryBoost":     {"POST", "/batteryboost/{value:[01truefalse]}", boolHandler(lp.SetBatteryBoost, lp.GetBatteryBoost)},
+			"bufferSoc":        {"POST", "/buffersoc/{value:[0-9.]+}", floatPtrHandler(lp.SetBufferSoc, lp.GetBufferSoc)},
+			"bufferSocDelete":  {"DELETE", "/buffersoc", floatPtrHandler(lp.SetBufferSoc, lp.GetBufferSoc)},
+			"bufferStartSoc":   {"POST", "/bufferstartsoc/{value:[0-9.]+}", floatPtrHandler(lp.SetBufferStartSoc, lp.GetBufferStartSoc)},
+			"bufferStartSoc2":  {"DELETE", "/bufferstartsoc", floatPtrHandler(lp.SetBufferStartSoc, lp.GetBufferStartSoc)},
 		}
 
 		for _, r := range routes {
@@ -190,7 +225,7 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 }
 
 // RegisterSystemHandler provides system level handlers
-func (s *HTTPd) RegisterSystemHandler(valueChan chan<- util.Param, cache *util.Cache, shutdown func()) {
+func (s *HTTPd) RegisterSystemHandler(valueChan chan<- util.Param, cache *util.Cache, configFile string, shutdown func()) {
 	router := s.Server.Handler.(*mux.Router)
 	auth := auth.New()
 
@@ -204,7 +239,8 @@ func (s *HTTPd) RegisterSystemHandler(valueChan chan<- util.Param, cache *util.C
 
 	{ // /api
 		routes := map[string]route{
-			"state": {"GET", "/state", stateHandler(cache)},
+			"state":   {"GET", "/state", ensureApiScopeHandler(auth)(stateHandler(cache)).ServeHTTP},
+			"openapi": {"GET", "/openapi.json", openapiHandler(router)},
 		}
 
 		for _, r := range routes {
@@ -221,6 +257,26 @@ func (s *HTTPd) RegisterSystemHandler(valueChan chan<- util.Param, cache *util.C
 			"auth":     {"GET", "/status", authStatusHandler(auth)},
 			"login":    {"POST", "/login", loginHandler(auth)},
 			"logout":   {"POST", "/logout", logoutHandler},
+			"oidc":     {"GET", "/oidc/login", oidcLoginHandler},
+			"callback": {"GET", "/oidc/callback", oidcCallbackHandler(auth)},
+		}
+
+		for _, r := range routes {
+			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+		}
+	}
+
+	{
+		// api/auth/apikeys
+		api := api.PathPrefix("/auth/apikeys").Subrouter()
+		api.Use(ensureAuthHandler(auth))
+
+		routes := map[string]route{
+			"apikeys":      {"GET", "", apiKeysHandler(auth)},
+			"newapikey":    {"POST", "", newApiKeyHandler(auth)},
+			"deleteapikey": {"DELETE", "/{id:[0-9a-f]+}", revokeApiKeyHandler(auth)},
+			"required":     {"GET", "/required", boolGetHandler(auth.IsApiAuthRequired)},
+			"required2":    {"POST", "/required/{value:[01truefalse]+}", boolHandler(auth.SetApiAuthRequired, auth.IsApiAuthRequired)},
 		}
 
 		for _, r := range routes {
@@ -243,10 +299,18 @@ func (s *HTTPd) RegisterSystemHandler(valueChan chan<- util.Param, cache *util.C
 			"updatedevice":       {"PUT", "/devices/{class:[a-z]+}/{id:[0-9.]+}", updateDeviceHandler},
 			"deletedevice":       {"DELETE", "/devices/{class:[a-z]+}/{id:[0-9.]+}", deleteDeviceHandler},
 			"testconfig":         {"POST", "/test/{class:[a-z]+}", testConfigHandler},
+			"loadpoints":         {"GET", "/loadpoints", loadpointsConfigHandler},
+			"loadpoint":          {"GET", "/loadpoints/{id:[0-9]+}", loadpointConfigHandler},
+			"newloadpoint":       {"POST", "/loadpoints", newLoadpointHandler},
+			"updateloadpoint":    {"PUT", "/loadpoints/{id:[0-9]+}", updateLoadpointHandler},
+			"deleteloadpoint":    {"DELETE", "/loadpoints/{id:[0-9]+}", deleteLoadpointHandler},
 			"testmerged":         {"POST", "/test/{class:[a-z]+}/merge/{id:[0-9.]+}", testConfigHandler},
 			"interval":           {"POST", "/interval/{value:[0-9.]+}", settingsSetDurationHandler(keys.Interval)},
 			"updatesponsortoken": {"POST", "/sponsortoken", updateSponsortokenHandler},
 			"deletesponsortoken": {"DELETE", "/sponsortoken", settingsDeleteHandler(keys.SponsorToken)},
+			"tokens":             {"GET", "/tokens", tokensHandler},
+			"revoketoken":        {"DELETE", "/tokens/{id:[0-9]+}", revokeTokenHandler},
+			"enabletoken":        {"POST", "/tokens/{id:[0-9]+}", enableTokenHandler},
 		}
 
 		// yaml handlers
@@ -286,12 +350,17 @@ func (s *HTTPd) RegisterSystemHandler(valueChan chan<- util.Param, cache *util.C
 
 		// system api
 		routes := map[string]route{
-			"log":      {"GET", "/log", logHandler},
-			"logareas": {"GET", "/log/areas", logAreasHandler},
+			"log":           {"GET", "/log", logHandler},
+			"logareas":      {"GET", "/log/areas", logAreasHandler},
+			"loglevels":     {"GET", "/log/levels", logLevelsHandler},
+			"setloglevel":   {"POST", "/log/levels/{area}/{level}", setLogLevelHandler},
+			"resetloglevel": {"DELETE", "/log/levels/{area}", setLogLevelHandler},
 			"shutdown": {"POST", "/shutdown", func(w http.ResponseWriter, r *http.Request) {
 				shutdown()
 				w.WriteHeader(http.StatusNoContent)
 			}},
+			"backup":  {"GET", "/backup", backupHandler(configFile)},
+			"restore": {"POST", "/restore", restoreHandler(configFile, shutdown)},
 		}
 
 		for _, r := range routes {
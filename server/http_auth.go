@@ -1,7 +1,10 @@
 package server
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -21,23 +24,23 @@ type loginRequest struct {
 	Password string `json:"password"`
 }
 
-func updatePasswordHandler(auth auth.Auth) http.HandlerFunc {
+func updatePasswordHandler(authenticator auth.Auth) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req updatePasswordRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			jsonError(w, http.StatusBadRequest, err)
 			return
 		}
 
 		// update password
-		if auth.IsAdminPasswordConfigured() {
-			if !auth.IsAdminPasswordValid(req.Current) {
-				http.Error(w, "Invalid password", http.StatusBadRequest)
+		if authenticator.IsAdminPasswordConfigured() {
+			if !authenticator.IsAdminPasswordValid(req.Current) {
+				jsonError(w, http.StatusBadRequest, errors.New("invalid password"))
 				return
 			}
 
-			if err := auth.SetAdminPassword(req.New); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+			if err := authenticator.SetAdminPassword(req.New); err != nil {
+				jsonError(w, http.StatusInternalServerError, err)
 				return
 			}
 
@@ -46,8 +49,8 @@ func updatePasswordHandler(auth auth.Auth) http.HandlerFunc {
 		}
 
 		// create new password
-		if err := auth.SetAdminPassword(req.New); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		if err := authenticator.SetAdminPassword(req.New); err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
 			return
 		}
 		w.WriteHeader(http.StatusCreated)
@@ -71,16 +74,16 @@ func jwtFromRequest(r *http.Request) string {
 }
 
 // authStatusHandler login status (true/false) based on jwt token. Error if admin password is not configured
-func authStatusHandler(auth auth.Auth) http.HandlerFunc {
+func authStatusHandler(authenticator auth.Auth) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if !auth.IsAdminPasswordConfigured() {
-			http.Error(w, "Not implemented", http.StatusNotImplemented)
+		if !authenticator.IsAdminPasswordConfigured() && auth.IdentityProvider() == nil {
+			jsonError(w, http.StatusNotImplemented, errors.New("not implemented"))
 			return
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		ok, err := auth.ValidateJwtToken(jwtFromRequest(r))
-		if err != nil || !ok {
+		scope, ok := authenticator.Authorize(jwtFromRequest(r))
+		if !ok || !scope.Allows(auth.ScopeAdmin) {
 			w.Write([]byte("false"))
 			return
 		}
@@ -88,23 +91,23 @@ func authStatusHandler(auth auth.Auth) http.HandlerFunc {
 	}
 }
 
-func loginHandler(auth auth.Auth) http.HandlerFunc {
+func loginHandler(authenticator auth.Auth) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req loginRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			jsonError(w, http.StatusBadRequest, err)
 			return
 		}
 
-		if !auth.IsAdminPasswordValid(req.Password) {
-			http.Error(w, "Invalid password", http.StatusUnauthorized)
+		if !authenticator.IsAdminPasswordValid(req.Password) {
+			jsonError(w, http.StatusUnauthorized, errors.New("invalid password"))
 			return
 		}
 
 		lifetime := time.Hour * 24 * 90 // 90 day valid
-		tokenString, err := auth.GenerateJwtToken(lifetime)
+		tokenString, err := authenticator.GenerateJwtToken(lifetime)
 		if err != nil {
-			http.Error(w, "Failed to generate JWT token.", http.StatusInternalServerError)
+			jsonError(w, http.StatusInternalServerError, errors.New("failed to generate jwt token"))
 			return
 		}
 
@@ -119,6 +122,103 @@ func loginHandler(auth auth.Auth) http.HandlerFunc {
 	}
 }
 
+const (
+	oidcStateCookieName = "oidc_state"
+	oidcNonceCookieName = "oidc_nonce"
+	oidcSessionLifetime = 12 * time.Hour
+)
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// oidcLoginHandler redirects to the identity provider's login
+func oidcLoginHandler(w http.ResponseWriter, r *http.Request) {
+	provider := auth.IdentityProvider()
+	if provider == nil {
+		jsonError(w, http.StatusNotImplemented, errors.New("not implemented"))
+		return
+	}
+
+	state, err := randomHex(16)
+	if err == nil {
+		var nonce string
+		if nonce, err = randomHex(16); err == nil {
+			// lax cookies are sent with the identity provider's top-level redirect
+			for name, val := range map[string]string{oidcStateCookieName: state, oidcNonceCookieName: nonce} {
+				http.SetCookie(w, &http.Cookie{
+					Name:     name,
+					Value:    val,
+					Path:     "/api/auth/oidc",
+					HttpOnly: true,
+					MaxAge:   int((10 * time.Minute).Seconds()),
+					SameSite: http.SameSiteLaxMode,
+				})
+			}
+
+			var uri string
+			if uri, err = provider.LoginURL(state, nonce); err == nil {
+				http.Redirect(w, r, uri, http.StatusFound)
+				return
+			}
+		}
+	}
+
+	jsonError(w, http.StatusInternalServerError, err)
+}
+
+// oidcCallbackHandler completes the identity provider's login and issues the auth cookie
+func oidcCallbackHandler(authenticator auth.Auth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provider := auth.IdentityProvider()
+		if provider == nil {
+			jsonError(w, http.StatusNotImplemented, errors.New("not implemented"))
+			return
+		}
+
+		state, err := r.Cookie(oidcStateCookieName)
+		if err != nil || state.Value != r.URL.Query().Get("state") {
+			jsonError(w, http.StatusBadRequest, errors.New("invalid state"))
+			return
+		}
+
+		nonce, err := r.Cookie(oidcNonceCookieName)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, errors.New("invalid nonce"))
+			return
+		}
+
+		subject, scope, err := provider.Exchange(r.Context(), r.URL.Query().Get("code"), nonce.Value)
+		if err != nil {
+			jsonError(w, http.StatusUnauthorized, err)
+			return
+		}
+
+		// short-lived to pick up group membership changes at the identity provider
+		lifetime := oidcSessionLifetime
+		tokenString, err := authenticator.GenerateScopedJwtToken(subject, scope, lifetime)
+		if err != nil {
+			jsonError(w, http.StatusInternalServerError, errors.New("failed to generate jwt token"))
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     authCookieName,
+			Value:    tokenString,
+			Path:     "/",
+			HttpOnly: true,
+			Expires:  time.Now().Add(lifetime),
+			SameSite: http.SameSiteStrictMode,
+		})
+
+		http.Redirect(w, r, "/", http.StatusFound)
+	}
+}
+
 func logoutHandler(w http.ResponseWriter, r *http.Request) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     authCookieName,
@@ -127,13 +227,19 @@ func logoutHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func ensureAuthHandler(auth auth.Auth) mux.MiddlewareFunc {
+// ensureAuthHandler requires an admin jwt or an api key with admin scope
+func ensureAuthHandler(authenticator auth.Auth) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// check jwt token
-			ok, err := auth.ValidateJwtToken(jwtFromRequest(r))
-			if !ok || err != nil {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			// check jwt token or api key
+			scope, ok := authenticator.Authorize(jwtFromRequest(r))
+			if !ok {
+				jsonError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+				return
+			}
+
+			if !scope.Allows(auth.ScopeAdmin) {
+				jsonError(w, http.StatusForbidden, errors.New("forbidden"))
 				return
 			}
 
@@ -142,3 +248,86 @@ func ensureAuthHandler(auth auth.Auth) mux.MiddlewareFunc {
 		})
 	}
 }
+
+// ensureApiScopeHandler requires read scope for GET and control scope for all other requests if api authorization is enabled
+func ensureApiScopeHandler(authenticator auth.Auth) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions || !authenticator.IsApiAuthRequired() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			scope, ok := authenticator.Authorize(jwtFromRequest(r))
+			if !ok {
+				jsonError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+				return
+			}
+
+			required := auth.ScopeControl
+			if r.Method == http.MethodGet {
+				required = auth.ScopeRead
+			}
+
+			if !scope.Allows(required) {
+				jsonError(w, http.StatusForbidden, errors.New("forbidden"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type apiKeyRequest struct {
+	Name  string     `json:"name"`
+	Scope auth.Scope `json:"scope"`
+}
+
+// apiKeysHandler returns all api keys
+func apiKeysHandler(authenticator auth.Auth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonResult(w, authenticator.ApiKeys())
+	}
+}
+
+// newApiKeyHandler creates an api key and returns its token
+func newApiKeyHandler(authenticator auth.Auth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req apiKeyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		key, token, err := authenticator.CreateApiKey(req.Name, req.Scope)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res := struct {
+			auth.ApiKey
+			Token string `json:"token"`
+		}{
+			ApiKey: key,
+			Token:  token,
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// revokeApiKeyHandler deletes an api key
+func revokeApiKeyHandler(authenticator auth.Auth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		if err := authenticator.RevokeApiKey(id); err != nil {
+			jsonError(w, http.StatusNotFound, err)
+			return
+		}
+
+		jsonResult(w, id)
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/evcc-io/evcc/server/db"
+)
+
+// maxBackupSize limits the size of uploaded backup archives
+const maxBackupSize = 1 << 30
+
+// backupHandler downloads configuration, settings and session history as zip archive
+func backupHandler(configFile string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if db.Instance == nil {
+			jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+			return
+		}
+
+		// large databases may exceed the server's write timeout
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+		filename := "evcc-backup-" + time.Now().Format("20060102-150405") + ".zip"
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+		if err := db.Backup(w, configFile); err != nil {
+			log.ERROR.Println("backup:", err)
+		}
+	}
+}
+
+// restoreHandler restores a backup archive and restarts evcc to apply it
+func restoreHandler(configFile string, shutdown func()) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = http.NewResponseController(w).SetReadDeadline(time.Time{})
+
+		f, err := os.CreateTemp("", "evcc-restore")
+		if err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
+			return
+		}
+		defer os.Remove(f.Name())
+		defer f.Close()
+
+		size, err := io.Copy(f, http.MaxBytesReader(w, r.Body, maxBackupSize))
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := db.Restore(f, size, configFile); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		log.INFO.Println("backup restored, restarting to apply")
+		shutdown()
+	}
+}
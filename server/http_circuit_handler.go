@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/gorilla/mux"
+)
+
+// circuitLimitHandler updates a circuit's runtime limit, missing value removes the limit
+func circuitLimitHandler(set func(string, *float64) error, get func(api.Circuit) *float64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name := vars["name"]
+
+		var val *float64
+		if s, ok := vars["value"]; ok {
+			f, err := parseFloat(s)
+			if err != nil {
+				jsonError(w, http.StatusBadRequest, err)
+				return
+			}
+			val = &f
+		}
+
+		if err := set(name, val); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		dev, err := config.Circuits().ByName(name)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, get(dev.Instance()))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -57,6 +58,9 @@ func devicesHandler(w http.ResponseWriter, r *http.Request) {
 
 	case templates.Circuit:
 		res, err = devicesConfig(class, config.Circuits())
+
+	default:
+		err = fmt.Errorf("unsupported class: %s", class)
 	}
 
 	if err != nil {
@@ -135,6 +139,9 @@ func deviceConfigHandler(w http.ResponseWriter, r *http.Request) {
 
 	case templates.Circuit:
 		res, err = deviceConfig(class, id, config.Circuits())
+
+	default:
+		err = fmt.Errorf("unsupported class: %s", class)
 	}
 
 	if err != nil {
@@ -181,6 +188,9 @@ func deviceStatusHandler(w http.ResponseWriter, r *http.Request) {
 
 	case templates.Circuit:
 		instance, err = deviceStatus(name, config.Circuits())
+
+	default:
+		err = fmt.Errorf("unsupported class: %s", class)
 	}
 
 	if err != nil {
@@ -238,6 +248,9 @@ func newDeviceHandler(w http.ResponseWriter, r *http.Request) {
 		conf, err = newDevice(class, req, func(_ context.Context, _ string, other map[string]interface{}) (api.Circuit, error) {
 			return circuit.NewFromConfig(util.NewLogger("circuit"), other)
 		}, config.Circuits())
+
+	default:
+		err = fmt.Errorf("unsupported class: %s", class)
 	}
 
 	if err != nil {
@@ -259,7 +272,7 @@ func newDeviceHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func updateDevice[T any](id int, class templates.Class, conf map[string]any, newFromConf newFromConfFunc[T], h config.Handler[T]) error {
-	dev, instance, merged, err := deviceInstanceFromMergedConfig(id, class, conf, newFromConf, h)
+	dev, instance, merged, err := deviceInstanceFromMergedConfig(context.TODO(), id, class, conf, newFromConf, h)
 	if err != nil {
 		return err
 	}
@@ -309,6 +322,9 @@ func updateDeviceHandler(w http.ResponseWriter, r *http.Request) {
 		err = updateDevice(id, class, req, func(_ context.Context, _ string, other map[string]interface{}) (api.Circuit, error) {
 			return circuit.NewFromConfig(util.NewLogger("circuit"), other)
 		}, config.Circuits())
+
+	default:
+		err = fmt.Errorf("unsupported class: %s", class)
 	}
 
 	setConfigDirty()
@@ -375,6 +391,9 @@ func deleteDeviceHandler(w http.ResponseWriter, r *http.Request) {
 
 	case templates.Circuit:
 		err = deleteDevice(id, config.Circuits())
+
+	default:
+		err = fmt.Errorf("unsupported class: %s", class)
 	}
 
 	setConfigDirty()
@@ -393,12 +412,13 @@ func deleteDeviceHandler(w http.ResponseWriter, r *http.Request) {
 	jsonResult(w, res)
 }
 
-func testConfig[T any](id int, class templates.Class, conf map[string]any, newFromConf newFromConfFunc[T], h config.Handler[T]) (T, error) {
+// testConfig creates a device instance which is torn down when ctx is done
+func testConfig[T any](ctx context.Context, id int, class templates.Class, conf map[string]any, newFromConf newFromConfFunc[T], h config.Handler[T]) (T, error) {
 	if id == 0 {
-		return newFromConf(context.TODO(), typeTemplate, conf)
+		return newFromConf(ctx, typeTemplate, conf)
 	}
 
-	_, instance, _, err := deviceInstanceFromMergedConfig(id, class, conf, newFromConf, h)
+	_, instance, _, err := deviceInstanceFromMergedConfig(ctx, id, class, conf, newFromConf, h)
 
 	return instance, err
 }
@@ -430,20 +450,33 @@ func testConfigHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	delete(req, "type")
 
+	// tear down the test instance when done
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	var instance any
 
 	switch class {
 	case templates.Charger:
-		instance, err = testConfig(id, class, req, charger.NewFromConfig, config.Chargers())
+		instance, err = testConfig(ctx, id, class, req, charger.NewFromConfig, config.Chargers())
 
 	case templates.Meter:
-		instance, err = testConfig(id, class, req, meter.NewFromConfig, config.Meters())
+		instance, err = testConfig(ctx, id, class, req, meter.NewFromConfig, config.Meters())
 
 	case templates.Vehicle:
-		instance, err = testConfig(id, class, req, vehicle.NewFromConfig, config.Vehicles())
+		instance, err = testConfig(ctx, id, class, req, vehicle.NewFromConfig, config.Vehicles())
 
 	case templates.Circuit:
 		err = api.ErrNotAvailable
+
+	case templates.Loadpoint:
+		if err = validateLoadpointConfig(req); err == nil {
+			jsonResult(w, "ok")
+			return
+		}
+
+	default:
+		err = fmt.Errorf("unsupported class: %s", class)
 	}
 
 	if err != nil {
@@ -89,7 +89,7 @@ func mergeMasked(class templates.Class, conf, old map[string]any) (map[string]an
 	return res, nil
 }
 
-func deviceInstanceFromMergedConfig[T any](id int, class templates.Class, conf map[string]any, newFromConf newFromConfFunc[T], h config.Handler[T]) (config.Device[T], T, map[string]any, error) {
+func deviceInstanceFromMergedConfig[T any](ctx context.Context, id int, class templates.Class, conf map[string]any, newFromConf newFromConfFunc[T], h config.Handler[T]) (config.Device[T], T, map[string]any, error) {
 	var zero T
 
 	dev, err := h.ByName(config.NameForID(id))
@@ -102,7 +102,7 @@ func deviceInstanceFromMergedConfig[T any](id int, class templates.Class, conf m
 		return nil, zero, nil, err
 	}
 
-	instance, err := newFromConf(context.TODO(), typeTemplate, merged)
+	instance, err := newFromConf(ctx, typeTemplate, merged)
 
 	return dev, instance, merged, err
 }
@@ -130,12 +130,12 @@ func testInstance(instance any) map[string]testResult {
 		res["power"] = makeResult(val, err)
 	}
 
-	if dev, ok := instance.(api.MeterEnergy); ok {
+	if dev, ok := api.As[api.MeterEnergy](instance); ok {
 		val, err := dev.TotalEnergy()
 		res["energy"] = makeResult(val, err)
 	}
 
-	if dev, ok := instance.(api.Battery); ok {
+	if dev, ok := api.As[api.Battery](instance); ok {
 		val, err := dev.Soc()
 		key := "soc"
 		if fd, ok := instance.(api.FeatureDescriber); ok && slices.Contains(fd.Features(), api.Heating) {
@@ -153,7 +153,7 @@ func testInstance(instance any) map[string]testResult {
 		res["odometer"] = makeResult(val, err)
 	}
 
-	if dev, ok := instance.(api.BatteryCapacity); ok {
+	if dev, ok := api.As[api.BatteryCapacity](instance); ok {
 		val := dev.Capacity()
 		res["capacity"] = makeResult(val, nil)
 	}
@@ -173,6 +173,10 @@ func testInstance(instance any) map[string]testResult {
 		res["phasePowers"] = makeResult([]float64{p1, p2, p3}, err)
 	}
 
+	if dev, ok := api.As[api.MpptPowers](instance); ok {
+		res["mpptPowers"] = makeResult(dev.MpptPowers())
+	}
+
 	if dev, ok := instance.(api.ChargeState); ok {
 		val, err := dev.Status()
 		res["chargeStatus"] = makeResult(val, err)
@@ -0,0 +1,261 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/evcc-io/evcc/util/templates"
+	"github.com/gorilla/mux"
+)
+
+var (
+	configLoadpointsMu sync.Mutex
+	configLoadpoints   = make(map[int]loadpoint.API)
+)
+
+// RegisterConfigLoadpoint registers a running database loadpoint for applying configuration updates
+func RegisterConfigLoadpoint(id int, lp loadpoint.API) {
+	configLoadpointsMu.Lock()
+	defer configLoadpointsMu.Unlock()
+	configLoadpoints[id] = lp
+}
+
+// configLoadpoint returns the running loadpoint for the given database configuration id
+func configLoadpoint(id int) (loadpoint.API, bool) {
+	configLoadpointsMu.Lock()
+	defer configLoadpointsMu.Unlock()
+	lp, ok := configLoadpoints[id]
+	return lp, ok
+}
+
+// validateLoadpointConfig checks that the loadpoint references existing devices
+func validateLoadpointConfig(conf map[string]any) error {
+	var cc struct {
+		Title   string
+		Charger string
+		Meter   string
+		Circuit string
+		Other   map[string]any `mapstructure:",remain"`
+	}
+
+	if err := util.DecodeOther(conf, &cc); err != nil {
+		return err
+	}
+
+	if cc.Title == "" {
+		return errors.New("missing title")
+	}
+
+	if cc.Charger == "" {
+		return errors.New("missing charger")
+	}
+
+	if _, err := config.Chargers().ByName(cc.Charger); err != nil {
+		return fmt.Errorf("charger: %w", err)
+	}
+
+	if cc.Meter != "" {
+		if _, err := config.Meters().ByName(cc.Meter); err != nil {
+			return fmt.Errorf("meter: %w", err)
+		}
+	}
+
+	if cc.Circuit != "" {
+		if _, err := config.Circuits().ByName(cc.Circuit); err != nil {
+			return fmt.Errorf("circuit: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func loadpointConfigMap(conf config.Config) map[string]any {
+	res := conf.Named().Other
+	res["id"] = conf.ID
+	res["name"] = config.NameForID(conf.ID)
+	return res
+}
+
+// loadpointConfigByID returns the database loadpoint configuration for the given id
+func loadpointConfigByID(r *http.Request) (config.Config, error) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	conf, err := config.ConfigByID(id)
+	if err == nil && conf.Class != templates.Loadpoint {
+		err = fmt.Errorf("not a loadpoint: %d", id)
+	}
+
+	return conf, err
+}
+
+// loadpointsConfigHandler returns all database loadpoint configurations
+func loadpointsConfigHandler(w http.ResponseWriter, r *http.Request) {
+	configs, err := config.ConfigurationsByClass(templates.Loadpoint)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := make([]map[string]any, 0, len(configs))
+	for _, conf := range configs {
+		res = append(res, loadpointConfigMap(conf))
+	}
+
+	jsonResult(w, res)
+}
+
+// loadpointConfigHandler returns a database loadpoint configuration
+func loadpointConfigHandler(w http.ResponseWriter, r *http.Request) {
+	conf, err := loadpointConfigByID(r)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	jsonResult(w, loadpointConfigMap(conf))
+}
+
+// newLoadpointHandler validates and stores a new loadpoint configuration.
+// The loadpoint is started after restart.
+func newLoadpointHandler(w http.ResponseWriter, r *http.Request) {
+	var req map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+	delete(req, "id")
+	delete(req, "name")
+
+	if err := validateLoadpointConfig(req); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	conf, err := config.AddConfig(templates.Loadpoint, "", req)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	setConfigDirty()
+
+	res := struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}{
+		ID:   conf.ID,
+		Name: config.NameForID(conf.ID),
+	}
+
+	jsonResult(w, res)
+}
+
+// updateLoadpointHandler validates and updates a loadpoint configuration, merging the request over the current configuration.
+// Settings are applied to the running loadpoint, changed device references require a restart.
+func updateLoadpointHandler(w http.ResponseWriter, r *http.Request) {
+	conf, err := loadpointConfigByID(r)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	var req map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+	delete(req, "id")
+	delete(req, "name")
+
+	// settings missing from the request keep their current values
+	merged := make(map[string]any)
+	maps.Copy(merged, conf.Named().Other)
+	maps.Copy(merged, req)
+
+	if err := validateLoadpointConfig(merged); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	prev, _, err := loadpoint.SplitConfig(conf.Named().Other, loadpoint.DynamicConfig{})
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	static, _, err := loadpoint.SplitConfig(merged, loadpoint.DynamicConfig{})
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	lp, running := configLoadpoint(conf.ID)
+
+	var dynamic loadpoint.DynamicConfig
+	if running {
+		if _, dynamic, err = loadpoint.SplitConfig(req, loadpoint.CurrentConfig(lp)); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	if err := conf.Update(merged); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if !running || static != prev {
+		setConfigDirty()
+	}
+
+	if running {
+		if err := dynamic.Apply(lp); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	res := struct {
+		ID int `json:"id"`
+	}{
+		ID: conf.ID,
+	}
+
+	jsonResult(w, res)
+}
+
+// deleteLoadpointHandler deletes a loadpoint configuration.
+// A running loadpoint is removed after restart.
+func deleteLoadpointHandler(w http.ResponseWriter, r *http.Request) {
+	conf, err := loadpointConfigByID(r)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	if err := conf.Delete(); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	setConfigDirty()
+
+	res := struct {
+		ID int `json:"id"`
+	}{
+		ID: conf.ID,
+	}
+
+	jsonResult(w, res)
+}
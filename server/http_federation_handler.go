@@ -0,0 +1,54 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/evcc-io/evcc/server/federation"
+	"github.com/evcc-io/evcc/util/auth"
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+)
+
+// RegisterFederationHandlers provides the remote sites api
+func (s *HTTPd) RegisterFederationHandlers(f *federation.Federation) {
+	router := s.Server.Handler.(*mux.Router)
+
+	api := router.PathPrefix("/api/remotes").Subrouter()
+	api.Use(jsonHandler)
+	api.Use(handlers.CompressHandler)
+	api.Use(ensureApiScopeHandler(auth.New()))
+
+	routes := map[string]route{
+		"remotes":     {"GET", "", remotesHandler(f)},
+		"remotestate": {"GET", "/{name}/state", remoteStateHandler(f)},
+	}
+
+	for _, r := range routes {
+		api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+	}
+}
+
+// remotesHandler returns the overview of all remote sites
+func remotesHandler(f *federation.Federation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonResult(w, f.Sites())
+	}
+}
+
+// remoteStateHandler returns the full state of a remote instance
+func remoteStateHandler(f *federation.Federation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := f.State(mux.Vars(r)["name"])
+		if err != nil {
+			status := http.StatusBadGateway
+			if errors.Is(err, federation.ErrNotFound) {
+				status = http.StatusNotFound
+			}
+			jsonError(w, status, err)
+			return
+		}
+
+		jsonResult(w, res)
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/evcc-io/evcc/util/auth"
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+)
+
+// RegisterHistoryHandler provides the measurement history api
+func (s *HTTPd) RegisterHistoryHandler(h *History) {
+	router := s.Server.Handler.(*mux.Router)
+
+	api := router.PathPrefix("/api/history").Subrouter()
+	api.Use(jsonHandler)
+	api.Use(handlers.CompressHandler)
+	api.Use(ensureApiScopeHandler(auth.New()))
+
+	routes := map[string]route{
+		"historykeys":  {"GET", "/keys", historyKeysHandler(h)},
+		"history":      {"GET", "", historyHandler(h)},
+		"historydaily": {"GET", "/daily", historyDailyHandler(h)},
+	}
+
+	for _, r := range routes {
+		api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+	}
+}
+
+// historyKeysHandler returns the recorded history keys
+func historyKeysHandler(h *History) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonResult(w, h.Keys())
+	}
+}
+
+// historyHandler returns the recent values of the key, defaulting to the last 24 hours
+func historyHandler(h *History) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key")
+		if key == "" {
+			jsonError(w, http.StatusBadRequest, errors.New("missing key"))
+			return
+		}
+
+		period := 24 * time.Hour
+		if v := r.URL.Query().Get("period"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil || d <= 0 || d > historyRetention {
+				jsonError(w, http.StatusBadRequest, errors.New("invalid period"))
+				return
+			}
+			period = d
+		}
+
+		jsonResult(w, h.Recent(key, period))
+	}
+}
+
+// historyDailyHandler returns the daily aggregates of the key, defaulting to the last 30 days
+func historyDailyHandler(h *History) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key")
+		if key == "" {
+			jsonError(w, http.StatusBadRequest, errors.New("missing key"))
+			return
+		}
+
+		y, m, d := time.Now().Date()
+		to := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+		from := to.AddDate(0, 0, -30)
+
+		for _, f := range []struct {
+			param string
+			val   *time.Time
+		}{
+			{"from", &from},
+			{"to", &to},
+		} {
+			v := r.URL.Query().Get(f.param)
+			if v == "" {
+				continue
+			}
+
+			t, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+			if err != nil {
+				jsonError(w, http.StatusBadRequest, err)
+				return
+			}
+			*f.val = t
+		}
+
+		res, err := h.Daily(key, from, to)
+		if err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		jsonResult(w, res)
+	}
+}
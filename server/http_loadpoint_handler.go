@@ -10,6 +10,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
 	"github.com/gorilla/mux"
 )
 
@@ -39,6 +40,97 @@ func remoteDemandHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// temporaryModeHandler sets the charge mode for given duration
+func temporaryModeHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		mode, err := api.ChargeModeString(vars["value"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		duration, err := util.ParseDuration(vars["duration"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := lp.SetTemporaryMode(mode, duration); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		restore, until := lp.GetModeOverride()
+
+		res := struct {
+			Mode    api.ChargeMode `json:"mode"`
+			Restore api.ChargeMode `json:"restore"`
+			Until   time.Time      `json:"until"`
+		}{
+			Mode:    lp.GetMode(),
+			Restore: restore,
+			Until:   until,
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// pauseHandler pauses charging for given reason and optional duration
+func pauseHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		reason, err := loadpoint.PauseReasonString(vars["reason"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		var duration time.Duration
+		if val, ok := vars["duration"]; ok {
+			if duration, err = util.ParseDuration(val); err != nil {
+				jsonError(w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		if err := lp.Pause(reason, duration); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, pauseResult(lp))
+	}
+}
+
+// resumeHandler removes the pause
+func resumeHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lp.Resume()
+		jsonResult(w, pauseResult(lp))
+	}
+}
+
+func pauseResult(lp loadpoint.API) any {
+	reason, until := lp.GetPause()
+
+	var ts *time.Time
+	if !until.IsZero() {
+		ts = &until
+	}
+
+	return struct {
+		Reason loadpoint.PauseReason `json:"reason"`
+		Until  *time.Time            `json:"until"`
+	}{
+		Reason: reason,
+		Until:  ts,
+	}
+}
+
 // planHandler returns the current plan
 func planHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -49,7 +141,7 @@ func planHandler(lp loadpoint.API) http.HandlerFunc {
 		requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower)
 		plan, err := lp.GetPlan(planTime, requiredDuration)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			jsonError(w, http.StatusBadRequest, err)
 			return
 		}
 
@@ -106,7 +198,7 @@ func planPreviewHandler(lp loadpoint.API) http.HandlerFunc {
 		requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower)
 		plan, err := lp.GetPlan(planTime, requiredDuration)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			jsonError(w, http.StatusBadRequest, err)
 			return
 		}
 
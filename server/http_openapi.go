@@ -0,0 +1,163 @@
+package server
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// openapiPathParam matches mux path variables with optional pattern
+var openapiPathParam = regexp.MustCompile(`\{([^{}:]+)(?::([^{}]*))?\}`)
+
+type openapiSchema struct {
+	Ref        string                   `json:"$ref,omitempty"`
+	Type       string                   `json:"type,omitempty"`
+	Pattern    string                   `json:"pattern,omitempty"`
+	Properties map[string]openapiSchema `json:"properties,omitempty"`
+	Required   []string                 `json:"required,omitempty"`
+}
+
+type openapiParameter struct {
+	Name     string        `json:"name"`
+	In       string        `json:"in"`
+	Required bool          `json:"required"`
+	Schema   openapiSchema `json:"schema"`
+}
+
+type openapiMediaType struct {
+	Schema openapiSchema `json:"schema"`
+}
+
+type openapiResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openapiMediaType `json:"content,omitempty"`
+}
+
+type openapiOperation struct {
+	OperationID string                     `json:"operationId"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []openapiParameter         `json:"parameters,omitempty"`
+	Responses   map[string]openapiResponse `json:"responses"`
+}
+
+// openapiPath converts a mux path template to an OpenAPI path and its parameters
+func openapiPath(tpl string) (string, []openapiParameter) {
+	var params []openapiParameter
+
+	path := openapiPathParam.ReplaceAllStringFunc(tpl, func(s string) string {
+		m := openapiPathParam.FindStringSubmatch(s)
+
+		schema := openapiSchema{Type: "string"}
+		if m[2] != "" {
+			schema.Pattern = "^" + m[2] + "$"
+		}
+
+		params = append(params, openapiParameter{Name: m[1], In: "path", Required: true, Schema: schema})
+
+		return "{" + m[1] + "}"
+	})
+
+	return path, params
+}
+
+// openapiOperationID creates a unique operation id from method and path
+func openapiOperationID(method, path string) string {
+	id := strings.ToLower(method) + strings.NewReplacer("/", "_", "{", "", "}", "", ".", "_", "-", "_").Replace(path)
+	return strings.TrimSuffix(id, "_")
+}
+
+// openapiDocument creates the OpenAPI document from the router's api routes
+func openapiDocument(router *mux.Router) (map[string]any, error) {
+	paths := make(map[string]map[string]openapiOperation)
+
+	errorResponse := openapiResponse{
+		Description: "Error",
+		Content: map[string]openapiMediaType{
+			"application/json": {Schema: openapiSchema{Ref: "#/components/schemas/Error"}},
+		},
+	}
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		if err != nil || route.GetHandler() == nil || !strings.HasPrefix(tpl, "/api/") {
+			return nil
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+
+		path, params := openapiPath(tpl)
+
+		var tags []string
+		if segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/api/"), "/"); segment != "" {
+			tags = []string{segment}
+		}
+
+		for _, method := range methods {
+			if method == http.MethodOptions {
+				continue
+			}
+
+			if paths[path] == nil {
+				paths[path] = make(map[string]openapiOperation)
+			}
+
+			paths[path][strings.ToLower(method)] = openapiOperation{
+				OperationID: openapiOperationID(method, path),
+				Tags:        tags,
+				Parameters:  params,
+				Responses: map[string]openapiResponse{
+					"200":     {Description: "Success"},
+					"default": errorResponse,
+				},
+			}
+		}
+
+		return nil
+	})
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "evcc",
+			"version": Version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": map[string]openapiSchema{
+				"Error": {
+					Type: "object",
+					Properties: map[string]openapiSchema{
+						"status": {Type: "integer"},
+						"code":   {Type: "string"},
+						"error":  {Type: "string"},
+						"line":   {Type: "integer"},
+					},
+					Required: []string{"status", "code", "error"},
+				},
+			},
+			"securitySchemes": map[string]any{
+				"bearer": map[string]any{"type": "http", "scheme": "bearer"},
+				"cookie": map[string]any{"type": "apiKey", "in": "cookie", "name": authCookieName},
+			},
+		},
+		"security": []map[string][]string{{"bearer": {}}, {"cookie": {}}, {}},
+	}, err
+}
+
+// openapiHandler serves the OpenAPI document generated from the registered routes
+func openapiHandler(router *mux.Router) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		doc, err := openapiDocument(router)
+		if err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		jsonWrite(w, doc)
+	}
+}
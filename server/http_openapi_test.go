@@ -0,0 +1,70 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenapiPath(t *testing.T) {
+	path, params := openapiPath("/api/loadpoints/1/plan/preview/{type:(?:soc|energy)}/{value:[0-9.]+}/{time}")
+	assert.Equal(t, "/api/loadpoints/1/plan/preview/{type}/{value}/{time}", path)
+
+	require.Len(t, params, 3)
+	assert.Equal(t, openapiParameter{Name: "type", In: "path", Required: true, Schema: openapiSchema{Type: "string", Pattern: "^(?:soc|energy)$"}}, params[0])
+	assert.Equal(t, "^[0-9.]+$", params[1].Schema.Pattern)
+	assert.Empty(t, params[2].Schema.Pattern)
+
+	assert.Equal(t, "post_api_loadpoints_1_mode_value", openapiOperationID(http.MethodPost, "/api/loadpoints/1/mode/{value}"))
+}
+
+func TestOpenapiDocument(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/", func(http.ResponseWriter, *http.Request) {})
+
+	api := router.PathPrefix("/api").Subrouter()
+	for _, r := range []route{
+		{"GET", "/state", func(http.ResponseWriter, *http.Request) {}},
+		{"POST", "/loadpoints/1/mode/{value:[a-z]+}", func(http.ResponseWriter, *http.Request) {}},
+		{"DELETE", "/loadpoints/1/mode/{value:[a-z]+}", func(http.ResponseWriter, *http.Request) {}},
+	} {
+		api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+	}
+
+	doc, err := openapiDocument(router)
+	require.NoError(t, err)
+
+	b, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	var res struct {
+		OpenAPI string
+		Paths   map[string]map[string]openapiOperation
+	}
+	require.NoError(t, json.Unmarshal(b, &res))
+
+	assert.Equal(t, "3.0.3", res.OpenAPI)
+	assert.Len(t, res.Paths, 2, "non-api routes excluded")
+	assert.Contains(t, res.Paths["/api/state"], "get")
+
+	ops := res.Paths["/api/loadpoints/1/mode/{value}"]
+	require.Len(t, ops, 2, "options excluded")
+	assert.Equal(t, []string{"loadpoints"}, ops["post"].Tags)
+	assert.Equal(t, "delete_api_loadpoints_1_mode_value", ops["delete"].OperationID)
+	assert.Equal(t, "#/components/schemas/Error", ops["post"].Responses["default"].Content["application/json"].Schema.Ref)
+}
+
+func TestJsonError(t *testing.T) {
+	w := httptest.NewRecorder()
+	jsonError(w, http.StatusNotFound, errors.New("foo"))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"status":404,"code":"not_found","error":"foo"}`, w.Body.String())
+}
@@ -8,6 +8,7 @@ import (
 	"math"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/session"
@@ -15,6 +16,7 @@ import (
 	"github.com/evcc-io/evcc/util/locale"
 	"github.com/gorilla/mux"
 	"golang.org/x/text/language"
+	"gorm.io/gorm"
 )
 
 func csvResult(ctx context.Context, w http.ResponseWriter, res any, filename string) {
@@ -28,13 +30,22 @@ func csvResult(ctx context.Context, w http.ResponseWriter, res any, filename str
 	}
 }
 
-// sessionHandler returns the list of charging sessions
-func sessionHandler(w http.ResponseWriter, r *http.Request) {
-	if db.Instance == nil {
-		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
-		return
+func pdfResult(ctx context.Context, w http.ResponseWriter, res any, filename string) {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.pdf"`)
+
+	if ww, ok := res.(api.PdfWriter); ok {
+		_ = ww.WritePdf(ctx, w)
+	} else {
+		w.WriteHeader(http.StatusInternalServerError)
 	}
+}
 
+// errInvalidFilter indicates an invalid session filter
+var errInvalidFilter = errors.New("invalid filter")
+
+// querySessions returns the charging sessions matching the request's filter and the export file name
+func querySessions(r *http.Request) (session.Sessions, string, error) {
 	var (
 		res  session.Sessions
 		cond []string
@@ -57,10 +68,94 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// date range, both inclusive
+	for _, f := range []struct{ param, cond string }{
+		{"from", "DATE(created) >= ?"},
+		{"to", "DATE(created) <= ?"},
+	} {
+		date := r.URL.Query().Get(f.param)
+		if date == "" {
+			continue
+		}
+
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return nil, "", fmt.Errorf("%w: %s: %w", errInvalidFilter, f.param, err)
+		}
+
+		filename += "-" + date
+		push(f.cond, date)
+	}
+
+	if loadpoint := r.URL.Query().Get("loadpoint"); loadpoint != "" {
+		filename += "-" + loadpoint
+		push("loadpoint = ?", loadpoint)
+	}
+
+	if vehicle := r.URL.Query().Get("vehicle"); vehicle != "" {
+		filename += "-" + vehicle
+		push("vehicle = ?", vehicle)
+	}
+
+	if user := r.URL.Query().Get("user"); user != "" {
+		filename += "-" + user
+		push("user = ?", user)
+	}
+
+	if identifier := r.URL.Query().Get("identifier"); identifier != "" {
+		filename += "-" + identifier
+		push("identifier = ?", identifier)
+	}
+
+	tx := db.Instance
+
+	// per-slot energy, price and co2 breakdown
+	if r.URL.Query().Get("slots") == "true" {
+		tx = tx.Preload("Slots", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("start")
+		})
+	}
+
 	// TODO support other databases than Sqlite
 	query := strings.Join(append([]string{"charged_kwh>=0.05"}, cond...), " AND ")
-	if txn := db.Instance.Where(query, args...).Order("created DESC").Find(&res); txn.Error != nil {
-		jsonError(w, http.StatusInternalServerError, txn.Error)
+	if txn := tx.Where(query, args...).Order("created DESC").Find(&res); txn.Error != nil {
+		return nil, "", txn.Error
+	}
+
+	return res, filename, nil
+}
+
+// sessionErrorStatus returns the http status for session query errors
+func sessionErrorStatus(err error) int {
+	if errors.Is(err, errInvalidFilter) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// requestLanguage returns the requested export language
+func requestLanguage(r *http.Request) string {
+	lang := r.URL.Query().Get("lang")
+	if lang == "" {
+		// get request language
+		lang = r.Header.Get("Accept-Language")
+		if tags, _, err := language.ParseAcceptLanguage(lang); err == nil && len(tags) > 0 {
+			lang = tags[0].String()
+		}
+	}
+
+	return lang
+}
+
+// sessionHandler returns the list of charging sessions
+func sessionHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	res, filename, err := querySessions(r)
+	if err != nil {
+		jsonError(w, sessionErrorStatus(err), err)
 		return
 	}
 
@@ -72,19 +167,39 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if r.URL.Query().Get("format") == "csv" {
-		lang := r.URL.Query().Get("lang")
-		if lang == "" {
-			// get request language
-			lang = r.Header.Get("Accept-Language")
-			if tags, _, err := language.ParseAcceptLanguage(lang); err == nil && len(tags) > 0 {
-				lang = tags[0].String()
-			}
-		}
-
-		ctx := context.WithValue(context.Background(), locale.Locale, lang)
+	switch r.URL.Query().Get("format") {
+	case "csv":
+		ctx := context.WithValue(context.Background(), locale.Locale, requestLanguage(r))
 		csvResult(ctx, w, &res, filename)
 		return
+	case "pdf":
+		ctx := context.WithValue(context.Background(), locale.Locale, requestLanguage(r))
+		pdfResult(ctx, w, &res, filename)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// sessionSummaryHandler returns the charging sessions aggregated by identifier
+func sessionSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	sessions, filename, err := querySessions(r)
+	if err != nil {
+		jsonError(w, sessionErrorStatus(err), err)
+		return
+	}
+
+	res := sessions.SummaryByIdentifier()
+
+	if r.URL.Query().Get("format") == "csv" {
+		ctx := context.WithValue(context.Background(), locale.Locale, requestLanguage(r))
+		csvResult(ctx, w, &res, strings.Replace(filename, "session", "summary", 1))
+		return
 	}
 
 	jsonResult(w, res)
@@ -107,6 +222,11 @@ func deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if txn := db.Instance.Where("session_id = ?", id).Delete(new(session.Slot)); txn.Error != nil {
+		jsonError(w, http.StatusBadRequest, txn.Error)
+		return
+	}
+
 	jsonResult(w, res)
 }
 
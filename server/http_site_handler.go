@@ -6,18 +6,21 @@ import (
 	"fmt"
 	"io/fs"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server/assets"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/encode"
 	"github.com/evcc-io/evcc/util/jq"
 	"github.com/evcc-io/evcc/util/logstash"
+	"github.com/evcc-io/evcc/util/supervisor"
 	"github.com/gorilla/mux"
 	"github.com/itchyny/gojq"
 	"golang.org/x/text/language"
@@ -84,14 +87,27 @@ func jsonResult(w http.ResponseWriter, res interface{}) {
 	jsonWrite(w, map[string]interface{}{"result": res})
 }
 
+// errorResponse is the structured api error
+type errorResponse struct {
+	Status int    `json:"status"`
+	Code   string `json:"code"` // snake case http status text, e.g. bad_request
+	Error  string `json:"error"`
+	Line   int    `json:"line,omitempty"` // yaml error line
+}
+
+// errorCode returns the snake case status text as machine-readable error code
+func errorCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
 func jsonError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 
-	res := struct {
-		Error string `json:"error"`
-		Line  int    `json:"line,omitempty"`
-	}{
-		Error: err.Error(),
+	res := errorResponse{
+		Status: status,
+		Code:   errorCode(status),
+		Error:  err.Error(),
 	}
 
 	var (
@@ -228,16 +244,52 @@ func stateHandler(cache *util.Cache) http.HandlerFunc {
 	}
 }
 
-// healthHandler returns current charge mode
+// healthResult is the site's readiness, per-device health and device connection state
+type healthResult struct {
+	Status      string              `json:"status"` // ok, degraded or error
+	Ready       bool                `json:"ready"`
+	Devices     []site.DeviceStatus `json:"devices"`
+	Connections []supervisor.Status `json:"connections"`
+}
+
+func newHealthResult(ready bool, devices []site.DeviceStatus) healthResult {
+	res := healthResult{
+		Status:      site.DeviceError,
+		Ready:       ready,
+		Devices:     devices,
+		Connections: supervisor.Statuses(),
+	}
+
+	if res.Devices == nil {
+		res.Devices = []site.DeviceStatus{}
+	}
+
+	if ready {
+		res.Status = site.DeviceOk
+		if slices.ContainsFunc(devices, func(d site.DeviceStatus) bool { return d.Status != site.DeviceOk }) ||
+			slices.ContainsFunc(res.Connections, func(c supervisor.Status) bool { return c.State != supervisor.StateOk }) {
+			res.Status = site.DeviceDegraded
+		}
+	}
+
+	return res
+}
+
+// healthHandler returns the site's readiness and per-device health.
+// Device failures degrade the status but do not affect readiness.
 func healthHandler(site site.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if site == nil || !site.Healthy() {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
+		res := newHealthResult(false, nil)
+		if site != nil {
+			res = newHealthResult(site.Healthy(), site.DeviceHealth())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if !res.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
 		}
 
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintln(w, "OK")
+		_ = json.NewEncoder(w).Encode(res)
 	}
 }
 
@@ -260,11 +312,24 @@ func tariffHandler(site site.API) http.HandlerFunc {
 		}
 
 		res := struct {
-			Rates api.Rates `json:"rates"`
+			Rates   api.Rates  `json:"rates"`
+			Stale   bool       `json:"stale,omitempty"`
+			Updated *time.Time `json:"updated,omitempty"`
 		}{
 			Rates: rates,
 		}
 
+		// rates served from cache
+		if c, ok := t.(interface {
+			Stale() bool
+			Updated() time.Time
+		}); ok {
+			res.Stale = c.Stale()
+			if updated := c.Updated(); !updated.IsZero() {
+				res.Updated = &updated
+			}
+		}
+
 		jsonResult(w, res)
 	}
 }
@@ -280,6 +345,22 @@ func logAreasHandler(w http.ResponseWriter, r *http.Request) {
 	jsonResult(w, logstash.Areas())
 }
 
+func logLevelsHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResult(w, util.LogLevels())
+}
+
+// setLogLevelHandler changes a log area's level at runtime, missing level restores the configured default
+func setLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := util.SetLogLevel(vars["area"], vars["level"]); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, util.LogLevels())
+}
+
 func logHandler(w http.ResponseWriter, r *http.Request) {
 	a := r.URL.Query()["area"]
 	l := logstash.LogLevelToThreshold(r.URL.Query().Get("level"))
@@ -307,3 +388,77 @@ func logHandler(w http.ResponseWriter, r *http.Request) {
 
 	jsonResult(w, log)
 }
+
+// plannerWindowHandler returns the cheapest charging window for the given duration or energy and deadline
+func plannerWindowHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		deadline, err := time.Parse(time.RFC3339, q.Get("deadline"))
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, fmt.Errorf("invalid deadline: %w", err))
+			return
+		}
+
+		var power float64
+		if s := q.Get("power"); s != "" {
+			if power, err = strconv.ParseFloat(s, 64); err != nil || power <= 0 {
+				jsonError(w, http.StatusBadRequest, fmt.Errorf("invalid power: %s", s))
+				return
+			}
+		}
+
+		var duration time.Duration
+		switch {
+		case q.Has("duration"):
+			if duration, err = time.ParseDuration(q.Get("duration")); err != nil || duration <= 0 {
+				jsonError(w, http.StatusBadRequest, fmt.Errorf("invalid duration: %s", q.Get("duration")))
+				return
+			}
+
+		case q.Has("energy"):
+			energy, err := strconv.ParseFloat(q.Get("energy"), 64)
+			if err != nil || energy <= 0 || power == 0 {
+				jsonError(w, http.StatusBadRequest, errors.New("energy requires positive energy and power"))
+				return
+			}
+			duration = time.Duration(energy * 1e3 / power * float64(time.Hour))
+
+		default:
+			jsonError(w, http.StatusBadRequest, errors.New("missing duration or energy"))
+			return
+		}
+
+		tariff := site.GetTariff("planner")
+		if tariff == nil {
+			jsonError(w, http.StatusNotFound, errors.New("tariff not available"))
+			return
+		}
+
+		plan, err := planner.New(util.NewLogger("planner"), tariff).Plan(duration, deadline)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res := struct {
+			Deadline time.Time `json:"deadline"`
+			Duration int64     `json:"duration"`
+			Plan     api.Rates `json:"plan"`
+			Power    float64   `json:"power,omitempty"`
+			Cost     *float64  `json:"cost,omitempty"`
+		}{
+			Deadline: deadline,
+			Duration: int64(duration.Seconds()),
+			Plan:     plan,
+			Power:    power,
+		}
+
+		if power > 0 {
+			cost := planner.TotalCost(plan, power)
+			res.Cost = &cost
+		}
+
+		jsonResult(w, res)
+	}
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api/globalconfig"
+	"github.com/evcc-io/evcc/util"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	selfSignedCert = "selfsigned.crt"
+	selfSignedKey  = "selfsigned.key"
+)
+
+// ConfigureTLS enables https using acme, a self-signed or a provided certificate.
+// Acme and self-signed certificates are stored in dir. The acme dns challenge publishes its txt record using dnsRecord.
+func (s *HTTPd) ConfigureTLS(conf globalconfig.TLS, host, dir string, dnsRecord func(string) error) error {
+	switch strings.ToLower(conf.Mode) {
+	case "":
+		return nil
+
+	case "file":
+		cert, err := tls.LoadX509KeyPair(conf.Cert, conf.Key)
+		if err != nil {
+			return err
+		}
+		s.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	case "selfsigned":
+		cert, err := selfSignedCertificate(host, dir)
+		if err != nil {
+			return err
+		}
+		s.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	case "acme":
+		domains := conf.Domains
+		if len(domains) == 0 {
+			domains = []string{host}
+		}
+
+		challenge := strings.ToLower(conf.Challenge)
+		if challenge == "" {
+			// tls-alpn-01 is validated on port 443 only
+			challenge = "http"
+			if s.port() == "443" {
+				challenge = "tls-alpn"
+			}
+		}
+
+		if challenge == "dns" {
+			m, err := newDnsManager(domains, conf.Email, filepath.Join(dir, "acme-dns"), dnsRecord)
+			if err != nil {
+				return err
+			}
+			s.TLSConfig = m.TLSConfig()
+			break
+		}
+
+		m := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			Cache:      autocert.DirCache(filepath.Join(dir, "acme")),
+			HostPolicy: autocert.HostWhitelist(domains...),
+			Email:      conf.Email,
+		}
+
+		switch challenge {
+		case "tls-alpn":
+			if port := s.port(); port != "443" {
+				return fmt.Errorf("tls-alpn challenge requires port 443, got %s", port)
+			}
+		case "http":
+			// http-01 challenge requires port 80
+			go func() {
+				log := util.NewLogger("acme")
+				log.ERROR.Println(http.ListenAndServe(":80", m.HTTPHandler(nil)))
+			}()
+		default:
+			return fmt.Errorf("invalid acme challenge: %s", conf.Challenge)
+		}
+
+		s.TLSConfig = m.TLSConfig()
+
+	default:
+		return fmt.Errorf("invalid tls mode: %s", conf.Mode)
+	}
+
+	return nil
+}
+
+// port returns the listening port
+func (s *HTTPd) port() string {
+	_, port, _ := net.SplitHostPort(s.Addr)
+	return port
+}
+
+// ListenAndServe listens using https if tls is configured
+func (s *HTTPd) ListenAndServe() error {
+	if s.TLSConfig != nil {
+		return s.Server.ListenAndServeTLS("", "")
+	}
+	return s.Server.ListenAndServe()
+}
+
+// selfSignedCertificate loads or creates a self-signed certificate for host
+func selfSignedCertificate(host, dir string) (tls.Certificate, error) {
+	certFile := filepath.Join(dir, selfSignedCert)
+	keyFile := filepath.Join(dir, selfSignedKey)
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		return cert, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"evcc"}, CommonName: host},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = append(template.IPAddresses, ip)
+	} else if host != "" {
+		template.DNSNames = append(template.DNSNames, host)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return tls.Certificate{}, err
+	}
+
+	if err := os.WriteFile(certFile, certPem, 0o644); err != nil {
+		return tls.Certificate{}, err
+	}
+
+	if err := os.WriteFile(keyFile, keyPem, 0o600); err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.X509KeyPair(certPem, keyPem)
+}
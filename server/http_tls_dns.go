@@ -0,0 +1,291 @@
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"golang.org/x/crypto/acme"
+)
+
+const (
+	dnsAccountKey   = "account.key"
+	dnsCert         = "cert.pem"
+	dnsKey          = "key.pem"
+	dnsRenewBefore  = 30 * 24 * time.Hour
+	dnsCheckPeriod  = 12 * time.Hour
+	dnsPropagation  = 5 * time.Minute
+	dnsLookupPeriod = 10 * time.Second
+)
+
+// dnsManager obtains and renews acme certificates using the dns-01 challenge.
+// The challenge txt record is published by a plugin receiving "<record> <value>".
+type dnsManager struct {
+	log     *util.Logger
+	client  *acme.Client
+	email   string
+	domains []string
+	dir     string
+	record  func(string) error
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+func newDnsManager(domains []string, email, dir string, record func(string) error) (*dnsManager, error) {
+	if record == nil {
+		return nil, errors.New("dns challenge requires a dns plugin")
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	key, err := loadOrCreateKey(filepath.Join(dir, dnsAccountKey))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &dnsManager{
+		log:     util.NewLogger("acme"),
+		client:  &acme.Client{Key: key, DirectoryURL: acme.LetsEncryptURL},
+		email:   email,
+		domains: domains,
+		dir:     dir,
+		record:  record,
+	}
+
+	// use persisted certificate until renewal
+	if cert, err := tls.LoadX509KeyPair(filepath.Join(dir, dnsCert), filepath.Join(dir, dnsKey)); err == nil {
+		m.cert = &cert
+	}
+
+	go m.run()
+
+	return m, nil
+}
+
+// TLSConfig returns the tls configuration serving the current certificate
+func (m *dnsManager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			m.mu.RLock()
+			defer m.mu.RUnlock()
+
+			if m.cert == nil {
+				return nil, errors.New("acme: certificate not yet available")
+			}
+			return m.cert, nil
+		},
+	}
+}
+
+// run obtains the certificate and renews it before expiry
+func (m *dnsManager) run() {
+	m.renew()
+	for range time.Tick(dnsCheckPeriod) {
+		m.renew()
+	}
+}
+
+func (m *dnsManager) renew() {
+	if !m.renewalDue() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*dnsPropagation)
+	defer cancel()
+
+	cert, err := m.obtain(ctx)
+	if err != nil {
+		m.log.ERROR.Println(err)
+		return
+	}
+
+	m.mu.Lock()
+	m.cert = cert
+	m.mu.Unlock()
+
+	m.log.INFO.Printf("certificate obtained for %s", strings.Join(m.domains, ", "))
+}
+
+// renewalDue checks if the certificate is missing, expires soon or doesn't match the domains
+func (m *dnsManager) renewalDue() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.cert == nil || len(m.cert.Certificate) == 0 {
+		return true
+	}
+
+	leaf, err := x509.ParseCertificate(m.cert.Certificate[0])
+	if err != nil {
+		return true
+	}
+
+	return time.Until(leaf.NotAfter) < dnsRenewBefore || !slices.Equal(slices.Sorted(slices.Values(leaf.DNSNames)), slices.Sorted(slices.Values(m.domains)))
+}
+
+func (m *dnsManager) obtain(ctx context.Context) (*tls.Certificate, error) {
+	var contact []string
+	if m.email != "" {
+		contact = []string{"mailto:" + m.email}
+	}
+
+	if _, err := m.client.Register(ctx, &acme.Account{Contact: contact}, acme.AcceptTOS); err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return nil, fmt.Errorf("account: %w", err)
+	}
+
+	order, err := m.client.AuthorizeOrder(ctx, acme.DomainIDs(m.domains...))
+	if err != nil {
+		return nil, fmt.Errorf("order: %w", err)
+	}
+
+	for _, uri := range order.AuthzURLs {
+		if err := m.authorize(ctx, uri); err != nil {
+			return nil, err
+		}
+	}
+
+	if order, err = m.client.WaitOrder(ctx, order.URI); err != nil {
+		return nil, fmt.Errorf("order: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: m.domains}, key)
+	if err != nil {
+		return nil, err
+	}
+
+	der, _, err := m.client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("certificate: %w", err)
+	}
+
+	var certPem []byte
+	for _, b := range der {
+		certPem = append(certPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: b})...)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	if err := os.WriteFile(filepath.Join(m.dir, dnsCert), certPem, 0o644); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(filepath.Join(m.dir, dnsKey), keyPem, 0o600); err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair(certPem, keyPem)
+	return &cert, err
+}
+
+// authorize fulfills the dns-01 challenge of a single authorization
+func (m *dnsManager) authorize(ctx context.Context, uri string) error {
+	z, err := m.client.GetAuthorization(ctx, uri)
+	if err != nil {
+		return err
+	}
+
+	if z.Status == acme.StatusValid {
+		return nil
+	}
+
+	idx := slices.IndexFunc(z.Challenges, func(c *acme.Challenge) bool {
+		return c.Type == "dns-01"
+	})
+	if idx < 0 {
+		return fmt.Errorf("%s: dns-01 challenge not offered", z.Identifier.Value)
+	}
+	chal := z.Challenges[idx]
+
+	value, err := m.client.DNS01ChallengeRecord(chal.Token)
+	if err != nil {
+		return err
+	}
+
+	record := "_acme-challenge." + z.Identifier.Value
+	if err := m.record(record + " " + value); err != nil {
+		return fmt.Errorf("%s: %w", record, err)
+	}
+
+	m.waitPropagation(ctx, record, value)
+
+	if _, err := m.client.Accept(ctx, chal); err != nil {
+		return fmt.Errorf("%s: %w", record, err)
+	}
+
+	if _, err := m.client.WaitAuthorization(ctx, z.URI); err != nil {
+		return fmt.Errorf("%s: %w", z.Identifier.Value, err)
+	}
+
+	return nil
+}
+
+// waitPropagation waits until the txt record is visible or the propagation timeout has elapsed
+func (m *dnsManager) waitPropagation(ctx context.Context, record, value string) {
+	ctx, cancel := context.WithTimeout(ctx, dnsPropagation)
+	defer cancel()
+
+	for {
+		if txt, err := net.DefaultResolver.LookupTXT(ctx, record); err == nil && slices.Contains(txt, value) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			m.log.WARN.Printf("%s: txt record not visible, trying anyway", record)
+			return
+		case <-time.After(dnsLookupPeriod):
+		}
+	}
+}
+
+// loadOrCreateKey loads or creates a persisted ecdsa key
+func loadOrCreateKey(file string) (crypto.Signer, error) {
+	if b, err := os.ReadFile(file); err == nil {
+		block, _ := pem.Decode(b)
+		if block == nil {
+			return nil, fmt.Errorf("invalid key: %s", file)
+		}
+		return x509.ParseECPrivateKey(block.Bytes)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600)
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"crypto/x509"
+	"net/http"
+	"testing"
+
+	"github.com/evcc-io/evcc/api/globalconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfSignedCertificate(t *testing.T) {
+	dir := t.TempDir()
+
+	cert, err := selfSignedCertificate("evcc.local", dir)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	assert.NoError(t, leaf.VerifyHostname("evcc.local"))
+	assert.NoError(t, leaf.VerifyHostname("127.0.0.1"))
+
+	// reuse persisted certificate
+	cert2, err := selfSignedCertificate("evcc.local", dir)
+	require.NoError(t, err)
+	assert.Equal(t, cert.Certificate, cert2.Certificate)
+}
+
+func TestConfigureTLS(t *testing.T) {
+	s := &HTTPd{Server: new(http.Server)}
+
+	require.NoError(t, s.ConfigureTLS(globalconfig.TLS{}, "evcc.local", t.TempDir(), nil))
+	assert.Nil(t, s.TLSConfig)
+
+	require.NoError(t, s.ConfigureTLS(globalconfig.TLS{Mode: "selfsigned"}, "192.168.0.1", t.TempDir(), nil))
+	require.NotNil(t, s.TLSConfig)
+	assert.Len(t, s.TLSConfig.Certificates, 1)
+
+	assert.Error(t, s.ConfigureTLS(globalconfig.TLS{Mode: "foo"}, "evcc.local", t.TempDir(), nil))
+
+	// dns challenge requires a plugin
+	assert.Error(t, s.ConfigureTLS(globalconfig.TLS{Mode: "acme", Challenge: "dns"}, "evcc.local", t.TempDir(), nil))
+
+	// tls-alpn challenge requires port 443
+	s.Addr = ":7070"
+	assert.Error(t, s.ConfigureTLS(globalconfig.TLS{Mode: "acme", Challenge: "tls-alpn"}, "evcc.local", t.TempDir(), nil))
+}
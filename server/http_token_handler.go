@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/evcc-io/evcc/util/oauth"
+	"github.com/gorilla/mux"
+)
+
+// tokensHandler returns the status of all provider tokens
+func tokensHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResult(w, oauth.Tokens.Status())
+}
+
+// revokeTokenHandler revokes a provider token
+func revokeTokenHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := oauth.Tokens.Revoke(id); err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	jsonResult(w, id)
+}
+
+// enableTokenHandler re-enables a revoked provider token
+func enableTokenHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := oauth.Tokens.Enable(id); err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	jsonResult(w, id)
+}
@@ -2,17 +2,21 @@ package server
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api/globalconfig"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/util"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	influxapi "github.com/influxdata/influxdb-client-go/v2/api"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
 	influxlog "github.com/influxdata/influxdb-client-go/v2/log"
 )
@@ -20,15 +24,61 @@ import (
 // Influx is a influx publisher
 type Influx struct {
 	sync.Mutex
-	log      *util.Logger
-	clock    clock.Clock
-	client   influxdb2.Client
-	org      string
-	database string
+	log         *util.Logger
+	clock       clock.Clock
+	client      influxdb2.Client
+	org         string
+	database    string
+	measurement *template.Template
+	tags        map[string]string
+	buckets     []globalconfig.InfluxBucket
+}
+
+// InfluxOption configures the influx publisher
+type InfluxOption func(*Influx) error
+
+// WithMeasurement sets the measurement name template
+func WithMeasurement(tmpl string) InfluxOption {
+	return func(m *Influx) error {
+		if tmpl == "" {
+			return nil
+		}
+
+		t, err := template.New("measurement").Option("missingkey=error").Parse(tmpl)
+		if err == nil {
+			m.measurement = t
+		}
+		return err
+	}
+}
+
+// WithTags sets the tag name mapping
+func WithTags(tags map[string]string) InfluxOption {
+	return func(m *Influx) error {
+		m.tags = tags
+		return nil
+	}
+}
+
+// WithBuckets adds additional buckets
+func WithBuckets(buckets []globalconfig.InfluxBucket) InfluxOption {
+	return func(m *Influx) error {
+		for _, b := range buckets {
+			if b.Database == "" {
+				return errors.New("missing bucket database")
+			}
+			if b.Interval < 0 {
+				return fmt.Errorf("invalid bucket interval: %v", b.Interval)
+			}
+		}
+
+		m.buckets = buckets
+		return nil
+	}
 }
 
 // NewInfluxClient creates new publisher for influx
-func NewInfluxClient(url, token, org, user, password, database string, insecure bool) *Influx {
+func NewInfluxClient(url, token, org, user, password, database string, insecure bool, opts ...InfluxOption) (*Influx, error) {
 	log := util.NewLogger("influx")
 
 	// InfluxDB v1 compatibility
@@ -46,13 +96,55 @@ func NewInfluxClient(url, token, org, user, password, database string, insecure
 	// handle error logging in writer
 	influxlog.Log = nil
 
-	return &Influx{
+	m := &Influx{
 		log:      log,
 		clock:    clock.New(),
 		client:   client,
 		org:      org,
 		database: database,
 	}
+
+	for _, o := range opts {
+		if err := o(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// measurementName returns the measurement name for the key
+func (m *Influx) measurementName(key string) string {
+	if m.measurement == nil {
+		return key
+	}
+
+	var b strings.Builder
+	if err := m.measurement.Execute(&b, map[string]any{"key": key}); err != nil {
+		return key
+	}
+
+	return b.String()
+}
+
+// mapTags renames or drops tags according to the tag name mapping
+func (m *Influx) mapTags(tags map[string]string) map[string]string {
+	if len(m.tags) == 0 {
+		return tags
+	}
+
+	res := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if name, ok := m.tags[k]; ok {
+			if name == "" {
+				continue
+			}
+			k = name
+		}
+		res[k] = v
+	}
+
+	return res
 }
 
 // pointWriter is the minimal interface for influxdb2 api.Writer
@@ -63,7 +155,7 @@ type pointWriter interface {
 // writePoint asynchronously writes a point to influx
 func (m *Influx) writePoint(writer pointWriter, key string, fields map[string]any, tags map[string]string) {
 	m.log.TRACE.Printf("write %s=%v (%v)", key, fields, tags)
-	writer.WritePoint(influxdb2.NewPoint(key, tags, fields, m.clock.Now()))
+	writer.WritePoint(influxdb2.NewPoint(m.measurementName(key), m.mapTags(tags), fields, m.clock.Now()))
 }
 
 // writeComplexPoint asynchronously writes a point to influx
@@ -129,9 +221,9 @@ func (m *Influx) writeComplexPoint(writer pointWriter, param util.Param, tags ma
 	m.writePoint(writer, param.Key, fields, tags)
 }
 
-// Run Influx publisher
-func (m *Influx) Run(site site.API, in <-chan util.Param) {
-	writer := m.client.WriteAPI(m.org, m.database)
+// writeAPI creates a write api for the bucket and logs its errors
+func (m *Influx) writeAPI(database string) influxapi.WriteAPI {
+	writer := m.client.WriteAPI(m.org, database)
 
 	// log errors
 	go func() {
@@ -141,6 +233,26 @@ func (m *Influx) Run(site site.API, in <-chan util.Param) {
 		}
 	}()
 
+	return writer
+}
+
+// Run Influx publisher
+func (m *Influx) Run(site site.API, in <-chan util.Param) {
+	writer := multiWriter{m.writeAPI(m.database)}
+
+	for _, b := range m.buckets {
+		w := m.writeAPI(b.Database)
+		if b.Interval == 0 {
+			writer = append(writer, w)
+			continue
+		}
+
+		ds := newDownsampler(w)
+		go ds.run(m.clock.Ticker(b.Interval))
+
+		writer = append(writer, ds)
+	}
+
 	// add points to batch for async writing
 	for param := range in {
 		tags := make(map[string]string)
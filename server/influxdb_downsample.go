@@ -0,0 +1,130 @@
+package server
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// multiWriter writes points to multiple writers
+type multiWriter []pointWriter
+
+func (mw multiWriter) WritePoint(p *write.Point) {
+	for _, w := range mw {
+		w.WritePoint(p)
+	}
+}
+
+// series is the aggregated state of a measurement and tag set
+type series struct {
+	name  string
+	tags  map[string]string
+	sum   map[string]float64
+	count map[string]int
+}
+
+// downsampler averages numeric point fields per series and writes them once per interval
+type downsampler struct {
+	mu     sync.Mutex
+	writer pointWriter
+	series map[string]*series
+}
+
+func newDownsampler(writer pointWriter) *downsampler {
+	return &downsampler{
+		writer: writer,
+		series: make(map[string]*series),
+	}
+}
+
+// seriesKey identifies measurement and tag set
+func seriesKey(p *write.Point) string {
+	var b strings.Builder
+	b.WriteString(p.Name())
+
+	for _, t := range p.TagList() {
+		b.WriteString("," + t.Key + "=" + t.Value)
+	}
+
+	return b.String()
+}
+
+// WritePoint adds the point's numeric fields to the aggregate
+func (ds *downsampler) WritePoint(p *write.Point) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	key := seriesKey(p)
+
+	s, ok := ds.series[key]
+	if !ok {
+		s = &series{
+			name:  p.Name(),
+			tags:  make(map[string]string),
+			sum:   make(map[string]float64),
+			count: make(map[string]int),
+		}
+
+		for _, t := range p.TagList() {
+			s.tags[t.Key] = t.Value
+		}
+
+		ds.series[key] = s
+	}
+
+	for _, f := range p.FieldList() {
+		var v float64
+
+		switch val := f.Value.(type) {
+		case float64:
+			v = val
+		case int64:
+			v = float64(val)
+		case uint64:
+			v = float64(val)
+		default:
+			continue
+		}
+
+		s.sum[f.Key] += v
+		s.count[f.Key]++
+	}
+}
+
+// flush writes the averaged values and resets the aggregate
+func (ds *downsampler) flush(ts time.Time) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	keys := make([]string, 0, len(ds.series))
+	for k := range ds.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		s := ds.series[k]
+
+		if len(s.count) > 0 {
+			fields := make(map[string]any, len(s.count))
+			for f, c := range s.count {
+				fields[f] = s.sum[f] / float64(c)
+			}
+
+			ds.writer.WritePoint(influxdb2.NewPoint(s.name, s.tags, fields, ts))
+		}
+	}
+
+	clear(ds.series)
+}
+
+func (ds *downsampler) run(ticker *clock.Ticker) {
+	for ts := range ticker.C {
+		ds.flush(ts)
+	}
+}
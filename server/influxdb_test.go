@@ -2,12 +2,15 @@ package server
 
 import (
 	"testing"
+	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api/globalconfig"
 	"github.com/evcc-io/evcc/util"
 	inf2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type influxWriter struct {
@@ -88,3 +91,46 @@ func TestInfluxTypes(t *testing.T) {
 		w.finish()
 	}
 }
+
+func TestInfluxNaming(t *testing.T) {
+	m := &Influx{
+		log:   util.NewLogger("foo"),
+		clock: clock.NewMock(),
+	}
+
+	require.NoError(t, WithMeasurement("evcc_{{.key}}")(m))
+	require.NoError(t, WithTags(map[string]string{"loadpoint": "lp", "vehicle": ""})(m))
+
+	w := &influxWriter{
+		t: t, p: []*write.Point{inf2.NewPoint("evcc_chargePower", map[string]string{"lp": "Garage"}, map[string]any{"value": 1000.0}, m.clock.Now())},
+	}
+	m.writeComplexPoint(w, util.Param{Key: "chargePower", Val: 1000.0}, map[string]string{"loadpoint": "Garage", "vehicle": "Car"})
+	w.finish()
+
+	assert.Error(t, WithMeasurement("{{.key")(m))
+	assert.Error(t, WithBuckets([]globalconfig.InfluxBucket{{Interval: time.Minute}})(m))
+}
+
+func TestInfluxDownsampling(t *testing.T) {
+	clock := clock.NewMock()
+
+	w := &influxWriter{
+		t: t, p: []*write.Point{
+			inf2.NewPoint("chargePower", map[string]string{"loadpoint": "Garage"}, map[string]any{"value": 1500.0}, clock.Now()),
+			inf2.NewPoint("gridPower", nil, map[string]any{"value": 100.0}, clock.Now()),
+		},
+	}
+
+	ds := newDownsampler(w)
+	ds.WritePoint(inf2.NewPoint("chargePower", map[string]string{"loadpoint": "Garage"}, map[string]any{"value": 1000.0}, clock.Now()))
+	ds.WritePoint(inf2.NewPoint("chargePower", map[string]string{"loadpoint": "Garage"}, map[string]any{"value": 2000}, clock.Now()))
+	ds.WritePoint(inf2.NewPoint("gridPower", nil, map[string]any{"value": 100.0}, clock.Now()))
+	ds.WritePoint(inf2.NewPoint("vehicleTitle", nil, map[string]any{"value": nil}, clock.Now()))
+
+	ds.flush(clock.Now())
+	w.finish()
+
+	// aggregate is reset after flush
+	ds.flush(clock.Now())
+	w.finish()
+}
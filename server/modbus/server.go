@@ -0,0 +1,222 @@
+package modbus
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sync"
+
+	"github.com/andig/mbserver"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/util"
+)
+
+// siteKeys are the site model values in register order
+var siteKeys = []string{keys.GridPower, keys.PvPower, keys.BatteryPower, keys.HomePower, keys.BatterySoc}
+
+// Server is a modbus slave exposing site and loadpoint values using a SunSpec-like register layout
+type Server struct {
+	*mbserver.DummyHandler
+	log      *util.Logger
+	site     site.API
+	readOnly bool
+
+	mu     sync.RWMutex
+	values map[string]float64
+}
+
+// StartServer starts the modbus slave on the given port
+func StartServer(port int, site site.API, readOnly bool) (*Server, error) {
+	s := newServer(site, readOnly)
+
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.DEBUG.Printf("modbus server listening at :%d", port)
+
+	srv, err := mbserver.New(s, mbserver.Logger(&logger{log: s.log}))
+	if err == nil {
+		err = srv.Start(l)
+	}
+
+	return s, err
+}
+
+func newServer(site site.API, readOnly bool) *Server {
+	return &Server{
+		DummyHandler: new(mbserver.DummyHandler),
+		log:          util.NewLogger("modbus"),
+		site:         site,
+		readOnly:     readOnly,
+		values:       make(map[string]float64),
+	}
+}
+
+// Run updates the site values
+func (s *Server) Run(in <-chan util.Param) {
+	for p := range in {
+		if p.Loadpoint != nil {
+			continue
+		}
+
+		if v, ok := p.Val.(float64); ok {
+			s.mu.Lock()
+			s.values[p.Key] = v
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *Server) siteValue(key string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if v, ok := s.values[key]; ok {
+		return v
+	}
+
+	return math.NaN()
+}
+
+// registers returns the complete register image starting at sunspecBase
+func (s *Server) registers() registers {
+	var r registers
+
+	r.string("SunS", 2)
+
+	r.uint16(sunspecCommonModel, sunspecCommonLength)
+	r.string("evcc", 16)
+	r.string("evcc", 16)
+	r.string("", 8)
+	r.string(server.Version, 8)
+	r.string("", 16)
+	r.uint16(1, 0)
+
+	lps := s.site.Loadpoints()
+
+	r.uint16(sunspecSiteModel, sunspecSiteLength)
+	for _, key := range siteKeys {
+		r.float32(s.siteValue(key))
+	}
+	r.uint16(uint16(len(lps)), 0)
+
+	for _, lp := range lps {
+		r.uint16(sunspecLoadpointModel, sunspecLoadpointLen)
+		r.float32(lp.GetChargePower())
+		r.uint16(
+			indexOf(sunspecStatus, lp.GetStatus()),
+			indexOf(sunspecModes, lp.GetMode()),
+			uint16(math.Round(10*lp.GetMinCurrent())),
+			uint16(math.Round(10*lp.GetMaxCurrent())),
+			uint16(lp.GetLimitSoc()),
+			uint16(lp.GetPhases()),
+		)
+	}
+
+	r.uint16(sunspecEndModel, 0)
+
+	return r
+}
+
+func (s *Server) read(addr, qty uint16) ([]uint16, error) {
+	r := s.registers()
+
+	if addr < sunspecBase || int(addr-sunspecBase)+int(qty) > len(r) {
+		return nil, mbserver.ErrIllegalDataAddress
+	}
+
+	return r[addr-sunspecBase : addr-sunspecBase+qty], nil
+}
+
+// write validates and applies loadpoint control register writes
+func (s *Server) write(addr uint16, values []uint16) error {
+	lps := s.site.Loadpoints()
+
+	type setter struct {
+		lp, reg int
+		val     uint16
+	}
+
+	var set []setter
+
+	for i, val := range values {
+		a := int(addr) + i
+
+		lp := -1
+		for id := range lps {
+			if start := int(sunspecLoadpointAddr(id)); a >= start && a < start+sunspecLoadpointLen {
+				lp = id
+				break
+			}
+		}
+
+		if lp < 0 {
+			return mbserver.ErrIllegalDataAddress
+		}
+
+		reg := a - int(sunspecLoadpointAddr(lp))
+
+		switch reg {
+		case lpMode:
+			if int(val) >= len(sunspecModes) {
+				return mbserver.ErrIllegalDataValue
+			}
+		case lpMinCurrent, lpMaxCurrent:
+		case lpLimitSoc:
+			if val > 100 {
+				return mbserver.ErrIllegalDataValue
+			}
+		default:
+			return mbserver.ErrIllegalDataAddress
+		}
+
+		set = append(set, setter{lp: lp, reg: reg, val: val})
+	}
+
+	for _, w := range set {
+		lp := lps[w.lp]
+
+		var err error
+		switch w.reg {
+		case lpMode:
+			lp.SetMode(sunspecModes[w.val])
+		case lpMinCurrent:
+			err = lp.SetMinCurrent(float64(w.val) / 10)
+		case lpMaxCurrent:
+			err = lp.SetMaxCurrent(float64(w.val) / 10)
+		case lpLimitSoc:
+			lp.SetLimitSoc(int(w.val))
+		}
+
+		if err != nil {
+			s.log.DEBUG.Printf("loadpoint %d: %v", w.lp+1, err)
+			return mbserver.ErrIllegalDataValue
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) HandleInputRegisters(req *mbserver.InputRegistersRequest) ([]uint16, error) {
+	s.log.TRACE.Printf("read input: id %d addr %d qty %d", req.UnitId, req.Addr, req.Quantity)
+	return s.read(req.Addr, req.Quantity)
+}
+
+func (s *Server) HandleHoldingRegisters(req *mbserver.HoldingRegistersRequest) ([]uint16, error) {
+	if req.IsWrite {
+		if s.readOnly {
+			s.log.TRACE.Printf("deny: write holdings: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
+			return nil, mbserver.ErrIllegalFunction
+		}
+
+		s.log.TRACE.Printf("write holdings: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
+		return req.Args, s.write(req.Addr, req.Args)
+	}
+
+	s.log.TRACE.Printf("read holdings: id %d addr %d qty %d", req.UnitId, req.Addr, req.Quantity)
+	return s.read(req.Addr, req.Quantity)
+}
@@ -0,0 +1,97 @@
+package modbus
+
+import (
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/andig/mbserver"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/modbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testSite struct {
+	site.API
+	lps []loadpoint.API
+}
+
+func (s *testSite) Loadpoints() []loadpoint.API {
+	return s.lps
+}
+
+func TestServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetChargePower().Return(3680.0).AnyTimes()
+	lp.EXPECT().GetStatus().Return(api.StatusC).AnyTimes()
+	lp.EXPECT().GetMode().Return(api.ModePV).AnyTimes()
+	lp.EXPECT().GetMinCurrent().Return(6.0).AnyTimes()
+	lp.EXPECT().GetMaxCurrent().Return(16.0).AnyTimes()
+	lp.EXPECT().GetLimitSoc().Return(80).AnyTimes()
+	lp.EXPECT().GetPhases().Return(1).AnyTimes()
+
+	s := newServer(&testSite{lps: []loadpoint.API{lp}}, false)
+
+	in := make(chan util.Param, 1)
+	in <- util.Param{Key: keys.GridPower, Val: -1500.0}
+	close(in)
+	s.Run(in)
+
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	srv, _ := mbserver.New(s)
+	require.NoError(t, srv.Start(l))
+	defer func() { _ = srv.Stop() }()
+
+	conn, err := modbus.NewConnection(l.Addr().String(), "", "", 0, modbus.Tcp, 1)
+	require.NoError(t, err)
+
+	// marker
+	b, err := conn.ReadHoldingRegisters(sunspecBase, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "SunS", string(b))
+
+	// site model
+	b, err = conn.ReadHoldingRegisters(sunspecBase+2+2+sunspecCommonLength, 6)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(sunspecSiteModel), binary.BigEndian.Uint16(b))
+	assert.Equal(t, float32(-1500), math.Float32frombits(binary.BigEndian.Uint32(b[4:])))
+	assert.True(t, math.IsNaN(float64(math.Float32frombits(binary.BigEndian.Uint32(b[8:])))))
+
+	// loadpoint model
+	addr := sunspecLoadpointAddr(0)
+	b, err = conn.ReadHoldingRegisters(addr, sunspecLoadpointLen+2)
+	require.NoError(t, err)
+	assert.Equal(t, float32(3680), math.Float32frombits(binary.BigEndian.Uint32(b)))
+	assert.Equal(t, []uint16{3, 3, 60, 160, 80, 1}, bytesAsUint16(b[4:16]))
+	assert.Equal(t, []uint16{sunspecEndModel, 0}, bytesAsUint16(b[16:]))
+
+	// control
+	lp.EXPECT().SetMode(api.ModeNow)
+	_, err = conn.WriteSingleRegister(addr+lpMode, 1)
+	require.NoError(t, err)
+
+	lp.EXPECT().SetMinCurrent(8.0).Return(nil)
+	lp.EXPECT().SetMaxCurrent(10.5).Return(nil)
+	_, err = conn.WriteMultipleRegisters(addr+lpMinCurrent, 2, asBytes([]uint16{80, 105}))
+	require.NoError(t, err)
+
+	// read-only and invalid registers
+	_, err = conn.WriteSingleRegister(addr+lpStatus, 1)
+	assert.Error(t, err)
+	_, err = conn.WriteSingleRegister(addr+lpMode, 9)
+	assert.Error(t, err)
+	_, err = conn.ReadHoldingRegisters(addr, 20)
+	assert.Error(t, err)
+}
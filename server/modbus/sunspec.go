@@ -0,0 +1,80 @@
+package modbus
+
+import (
+	"math"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// SunSpec-like holding register layout. The map starts with the SunSpec marker and common model
+// followed by the evcc vendor models and the end marker:
+//
+//	40000  "SunS" marker
+//	40002  common model (1), length 66: manufacturer, model, options, version, serial, device address
+//	40070  site model (64900), length 12: grid, pv, battery, home power (float32, W), battery soc (float32, %), loadpoint count
+//	40084  loadpoint model (64901) per loadpoint, length 8: charge power (float32, W), status, mode, min current, max current, limit soc, phases
+//	       end marker (0xFFFF, 0)
+const (
+	sunspecBase = 40000
+
+	sunspecCommonModel    = 1
+	sunspecCommonLength   = 66
+	sunspecSiteModel      = 64900
+	sunspecSiteLength     = 12
+	sunspecLoadpointModel = 64901
+	sunspecLoadpointLen   = 8
+
+	sunspecEndModel = 0xFFFF
+)
+
+// loadpoint model register offsets relative to the model's data block
+const (
+	lpChargePower = 0
+	lpStatus      = 2
+	lpMode        = 3
+	lpMinCurrent  = 4 // 0.1A
+	lpMaxCurrent  = 5 // 0.1A
+	lpLimitSoc    = 6 // %
+	lpPhases      = 7
+)
+
+// sunspecModes maps charge modes to register values
+var sunspecModes = []api.ChargeMode{api.ModeOff, api.ModeNow, api.ModeMinPV, api.ModePV}
+
+// sunspecStatus maps charge status to register values
+var sunspecStatus = []api.ChargeStatus{api.StatusNone, api.StatusA, api.StatusB, api.StatusC, api.StatusD, api.StatusE, api.StatusF}
+
+// sunspecLoadpointAddr returns the address of the loadpoint model data block. The loadpoint index starts at 0.
+func sunspecLoadpointAddr(lp int) uint16 {
+	return sunspecBase + 2 + 2 + sunspecCommonLength + 2 + sunspecSiteLength + uint16(lp)*(2+sunspecLoadpointLen) + 2
+}
+
+// registers is a register image builder
+type registers []uint16
+
+func (r *registers) uint16(u ...uint16) {
+	*r = append(*r, u...)
+}
+
+func (r *registers) float32(f float64) {
+	u := math.Float32bits(float32(f))
+	r.uint16(uint16(u>>16), uint16(u))
+}
+
+// string encodes s into a fixed length block of n registers
+func (r *registers) string(s string, n int) {
+	b := make([]byte, 2*n)
+	copy(b, s)
+	for i := 0; i < n; i++ {
+		r.uint16(uint16(b[2*i])<<8 | uint16(b[2*i+1]))
+	}
+}
+
+func indexOf[T comparable](s []T, v T) uint16 {
+	for i, e := range s {
+		if e == v {
+			return uint16(i)
+		}
+	}
+	return 0xFFFF
+}
@@ -9,11 +9,14 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/api/globalconfig"
+	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/provider/mqtt"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
 )
 
 // MQTT is the MQTT server. It uses the MQTT client for publishing.
@@ -21,19 +24,26 @@ type MQTT struct {
 	log       *util.Logger
 	Handler   *mqtt.Client
 	root      string
+	layout    *mqttLayout
 	publisher func(topic string, retained bool, payload string)
 }
 
 // NewMQTT creates MQTT server
-func NewMQTT(root string, site site.API) (*MQTT, error) {
+func NewMQTT(root string, conf globalconfig.MqttPublish, site site.API) (*MQTT, error) {
+	layout, err := newMqttLayout(conf)
+	if err != nil {
+		return nil, fmt.Errorf("mqtt: %w", err)
+	}
+
 	m := &MQTT{
 		log:     util.NewLogger("mqtt"),
 		Handler: mqtt.Instance,
 		root:    root,
+		layout:  layout,
 	}
 	m.publisher = m.publishString
 
-	err := m.Handler.Cleanup(m.root, true)
+	err = m.Handler.Cleanup(m.root, true)
 	if err == nil {
 		err = m.Listen(site)
 	}
@@ -121,7 +131,8 @@ func (m *MQTT) publishComplex(topic string, retained bool, payload interface{})
 }
 
 func (m *MQTT) publishString(topic string, retained bool, payload string) {
-	token := m.Handler.Client.Publish(topic, m.Handler.Qos, retained, m.encode(payload))
+	qos, retained := m.policy(topic, m.Handler.Qos, retained)
+	token := m.Handler.Client.Publish(topic, qos, retained, m.encode(payload))
 	go m.Handler.WaitForToken("send", topic, token)
 }
 
@@ -148,13 +159,15 @@ func (m *MQTT) publish(topic string, retained bool, payload interface{}) {
 }
 
 func (m *MQTT) Listen(site site.API) error {
-	if err := m.listenSiteSetters(m.root+"/site", site); err != nil {
+	if err := m.listenSiteSetters(m.siteTopic, site); err != nil {
 		return err
 	}
 
 	// loadpoint setters
 	for id, lp := range site.Loadpoints() {
-		topic := fmt.Sprintf("%s/loadpoints/%d", m.root, id+1)
+		topic := func(key string) string {
+			return m.loadpointTopic(id+1, key)
+		}
 		if err := m.listenLoadpointSetters(topic, site, lp); err != nil {
 			return err
 		}
@@ -168,10 +181,19 @@ func (m *MQTT) Listen(site site.API) error {
 		}
 	}
 
+	// circuit setters
+	for _, dev := range config.Circuits().Devices() {
+		name := dev.Config().Name
+		topic := fmt.Sprintf("%s/%s", m.siteTopic(keys.Circuits), name)
+		if err := m.listenCircuitSetters(topic, site, name); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (m *MQTT) listenSiteSetters(topic string, site site.API) error {
+func (m *MQTT) listenSiteSetters(topic func(string) string, site site.API) error {
 	for _, s := range []setter{
 		{"/bufferSoc", floatSetter(site.SetBufferSoc)},
 		{"/bufferStartSoc", floatSetter(site.SetBufferStartSoc)},
@@ -184,8 +206,11 @@ func (m *MQTT) listenSiteSetters(topic string, site site.API) error {
 			}
 		}))},
 		{"/batteryGridChargeLimit", floatPtrSetter(pass(site.SetBatteryGridChargeLimit))},
+		{"/batteryGridChargeHours", intSetter(site.SetBatteryGridChargeHours)},
+		{"/batteryReserve", floatPtrSetter(site.SetBatteryReserve)},
+		{"/exportLimit", floatPtrSetter(site.SetExportLimit)},
 	} {
-		if err := m.Handler.ListenSetter(topic+s.topic, s.fun); err != nil {
+		if err := m.Handler.ListenSetter(topic(strings.TrimPrefix(s.topic, "/")), s.fun); err != nil {
 			return err
 		}
 	}
@@ -193,7 +218,7 @@ func (m *MQTT) listenSiteSetters(topic string, site site.API) error {
 	return nil
 }
 
-func (m *MQTT) listenLoadpointSetters(topic string, site site.API, lp loadpoint.API) error {
+func (m *MQTT) listenLoadpointSetters(topic func(string) string, site site.API, lp loadpoint.API) error {
 	for _, s := range []setter{
 		{"/mode", setterFunc(api.ChargeModeString, pass(lp.SetMode))},
 		{"/phases", intSetter(lp.SetPhases)},
@@ -206,7 +231,18 @@ func (m *MQTT) listenLoadpointSetters(topic string, site site.API, lp loadpoint.
 		{"/enableDelay", durationSetter(pass(lp.SetEnableDelay))},
 		{"/disableDelay", durationSetter(pass(lp.SetDisableDelay))},
 		{"/smartCostLimit", floatPtrSetter(pass(lp.SetSmartCostLimit))},
+		{"/smartCo2Limit", floatPtrSetter(pass(lp.SetSmartCo2Limit))},
+		{"/bufferSoc", floatPtrSetter(lp.SetBufferSoc)},
+		{"/bufferStartSoc", floatPtrSetter(lp.SetBufferStartSoc)},
 		{"/batteryBoost", boolSetter(lp.SetBatteryBoost)},
+		{"/pause", setterFunc(loadpoint.PauseReasonString, func(reason loadpoint.PauseReason) error {
+			if reason == loadpoint.PauseNone {
+				lp.Resume()
+				return nil
+			}
+			return lp.Pause(reason, 0)
+		})},
+		{"/planOptimization", setterFunc(loadpoint.PlanOptimizationString, lp.SetPlanOptimization)},
 		{"/planEnergy", func(payload string) error {
 			var plan struct {
 				Time  time.Time `json:"time"`
@@ -231,7 +267,7 @@ func (m *MQTT) listenLoadpointSetters(topic string, site site.API, lp loadpoint.
 			return err
 		}},
 	} {
-		if err := m.Handler.ListenSetter(topic+s.topic, s.fun); err != nil {
+		if err := m.Handler.ListenSetter(topic(strings.TrimPrefix(s.topic, "/")), s.fun); err != nil {
 			return err
 		}
 	}
@@ -263,6 +299,23 @@ func (m *MQTT) listenVehicleSetters(topic string, v vehicle.API) error {
 	return nil
 }
 
+func (m *MQTT) listenCircuitSetters(topic string, site site.API, name string) error {
+	for _, s := range []setter{
+		{topic + "/maxPower", floatPtrSetter(func(power *float64) error {
+			return site.SetCircuitMaxPower(name, power)
+		})},
+		{topic + "/maxCurrent", floatPtrSetter(func(current *float64) error {
+			return site.SetCircuitMaxCurrent(name, current)
+		})},
+	} {
+		if err := m.Handler.ListenSetter(s.topic, s.fun); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Run starts the MQTT publisher for the MQTT API
 func (m *MQTT) Run(site site.API, in <-chan util.Param) {
 	// number of loadpoints
@@ -274,24 +327,44 @@ func (m *MQTT) Run(site site.API, in <-chan util.Param) {
 	m.publish(topic, true, len(site.Vehicles().Settings()))
 
 	for i := 0; i < 10; i++ {
-		m.publish(fmt.Sprintf("%s/site/pv/%d", m.root, i), true, nil)
-		m.publish(fmt.Sprintf("%s/site/battery/%d", m.root, i), true, nil)
-		m.publish(fmt.Sprintf("%s/site/vehicles/%d", m.root, i), true, nil)
+		m.publish(fmt.Sprintf("%s/%d", m.siteTopic("pv"), i), true, nil)
+		m.publish(fmt.Sprintf("%s/%d", m.siteTopic("battery"), i), true, nil)
+		m.publish(fmt.Sprintf("%s/%d", m.siteTopic("vehicles"), i), true, nil)
 	}
 
 	// alive indicator
 	var updated time.Time
 
+	// json state documents
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
 	// publish
-	for p := range in {
+	for {
+		var p util.Param
+
+		select {
+		case <-ticker.C:
+			m.publishStates()
+			continue
+
+		case param, ok := <-in:
+			if !ok {
+				return
+			}
+			p = param
+		}
+
 		switch {
+		case p.Loadpoint != nil && m.layout.json:
+			m.updateState(*p.Loadpoint+1, p.Key, p.Val)
+			continue
 		case p.Loadpoint != nil:
-			id := *p.Loadpoint + 1
-			topic = fmt.Sprintf("%s/loadpoints/%d/%s", m.root, id, p.Key)
+			topic = m.loadpointTopic(*p.Loadpoint+1, p.Key)
 		case p.Key == "vehicles":
 			topic = fmt.Sprintf("%s/vehicles", m.root)
 		default:
-			topic = fmt.Sprintf("%s/site/%s", m.root, p.Key)
+			topic = m.siteTopic(p.Key)
 		}
 
 		// alive indicator
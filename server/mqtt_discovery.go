@@ -0,0 +1,265 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/site"
+)
+
+// haDevice is the Home Assistant device an entity belongs to
+type haDevice struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+	Model        string   `json:"model,omitempty"`
+	SwVersion    string   `json:"sw_version,omitempty"`
+	ViaDevice    string   `json:"via_device,omitempty"`
+}
+
+// haEntity is the Home Assistant MQTT discovery config payload
+type haEntity struct {
+	component string
+
+	Name              string    `json:"name"`
+	UniqueID          string    `json:"unique_id"`
+	StateTopic        string    `json:"state_topic,omitempty"`
+	ValueTemplate     string    `json:"value_template,omitempty"`
+	CommandTopic      string    `json:"command_topic,omitempty"`
+	UnitOfMeasurement string    `json:"unit_of_measurement,omitempty"`
+	DeviceClass       string    `json:"device_class,omitempty"`
+	StateClass        string    `json:"state_class,omitempty"`
+	Options           []string  `json:"options,omitempty"`
+	Min               *float64  `json:"min,omitempty"`
+	Max               *float64  `json:"max,omitempty"`
+	PayloadOn         string    `json:"payload_on,omitempty"`
+	PayloadOff        string    `json:"payload_off,omitempty"`
+	StateOn           string    `json:"state_on,omitempty"`
+	StateOff          string    `json:"state_off,omitempty"`
+	Device            *haDevice `json:"device"`
+}
+
+func haSensor(name, topic, unit, deviceClass, stateClass string) haEntity {
+	return haEntity{
+		component:         "sensor",
+		Name:              name,
+		StateTopic:        topic,
+		UnitOfMeasurement: unit,
+		DeviceClass:       deviceClass,
+		StateClass:        stateClass,
+	}
+}
+
+func haPower(name, topic string) haEntity {
+	return haSensor(name, topic, "W", "power", "measurement")
+}
+
+func haBinarySensor(name, topic, deviceClass string) haEntity {
+	return haEntity{
+		component:   "binary_sensor",
+		Name:        name,
+		StateTopic:  topic,
+		DeviceClass: deviceClass,
+		PayloadOn:   "true",
+		PayloadOff:  "false",
+	}
+}
+
+func haSwitch(name, topic string) haEntity {
+	return haEntity{
+		component:    "switch",
+		Name:         name,
+		StateTopic:   topic,
+		CommandTopic: topic + "/set",
+		PayloadOn:    "true",
+		PayloadOff:   "false",
+		StateOn:      "true",
+		StateOff:     "false",
+	}
+}
+
+func haNumber(name, topic, unit string, min, max float64) haEntity {
+	return haEntity{
+		component:         "number",
+		Name:              name,
+		StateTopic:        topic,
+		CommandTopic:      topic + "/set",
+		UnitOfMeasurement: unit,
+		Min:               &min,
+		Max:               &max,
+	}
+}
+
+func haSelect(name, topic string, options []string) haEntity {
+	return haEntity{
+		component:    "select",
+		Name:         name,
+		StateTopic:   topic,
+		CommandTopic: topic + "/set",
+		Options:      options,
+	}
+}
+
+// haObjectID converts a topic into a Home Assistant compatible object id
+func haObjectID(topic string) string {
+	return strings.NewReplacer("/", "_", ".", "_", ":", "_", " ", "_", "-", "_").Replace(topic)
+}
+
+// discoveryEntities returns the Home Assistant entities for site, meters, loadpoints and vehicles
+func (m *MQTT) discoveryEntities(site site.API) []haEntity {
+	var res []haEntity
+
+	add := func(dev *haDevice, ee ...haEntity) {
+		for _, e := range ee {
+			e.Device = dev
+			e.UniqueID = strings.ToLower(haObjectID(dev.Identifiers[0] + "_" + e.Name))
+			res = append(res, e)
+		}
+	}
+
+	siteID := haObjectID(m.root)
+
+	title := site.GetTitle()
+	if title == "" {
+		title = "evcc"
+	}
+
+	siteDevice := &haDevice{
+		Identifiers:  []string{siteID},
+		Name:         title,
+		Manufacturer: "evcc",
+		Model:        "Site",
+		SwVersion:    FormattedVersion(),
+	}
+
+	add(siteDevice,
+		haPower("PV power", m.siteTopic(keys.PvPower)),
+		haPower("Grid power", m.siteTopic(keys.GridPower)),
+		haPower("Home power", m.siteTopic(keys.HomePower)),
+		haPower("Battery power", m.siteTopic(keys.BatteryPower)),
+		haSensor("Battery SoC", m.siteTopic(keys.BatterySoc), "%", "battery", "measurement"),
+		haSensor("PV energy", m.siteTopic(keys.PvEnergy), "kWh", "energy", "total_increasing"),
+		haSensor("Grid tariff", m.siteTopic(keys.TariffGrid), "", "", "measurement"),
+		haSensor("Feed-in tariff", m.siteTopic(keys.TariffFeedIn), "", "", "measurement"),
+		haSensor("CO₂ intensity", m.siteTopic(keys.TariffCo2), "g/kWh", "", "measurement"),
+		haNumber("Priority SoC", m.siteTopic(keys.PrioritySoc), "%", 0, 100),
+		haNumber("Buffer SoC", m.siteTopic(keys.BufferSoc), "%", 0, 100),
+		haNumber("Buffer start SoC", m.siteTopic(keys.BufferStartSoc), "%", 0, 100),
+		haSwitch("Battery discharge control", m.siteTopic(keys.BatteryDischargeControl)),
+	)
+
+	// meters
+	for i, ref := range site.GetPVMeterRefs() {
+		topic := fmt.Sprintf("%s/%d", m.siteTopic(keys.Pv), i+1)
+		add(&haDevice{
+			Identifiers:  []string{fmt.Sprintf("%s_pv_%d", siteID, i+1)},
+			Name:         "PV " + ref,
+			Manufacturer: "evcc",
+			Model:        "Meter",
+			ViaDevice:    siteID,
+		},
+			haPower("Power", topic+"/power"),
+			haSensor("Energy", topic+"/energy", "kWh", "energy", "total_increasing"),
+		)
+	}
+
+	for i, ref := range site.GetBatteryMeterRefs() {
+		topic := fmt.Sprintf("%s/%d", m.siteTopic(keys.Battery), i+1)
+		add(&haDevice{
+			Identifiers:  []string{fmt.Sprintf("%s_battery_%d", siteID, i+1)},
+			Name:         "Battery " + ref,
+			Manufacturer: "evcc",
+			Model:        "Meter",
+			ViaDevice:    siteID,
+		},
+			haPower("Power", topic+"/power"),
+			haSensor("SoC", topic+"/soc", "%", "battery", "measurement"),
+		)
+	}
+
+	// loadpoints
+	modes := []string{api.ModeOff.String(), api.ModeNow.String(), api.ModeMinPV.String(), api.ModePV.String()}
+
+	for id, lp := range site.Loadpoints() {
+		topic := func(key string) string {
+			return m.loadpointTopic(id+1, key)
+		}
+
+		entities := []struct {
+			key    string
+			entity haEntity
+		}{
+			{keys.ChargePower, haPower("Charge power", topic(keys.ChargePower))},
+			{keys.ChargedEnergy, haSensor("Charged energy", topic(keys.ChargedEnergy), "Wh", "energy", "total")},
+			{keys.VehicleSoc, haSensor("Vehicle SoC", topic(keys.VehicleSoc), "%", "battery", "measurement")},
+			{keys.Charging, haBinarySensor("Charging", topic(keys.Charging), "battery_charging")},
+			{keys.Connected, haBinarySensor("Connected", topic(keys.Connected), "plug")},
+			{keys.Enabled, haBinarySensor("Enabled", topic(keys.Enabled), "")},
+			{keys.Mode, haSelect("Mode", topic(keys.Mode), modes)},
+			{keys.LimitSoc, haNumber("Limit SoC", topic(keys.LimitSoc), "%", 0, 100)},
+			{keys.MinCurrent, haNumber("Min current", topic(keys.MinCurrent), "A", 0, 32)},
+			{keys.MaxCurrent, haNumber("Max current", topic(keys.MaxCurrent), "A", 0, 32)},
+			{keys.BatteryBoost, haSwitch("Battery boost", topic(keys.BatteryBoost))},
+		}
+
+		ee := make([]haEntity, 0, len(entities))
+		for _, e := range entities {
+			// read values from the json state document
+			if m.layout.json {
+				e.entity.StateTopic = topic(mqttStateKey)
+				e.entity.ValueTemplate = fmt.Sprintf("{{ value_json.%s }}", e.key)
+				if e.entity.PayloadOn != "" {
+					e.entity.ValueTemplate = fmt.Sprintf("{{ value_json.%s | string | lower }}", e.key)
+				}
+			}
+
+			ee = append(ee, e.entity)
+		}
+
+		add(&haDevice{
+			Identifiers:  []string{fmt.Sprintf("%s_loadpoint_%d", siteID, id+1)},
+			Name:         lp.Title(),
+			Manufacturer: "evcc",
+			Model:        "Loadpoint",
+			ViaDevice:    siteID,
+		}, ee...)
+	}
+
+	// vehicles
+	for _, v := range site.Vehicles().Settings() {
+		topic := fmt.Sprintf("%s/vehicles/%s", m.root, v.Name())
+
+		add(&haDevice{
+			Identifiers:  []string{fmt.Sprintf("%s_vehicle_%s", siteID, haObjectID(v.Name()))},
+			Name:         v.Instance().Title(),
+			Manufacturer: "evcc",
+			Model:        "Vehicle",
+			ViaDevice:    siteID,
+		},
+			haNumber("Min SoC", topic+"/minSoc", "%", 0, 100),
+			haNumber("Limit SoC", topic+"/limitSoc", "%", 0, 100),
+		)
+	}
+
+	return res
+}
+
+// PublishDiscovery publishes Home Assistant MQTT discovery configs below the given prefix
+func (m *MQTT) PublishDiscovery(prefix string, site site.API) error {
+	prefix = strings.Trim(prefix, "/")
+
+	for _, e := range m.discoveryEntities(site) {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("discovery: %w", err)
+		}
+
+		topic := fmt.Sprintf("%s/%s/%s/config", prefix, e.component, e.UniqueID)
+		m.publisher(topic, true, string(b))
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/evcc-io/evcc/api/globalconfig"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type discoverySite struct {
+	site.API
+	lps []loadpoint.API
+}
+
+func (s *discoverySite) GetTitle() string              { return "Home" }
+func (s *discoverySite) GetPVMeterRefs() []string      { return []string{"pv"} }
+func (s *discoverySite) GetBatteryMeterRefs() []string { return nil }
+func (s *discoverySite) Loadpoints() []loadpoint.API   { return s.lps }
+func (s *discoverySite) Vehicles() site.Vehicles       { return discoveryVehicles{} }
+
+type discoveryVehicles struct {
+	site.Vehicles
+}
+
+func (discoveryVehicles) Settings() []vehicle.API { return nil }
+
+func TestPublishDiscovery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().Title().Return("Garage").AnyTimes()
+
+	payloads := make(map[string]string)
+
+	layout, err := newMqttLayout(globalconfig.MqttPublish{})
+	require.NoError(t, err)
+
+	m := &MQTT{
+		root:   "evcc",
+		layout: layout,
+		publisher: func(topic string, retained bool, payload string) {
+			assert.True(t, retained)
+			payloads[topic] = payload
+		},
+	}
+
+	require.NoError(t, m.PublishDiscovery("homeassistant/", &discoverySite{lps: []loadpoint.API{lp}}))
+
+	var mode struct {
+		UniqueID     string   `json:"unique_id"`
+		StateTopic   string   `json:"state_topic"`
+		CommandTopic string   `json:"command_topic"`
+		Options      []string `json:"options"`
+		Device       struct {
+			Name      string `json:"name"`
+			ViaDevice string `json:"via_device"`
+		} `json:"device"`
+	}
+
+	require.Contains(t, payloads, "homeassistant/select/evcc_loadpoint_1_mode/config")
+	require.NoError(t, json.Unmarshal([]byte(payloads["homeassistant/select/evcc_loadpoint_1_mode/config"]), &mode))
+	assert.Equal(t, "evcc/loadpoints/1/mode", mode.StateTopic)
+	assert.Equal(t, "evcc/loadpoints/1/mode/set", mode.CommandTopic)
+	assert.Equal(t, []string{"off", "now", "minpv", "pv"}, mode.Options)
+	assert.Equal(t, "Garage", mode.Device.Name)
+	assert.Equal(t, "evcc", mode.Device.ViaDevice)
+
+	assert.Contains(t, payloads, "homeassistant/sensor/evcc_pv_power/config")
+	assert.Contains(t, payloads, "homeassistant/sensor/evcc_pv_1_power/config")
+	assert.Contains(t, payloads, "homeassistant/binary_sensor/evcc_loadpoint_1_charging/config")
+	assert.Contains(t, payloads, "homeassistant/switch/evcc_loadpoint_1_battery_boost/config")
+
+	// json state document
+	m.layout.json = true
+	require.NoError(t, m.PublishDiscovery("homeassistant", &discoverySite{lps: []loadpoint.API{lp}}))
+
+	var charging struct {
+		StateTopic    string `json:"state_topic"`
+		ValueTemplate string `json:"value_template"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(payloads["homeassistant/binary_sensor/evcc_loadpoint_1_charging/config"]), &charging))
+	assert.Equal(t, "evcc/loadpoints/1/state", charging.StateTopic)
+	assert.Equal(t, "{{ value_json.charging | string | lower }}", charging.ValueTemplate)
+}
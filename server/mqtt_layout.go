@@ -0,0 +1,183 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/evcc-io/evcc/api/globalconfig"
+)
+
+const (
+	mqttSiteTemplate      = "{{.root}}/site/{{.key}}"
+	mqttLoadpointTemplate = "{{.root}}/loadpoints/{{.loadpoint}}/{{.key}}"
+
+	// mqttStateKey is the key of the json state document
+	mqttStateKey = "state"
+)
+
+// mqttLayout renders topics and holds the json state documents
+type mqttLayout struct {
+	site, loadpoint *template.Template
+	json            bool
+	policies        []globalconfig.MqttPolicy
+	state           map[int]map[string]any
+	dirty           map[int]bool
+}
+
+func newMqttLayout(conf globalconfig.MqttPublish) (*mqttLayout, error) {
+	l := &mqttLayout{
+		json:     conf.Json,
+		policies: conf.Topics,
+		state:    make(map[int]map[string]any),
+		dirty:    make(map[int]bool),
+	}
+
+	var err error
+	if l.site, err = parseTopicTemplate("site", conf.Site, mqttSiteTemplate); err != nil {
+		return nil, err
+	}
+	if l.loadpoint, err = parseTopicTemplate("loadpoint", conf.Loadpoint, mqttLoadpointTemplate); err != nil {
+		return nil, err
+	}
+
+	for _, p := range conf.Topics {
+		if p.Qos != nil && *p.Qos > 2 {
+			return nil, fmt.Errorf("invalid qos for %s: %d", p.Filter, *p.Qos)
+		}
+	}
+
+	return l, nil
+}
+
+func parseTopicTemplate(name, text, def string) (*template.Template, error) {
+	if text == "" {
+		text = def
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s topic template: %w", name, err)
+	}
+
+	return tmpl, nil
+}
+
+func renderTopic(tmpl *template.Template, data map[string]any) string {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		// templates are validated on startup, fall back to key only
+		return fmt.Sprintf("%v", data["key"])
+	}
+	return strings.Trim(b.String(), "/")
+}
+
+// siteTopic returns the topic of the site key
+func (m *MQTT) siteTopic(key string) string {
+	return renderTopic(m.layout.site, map[string]any{
+		"root": m.root,
+		"key":  key,
+	})
+}
+
+// loadpointTopic returns the topic of the loadpoint key. The loadpoint id starts at 1.
+func (m *MQTT) loadpointTopic(id int, key string) string {
+	return renderTopic(m.layout.loadpoint, map[string]any{
+		"root":      m.root,
+		"loadpoint": id,
+		"key":       key,
+	})
+}
+
+// mqttTopicMatch checks if topic matches the mqtt topic filter
+func mqttTopicMatch(filter, topic string) bool {
+	ff := strings.Split(filter, "/")
+	tt := strings.Split(topic, "/")
+
+	for i, f := range ff {
+		if f == "#" {
+			return true
+		}
+		if i >= len(tt) || (f != "+" && f != tt[i]) {
+			return false
+		}
+	}
+
+	return len(ff) == len(tt)
+}
+
+// policy returns qos and retain flag for the topic
+func (m *MQTT) policy(topic string, qos byte, retained bool) (byte, bool) {
+	for _, p := range m.layout.policies {
+		if !mqttTopicMatch(p.Filter, topic) {
+			continue
+		}
+
+		if p.Qos != nil {
+			qos = *p.Qos
+		}
+		if p.Retain != nil {
+			retained = *p.Retain
+		}
+
+		break
+	}
+
+	return qos, retained
+}
+
+// jsonValue converts published values into json compatible values
+func jsonValue(v any) any {
+	switch val := v.(type) {
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			return nil
+		}
+	case time.Time:
+		if val.IsZero() {
+			return nil
+		}
+	case time.Duration:
+		return val.Seconds()
+	case fmt.Stringer:
+		return val.String()
+	}
+
+	return v
+}
+
+// updateState updates the loadpoint json state document
+func (m *MQTT) updateState(id int, key string, val any) {
+	state, ok := m.layout.state[id]
+	if !ok {
+		state = make(map[string]any)
+		m.layout.state[id] = state
+	}
+
+	val = jsonValue(val)
+	if _, err := json.Marshal(val); err != nil {
+		m.log.DEBUG.Printf("state: skipping %s: %v", key, err)
+		return
+	}
+
+	state[key] = val
+	m.layout.dirty[id] = true
+}
+
+// publishStates publishes modified loadpoint json state documents
+func (m *MQTT) publishStates() {
+	for id := range m.layout.dirty {
+		b, err := json.Marshal(m.layout.state[id])
+		if err != nil {
+			m.log.ERROR.Printf("state: %v", err)
+			continue
+		}
+
+		m.publishSingleValue(m.loadpointTopic(id, mqttStateKey), true, string(b))
+		delete(m.layout.dirty, id)
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api/globalconfig"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMqttTopicMatch(t *testing.T) {
+	for _, tc := range []struct {
+		filter, topic string
+		match         bool
+	}{
+		{"#", "evcc/site/pvPower", true},
+		{"evcc/#", "evcc/site/pvPower", true},
+		{"evcc/site/+", "evcc/site/pvPower", true},
+		{"evcc/site/+", "evcc/site/pv/1/power", false},
+		{"evcc/loadpoints/+/chargePower", "evcc/loadpoints/1/chargePower", true},
+		{"evcc/loadpoints/+/chargePower", "evcc/loadpoints/1/chargedEnergy", false},
+		{"evcc/site", "evcc/site/pvPower", false},
+	} {
+		assert.Equal(t, tc.match, mqttTopicMatch(tc.filter, tc.topic), tc)
+	}
+}
+
+func TestMqttLayout(t *testing.T) {
+	_, err := newMqttLayout(globalconfig.MqttPublish{Site: "{{.root"})
+	assert.Error(t, err, "invalid template")
+
+	qos, retain := byte(0), false
+	layout, err := newMqttLayout(globalconfig.MqttPublish{
+		Loadpoint: "{{.root}}/lp{{.loadpoint}}/{{.key}}",
+		Topics: []globalconfig.MqttPolicy{
+			{Filter: "evcc/+/chargePower", Qos: &qos, Retain: &retain},
+		},
+	})
+	require.NoError(t, err)
+
+	m := &MQTT{root: "evcc", layout: layout}
+	assert.Equal(t, "evcc/site/pvPower", m.siteTopic("pvPower"))
+	assert.Equal(t, "evcc/lp1/chargePower", m.loadpointTopic(1, "chargePower"))
+
+	q, r := m.policy("evcc/lp1/chargePower", 1, true)
+	assert.Equal(t, byte(0), q)
+	assert.False(t, r)
+
+	q, r = m.policy("evcc/lp1/mode", 1, true)
+	assert.Equal(t, byte(1), q)
+	assert.True(t, r)
+}
+
+func TestMqttJsonState(t *testing.T) {
+	layout, err := newMqttLayout(globalconfig.MqttPublish{Json: true})
+	require.NoError(t, err)
+
+	payloads := make(map[string]string)
+
+	m := &MQTT{
+		log:    util.NewLogger("foo"),
+		root:   "evcc",
+		layout: layout,
+		publisher: func(topic string, retained bool, payload string) {
+			payloads[topic] = payload
+		},
+	}
+
+	m.updateState(1, "chargePower", 1000.0)
+	m.updateState(1, "vehicleSoc", math.NaN())
+	m.updateState(1, "chargeDuration", time.Minute)
+	m.updateState(1, "charging", true)
+	m.publishStates()
+
+	require.Len(t, payloads, 1)
+
+	var state map[string]any
+	require.NoError(t, json.Unmarshal([]byte(payloads["evcc/loadpoints/1/state"]), &state))
+	assert.Equal(t, map[string]any{
+		"chargePower":    1000.0,
+		"vehicleSoc":     nil,
+		"chargeDuration": 60.0,
+		"charging":       true,
+	}, state)
+
+	// unchanged state is not published again
+	clear(payloads)
+	m.publishStates()
+	assert.Empty(t, payloads)
+}
@@ -0,0 +1,835 @@
+package ocpi
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+)
+
+const (
+	version = "2.2.1"
+
+	locationID  = "1"
+	connectorID = "1"
+
+	// commandTimeout is the time the eMSP should wait for the asynchronous command result
+	commandTimeout = 30
+
+	maxLimit = 100
+)
+
+// Config is the OCPI CPO configuration
+type Config struct {
+	Token       string // token for incoming requests, empty disables the interface
+	EmspToken   string // token for command results sent to the eMSP
+	EmspURL     string // eMSP base url, command results are only sent to urls below
+	CountryCode string // ISO 3166-1 alpha-2 country code
+	PartyID     string // ISO 15118 party id
+	Location    LocationConfig
+}
+
+// LocationConfig is the published charging location
+type LocationConfig struct {
+	Name       string
+	Address    string
+	City       string
+	PostalCode string
+	Country    string // ISO 3166-1 alpha-3 country code
+	Latitude   float64
+	Longitude  float64
+	TimeZone   string
+}
+
+// evseState is the connection state of a loadpoint
+type evseState struct {
+	connected bool
+	start     time.Time // active session start
+	energy    float64   // active session energy in Wh
+	updated   time.Time
+	restore   *api.ChargeMode // mode restored when the remotely started session ends
+	paused    bool            // charging paused by remote stop until the session ends
+}
+
+// Server implements a minimal OCPI 2.2.1 CPO with locations, sessions, cdrs and commands modules
+type Server struct {
+	*request.Helper
+	log     *util.Logger
+	cc      Config
+	emsp    *url.URL
+	site    site.API
+	started time.Time
+
+	mu       sync.RWMutex
+	currency string
+	evses    []evseState
+}
+
+// New creates an OCPI server
+func New(cc Config, site site.API) (*Server, error) {
+	if cc.Token == "" {
+		return nil, errors.New("missing token")
+	}
+
+	if len(cc.CountryCode) != 2 || len(cc.PartyID) != 3 {
+		return nil, errors.New("country code must have 2 and party id 3 characters")
+	}
+
+	var emsp *url.URL
+	if cc.EmspURL != "" {
+		u, err := url.Parse(strings.TrimSuffix(cc.EmspURL, "/"))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid emsp url: %s", cc.EmspURL)
+		}
+		emsp = u
+	}
+
+	log := util.NewLogger("ocpi").Redact(cc.Token, cc.EmspToken)
+
+	now := time.Now()
+	evses := make([]evseState, len(site.Loadpoints()))
+	for i := range evses {
+		evses[i].updated = now
+	}
+
+	s := &Server{
+		Helper:   request.NewHelper(log),
+		log:      log,
+		cc:       cc,
+		emsp:     emsp,
+		site:     site,
+		started:  now,
+		currency: "EUR",
+		evses:    evses,
+	}
+
+	return s, nil
+}
+
+// Register adds the OCPI routes to the router
+func (s *Server) Register(router *mux.Router) {
+	r := router.PathPrefix("/ocpi").Subrouter()
+	r.Use(s.authHandler)
+
+	r.HandleFunc("/versions", s.versionsHandler).Methods(http.MethodGet)
+	r.HandleFunc("/"+version, s.detailsHandler).Methods(http.MethodGet)
+
+	r.HandleFunc("/"+version+"/locations", s.locationsHandler).Methods(http.MethodGet)
+	r.HandleFunc("/"+version+"/locations/{location}", s.locationHandler).Methods(http.MethodGet)
+	r.HandleFunc("/"+version+"/locations/{location}/{evse}", s.locationHandler).Methods(http.MethodGet)
+	r.HandleFunc("/"+version+"/locations/{location}/{evse}/{connector}", s.locationHandler).Methods(http.MethodGet)
+
+	r.HandleFunc("/"+version+"/sessions", s.sessionsHandler).Methods(http.MethodGet)
+	r.HandleFunc("/"+version+"/cdrs", s.cdrsHandler).Methods(http.MethodGet)
+	r.HandleFunc("/"+version+"/commands/{command}", s.commandHandler).Methods(http.MethodPost)
+}
+
+// Run tracks loadpoint sessions and the site currency
+func (s *Server) Run(in <-chan util.Param) {
+	for p := range in {
+		s.mu.Lock()
+
+		switch {
+		case p.Loadpoint == nil && p.Key == keys.Currency:
+			s.currency = fmt.Sprint(p.Val)
+
+		case p.Loadpoint != nil && *p.Loadpoint < len(s.evses):
+			e := &s.evses[*p.Loadpoint]
+
+			switch p.Key {
+			case keys.Connected:
+				if connected, ok := p.Val.(bool); ok && connected != e.connected {
+					e.connected = connected
+					e.updated = time.Now()
+					e.start, e.energy = time.Time{}, 0
+					if connected {
+						e.start = e.updated
+					} else if e.restore != nil || e.paused {
+						// don't block the parameter stream while updating the loadpoint
+						go s.endSession(*p.Loadpoint, e.restore, e.paused)
+						e.restore, e.paused = nil, false
+					}
+				}
+
+			case keys.ChargedEnergy:
+				if v, ok := p.Val.(float64); ok {
+					e.energy = v
+				}
+			}
+		}
+
+		s.mu.Unlock()
+	}
+}
+
+// token returns the base64-decoded token if applicable
+func token(s string) string {
+	if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return string(b)
+	}
+	return s
+}
+
+// authHandler validates the Authorization: Token header. The token may be base64 encoded as required by OCPI 2.2.
+func (s *Server) authHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Token ")
+		if !ok || (subtle.ConstantTimeCompare([]byte(t), []byte(s.cc.Token)) != 1 &&
+			subtle.ConstantTimeCompare([]byte(token(t)), []byte(s.cc.Token)) != 1) {
+			s.error(w, http.StatusUnauthorized, statusClientError, "invalid token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) write(w http.ResponseWriter, status int, res Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		s.log.ERROR.Println(err)
+	}
+}
+
+func (s *Server) result(w http.ResponseWriter, data any) {
+	s.write(w, http.StatusOK, Response{Data: data, StatusCode: statusSuccess, Timestamp: time.Now().UTC()})
+}
+
+func (s *Server) error(w http.ResponseWriter, status, code int, msg string) {
+	s.write(w, status, Response{StatusCode: code, StatusMessage: msg, Timestamp: time.Now().UTC()})
+}
+
+// baseURL returns the externally visible OCPI base url
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return scheme + "://" + r.Host + "/ocpi"
+}
+
+func (s *Server) versionsHandler(w http.ResponseWriter, r *http.Request) {
+	s.result(w, []Version{{Version: version, URL: baseURL(r) + "/" + version}})
+}
+
+func (s *Server) detailsHandler(w http.ResponseWriter, r *http.Request) {
+	base := baseURL(r) + "/" + version
+
+	res := VersionDetails{Version: version}
+	for _, e := range []Endpoint{
+		{Identifier: "locations", Role: "SENDER"},
+		{Identifier: "sessions", Role: "SENDER"},
+		{Identifier: "cdrs", Role: "SENDER"},
+		{Identifier: "commands", Role: "RECEIVER"},
+	} {
+		e.URL = base + "/" + e.Identifier
+		res.Endpoints = append(res.Endpoints, e)
+	}
+
+	s.result(w, res)
+}
+
+func evseUID(id int) string {
+	return strconv.Itoa(id + 1)
+}
+
+func (s *Server) evseID(id int) string {
+	return fmt.Sprintf("%s*%s*E%d", strings.ToUpper(s.cc.CountryCode), strings.ToUpper(s.cc.PartyID), id+1)
+}
+
+// loadpoint returns the loadpoint id for the evse uid
+func (s *Server) loadpoint(uid string) (int, bool) {
+	id, err := strconv.Atoi(uid)
+	if err != nil || id < 1 || id > len(s.site.Loadpoints()) {
+		return 0, false
+	}
+	return id - 1, true
+}
+
+func evseStatus(lp loadpoint.API) string {
+	switch lp.GetStatus() {
+	case api.StatusA:
+		return "AVAILABLE"
+	case api.StatusB, api.StatusC:
+		return "CHARGING"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func powerType(lp loadpoint.API) string {
+	if lp.GetPhases() == 1 {
+		return "AC_1_PHASE"
+	}
+	return "AC_3_PHASE"
+}
+
+func (s *Server) coordinates() GeoLocation {
+	return GeoLocation{
+		Latitude:  strconv.FormatFloat(s.cc.Location.Latitude, 'f', 6, 64),
+		Longitude: strconv.FormatFloat(s.cc.Location.Longitude, 'f', 6, 64),
+	}
+}
+
+func (s *Server) evse(id int, lp loadpoint.API) EVSE {
+	s.mu.RLock()
+	updated := s.evses[id].updated
+	s.mu.RUnlock()
+
+	return EVSE{
+		UID:          evseUID(id),
+		EvseID:       s.evseID(id),
+		Status:       evseStatus(lp),
+		Capabilities: []string{"REMOTE_START_STOP_CAPABLE"},
+		Connectors: []Connector{{
+			ID:          connectorID,
+			Standard:    "IEC_62196_T2",
+			Format:      "SOCKET",
+			PowerType:   powerType(lp),
+			MaxVoltage:  230,
+			MaxAmperage: int(lp.GetMaxCurrent()),
+			LastUpdated: updated,
+		}},
+		LastUpdated: updated,
+	}
+}
+
+func (s *Server) location() Location {
+	loc := s.cc.Location
+
+	name := loc.Name
+	if name == "" {
+		name = s.site.GetTitle()
+	}
+
+	res := Location{
+		CountryCode: strings.ToUpper(s.cc.CountryCode),
+		PartyID:     strings.ToUpper(s.cc.PartyID),
+		ID:          locationID,
+		Publish:     true,
+		Name:        name,
+		Address:     loc.Address,
+		City:        loc.City,
+		PostalCode:  loc.PostalCode,
+		Country:     loc.Country,
+		Coordinates: s.coordinates(),
+		TimeZone:    loc.TimeZone,
+		LastUpdated: s.started,
+	}
+
+	for id, lp := range s.site.Loadpoints() {
+		evse := s.evse(id, lp)
+		if evse.LastUpdated.After(res.LastUpdated) {
+			res.LastUpdated = evse.LastUpdated
+		}
+		res.EVSEs = append(res.EVSEs, evse)
+	}
+
+	return res
+}
+
+// paginate applies the date_from, date_to, offset and limit query parameters
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T, updated func(T) time.Time) ([]T, error) {
+	q := r.URL.Query()
+
+	var from, to time.Time
+	for _, p := range []struct {
+		param string
+		t     *time.Time
+	}{{"date_from", &from}, {"date_to", &to}} {
+		if v := q.Get(p.param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", p.param, err)
+			}
+			*p.t = t
+		}
+	}
+
+	filtered := make([]T, 0, len(items))
+	for _, it := range items {
+		u := updated(it)
+		if (from.IsZero() || !u.Before(from)) && (to.IsZero() || u.Before(to)) {
+			filtered = append(filtered, it)
+		}
+	}
+
+	offset, limit := 0, maxLimit
+	if v := q.Get("offset"); v != "" {
+		var err error
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return nil, errors.New("invalid offset")
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			return nil, errors.New("invalid limit")
+		}
+		limit = min(limit, maxLimit)
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(len(filtered)))
+	w.Header().Set("X-Limit", strconv.Itoa(limit))
+
+	offset = min(offset, len(filtered))
+	end := min(offset+limit, len(filtered))
+
+	if end < len(filtered) {
+		next := *r.URL
+		q.Set("offset", strconv.Itoa(end))
+		q.Set("limit", strconv.Itoa(limit))
+		next.RawQuery = q.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s>; rel="next"`, strings.TrimSuffix(baseURL(r), "/ocpi"), next.RequestURI()))
+	}
+
+	return filtered[offset:end], nil
+}
+
+func (s *Server) locationsHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := paginate(w, r, []Location{s.location()}, func(l Location) time.Time { return l.LastUpdated })
+	if err != nil {
+		s.error(w, http.StatusBadRequest, statusInvalidParams, err.Error())
+		return
+	}
+
+	s.result(w, res)
+}
+
+// locationHandler returns a single location, evse or connector
+func (s *Server) locationHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if vars["location"] != locationID {
+		s.error(w, http.StatusNotFound, statusUnknown, "unknown location")
+		return
+	}
+
+	evse, ok := vars["evse"]
+	if !ok {
+		s.result(w, s.location())
+		return
+	}
+
+	id, ok := s.loadpoint(evse)
+	if !ok {
+		s.error(w, http.StatusNotFound, statusUnknown, "unknown evse")
+		return
+	}
+
+	res := s.evse(id, s.site.Loadpoints()[id])
+
+	connector, ok := vars["connector"]
+	if !ok {
+		s.result(w, res)
+		return
+	}
+
+	if connector != connectorID {
+		s.error(w, http.StatusNotFound, statusUnknown, "unknown connector")
+		return
+	}
+
+	s.result(w, res.Connectors[0])
+}
+
+// activeSessionID returns the id of the loadpoint's active session
+func activeSessionID(id int, start time.Time) string {
+	return fmt.Sprintf("%s-%d", evseUID(id), start.Unix())
+}
+
+func (s *Server) cdrToken(identifier string) CdrToken {
+	res := CdrToken{
+		CountryCode: strings.ToUpper(s.cc.CountryCode),
+		PartyID:     strings.ToUpper(s.cc.PartyID),
+		UID:         identifier,
+		Type:        "RFID",
+		ContractID:  identifier,
+	}
+
+	if identifier == "" {
+		res.UID, res.ContractID, res.Type = "evcc", "evcc", "OTHER"
+	}
+
+	return res
+}
+
+// evseByTitle returns the evse uid of the loadpoint with given title
+func (s *Server) evseByTitle(title string) string {
+	for id, lp := range s.site.Loadpoints() {
+		if lp.Title() == title {
+			return evseUID(id)
+		}
+	}
+	return evseUID(0)
+}
+
+// completedSessions returns the persisted sessions
+func completedSessions() (session.Sessions, error) {
+	var res session.Sessions
+
+	if db.Instance == nil {
+		return res, nil
+	}
+
+	if txn := db.Instance.Where("charged_kwh>=0.05").Order("created").Find(&res); txn.Error != nil {
+		return nil, txn.Error
+	}
+
+	// skip pending sessions
+	return lo.Filter(res, func(s session.Session, _ int) bool {
+		return !s.Finished.IsZero()
+	}), nil
+}
+
+func (s *Server) sessions() ([]Session, error) {
+	completed, err := completedSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	currency := s.currency
+	s.mu.RUnlock()
+
+	res := make([]Session, 0, len(completed))
+	for _, cs := range completed {
+		sess := Session{
+			CountryCode:   strings.ToUpper(s.cc.CountryCode),
+			PartyID:       strings.ToUpper(s.cc.PartyID),
+			ID:            strconv.FormatUint(uint64(cs.ID), 10),
+			StartDateTime: cs.Created.UTC(),
+			EndDateTime:   lo.ToPtr(cs.Finished.UTC()),
+			Kwh:           cs.ChargedEnergy,
+			CdrToken:      s.cdrToken(cs.Identifier),
+			AuthMethod:    "WHITELIST",
+			LocationID:    locationID,
+			EvseUID:       s.evseByTitle(cs.Loadpoint),
+			ConnectorID:   connectorID,
+			Currency:      currency,
+			Status:        "COMPLETED",
+			LastUpdated:   cs.Finished.UTC(),
+		}
+
+		if cs.Price != nil {
+			sess.TotalCost = &Price{ExclVat: *cs.Price}
+		}
+
+		res = append(res, sess)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for id, e := range s.evses {
+		if !e.connected {
+			continue
+		}
+
+		res = append(res, Session{
+			CountryCode:   strings.ToUpper(s.cc.CountryCode),
+			PartyID:       strings.ToUpper(s.cc.PartyID),
+			ID:            activeSessionID(id, e.start),
+			StartDateTime: e.start.UTC(),
+			Kwh:           e.energy / 1e3,
+			CdrToken:      s.cdrToken(""),
+			AuthMethod:    "WHITELIST",
+			LocationID:    locationID,
+			EvseUID:       evseUID(id),
+			ConnectorID:   connectorID,
+			Currency:      currency,
+			Status:        "ACTIVE",
+			LastUpdated:   time.Now().UTC(),
+		})
+	}
+
+	return res, nil
+}
+
+func (s *Server) sessionsHandler(w http.ResponseWriter, r *http.Request) {
+	sessions, err := s.sessions()
+	if err != nil {
+		s.error(w, http.StatusInternalServerError, statusClientError, err.Error())
+		return
+	}
+
+	res, err := paginate(w, r, sessions, func(s Session) time.Time { return s.LastUpdated })
+	if err != nil {
+		s.error(w, http.StatusBadRequest, statusInvalidParams, err.Error())
+		return
+	}
+
+	s.result(w, res)
+}
+
+func (s *Server) cdrs() ([]CDR, error) {
+	completed, err := completedSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	currency := s.currency
+	s.mu.RUnlock()
+
+	loc := s.cc.Location
+	lps := s.site.Loadpoints()
+
+	res := make([]CDR, 0, len(completed))
+	for _, cs := range completed {
+		uid := s.evseByTitle(cs.Loadpoint)
+		id, _ := s.loadpoint(uid)
+
+		cdr := CDR{
+			CountryCode:   strings.ToUpper(s.cc.CountryCode),
+			PartyID:       strings.ToUpper(s.cc.PartyID),
+			ID:            strconv.FormatUint(uint64(cs.ID), 10),
+			StartDateTime: cs.Created.UTC(),
+			EndDateTime:   cs.Finished.UTC(),
+			SessionID:     strconv.FormatUint(uint64(cs.ID), 10),
+			CdrToken:      s.cdrToken(cs.Identifier),
+			AuthMethod:    "WHITELIST",
+			CdrLocation: CdrLocation{
+				ID:                 locationID,
+				Name:               loc.Name,
+				Address:            loc.Address,
+				City:               loc.City,
+				PostalCode:         loc.PostalCode,
+				Country:            loc.Country,
+				Coordinates:        s.coordinates(),
+				EvseUID:            uid,
+				EvseID:             s.evseID(id),
+				ConnectorID:        connectorID,
+				ConnectorStandard:  "IEC_62196_T2",
+				ConnectorFormat:    "SOCKET",
+				ConnectorPowerType: "AC_3_PHASE",
+			},
+			Currency: currency,
+			ChargingPeriods: []ChargingPeriod{{
+				StartDateTime: cs.Created.UTC(),
+				Dimensions:    []CdrDimension{{Type: "ENERGY", Volume: cs.ChargedEnergy}},
+			}},
+			TotalEnergy: cs.ChargedEnergy,
+			TotalTime:   cs.Finished.Sub(cs.Created).Hours(),
+			LastUpdated: cs.Finished.UTC(),
+		}
+
+		if id < len(lps) {
+			cdr.CdrLocation.ConnectorPowerType = powerType(lps[id])
+		}
+
+		if cs.ChargeDuration != nil {
+			cdr.TotalParkingTime = max(0, cdr.TotalTime-cs.ChargeDuration.Hours())
+		}
+
+		if cs.Price != nil {
+			cdr.TotalCost.ExclVat = *cs.Price
+		}
+
+		res = append(res, cdr)
+	}
+
+	return res, nil
+}
+
+func (s *Server) cdrsHandler(w http.ResponseWriter, r *http.Request) {
+	cdrs, err := s.cdrs()
+	if err != nil {
+		s.error(w, http.StatusInternalServerError, statusClientError, err.Error())
+		return
+	}
+
+	res, err := paginate(w, r, cdrs, func(c CDR) time.Time { return c.LastUpdated })
+	if err != nil {
+		s.error(w, http.StatusBadRequest, statusInvalidParams, err.Error())
+		return
+	}
+
+	s.result(w, res)
+}
+
+// activeSession returns the loadpoint id of the active session
+func (s *Server) activeSession(sessionID string) (int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for id, e := range s.evses {
+		if e.connected && activeSessionID(id, e.start) == sessionID {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// restoreMode restores the mode active before a remote start unless it has been changed meanwhile
+func restoreMode(lp loadpoint.API, restore *api.ChargeMode) {
+	if restore != nil && lp.GetMode() == api.ModeNow {
+		lp.SetMode(*restore)
+	}
+}
+
+// startSession temporarily switches the loadpoint to fast mode until the session ends
+func (s *Server) startSession(id int) {
+	lp := s.site.Loadpoints()[id]
+
+	s.mu.Lock()
+	e := &s.evses[id]
+	if e.restore == nil {
+		mode := lp.GetMode()
+		e.restore = &mode
+	}
+	paused := e.paused
+	e.paused = false
+	s.mu.Unlock()
+
+	if paused {
+		lp.Resume()
+	}
+
+	lp.SetMode(api.ModeNow)
+}
+
+// stopSession restores the previous mode and pauses charging until the session ends
+func (s *Server) stopSession(id int) error {
+	lp := s.site.Loadpoints()[id]
+
+	s.mu.Lock()
+	e := &s.evses[id]
+	restore := e.restore
+	e.restore, e.paused = nil, true
+	s.mu.Unlock()
+
+	restoreMode(lp, restore)
+
+	return lp.Pause(loadpoint.PauseUser, 0)
+}
+
+// endSession reverts remote commands when the vehicle disconnects
+func (s *Server) endSession(id int, restore *api.ChargeMode, paused bool) {
+	lp := s.site.Loadpoints()[id]
+
+	restoreMode(lp, restore)
+
+	if paused {
+		lp.Resume()
+	}
+}
+
+// commandHandler starts charging in fast mode or pauses charging for the current session.
+// The user's charge mode is restored when the session ends.
+func (s *Server) commandHandler(w http.ResponseWriter, r *http.Request) {
+	var req CommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.error(w, http.StatusBadRequest, statusInvalidParams, err.Error())
+		return
+	}
+
+	// results carry the eMSP token and must not be sent to arbitrary urls
+	if req.ResponseURL != "" && !s.emspURL(req.ResponseURL) {
+		s.error(w, http.StatusBadRequest, statusInvalidParams, "response_url not below emsp url")
+		return
+	}
+
+	result := commandAccepted
+
+	switch command := strings.ToUpper(mux.Vars(r)["command"]); command {
+	case "START_SESSION":
+		id, ok := s.loadpoint(req.EvseUID)
+		if req.LocationID != locationID || !ok {
+			result = commandRejected
+			break
+		}
+
+		s.log.INFO.Printf("start session on evse %s", req.EvseUID)
+		s.startSession(id)
+
+	case "STOP_SESSION":
+		id, ok := s.activeSession(req.SessionID)
+		if !ok {
+			result = commandUnknown
+			break
+		}
+
+		s.log.INFO.Printf("stop session %s", req.SessionID)
+		if err := s.stopSession(id); err != nil {
+			s.log.ERROR.Printf("stop session %s: %v", req.SessionID, err)
+			result = commandRejected
+		}
+
+	default:
+		result = commandNotSupported
+	}
+
+	s.result(w, CommandResponse{Result: result, Timeout: commandTimeout})
+
+	if result == commandAccepted && req.ResponseURL != "" {
+		go s.sendResult(req.ResponseURL, CommandResult{Result: commandAccepted})
+	}
+}
+
+// emspURL checks if the uri is below the configured eMSP base url
+func (s *Server) emspURL(uri string) bool {
+	if s.emsp == nil {
+		return false
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil || u.User != nil {
+		return false
+	}
+
+	p := path.Clean("/" + u.Path)
+
+	return strings.EqualFold(u.Scheme, s.emsp.Scheme) && strings.EqualFold(u.Host, s.emsp.Host) &&
+		(p == s.emsp.Path || strings.HasPrefix(p, s.emsp.Path+"/"))
+}
+
+// sendResult posts the asynchronous command result to the eMSP
+func (s *Server) sendResult(uri string, res CommandResult) {
+	b, err := json.Marshal(res)
+	if err != nil {
+		s.log.ERROR.Println(err)
+		return
+	}
+
+	headers := map[string]string{}
+	if s.cc.EmspToken != "" {
+		headers["Authorization"] = "Token " + base64.StdEncoding.EncodeToString([]byte(s.cc.EmspToken))
+	}
+
+	req, err := request.New(http.MethodPost, uri, bytes.NewReader(b), request.JSONEncoding, headers)
+	if err == nil {
+		_, err = s.DoBody(req)
+	}
+
+	if err != nil {
+		s.log.ERROR.Printf("command result: %v", err)
+	}
+}
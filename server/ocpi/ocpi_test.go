@@ -0,0 +1,223 @@
+package ocpi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testSite struct {
+	site.API
+	lps []loadpoint.API
+}
+
+func (s *testSite) GetTitle() string            { return "Home" }
+func (s *testSite) Loadpoints() []loadpoint.API { return s.lps }
+
+func newTestServer(t *testing.T, lps ...loadpoint.API) (*Server, *mux.Router) {
+	t.Helper()
+
+	s, err := New(Config{Token: "secret", CountryCode: "de", PartyID: "evc"}, &testSite{lps: lps})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	s.Register(router)
+
+	return s, router
+}
+
+func do(router http.Handler, method, path, token, body string) (*httptest.ResponseRecorder, Response) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Token "+token)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var res Response
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+
+	return w, res
+}
+
+func TestConfig(t *testing.T) {
+	_, err := New(Config{CountryCode: "DE", PartyID: "EVC"}, &testSite{})
+	assert.Error(t, err)
+
+	_, err = New(Config{Token: "secret", CountryCode: "DEU", PartyID: "EVC"}, &testSite{})
+	assert.Error(t, err)
+}
+
+func TestAuth(t *testing.T) {
+	_, router := newTestServer(t)
+
+	w, res := do(router, http.MethodGet, "/ocpi/versions", "", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, statusClientError, res.StatusCode)
+
+	w, _ = do(router, http.MethodGet, "/ocpi/versions", "wrong", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w, res = do(router, http.MethodGet, "/ocpi/versions", "secret", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, statusSuccess, res.StatusCode)
+
+	w, _ = do(router, http.MethodGet, "/ocpi/versions", base64.StdEncoding.EncodeToString([]byte("secret")), "")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestVersions(t *testing.T) {
+	_, router := newTestServer(t)
+
+	_, res := do(router, http.MethodGet, "/ocpi/2.2.1", "secret", "")
+
+	b, _ := json.Marshal(res.Data)
+	var details VersionDetails
+	require.NoError(t, json.Unmarshal(b, &details))
+
+	assert.Equal(t, "2.2.1", details.Version)
+	assert.Len(t, details.Endpoints, 4)
+	assert.Equal(t, "http://example.com/ocpi/2.2.1/locations", details.Endpoints[0].URL)
+}
+
+func TestLocations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetStatus().Return(api.StatusC).AnyTimes()
+	lp.EXPECT().GetPhases().Return(1).AnyTimes()
+	lp.EXPECT().GetMaxCurrent().Return(16.0).AnyTimes()
+
+	_, router := newTestServer(t, lp)
+
+	w, res := do(router, http.MethodGet, "/ocpi/2.2.1/locations", "secret", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+
+	b, _ := json.Marshal(res.Data)
+	var locs []Location
+	require.NoError(t, json.Unmarshal(b, &locs))
+	require.Len(t, locs, 1)
+
+	loc := locs[0]
+	assert.Equal(t, "Home", loc.Name)
+	assert.Equal(t, "DE", loc.CountryCode)
+	require.Len(t, loc.EVSEs, 1)
+	assert.Equal(t, "DE*EVC*E1", loc.EVSEs[0].EvseID)
+	assert.Equal(t, "CHARGING", loc.EVSEs[0].Status)
+	assert.Equal(t, "AC_1_PHASE", loc.EVSEs[0].Connectors[0].PowerType)
+	assert.Equal(t, 16, loc.EVSEs[0].Connectors[0].MaxAmperage)
+
+	w, _ = do(router, http.MethodGet, "/ocpi/2.2.1/locations/1/1/1", "secret", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w, res = do(router, http.MethodGet, "/ocpi/2.2.1/locations/1/2", "secret", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, statusUnknown, res.StatusCode)
+
+	w, _ = do(router, http.MethodGet, "/ocpi/2.2.1/locations?date_from=invalid", "secret", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	s, router := newTestServer(t, lp)
+
+	result := func(res Response) string {
+		b, _ := json.Marshal(res.Data)
+		var cr CommandResponse
+		require.NoError(t, json.Unmarshal(b, &cr))
+		return cr.Result
+	}
+
+	lp.EXPECT().GetMode().Return(api.ModePV)
+	lp.EXPECT().SetMode(api.ModeNow)
+	_, res := do(router, http.MethodPost, "/ocpi/2.2.1/commands/START_SESSION", "secret", `{"location_id":"1","evse_uid":"1"}`)
+	assert.Equal(t, commandAccepted, result(res))
+
+	_, res = do(router, http.MethodPost, "/ocpi/2.2.1/commands/START_SESSION", "secret", `{"location_id":"1","evse_uid":"2"}`)
+	assert.Equal(t, commandRejected, result(res))
+
+	_, res = do(router, http.MethodPost, "/ocpi/2.2.1/commands/STOP_SESSION", "secret", `{"session_id":"unknown"}`)
+	assert.Equal(t, commandUnknown, result(res))
+
+	// connect vehicle to create an active session
+	in := make(chan util.Param, 1)
+	in <- util.Param{Loadpoint: lo.ToPtr(0), Key: keys.Connected, Val: true}
+	close(in)
+	s.Run(in)
+
+	sessions, err := s.sessions()
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "ACTIVE", sessions[0].Status)
+
+	// stop restores the user's mode and pauses the session
+	lp.EXPECT().GetMode().Return(api.ModeNow)
+	lp.EXPECT().SetMode(api.ModePV)
+	lp.EXPECT().Pause(loadpoint.PauseUser, time.Duration(0))
+	_, res = do(router, http.MethodPost, "/ocpi/2.2.1/commands/STOP_SESSION", "secret", `{"session_id":"`+sessions[0].ID+`"}`)
+	assert.Equal(t, commandAccepted, result(res))
+
+	// pause ends with the session
+	done := make(chan struct{})
+	lp.EXPECT().Resume().Do(func() { close(done) })
+
+	in = make(chan util.Param, 1)
+	in <- util.Param{Loadpoint: lo.ToPtr(0), Key: keys.Connected, Val: false}
+	close(in)
+	s.Run(in)
+	<-done
+
+	_, res = do(router, http.MethodPost, "/ocpi/2.2.1/commands/UNLOCK_CONNECTOR", "secret", `{}`)
+	assert.Equal(t, commandNotSupported, result(res))
+}
+
+func TestCommandResponseURL(t *testing.T) {
+	s, err := New(Config{Token: "secret", CountryCode: "de", PartyID: "evc", EmspURL: "https://emsp.example.org/ocpi/"}, &testSite{})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		uri string
+		ok  bool
+	}{
+		{"https://emsp.example.org/ocpi/commands/START_SESSION/1", true},
+		{"https://EMSP.example.org/ocpi", true},
+		{"http://emsp.example.org/ocpi/commands", false},
+		{"https://emsp.example.org/ocpix/commands", false},
+		{"https://emsp.example.org/ocpi/../admin", false},
+		{"https://user@emsp.example.org/ocpi/commands", false},
+		{"https://emsp.example.org.evil/ocpi/commands", false},
+		{"http://127.0.0.1/ocpi/commands", false},
+	} {
+		assert.Equal(t, tc.ok, s.emspURL(tc.uri), tc.uri)
+	}
+
+	// no emsp url configured
+	s, router := newTestServer(t)
+	assert.False(t, s.emspURL("https://emsp.example.org/ocpi"))
+
+	w, _ := do(router, http.MethodPost, "/ocpi/2.2.1/commands/START_SESSION", "secret", `{"location_id":"1","evse_uid":"1","response_url":"http://127.0.0.1/"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	_, err = New(Config{Token: "secret", CountryCode: "de", PartyID: "evc", EmspURL: "ftp://emsp"}, &testSite{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,174 @@
+package ocpi
+
+import "time"
+
+// OCPI 2.2.1 status codes
+const (
+	statusSuccess       = 1000
+	statusClientError   = 2000
+	statusInvalidParams = 2001
+	statusUnknown       = 2003
+)
+
+// Response is the OCPI response envelope
+type Response struct {
+	Data          any       `json:"data,omitempty"`
+	StatusCode    int       `json:"status_code"`
+	StatusMessage string    `json:"status_message,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+type Version struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
+type Endpoint struct {
+	Identifier string `json:"identifier"`
+	Role       string `json:"role"`
+	URL        string `json:"url"`
+}
+
+type VersionDetails struct {
+	Version   string     `json:"version"`
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+type GeoLocation struct {
+	Latitude  string `json:"latitude"`
+	Longitude string `json:"longitude"`
+}
+
+type Connector struct {
+	ID          string    `json:"id"`
+	Standard    string    `json:"standard"`
+	Format      string    `json:"format"`
+	PowerType   string    `json:"power_type"`
+	MaxVoltage  int       `json:"max_voltage"`
+	MaxAmperage int       `json:"max_amperage"`
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+type EVSE struct {
+	UID          string      `json:"uid"`
+	EvseID       string      `json:"evse_id,omitempty"`
+	Status       string      `json:"status"`
+	Capabilities []string    `json:"capabilities,omitempty"`
+	Connectors   []Connector `json:"connectors"`
+	LastUpdated  time.Time   `json:"last_updated"`
+}
+
+type Location struct {
+	CountryCode string      `json:"country_code"`
+	PartyID     string      `json:"party_id"`
+	ID          string      `json:"id"`
+	Publish     bool        `json:"publish"`
+	Name        string      `json:"name,omitempty"`
+	Address     string      `json:"address"`
+	City        string      `json:"city"`
+	PostalCode  string      `json:"postal_code,omitempty"`
+	Country     string      `json:"country"`
+	Coordinates GeoLocation `json:"coordinates"`
+	EVSEs       []EVSE      `json:"evses,omitempty"`
+	TimeZone    string      `json:"time_zone"`
+	LastUpdated time.Time   `json:"last_updated"`
+}
+
+type CdrToken struct {
+	CountryCode string `json:"country_code"`
+	PartyID     string `json:"party_id"`
+	UID         string `json:"uid"`
+	Type        string `json:"type"`
+	ContractID  string `json:"contract_id"`
+}
+
+type Price struct {
+	ExclVat float64 `json:"excl_vat"`
+}
+
+type Session struct {
+	CountryCode   string     `json:"country_code"`
+	PartyID       string     `json:"party_id"`
+	ID            string     `json:"id"`
+	StartDateTime time.Time  `json:"start_date_time"`
+	EndDateTime   *time.Time `json:"end_date_time,omitempty"`
+	Kwh           float64    `json:"kwh"`
+	CdrToken      CdrToken   `json:"cdr_token"`
+	AuthMethod    string     `json:"auth_method"`
+	LocationID    string     `json:"location_id"`
+	EvseUID       string     `json:"evse_uid"`
+	ConnectorID   string     `json:"connector_id"`
+	Currency      string     `json:"currency"`
+	TotalCost     *Price     `json:"total_cost,omitempty"`
+	Status        string     `json:"status"`
+	LastUpdated   time.Time  `json:"last_updated"`
+}
+
+type CdrDimension struct {
+	Type   string  `json:"type"`
+	Volume float64 `json:"volume"`
+}
+
+type ChargingPeriod struct {
+	StartDateTime time.Time      `json:"start_date_time"`
+	Dimensions    []CdrDimension `json:"dimensions"`
+}
+
+type CdrLocation struct {
+	ID                 string      `json:"id"`
+	Name               string      `json:"name,omitempty"`
+	Address            string      `json:"address"`
+	City               string      `json:"city"`
+	PostalCode         string      `json:"postal_code,omitempty"`
+	Country            string      `json:"country"`
+	Coordinates        GeoLocation `json:"coordinates"`
+	EvseUID            string      `json:"evse_uid"`
+	EvseID             string      `json:"evse_id"`
+	ConnectorID        string      `json:"connector_id"`
+	ConnectorStandard  string      `json:"connector_standard"`
+	ConnectorFormat    string      `json:"connector_format"`
+	ConnectorPowerType string      `json:"connector_power_type"`
+}
+
+type CDR struct {
+	CountryCode      string           `json:"country_code"`
+	PartyID          string           `json:"party_id"`
+	ID               string           `json:"id"`
+	StartDateTime    time.Time        `json:"start_date_time"`
+	EndDateTime      time.Time        `json:"end_date_time"`
+	SessionID        string           `json:"session_id"`
+	CdrToken         CdrToken         `json:"cdr_token"`
+	AuthMethod       string           `json:"auth_method"`
+	CdrLocation      CdrLocation      `json:"cdr_location"`
+	Currency         string           `json:"currency"`
+	ChargingPeriods  []ChargingPeriod `json:"charging_periods"`
+	TotalCost        Price            `json:"total_cost"`
+	TotalEnergy      float64          `json:"total_energy"`
+	TotalTime        float64          `json:"total_time"`
+	TotalParkingTime float64          `json:"total_parking_time,omitempty"`
+	LastUpdated      time.Time        `json:"last_updated"`
+}
+
+// command results
+const (
+	commandAccepted     = "ACCEPTED"
+	commandRejected     = "REJECTED"
+	commandNotSupported = "NOT_SUPPORTED"
+	commandUnknown      = "UNKNOWN_SESSION"
+)
+
+type CommandRequest struct {
+	ResponseURL string `json:"response_url"`
+	LocationID  string `json:"location_id"`
+	EvseUID     string `json:"evse_uid"`
+	SessionID   string `json:"session_id"`
+}
+
+type CommandResponse struct {
+	Result  string `json:"result"`
+	Timeout int    `json:"timeout"`
+}
+
+type CommandResult struct {
+	Result string `json:"result"`
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Prometheus is a prometheus metrics publisher
+type Prometheus struct {
+	site      *prometheus.GaugeVec
+	loadpoint *prometheus.GaugeVec
+	messages  *prometheus.CounterVec
+}
+
+// NewPrometheus creates a prometheus publisher registered with the given registerer
+func NewPrometheus(reg prometheus.Registerer) (*Prometheus, error) {
+	m := &Prometheus{
+		site: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "evcc",
+			Subsystem: "site",
+			Name:      "value",
+			Help:      "Site values",
+		}, []string{"key", "id"}),
+		loadpoint: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "evcc",
+			Subsystem: "loadpoint",
+			Name:      "value",
+			Help:      "Loadpoint and vehicle values",
+		}, []string{"loadpoint", "key", "id"}),
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "evcc",
+			Name:      "log_messages_total",
+			Help:      "Total count of warning and error log messages",
+		}, []string{"level"}),
+	}
+
+	for _, c := range []prometheus.Collector{m.site, m.loadpoint, m.messages} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// metricValue converts numeric and boolean values to float
+func metricValue(v any) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case float32:
+		return float64(val), true
+	case int:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case bool:
+		if val {
+			return 1, true
+		}
+		return 0, true
+	case *float64:
+		if val != nil {
+			return *val, true
+		}
+	}
+
+	return 0, false
+}
+
+// values flattens the param value into metric values by key and id
+func (m *Prometheus) values(param util.Param, set func(key, id string, val float64)) {
+	if v, ok := metricValue(param.Val); ok {
+		set(param.Key, "", v)
+		return
+	}
+
+	switch val := param.Val.(type) {
+	case []float64:
+		for i, v := range val {
+			set(param.Key, fmt.Sprintf("l%d", i+1), v)
+		}
+		return
+
+	case [3]float64:
+		for i, v := range val {
+			set(param.Key, fmt.Sprintf("l%d", i+1), v)
+		}
+		return
+	}
+
+	// slice of structs, e.g. pv and battery meters
+	if param.Val == nil {
+		return
+	}
+
+	if typ := reflect.TypeOf(param.Val); typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Struct {
+		val := reflect.ValueOf(param.Val)
+
+		for i := 0; i < val.Len(); i++ {
+			val := val.Index(i)
+			typ := val.Type()
+
+			for j := 0; j < typ.NumField(); j++ {
+				if !typ.Field(j).IsExported() {
+					continue
+				}
+
+				if v, ok := metricValue(val.Field(j).Interface()); ok {
+					n := typ.Field(j).Name
+					set(param.Key+strings.ToUpper(n[:1])+n[1:], strconv.Itoa(i+1), v)
+				}
+			}
+		}
+	}
+}
+
+// Run prometheus publisher
+func (m *Prometheus) Run(site site.API, in <-chan util.Param) {
+	for param := range in {
+		// count warnings and errors
+		if param.Key == "log" {
+			if val := reflect.ValueOf(param.Val); val.Kind() == reflect.Struct {
+				if level := val.FieldByName("Level"); level.IsValid() && level.Kind() == reflect.String {
+					m.messages.WithLabelValues(level.String()).Inc()
+				}
+			}
+			continue
+		}
+
+		if param.Loadpoint == nil {
+			m.values(param, func(key, id string, val float64) {
+				m.site.WithLabelValues(key, id).Set(val)
+			})
+			continue
+		}
+
+		title := site.Loadpoints()[*param.Loadpoint].Title()
+		m.values(param, func(key, id string, val float64) {
+			m.loadpoint.WithLabelValues(title, key, id).Set(val)
+		})
+	}
+}
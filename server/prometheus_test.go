@@ -0,0 +1,34 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusSiteValues(t *testing.T) {
+	m, err := NewPrometheus(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	in := make(chan util.Param, 8)
+	in <- util.Param{Key: "gridPower", Val: 1200.0}
+	in <- util.Param{Key: "batteryGridChargeActive", Val: true}
+	in <- util.Param{Key: "gridCurrents", Val: []float64{1, 2, 3}}
+	in <- util.Param{Key: "pv", Val: []struct{ Power float64 }{{Power: 500}, {Power: 700}}}
+	in <- util.Param{Key: "siteTitle", Val: "home"}
+	in <- util.Param{Key: "log", Val: struct{ Level string }{Level: "error"}}
+	close(in)
+
+	m.Run(nil, in)
+
+	assert.Equal(t, 1200.0, testutil.ToFloat64(m.site.WithLabelValues("gridPower", "")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.site.WithLabelValues("batteryGridChargeActive", "")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.site.WithLabelValues("gridCurrents", "l2")))
+	assert.Equal(t, 700.0, testutil.ToFloat64(m.site.WithLabelValues("pvPower", "2")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.messages.WithLabelValues("error")))
+	assert.Equal(t, 7, testutil.CollectAndCount(m.site))
+}
@@ -0,0 +1,151 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/supervisor"
+	"github.com/evcc-io/evcc/util/transport"
+)
+
+const maxRetryDelay = 5 * time.Minute
+
+// Client connects an evcc instance to a relay server
+type Client struct {
+	log    *util.Logger
+	uri    string
+	name   string
+	header http.Header
+	client *http.Client
+	ln     *listener
+}
+
+// NewClient creates a relay client
+func NewClient(cc Config) (*Client, error) {
+	if cc.URI == "" || cc.Name == "" || cc.Token == "" {
+		return nil, errors.New("missing uri, name or token")
+	}
+
+	u, err := url.Parse(strings.TrimSuffix(cc.URI, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	// the token must not be sent unencrypted
+	switch u.Scheme {
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		return nil, errors.New("invalid uri scheme, must be https: " + u.Scheme)
+	}
+
+	log := util.NewLogger("relay")
+
+	c := &Client{
+		log:    log,
+		uri:    u.String(),
+		name:   cc.Name,
+		header: http.Header{"Authorization": []string{"Bearer " + cc.Token}},
+		// logging round tripper would consume the upgraded connection
+		client: &http.Client{Transport: transport.Default()},
+		ln:     newListener(),
+	}
+
+	return c, nil
+}
+
+// Run serves handler via the relay until the client is closed
+func (c *Client) Run(handler http.Handler) {
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: request.Timeout,
+		ErrorLog:          c.log.ERROR,
+	}
+
+	go func() { _ = srv.Serve(c.ln) }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-c.ln.done
+		cancel()
+	}()
+
+	sv := supervisor.New(c.log, "relay "+c.uri, supervisor.WithBackoff(time.Second, maxRetryDelay))
+
+	sv.Run(ctx, func(connected func()) error {
+		conn, err := c.dial(connectPath, nil)
+		if err != nil {
+			return err
+		}
+		defer conn.CloseNow()
+
+		connected()
+		c.log.INFO.Printf("connected to %s as %s", c.uri, c.name)
+
+		return c.serve(ctx, conn)
+	})
+}
+
+// Close stops the client
+func (c *Client) Close() error {
+	return c.ln.Close()
+}
+
+// serve opens data connections as requested by the relay until the control connection is lost
+func (c *Client) serve(ctx context.Context, conn *websocket.Conn) error {
+	for {
+		_, b, err := conn.Read(ctx)
+		if err != nil {
+			return err
+		}
+
+		go c.accept(string(b))
+	}
+}
+
+// accept opens the data connection for the given stream id
+func (c *Client) accept(id string) {
+	conn, err := c.dial(acceptPath, url.Values{"id": {id}})
+	if err != nil {
+		c.log.ERROR.Println("accept:", err)
+		return
+	}
+
+	// websocket connections are closed when a deadline expires, but the http server
+	// uses deadlines for aborting reads, hence serve via a deadline-capable pipe
+	local, remote := net.Pipe()
+	go join(remote, websocket.NetConn(context.Background(), conn, websocket.MessageBinary))
+
+	c.ln.push(local)
+}
+
+func (c *Client) dial(path string, params url.Values) (*websocket.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+	defer cancel()
+
+	if params == nil {
+		params = make(url.Values)
+	}
+	params.Set("name", c.name)
+
+	conn, _, err := websocket.Dial(ctx, c.uri+path+"?"+params.Encode(), &websocket.DialOptions{
+		HTTPClient: c.client,
+		HTTPHeader: c.header,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// allow arbitrary request and response sizes
+	conn.SetReadLimit(-1)
+
+	return conn, nil
+}
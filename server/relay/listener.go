@@ -0,0 +1,72 @@
+package relay
+
+import (
+	"io"
+	"net"
+	"sync"
+)
+
+// listener hands tunneled data connections to an http server
+type listener struct {
+	conns chan net.Conn
+	done  chan struct{}
+	once  sync.Once
+}
+
+var _ net.Listener = (*listener)(nil)
+
+func newListener() *listener {
+	return &listener{
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *listener) Close() error {
+	l.once.Do(func() { close(l.done) })
+	return nil
+}
+
+func (l *listener) Addr() net.Addr {
+	return tunnelAddr{}
+}
+
+// push passes a data connection to the listener
+func (l *listener) push(conn net.Conn) {
+	select {
+	case l.conns <- conn:
+	case <-l.done:
+		conn.Close()
+	}
+}
+
+type tunnelAddr struct{}
+
+func (tunnelAddr) Network() string { return "tunnel" }
+func (tunnelAddr) String() string  { return "relay" }
+
+// join copies between both connections until either is closed
+func join(a, b net.Conn) {
+	var once sync.Once
+	done := func() {
+		a.Close()
+		b.Close()
+	}
+
+	go func() {
+		_, _ = io.Copy(a, b)
+		once.Do(done)
+	}()
+
+	_, _ = io.Copy(b, a)
+	once.Do(done)
+}
@@ -0,0 +1,49 @@
+// Package relay provides remote access to the evcc UI and api without port forwarding.
+//
+// The evcc instance keeps an outbound websocket control connection to the relay. For every
+// public request the relay asks the instance to open an additional data connection which
+// then carries the plain http request, including websocket upgrades. The relay does not
+// authenticate users, evcc's own credentials apply.
+//
+// Security: the relay terminates TLS and forwards requests unencrypted through the tunnel.
+// It can read and modify all traffic including passwords and session cookies and must
+// therefore be fully trusted. Only use a relay operated by yourself.
+//
+// Instances are addressed strictly by the first label of the requested host name.
+package relay
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+const (
+	connectPath = "/tunnel/connect"
+	acceptPath  = "/tunnel/accept"
+)
+
+// Config is the relay client configuration
+type Config struct {
+	URI   string // relay url, e.g. wss://relay.example.org
+	Name  string // instance name registered at the relay
+	Token string // instance token shared with the relay
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// bearerToken returns the request's bearer token
+func bearerToken(r *http.Request) string {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return token
+}
+
+func validToken(token, expected string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
@@ -0,0 +1,107 @@
+package relay
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelay(t *testing.T) {
+	s, err := NewServer(map[string]string{"home": "secret"})
+	require.NoError(t, err)
+
+	relay := httptest.NewTLSServer(s)
+	defer relay.Close()
+
+	// instances are addressed by host name
+	get := func(host string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, relay.URL+"/api/state", nil)
+		req.Host = host
+		req.Header.Set("Authorization", "Bearer user")
+
+		resp, err := relay.Client().Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// unknown instance
+	resp := get("other.relay.test")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// not connected
+	resp = get("home.relay.test")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+
+	// invalid token
+	resp, err = relay.Client().Get(relay.URL + connectPath + "?name=home")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/state", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	})
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow()
+		_ = conn.Write(r.Context(), websocket.MessageText, []byte("hello"))
+		_, _, _ = conn.Read(r.Context())
+	})
+
+	// token must not be sent unencrypted
+	_, err = NewClient(Config{URI: "ws://relay.test", Name: "home", Token: "secret"})
+	require.Error(t, err)
+
+	c, err := NewClient(Config{URI: relay.URL, Name: "home", Token: "secret"})
+	require.NoError(t, err)
+	defer c.Close()
+
+	c.client = relay.Client()
+
+	go c.Run(mux)
+
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.tunnels["home"] != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// no fallback to the only instance
+	resp = get(relay.Listener.Addr().String())
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// credentials are passed through to evcc
+	resp = get("home.relay.test")
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Bearer user", string(b))
+
+	// websocket upgrade
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, relay.URL+"/ws", &websocket.DialOptions{Host: "home.relay.test", HTTPClient: relay.Client()})
+	require.NoError(t, err)
+	defer conn.CloseNow()
+
+	_, msg, err := conn.Read(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(msg))
+}
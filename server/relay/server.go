@@ -0,0 +1,251 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/evcc-io/evcc/util"
+)
+
+// acceptTimeout is the time allowed for the instance to open a data connection
+const acceptTimeout = 10 * time.Second
+
+// tunnel is the control connection of a connected instance
+type tunnel struct {
+	mu      sync.Mutex
+	conn    *websocket.Conn
+	pending map[string]chan net.Conn
+}
+
+// Server is the relay server forwarding public requests to connected instances
+type Server struct {
+	log     *util.Logger
+	tokens  map[string]string
+	mu      sync.Mutex
+	tunnels map[string]*tunnel
+}
+
+// NewServer creates a relay server for the given instance name/token pairs
+func NewServer(tokens map[string]string) (*Server, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("missing instances")
+	}
+
+	return &Server{
+		log:     util.NewLogger("relay"),
+		tokens:  tokens,
+		tunnels: make(map[string]*tunnel),
+	}, nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case connectPath:
+		s.connect(w, r)
+	case acceptPath:
+		s.accept(w, r)
+	default:
+		s.forward(w, r)
+	}
+}
+
+// authorize validates the instance's token and returns the instance name
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) (string, bool) {
+	name := r.URL.Query().Get("name")
+	if !validToken(bearerToken(r), s.tokens[name]) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return "", false
+	}
+	return name, true
+}
+
+// connect registers an instance's control connection
+func (s *Server) connect(w http.ResponseWriter, r *http.Request) {
+	name, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.CloseNow()
+
+	t := &tunnel{
+		conn:    conn,
+		pending: make(map[string]chan net.Conn),
+	}
+
+	s.mu.Lock()
+	if prev, ok := s.tunnels[name]; ok {
+		prev.conn.Close(websocket.StatusPolicyViolation, "replaced")
+	}
+	s.tunnels[name] = t
+	s.mu.Unlock()
+
+	s.log.INFO.Printf("%s: connected from %s", name, r.RemoteAddr)
+
+	// keep reading for control frames until the instance disconnects
+	_, _, err = conn.Read(context.Background())
+
+	s.mu.Lock()
+	if s.tunnels[name] == t {
+		delete(s.tunnels, name)
+	}
+	s.mu.Unlock()
+
+	s.log.INFO.Printf("%s: disconnected: %v", name, err)
+}
+
+// accept hands an instance's data connection to the waiting public request
+func (s *Server) accept(w http.ResponseWriter, r *http.Request) {
+	name, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	t := s.tunnels[name]
+	s.mu.Unlock()
+
+	if t == nil {
+		http.Error(w, "not connected", http.StatusNotFound)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+
+	t.mu.Lock()
+	ch, ok := t.pending[id]
+	delete(t.pending, id)
+	t.mu.Unlock()
+
+	if !ok {
+		http.Error(w, "unknown stream", http.StatusNotFound)
+		return
+	}
+
+	ws, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	ws.SetReadLimit(-1)
+
+	// keep the handler alive until the data connection is closed
+	ctx, cancel := context.WithCancel(context.Background())
+	ch <- &closeNotifyConn{Conn: websocket.NetConn(ctx, ws, websocket.MessageBinary), cancel: cancel}
+	<-ctx.Done()
+}
+
+// open requests a data connection from the instance
+func (s *Server) open(ctx context.Context, name string) (net.Conn, error) {
+	s.mu.Lock()
+	t := s.tunnels[name]
+	s.mu.Unlock()
+
+	if t == nil {
+		return nil, errors.New("instance not connected")
+	}
+
+	id := randomID()
+	ch := make(chan net.Conn, 1)
+
+	t.mu.Lock()
+	t.pending[id] = ch
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		delete(t.pending, id)
+		t.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, acceptTimeout)
+	defer cancel()
+
+	if err := t.conn.Write(ctx, websocket.MessageText, []byte(id)); err != nil {
+		return nil, err
+	}
+
+	select {
+	case conn := <-ch:
+		return conn, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// instance returns the instance addressed by the request's first host label
+func (s *Server) instance(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+
+	name, _, _ := strings.Cut(host, ".")
+	return name
+}
+
+// forward proxies a public request through a data connection of the addressed instance.
+// The relay terminates the public TLS connection and therefore sees all traffic in plain text.
+func (s *Server) forward(w http.ResponseWriter, r *http.Request) {
+	name := s.instance(r)
+	if _, ok := s.tokens[name]; !ok {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	conn, err := s.open(r.Context(), name)
+	if err != nil {
+		s.log.DEBUG.Printf("%s: %v", name, err)
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+	defer conn.Close()
+
+	// the data connection carries a single request
+	dial := make(chan net.Conn, 1)
+	dial <- conn
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(&url.URL{Scheme: "http", Host: pr.In.Host})
+			pr.SetXForwarded()
+		},
+		Transport: &http.Transport{
+			DialContext: func(context.Context, string, string) (net.Conn, error) {
+				select {
+				case conn := <-dial:
+					return conn, nil
+				default:
+					return nil, net.ErrClosed
+				}
+			},
+			DisableKeepAlives: true,
+		},
+		ErrorLog: s.log.ERROR,
+	}
+
+	proxy.ServeHTTP(w, r)
+}
+
+// closeNotifyConn cancels its context when closed
+type closeNotifyConn struct {
+	net.Conn
+	cancel func()
+}
+
+func (c *closeNotifyConn) Close() error {
+	defer c.cancel()
+	return c.Conn.Close()
+}
@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,12 +16,68 @@ import (
 const (
 	// Time allowed to write a message to the peer
 	socketWriteTimeout = 10 * time.Second
+
+	// Interval for combining value updates into a single message
+	socketCoalesceInterval = 250 * time.Millisecond
 )
 
 // socketSubscriber is a middleman between the websocket connection and the hub.
 type socketSubscriber struct {
 	send      chan []byte
 	closeSlow func()
+	filter    socketFilter
+}
+
+// socketFilter restricts the keys and loadpoints sent to a subscriber
+type socketFilter struct {
+	keys       map[string]bool
+	loadpoints map[int]bool
+}
+
+// newSocketFilter creates a filter from comma-separated keys and loadpoint ids
+func newSocketFilter(keys, loadpoints string) (socketFilter, error) {
+	var res socketFilter
+
+	for _, k := range strings.Split(keys, ",") {
+		if k = strings.TrimSpace(k); k == "" {
+			continue
+		}
+		if res.keys == nil {
+			res.keys = make(map[string]bool)
+		}
+		res.keys[k] = true
+	}
+
+	for _, lp := range strings.Split(loadpoints, ",") {
+		if lp = strings.TrimSpace(lp); lp == "" {
+			continue
+		}
+
+		id, err := strconv.Atoi(lp)
+		if err != nil || id < 0 {
+			return res, fmt.Errorf("invalid loadpoint: %s", lp)
+		}
+
+		if res.loadpoints == nil {
+			res.loadpoints = make(map[int]bool)
+		}
+		res.loadpoints[id] = true
+	}
+
+	return res, nil
+}
+
+// match checks if the param passes the filter. Site values are not restricted by loadpoints.
+func (f socketFilter) match(p util.Param) bool {
+	if f.keys != nil && !f.keys[p.Key] {
+		return false
+	}
+
+	if p.Loadpoint != nil && f.loadpoints != nil && !f.loadpoints[*p.Loadpoint] {
+		return false
+	}
+
+	return true
 }
 
 func writeTimeout(ctx context.Context, timeout time.Duration, c *websocket.Conn, msg []byte) error {
@@ -46,7 +104,14 @@ func NewSocketHub() *SocketHub {
 }
 
 // ServeWebsocket handles websocket requests from the peer.
+// Clients may restrict the received values using the keys and loadpoints query parameters.
 func (h *SocketHub) ServeWebsocket(w http.ResponseWriter, r *http.Request) {
+	filter, err := newSocketFilter(r.URL.Query().Get("keys"), r.URL.Query().Get("loadpoints"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	acceptOptions := &websocket.AcceptOptions{
 		InsecureSkipVerify: true,
 	}
@@ -64,10 +129,10 @@ func (h *SocketHub) ServeWebsocket(w http.ResponseWriter, r *http.Request) {
 	}
 	defer conn.Close(websocket.StatusInternalError, "")
 
-	_ = h.subscribe(r.Context(), conn)
+	_ = h.subscribe(r.Context(), conn, filter)
 }
 
-func (h *SocketHub) subscribe(ctx context.Context, conn *websocket.Conn) error {
+func (h *SocketHub) subscribe(ctx context.Context, conn *websocket.Conn, filter socketFilter) error {
 	ctx = conn.CloseRead(ctx)
 
 	s := &socketSubscriber{
@@ -75,6 +140,7 @@ func (h *SocketHub) subscribe(ctx context.Context, conn *websocket.Conn) error {
 		closeSlow: func() {
 			conn.Close(websocket.StatusPolicyViolation, "connection too slow to keep up with messages")
 		},
+		filter: filter,
 	}
 
 	h.addSubscriber(s)
@@ -109,49 +175,131 @@ func (h *SocketHub) deleteSubscriber(s *socketSubscriber) {
 	h.mu.Unlock()
 }
 
-func (h *SocketHub) welcome(subscriber *socketSubscriber, params []util.Param) {
-	var msg strings.Builder
+// socketValue is a param with its encoded key/value pair
+type socketValue struct {
+	param util.Param
+	kv    string
+}
+
+// message creates a json object of the values matching the subscriber's filter
+func (s *socketSubscriber) message(values []socketValue) ([]byte, bool) {
+	var (
+		msg strings.Builder
+		ok  bool
+	)
+
 	msg.WriteString("{")
-	for _, p := range params {
-		if msg.Len() > 1 {
+	for _, v := range values {
+		if !s.filter.match(v.param) {
+			continue
+		}
+		if ok {
 			msg.WriteString(",")
 		}
-		msg.WriteString(kv(p))
+		msg.WriteString(v.kv)
+		ok = true
 	}
 	msg.WriteString("}")
 
+	return []byte(msg.String()), ok
+}
+
+func (h *SocketHub) welcome(subscriber *socketSubscriber, params []util.Param) {
+	values := make([]socketValue, 0, len(params))
+	for _, p := range params {
+		values = append(values, socketValue{param: p, kv: kv(p)})
+	}
+
 	// should not block
-	subscriber.send <- []byte(msg.String())
+	msg, _ := subscriber.message(values)
+	subscriber.send <- msg
 }
 
-func (h *SocketHub) broadcast(p util.Param) {
+func (h *SocketHub) broadcast(values []socketValue) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	if len(h.subscribers) > 0 {
-		msg := "{" + kv(p) + "}"
+	for s := range h.subscribers {
+		msg, ok := s.message(values)
+		if !ok {
+			continue
+		}
 
-		for s := range h.subscribers {
-			select {
-			case s.send <- []byte(msg):
-			default:
-				s.closeSlow()
-			}
+		select {
+		case s.send <- msg:
+		default:
+			s.closeSlow()
 		}
 	}
 }
 
-// Run starts data and status distribution
+// socketDelta collects changed values between broadcasts
+type socketDelta struct {
+	sent    map[string]string // last sent encoded values by unique id
+	pending map[string]util.Param
+	order   []string
+}
+
+func newSocketDelta() *socketDelta {
+	return &socketDelta{
+		sent:    make(map[string]string),
+		pending: make(map[string]util.Param),
+	}
+}
+
+// add queues the param, replacing a pending value of the same key
+func (d *socketDelta) add(p util.Param) {
+	id := p.UniqueID()
+	if _, ok := d.pending[id]; !ok {
+		d.order = append(d.order, id)
+	}
+	d.pending[id] = p
+}
+
+// flush returns the pending values that have changed since last sent
+func (d *socketDelta) flush() []socketValue {
+	var res []socketValue
+
+	for _, id := range d.order {
+		p := d.pending[id]
+		if val := kv(p); val != d.sent[id] {
+			d.sent[id] = val
+			res = append(res, socketValue{param: p, kv: val})
+		}
+	}
+
+	clear(d.pending)
+	d.order = d.order[:0]
+
+	return res
+}
+
+// Run starts data and status distribution. Values are sent only when changed and
+// combined into a single message per coalesce interval.
 func (h *SocketHub) Run(in <-chan util.Param, cache *util.Cache) {
+	delta := newSocketDelta()
+
+	ticker := time.NewTicker(socketCoalesceInterval)
+	defer ticker.Stop()
+
+	broadcast := func() {
+		if values := delta.flush(); len(values) > 0 {
+			h.broadcast(values)
+		}
+	}
+
 	for {
 		select {
 		case client := <-h.register:
 			h.welcome(client, cache.All())
+		case <-ticker.C:
+			broadcast()
 		case msg, ok := <-in:
 			if !ok {
+				broadcast()
 				return // break if channel closed
 			}
-			h.broadcast(msg)
+			delta.add(msg)
 		}
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/evcc-io/evcc/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -49,3 +50,65 @@ func TestEncodeSlice(t *testing.T) {
 		assert.Equal(t, tc.out, out)
 	}
 }
+
+func TestSocketFilter(t *testing.T) {
+	lp := func(id int) *int { return &id }
+
+	f, err := newSocketFilter("", "")
+	require.NoError(t, err)
+	assert.True(t, f.match(util.Param{Key: "pvPower"}), "empty filter")
+	assert.True(t, f.match(util.Param{Key: "chargePower", Loadpoint: lp(1)}), "empty filter")
+
+	f, err = newSocketFilter("pvPower, chargePower", "1")
+	require.NoError(t, err)
+	assert.True(t, f.match(util.Param{Key: "pvPower"}))
+	assert.False(t, f.match(util.Param{Key: "gridPower"}))
+	assert.True(t, f.match(util.Param{Key: "chargePower", Loadpoint: lp(1)}))
+	assert.False(t, f.match(util.Param{Key: "chargePower", Loadpoint: lp(0)}))
+	assert.False(t, f.match(util.Param{Key: "mode", Loadpoint: lp(1)}))
+
+	_, err = newSocketFilter("", "foo")
+	assert.Error(t, err)
+}
+
+func TestSocketWelcomeFilter(t *testing.T) {
+	lp := 0
+
+	f, err := newSocketFilter("pvPower,mode", "")
+	require.NoError(t, err)
+
+	s := &socketSubscriber{send: make(chan []byte, 1), filter: f}
+	new(SocketHub).welcome(s, []util.Param{
+		{Key: "pvPower", Val: 1000.0},
+		{Key: "gridPower", Val: 100.0},
+		{Key: "mode", Val: "pv", Loadpoint: &lp},
+	})
+
+	assert.Equal(t, `{"pvPower":1000,"loadpoints.0.mode":"pv"}`, string(<-s.send))
+}
+
+func TestSocketDelta(t *testing.T) {
+	lp := 0
+	d := newSocketDelta()
+
+	d.add(util.Param{Key: "pvPower", Val: 1000.0})
+	d.add(util.Param{Key: "gridPower", Val: 100.0})
+	d.add(util.Param{Key: "pvPower", Val: 2000.0})
+	d.add(util.Param{Key: "mode", Val: "pv", Loadpoint: &lp})
+
+	s := &socketSubscriber{send: make(chan []byte, 1)}
+	msg, ok := s.message(d.flush())
+	require.True(t, ok)
+	assert.Equal(t, `{"pvPower":2000,"gridPower":100,"loadpoints.0.mode":"pv"}`, string(msg))
+
+	// unchanged values are not sent again
+	d.add(util.Param{Key: "pvPower", Val: 2000.0})
+	d.add(util.Param{Key: "gridPower", Val: 200.0})
+	d.add(util.Param{Key: "mode", Val: "pv", Loadpoint: &lp})
+
+	msg, ok = s.message(d.flush())
+	require.True(t, ok)
+	assert.Equal(t, `{"gridPower":200}`, string(msg))
+
+	assert.Empty(t, d.flush())
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// sseKeepAlive is the interval of keep-alive comments on idle event streams
+const sseKeepAlive = 30 * time.Second
+
+// ServeEvents streams the websocket updates as server-sent events.
+// Clients may restrict the received values using the keys and loadpoints query parameters.
+func (h *SocketHub) ServeEvents(w http.ResponseWriter, r *http.Request) {
+	filter, err := newSocketFilter(r.URL.Query().Get("keys"), r.URL.Query().Get("loadpoints"))
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // disable reverse proxy buffering
+	w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		log.ERROR.Println("events:", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	s := &socketSubscriber{
+		send:      make(chan []byte, 1024),
+		closeSlow: cancel,
+		filter:    filter,
+	}
+
+	h.addSubscriber(s)
+	defer h.deleteSubscriber(s)
+
+	// send welcome message
+	h.register <- s
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		var msg []byte
+
+		select {
+		case b := <-s.send:
+			msg = append(append([]byte("data: "), b...), "\n\n"...)
+		case <-ticker.C:
+			msg = []byte(": keep-alive\n\n")
+		case <-ctx.Done():
+			return
+		}
+
+		// server write timeout does not apply to long-running streams
+		_ = rc.SetWriteDeadline(time.Now().Add(socketWriteTimeout))
+
+		if _, err := w.Write(msg); err != nil {
+			return
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
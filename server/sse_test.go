@@ -0,0 +1,55 @@
+package server
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeEvents(t *testing.T) {
+	cache := util.NewCache()
+	cache.Add("pvPower", util.Param{Key: "pvPower", Val: 1000})
+	cache.Add("homePower", util.Param{Key: "homePower", Val: 500})
+
+	in := make(chan util.Param)
+	defer close(in)
+
+	hub := NewSocketHub()
+	go hub.Run(in, cache)
+
+	srv := httptest.NewServer(http.HandlerFunc(hub.ServeEvents))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?keys=pvPower,gridPower")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	r := bufio.NewReader(resp.Body)
+	event := func() string {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		blank, err := r.ReadString('\n')
+		require.NoError(t, err)
+		require.Equal(t, "\n", blank)
+		return line
+	}
+
+	// welcome message
+	assert.Equal(t, "data: {\"pvPower\":1000}\n", event())
+
+	in <- util.Param{Key: "homePower", Val: 600}
+	in <- util.Param{Key: "gridPower", Val: -200}
+	assert.Equal(t, "data: {\"gridPower\":-200}\n", event())
+
+	resp, err = http.Get(srv.URL + "?loadpoints=x")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -32,11 +32,14 @@ func init() {
 }
 
 func NewAmberFromConfig(other map[string]interface{}) (api.Tariff, error) {
-	var cc struct {
-		embed   `mapstructure:",squash"`
-		Token   string
-		SiteID  string
-		Channel string
+	cc := struct {
+		embed      `mapstructure:",squash"`
+		Token      string
+		SiteID     string
+		Channel    string
+		Resolution int
+	}{
+		Resolution: 30,
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -55,14 +58,23 @@ func NewAmberFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		return nil, errors.New("missing channel")
 	}
 
+	channel := strings.ToLower(cc.Channel)
+	if !slices.Contains([]string{amber.ChannelGeneral, amber.ChannelControlledLoad, amber.ChannelFeedIn}, channel) {
+		return nil, fmt.Errorf("invalid channel: %s", cc.Channel)
+	}
+
+	if !slices.Contains(amber.Resolutions, cc.Resolution) {
+		return nil, fmt.Errorf("invalid resolution: %d", cc.Resolution)
+	}
+
 	log := util.NewLogger("amber").Redact(cc.Token)
 
 	t := &Amber{
 		embed:   &cc.embed,
 		log:     log,
 		Helper:  request.NewHelper(log),
-		uri:     fmt.Sprintf(amber.URI, strings.ToUpper(cc.SiteID)),
-		channel: strings.ToLower(cc.Channel),
+		uri:     fmt.Sprintf(amber.URI, strings.ToUpper(cc.SiteID), cc.Resolution),
+		channel: channel,
 		data:    util.NewMonitor[api.Rates](2 * time.Hour),
 	}
 
@@ -97,27 +109,41 @@ func (t *Amber) run(done chan error) {
 			continue
 		}
 
-		data := make(api.Rates, 0, len(res))
-
-		for _, r := range res {
-			if t.channel == strings.ToLower(r.ChannelType) {
-				startTime, _ := time.Parse("2006-01-02T15:04:05Z", r.StartTime)
-				endTime, _ := time.Parse("2006-01-02T15:04:05Z", r.EndTime)
-				ar := api.Rate{
-					Start: startTime.Local(),
-					End:   endTime.Local(),
-					Price: r.PerKwh / 1e2,
-				}
-				if r.AdvancedPrice != nil {
-					ar.Price = r.AdvancedPrice.Predicted / 1e2
-				}
-				data = append(data, ar)
-			}
+		mergeRates(t.data, t.rates(res))
+		once.Do(func() { close(done) })
+	}
+}
+
+// rates converts the selected channel's intervals to rates. Feed-in prices are
+// reported as cost by Amber and are inverted to represent the feed-in compensation.
+func (t *Amber) rates(res []amber.PriceInfo) api.Rates {
+	data := make(api.Rates, 0, len(res))
+
+	for _, r := range res {
+		if t.channel != strings.ToLower(r.ChannelType) {
+			continue
 		}
 
-		mergeRates(t.data, data)
-		once.Do(func() { close(done) })
+		startTime, _ := time.Parse("2006-01-02T15:04:05Z", r.StartTime)
+		endTime, _ := time.Parse("2006-01-02T15:04:05Z", r.EndTime)
+
+		price := r.PerKwh
+		if r.AdvancedPrice != nil {
+			price = r.AdvancedPrice.Predicted
+		}
+
+		if t.channel == amber.ChannelFeedIn {
+			price = -price
+		}
+
+		data = append(data, api.Rate{
+			Start: startTime.Local(),
+			End:   endTime.Local(),
+			Price: price / 1e2,
+		})
 	}
+
+	return data
 }
 
 // Rates implements the api.Tariff interface
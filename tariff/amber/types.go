@@ -1,6 +1,16 @@
 package amber
 
-const URI = "https://api.amber.com.au/v1/sites/%s/prices?resolution=30"
+const URI = "https://api.amber.com.au/v1/sites/%s/prices?resolution=%d"
+
+// Channel types
+const (
+	ChannelGeneral        = "general"
+	ChannelControlledLoad = "controlledload"
+	ChannelFeedIn         = "feedin"
+)
+
+// Resolutions are the supported interval lengths in minutes
+var Resolutions = []int{5, 30}
 
 type AdvancedPrice struct {
 	Low       float64 `json:"low"`
@@ -0,0 +1,36 @@
+package tariff
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/tariff/amber"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmberRates(t *testing.T) {
+	res := []amber.PriceInfo{
+		{ChannelType: "general", StartTime: "2024-10-15T00:00:01Z", EndTime: "2024-10-15T00:05:00Z", PerKwh: 30},
+		{ChannelType: "feedIn", StartTime: "2024-10-15T00:00:01Z", EndTime: "2024-10-15T00:05:00Z", PerKwh: -8},
+		{ChannelType: "feedIn", StartTime: "2024-10-15T00:05:01Z", EndTime: "2024-10-15T00:10:00Z", PerKwh: -8, AdvancedPrice: &amber.AdvancedPrice{Predicted: -10}},
+	}
+
+	general := (&Amber{channel: amber.ChannelGeneral}).rates(res)
+	require.Len(t, general, 1)
+	assert.Equal(t, 0.3, general[0].Price)
+
+	feedin := (&Amber{channel: amber.ChannelFeedIn}).rates(res)
+	require.Len(t, feedin, 2)
+	assert.Equal(t, 0.08, feedin[0].Price)
+	assert.Equal(t, 0.1, feedin[1].Price)
+}
+
+func TestAmberConfig(t *testing.T) {
+	for _, cc := range []map[string]interface{}{
+		{"token": "t", "siteid": "s", "channel": "foo"},
+		{"token": "t", "siteid": "s", "channel": "general", "resolution": 15},
+	} {
+		_, err := NewAmberFromConfig(cc)
+		assert.Error(t, err)
+	}
+}
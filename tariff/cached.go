@@ -0,0 +1,112 @@
+package tariff
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+)
+
+// Cached wraps an api.Tariff and persists its rates. If the tariff fails or has no data yet,
+// the last persisted rates are returned and marked stale.
+type Cached struct {
+	api.Tariff
+	key string
+
+	mu      sync.Mutex
+	typ     api.TariffType
+	rates   api.Rates
+	updated time.Time
+	stale   bool
+}
+
+type cachedRates struct {
+	Type    api.TariffType `json:"type"`
+	Rates   api.Rates      `json:"rates"`
+	Updated time.Time      `json:"updated"`
+}
+
+// NewCached creates a tariff that persists its rates using the given settings key
+func NewCached(key string, t api.Tariff) *Cached {
+	c := &Cached{
+		Tariff: t,
+		key:    key,
+		stale:  true,
+	}
+
+	var res cachedRates
+	if err := settings.Json(key, &res); err == nil {
+		c.typ = res.Type
+		c.rates = res.Rates
+		c.updated = res.Updated
+	}
+
+	return c
+}
+
+// Rates implements the api.Tariff interface
+func (t *Cached) Rates() (api.Rates, error) {
+	res, err := t.Tariff.Rates()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err == nil && len(res) > 0 {
+		t.stale = false
+
+		if typ := t.Tariff.Type(); !slices.EqualFunc(res, t.rates, equalRate) || typ != t.typ {
+			t.typ = typ
+			t.rates = res
+			t.updated = time.Now()
+
+			_ = settings.SetJson(t.key, cachedRates{Type: t.typ, Rates: t.rates, Updated: t.updated})
+		}
+
+		return res, nil
+	}
+
+	// drop past rates from cache
+	now := time.Now()
+	cached := slices.DeleteFunc(slices.Clone(t.rates), func(r api.Rate) bool {
+		return !r.End.After(now)
+	})
+
+	if len(cached) == 0 {
+		return res, err
+	}
+
+	t.stale = true
+
+	return cached, nil
+}
+
+// Type implements the api.Tariff interface
+func (t *Cached) Type() api.TariffType {
+	if typ := t.Tariff.Type(); typ != 0 {
+		return typ
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.typ
+}
+
+// Stale returns true if the returned rates are served from cache
+func (t *Cached) Stale() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stale
+}
+
+// Updated returns the time the rates were last fetched successfully
+func (t *Cached) Updated() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.updated
+}
+
+func equalRate(a, b api.Rate) bool {
+	return a.Start.Equal(b.Start) && a.End.Equal(b.End) && a.Price == b.Price
+}
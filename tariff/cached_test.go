@@ -0,0 +1,49 @@
+package tariff
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	now := time.Now().Truncate(time.Hour)
+	rates := api.Rates{
+		{Start: now.Add(-time.Hour), End: now, Price: 1},
+		{Start: now, End: now.Add(time.Hour), Price: 2},
+	}
+
+	tf := api.NewMockTariff(ctrl)
+	tf.EXPECT().Type().Return(api.TariffTypePriceForecast).AnyTimes()
+	tf.EXPECT().Rates().Return(rates, nil)
+
+	c := NewCached("tariff.test", tf)
+	res, err := c.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, rates, res)
+	assert.False(t, c.Stale())
+	assert.False(t, c.Updated().IsZero())
+
+	// restart with failing tariff
+	failed := NewWrapper("test", nil, errors.New("offline"))
+	c = NewCached("tariff.test", failed)
+	assert.Equal(t, api.TariffTypePriceForecast, c.Type())
+
+	res, err = c.Rates()
+	require.NoError(t, err)
+	require.Len(t, res, 1, "past rates dropped")
+	assert.Equal(t, 2.0, res[0].Price)
+	assert.True(t, c.Stale())
+
+	// no cached data
+	c = NewCached("tariff.other", failed)
+	_, err = c.Rates()
+	assert.Error(t, err)
+}
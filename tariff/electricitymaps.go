@@ -30,6 +30,7 @@ type CarbonIntensity struct {
 }
 
 type CarbonIntensitySlot struct {
+	Error           string
 	CarbonIntensity float64   // 626,
 	Datetime        time.Time // "2022-12-12T16:00:00.000Z"
 }
@@ -46,6 +47,7 @@ func NewElectricityMapsFromConfig(other map[string]interface{}) (api.Tariff, err
 		Token string
 		Zone  string
 	}{
+		Uri:  "https://api.electricitymap.org/v3",
 		Zone: "DE",
 	}
 
@@ -67,6 +69,7 @@ func NewElectricityMapsFromConfig(other map[string]interface{}) (api.Tariff, err
 		Base: t.Client.Transport,
 		Decorator: transport.DecorateHeaders(map[string]string{
 			"X-BLOBR-KEY": cc.Token,
+			"auth-token":  cc.Token,
 		}),
 	}
 
@@ -80,40 +83,78 @@ func NewElectricityMapsFromConfig(other map[string]interface{}) (api.Tariff, err
 func (t *ElectricityMaps) run(done chan error) {
 	var once sync.Once
 
-	uri := fmt.Sprintf("%s/carbon-intensity/forecast?zone=%s", t.uri, t.zone)
-
 	tick := time.NewTicker(time.Hour)
 	for ; true; <-tick.C {
-		var res CarbonIntensity
-
-		if err := backoff.Retry(func() error {
-			return backoffPermanentError(t.GetJSON(uri, &res))
-		}, bo()); err != nil {
-			if res.Error != "" {
-				err = errors.New(res.Error)
+		data, err := t.forecast()
+		if err != nil {
+			// fall back to latest value if forecast is not available, e.g. for the free tier
+			var fallbackErr error
+			if data, fallbackErr = t.latest(); fallbackErr == nil {
+				t.log.DEBUG.Printf("forecast not available, using latest value: %v", err)
+				err = nil
 			}
+		}
 
+		if err != nil {
 			once.Do(func() { done <- err })
 
 			t.log.ERROR.Println(err)
 			continue
 		}
 
-		data := make(api.Rates, 0, len(res.Forecast))
-		for _, r := range res.Forecast {
-			ar := api.Rate{
-				Start: r.Datetime.Local(),
-				End:   r.Datetime.Add(time.Hour).Local(),
-				Price: r.CarbonIntensity,
-			}
-			data = append(data, ar)
-		}
-
 		mergeRates(t.data, data)
 		once.Do(func() { close(done) })
 	}
 }
 
+// forecast returns the carbon intensity forecast
+func (t *ElectricityMaps) forecast() (api.Rates, error) {
+	var res CarbonIntensity
+
+	uri := fmt.Sprintf("%s/carbon-intensity/forecast?zone=%s", t.uri, t.zone)
+	if err := backoff.Retry(func() error {
+		return backoffPermanentError(t.GetJSON(uri, &res))
+	}, bo()); err != nil {
+		if res.Error != "" {
+			err = errors.New(res.Error)
+		}
+		return nil, err
+	}
+
+	data := make(api.Rates, 0, len(res.Forecast))
+	for _, r := range res.Forecast {
+		ar := api.Rate{
+			Start: r.Datetime.Local(),
+			End:   r.Datetime.Add(time.Hour).Local(),
+			Price: r.CarbonIntensity,
+		}
+		data = append(data, ar)
+	}
+
+	return data, nil
+}
+
+// latest returns the latest carbon intensity as single rate
+func (t *ElectricityMaps) latest() (api.Rates, error) {
+	var res CarbonIntensitySlot
+
+	uri := fmt.Sprintf("%s/carbon-intensity/latest?zone=%s", t.uri, t.zone)
+	if err := backoff.Retry(func() error {
+		return backoffPermanentError(t.GetJSON(uri, &res))
+	}, bo()); err != nil {
+		if res.Error != "" {
+			err = errors.New(res.Error)
+		}
+		return nil, err
+	}
+
+	return api.Rates{{
+		Start: res.Datetime.Local(),
+		End:   res.Datetime.Add(time.Hour).Local(),
+		Price: res.CarbonIntensity,
+	}}, nil
+}
+
 func (t *ElectricityMaps) Rates() (api.Rates, error) {
 	var res api.Rates
 	err := t.data.GetFunc(func(val api.Rates) {
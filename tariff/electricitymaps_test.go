@@ -0,0 +1,37 @@
+package tariff
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElectricityMapsLatestFallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/carbon-intensity/forecast":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"forecast not included in plan"}`))
+		case "/carbon-intensity/latest":
+			_, _ = w.Write([]byte(`{"zone":"DE","carbonIntensity":350,"datetime":"` + time.Now().UTC().Truncate(time.Hour).Format(time.RFC3339) + `"}`))
+		}
+	}))
+	defer srv.Close()
+
+	tf, err := NewElectricityMapsFromConfig(map[string]interface{}{
+		"uri":   srv.URL,
+		"token": "foo",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.TariffTypeCo2, tf.Type())
+
+	rates, err := tf.Rates()
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, 350.0, rates[0].Price)
+}
@@ -138,8 +138,15 @@ func (t *Entsoe) run(done chan error) {
 			continue
 		}
 
-		// extract desired series
-		res, err := entsoe.GetTsPriceData(tr.TimeSeries, entsoe.ResolutionHour)
+		// extract desired series, markets may publish hourly, half-hourly or quarter-hourly prices
+		resolution, err := entsoe.AvailableResolution(tr.TimeSeries)
+		if err != nil {
+			once.Do(func() { done <- err })
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		res, err := entsoe.GetTsPriceData(tr.TimeSeries, resolution)
 		if err != nil {
 			once.Do(func() { done <- err })
 			t.log.ERROR.Println(err)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/dylanmei/iso8601"
@@ -49,6 +50,24 @@ type Rate struct {
 	Value float64
 }
 
+// Resolutions lists the supported price resolutions in order of preference
+var Resolutions = []ResolutionType{ResolutionHour, ResolutionHalfHour, ResolutionQuarterHour}
+
+// AvailableResolution returns the first supported resolution contained in the TimeSeries data
+func AvailableResolution(ts []TimeSeries) (ResolutionType, error) {
+	for _, res := range Resolutions {
+		for _, ts := range ts {
+			if slices.ContainsFunc(ts.Period, func(p TimeSeriesPeriod) bool {
+				return p.Resolution == res
+			}) {
+				return res, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%w: no supported resolution", ErrInvalidData)
+}
+
 // GetTsPriceData accepts a set of TimeSeries data entries, and
 // returns a sorted array of Rate based on the timestamp of each data entry.
 func GetTsPriceData(ts []TimeSeries, resolution ResolutionType) ([]Rate, error) {
@@ -89,19 +108,16 @@ func ExtractPeriodPriceData(period *TimeSeriesPeriod) ([]Rate, error) {
 		return nil, err
 	}
 
-	var count int
-	switch period.Resolution {
-	case ResolutionHour:
-		count = 24
-	case ResolutionHalfHour:
-		count = 2 * 24
-	case ResolutionQuarterHour:
-		count = 4 * 24
-	default:
+	if !slices.Contains(Resolutions, period.Resolution) {
 		return nil, fmt.Errorf("%w: invalid resolution: %v", ErrInvalidData, period.Resolution)
 	}
 
+	// derive number of points from the period interval to handle DST transition days
 	ts := period.TimeInterval.Start.Time
+	count := int(24 * time.Hour / duration)
+	if end := period.TimeInterval.End.Time; end.After(ts) {
+		count = int(end.Sub(ts) / duration)
+	}
 	points := lo.SliceToMap(period.Point, func(p Point) (int, Point) {
 		return p.Position, p
 	})
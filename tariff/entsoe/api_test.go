@@ -0,0 +1,70 @@
+package entsoe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func period(start, end string, resolution ResolutionType, points int) TimeSeriesPeriod {
+	var p TimeSeriesPeriod
+	p.TimeInterval.Start.Time, _ = time.Parse(time.RFC3339, start)
+	p.TimeInterval.End.Time, _ = time.Parse(time.RFC3339, end)
+	p.Resolution = resolution
+
+	for i := 1; i <= points; i++ {
+		p.Point = append(p.Point, Point{Position: i, PriceAmount: float64(i)})
+	}
+
+	return p
+}
+
+func TestExtractPeriodPriceData(t *testing.T) {
+	for _, tc := range []struct {
+		period TimeSeriesPeriod
+		count  int
+	}{
+		{period("2024-10-14T22:00:00Z", "2024-10-15T22:00:00Z", ResolutionHour, 24), 24},
+		{period("2024-10-14T22:00:00Z", "2024-10-15T22:00:00Z", ResolutionQuarterHour, 96), 96},
+		{period("2024-10-14T23:00:00Z", "2024-10-15T23:00:00Z", ResolutionHalfHour, 48), 48},
+		// DST end
+		{period("2024-10-26T22:00:00Z", "2024-10-27T23:00:00Z", ResolutionHour, 25), 25},
+		// compressed points are repeated
+		{period("2024-10-14T22:00:00Z", "2024-10-15T22:00:00Z", ResolutionHour, 1), 24},
+	} {
+		res, err := ExtractPeriodPriceData(&tc.period)
+		require.NoError(t, err)
+		require.Len(t, res, tc.count)
+
+		d, _ := time.ParseDuration(map[ResolutionType]string{
+			ResolutionHour:        "1h",
+			ResolutionHalfHour:    "30m",
+			ResolutionQuarterHour: "15m",
+		}[tc.period.Resolution])
+
+		assert.Equal(t, tc.period.TimeInterval.Start.Time, res[0].Start)
+		assert.Equal(t, tc.period.TimeInterval.End.Time, res[len(res)-1].End)
+		assert.Equal(t, d, res[0].End.Sub(res[0].Start))
+	}
+}
+
+func TestAvailableResolution(t *testing.T) {
+	ts := []TimeSeries{{Period: []TimeSeriesPeriod{
+		period("2024-10-14T22:00:00Z", "2024-10-15T22:00:00Z", ResolutionQuarterHour, 96),
+	}}}
+
+	res, err := AvailableResolution(ts)
+	require.NoError(t, err)
+	assert.Equal(t, ResolutionQuarterHour, res)
+
+	ts[0].Period = append(ts[0].Period, period("2024-10-14T22:00:00Z", "2024-10-15T22:00:00Z", ResolutionHour, 24))
+
+	res, err = AvailableResolution(ts)
+	require.NoError(t, err)
+	assert.Equal(t, ResolutionHour, res)
+
+	_, err = AvailableResolution(nil)
+	assert.ErrorIs(t, err, ErrInvalidData)
+}
@@ -13,9 +13,10 @@ import (
 )
 
 type Fixed struct {
-	clock   clock.Clock
-	zones   fixed.Zones
-	dynamic bool
+	clock    clock.Clock
+	zones    fixed.Zones
+	holidays *fixed.Holidays
+	dynamic  bool
 }
 
 var _ api.Tariff = (*Fixed)(nil)
@@ -26,10 +27,11 @@ func init() {
 
 func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 	var cc struct {
-		Price float64
-		Zones []struct {
-			Price       float64
-			Days, Hours string
+		Price    float64
+		Holidays string // country or country-region calendar, holidays are treated like sundays
+		Zones    []struct {
+			Price              float64
+			Days, Hours, Dates string
 		}
 	}
 
@@ -42,6 +44,14 @@ func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		dynamic: len(cc.Zones) >= 1,
 	}
 
+	if cc.Holidays != "" {
+		holidays, err := fixed.ParseHolidays(cc.Holidays)
+		if err != nil {
+			return nil, err
+		}
+		t.holidays = holidays
+	}
+
 	for _, z := range cc.Zones {
 		days, err := fixed.ParseDays(z.Days)
 		if err != nil {
@@ -53,10 +63,18 @@ func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 			return nil, err
 		}
 
+		var dates []fixed.DateRange
+		if z.Dates != "" {
+			if dates, err = fixed.ParseDateRanges(z.Dates); err != nil {
+				return nil, err
+			}
+		}
+
 		if len(hours) == 0 {
 			t.zones = append(t.zones, fixed.Zone{
 				Price: z.Price,
 				Days:  days,
+				Dates: dates,
 			})
 			continue
 		}
@@ -66,6 +84,7 @@ func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 				Price: z.Price,
 				Days:  days,
 				Hours: h,
+				Dates: dates,
 			})
 		}
 	}
@@ -86,14 +105,18 @@ func (t *Fixed) Rates() (api.Rates, error) {
 
 	start := now.With(t.clock.Now().Local()).BeginningOfDay()
 	for i := 0; i < 7; i++ {
-		dow := fixed.Day((int(start.Weekday()) + i) % 7)
+		dayStart := start.AddDate(0, 0, i)
 
-		zones := t.zones.ForDay(dow)
+		dow := fixed.Day(dayStart.Weekday())
+		if t.holidays != nil && t.holidays.Contains(dayStart) {
+			dow = fixed.Sunday
+		}
+
+		zones := t.zones.ForDay(dow).ForDate(dayStart)
 		if len(zones) == 0 {
 			return nil, fmt.Errorf("no zones for weekday %d", dow)
 		}
 
-		dayStart := start.AddDate(0, 0, i)
 		markers := zones.TimeTableMarkers()
 
 		for i, m := range markers {
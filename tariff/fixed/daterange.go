@@ -0,0 +1,89 @@
+package fixed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type MonthDay struct {
+	Month time.Month
+	Day   int
+}
+
+func (md MonthDay) before(o MonthDay) bool {
+	return md.Month < o.Month || md.Month == o.Month && md.Day < o.Day
+}
+
+func (md MonthDay) String() string {
+	return fmt.Sprintf("%02d.%02d", md.Day, md.Month)
+}
+
+// DateRange is a recurring range of days within the year, both inclusive.
+// Ranges ending before they start span the turn of the year.
+type DateRange struct {
+	From, To MonthDay
+}
+
+func (dr DateRange) Contains(t time.Time) bool {
+	md := MonthDay{t.Month(), t.Day()}
+
+	if dr.To.before(dr.From) {
+		return !md.before(dr.From) || !dr.To.before(md)
+	}
+
+	return !md.before(dr.From) && !dr.To.before(md)
+}
+
+func (dr DateRange) String() string {
+	return dr.From.String() + "-" + dr.To.String()
+}
+
+func parseDate(s string) (MonthDay, error) {
+	s = strings.TrimSpace(s)
+
+	// leap year for accepting 29.02
+	t, err := time.Parse("2.1.2006", s+".2024")
+	if err != nil {
+		return MonthDay{}, fmt.Errorf("invalid date: %s", s)
+	}
+
+	return MonthDay{t.Month(), t.Day()}, nil
+}
+
+// ParseDateRange parses a date range
+// Date range format:
+//
+//	dd.mm-dd.mm
+func ParseDateRange(s string) (DateRange, error) {
+	fromto := strings.SplitN(s, "-", 2)
+	if len(fromto) != 2 {
+		return DateRange{}, fmt.Errorf("invalid date range: %s", s)
+	}
+
+	from, err := parseDate(fromto[0])
+	if err != nil {
+		return DateRange{}, err
+	}
+
+	to, err := parseDate(fromto[1])
+	if err != nil {
+		return DateRange{}, err
+	}
+
+	return DateRange{from, to}, nil
+}
+
+func ParseDateRanges(s string) ([]DateRange, error) {
+	var res []DateRange
+
+	for _, segment := range strings.Split(s, ",") {
+		dr, err := ParseDateRange(segment)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, dr)
+	}
+
+	return res, nil
+}
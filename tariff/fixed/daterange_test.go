@@ -0,0 +1,41 @@
+package fixed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateRange(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 12, 0, 0, 0, time.Local)
+	}
+
+	summer, err := ParseDateRange("01.04-30.09")
+	require.NoError(t, err)
+	assert.Equal(t, DateRange{MonthDay{time.April, 1}, MonthDay{time.September, 30}}, summer)
+
+	assert.True(t, summer.Contains(date(time.April, 1)))
+	assert.True(t, summer.Contains(date(time.September, 30)))
+	assert.False(t, summer.Contains(date(time.October, 1)))
+
+	winter, err := ParseDateRange(" 1.10 - 31.3 ")
+	require.NoError(t, err)
+	assert.Equal(t, "01.10-31.03", winter.String())
+
+	assert.True(t, winter.Contains(date(time.December, 31)))
+	assert.True(t, winter.Contains(date(time.January, 1)))
+	assert.False(t, winter.Contains(date(time.April, 1)))
+
+	_, err = ParseDateRange("01.04")
+	assert.Error(t, err)
+
+	_, err = ParseDateRange("32.01-01.02")
+	assert.Error(t, err)
+
+	dr, err := ParseDateRanges("01.01-31.01,01.12-31.12")
+	require.NoError(t, err)
+	assert.Len(t, dr, 2)
+}
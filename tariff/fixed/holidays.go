@@ -0,0 +1,176 @@
+package fixed
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// holiday is a public holiday, either on a fixed date or relative to easter sunday
+type holiday struct {
+	date    func(year int) time.Time
+	regions []string // empty for nationwide
+}
+
+func fixedDate(month time.Month, day int) func(int) time.Time {
+	return func(year int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func easterOffset(days int) func(int) time.Time {
+	return func(year int) time.Time {
+		return easter(year).AddDate(0, 0, days)
+	}
+}
+
+// easter returns easter sunday using the anonymous gregorian algorithm
+func easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// repentanceDay returns the wednesday before november 23rd
+func repentanceDay(year int) time.Time {
+	t := time.Date(year, time.November, 22, 0, 0, 0, 0, time.UTC)
+	for t.Weekday() != time.Wednesday {
+		t = t.AddDate(0, 0, -1)
+	}
+	return t
+}
+
+// kingsDay returns april 27th or april 26th if the former is a sunday
+func kingsDay(year int) time.Time {
+	t := time.Date(year, time.April, 27, 0, 0, 0, 0, time.UTC)
+	if t.Weekday() == time.Sunday {
+		t = t.AddDate(0, 0, -1)
+	}
+	return t
+}
+
+var (
+	newYear       = holiday{date: fixedDate(time.January, 1)}
+	goodFriday    = holiday{date: easterOffset(-2)}
+	easterMonday  = holiday{date: easterOffset(1)}
+	labourDay     = holiday{date: fixedDate(time.May, 1)}
+	ascension     = holiday{date: easterOffset(39)}
+	whitMonday    = holiday{date: easterOffset(50)}
+	assumption    = holiday{date: fixedDate(time.August, 15)}
+	allSaints     = holiday{date: fixedDate(time.November, 1)}
+	christmas     = holiday{date: fixedDate(time.December, 25)}
+	boxingDay     = holiday{date: fixedDate(time.December, 26)}
+	epiphany      = holiday{date: fixedDate(time.January, 6)}
+	corpusChristi = holiday{date: easterOffset(60)}
+)
+
+func regional(h holiday, regions ...string) holiday {
+	h.regions = regions
+	return h
+}
+
+// calendars are the public holidays by ISO 3166-1 country code, regions by ISO 3166-2 subdivision code
+var calendars = map[string][]holiday{
+	"AT": {
+		newYear, epiphany, easterMonday, labourDay, ascension, whitMonday, corpusChristi, assumption,
+		{date: fixedDate(time.October, 26)},
+		allSaints,
+		{date: fixedDate(time.December, 8)},
+		christmas, boxingDay,
+	},
+	"BE": {
+		newYear, easterMonday, labourDay, ascension, whitMonday,
+		{date: fixedDate(time.July, 21)},
+		assumption, allSaints,
+		{date: fixedDate(time.November, 11)},
+		christmas,
+	},
+	"CH": {
+		newYear, goodFriday, easterMonday, ascension, whitMonday,
+		{date: fixedDate(time.August, 1)},
+		christmas, boxingDay,
+	},
+	"DE": {
+		newYear,
+		regional(epiphany, "BW", "BY", "ST"),
+		regional(holiday{date: fixedDate(time.March, 8)}, "BE", "MV"),
+		goodFriday, easterMonday, labourDay, ascension, whitMonday,
+		regional(corpusChristi, "BW", "BY", "HE", "NW", "RP", "SL"),
+		regional(assumption, "SL"),
+		regional(holiday{date: fixedDate(time.September, 20)}, "TH"),
+		{date: fixedDate(time.October, 3)},
+		regional(holiday{date: fixedDate(time.October, 31)}, "BB", "HB", "HH", "MV", "NI", "SN", "ST", "SH", "TH"),
+		regional(allSaints, "BW", "BY", "NW", "RP", "SL"),
+		regional(holiday{date: repentanceDay}, "SN"),
+		christmas, boxingDay,
+	},
+	"DK": {
+		newYear,
+		{date: easterOffset(-3)},
+		goodFriday, easterMonday, ascension, whitMonday, christmas, boxingDay,
+	},
+	"FR": {
+		newYear, easterMonday, labourDay,
+		{date: fixedDate(time.May, 8)},
+		ascension, whitMonday,
+		{date: fixedDate(time.July, 14)},
+		assumption, allSaints,
+		{date: fixedDate(time.November, 11)},
+		christmas,
+	},
+	"NL": {
+		newYear, goodFriday, easterMonday,
+		{date: kingsDay},
+		ascension, whitMonday, christmas, boxingDay,
+	},
+}
+
+// Holidays is a public holiday calendar
+type Holidays struct {
+	holidays []holiday
+}
+
+// ParseHolidays creates a holiday calendar for country or country-region, e.g. DE or DE-BY
+func ParseHolidays(s string) (*Holidays, error) {
+	country, region, _ := strings.Cut(strings.ToUpper(strings.TrimSpace(s)), "-")
+
+	hh, ok := calendars[country]
+	if !ok {
+		return nil, fmt.Errorf("invalid holiday calendar: %s", s)
+	}
+
+	res := new(Holidays)
+	for _, h := range hh {
+		if len(h.regions) == 0 || slices.Contains(h.regions, region) {
+			res.holidays = append(res.holidays, h)
+		}
+	}
+
+	return res, nil
+}
+
+// Contains returns true if the given day is a public holiday
+func (h *Holidays) Contains(t time.Time) bool {
+	for _, hd := range h.holidays {
+		d := hd.date(t.Year())
+		if d.Month() == t.Month() && d.Day() == t.Day() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package fixed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEaster(t *testing.T) {
+	for year, expect := range map[int]string{
+		2024: "2024-03-31",
+		2025: "2025-04-20",
+		2026: "2026-04-05",
+		2038: "2038-04-25",
+	} {
+		assert.Equal(t, expect, easter(year).Format(time.DateOnly))
+	}
+}
+
+func TestHolidays(t *testing.T) {
+	date := func(s string) time.Time {
+		t, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+
+	de, err := ParseHolidays("DE")
+	require.NoError(t, err)
+
+	by, err := ParseHolidays("de-by")
+	require.NoError(t, err)
+
+	sn, err := ParseHolidays("DE-SN")
+	require.NoError(t, err)
+
+	nl, err := ParseHolidays("NL")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		h   *Holidays
+		day string
+		res bool
+	}{
+		{de, "2026-01-01", true},
+		{de, "2026-01-02", false},
+		{de, "2026-04-03", true},  // good friday
+		{de, "2026-05-25", true},  // whit monday
+		{de, "2026-06-04", false}, // corpus christi
+		{by, "2026-06-04", true},
+		{by, "2026-01-06", true},
+		{de, "2026-01-06", false},
+		{sn, "2026-11-18", true}, // repentance day
+		{nl, "2025-04-26", true}, // kings day on saturday due to sunday
+		{nl, "2026-04-27", true},
+	} {
+		assert.Equal(t, tc.res, tc.h.Contains(date(tc.day)), tc.day)
+	}
+
+	_, err = ParseHolidays("XX")
+	assert.Error(t, err)
+}
@@ -2,12 +2,14 @@ package fixed
 
 import (
 	"slices"
+	"time"
 )
 
 type Zone struct {
 	Price float64
 	Days  []Day
 	Hours TimeRange
+	Dates []DateRange
 }
 
 type Zones []Zone
@@ -40,6 +42,20 @@ func (r Zones) ForDay(day Day) Zones {
 	return zones
 }
 
+// ForDate returns the zones whose date ranges contain given day
+func (r Zones) ForDate(t time.Time) Zones {
+	var zones Zones
+	for _, z := range r {
+		if len(z.Dates) == 0 || slices.ContainsFunc(z.Dates, func(dr DateRange) bool {
+			return dr.Contains(t)
+		}) {
+			zones = append(zones, z)
+		}
+	}
+
+	return zones
+}
+
 // TimeTableMarkers returns list of zone start/end markers
 func (r Zones) TimeTableMarkers() []HourMin {
 	res := []HourMin{{Hour: 0, Min: 0}}
@@ -103,3 +103,47 @@ func TestFixedSplitZones(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expect, rates)
 }
+
+func TestFixedHolidaysAndSeasons(t *testing.T) {
+	at, err := NewFixedFromConfig(map[string]interface{}{
+		"price":    0.3,
+		"holidays": "DE",
+		"zones": []map[string]interface{}{
+			{"price": 0.2, "days": "Sun"},
+			{"price": 0.4, "days": "Mon-Sat", "hours": "17-20", "dates": "01.10-31.03"},
+		},
+	})
+	require.NoError(t, err)
+
+	tf := at.(*Fixed)
+	clk := clock.NewMock()
+	tf.clock = clk
+
+	// thursday, new year
+	clk.Set(time.Date(2026, time.January, 1, 12, 0, 0, 0, time.Local))
+
+	rates, err := tf.Rates()
+	require.NoError(t, err)
+
+	price := func(day, hour int) float64 {
+		ts := time.Date(2026, time.January, day, hour, 0, 0, 0, time.Local)
+		r, err := rates.Current(ts)
+		require.NoError(t, err)
+		return r.Price
+	}
+
+	assert.Equal(t, 0.2, price(1, 18), "holiday")
+	assert.Equal(t, 0.4, price(2, 18), "winter peak")
+	assert.Equal(t, 0.3, price(2, 12), "winter off-peak")
+	assert.Equal(t, 0.2, price(4, 18), "sunday")
+
+	// summer
+	clk.Set(time.Date(2026, time.July, 1, 12, 0, 0, 0, time.Local))
+
+	rates, err = tf.Rates()
+	require.NoError(t, err)
+
+	r, err := rates.Current(time.Date(2026, time.July, 1, 18, 0, 0, 0, time.Local))
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, r.Price)
+}
@@ -0,0 +1,193 @@
+package tariff
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff/forecastsolar"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+type ForecastSolar struct {
+	*request.Helper
+	log  *util.Logger
+	uris []string
+	data *util.Monitor[api.Rates]
+}
+
+type forecastSolarPlane struct {
+	Declination, Azimuth, Kwp float64
+}
+
+var _ api.Tariff = (*ForecastSolar)(nil)
+
+func init() {
+	registry.Add("forecast-solar", NewForecastSolarFromConfig)
+}
+
+func NewForecastSolarFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		Latitude, Longitude float64
+		Planes              []forecastSolarPlane
+		ApiKey              string
+		Interval            time.Duration
+	}{
+		Interval: time.Hour,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Latitude == 0 && cc.Longitude == 0 {
+		return nil, errors.New("missing latitude/longitude")
+	}
+
+	if len(cc.Planes) == 0 {
+		return nil, errors.New("missing planes")
+	}
+
+	// the paid tiers use the api key as path prefix
+	base := forecastsolar.URI
+	if cc.ApiKey != "" {
+		base += "/" + cc.ApiKey
+	}
+
+	var uris []string
+	for i, p := range cc.Planes {
+		if p.Kwp <= 0 {
+			return nil, fmt.Errorf("plane %d: missing kwp", i+1)
+		}
+
+		if p.Declination < 0 || p.Declination > 90 || p.Azimuth < -180 || p.Azimuth > 180 {
+			return nil, fmt.Errorf("plane %d: invalid orientation", i+1)
+		}
+
+		uris = append(uris, base+fmt.Sprintf(forecastsolar.EstimatePath, cc.Latitude, cc.Longitude, p.Declination, p.Azimuth, p.Kwp))
+	}
+
+	log := util.NewLogger("forecast-solar").Redact(cc.ApiKey)
+
+	t := &ForecastSolar{
+		log:    log,
+		Helper: request.NewHelper(log),
+		uris:   uris,
+		data:   util.NewMonitor[api.Rates](2 * cc.Interval),
+	}
+
+	done := make(chan error)
+	go t.run(cc.Interval, done)
+	err := <-done
+
+	return t, err
+}
+
+func (t *ForecastSolar) run(interval time.Duration, done chan error) {
+	var once sync.Once
+
+	tick := time.NewTicker(interval)
+	for ; true; <-tick.C {
+		var res []forecastsolar.Response
+
+		if err := func() error {
+			for _, uri := range t.uris {
+				var plane forecastsolar.Response
+
+				if err := backoff.Retry(func() error {
+					return backoffPermanentError(t.GetJSON(uri, &plane))
+				}, bo()); err != nil {
+					return err
+				}
+
+				res = append(res, plane)
+			}
+			return nil
+		}(); err != nil {
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		data, err := t.rates(res)
+		if err != nil {
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		mergeRates(t.data, data)
+		once.Do(func() { close(done) })
+	}
+}
+
+// rates aggregates all planes into a single series of average power in W.
+// The energy of each period is reported at the period's end timestamp.
+func (t *ForecastSolar) rates(res []forecastsolar.Response) (api.Rates, error) {
+	// keyed by unix time since time.Time map keys also compare the location
+	power := make(map[int64]api.Rate)
+
+	for _, plane := range res {
+		ts := make([]time.Time, 0, len(plane.Result.WattHoursPeriod))
+		energy := make(map[int64]float64, len(plane.Result.WattHoursPeriod))
+
+		for k, v := range plane.Result.WattHoursPeriod {
+			end, err := time.Parse(time.RFC3339, k)
+			if err != nil {
+				return nil, err
+			}
+
+			ts = append(ts, end)
+			energy[end.Unix()] = v
+		}
+
+		slices.SortFunc(ts, func(a, b time.Time) int {
+			return a.Compare(b)
+		})
+
+		for i := 1; i < len(ts); i++ {
+			start, end := ts[i-1], ts[i]
+
+			r, ok := power[start.Unix()]
+			if !ok {
+				r = api.Rate{
+					Start: start.Local(),
+					End:   end.Local(),
+				}
+			}
+
+			r.Price += energy[end.Unix()] / end.Sub(start).Hours()
+			power[start.Unix()] = r
+		}
+	}
+
+	data := make(api.Rates, 0, len(power))
+	for _, r := range power {
+		data = append(data, r)
+	}
+
+	data.Sort()
+
+	return data, nil
+}
+
+// Rates implements the api.Tariff interface
+func (t *ForecastSolar) Rates() (api.Rates, error) {
+	var res api.Rates
+	err := t.data.GetFunc(func(val api.Rates) {
+		res = slices.Clone(val)
+	})
+	return res, err
+}
+
+// Type implements the api.Tariff interface
+func (t *ForecastSolar) Type() api.TariffType {
+	return api.TariffTypeSolar
+}
@@ -0,0 +1,32 @@
+package forecastsolar
+
+const (
+	// URI is the public api endpoint
+	URI = "https://api.forecast.solar"
+
+	// EstimatePath is the estimate path for latitude, longitude, declination, azimuth and kWp
+	EstimatePath = "/estimate/%g/%g/%g/%g/%g?time=iso8601"
+)
+
+type Response struct {
+	Result  Result  `json:"result"`
+	Message Message `json:"message"`
+}
+
+type Result struct {
+	Watts           map[string]float64 `json:"watts"`
+	WattHoursPeriod map[string]float64 `json:"watt_hours_period"`
+}
+
+type Message struct {
+	Code      int       `json:"code"`
+	Type      string    `json:"type"`
+	Text      string    `json:"text"`
+	Ratelimit Ratelimit `json:"ratelimit"`
+}
+
+type Ratelimit struct {
+	Period    int `json:"period"`
+	Limit     int `json:"limit"`
+	Remaining int `json:"remaining"`
+}
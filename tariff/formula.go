@@ -0,0 +1,154 @@
+package tariff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/provider/golang"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// Formula applies a formula to the rates of a base tariff.
+// The formula can use the base price, time-of-day dependent fees and the hour of the rate.
+type Formula struct {
+	tariff api.Tariff
+	fees   api.Tariff
+	fn     func(price, fee float64, hour int) (float64, error)
+}
+
+var _ api.Tariff = (*Formula)(nil)
+
+func init() {
+	registry.AddCtx("formula", NewFormulaFromConfig)
+}
+
+func NewFormulaFromConfig(ctx context.Context, other map[string]interface{}) (api.Tariff, error) {
+	var cc struct {
+		Tariff  config.Typed
+		Formula string
+		Fees    map[string]interface{}
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Tariff.Type == "" {
+		return nil, errors.New("missing tariff")
+	}
+
+	if cc.Formula == "" {
+		return nil, errors.New("missing formula")
+	}
+
+	fn, err := formulaFunc(cc.Formula)
+	if err != nil {
+		return nil, fmt.Errorf("formula: %w", err)
+	}
+
+	base, err := NewFromConfig(ctx, cc.Tariff.Type, cc.Tariff.Other)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Formula{
+		tariff: base,
+		fn:     fn,
+	}
+
+	// fees use the fixed tariff configuration including zones
+	if cc.Fees != nil {
+		if t.fees, err = NewFixedFromConfig(cc.Fees); err != nil {
+			return nil, fmt.Errorf("fees: %w", err)
+		}
+	}
+
+	return t, nil
+}
+
+// formulaFunc compiles the formula into a function of price, fee and hour
+func formulaFunc(formula string) (fn func(price, fee float64, hour int) (float64, error), err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	vm, err := golang.RegisteredVM("", `import "math"`)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := vm.Eval(fmt.Sprintf("func formula(price, fee float64, hour int) float64 { return float64(%s) }", formula)); err != nil {
+		return nil, err
+	}
+
+	v, err := vm.Eval("formula")
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Kind() != reflect.Func {
+		return nil, fmt.Errorf("invalid formula: %s", formula)
+	}
+
+	// interpreter is not safe for concurrent use
+	var mu sync.Mutex
+
+	return func(price, fee float64, hour int) (res float64, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+
+		out := v.Call([]reflect.Value{reflect.ValueOf(price), reflect.ValueOf(fee), reflect.ValueOf(hour)})
+		return out[0].Float(), nil
+	}, nil
+}
+
+// Rates implements the api.Tariff interface
+func (t *Formula) Rates() (api.Rates, error) {
+	rates, err := t.tariff.Rates()
+	if err != nil {
+		return nil, err
+	}
+
+	var fees api.Rates
+	if t.fees != nil {
+		if fees, err = t.fees.Rates(); err != nil {
+			return nil, err
+		}
+	}
+
+	res := slices.Clone(rates)
+	for i, r := range res {
+		var fee float64
+		if fr, err := fees.Current(r.Start); err == nil {
+			fee = fr.Price
+		}
+
+		if res[i].Price, err = t.fn(r.Price, fee, r.Start.Local().Hour()); err != nil {
+			return nil, fmt.Errorf("formula: %w", err)
+		}
+	}
+
+	return res, nil
+}
+
+// Type implements the api.Tariff interface
+func (t *Formula) Type() api.TariffType {
+	if typ := t.tariff.Type(); typ != api.TariffTypePriceStatic || t.fees == nil {
+		return typ
+	}
+	return t.fees.Type()
+}
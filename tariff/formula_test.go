@@ -0,0 +1,56 @@
+package tariff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormula(t *testing.T) {
+	tf, err := NewFormulaFromConfig(context.TODO(), map[string]interface{}{
+		"tariff": map[string]interface{}{
+			"type":  "fixed",
+			"price": 0.1,
+		},
+		"formula": "math.Max(price, 0)*1.19 + fee",
+		"fees": map[string]interface{}{
+			"price": 0.2,
+			"zones": []map[string]interface{}{
+				{"hours": "0-6", "price": 0.1},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	rates, err := tf.Rates()
+	require.NoError(t, err)
+
+	for _, r := range rates {
+		fee := 0.2
+		if r.Start.Hour() < 6 {
+			fee = 0.1
+		}
+		assert.InDelta(t, 0.1*1.19+fee, r.Price, 1e-6, r.Start)
+	}
+}
+
+func TestFormulaInvalid(t *testing.T) {
+	_, err := NewFormulaFromConfig(context.TODO(), map[string]interface{}{
+		"tariff":  map[string]interface{}{"type": "fixed", "price": 0.1},
+		"formula": "price +* 2",
+	})
+	assert.Error(t, err)
+}
+
+func TestFormulaPanic(t *testing.T) {
+	tf, err := NewFormulaFromConfig(context.TODO(), map[string]interface{}{
+		"tariff":  map[string]interface{}{"type": "fixed", "price": 0.1},
+		"formula": "[]float64{price}[hour+1]",
+	})
+	require.NoError(t, err)
+
+	_, err = tf.Rates()
+	assert.Error(t, err)
+}
@@ -0,0 +1,171 @@
+package tariff
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff/nordpool"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+type Nordpool struct {
+	*embed
+	log      *util.Logger
+	area     string
+	currency string
+	data     *util.Monitor[api.Rates]
+}
+
+var _ api.Tariff = (*Nordpool)(nil)
+
+func init() {
+	registry.Add("nordpool", NewNordpoolFromConfig)
+}
+
+func NewNordpoolFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		embed    `mapstructure:",squash"`
+		Area     string
+		Currency string
+	}{
+		Currency: "EUR",
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	area := strings.ToUpper(cc.Area)
+	if area == "" {
+		return nil, errors.New("missing area")
+	}
+	if !slices.Contains(nordpool.Areas, area) {
+		return nil, fmt.Errorf("invalid area: %s", cc.Area)
+	}
+
+	currency := strings.ToUpper(cc.Currency)
+	if !slices.Contains(nordpool.Currencies, currency) {
+		return nil, fmt.Errorf("invalid currency: %s", cc.Currency)
+	}
+
+	t := &Nordpool{
+		embed:    &cc.embed,
+		log:      util.NewLogger("nordpool"),
+		area:     area,
+		currency: currency,
+		data:     util.NewMonitor[api.Rates](2 * time.Hour),
+	}
+
+	done := make(chan error)
+	go t.run(done)
+	err := <-done
+
+	return t, err
+}
+
+func (t *Nordpool) run(done chan error) {
+	var once sync.Once
+	client := request.NewHelper(t.log)
+
+	tick := time.NewTicker(time.Hour)
+	for ; true; <-tick.C {
+		var data api.Rates
+
+		// day-ahead prices are published around 13:00 CET, tomorrow may be missing
+		ts := time.Now()
+		for day := range 2 {
+			var res nordpool.Prices
+
+			uri := fmt.Sprintf(nordpool.URI, ts.AddDate(0, 0, day).Format(nordpool.TimeFormat), t.area, t.currency)
+
+			err := backoff.Retry(func() error {
+				err := client.GetJSON(uri, &res)
+				if errors.Is(err, io.EOF) {
+					return backoff.Permanent(err)
+				}
+				return backoffPermanentError(err)
+			}, bo())
+
+			// no content
+			if day > 0 && errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				once.Do(func() { done <- err })
+
+				t.log.ERROR.Println(err)
+				break
+			}
+
+			rates, err := t.rates(res)
+			if err != nil {
+				once.Do(func() { done <- err })
+
+				t.log.ERROR.Println(err)
+				break
+			}
+
+			data = append(data, rates...)
+		}
+
+		if len(data) == 0 {
+			continue
+		}
+
+		mergeRates(t.data, data)
+		once.Do(func() { close(done) })
+	}
+}
+
+// rates converts the area prices per MWh into rates
+func (t *Nordpool) rates(res nordpool.Prices) (api.Rates, error) {
+	data := make(api.Rates, 0, len(res.MultiAreaEntries))
+
+	for _, r := range res.MultiAreaEntries {
+		price, ok := r.EntryPerArea[t.area]
+		if !ok {
+			continue
+		}
+
+		start, err := time.Parse(time.RFC3339, r.DeliveryStart)
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := time.Parse(time.RFC3339, r.DeliveryEnd)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, api.Rate{
+			Start: start.Local(),
+			End:   end.Local(),
+			Price: t.totalPrice(price / 1e3),
+		})
+	}
+
+	return data, nil
+}
+
+// Rates implements the api.Tariff interface
+func (t *Nordpool) Rates() (api.Rates, error) {
+	var res api.Rates
+	err := t.data.GetFunc(func(val api.Rates) {
+		res = slices.Clone(val)
+	})
+	return res, err
+}
+
+// Type implements the api.Tariff interface
+func (t *Nordpool) Type() api.TariffType {
+	return api.TariffTypePriceForecast
+}
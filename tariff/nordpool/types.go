@@ -0,0 +1,27 @@
+package nordpool
+
+const (
+	URI        = "https://dataportal-api.nordpoolgroup.com/api/DayAheadPrices?market=DayAhead&date=%s&deliveryArea=%s&currency=%s"
+	TimeFormat = "2006-01-02"
+)
+
+// Areas are the supported day-ahead delivery areas
+var Areas = []string{
+	"AT", "BE", "DK1", "DK2", "EE", "FI", "FR", "GER", "LT", "LV", "NL", "PL",
+	"NO1", "NO2", "NO3", "NO4", "NO5", "SE1", "SE2", "SE3", "SE4", "SYS",
+}
+
+// Currencies are the supported price currencies
+var Currencies = []string{"EUR", "DKK", "NOK", "PLN", "SEK"}
+
+type Prices struct {
+	DeliveryDateCET  string  `json:"deliveryDateCET"`
+	Currency         string  `json:"currency"`
+	MultiAreaEntries []Entry `json:"multiAreaEntries"`
+}
+
+type Entry struct {
+	DeliveryStart string             `json:"deliveryStart"`
+	DeliveryEnd   string             `json:"deliveryEnd"`
+	EntryPerArea  map[string]float64 `json:"entryPerArea"` // price per MWh
+}
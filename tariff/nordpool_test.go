@@ -0,0 +1,43 @@
+package tariff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/evcc-io/evcc/tariff/nordpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNordpoolRates(t *testing.T) {
+	var res nordpool.Prices
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"deliveryDateCET": "2024-10-15",
+		"currency": "SEK",
+		"multiAreaEntries": [
+			{"deliveryStart": "2024-10-14T22:00:00Z", "deliveryEnd": "2024-10-14T23:00:00Z", "entryPerArea": {"SE3": 250.0, "SE4": 300.0}},
+			{"deliveryStart": "2024-10-14T23:00:00Z", "deliveryEnd": "2024-10-15T00:00:00Z", "entryPerArea": {"SE3": 500.0}}
+		]
+	}`), &res))
+
+	tf := &Nordpool{
+		embed: &embed{Charges: 0.5, Tax: 0.25},
+		area:  "SE3",
+	}
+
+	rates, err := tf.rates(res)
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+
+	assert.Equal(t, (0.25+0.5)*1.25, rates[0].Price)
+	assert.Equal(t, (0.5+0.5)*1.25, rates[1].Price)
+	assert.Equal(t, "2024-10-14T22:00:00Z", rates[0].Start.UTC().Format("2006-01-02T15:04:05Z"))
+}
+
+func TestNordpoolConfig(t *testing.T) {
+	_, err := NewNordpoolFromConfig(map[string]interface{}{"area": "XX"})
+	require.Error(t, err)
+
+	_, err = NewNordpoolFromConfig(map[string]interface{}{"area": "SE3", "currency": "USD"})
+	require.Error(t, err)
+}
@@ -1,7 +1,9 @@
 package tariff
 
 import (
+	"cmp"
 	"errors"
+	"fmt"
 	"slices"
 	"strings"
 	"sync"
@@ -20,6 +22,7 @@ type Octopus struct {
 	region      string
 	productCode string
 	apikey      string
+	export      bool
 	data        *util.Monitor[api.Rates]
 }
 
@@ -35,6 +38,7 @@ func NewOctopusFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		Tariff      string // DEPRECATED: use ProductCode
 		ProductCode string
 		ApiKey      string
+		Export      bool // use export (outgoing) agreement of the account
 	}
 
 	logger := util.NewLogger("octopus")
@@ -48,6 +52,12 @@ func NewOctopusFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		if cc.Region == "" {
 			return nil, errors.New("missing region")
 		}
+		if !slices.Contains(octoRest.Regions, strings.ToUpper(cc.Region)) {
+			return nil, fmt.Errorf("invalid region: %s", cc.Region)
+		}
+		if cc.Export {
+			return nil, errors.New("export requires apikey- use the outgoing product code instead")
+		}
 		if cc.Tariff != "" {
 			// deprecated - copy to correct slot and WARN
 			logger.WARN.Print("'tariff' is deprecated and will break in a future version - use 'productCode' instead")
@@ -71,6 +81,7 @@ func NewOctopusFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		region:      cc.Region,
 		productCode: cc.ProductCode,
 		apikey:      cc.ApiKey,
+		export:      cc.Export,
 		data:        util.NewMonitor[api.Rates](2 * time.Hour),
 	}
 
@@ -85,30 +96,49 @@ func (t *Octopus) run(done chan error) {
 	var once sync.Once
 	client := request.NewHelper(t.log)
 
-	var restQueryUri string
+	var (
+		restQueryUri string
+		gqlCli       *octoGql.OctopusGraphQLClient
+		intelligent  bool
+	)
 
 	// If ApiKey is available, use GraphQL to get appropriate tariff code before entering execution loop.
 	if t.apikey != "" {
-		gqlCli, err := octoGql.NewClient(t.log, t.apikey)
-		if err != nil {
+		var err error
+		if gqlCli, err = octoGql.NewClient(t.log, t.apikey); err != nil {
 			once.Do(func() { done <- err })
 			t.log.ERROR.Println(err)
 			return
 		}
-		tariffCode, err := gqlCli.TariffCode()
+
+		var tariffCode string
+		if t.export {
+			tariffCode, err = gqlCli.ExportTariffCode()
+		} else {
+			tariffCode, err = gqlCli.TariffCode()
+		}
 		if err != nil {
 			once.Do(func() { done <- err })
 			t.log.ERROR.Println(err)
 			return
 		}
+
 		restQueryUri = octoRest.ConstructRatesAPIFromTariffCode(tariffCode)
+
+		// Intelligent Octopus dispatches extend the off-peak rate
+		intelligent = !t.export && strings.Contains(strings.ToUpper(tariffCode), "INTELLI")
 	} else {
 		// Construct Rest Query URI using tariff and region codes.
 		restQueryUri = octoRest.ConstructRatesAPIFromProductAndRegionCode(t.productCode, t.region)
 	}
 
-	// TODO tick every 15 minutes if GraphQL is available to poll for Intelligent slots.
-	tick := time.NewTicker(time.Hour)
+	// poll more frequently for Intelligent Octopus dispatch slots
+	interval := time.Hour
+	if intelligent {
+		interval = 15 * time.Minute
+	}
+
+	tick := time.NewTicker(interval)
 	for ; true; <-tick.C {
 		var res octoRest.UnitRates
 
@@ -132,11 +162,41 @@ func (t *Octopus) run(done chan error) {
 			data = append(data, ar)
 		}
 
+		if intelligent {
+			if dispatches, err := gqlCli.PlannedDispatches(); err == nil {
+				data = octopusDispatchRates(data, dispatches)
+			} else {
+				t.log.ERROR.Println("dispatches:", err)
+			}
+		}
+
 		mergeRates(t.data, data)
 		once.Do(func() { close(done) })
 	}
 }
 
+// octopusDispatchRates applies the off-peak price to all rates overlapping a planned dispatch
+func octopusDispatchRates(rates api.Rates, dispatches []octoGql.Dispatch) api.Rates {
+	if len(rates) == 0 || len(dispatches) == 0 {
+		return rates
+	}
+
+	offPeak := slices.MinFunc(rates, func(a, b api.Rate) int {
+		return cmp.Compare(a.Price, b.Price)
+	}).Price
+
+	for i, r := range rates {
+		for _, d := range dispatches {
+			if r.Start.Before(d.End) && r.End.After(d.Start) {
+				rates[i].Price = offPeak
+				break
+			}
+		}
+	}
+
+	return rates
+}
+
 // Rates implements the api.Tariff interface
 func (t *Octopus) Rates() (api.Rates, error) {
 	var res api.Rates
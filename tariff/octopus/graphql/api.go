@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -116,6 +117,22 @@ func (c *OctopusGraphQLClient) AccountNumber() (string, error) {
 
 // TariffCode queries the Tariff Code of the first Electricity Agreement active on the account.
 func (c *OctopusGraphQLClient) TariffCode() (string, error) {
+	return c.tariffCode(false)
+}
+
+// ExportTariffCode queries the Tariff Code of the first export Electricity Agreement active on the account.
+func (c *OctopusGraphQLClient) ExportTariffCode() (string, error) {
+	return c.tariffCode(true)
+}
+
+// isExportTariff returns true for export (outgoing) tariff codes
+func isExportTariff(code string) bool {
+	code = strings.ToUpper(code)
+	return strings.Contains(code, "OUTGOING") || strings.Contains(code, "EXPORT")
+}
+
+// tariffCode queries the Tariff Code of the first import or export Electricity Agreement active on the account.
+func (c *OctopusGraphQLClient) tariffCode(export bool) (string, error) {
 	// Update refresh token (if necessary)
 	if err := c.refreshToken(); err != nil {
 		return "", err
@@ -124,7 +141,7 @@ func (c *OctopusGraphQLClient) TariffCode() (string, error) {
 	// Get Account Number
 	acc, err := c.AccountNumber()
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
@@ -135,13 +152,63 @@ func (c *OctopusGraphQLClient) TariffCode() (string, error) {
 		return "", err
 	}
 
-	if len(q.Account.ElectricityAgreements) == 0 {
-		return "", errors.New("no electricity agreements found")
+	for _, a := range q.Account.ElectricityAgreements {
+		if code := a.Tariff.TariffCode(); isExportTariff(code) == export {
+			return code, nil
+		}
+	}
+
+	if export {
+		return "", errors.New("no export electricity agreements found")
+	}
+
+	return "", errors.New("no electricity agreements found")
+}
+
+// PlannedDispatches queries the Intelligent Octopus dispatch slots of the account.
+func (c *OctopusGraphQLClient) PlannedDispatches() ([]Dispatch, error) {
+	// Update refresh token (if necessary)
+	if err := c.refreshToken(); err != nil {
+		return nil, err
+	}
+
+	acc, err := c.AccountNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	var q krakenPlannedDispatches
+	if err := c.Client.Query(ctx, &q, map[string]interface{}{"accountNumber": acc}); err != nil {
+		return nil, err
+	}
+
+	res := make([]Dispatch, 0, len(q.PlannedDispatches))
+	for _, d := range q.PlannedDispatches {
+		start, err := parseTime(d.Start)
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := parseTime(d.End)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, Dispatch{Start: start, End: end})
 	}
 
-	// check type
-	//switch t := q.Account.ElectricityAgreements[0].Tariff.(type) {
-	//
-	//}
-	return q.Account.ElectricityAgreements[0].Tariff.TariffCode(), nil
+	return res, nil
+}
+
+// Dispatch is an Intelligent Octopus dispatch slot charged at the off-peak rate.
+type Dispatch struct {
+	Start, End time.Time
+}
+
+// parseTime parses Kraken timestamps which may omit the RFC3339 T separator.
+func parseTime(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339, strings.Replace(s, " ", "T", 1))
 }
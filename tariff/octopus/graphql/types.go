@@ -77,3 +77,11 @@ type krakenAccountElectricityAgreements struct {
 		} `graphql:"electricityAgreements(active: true)"`
 	} `graphql:"account(accountNumber: $accountNumber)"`
 }
+
+// krakenPlannedDispatches is a representation of a GraphQL query for obtaining the Intelligent Octopus dispatch slots.
+type krakenPlannedDispatches struct {
+	PlannedDispatches []struct {
+		Start string `graphql:"startDt"`
+		End   string `graphql:"endDt"`
+	} `graphql:"plannedDispatches(accountNumber: $accountNumber)"`
+}
@@ -13,6 +13,9 @@ const ProductURI = "https://api.octopus.energy/v1/products/%s/"
 // Substitute first %s with product code, second with tariff code.
 const RatesURI = ProductURI + "electricity-tariffs/%s/standard-unit-rates/"
 
+// Regions are the grid supply point group codes of the UK distribution network operator regions.
+var Regions = []string{"A", "B", "C", "D", "E", "F", "G", "H", "J", "K", "L", "M", "N", "P"}
+
 // ConstructRatesAPIFromProductAndRegionCode returns a validly formatted, fully qualified URI to the unit rate information
 // derived from the given product code and region.
 func ConstructRatesAPIFromProductAndRegionCode(product string, region string) string {
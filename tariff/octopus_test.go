@@ -2,7 +2,10 @@ package tariff
 
 import (
 	"testing"
+	"time"
 
+	"github.com/evcc-io/evcc/api"
+	octoGql "github.com/evcc-io/evcc/tariff/octopus/graphql"
 	"github.com/evcc-io/evcc/util/test"
 	"github.com/stretchr/testify/require"
 )
@@ -35,3 +38,31 @@ func TestOctopusConfigParse(t *testing.T) {
 	_, err = NewOctopusFromConfig(invalidApiAndProductCodeConfig)
 	require.Error(t, err)
 }
+
+func TestOctopusInvalidRegion(t *testing.T) {
+	_, err := NewOctopusFromConfig(map[string]interface{}{
+		"region":      "I",
+		"productcode": "AGILE-FLEX-22-11-25",
+	})
+	require.Error(t, err)
+}
+
+func TestOctopusDispatchRates(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+
+	rate := func(start int, price float64) api.Rate {
+		return api.Rate{
+			Start: now.Add(time.Duration(start) * 30 * time.Minute),
+			End:   now.Add(time.Duration(start+1) * 30 * time.Minute),
+			Price: price,
+		}
+	}
+
+	rates := api.Rates{rate(0, 0.30), rate(1, 0.30), rate(2, 0.07), rate(3, 0.30)}
+	dispatches := []octoGql.Dispatch{
+		{Start: now.Add(45 * time.Minute), End: now.Add(60 * time.Minute)},
+	}
+
+	res := octopusDispatchRates(rates, dispatches)
+	require.Equal(t, []float64{0.30, 0.07, 0.07, 0.30}, []float64{res[0].Price, res[1].Price, res[2].Price, res[3].Price})
+}
@@ -0,0 +1,83 @@
+package tariff
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// SolarAdjusted is a price tariff where the grid price is reduced by the share of
+// the consumption power that is expected to be covered by the solar forecast.
+// Solar energy is valued at the feed-in price.
+type SolarAdjusted struct {
+	grid, feedin, solar api.Tariff
+	power               float64
+}
+
+var _ api.Tariff = (*SolarAdjusted)(nil)
+
+// NewSolarAdjusted creates a solar adjusted tariff for the given consumption power
+func NewSolarAdjusted(grid, feedin, solar api.Tariff, power float64) api.Tariff {
+	return &SolarAdjusted{
+		grid:   grid,
+		feedin: feedin,
+		solar:  solar,
+		power:  power,
+	}
+}
+
+// Rates implements the api.Tariff interface
+func (t *SolarAdjusted) Rates() (api.Rates, error) {
+	rates, err := t.grid.Rates()
+	if err != nil {
+		return nil, err
+	}
+
+	solar, err := t.solar.Rates()
+	if err != nil || t.power <= 0 {
+		// plan without solar if forecast is not available
+		return rates, nil
+	}
+
+	var feedin api.Rates
+	if t.feedin != nil {
+		feedin, _ = t.feedin.Rates()
+	}
+
+	res := make(api.Rates, 0, len(rates))
+	for _, r := range rates {
+		share := min(1, SolarEnergy(solar, r.Start, r.End)*1e3/r.End.Sub(r.Start).Hours()/t.power)
+
+		var feedinPrice float64
+		if fr, err := feedin.Current(r.Start); err == nil {
+			feedinPrice = fr.Price
+		}
+
+		r.Price = r.Price*(1-share) + feedinPrice*share
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+// Type implements the api.Tariff interface
+func (t *SolarAdjusted) Type() api.TariffType {
+	return t.grid.Type()
+}
+
+// SolarEnergy returns the forecasted solar energy in kWh between from and to.
+// Solar forecast rates contain the average power in W.
+func SolarEnergy(rates api.Rates, from, to time.Time) float64 {
+	var res float64
+
+	for _, r := range rates {
+		start := max(r.Start.UnixNano(), from.UnixNano())
+		end := min(r.End.UnixNano(), to.UnixNano())
+
+		if end > start {
+			res += r.Price / 1e3 * time.Duration(end-start).Hours()
+		}
+	}
+
+	return res
+}
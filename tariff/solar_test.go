@@ -0,0 +1,141 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff/forecastsolar"
+	"github.com/evcc-io/evcc/tariff/solcast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSolarEnergy(t *testing.T) {
+	now := time.Date(2024, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	rates := api.Rates{
+		{Start: now, End: now.Add(time.Hour), Price: 2000},
+		{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Price: 4000},
+	}
+
+	assert.Equal(t, 6.0, SolarEnergy(rates, now, now.Add(2*time.Hour)))
+	assert.Equal(t, 3.0, SolarEnergy(rates, now.Add(30*time.Minute), now.Add(90*time.Minute)))
+	assert.Equal(t, 0.0, SolarEnergy(rates, now.Add(-time.Hour), now))
+}
+
+func TestSolarAdjusted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2024, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	grid := api.NewMockTariff(ctrl)
+	grid.EXPECT().Rates().Return(api.Rates{
+		{Start: now, End: now.Add(time.Hour), Price: 0.3},
+		{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Price: 0.3},
+	}, nil)
+
+	feedin := api.NewMockTariff(ctrl)
+	feedin.EXPECT().Rates().Return(api.Rates{
+		{Start: now, End: now.Add(2 * time.Hour), Price: 0.1},
+	}, nil)
+
+	solar := api.NewMockTariff(ctrl)
+	solar.EXPECT().Rates().Return(api.Rates{
+		{Start: now, End: now.Add(time.Hour), Price: 5500},
+		{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Price: 22000},
+	}, nil)
+
+	rates, err := NewSolarAdjusted(grid, feedin, solar, 11000).Rates()
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+
+	assert.InDelta(t, 0.2, rates[0].Price, 1e-6, "half solar")
+	assert.InDelta(t, 0.1, rates[1].Price, 1e-6, "full solar")
+}
+
+func TestSolcastRates(t *testing.T) {
+	end := time.Date(2024, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	res := []solcast.Forecasts{
+		{Forecasts: []solcast.Forecast{{PvEstimate: 1.5, PeriodEnd: end, Period: "PT30M"}}},
+		{Forecasts: []solcast.Forecast{{PvEstimate: 0.5, PeriodEnd: end, Period: "PT30M"}}},
+	}
+
+	rates, err := new(Solcast).rates(res)
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+
+	assert.Equal(t, 2000.0, rates[0].Price)
+	assert.Equal(t, 30*time.Minute, rates[0].End.Sub(rates[0].Start))
+}
+
+func TestSolcastBudget(t *testing.T) {
+	_, err := NewSolcastFromConfig(map[string]interface{}{
+		"token":    "foo",
+		"site":     "a,b",
+		"interval": "1h",
+	})
+	assert.ErrorContains(t, err, "budget")
+}
+
+func TestForecastSolarRates(t *testing.T) {
+	plane := forecastsolar.Response{
+		Result: forecastsolar.Result{
+			WattHoursPeriod: map[string]float64{
+				"2024-10-15T08:00:00+02:00": 0,
+				"2024-10-15T09:00:00+02:00": 500,
+				"2024-10-15T09:30:00+02:00": 400,
+			},
+		},
+	}
+
+	rates, err := new(ForecastSolar).rates([]forecastsolar.Response{plane, plane})
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+
+	assert.Equal(t, 1000.0, rates[0].Price)
+	assert.Equal(t, time.Hour, rates[0].End.Sub(rates[0].Start))
+	assert.Equal(t, 1600.0, rates[1].Price)
+	assert.Equal(t, 30*time.Minute, rates[1].End.Sub(rates[1].Start))
+}
+
+func TestForecastSolarConfig(t *testing.T) {
+	_, err := NewForecastSolarFromConfig(map[string]interface{}{
+		"latitude":  50.0,
+		"longitude": 8.0,
+	})
+	assert.ErrorContains(t, err, "planes")
+
+	_, err = NewForecastSolarFromConfig(map[string]interface{}{
+		"latitude":  50.0,
+		"longitude": 8.0,
+		"planes":    []map[string]interface{}{{"declination": 30, "azimuth": 270, "kwp": 5}},
+	})
+	assert.ErrorContains(t, err, "orientation")
+}
+
+func TestForecastSolarRatesOffset(t *testing.T) {
+	// same slots reported with different zone offsets
+	india := forecastsolar.Response{
+		Result: forecastsolar.Result{
+			WattHoursPeriod: map[string]float64{
+				"2024-10-15T08:00:00+05:30": 0,
+				"2024-10-15T08:30:00+05:30": 500,
+			},
+		},
+	}
+	utc := forecastsolar.Response{
+		Result: forecastsolar.Result{
+			WattHoursPeriod: map[string]float64{
+				"2024-10-15T02:30:00Z": 0,
+				"2024-10-15T03:00:00Z": 500,
+			},
+		},
+	}
+
+	rates, err := new(ForecastSolar).rates([]forecastsolar.Response{india, utc})
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, 2000.0, rates[0].Price)
+}
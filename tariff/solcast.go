@@ -0,0 +1,181 @@
+package tariff
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/dylanmei/iso8601"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff/solcast"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/transport"
+)
+
+type Solcast struct {
+	*request.Helper
+	log   *util.Logger
+	sites []string
+	data  *util.Monitor[api.Rates]
+}
+
+var _ api.Tariff = (*Solcast)(nil)
+
+func init() {
+	registry.Add("solcast", NewSolcastFromConfig)
+}
+
+func NewSolcastFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		Token    string
+		Site     string // comma-separated list of rooftop site ids
+		Budget   int
+		Interval time.Duration
+	}{
+		Budget: solcast.DailyBudget,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Token == "" {
+		return nil, errors.New("missing token")
+	}
+
+	if cc.Site == "" {
+		return nil, errors.New("missing site")
+	}
+
+	sites := strings.Split(strings.ReplaceAll(cc.Site, " ", ""), ",")
+
+	// each site consumes one api call per update
+	if cc.Interval == 0 {
+		if cc.Budget <= 0 {
+			return nil, errors.New("invalid budget")
+		}
+
+		cc.Interval = 24 * time.Hour * time.Duration(len(sites)) / time.Duration(cc.Budget)
+	}
+
+	if calls := int(24*time.Hour/cc.Interval) * len(sites); cc.Budget > 0 && calls > cc.Budget {
+		return nil, fmt.Errorf("interval %v exceeds daily budget: %d > %d calls", cc.Interval, calls, cc.Budget)
+	}
+
+	log := util.NewLogger("solcast").Redact(cc.Token)
+
+	t := &Solcast{
+		log:    log,
+		Helper: request.NewHelper(log),
+		sites:  sites,
+		data:   util.NewMonitor[api.Rates](2 * cc.Interval),
+	}
+
+	t.Client.Transport = &transport.Decorator{
+		Base: t.Client.Transport,
+		Decorator: transport.DecorateHeaders(map[string]string{
+			"Authorization": "Bearer " + cc.Token,
+		}),
+	}
+
+	done := make(chan error)
+	go t.run(cc.Interval, done)
+	err := <-done
+
+	return t, err
+}
+
+func (t *Solcast) run(interval time.Duration, done chan error) {
+	var once sync.Once
+
+	tick := time.NewTicker(interval)
+	for ; true; <-tick.C {
+		var res []solcast.Forecasts
+
+		if err := func() error {
+			for _, site := range t.sites {
+				var fc solcast.Forecasts
+				uri := fmt.Sprintf(solcast.URI, site)
+
+				// retrying consumes api budget, permanent errors include exceeded rate limit
+				if err := backoff.Retry(func() error {
+					return backoffPermanentError(t.GetJSON(uri, &fc))
+				}, bo()); err != nil {
+					return fmt.Errorf("site %s: %w", site, err)
+				}
+
+				res = append(res, fc)
+			}
+			return nil
+		}(); err != nil {
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		data, err := t.rates(res)
+		if err != nil {
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		mergeRates(t.data, data)
+		once.Do(func() { close(done) })
+	}
+}
+
+// rates aggregates the forecasts of all sites into a single series of average power in W
+func (t *Solcast) rates(res []solcast.Forecasts) (api.Rates, error) {
+	power := make(map[time.Time]api.Rate)
+
+	for _, fc := range res {
+		for _, f := range fc.Forecasts {
+			period, err := iso8601.ParseDuration(f.Period)
+			if err != nil {
+				return nil, err
+			}
+
+			r, ok := power[f.PeriodEnd]
+			if !ok {
+				r = api.Rate{
+					Start: f.PeriodEnd.Add(-period).Local(),
+					End:   f.PeriodEnd.Local(),
+				}
+			}
+
+			r.Price += f.PvEstimate * 1e3
+			power[f.PeriodEnd] = r
+		}
+	}
+
+	data := make(api.Rates, 0, len(power))
+	for _, r := range power {
+		data = append(data, r)
+	}
+
+	data.Sort()
+
+	return data, nil
+}
+
+// Rates implements the api.Tariff interface
+func (t *Solcast) Rates() (api.Rates, error) {
+	var res api.Rates
+	err := t.data.GetFunc(func(val api.Rates) {
+		res = slices.Clone(val)
+	})
+	return res, err
+}
+
+// Type implements the api.Tariff interface
+func (t *Solcast) Type() api.TariffType {
+	return api.TariffTypeSolar
+}
@@ -0,0 +1,20 @@
+package solcast
+
+import "time"
+
+const URI = "https://api.solcast.com.au/rooftop_sites/%s/forecasts?format=json&hours=48"
+
+// DailyBudget is the number of API calls per day available for hobbyist accounts
+const DailyBudget = 10
+
+type Forecasts struct {
+	Forecasts []Forecast `json:"forecasts"`
+}
+
+type Forecast struct {
+	PvEstimate   float64   `json:"pv_estimate"` // kW
+	PvEstimate10 float64   `json:"pv_estimate10"`
+	PvEstimate90 float64   `json:"pv_estimate90"`
+	PeriodEnd    time.Time `json:"period_end"`
+	Period       string    `json:"period"`
+}
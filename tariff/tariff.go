@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 type Tariff struct {
 	*embed
 	log    *util.Logger
+	typ    api.TariffType
 	data   *util.Monitor[api.Rates]
 	priceG func() (float64, error)
 }
@@ -34,8 +36,11 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		Price    *provider.Config
 		Forecast *provider.Config
 		Cache    time.Duration
+		Interval time.Duration
+		Tariff   string // optional tariff type, e.g. co2
 	}{
-		Cache: 15 * time.Minute,
+		Cache:    15 * time.Minute,
+		Interval: time.Hour,
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -46,6 +51,14 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		return nil, fmt.Errorf("must have either price or forecast")
 	}
 
+	var typ api.TariffType
+	if cc.Tariff != "" {
+		var err error
+		if typ, err = api.TariffTypeString(strings.ToLower(cc.Tariff)); err != nil {
+			return nil, fmt.Errorf("invalid tariff type: %s", cc.Tariff)
+		}
+	}
+
 	var (
 		err       error
 		priceG    func() (float64, error)
@@ -71,23 +84,24 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 	t := &Tariff{
 		log:    util.NewLogger("tariff"),
 		embed:  &cc.embed,
+		typ:    typ,
 		priceG: priceG,
 		data:   util.NewMonitor[api.Rates](2 * time.Hour),
 	}
 
 	if forecastG != nil {
 		done := make(chan error)
-		go t.run(forecastG, done)
+		go t.run(forecastG, cc.Interval, done)
 		err = <-done
 	}
 
 	return t, err
 }
 
-func (t *Tariff) run(forecastG func() (string, error), done chan error) {
+func (t *Tariff) run(forecastG func() (string, error), interval time.Duration, done chan error) {
 	var once sync.Once
 
-	tick := time.NewTicker(time.Hour)
+	tick := time.NewTicker(interval)
 	for ; true; <-tick.C {
 		var data api.Rates
 		if err := backoff.Retry(func() error {
@@ -98,6 +112,9 @@ func (t *Tariff) run(forecastG func() (string, error), done chan error) {
 			if err := json.Unmarshal([]byte(s), &data); err != nil {
 				return backoff.Permanent(err)
 			}
+			if err := validateRates(data); err != nil {
+				return backoff.Permanent(err)
+			}
 			for i, r := range data {
 				data[i].Price = t.totalPrice(r.Price)
 			}
@@ -114,6 +131,19 @@ func (t *Tariff) run(forecastG func() (string, error), done chan error) {
 	}
 }
 
+// validateRates checks forecast rates for consistency and sorts them by start time
+func validateRates(data api.Rates) error {
+	for _, r := range data {
+		if r.Start.IsZero() || !r.End.After(r.Start) {
+			return fmt.Errorf("invalid rate: start %v, end %v", r.Start, r.End)
+		}
+	}
+
+	data.Sort()
+
+	return nil
+}
+
 func (t *Tariff) forecastRates() (api.Rates, error) {
 	var res api.Rates
 	err := t.data.GetFunc(func(val api.Rates) {
@@ -154,6 +184,9 @@ func (t *Tariff) Rates() (api.Rates, error) {
 
 // Type implements the api.Tariff interface
 func (t *Tariff) Type() api.TariffType {
+	if t.typ != 0 {
+		return t.typ
+	}
 	if t.priceG != nil {
 		return api.TariffTypePriceDynamic
 	}
@@ -0,0 +1,63 @@
+package tariff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigurableForecast(t *testing.T) {
+	tf, err := NewConfigurableFromConfig(context.TODO(), map[string]interface{}{
+		"charges": 0.1,
+		"forecast": map[string]interface{}{
+			"source": "const",
+			"value": `[
+				{"start": "2024-10-15T01:00:00Z", "end": "2024-10-15T02:00:00Z", "price": 0.2},
+				{"start": "2024-10-15T00:00:00Z", "end": "2024-10-15T01:00:00Z", "price": 0.3}
+			]`,
+		},
+	})
+	require.NoError(t, err)
+
+	rates, err := tf.Rates()
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+
+	// sorted by start
+	assert.InDelta(t, 0.4, rates[0].Price, 1e-6)
+	assert.InDelta(t, 0.3, rates[1].Price, 1e-6)
+}
+
+func TestConfigurableInvalidForecast(t *testing.T) {
+	_, err := NewConfigurableFromConfig(context.TODO(), map[string]interface{}{
+		"forecast": map[string]interface{}{
+			"source": "const",
+			"value":  `[{"start": "2024-10-15T01:00:00Z", "end": "2024-10-15T00:00:00Z", "price": 0.2}]`,
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestConfigurableType(t *testing.T) {
+	tf, err := NewConfigurableFromConfig(context.TODO(), map[string]interface{}{
+		"tariff": "co2",
+		"price": map[string]interface{}{
+			"source": "const",
+			"value":  "350",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.TariffTypeCo2, tf.Type())
+
+	_, err = NewConfigurableFromConfig(context.TODO(), map[string]interface{}{
+		"tariff": "foo",
+		"price": map[string]interface{}{
+			"source": "const",
+			"value":  "350",
+		},
+	})
+	assert.Error(t, err)
+}
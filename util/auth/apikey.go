@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+const apiKeyPrefix = "evcc_"
+
+// ApiKey is a revocable token granting scoped api access
+type ApiKey struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Scope   Scope     `json:"scope"`
+	Created time.Time `json:"created"`
+}
+
+// apiKey is the persisted api key including the token hash
+type apiKey struct {
+	ApiKey
+	Hash string `json:"hash"`
+}
+
+func hashApiKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (a *auth) apiKeys() []apiKey {
+	var res []apiKey
+	if s, err := a.settings.String(keys.ApiKeys); err == nil && s != "" {
+		_ = json.Unmarshal([]byte(s), &res)
+	}
+	return res
+}
+
+func (a *auth) setApiKeys(res []apiKey) error {
+	b, err := json.Marshal(res)
+	if err == nil {
+		a.settings.SetString(keys.ApiKeys, string(b))
+	}
+	return err
+}
+
+// ApiKeys returns all api keys without their tokens
+func (a *auth) ApiKeys() []ApiKey {
+	res := make([]ApiKey, 0)
+	for _, k := range a.apiKeys() {
+		res = append(res, k.ApiKey)
+	}
+	return res
+}
+
+// CreateApiKey creates a new api key. The token is only returned once and cannot be recovered.
+func (a *auth) CreateApiKey(name string, scope Scope) (ApiKey, string, error) {
+	if name == "" {
+		return ApiKey{}, "", errors.New("name cannot be empty")
+	}
+
+	if !scope.IsAScope() {
+		return ApiKey{}, "", fmt.Errorf("invalid scope: %d", scope)
+	}
+
+	id, err := a.generateRandomKey(4)
+	if err != nil {
+		return ApiKey{}, "", err
+	}
+
+	secret, err := a.generateRandomKey(32)
+	if err != nil {
+		return ApiKey{}, "", err
+	}
+
+	token := apiKeyPrefix + secret
+
+	key := apiKey{
+		ApiKey: ApiKey{
+			ID:      id,
+			Name:    name,
+			Scope:   scope,
+			Created: time.Now().Truncate(time.Second),
+		},
+		Hash: hashApiKey(token),
+	}
+
+	if err := a.setApiKeys(append(a.apiKeys(), key)); err != nil {
+		return ApiKey{}, "", err
+	}
+
+	return key.ApiKey, token, nil
+}
+
+// RevokeApiKey deletes the api key with given id
+func (a *auth) RevokeApiKey(id string) error {
+	res := a.apiKeys()
+
+	idx := slices.IndexFunc(res, func(k apiKey) bool {
+		return k.ID == id
+	})
+	if idx < 0 {
+		return fmt.Errorf("api key not found: %s", id)
+	}
+
+	return a.setApiKeys(slices.Delete(res, idx, idx+1))
+}
+
+// ValidateApiKey returns the scope of the given api key token
+func (a *auth) ValidateApiKey(token string) (Scope, bool) {
+	if !strings.HasPrefix(token, apiKeyPrefix) {
+		return 0, false
+	}
+
+	hash := []byte(hashApiKey(token))
+	for _, k := range a.apiKeys() {
+		if subtle.ConstantTimeCompare(hash, []byte(k.Hash)) == 1 {
+			return k.Scope, true
+		}
+	}
+
+	return 0, false
+}
+
+// Authorize returns the scope granted by the given admin JWT, identity provider token or api key token
+func (a *auth) Authorize(token string) (Scope, bool) {
+	if token == "" {
+		return 0, false
+	}
+
+	if scope, ok := a.ValidateApiKey(token); ok {
+		return scope, true
+	}
+
+	if ok, err := a.ValidateJwtToken(token); ok && err == nil {
+		return ScopeAdmin, true
+	}
+
+	if scope, ok := a.validateScopedJwtToken(token); ok {
+		return scope, true
+	}
+
+	// id tokens issued by the identity provider
+	if o := identityProvider; o != nil {
+		if _, scope, err := o.Verify(context.Background(), token); err == nil {
+			return scope, true
+		}
+	}
+
+	return 0, false
+}
+
+// IsApiAuthRequired checks if the site and loadpoint api require authorization
+func (a *auth) IsApiAuthRequired() bool {
+	s, err := a.settings.String(keys.ApiAuthRequired)
+	if err != nil {
+		return false
+	}
+	res, _ := strconv.ParseBool(s)
+	return res
+}
+
+// SetApiAuthRequired enables or disables authorization for the site and loadpoint api
+func (a *auth) SetApiAuthRequired(required bool) error {
+	a.settings.SetString(keys.ApiAuthRequired, strconv.FormatBool(required))
+	return nil
+}
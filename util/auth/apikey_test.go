@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApiKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := settings.NewMockAPI(ctrl)
+	auth := NewMock(mock)
+
+	var stored string
+	mock.EXPECT().String(keys.ApiKeys).DoAndReturn(func(string) (string, error) { return stored, nil }).AnyTimes()
+	mock.EXPECT().SetString(keys.ApiKeys, gomock.Any()).Do(func(_, s string) { stored = s }).AnyTimes()
+	mock.EXPECT().String(keys.JwtSecret).Return("somesecret", nil).AnyTimes()
+
+	_, _, err := auth.CreateApiKey("", ScopeRead)
+	assert.Error(t, err, "empty name")
+
+	_, _, err = auth.CreateApiKey("foo", Scope(0))
+	assert.Error(t, err, "invalid scope")
+
+	key, token, err := auth.CreateApiKey("dashboard", ScopeRead)
+	require.NoError(t, err)
+	assert.Equal(t, "dashboard", key.Name)
+	assert.NotContains(t, stored, token, "token must not be stored")
+	assert.Len(t, auth.ApiKeys(), 1)
+
+	scope, ok := auth.Authorize(token)
+	assert.True(t, ok)
+	assert.Equal(t, ScopeRead, scope)
+	assert.False(t, scope.Allows(ScopeControl))
+
+	_, ok = auth.Authorize(token + "x")
+	assert.False(t, ok, "invalid token")
+
+	// admin jwt grants admin scope
+	jwt, err := auth.GenerateJwtToken(time.Hour)
+	require.NoError(t, err)
+	scope, ok = auth.Authorize(jwt)
+	assert.True(t, ok)
+	assert.True(t, scope.Allows(ScopeControl))
+
+	require.NoError(t, auth.RevokeApiKey(key.ID))
+	assert.Error(t, auth.RevokeApiKey(key.ID), "already revoked")
+
+	_, ok = auth.Authorize(token)
+	assert.False(t, ok, "revoked token")
+	assert.Empty(t, auth.ApiKeys())
+}
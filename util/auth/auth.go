@@ -21,7 +21,15 @@ type Auth interface {
 	IsAdminPasswordValid(string) bool
 	GenerateJwtToken(time.Duration) (string, error)
 	ValidateJwtToken(string) (bool, error)
+	GenerateScopedJwtToken(string, Scope, time.Duration) (string, error)
 	IsAdminPasswordConfigured() bool
+	ApiKeys() []ApiKey
+	CreateApiKey(string, Scope) (ApiKey, string, error)
+	RevokeApiKey(string) error
+	ValidateApiKey(string) (Scope, bool)
+	Authorize(string) (Scope, bool)
+	IsApiAuthRequired() bool
+	SetApiAuthRequired(bool) error
 }
 
 type auth struct {
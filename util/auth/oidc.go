@@ -0,0 +1,263 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+const (
+	oidcSubjectPrefix = "oidc:"
+	oidcRetryDelay    = time.Minute
+)
+
+// OIDCConfig is the OpenID Connect identity provider configuration
+type OIDCConfig struct {
+	Issuer       string   // issuer url, e.g. https://auth.example.org/realms/home
+	ClientID     string   // client id
+	ClientSecret string   // client secret
+	RedirectURL  string   // callback url, defaults to <network uri>/api/auth/oidc/callback
+	Claim        string   // claim containing the user's groups, default groups
+	Admin        []string // groups granted admin scope
+	Control      []string // groups granted control scope
+	Read         []string // groups granted read scope, users in none of the groups are denied access
+}
+
+// OIDC authenticates users using an external identity provider
+type OIDC struct {
+	oauth2.Config
+	log                  *util.Logger
+	client               *http.Client
+	issuer               string
+	claim                string
+	admin, control, read []string
+
+	mu       sync.Mutex
+	verifier *oidc.IDTokenVerifier
+	failed   time.Time
+}
+
+var identityProvider *OIDC
+
+// ConfigureOIDC enables the identity provider for UI login and api access.
+// The provider is discovered in the background and on first use, startup does not depend on its availability.
+func ConfigureOIDC(cc OIDCConfig) error {
+	if cc.Issuer == "" || cc.ClientID == "" {
+		return errors.New("missing issuer or client id")
+	}
+
+	if cc.Claim == "" {
+		cc.Claim = "groups"
+	}
+
+	log := util.NewLogger("oidc")
+
+	o := &OIDC{
+		Config: oauth2.Config{
+			ClientID:     cc.ClientID,
+			ClientSecret: cc.ClientSecret,
+			RedirectURL:  cc.RedirectURL,
+			Scopes:       []string{oidc.ScopeOpenID, "profile", cc.Claim},
+		},
+		log:     log,
+		client:  request.NewClient(log),
+		issuer:  cc.Issuer,
+		claim:   cc.Claim,
+		admin:   cc.Admin,
+		control: cc.Control,
+		read:    cc.Read,
+	}
+
+	go func() {
+		if err := o.discover(); err != nil {
+			o.log.WARN.Println(err)
+		}
+	}()
+
+	identityProvider = o
+
+	return nil
+}
+
+// discover fetches the provider's endpoints and keys unless already available
+func (o *OIDC) discover() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.verifier != nil {
+		return nil
+	}
+
+	// don't block every request while the provider is unavailable
+	if time.Since(o.failed) < oidcRetryDelay {
+		return errors.New("oidc provider unavailable")
+	}
+
+	// context is retained for fetching the provider's keys
+	ctx := oidc.ClientContext(context.Background(), o.client)
+
+	provider, err := oidc.NewProvider(ctx, o.issuer)
+	if err != nil {
+		o.failed = time.Now()
+		return fmt.Errorf("oidc provider: %w", err)
+	}
+
+	o.Config.Endpoint = provider.Endpoint()
+	o.verifier = provider.Verifier(&oidc.Config{ClientID: o.Config.ClientID})
+
+	return nil
+}
+
+// IdentityProvider returns the configured identity provider or nil
+func IdentityProvider() *OIDC {
+	return identityProvider
+}
+
+// LoginURL returns the identity provider's login url
+func (o *OIDC) LoginURL(state, nonce string) (string, error) {
+	if err := o.discover(); err != nil {
+		return "", err
+	}
+
+	return o.Config.AuthCodeURL(state, oidc.Nonce(nonce)), nil
+}
+
+// Exchange exchanges the authorization code and returns the user's subject and scope
+func (o *OIDC) Exchange(ctx context.Context, code, nonce string) (string, Scope, error) {
+	if err := o.discover(); err != nil {
+		return "", 0, err
+	}
+
+	token, err := o.Config.Exchange(oidc.ClientContext(ctx, o.client), code)
+	if err != nil {
+		return "", 0, err
+	}
+
+	raw, ok := token.Extra("id_token").(string)
+	if !ok {
+		return "", 0, errors.New("missing id token")
+	}
+
+	idToken, scope, err := o.Verify(ctx, raw)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if idToken.Nonce != nonce {
+		return "", 0, errors.New("invalid nonce")
+	}
+
+	return idToken.Subject, scope, nil
+}
+
+// Verify validates an id token issued by the identity provider and returns the granted scope
+func (o *OIDC) Verify(ctx context.Context, raw string) (*oidc.IDToken, Scope, error) {
+	if err := o.discover(); err != nil {
+		return nil, 0, err
+	}
+
+	idToken, err := o.verifier.Verify(ctx, raw)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, 0, err
+	}
+
+	var groups []string
+	switch v := claims[o.claim].(type) {
+	case string:
+		groups = []string{v}
+	case []any:
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+	}
+
+	scope, ok := o.scope(groups)
+	if !ok {
+		return nil, 0, errors.New("access denied")
+	}
+
+	return idToken, scope, nil
+}
+
+// scope maps the user's groups to the highest granted scope
+func (o *OIDC) scope(groups []string) (Scope, bool) {
+	member := func(roles []string) bool {
+		return slices.ContainsFunc(groups, func(g string) bool {
+			return slices.Contains(roles, g)
+		})
+	}
+
+	switch {
+	case member(o.admin):
+		return ScopeAdmin, true
+	case member(o.control):
+		return ScopeControl, true
+	case member(o.read):
+		return ScopeRead, true
+	default:
+		return 0, false
+	}
+}
+
+// scopedClaims are the claims of tokens issued to identity provider users
+type scopedClaims struct {
+	jwt.RegisteredClaims
+	Scope Scope `json:"scope"`
+}
+
+// GenerateScopedJwtToken generates a JWT token for an identity provider user with the given scope and lifetime
+func (a *auth) GenerateScopedJwtToken(subject string, scope Scope, lifetime time.Duration) (string, error) {
+	jwtSecret, err := a.getJwtSecret()
+	if err != nil {
+		return "", err
+	}
+
+	claims := &scopedClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   oidcSubjectPrefix + subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(lifetime)),
+		},
+		Scope: scope,
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtSecret)
+}
+
+// validateScopedJwtToken validates a JWT token issued to an identity provider user and returns its scope
+func (a *auth) validateScopedJwtToken(tokenString string) (Scope, bool) {
+	jwtSecret, err := a.getJwtSecret()
+	if err != nil {
+		return 0, false
+	}
+
+	var claims scopedClaims
+	if _, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		return jwtSecret, nil
+	}); err != nil {
+		return 0, false
+	}
+
+	if !strings.HasPrefix(claims.Subject, oidcSubjectPrefix) || !claims.Scope.IsAScope() {
+		return 0, false
+	}
+
+	return claims.Scope, true
+}
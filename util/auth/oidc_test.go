@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOIDCVerify(t *testing.T) {
+	const issuer = "https://auth.example.org"
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	o := &OIDC{
+		verifier: oidc.NewVerifier(issuer, &oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{key.Public()}}, &oidc.Config{ClientID: "evcc"}),
+		claim:    "groups",
+		admin:    []string{"admins"},
+		control:  []string{"family"},
+		read:     []string{"guests"},
+	}
+
+	token := func(groups ...string) string {
+		res, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":    issuer,
+			"aud":    "evcc",
+			"sub":    "alice",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": groups,
+		}).SignedString(key)
+		require.NoError(t, err)
+		return res
+	}
+
+	for _, tc := range []struct {
+		groups []string
+		scope  Scope
+	}{
+		{[]string{"guests"}, ScopeRead},
+		{[]string{"family"}, ScopeControl},
+		{[]string{"family", "admins"}, ScopeAdmin},
+	} {
+		idToken, scope, err := o.Verify(context.Background(), token(tc.groups...))
+		require.NoError(t, err)
+		assert.Equal(t, "alice", idToken.Subject)
+		assert.Equal(t, tc.scope, scope, "%v", tc.groups)
+	}
+
+	// users without configured group are denied
+	for _, groups := range [][]string{nil, {"others"}} {
+		_, _, err := o.Verify(context.Background(), token(groups...))
+		assert.Error(t, err, "%v", groups)
+	}
+
+	_, _, err = o.Verify(context.Background(), token()+"x")
+	assert.Error(t, err, "invalid signature")
+}
+
+func TestScopedJwtToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mock := settings.NewMockAPI(ctrl)
+	mock.EXPECT().String(keys.ApiKeys).Return("", nil).AnyTimes()
+	mock.EXPECT().String(keys.JwtSecret).Return("somesecret", nil).AnyTimes()
+
+	a := &auth{settings: mock}
+
+	token, err := a.GenerateScopedJwtToken("alice", ScopeControl, time.Hour)
+	require.NoError(t, err)
+
+	ok, _ := a.ValidateJwtToken(token)
+	assert.False(t, ok, "not an admin token")
+
+	scope, ok := a.Authorize(token)
+	assert.True(t, ok)
+	assert.Equal(t, ScopeControl, scope)
+
+	// admin token is not a scoped token
+	admin, err := a.GenerateJwtToken(time.Hour)
+	require.NoError(t, err)
+	_, ok = a.validateScopedJwtToken(admin)
+	assert.False(t, ok)
+}
+
+func TestOIDCUnavailable(t *testing.T) {
+	// startup doesn't depend on the provider
+	require.NoError(t, ConfigureOIDC(OIDCConfig{Issuer: "http://127.0.0.1:1", ClientID: "evcc"}))
+	t.Cleanup(func() { identityProvider = nil })
+
+	o := IdentityProvider()
+	require.NotNil(t, o)
+
+	_, err := o.LoginURL("state", "nonce")
+	assert.Error(t, err)
+
+	_, _, err = o.Verify(context.Background(), "token")
+	assert.Error(t, err)
+}
@@ -0,0 +1,17 @@
+package auth
+
+//go:generate enumer -type Scope -trimprefix Scope -transform=lower -text
+
+// Scope is the access level granted to an api key
+type Scope int
+
+const (
+	ScopeRead    Scope = iota + 1 // read-only access
+	ScopeControl                  // read access and control of site and loadpoints
+	ScopeAdmin                    // full access including configuration
+)
+
+// Allows checks if the scope grants the required access level
+func (s Scope) Allows(required Scope) bool {
+	return s >= required
+}
@@ -19,6 +19,12 @@ var re = regexp.MustCompile(`(?i)\${(\w+)(:([a-zA-Z0-9%.]+))?}`)
 // FormatValue will apply specific formatting in addition to standard sprintf
 func FormatValue(format string, val interface{}) string {
 	switch typed := val.(type) {
+	case *float64:
+		// optional values like session price
+		if typed == nil {
+			return ""
+		}
+		return FormatValue(format, *typed)
 	case bool:
 		if format == "%d" {
 			if typed {
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -23,6 +24,8 @@ func TestReplace(t *testing.T) {
 		{"foo", math.Pi, "${foo:%.2f}", "3.14"},
 		{"foo", math.Pi, "${foo:%.0f}%", "3%"},
 		{"foo", 3, "${foo}%", "3%"},
+		{"foo", lo.ToPtr(1234.5), "${foo:%.1fk}", "1.2"},
+		{"foo", (*float64)(nil), "${foo:%.2f}", ""},
 	}
 
 	for _, c := range cases {
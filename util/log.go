@@ -1,9 +1,9 @@
 package util
 
 import (
+	"fmt"
 	"io"
 	"log"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -14,8 +14,9 @@ import (
 )
 
 var (
-	loggers = map[string]*Logger{}
-	levels  = map[string]jww.Threshold{}
+	loggers    = map[string]*Logger{}
+	levels     = map[string]jww.Threshold{}
+	configured = map[string]jww.Threshold{} // area levels before runtime changes
 
 	loggersMux sync.Mutex
 
@@ -60,7 +61,7 @@ func newLogger(area string, lp int) *Logger {
 	redactor := new(Redactor)
 	notepad := jww.NewNotepad(
 		level, jww.LevelTrace,
-		&redactWriter{os.Stdout, redactor}, &redactWriter{logstash.DefaultHandler, redactor},
+		&redactWriter{newConsoleWriter(lp), redactor}, &redactWriter{logstash.DefaultHandler, redactor},
 		padded, log.Ldate|log.Ltime)
 
 	logger := &Logger{
@@ -110,6 +111,7 @@ func LogLevel(defaultLevel string, areaLevels map[string]string) {
 	for area, level := range areaLevels {
 		area = strings.ToLower(area)
 		levels[area] = logstash.LogLevelToThreshold(level)
+		configured[area] = levels[area]
 	}
 
 	Loggers(func(name string, logger *Logger) {
@@ -117,6 +119,49 @@ func LogLevel(defaultLevel string, areaLevels map[string]string) {
 	})
 }
 
+// LogLevels returns the current log level of all loggers
+func LogLevels() map[string]string {
+	loggersMux.Lock()
+	defer loggersMux.Unlock()
+
+	res := make(map[string]string, len(loggers))
+	for name := range loggers {
+		res[name] = strings.ToLower(logLevelForArea(name).String())
+	}
+
+	return res
+}
+
+// SetLogLevel changes the log level of a single log area at runtime, empty level restores the configured level
+func SetLogLevel(area, level string) error {
+	area = strings.ToLower(area)
+
+	loggersMux.Lock()
+	defer loggersMux.Unlock()
+
+	if level == "" {
+		if threshold, ok := configured[area]; ok {
+			levels[area] = threshold
+		} else {
+			delete(levels, area)
+		}
+	} else {
+		threshold := logstash.LogLevelToThreshold(level)
+		if !strings.EqualFold(threshold.String(), level) {
+			return fmt.Errorf("invalid log level: %s", level)
+		}
+		levels[area] = threshold
+	}
+
+	for name, logger := range loggers {
+		if strings.ToLower(name) == area {
+			logger.SetStdoutThreshold(logLevelForArea(name))
+		}
+	}
+
+	return nil
+}
+
 var uiChan chan<- Param
 
 type uiWriter struct {
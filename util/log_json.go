@@ -0,0 +1,79 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// jsonLogs enables structured console output
+var jsonLogs atomic.Bool
+
+// LogFormat sets the console log format, either text (default) or json
+func LogFormat(format string) error {
+	switch strings.ToLower(format) {
+	case "", "text":
+		jsonLogs.Store(false)
+	case "json":
+		jsonLogs.Store(true)
+	default:
+		return fmt.Errorf("invalid log format: %s", format)
+	}
+	return nil
+}
+
+var jsonLogRegex = regexp.MustCompile(`(?s)^\[([a-zA-Z0-9-]+)\s*\] (\w+) (\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}) (.*)$`)
+
+// consoleWriter writes log lines to stdout as text or json
+type consoleWriter struct {
+	out io.Writer
+	lp  int
+}
+
+func newConsoleWriter(lp int) *consoleWriter {
+	return &consoleWriter{out: os.Stdout, lp: lp}
+}
+
+func (w *consoleWriter) Write(p []byte) (int, error) {
+	if !jsonLogs.Load() {
+		return w.out.Write(p)
+	}
+
+	m := jsonLogRegex.FindSubmatch(p)
+	if m == nil {
+		return w.out.Write(p)
+	}
+
+	ts, err := time.ParseInLocation("2006/01/02 15:04:05", string(m[3]), time.Local)
+	if err != nil {
+		ts = time.Now()
+	}
+
+	b, err := json.Marshal(struct {
+		Time      time.Time `json:"time"`
+		Level     string    `json:"level"`
+		Device    string    `json:"device"`
+		Loadpoint int       `json:"loadpoint,omitempty"`
+		Message   string    `json:"msg"`
+	}{
+		Time:      ts,
+		Level:     strings.ToLower(string(m[2])),
+		Device:    string(m[1]),
+		Loadpoint: w.lp,
+		Message:   strings.TrimRight(string(m[4]), "\n"),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := w.out.Write(append(b, '\n')); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/evcc-io/evcc/util/logstash"
@@ -14,3 +16,38 @@ func TestLogger(t *testing.T) {
 
 	require.Len(t, logstash.All(nil, jww.LevelTrace, 0), 1)
 }
+
+func TestSetLogLevel(t *testing.T) {
+	LogLevel("info", map[string]string{"device": "debug"})
+	log := NewLogger("device")
+
+	require.NoError(t, SetLogLevel("Device", "trace"))
+	require.Equal(t, jww.LevelTrace, log.GetStdoutThreshold())
+	require.Equal(t, "trace", LogLevels()["device"])
+
+	require.Error(t, SetLogLevel("device", "verbose"))
+
+	// restore configured level
+	require.NoError(t, SetLogLevel("device", ""))
+	require.Equal(t, jww.LevelDebug, log.GetStdoutThreshold())
+}
+
+func TestJsonLogs(t *testing.T) {
+	require.NoError(t, LogFormat("json"))
+	defer func() { _ = LogFormat("text") }()
+
+	var b bytes.Buffer
+	w := &consoleWriter{out: &b, lp: 1}
+
+	_, err := w.Write([]byte("[lp-1  ] WARN 2026/10/15 12:00:00 charger not reachable\n"))
+	require.NoError(t, err)
+
+	var res map[string]any
+	require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+	require.Equal(t, "warn", res["level"])
+	require.Equal(t, "lp-1", res["device"])
+	require.Equal(t, 1.0, res["loadpoint"])
+	require.Equal(t, "charger not reachable", res["msg"])
+
+	require.Error(t, LogFormat("xml"))
+}
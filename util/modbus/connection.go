@@ -1,9 +1,16 @@
 package modbus
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/supervisor"
+	"github.com/evcc-io/evcc/util/tracing"
+	"github.com/grid-x/modbus"
 	"github.com/volkszaehler/mbmd/meters"
 )
 
@@ -14,6 +21,43 @@ type Connection struct {
 	slaveID uint8 // duplicated from meters.Connection
 	logical meters.Logger
 	delay   time.Duration
+	sv      *supervisor.Supervisor
+}
+
+const (
+	circuitThreshold = 5                // consecutive failures pausing requests
+	circuitCooldown  = 30 * time.Second // pause duration
+)
+
+// supervisors are shared per device address like the underlying physical connections
+var (
+	supervisors  = make(map[string]*supervisor.Supervisor)
+	supervisorMu sync.Mutex
+)
+
+// supervise pauses requests to unreachable devices
+func (c *Connection) supervise() *Connection {
+	supervisorMu.Lock()
+	defer supervisorMu.Unlock()
+
+	addr := c.Addr()
+
+	sv, ok := supervisors[addr]
+	if !ok {
+		sv = supervisor.New(util.NewLogger("modbus"), "modbus "+addr,
+			supervisor.WithCircuitBreaker(circuitThreshold, circuitCooldown),
+			supervisor.WithFailure(func(err error) bool {
+				// exception responses prove the device is reachable
+				var mbErr *modbus.Error
+				return !errors.As(err, &mbErr)
+			}))
+
+		supervisors[addr] = sv
+	}
+
+	c.sv = sv
+
+	return c
 }
 
 func (c *Connection) Addr() string {
@@ -29,11 +73,13 @@ func (c *Connection) Delay(delay time.Duration) {
 }
 
 func (c *Connection) Clone(slaveID uint8) *Connection {
-	return &Connection{
+	res := &Connection{
 		slaveID:    slaveID,
 		Connection: c.Connection.Clone(slaveID),
 		logger:     c.logger,
 	}
+
+	return res.supervise()
 }
 
 // TODO resolve conflicts
@@ -50,80 +96,94 @@ func (c *Connection) Timeout(timeout time.Duration) {
 	}
 }
 
-func (c *Connection) exec(fun func() ([]byte, error)) ([]byte, error) {
-	return c.WithLogger(c.logical, func() ([]byte, error) {
-		time.Sleep(c.delay)
-
-		b, err := fun()
-		if err != nil {
-			c.Connection.Close()
-		}
-		return b, err
+func (c *Connection) exec(op string, address uint16, fun func() ([]byte, error)) ([]byte, error) {
+	_, span := tracing.StartClient(context.Background(), "modbus "+op,
+		tracing.Attr("server.address", c.Connection.String()),
+		tracing.Attr("modbus.slave_id", c.slaveID),
+		tracing.Attr("modbus.address", address),
+	)
+
+	var b []byte
+	err := c.sv.Do(func() (err error) {
+		b, err = c.WithLogger(c.logical, func() ([]byte, error) {
+			time.Sleep(c.delay)
+
+			b, err := fun()
+			if err != nil {
+				c.Connection.Close()
+			}
+			return b, err
+		})
+		return err
 	})
+
+	span.End(err)
+
+	return b, err
 }
 
 func (c *Connection) ReadCoils(address, quantity uint16) ([]byte, error) {
-	return c.exec(func() ([]byte, error) {
+	return c.exec("ReadCoils", address, func() ([]byte, error) {
 		return c.ModbusClient().ReadCoils(address, quantity)
 	})
 }
 
 func (c *Connection) WriteSingleCoil(address, value uint16) ([]byte, error) {
-	return c.exec(func() ([]byte, error) {
+	return c.exec("WriteSingleCoil", address, func() ([]byte, error) {
 		return c.ModbusClient().WriteSingleCoil(address, value)
 	})
 }
 
 func (c *Connection) ReadInputRegisters(address, quantity uint16) ([]byte, error) {
-	return c.exec(func() ([]byte, error) {
+	return c.exec("ReadInputRegisters", address, func() ([]byte, error) {
 		return c.ModbusClient().ReadInputRegisters(address, quantity)
 	})
 }
 
 func (c *Connection) ReadHoldingRegisters(address, quantity uint16) ([]byte, error) {
-	return c.exec(func() ([]byte, error) {
+	return c.exec("ReadHoldingRegisters", address, func() ([]byte, error) {
 		return c.ModbusClient().ReadHoldingRegisters(address, quantity)
 	})
 }
 
 func (c *Connection) WriteSingleRegister(address, value uint16) ([]byte, error) {
-	return c.exec(func() ([]byte, error) {
+	return c.exec("WriteSingleRegister", address, func() ([]byte, error) {
 		return c.ModbusClient().WriteSingleRegister(address, value)
 	})
 }
 
 func (c *Connection) WriteMultipleRegisters(address, quantity uint16, value []byte) ([]byte, error) {
-	return c.exec(func() ([]byte, error) {
+	return c.exec("WriteMultipleRegisters", address, func() ([]byte, error) {
 		return c.ModbusClient().WriteMultipleRegisters(address, quantity, value)
 	})
 }
 
 func (c *Connection) ReadDiscreteInputs(address, quantity uint16) (results []byte, err error) {
-	return c.exec(func() ([]byte, error) {
+	return c.exec("ReadDiscreteInputs", address, func() ([]byte, error) {
 		return c.ModbusClient().ReadDiscreteInputs(address, quantity)
 	})
 }
 
 func (c *Connection) WriteMultipleCoils(address, quantity uint16, value []byte) (results []byte, err error) {
-	return c.exec(func() ([]byte, error) {
+	return c.exec("WriteMultipleCoils", address, func() ([]byte, error) {
 		return c.ModbusClient().WriteMultipleCoils(address, quantity, value)
 	})
 }
 
 func (c *Connection) ReadWriteMultipleRegisters(readAddress, readQuantity, writeAddress, writeQuantity uint16, value []byte) (results []byte, err error) {
-	return c.exec(func() ([]byte, error) {
+	return c.exec("ReadWriteMultipleRegisters", readAddress, func() ([]byte, error) {
 		return c.ModbusClient().ReadWriteMultipleRegisters(readAddress, readQuantity, writeAddress, writeQuantity, value)
 	})
 }
 
 func (c *Connection) MaskWriteRegister(address, andMask, orMask uint16) (results []byte, err error) {
-	return c.exec(func() ([]byte, error) {
+	return c.exec("MaskWriteRegister", address, func() ([]byte, error) {
 		return c.ModbusClient().MaskWriteRegister(address, andMask, orMask)
 	})
 }
 
 func (c *Connection) ReadFIFOQueue(address uint16) (results []byte, err error) {
-	return c.exec(func() ([]byte, error) {
+	return c.exec("ReadFIFOQueue", address, func() ([]byte, error) {
 		return c.ModbusClient().ReadFIFOQueue(address)
 	})
 }
@@ -112,7 +112,7 @@ func NewConnection(uri, device, comset string, baudrate int, proto Protocol, sla
 		logger:     conn.logger,
 	}
 
-	return res, nil
+	return res.supervise(), nil
 }
 
 func physicalConnection(proto Protocol, cfg Settings) (*meterConnection, error) {
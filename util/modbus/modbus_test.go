@@ -41,3 +41,14 @@ func TestSettingsProtocol(t *testing.T) {
 		require.Equal(t, tc.res, tc.Protocol(), tc)
 	}
 }
+
+func TestSharedSupervisor(t *testing.T) {
+	a, err := NewConnection("localhost:5020", "", "", 0, Tcp, 1)
+	require.NoError(t, err)
+
+	b, err := NewConnection("localhost:5020", "", "", 0, Tcp, 1)
+	require.NoError(t, err)
+
+	require.Same(t, a.sv, b.sv)
+	require.NotSame(t, a.sv, a.Clone(2).sv)
+}
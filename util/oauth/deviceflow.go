@@ -0,0 +1,187 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"golang.org/x/oauth2"
+)
+
+// DeviceFlow implements the OAuth2 device authorization grant (RFC 8628) for providers
+// using user-hosted OAuth apps. The user code is displayed in the UI while the token is
+// polled in background. Tokens are persisted to the settings database and refreshed automatically.
+type DeviceFlow struct {
+	mu          sync.Mutex
+	log         *util.Logger
+	oc          *oauth2.Config
+	opts        []oauth2.AuthCodeOption
+	settingsKey string
+	token       *oauth2.Token
+	authC       chan<- bool
+	cancel      context.CancelFunc
+}
+
+var _ api.AuthProvider = (*DeviceFlow)(nil)
+
+// DeviceCode is the login response displayed in the UI
+type DeviceCode struct {
+	Code     string    `json:"code"`
+	LoginUri string    `json:"loginUri"`
+	Expiry   time.Time `json:"expiry"`
+}
+
+// NewDeviceFlow creates a device flow, restoring the token persisted under the settings key
+func NewDeviceFlow(log *util.Logger, oc *oauth2.Config, settingsKey string, opts ...oauth2.AuthCodeOption) *DeviceFlow {
+	f := &DeviceFlow{
+		log:         log,
+		oc:          oc,
+		opts:        opts,
+		settingsKey: settingsKey,
+	}
+
+	var tok oauth2.Token
+	if err := settings.Json(settingsKey, &tok); err == nil && tok.RefreshToken != "" {
+		f.token = &tok
+	}
+
+	return f
+}
+
+func (f *DeviceFlow) context() context.Context {
+	return context.WithValue(context.Background(), oauth2.HTTPClient, request.NewClient(f.log))
+}
+
+// SetCallbackParams implements the api.AuthProvider interface
+func (f *DeviceFlow) SetCallbackParams(_, _ string, authenticated chan<- bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.authC = authenticated
+	f.publish(f.token != nil)
+}
+
+// publish sends the authentication status, must be called with lock held
+func (f *DeviceFlow) publish(authenticated bool) {
+	if f.authC != nil {
+		go func(c chan<- bool) { c <- authenticated }(f.authC)
+	}
+}
+
+// LoginHandler implements the api.AuthProvider interface. It starts the device authorization
+// and returns the user code while polling for the token in background.
+func (f *DeviceFlow) LoginHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		da, err := f.oc.DeviceAuth(f.context(), f.opts...)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			_ = json.NewEncoder(w).Encode(struct {
+				Error string `json:"error"`
+			}{Error: err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithDeadline(f.context(), da.Expiry)
+
+		f.mu.Lock()
+		if f.cancel != nil {
+			f.cancel()
+		}
+		f.cancel = cancel
+		f.mu.Unlock()
+
+		go f.poll(ctx, cancel, da)
+
+		uri := da.VerificationURIComplete
+		if uri == "" {
+			uri = da.VerificationURI
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(DeviceCode{
+			Code:     da.UserCode,
+			LoginUri: uri,
+			Expiry:   da.Expiry,
+		})
+	}
+}
+
+// poll waits for the user to authorize the device
+func (f *DeviceFlow) poll(ctx context.Context, cancel context.CancelFunc, da *oauth2.DeviceAuthResponse) {
+	defer cancel()
+
+	token, err := f.oc.DeviceAccessToken(ctx, da, f.opts...)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			f.log.ERROR.Printf("device login: %v", err)
+		}
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.setToken(token)
+	f.publish(true)
+}
+
+// setToken updates and persists the token, must be called with lock held
+func (f *DeviceFlow) setToken(token *oauth2.Token) {
+	f.token = token
+
+	if err := settings.SetJson(f.settingsKey, token); err != nil {
+		f.log.ERROR.Printf("persisting token: %v", err)
+	}
+}
+
+// LogoutHandler implements the api.AuthProvider interface
+func (f *DeviceFlow) LogoutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if f.cancel != nil {
+			f.cancel()
+			f.cancel = nil
+		}
+
+		f.token = nil
+		if err := settings.Delete(f.settingsKey); err != nil {
+			f.log.ERROR.Printf("deleting token: %v", err)
+		}
+
+		f.publish(false)
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// Token implements the oauth2.TokenSource interface. Refreshed tokens are persisted.
+func (f *DeviceFlow) Token() (*oauth2.Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.token == nil {
+		return nil, api.ErrLoginRequired
+	}
+
+	if f.token.Valid() {
+		return f.token, nil
+	}
+
+	token, err := f.oc.TokenSource(f.context(), f.token).Token()
+	if err != nil {
+		return nil, err
+	}
+
+	f.setToken(token)
+
+	return token, nil
+}
@@ -0,0 +1,78 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestDeviceFlow(t *testing.T) {
+	var authorized bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"device_code":"device","user_code":"ABCD-1234","verification_uri":"https://example.com/device","expires_in":60,"interval":1}`))
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !authorized {
+			authorized = true
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"access","refresh_token":"refresh","token_type":"Bearer","expires_in":3600}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	f := NewDeviceFlow(util.NewLogger("foo"), &oauth2.Config{
+		ClientID: "client",
+		Endpoint: oauth2.Endpoint{
+			DeviceAuthURL: srv.URL + "/device",
+			TokenURL:      srv.URL + "/token",
+		},
+	}, "test.devicetoken")
+
+	_, err := f.Token()
+	require.ErrorIs(t, err, api.ErrLoginRequired)
+
+	authC := make(chan bool)
+	f.SetCallbackParams("", "", authC)
+	assert.False(t, <-authC)
+
+	rec := httptest.NewRecorder()
+	f.LoginHandler()(rec, httptest.NewRequest(http.MethodPost, "/login", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var res DeviceCode
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+	assert.Equal(t, "ABCD-1234", res.Code)
+	assert.Equal(t, "https://example.com/device", res.LoginUri)
+
+	select {
+	case auth := <-authC:
+		assert.True(t, auth)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for token")
+	}
+
+	token, err := f.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+
+	// token is restored from settings
+	token, err = NewDeviceFlow(util.NewLogger("foo"), f.oc, "test.devicetoken").Token()
+	require.NoError(t, err)
+	assert.Equal(t, "refresh", token.RefreshToken)
+}
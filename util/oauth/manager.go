@@ -0,0 +1,235 @@
+package oauth
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"golang.org/x/oauth2"
+)
+
+// Tokens is the token manager shared by all providers
+var Tokens = NewManager()
+
+// TokenStatus describes a managed token
+type TokenStatus struct {
+	ID        string    `json:"id"`
+	Account   string    `json:"account"`
+	Valid     bool      `json:"valid"`
+	Expiry    time.Time `json:"expiry"`
+	Refreshed time.Time `json:"refreshed"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ManagedToken is a token source owned by the manager
+type ManagedToken struct {
+	mu          sync.Mutex
+	m           *Manager
+	id          string
+	account     string
+	settingsKey string
+	ts          oauth2.TokenSource
+	token       *oauth2.Token
+	refreshed   time.Time
+	err         error
+	revoked     bool
+}
+
+// Manager owns the OAuth tokens of all providers. It persists refreshed tokens,
+// refreshes them periodically and allows revoking accounts.
+type Manager struct {
+	mu     sync.Mutex
+	log    *util.Logger
+	clock  clock.Clock
+	tokens []*ManagedToken
+}
+
+// NewManager creates a token manager
+func NewManager() *Manager {
+	return &Manager{
+		log:   util.NewLogger("oauth"),
+		clock: clock.New(),
+	}
+}
+
+// Register adds the account's token source. Tokens are persisted under the settings key unless empty.
+// Registering an existing account replaces its token source and re-enables a revoked token after new login.
+func (m *Manager) Register(account, settingsKey string, ts oauth2.TokenSource) *ManagedToken {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if idx := slices.IndexFunc(m.tokens, func(t *ManagedToken) bool { return t.account == account }); idx >= 0 {
+		t := m.tokens[idx]
+
+		t.mu.Lock()
+		t.settingsKey = settingsKey
+		t.ts = ts
+		t.token = nil
+		t.err = nil
+		t.revoked = false
+		t.mu.Unlock()
+
+		return t
+	}
+
+	t := &ManagedToken{
+		m:           m,
+		id:          strconv.Itoa(len(m.tokens) + 1),
+		account:     account,
+		settingsKey: settingsKey,
+		ts:          ts,
+	}
+
+	m.tokens = append(m.tokens, t)
+
+	return t
+}
+
+// Token implements the oauth2.TokenSource interface
+func (t *ManagedToken) Token() (*oauth2.Token, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.revoked {
+		return nil, api.ErrLoginRequired
+	}
+
+	token, err := t.ts.Token()
+
+	t.err = err
+	if err != nil {
+		return token, err
+	}
+
+	if t.token == nil || token.AccessToken != t.token.AccessToken {
+		t.refreshed = t.m.clock.Now()
+
+		if t.settingsKey != "" {
+			if err := settings.SetJson(t.settingsKey, token); err != nil {
+				t.m.log.ERROR.Printf("%s: persisting token: %v", t.account, err)
+			}
+		}
+	}
+
+	// copy to avoid races with the token source updating the token
+	tok := *token
+	t.token = &tok
+
+	return token, nil
+}
+
+func (t *ManagedToken) status() TokenStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := TokenStatus{
+		ID:        t.id,
+		Account:   t.account,
+		Refreshed: t.refreshed,
+	}
+
+	if t.token != nil && !t.revoked {
+		res.Valid = t.token.Valid()
+		res.Expiry = t.token.Expiry
+	}
+
+	if t.revoked {
+		res.Error = api.ErrLoginRequired.Error()
+	} else if t.err != nil {
+		res.Error = t.err.Error()
+	}
+
+	return res
+}
+
+// Status returns the status of all tokens
+func (m *Manager) Status() []TokenStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res := make([]TokenStatus, 0, len(m.tokens))
+	for _, t := range m.tokens {
+		res = append(res, t.status())
+	}
+
+	slices.SortFunc(res, func(a, b TokenStatus) int {
+		return strings.Compare(a.Account, b.Account)
+	})
+
+	return res
+}
+
+// token returns the token with given id
+func (m *Manager) token(id string) (*ManagedToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx := slices.IndexFunc(m.tokens, func(t *ManagedToken) bool { return t.id == id })
+	if idx < 0 {
+		return nil, errors.New("token not found")
+	}
+
+	return m.tokens[idx], nil
+}
+
+// Revoke removes the token and its persisted copy. The account requires a new login or must be re-enabled.
+func (m *Manager) Revoke(id string) error {
+	t, err := m.token(id)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.revoked = true
+	t.token = nil
+
+	if t.settingsKey != "" {
+		return settings.Delete(t.settingsKey)
+	}
+
+	return nil
+}
+
+// Enable re-enables a revoked token. Its token source is used again, e.g. for logging in with stored credentials.
+func (m *Manager) Enable(id string) error {
+	t, err := m.token(id)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	t.revoked = false
+	t.err = nil
+	t.mu.Unlock()
+
+	return nil
+}
+
+// refresh requests tokens of all accounts, refreshing expired tokens
+func (m *Manager) refresh() {
+	m.mu.Lock()
+	tokens := slices.Clone(m.tokens)
+	m.mu.Unlock()
+
+	for _, t := range tokens {
+		if _, err := t.Token(); err != nil && !errors.Is(err, api.ErrLoginRequired) {
+			m.log.ERROR.Printf("%s: token refresh: %v", t.account, err)
+		}
+	}
+}
+
+// Run refreshes the tokens in the given interval
+func (m *Manager) Run(interval time.Duration) {
+	for range m.clock.Tick(interval) {
+		m.refresh()
+	}
+}
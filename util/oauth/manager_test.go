@@ -0,0 +1,82 @@
+package oauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+func TestManager(t *testing.T) {
+	m := NewManager()
+
+	var access string
+	ts := m.Register("foo", "test.managedtoken", tokenSourceFunc(func() (*oauth2.Token, error) {
+		return &oauth2.Token{AccessToken: access, Expiry: time.Now().Add(time.Hour)}, nil
+	}))
+
+	// token not yet requested
+	status := m.Status()
+	require.Len(t, status, 1)
+	assert.Equal(t, "foo", status[0].Account)
+	assert.False(t, status[0].Valid)
+
+	access = "access"
+	m.refresh()
+
+	status = m.Status()
+	require.Len(t, status, 1)
+	assert.True(t, status[0].Valid)
+	assert.False(t, status[0].Refreshed.IsZero())
+
+	// token is persisted
+	var tok oauth2.Token
+	require.NoError(t, settings.Json("test.managedtoken", &tok))
+	assert.Equal(t, "access", tok.AccessToken)
+
+	access = "refreshed"
+	_, err := ts.Token()
+	require.NoError(t, err)
+	require.NoError(t, settings.Json("test.managedtoken", &tok))
+	assert.Equal(t, "refreshed", tok.AccessToken)
+
+	// revoked tokens require login
+	ts2 := m.Register("bar", "", tokenSourceFunc(func() (*oauth2.Token, error) {
+		return &oauth2.Token{AccessToken: "bar"}, nil
+	}))
+
+	require.NoError(t, m.Revoke("2"))
+	_, err = ts2.Token()
+	assert.ErrorIs(t, err, api.ErrLoginRequired)
+
+	assert.Error(t, m.Revoke("3"))
+
+	// revoked tokens can be re-enabled
+	require.NoError(t, m.Enable("2"))
+	_, err = ts2.Token()
+	assert.NoError(t, err)
+
+	// new login re-enables the account's token
+	require.NoError(t, m.Revoke("2"))
+	ts3 := m.Register("bar", "", tokenSourceFunc(func() (*oauth2.Token, error) {
+		return &oauth2.Token{AccessToken: "baz"}, nil
+	}))
+	assert.Same(t, ts2, ts3)
+	assert.Len(t, m.Status(), 2)
+
+	tok2, err := ts3.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "baz", tok2.AccessToken)
+
+	assert.Error(t, m.Enable("3"))
+}
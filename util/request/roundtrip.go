@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/tracing"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -111,9 +112,20 @@ func (r *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 		}
 	}
 
+	_, span := tracing.StartClient(req.Context(), "HTTP "+req.Method,
+		tracing.Attr("http.request.method", req.Method),
+		tracing.Attr("server.address", req.URL.Hostname()),
+		tracing.Attr("url.path", req.URL.Path),
+	)
+
 	startTime := time.Now()
 	resp, err := r.base.RoundTrip(req)
 
+	if err == nil {
+		span.SetAttributes(tracing.Attr("http.response.status_code", resp.StatusCode))
+	}
+	span.End(err)
+
 	reqMetric.WithLabelValues(req.URL.Hostname()).Observe(time.Since(startTime).Seconds())
 
 	if err == nil {
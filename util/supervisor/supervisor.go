@@ -0,0 +1,292 @@
+// Package supervisor paces reconnects and retries of device connections using exponential
+// backoff with jitter, stops hammering dead devices using a circuit breaker and reports
+// connection status.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/util"
+)
+
+// ErrCircuitOpen indicates that requests are rejected after repeated failures
+var ErrCircuitOpen = errors.New("circuit open")
+
+const (
+	StateOk       = "ok"       // last operation succeeded
+	StateDegraded = "degraded" // failing, retrying with backoff
+	StateOpen     = "open"     // circuit open, requests fail fast
+)
+
+// Status is a supervised connection's state
+type Status struct {
+	Name        string    `json:"name"`
+	State       string    `json:"state"`
+	Failures    int       `json:"failures"` // consecutive failures
+	LastSuccess time.Time `json:"lastSuccess"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Option configures a supervisor
+type Option func(*Supervisor)
+
+// WithBackoff sets the initial and maximum delay between reconnects or retries
+func WithBackoff(initial, max time.Duration) Option {
+	return func(s *Supervisor) {
+		s.initial, s.max = initial, max
+	}
+}
+
+// WithRetryTimeout limits the total time spent retrying a single request, default 1s
+func WithRetryTimeout(timeout time.Duration) Option {
+	return func(s *Supervisor) {
+		s.retryTimeout = timeout
+	}
+}
+
+// WithCircuitBreaker rejects requests for the cooldown period after threshold consecutive failures
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(s *Supervisor) {
+		s.threshold, s.cooldown = threshold, cooldown
+	}
+}
+
+// WithFailure classifies errors, errors not reported as failure don't count as connection failures
+func WithFailure(failure func(error) bool) Option {
+	return func(s *Supervisor) {
+		s.failure = failure
+	}
+}
+
+// Supervisor tracks a device connection's health
+type Supervisor struct {
+	log   *util.Logger
+	name  string
+	clock clock.Clock
+
+	initial, max time.Duration
+	retryTimeout time.Duration
+	threshold    int
+	cooldown     time.Duration
+	failure      func(error) bool
+
+	mu          sync.Mutex
+	bo          *backoff.ExponentialBackOff
+	failures    int
+	lastSuccess time.Time
+	openUntil   time.Time
+	err         error
+}
+
+var (
+	mu          sync.Mutex
+	supervisors = make(map[*Supervisor]struct{})
+)
+
+// New creates a supervisor and registers it for status reporting until closed
+func New(log *util.Logger, name string, opts ...Option) *Supervisor {
+	s := &Supervisor{
+		log:          log,
+		name:         name,
+		clock:        clock.New(),
+		initial:      500 * time.Millisecond,
+		max:          5 * time.Minute,
+		retryTimeout: time.Second,
+		failure:      func(error) bool { return true },
+	}
+
+	for _, o := range opts {
+		o(s)
+	}
+
+	s.bo = s.backoff(0)
+
+	mu.Lock()
+	supervisors[s] = struct{}{}
+	mu.Unlock()
+
+	return s
+}
+
+// Close unregisters the supervisor from status reporting
+func (s *Supervisor) Close() {
+	mu.Lock()
+	delete(supervisors, s)
+	mu.Unlock()
+}
+
+// backoff creates an exponential backoff with default jitter, zero timeout retries forever
+func (s *Supervisor) backoff(timeout time.Duration) *backoff.ExponentialBackOff {
+	return backoff.NewExponentialBackOff(
+		backoff.WithInitialInterval(s.initial),
+		backoff.WithMaxInterval(s.max),
+		backoff.WithMaxElapsedTime(timeout),
+		backoff.WithClockProvider(s.clock),
+	)
+}
+
+// Statuses returns the status of all supervised connections
+func Statuses() []Status {
+	mu.Lock()
+	defer mu.Unlock()
+
+	res := make([]Status, 0, len(supervisors))
+	for s := range supervisors {
+		res = append(res, s.Status())
+	}
+
+	slices.SortFunc(res, func(a, b Status) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return res
+}
+
+// Status returns the connection's status
+func (s *Supervisor) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := Status{
+		Name:        s.name,
+		State:       StateOk,
+		Failures:    s.failures,
+		LastSuccess: s.lastSuccess,
+	}
+
+	switch {
+	case s.open():
+		res.State = StateOpen
+	case s.failures > 0:
+		res.State = StateDegraded
+	}
+
+	if s.err != nil {
+		res.Error = s.err.Error()
+	}
+
+	return res
+}
+
+// open returns true if the circuit is open. Must be called with lock held.
+func (s *Supervisor) open() bool {
+	return s.clock.Now().Before(s.openUntil)
+}
+
+// Success records a successful operation, resetting backoff and circuit
+func (s *Supervisor) Success() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.log.DEBUG.Printf("%s: recovered after %d failures", s.name, s.failures)
+	}
+
+	s.bo.Reset()
+	s.failures = 0
+	s.lastSuccess = s.clock.Now()
+	s.openUntil = time.Time{}
+	s.err = nil
+}
+
+// Failure records a failed operation and returns the delay before the next attempt
+func (s *Supervisor) Failure(err error) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures++
+	s.err = err
+
+	if s.threshold > 0 && s.failures >= s.threshold && !s.open() {
+		s.log.WARN.Printf("%s: %d consecutive failures, pausing requests for %v", s.name, s.failures, s.cooldown)
+		s.openUntil = s.clock.Now().Add(s.cooldown)
+	}
+
+	return s.bo.NextBackOff()
+}
+
+// Allow returns ErrCircuitOpen while requests are paused after repeated failures
+func (s *Supervisor) Allow() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.open() {
+		return ErrCircuitOpen
+	}
+
+	return nil
+}
+
+// Do executes a single request unless the circuit is open and records its result
+func (s *Supervisor) Do(fun func() error) error {
+	if err := s.Allow(); err != nil {
+		return err
+	}
+
+	err := fun()
+	s.record(err)
+
+	return err
+}
+
+func (s *Supervisor) record(err error) {
+	if err == nil || !s.failure(err) {
+		s.Success()
+	} else {
+		_ = s.Failure(err)
+	}
+}
+
+// Retry executes a request, retrying failures with backoff within the retry timeout.
+// Errors wrapped using backoff.Permanent are not retried.
+func Retry[T any](s *Supervisor, fun func() (T, error)) (T, error) {
+	if err := s.Allow(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	res, err := backoff.RetryWithData(func() (T, error) {
+		res, err := fun()
+		if err != nil && !s.failure(err) {
+			err = backoff.Permanent(err)
+		}
+		return res, err
+	}, s.backoff(s.retryTimeout))
+
+	s.record(err)
+
+	return res, err
+}
+
+// Run keeps a connection alive. Serve is expected to connect, signal an established connection
+// and block until the connection is lost. Reconnects are delayed by backoff until ctx is done,
+// the supervisor is closed on return.
+func (s *Supervisor) Run(ctx context.Context, serve func(connected func()) error) {
+	defer s.Close()
+
+	for ctx.Err() == nil {
+		err := serve(s.Success)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil {
+			err = errors.New("connection closed")
+		}
+
+		delay := s.Failure(err)
+		s.log.ERROR.Printf("%s: %v, reconnecting in %v", s.name, err, delay.Truncate(time.Millisecond))
+
+		select {
+		case <-s.clock.After(delay):
+		case <-ctx.Done():
+		}
+	}
+}
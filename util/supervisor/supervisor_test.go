@@ -0,0 +1,141 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errFoo = errors.New("foo")
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := clock.NewMock()
+	errIgnored := errors.New("ignored")
+
+	s := New(util.NewLogger("test"), "circuit", WithCircuitBreaker(2, time.Minute), WithFailure(func(err error) bool {
+		return !errors.Is(err, errIgnored)
+	}))
+	s.clock = clock
+
+	fail := func() error { return errFoo }
+
+	require.Equal(t, errIgnored, s.Do(func() error { return errIgnored }))
+	assert.Equal(t, StateOk, s.Status().State, "ignored errors")
+
+	require.Equal(t, errFoo, s.Do(fail))
+	assert.Equal(t, StateDegraded, s.Status().State)
+
+	require.Equal(t, errFoo, s.Do(fail))
+	assert.Equal(t, StateOpen, s.Status().State)
+	assert.Equal(t, 2, s.Status().Failures)
+	assert.Equal(t, "foo", s.Status().Error)
+
+	var called bool
+	require.ErrorIs(t, s.Do(func() error { called = true; return nil }), ErrCircuitOpen)
+	assert.False(t, called, "fail fast")
+
+	// half-open after cooldown
+	clock.Add(time.Minute)
+	require.Equal(t, errFoo, s.Do(fail))
+	assert.Equal(t, StateOpen, s.Status().State, "reopened")
+
+	clock.Add(time.Minute)
+	require.NoError(t, s.Do(func() error { return nil }))
+
+	st := s.Status()
+	assert.Equal(t, StateOk, st.State)
+	assert.Zero(t, st.Failures)
+	assert.Equal(t, clock.Now(), st.LastSuccess)
+
+	assert.Contains(t, Statuses(), st)
+}
+
+func TestBackoff(t *testing.T) {
+	s := New(util.NewLogger("test"), "backoff", WithBackoff(time.Second, 4*time.Second))
+
+	var last time.Duration
+	for range 10 {
+		last = s.Failure(errFoo)
+		assert.Positive(t, last, "never stops")
+		assert.LessOrEqual(t, last, 6*time.Second, "max interval with jitter")
+	}
+
+	s.Success()
+	assert.LessOrEqual(t, s.Failure(errFoo), 1500*time.Millisecond, "reset")
+}
+
+func TestRetry(t *testing.T) {
+	s := New(util.NewLogger("test"), "retry", WithBackoff(time.Millisecond, time.Millisecond))
+
+	var count int
+	res, err := Retry(s, func() (int, error) {
+		if count++; count < 3 {
+			return 0, errFoo
+		}
+		return 42, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 42, res)
+	assert.Equal(t, 3, count)
+
+	count = 0
+	_, err = Retry(s, func() (int, error) {
+		count++
+		return 0, backoff.Permanent(errFoo)
+	})
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 1, count, "permanent")
+	assert.Equal(t, 1, s.Status().Failures)
+}
+
+func TestRun(t *testing.T) {
+	s := New(util.NewLogger("test"), "run", WithBackoff(time.Millisecond, time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var count int
+	s.Run(ctx, func(connected func()) error {
+		if count++; count == 3 {
+			connected()
+			assert.Equal(t, StateOk, s.Status().State)
+			cancel()
+			return nil
+		}
+
+		return errFoo
+	})
+
+	assert.Equal(t, 3, count)
+	assert.Equal(t, StateOk, s.Status().State, "cancelled after connect")
+	assert.Equal(t, 0, registered("run"), "unregistered after run")
+}
+
+func registered(name string) int {
+	var res int
+	for _, s := range Statuses() {
+		if s.Name == name {
+			res++
+		}
+	}
+	return res
+}
+
+func TestClose(t *testing.T) {
+	a := New(util.NewLogger("test"), "close")
+	b := New(util.NewLogger("test"), "close")
+	assert.Equal(t, 2, registered("close"))
+
+	// closing one instance keeps others with the same name
+	a.Close()
+	assert.Equal(t, 1, registered("close"))
+
+	b.Close()
+	assert.Equal(t, 0, registered("close"))
+}
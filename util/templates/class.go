@@ -10,4 +10,5 @@ const (
 	Vehicle
 	Tariff
 	Circuit
+	Loadpoint
 )
@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _ClassName = "chargermetervehicletariffcircuit"
+const _ClassName = "chargermetervehicletariffcircuitloadpoint"
 
-var _ClassIndex = [...]uint8{0, 7, 12, 19, 25, 32}
+var _ClassIndex = [...]uint8{0, 7, 12, 19, 25, 32, 41}
 
-const _ClassLowerName = "chargermetervehicletariffcircuit"
+const _ClassLowerName = "chargermetervehicletariffcircuitloadpoint"
 
 func (i Class) String() string {
 	i -= 1
@@ -30,9 +30,10 @@ func _ClassNoOp() {
 	_ = x[Vehicle-(3)]
 	_ = x[Tariff-(4)]
 	_ = x[Circuit-(5)]
+	_ = x[Loadpoint-(6)]
 }
 
-var _ClassValues = []Class{Charger, Meter, Vehicle, Tariff, Circuit}
+var _ClassValues = []Class{Charger, Meter, Vehicle, Tariff, Circuit, Loadpoint}
 
 var _ClassNameToValueMap = map[string]Class{
 	_ClassName[0:7]:        Charger,
@@ -45,6 +46,8 @@ var _ClassNameToValueMap = map[string]Class{
 	_ClassLowerName[19:25]: Tariff,
 	_ClassName[25:32]:      Circuit,
 	_ClassLowerName[25:32]: Circuit,
+	_ClassName[32:41]:      Loadpoint,
+	_ClassLowerName[32:41]: Loadpoint,
 }
 
 var _ClassNames = []string{
@@ -53,6 +56,7 @@ var _ClassNames = []string{
 	_ClassName[12:19],
 	_ClassName[19:25],
 	_ClassName[25:32],
+	_ClassName[32:41],
 }
 
 // ClassString retrieves an enum value from the enum constants string name.
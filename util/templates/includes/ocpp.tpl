@@ -21,6 +21,9 @@ meterinterval: {{ .meterinterval }}
 {{- if ne .connecttimeout "5m" }}
 connecttimeout: {{ .connecttimeout }}
 {{- end }}
+{{- if .backend }}
+backend: {{ .backend }}
+{{- end }}
 {{- if and .timeout (ne .timeout "30s") }}
 timeout: {{ .timeout }}
 {{- end }}
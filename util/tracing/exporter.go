@@ -0,0 +1,243 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+const (
+	batchSize     = 512
+	queueSize     = 4 * batchSize
+	flushInterval = 5 * time.Second
+)
+
+// Config is the OTLP exporter configuration
+type Config struct {
+	Endpoint string            // collector url, e.g. http://localhost:4318
+	Headers  map[string]string // additional request headers, e.g. for authentication
+}
+
+type exporter struct {
+	client   *http.Client
+	log      *util.Logger
+	uri      string
+	headers  map[string]string
+	resource []keyValue
+	spans    chan *Span
+}
+
+// Configure enables span recording and starts exporting to the collector
+func Configure(cc Config, version string) error {
+	if cc.Endpoint == "" {
+		return errors.New("missing endpoint")
+	}
+
+	log := util.NewLogger("tracing")
+
+	e := &exporter{
+		// plain client, exporter requests must not be traced themselves
+		client:  &http.Client{Timeout: 30 * time.Second},
+		log:     log,
+		uri:     strings.TrimSuffix(cc.Endpoint, "/") + "/v1/traces",
+		headers: cc.Headers,
+		resource: attributes([]Attribute{
+			Attr("service.name", "evcc"),
+			Attr("service.version", version),
+		}),
+		spans: make(chan *Span, queueSize),
+	}
+
+	go e.run()
+
+	active.Store(e)
+
+	return nil
+}
+
+// add queues the span for export, spans are dropped if the collector cannot keep up
+func (e *exporter) add(s *Span) {
+	select {
+	case e.spans <- s:
+	default:
+	}
+}
+
+func (e *exporter) run() {
+	batch := make([]*Span, 0, batchSize)
+	tick := time.NewTicker(flushInterval)
+
+	for {
+		select {
+		case s := <-e.spans:
+			if batch = append(batch, s); len(batch) < batchSize {
+				continue
+			}
+		case <-tick.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		if err := e.export(batch); err != nil {
+			e.log.ERROR.Printf("export: %v", err)
+		}
+
+		batch = batch[:0]
+	}
+}
+
+func (e *exporter) export(spans []*Span) error {
+	b, err := json.Marshal(e.payload(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.uri, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// OTLP json encoding, see https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type status struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type span struct {
+	TraceID      string     `json:"traceId"`
+	SpanID       string     `json:"spanId"`
+	ParentSpanID string     `json:"parentSpanId,omitempty"`
+	Name         string     `json:"name"`
+	Kind         Kind       `json:"kind"`
+	Start        string     `json:"startTimeUnixNano"`
+	End          string     `json:"endTimeUnixNano"`
+	Attributes   []keyValue `json:"attributes,omitempty"`
+	Status       status     `json:"status"`
+}
+
+type scopeSpans struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type resourceSpans struct {
+	Resource struct {
+		Attributes []keyValue `json:"attributes"`
+	} `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type payload struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+func attributes(attrs []Attribute) []keyValue {
+	res := make([]keyValue, 0, len(attrs))
+
+	for _, a := range attrs {
+		var v anyValue
+
+		switch val := a.Value.(type) {
+		case string:
+			v.StringValue = &val
+		case bool:
+			v.BoolValue = &val
+		case int:
+			s := strconv.Itoa(val)
+			v.IntValue = &s
+		case int64:
+			s := strconv.FormatInt(val, 10)
+			v.IntValue = &s
+		case uint8:
+			s := strconv.Itoa(int(val))
+			v.IntValue = &s
+		case uint16:
+			s := strconv.Itoa(int(val))
+			v.IntValue = &s
+		case float64:
+			v.DoubleValue = &val
+		default:
+			s := fmt.Sprint(val)
+			v.StringValue = &s
+		}
+
+		res = append(res, keyValue{Key: a.Key, Value: v})
+	}
+
+	return res
+}
+
+func (e *exporter) payload(spans []*Span) payload {
+	ss := scopeSpans{Spans: make([]span, 0, len(spans))}
+	ss.Scope.Name = "evcc"
+
+	for _, s := range spans {
+		res := span{
+			TraceID:    hex.EncodeToString(s.traceID[:]),
+			SpanID:     hex.EncodeToString(s.spanID[:]),
+			Name:       s.name,
+			Kind:       s.kind,
+			Start:      strconv.FormatInt(s.start.UnixNano(), 10),
+			End:        strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes: attributes(s.attrs),
+			Status:     status{Code: 1},
+		}
+
+		if s.parent != [8]byte{} {
+			res.ParentSpanID = hex.EncodeToString(s.parent[:])
+		}
+
+		if s.err != nil {
+			res.Status = status{Code: 2, Message: s.err.Error()}
+		}
+
+		ss.Spans = append(ss.Spans, res)
+	}
+
+	rs := resourceSpans{ScopeSpans: []scopeSpans{ss}}
+	rs.Resource.Attributes = e.resource
+
+	return payload{ResourceSpans: []resourceSpans{rs}}
+}
@@ -0,0 +1,112 @@
+// Package tracing records spans of device requests and control loops and exports them
+// to an OpenTelemetry collector using OTLP/HTTP with json encoding.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"sync/atomic"
+	"time"
+)
+
+// Kind is the span kind as defined by OpenTelemetry
+type Kind int
+
+const (
+	KindInternal Kind = 1
+	KindClient   Kind = 3
+)
+
+// Attribute is a span attribute
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Attr creates a span attribute
+func Attr(key string, value any) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is a timed operation
+type Span struct {
+	traceID [16]byte
+	spanID  [8]byte
+	parent  [8]byte
+	name    string
+	kind    Kind
+	start   time.Time
+	end     time.Time
+	attrs   []Attribute
+	err     error
+}
+
+type spanKey struct{}
+
+// active is the running exporter, spans are not recorded if nil
+var active atomic.Pointer[exporter]
+
+// Enabled returns true if spans are recorded
+func Enabled() bool {
+	return active.Load() != nil
+}
+
+// Start starts an internal span as child of the context's span, if any.
+// Returns nil span if tracing is disabled, all span methods are nil-safe.
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	return start(ctx, name, KindInternal, attrs)
+}
+
+// StartClient starts a span for a request to a remote device or service
+func StartClient(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	return start(ctx, name, KindClient, attrs)
+}
+
+func start(ctx context.Context, name string, kind Kind, attrs []Attribute) (context.Context, *Span) {
+	if !Enabled() {
+		return ctx, nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	s := &Span{
+		name:  name,
+		kind:  kind,
+		start: time.Now(),
+		attrs: attrs,
+	}
+
+	if parent, ok := ctx.Value(spanKey{}).(*Span); ok {
+		s.traceID = parent.traceID
+		s.parent = parent.spanID
+	} else {
+		_, _ = rand.Read(s.traceID[:])
+	}
+
+	_, _ = rand.Read(s.spanID[:])
+
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// SetAttributes adds attributes to the span
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s != nil {
+		s.attrs = append(s.attrs, attrs...)
+	}
+}
+
+// End completes the span, non-nil error marks the span as failed
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+
+	s.end = time.Now()
+	s.err = err
+
+	if e := active.Load(); e != nil {
+		e.add(s)
+	}
+}
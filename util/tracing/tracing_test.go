@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracing(t *testing.T) {
+	_, span := Start(context.Background(), "disabled")
+	assert.Nil(t, span)
+	span.End(nil)
+
+	bodyC := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		b, _ := io.ReadAll(r.Body)
+		bodyC <- b
+	}))
+	defer srv.Close()
+
+	require.NoError(t, Configure(Config{Endpoint: srv.URL, Headers: map[string]string{"Authorization": "secret"}}, "0.0.0"))
+	defer active.Store(nil)
+
+	ctx, parent := Start(context.Background(), "site.update", Attr("loadpoint", "Garage"))
+	_, child := StartClient(ctx, "modbus ReadHoldingRegisters", Attr("modbus.address", uint16(40000)))
+	child.End(errors.New("timeout"))
+	parent.End(nil)
+
+	e := active.Load()
+	require.NoError(t, e.export([]*Span{parent, child}))
+
+	var res payload
+	require.NoError(t, json.Unmarshal(<-bodyC, &res))
+	require.Len(t, res.ResourceSpans, 1)
+
+	spans := res.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, spans[0].TraceID, spans[1].TraceID)
+	assert.Equal(t, spans[0].SpanID, spans[1].ParentSpanID)
+	assert.Empty(t, spans[0].ParentSpanID)
+	assert.Equal(t, KindClient, spans[1].Kind)
+	assert.Equal(t, status{Code: 2, Message: "timeout"}, spans[1].Status)
+	assert.Equal(t, "40000", *spans[1].Attributes[0].Value.IntValue)
+	assert.Equal(t, "Garage", *spans[0].Attributes[0].Value.StringValue)
+}
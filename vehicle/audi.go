@@ -8,6 +8,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/vehicle/audi/etron"
+	"github.com/evcc-io/evcc/vehicle/vag"
 	"github.com/evcc-io/evcc/vehicle/vag/idkproxy"
 	"github.com/evcc-io/evcc/vehicle/vag/service"
 	"github.com/evcc-io/evcc/vehicle/vag/vwidentity"
@@ -67,6 +68,9 @@ func NewAudiFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		return nil, err
 	}
 
+	ats = vag.ManagedTokenSource("audi "+cc.User, ats)
+	its = vag.ManagedTokenSource("audi id "+cc.User, its)
+
 	// use the etron API for list of vehicles
 	api := etron.NewAPI(log, ats)
 
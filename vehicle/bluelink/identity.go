@@ -347,7 +347,7 @@ func (v *Identity) Login(user, password, language string) (err error) {
 	if err == nil {
 		var token *oauth2.Token
 		if token, err = v.exchangeCode(code); err == nil {
-			v.TokenSource = oauth.RefreshTokenSource(token, v)
+			v.TokenSource = oauth.Tokens.Register("bluelink "+user, "", oauth.RefreshTokenSource(token, v))
 		}
 	}
 
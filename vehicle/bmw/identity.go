@@ -128,7 +128,7 @@ func (v *Identity) Login(user, password string) (oauth2.TokenSource, error) {
 
 	ts := oauth2.ReuseTokenSourceWithExpiry(token, oauth.RefreshTokenSource(token, v), 15*time.Minute)
 
-	return ts, nil
+	return oauth.Tokens.Register("bmw "+user, "", ts), nil
 }
 
 func (v *Identity) retrieveToken(data url.Values) (*oauth2.Token, error) {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
 	"golang.org/x/oauth2"
 )
@@ -35,5 +36,5 @@ func NewIdentity(log *util.Logger, id, secret string, token *oauth2.Token) oauth
 	oc := Oauth2Config(id, secret)
 	client := request.NewClient(log)
 	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
-	return oc.TokenSource(ctx, token)
+	return oauth.Tokens.Register("fordconnect "+id, "", oc.TokenSource(ctx, token))
 }
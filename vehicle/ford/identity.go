@@ -73,7 +73,7 @@ func NewIdentity(log *util.Logger, user, password, domain string) *Identity {
 func (v *Identity) Login() error {
 	token, err := v.login()
 	if err == nil {
-		v.TokenSource = oauth.RefreshTokenSource(token, v)
+		v.TokenSource = oauth.Tokens.Register("ford "+v.user, "", oauth.RefreshTokenSource(token, v))
 	}
 	return err
 }
@@ -71,7 +71,7 @@ func (v *Identity) Login() (Token, error) {
 
 	token, err := v.login(data)
 	if err == nil {
-		v.TokenSource = oauth.RefreshTokenSource(&token.Token, v)
+		v.TokenSource = oauth.Tokens.Register("jlr "+v.user, "", oauth.RefreshTokenSource(&token.Token, v))
 	}
 
 	return token, err
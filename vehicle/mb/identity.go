@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/samber/lo"
 	"golang.org/x/net/publicsuffix"
@@ -125,7 +126,7 @@ func (v *Identity) Login(user, password string) error {
 	}
 
 	if err == nil {
-		v.TokenSource = v.oc.TokenSource(context.Background(), token)
+		v.TokenSource = oauth.Tokens.Register("smart "+user, "", v.oc.TokenSource(context.Background(), token))
 	}
 
 	return err
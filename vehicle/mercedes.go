@@ -36,15 +36,15 @@ func NewMercedesFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		return nil, err
 	}
 
-	token, err := cc.Tokens.Token()
-	if err != nil {
-		return nil, err
-	}
-
 	if cc.User == "" && cc.Account_ != "" {
 		cc.User = cc.Account_
 	}
 
+	token, err := cc.Tokens.TokenOrStored(mercedes.SettingsKey(cc.User, cc.Region))
+	if err != nil {
+		return nil, err
+	}
+
 	log := util.NewLogger("mercedes").Redact(cc.Tokens.Access, cc.Tokens.Refresh)
 	identity, err := mercedes.NewIdentity(log, token, cc.User, cc.Region)
 	if err != nil {
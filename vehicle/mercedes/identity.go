@@ -88,7 +88,7 @@ func NewIdentity(log *util.Logger, token *oauth2.Token, account string, region s
 		return nil, errors.New("token expired")
 	}
 
-	v.TokenSource = oauth.RefreshTokenSource(token, v)
+	v.TokenSource = oauth.Tokens.Register("mercedes "+account, v.settingsKey(), oauth.RefreshTokenSource(token, v))
 
 	// add instance
 	addInstance(account, v)
@@ -97,7 +97,12 @@ func NewIdentity(log *util.Logger, token *oauth2.Token, account string, region s
 }
 
 func (v *Identity) settingsKey() string {
-	return fmt.Sprintf("mercedes.%s-%s", v.account, v.region)
+	return SettingsKey(v.account, v.region)
+}
+
+// SettingsKey returns the database key of the account's token
+func SettingsKey(account, region string) string {
+	return fmt.Sprintf("mercedes.%s-%s", account, region)
 }
 
 func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
@@ -117,10 +122,5 @@ func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
 		return nil, err
 	}
 
-	tok := util.TokenWithExpiry(&res)
-	v.TokenSource = oauth.RefreshTokenSource(tok, v)
-
-	err := settings.SetJson(v.settingsKey(), tok)
-
-	return tok, err
+	return util.TokenWithExpiry(&res), nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
 	"golang.org/x/oauth2"
 )
@@ -132,7 +133,9 @@ func (v *Identity) Login(user, password string) error {
 		token, err = config.Exchange(ctx, code)
 	}
 
-	v.TokenSource = config.TokenSource(ctx, token)
+	if err == nil {
+		v.TokenSource = oauth.Tokens.Register("nissan "+user, "", config.TokenSource(ctx, token))
+	}
 
 	return err
 }
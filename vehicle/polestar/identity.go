@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
@@ -44,8 +45,7 @@ type Identity struct {
 	user, password string
 }
 
-// NewIdentity creates Polestar identity
-func NewIdentity(log *util.Logger, user, password string) (oauth2.TokenSource, error) {
+func newIdentity(log *util.Logger, user, password string) *Identity {
 	v := &Identity{
 		Helper:   request.NewHelper(log),
 		user:     user,
@@ -56,14 +56,49 @@ func NewIdentity(log *util.Logger, user, password string) (oauth2.TokenSource, e
 		PublicSuffixList: publicsuffix.List,
 	})
 
-	token, err := v.login()
+	return v
+}
+
+// NewIdentity creates Polestar identity. A token stored by `evcc token` is preferred over password login.
+func NewIdentity(log *util.Logger, user, password string) (oauth2.TokenSource, error) {
+	v := newIdentity(log, user, password)
+
+	var tok oauth2.Token
+	token := &tok
+
+	err := settings.Json(SettingsKey(user), &tok)
+	if err != nil {
+		token, err = v.login()
+	} else if !token.Valid() {
+		// falls back to login
+		token, err = v.RefreshToken(token)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth.Tokens.Register("polestar "+user, SettingsKey(user), oauth.RefreshTokenSource(token, v)), nil
+}
+
+// SettingsKey returns the database key of the account's token
+func SettingsKey(user string) string {
+	return "polestar." + strings.ToLower(user)
+}
+
+// AuthCodeURL returns the browser login url
+func AuthCodeURL(state string) string {
+	return OAuth2Config.AuthCodeURL(state, oauth2.AccessTypeOffline)
+}
 
-	return oauth.RefreshTokenSource(token, v), err
+// ExchangeCode exchanges the browser login's authorization code for a token
+func ExchangeCode(log *util.Logger, code string) (*oauth2.Token, error) {
+	return newIdentity(log, "", "").exchange(code)
 }
 
 func (v *Identity) login() (*oauth2.Token, error) {
 	state := lo.RandomString(16, lo.AlphanumericCharset)
-	uri := OAuth2Config.AuthCodeURL(state, oauth2.AccessTypeOffline)
+	uri := AuthCodeURL(state)
 
 	var param request.InterceptResult
 	v.Client.CheckRedirect, param = request.InterceptRedirect("resumePath", true)
@@ -96,6 +131,10 @@ func (v *Identity) login() (*oauth2.Token, error) {
 		return nil, err
 	}
 
+	return v.exchange(code)
+}
+
+func (v *Identity) exchange(code string) (*oauth2.Token, error) {
 	var res struct {
 		Token `graphql:"getAuthToken(code: $code)"`
 	}
@@ -113,7 +152,7 @@ func (v *Identity) login() (*oauth2.Token, error) {
 		Expiry:       time.Now().Add(time.Duration(res.ExpiresIn) * time.Second),
 	}
 
-	return token, err
+	return token, nil
 }
 
 func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
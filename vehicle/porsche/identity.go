@@ -63,8 +63,11 @@ func NewIdentity(log *util.Logger, user, password string) (oauth2.TokenSource, e
 	}
 
 	token, err := v.login()
+	if err != nil {
+		return nil, err
+	}
 
-	return oauth.RefreshTokenSource(token, v), err
+	return oauth.Tokens.Register("porsche "+user, "", oauth.RefreshTokenSource(token, v)), nil
 }
 
 func (v *Identity) login() (*oauth2.Token, error) {
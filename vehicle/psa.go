@@ -54,7 +54,7 @@ func newPSA(brand, realm string, other map[string]interface{}) (api.Vehicle, err
 		return nil, api.ErrMissingCredentials
 	}
 
-	token, err := cc.Tokens.Token()
+	token, err := cc.Tokens.TokenOrStored(psa.SettingsKey(brand, cc.User))
 	if err != nil {
 		return nil, err
 	}
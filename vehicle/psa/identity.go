@@ -28,7 +28,7 @@ func NewIdentity(log *util.Logger, brand, user string, oc *oauth2.Config, token
 	defer mu.Unlock()
 
 	// reuse identity instance
-	subject := "psa." + strings.ToLower(brand) + "." + strings.ToLower(user)
+	subject := SettingsKey(brand, user)
 	if instance := getInstance(subject); instance != nil {
 		return instance, nil
 	}
@@ -54,7 +54,7 @@ func NewIdentity(log *util.Logger, brand, user string, oc *oauth2.Config, token
 		return nil, errors.New("token expired")
 	}
 
-	v.TokenSource = oauth.RefreshTokenSource(token, v)
+	v.TokenSource = oauth.Tokens.Register(v.subject, v.subject, oauth.RefreshTokenSource(token, v))
 
 	// add instance
 	addInstance(v.subject, v)
@@ -62,6 +62,11 @@ func NewIdentity(log *util.Logger, brand, user string, oc *oauth2.Config, token
 	return v, nil
 }
 
+// SettingsKey returns the database key of the account's token
+func SettingsKey(brand, user string) string {
+	return "psa." + strings.ToLower(brand) + "." + strings.ToLower(user)
+}
+
 func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -77,8 +82,5 @@ func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
 		return nil, err
 	}
 
-	v.TokenSource = oauth.RefreshTokenSource(tok, v)
-	err = settings.SetJson(v.subject, tok)
-
-	return tok, err
+	return tok, nil
 }
@@ -54,7 +54,8 @@ func (v *Identity) Login() error {
 		return err
 	}
 
-	v.TokenSource = oauth2.ReuseTokenSourceWithExpiry(token, oauth.RefreshTokenSource(token, v), 15*time.Minute)
+	ts := oauth2.ReuseTokenSourceWithExpiry(token, oauth.RefreshTokenSource(token, v), 15*time.Minute)
+	v.TokenSource = oauth.Tokens.Register("saic "+v.User, "", ts)
 
 	return nil
 }
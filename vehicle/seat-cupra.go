@@ -9,6 +9,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/vehicle/seat/cupra"
+	"github.com/evcc-io/evcc/vehicle/vag"
 	"github.com/evcc-io/evcc/vehicle/vag/service"
 	"github.com/evcc-io/evcc/vehicle/vag/vwidentity"
 	"golang.org/x/oauth2"
@@ -55,6 +56,8 @@ func NewCupraFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		return nil, err
 	}
 
+	ts = vag.ManagedTokenSource("cupra "+cc.User, ts)
+
 	// get OIDC user information
 	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, request.NewClient(log))
 	ui, err := vwidentity.Config.NewProvider(ctx).UserInfo(ctx, ts)
@@ -8,6 +8,7 @@ import (
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/vehicle/skoda/myskoda"
 	"github.com/evcc-io/evcc/vehicle/skoda/myskoda/service"
+	"github.com/evcc-io/evcc/vehicle/vag"
 )
 
 // https://gitlab.com/prior99/skoda
@@ -55,6 +56,8 @@ func NewEnyaqFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		return nil, err
 	}
 
+	ts = vag.ManagedTokenSource("enyaq "+cc.User, ts)
+
 	api := myskoda.NewAPI(log, ts)
 	api.Client.Timeout = cc.Timeout
 
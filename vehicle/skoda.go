@@ -7,6 +7,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/vehicle/skoda"
+	"github.com/evcc-io/evcc/vehicle/vag"
 	"github.com/evcc-io/evcc/vehicle/vag/service"
 	"github.com/evcc-io/evcc/vehicle/vw"
 )
@@ -56,6 +57,8 @@ func NewSkodaFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		return nil, err
 	}
 
+	trs = vag.ManagedTokenSource("skoda "+cc.User, trs)
+
 	api := skoda.NewAPI(log, trs)
 	api.Client.Timeout = cc.Timeout
 
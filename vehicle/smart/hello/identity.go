@@ -30,7 +30,7 @@ func NewIdentity(log *util.Logger, user, password string) (*Identity, error) {
 		deviceID: lo.RandomString(16, lo.AlphanumericCharset),
 	}
 
-	v.TokenSource = oauth.RefreshTokenSource(nil, v)
+	v.TokenSource = oauth.Tokens.Register("smart hello "+user, "", oauth.RefreshTokenSource(nil, v))
 
 	_, err := v.Token()
 
@@ -88,7 +88,7 @@ func NewIdentity(log *util.Logger, token *oauth2.Token) (oauth2.TokenSource, err
 		return nil, errors.New("token expired")
 	}
 
-	v.TokenSource = oauth.RefreshTokenSource(token, v)
+	v.TokenSource = oauth.Tokens.Register("tesla "+claims.Subject, v.settingsKey(), oauth.RefreshTokenSource(token, v))
 
 	// add instance
 	addInstance(claims.Subject, v)
@@ -97,7 +97,21 @@ func NewIdentity(log *util.Logger, token *oauth2.Token) (oauth2.TokenSource, err
 }
 
 func (v *Identity) settingsKey() string {
-	return fmt.Sprintf("tesla-command.%s", v.subject)
+	return subjectSettingsKey(v.subject)
+}
+
+func subjectSettingsKey(subject string) string {
+	return fmt.Sprintf("tesla-command.%s", subject)
+}
+
+// SettingsKey returns the database key of the token's identity
+func SettingsKey(token *oauth2.Token) (string, error) {
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token.AccessToken, &claims); err != nil {
+		return "", err
+	}
+
+	return subjectSettingsKey(claims.Subject), nil
 }
 
 func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
@@ -107,12 +121,5 @@ func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
 	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, request.NewClient(v.log))
 	ts := OAuth2Config.TokenSource(ctx, token)
 
-	token, err := ts.Token()
-	if err != nil {
-		return nil, err
-	}
-
-	err = settings.SetJson(v.settingsKey(), token)
-
-	return token, err
+	return ts.Token()
 }
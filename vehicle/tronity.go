@@ -105,7 +105,7 @@ func NewTronityFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 
 	// replace client transport with authenticated transport
 	v.Client.Transport = &oauth2.Transport{
-		Source: ts,
+		Source: oauth.Tokens.Register("tronity "+cc.Credentials.ID, "", ts),
 		Base:   v.Client.Transport,
 	}
 
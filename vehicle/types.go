@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/evcc-io/evcc/server/db/settings"
 	"golang.org/x/oauth2"
 )
 
@@ -42,3 +43,13 @@ func (t *Tokens) Token() (*oauth2.Token, error) {
 		Expiry:       time.Now(),
 	}, nil
 }
+
+// TokenOrStored builds token from credentials. If credentials are missing but a token has been
+// stored under the settings key by `evcc token`, an empty token is returned for the identity to restore.
+func (t *Tokens) TokenOrStored(settingsKey string) (*oauth2.Token, error) {
+	if t.Access == "" && t.Refresh == "" && settings.Exists(settingsKey) {
+		return new(oauth2.Token), nil
+	}
+
+	return t.Token()
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"dario.cat/mergo"
+	"github.com/evcc-io/evcc/util/oauth"
 	"golang.org/x/oauth2"
 )
 
@@ -126,3 +127,28 @@ func (ts *metaTokenSource) TokenEx() (*Token, error) {
 
 	return token, err
 }
+
+var _ TokenSource = (*managedTokenSource)(nil)
+
+type managedTokenSource struct {
+	oauth2.TokenSource
+	ts TokenSource
+}
+
+// ManagedTokenSource registers the token source with the token manager.
+// Extended tokens are only returned while the managed token is valid.
+func ManagedTokenSource(account string, ts TokenSource) TokenSource {
+	return &managedTokenSource{
+		TokenSource: oauth.Tokens.Register(account, "", ts),
+		ts:          ts,
+	}
+}
+
+// TokenEx returns the extended VAG token unless the managed token has been revoked
+func (ts *managedTokenSource) TokenEx() (*Token, error) {
+	if _, err := ts.TokenSource.Token(); err != nil {
+		return nil, err
+	}
+
+	return ts.ts.TokenEx()
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/vehicle/vag/loginapps"
 	"github.com/evcc-io/evcc/vehicle/vag/vwidentity"
@@ -61,7 +62,7 @@ func NewIDFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		return nil, err
 	}
 
-	api := id.NewAPI(log, apps.TokenSource(token))
+	api := id.NewAPI(log, oauth.Tokens.Register("vw "+cc.User, "", apps.TokenSource(token)))
 	api.Client.Timeout = cc.Timeout
 
 	vehicle, err := ensureVehicleEx(
@@ -1,6 +1,7 @@
 package vehicle
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -44,6 +45,13 @@ func NewWrapper(name, typ string, other map[string]interface{}, err error) api.V
 	return v
 }
 
+// NewPendingWrapper creates a Vehicle wrapper for a vehicle that is still being initialized
+func NewPendingWrapper(name, typ string, other map[string]interface{}) api.Vehicle {
+	v := NewWrapper(name, typ, other, errors.New("initializing")).(*Wrapper)
+	v.Features_ = append(v.Features_, api.Pending)
+	return v
+}
+
 // WrappedConfig indicates a device with wrapped configuration
 func (v *Wrapper) WrappedConfig() (string, map[string]interface{}) {
 	return v.typ, v.config